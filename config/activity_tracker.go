@@ -0,0 +1,71 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import "fmt"
+
+// The activity tracker sinks
+const (
+	// ActivityTrackerSinkLog writes the events to the provider log
+	ActivityTrackerSinkLog = "log"
+	// ActivityTrackerSinkFile appends the events as JSON lines to a file collected by the logging agent
+	ActivityTrackerSinkFile = "file"
+
+	// DefaultActivityTrackerServiceName is the service of the events, VPC infrastructure
+	DefaultActivityTrackerServiceName = "is"
+)
+
+// ActivityTrackerConfig configures the IBM Cloud Activity Tracker events of the mutating operations (create, expand,
+// attach, delete...), for the accounts auditing the provisioning actions
+type ActivityTrackerConfig struct {
+	Enabled bool `toml:"activity_tracker_enabled" envconfig:"ACTIVITY_TRACKER_ENABLED"`
+
+	// Sink of the events, log (the default) or file
+	Sink string `toml:"sink,omitempty" envconfig:"ACTIVITY_TRACKER_SINK"`
+
+	// Path of the file of the file sink, e.g. /var/log/at/volume-events.log
+	Path string `toml:"path,omitempty" envconfig:"ACTIVITY_TRACKER_PATH"`
+
+	// ServiceName of the actions (<service>.<resource>.<verb>) and target CRNs, DefaultActivityTrackerServiceName if empty
+	ServiceName string `toml:"service_name,omitempty" envconfig:"ACTIVITY_TRACKER_SERVICE_NAME"`
+
+	// AccountID of the target CRNs, e.g. the account of the API key
+	AccountID string `toml:"account_id,omitempty" envconfig:"ACTIVITY_TRACKER_ACCOUNT_ID"`
+}
+
+// ServiceNameOrDefault returns the service name, DefaultActivityTrackerServiceName if empty
+func (at *ActivityTrackerConfig) ServiceNameOrDefault() string {
+	if at.ServiceName == "" {
+		return DefaultActivityTrackerServiceName
+	}
+	return at.ServiceName
+}
+
+// ValidateActivityTracker validates the sink of the activity tracker
+func (at *ActivityTrackerConfig) ValidateActivityTracker() error {
+	switch at.Sink {
+	case "", ActivityTrackerSinkLog:
+	case ActivityTrackerSinkFile:
+		if at.Path == "" {
+			return fmt.Errorf("the %s activity tracker sink requires a path", ActivityTrackerSinkFile)
+		}
+	default:
+		return fmt.Errorf("activity tracker sink '%s' is not one of %s or %s", at.Sink, ActivityTrackerSinkLog, ActivityTrackerSinkFile)
+	}
+	return nil
+}
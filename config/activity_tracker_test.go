@@ -0,0 +1,68 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateActivityTracker(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		at           ActivityTrackerConfig
+		expectErr    bool
+	}{
+		{testcasename: "Default sink", at: ActivityTrackerConfig{Enabled: true}},
+		{testcasename: "File sink", at: ActivityTrackerConfig{Enabled: true, Sink: ActivityTrackerSinkFile, Path: "/var/log/at/events.log"}},
+		{testcasename: "File sink without path", at: ActivityTrackerConfig{Enabled: true, Sink: ActivityTrackerSinkFile}, expectErr: true},
+		{testcasename: "Unknown sink", at: ActivityTrackerConfig{Enabled: true, Sink: "syslog"}, expectErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := testcase.at.ValidateActivityTracker()
+			assert.Equal(t, testcase.expectErr, err != nil)
+			conf := &Config{ActivityTracker: &testcase.at}
+			assert.Equal(t, testcase.expectErr, conf.Validate().HasErrors())
+		})
+	}
+}
+
+func TestParseActivityTracker(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	conf, err := ParseConfigStrict(logger, `
+[activity_tracker]
+activity_tracker_enabled = true
+sink = "file"
+path = "/var/log/at/events.log"
+account_id = "abc123"
+`)
+	assert.Nil(t, err)
+	assert.True(t, conf.ActivityTracker.Enabled)
+	assert.Equal(t, ActivityTrackerSinkFile, conf.ActivityTracker.Sink)
+	assert.Equal(t, "abc123", conf.ActivityTracker.AccountID)
+	assert.Equal(t, DefaultActivityTrackerServiceName, conf.ActivityTracker.ServiceNameOrDefault())
+
+	conf.ActivityTracker.ServiceName = "share"
+	assert.Equal(t, "share", conf.ActivityTracker.ServiceNameOrDefault())
+}
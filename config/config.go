@@ -20,12 +20,6 @@ package config
 import (
 	"os"
 	"strings"
-
-	"github.com/BurntSushi/toml"
-	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
-	"github.com/IBM/secret-utils-lib/pkg/utils"
-	"github.com/kelseyhightower/envconfig"
-	"go.uber.org/zap"
 )
 
 func getEnv(key string) string {
@@ -46,43 +40,78 @@ type Config struct {
 	Bluemix   *BluemixConfig //`required:"true"`
 	Softlayer *SoftlayerConfig
 	VPC       *VPCProviderConfig
+	VPCFile   *VPCFileConfig `toml:"vpc_file"`
 	IKS       *IKSConfig
 	API       *APIConfig
-}
-
-//ReadConfig loads the config from k8s secret ...
-func ReadConfig(k8sClient k8s_utils.KubernetesClient, logger *zap.Logger) (*Config, error) {
-	data, err := k8s_utils.GetSecretData(k8sClient, utils.STORAGE_SECRET_STORE_SECRET, utils.SECRET_STORE_FILE)
-	if err != nil {
-		logger.Error("Error reading config", zap.Error(err))
-		return nil, err
-	}
-	conf, err := ParseConfig(logger, data)
-	if err != nil {
-		logger.Error("Error parsing config", zap.Error(err))
-		return nil, err
-	}
 
-	return conf, nil
+	ActivityTracker *ActivityTrackerConfig `toml:"activity_tracker"`
 }
 
 // ServerConfig configuration options for the provider server itself
 type ServerConfig struct {
 	// DebugTrace is a flag to enable the debug level trace within the provider code.
 	DebugTrace bool `toml:"debug_trace" envconfig:"DEBUG_TRACE"`
+
+	// MetricsSummaryInterval enables a periodic metrics summary log line (e.g. "5m"), for deployments without Prometheus
+	MetricsSummaryInterval string `toml:"metrics_summary_interval" envconfig:"METRICS_SUMMARY_INTERVAL"`
+
+	// MaxOperationTimeout is the absolute ceiling of any operation regardless of per-call settings (e.g. "30m")
+	MaxOperationTimeout string `toml:"max_operation_timeout" envconfig:"MAX_OPERATION_TIMEOUT"`
+
+	// CapabilityRefreshInterval is the interval of the background refresh of provider capabilities and limits (e.g. "1h")
+	CapabilityRefreshInterval string `toml:"capability_refresh_interval" envconfig:"CAPABILITY_REFRESH_INTERVAL"`
+
+	// LogLevels overrides the log level per module, e.g. log_levels = { auth = "debug", waiter = "warn" }
+	LogLevels map[string]string `toml:"log_levels" envconfig:"LOG_LEVELS"`
+
+	// LogSamplingInitial and LogSamplingThereafter enable sampling of repeated log entries on hot paths:
+	// per second, the first LogSamplingInitial entries with the same message are logged, then every LogSamplingThereafter-th
+	LogSamplingInitial    int `toml:"log_sampling_initial" envconfig:"LOG_SAMPLING_INITIAL"`
+	LogSamplingThereafter int `toml:"log_sampling_thereafter" envconfig:"LOG_SAMPLING_THEREAFTER"`
+
+	// PersistenceEncryptionKeys are the AES keys encrypting the state persisted to disk (e.g. the token cache),
+	// as comma separated id:base64key entries. The first key encrypts, the others only decrypt during a rotation
+	PersistenceEncryptionKeys string `toml:"persistence_encryption_keys" json:"-" secretenv:"PERSISTENCE_ENCRYPTION_KEYS"`
+
+	// CircuitBreakerFailureThreshold opens the circuit of an endpoint (RIaaS, IAM) after this many consecutive 5xx or
+	// timeout failures, the calls then fast-fail for CircuitBreakerOpenTimeout (e.g. "30s"). Zero disables the breaker
+	CircuitBreakerFailureThreshold int    `toml:"circuit_breaker_failure_threshold" envconfig:"CIRCUIT_BREAKER_FAILURE_THRESHOLD"`
+	CircuitBreakerOpenTimeout      string `toml:"circuit_breaker_open_timeout" envconfig:"CIRCUIT_BREAKER_OPEN_TIMEOUT"`
+
+	// ReadOnly starts the library in a maintenance freeze, the mutating operations fail with
+	// provider.ErrMaintenanceFreeze while the reads continue. ReadOnlyReason is reported in the errors
+	ReadOnly       bool   `toml:"read_only" envconfig:"READ_ONLY"`
+	ReadOnlyReason string `toml:"read_only_reason,omitempty" envconfig:"READ_ONLY_REASON"`
+
+	// ExpandEnv expands the ${NAME} environment variable references of the config values when the config is parsed
+	// (see ExpandEnv). It is opt-in, the values of the configs which do not set it are kept as is
+	ExpandEnv bool `toml:"expand_env"`
 }
 
 // BluemixConfig ...
 type BluemixConfig struct {
 	IamURL          string `toml:"iam_url"`
 	IamClientID     string `toml:"iam_client_id"`
-	IamClientSecret string `toml:"iam_client_secret" json:"-"`
-	IamAPIKey       string `toml:"iam_api_key" json:"-"`
+	IamClientSecret string `toml:"iam_client_secret" json:"-" secretenv:"IAM_CLIENT_SECRET"`
+	IamAPIKey       string `toml:"iam_api_key" json:"-" secretenv:"IAM_API_KEY"`
 	RefreshToken    string `toml:"refresh_token" json:"-"`
 	APIEndpointURL  string `toml:"containers_api_route"`
 	PrivateAPIRoute string `toml:"containers_api_route_private"`
 	Encryption      bool   `toml:"encryption"`
 	CSRFToken       string `toml:"containers_api_csrf_token" json:"-"`
+
+	// IamTrustedProfileID authenticates as the trusted profile with the compute resource token mounted in the pod
+	// (at CRTokenFilePath, the default projected token path if empty) instead of an API key
+	IamTrustedProfileID string `toml:"iam_trusted_profile_id,omitempty" envconfig:"IAM_TRUSTED_PROFILE_ID"`
+	CRTokenFilePath     string `toml:"cr_token_file_path,omitempty" envconfig:"CR_TOKEN_FILE_PATH"`
+
+	// CredentialProvider supplies the API key instead of IamAPIKey when set (programmatically)
+	CredentialProvider CredentialProvider `toml:"-" json:"-" ignored:"true"`
+}
+
+// UsesTrustedProfile reports whether a trusted profile is configured instead of an API key
+func (b *BluemixConfig) UsesTrustedProfile() bool {
+	return b != nil && b.IamTrustedProfileID != ""
 }
 
 // SoftlayerConfig ...
@@ -92,7 +121,7 @@ type SoftlayerConfig struct {
 	SoftlayerFileEnabled         bool   `toml:"softlayer_file_enabled" envconfig:"SOFTLAYER_FILE_ENABLED"`
 	SoftlayerFileProviderName    string `toml:"softlayer_file_provider_name" envconfig:"SOFTLAYER_FILE_PROVIDER_NAME"`
 	SoftlayerUsername            string `toml:"softlayer_username" json:"-"`
-	SoftlayerAPIKey              string `toml:"softlayer_api_key" json:"-"`
+	SoftlayerAPIKey              string `toml:"softlayer_api_key" json:"-" secretenv:"SOFTLAYER_API_KEY"`
 	SoftlayerEndpointURL         string `toml:"softlayer_endpoint_url"`
 	SoftlayerDataCenter          string `toml:"softlayer_datacenter"`
 	SoftlayerTimeout             string `toml:"softlayer_api_timeout" envconfig:"SOFTLAYER_API_TIMEOUT"`
@@ -125,16 +154,35 @@ type VPCProviderConfig struct {
 	EndpointURL        string `toml:"gc_riaas_endpoint_url"`
 	PrivateEndpointURL string `toml:"gc_riaas_endpoint_private_url"`
 	TokenExchangeURL   string `toml:"gc_token_exchange_endpoint_url"`
-	APIKey             string `toml:"gc_api_key" json:"-"`
+	APIKey             string `toml:"gc_api_key" json:"-" secretenv:"VPC_API_KEY"`
 	ResourceGroupID    string `toml:"gc_resource_group_id"`
 	VPCAPIGeneration   int    `toml:"vpc_api_generation" envconfig:"VPC_API_GENERATION"`
 	APIVersion         string `toml:"api_version,omitempty" envconfig:"VPC_API_VERSION"`
 
+	// PreferFasterEndpoint selects the faster of the public and private endpoint (by observed latency) when both are set
+	PreferFasterEndpoint bool `toml:"prefer_faster_endpoint,omitempty" envconfig:"VPC_PREFER_FASTER_ENDPOINT"`
+
+	// EndpointHealthProbeInterval enables active health probing when both EndpointURL and PrivateEndpointURL are set (e.g. "30s").
+	// The private endpoint is used while healthy: the client fails over after EndpointFailoverThreshold consecutive failed probes
+	// and fails back after EndpointFailbackThreshold consecutive successful probes
+	EndpointHealthProbeInterval string `toml:"endpoint_health_probe_interval,omitempty" envconfig:"VPC_ENDPOINT_HEALTH_PROBE_INTERVAL"`
+	EndpointFailoverThreshold   int    `toml:"endpoint_failover_threshold,omitempty" envconfig:"VPC_ENDPOINT_FAILOVER_THRESHOLD"`
+	EndpointFailbackThreshold   int    `toml:"endpoint_failback_threshold,omitempty" envconfig:"VPC_ENDPOINT_FAILBACK_THRESHOLD"`
+
+	// ZoneEndpoints maps zones to the RIaaS endpoint used for the volumes of the zone, consulted before the region
+	// endpoint, e.g. for zonal direct links with a different route per zone. Set in a [vpc.zone_endpoints] table
+	ZoneEndpoints map[string]string `toml:"zone_endpoints,omitempty"`
+
+	// ReadReplicaEndpointURL is a read-only RIaaS endpoint the non-mutating calls (get, list, poll) are sent to,
+	// falling back to the primary endpoint when it fails. ReadReplicaCooldown (e.g. "30s") bypasses a failed replica
+	ReadReplicaEndpointURL string `toml:"read_replica_endpoint_url,omitempty" envconfig:"VPC_READ_REPLICA_ENDPOINT_URL"`
+	ReadReplicaCooldown    string `toml:"read_replica_cooldown,omitempty" envconfig:"VPC_READ_REPLICA_COOLDOWN"`
+
 	//NG Properties
 	G2EndpointURL        string `toml:"g2_riaas_endpoint_url"`
 	G2EndpointPrivateURL string `toml:"g2_riaas_endpoint_private_url"`
 	G2TokenExchangeURL   string `toml:"g2_token_exchange_endpoint_url"`
-	G2APIKey             string `toml:"g2_api_key" json:"-"`
+	G2APIKey             string `toml:"g2_api_key" json:"-" secretenv:"G2_API_KEY"`
 	G2ResourceGroupID    string `toml:"g2_resource_group_id"`
 	G2VPCAPIGeneration   int    `toml:"g2_vpc_api_generation" envconfig:"G2_VPC_API_GENERATION"`
 	G2APIVersion         string `toml:"g2_api_version,omitempty" envconfig:"G2_VPC_API_VERSION"`
@@ -146,11 +194,73 @@ type VPCProviderConfig struct {
 	MaxVPCRetryAttempt    int    `toml:"max_vpc_retry_attempt,omitempty" envconfig:"MAX_VPC_RETRY_ATTEMPT"`
 	MinVPCRetryGap        int    `toml:"min_vpc_retry_gap,omitempty" envconfig:"MIN_VPC_RETRY_INTERVAL"`
 	MinVPCRetryGapAttempt int    `toml:"min_vpc_retry_gap_attempt,omitempty" envconfig:"MIN_VPC_RETRY_INTERVAL_ATTEMPT"`
+	// Backoff of the retries: the max_retry_gap interval is shaped by retry_backoff_strategy (exponential,
+	// decorrelated-jitter, constant or fibonacci), multiplied by retry_backoff_multiplier after each retry by default,
+	// up to retry_max_interval (e.g. "1m"), and randomized by up to the retry_jitter fraction (0 to 1) of it
+	RetryBackoffStrategy   string  `toml:"retry_backoff_strategy,omitempty" envconfig:"VPC_RETRY_BACKOFF_STRATEGY"`
+	RetryBackoffMultiplier float64 `toml:"retry_backoff_multiplier,omitempty" envconfig:"VPC_RETRY_BACKOFF_MULTIPLIER"`
+	RetryMaxInterval       string  `toml:"retry_max_interval,omitempty" envconfig:"VPC_RETRY_MAX_INTERVAL"`
+	RetryJitter            float64 `toml:"retry_jitter,omitempty" envconfig:"VPC_RETRY_JITTER"`
+	// RetryReasonCodeAttempts overrides max_retry_attempt per reason code, e.g. { ErrorQuotaExceeded = 1 } not to
+	// retry them, or VPC_RETRY_REASON_CODE_ATTEMPTS="ErrorQuotaExceeded:1"
+	RetryReasonCodeAttempts map[string]int `toml:"retry_reason_code_attempts,omitempty" envconfig:"VPC_RETRY_REASON_CODE_ATTEMPTS"`
+	// Client side rate limits, read (get/list/poll) and mutate calls are limited independently. Zero QPS disables the limit
+	ReadRateLimitQPS     float64 `toml:"read_rate_limit_qps,omitempty" envconfig:"VPC_READ_RATE_LIMIT_QPS"`
+	ReadRateLimitBurst   int     `toml:"read_rate_limit_burst,omitempty" envconfig:"VPC_READ_RATE_LIMIT_BURST"`
+	MutateRateLimitQPS   float64 `toml:"mutate_rate_limit_qps,omitempty" envconfig:"VPC_MUTATE_RATE_LIMIT_QPS"`
+	MutateRateLimitBurst int     `toml:"mutate_rate_limit_burst,omitempty" envconfig:"VPC_MUTATE_RATE_LIMIT_BURST"`
+	// MaxConcurrentAttachPerZone bounds the concurrent attach operations per zone, 0 is unbounded
+	MaxConcurrentAttachPerZone int `toml:"max_concurrent_attach_per_zone,omitempty" envconfig:"VPC_MAX_CONCURRENT_ATTACH_PER_ZONE"`
+
+	// AttachDetachOnConflict enables the recovery of attach conflicts: a volume attached to a stale instance
+	// is force detached from it, then attached again
+	AttachDetachOnConflict bool `toml:"attach_detach_on_conflict,omitempty" envconfig:"VPC_ATTACH_DETACH_ON_CONFLICT"`
+
+	// HTTP transport tuning of the shared client. gzip compression of responses is requested unless disabled
+	DisableCompression  bool   `toml:"disable_compression,omitempty" envconfig:"VPC_DISABLE_COMPRESSION"`
+	MaxIdleConnsPerHost int    `toml:"max_idle_conns_per_host,omitempty" envconfig:"VPC_MAX_IDLE_CONNS_PER_HOST"`
+	IdleConnTimeout     string `toml:"idle_conn_timeout,omitempty" envconfig:"VPC_IDLE_CONN_TIMEOUT"`
+	KeepAliveInterval   string `toml:"keep_alive_interval,omitempty" envconfig:"VPC_KEEP_ALIVE_INTERVAL"`
+
+	// DNSOverrides maps endpoint hostnames to fixed IPs, e.g. dns_overrides = { "us-south.iaas.cloud.ibm.com" = "10.0.0.5" }
+	DNSOverrides map[string]string `toml:"dns_overrides,omitempty" envconfig:"VPC_DNS_OVERRIDES"`
+	// DNSResolver is the address (host:port) of the DNS server resolving the endpoints, the system resolver if empty
+	DNSResolver string `toml:"dns_resolver,omitempty" envconfig:"VPC_DNS_RESOLVER"`
+
 	// IKSTokenExchangePrivateURL, for private cluster support hence using for all cluster types
 	IKSTokenExchangePrivateURL string `toml:"iks_token_exchange_endpoint_private_url"`
 
 	IsIKS              bool `toml:"is_iks,omitempty"`
 	ClusterVolumeLabel string
+
+	// VPE (Virtual Private Endpoint) gateway properties, used instead of the above endpoints when UseVPE is set
+	UseVPE              bool   `toml:"use_vpe" envconfig:"VPC_USE_VPE"`
+	VPEEndpointURL      string `toml:"vpe_riaas_endpoint_url" envconfig:"VPE_RIAAS_ENDPOINT_URL"`
+	VPETokenExchangeURL string `toml:"vpe_token_exchange_endpoint_url" envconfig:"VPE_TOKEN_EXCHANGE_ENDPOINT_URL"`
+	// VPETLSServerName is the hostname the VPE gateway certificates are validated against, if it differs from the VPE hostname
+	VPETLSServerName string `toml:"vpe_tls_server_name" envconfig:"VPE_TLS_SERVER_NAME"`
+
+	// CredentialProvider supplies the API key instead of APIKey and G2APIKey when set (programmatically)
+	CredentialProvider CredentialProvider `toml:"-" json:"-" ignored:"true"`
+}
+
+// VPCFileConfig configures the VPC file share provider (FileShareManager). The shares are managed through the
+// endpoints and with the credentials of the [vpc] block, set in a [vpc_file] block
+type VPCFileConfig struct {
+	Enabled             bool   `toml:"vpc_file_enabled" envconfig:"VPC_FILE_ENABLED"`
+	VPCFileProviderName string `toml:"vpc_file_provider_name" envconfig:"VPC_FILE_PROVIDER_NAME"`
+
+	// APIVersion of the file share API, the [vpc] api_version if empty
+	APIVersion string `toml:"api_version,omitempty" envconfig:"VPC_FILE_API_VERSION"`
+
+	// DefaultProfile of the shares created without a profile, e.g. dp2
+	DefaultProfile string `toml:"default_profile,omitempty" envconfig:"VPC_FILE_DEFAULT_PROFILE"`
+
+	// AccessControlMode of the shares created without one, security_group or vpc
+	AccessControlMode string `toml:"access_control_mode,omitempty" envconfig:"VPC_FILE_ACCESS_CONTROL_MODE"`
+
+	// ShareTargetTimeout bounds the wait for a share target to become stable (e.g. "5m")
+	ShareTargetTimeout string `toml:"share_target_timeout,omitempty" envconfig:"VPC_FILE_SHARE_TARGET_TIMEOUT"`
 }
 
 //IKSConfig config
@@ -163,21 +273,3 @@ type IKSConfig struct {
 type APIConfig struct {
 	PassthroughSecret string `toml:"PassthroughSecret" json:"-"`
 }
-
-// ParseConfig loads the config from file
-func ParseConfig(logger *zap.Logger, data string) (*Config, error) {
-	configData := new(Config)
-	_, err := toml.Decode(data, configData)
-	if err != nil {
-		logger.Error("Failed to parse config", zap.Error(err))
-		return nil, err
-	}
-
-	err = envconfig.Process("", configData)
-	if err != nil {
-		logger.Error("Failed to gather environment config variable", zap.Error(err))
-		return nil, err
-	}
-
-	return configData, nil
-}
@@ -1,3 +1,6 @@
+//go:build !minimal
+// +build !minimal
+
 /**
  * Copyright 2020 IBM Corp.
  *
@@ -23,6 +26,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/IBM/ibmcloud-volume-interface/lib/deprecation"
 	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -151,6 +155,164 @@ func TestParseConfigNoMatchTwo(t *testing.T) {
 	assert.NotEqual(t, expected, testParseConf)
 }
 */
+func TestParseConfigErrors(t *testing.T) {
+	t.Log("Testing config parsing errors")
+
+	testcases := []struct {
+		testcasename string
+		data         string
+		env          map[string]string
+		expectedErr  error
+	}{
+		{
+			testcasename: "Valid config",
+			data:         "[server]\n  debug_trace = true\n",
+		},
+		{
+			testcasename: "TOML syntax error",
+			data:         "[server\n  debug_trace = true\n",
+			expectedErr:  ErrConfigSyntax,
+		},
+		{
+			testcasename: "Type mismatch",
+			data:         "[server]\n  debug_trace = \"yes\"\n",
+			expectedErr:  ErrConfigSyntax,
+		},
+		{
+			testcasename: "Syntax error is not overwritten by env error",
+			data:         "[server\n",
+			env:          map[string]string{"DEBUG_TRACE": "notabool"},
+			expectedErr:  ErrConfigSyntax,
+		},
+		{
+			testcasename: "Env processing error",
+			data:         "[server]\n  debug_trace = true\n",
+			env:          map[string]string{"DEBUG_TRACE": "notabool"},
+			expectedErr:  ErrConfigEnv,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			for key, value := range testcase.env {
+				t.Setenv(key, value)
+			}
+			conf, err := ParseConfig(testLogger, testcase.data)
+			if testcase.expectedErr == nil {
+				assert.Nil(t, err)
+				assert.NotNil(t, conf)
+				return
+			}
+			assert.Nil(t, conf)
+			assert.True(t, errors.Is(err, testcase.expectedErr), err)
+			var parseErr *ParseError
+			if assert.True(t, errors.As(err, &parseErr)) {
+				assert.NotNil(t, parseErr.Unwrap())
+			}
+		})
+	}
+}
+
+func TestParseConfigFile(t *testing.T) {
+	t.Log("Testing config file parsing")
+
+	conf, err := ParseConfigFile(testLogger, filepath.Join("..", "etc", "libconfig.toml"))
+	assert.Nil(t, err)
+	assert.True(t, conf.VPC.Enabled)
+
+	_, err = ParseConfigFile(testLogger, filepath.Join("..", "etc", "non-exist.toml"))
+	assert.True(t, errors.Is(err, ErrConfigNotFound))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestParseConfigStrict(t *testing.T) {
+	t.Log("Testing strict config parsing")
+
+	data := `
+[server]
+  debug_trace = true
+[vpc]
+  gc_riaas_endpoint_url = "https://us-south.iaas.cloud.ibm.com"
+`
+	typo := `
+[vpc]
+  g2_riaas_endpoint_uri = "https://us-south.iaas.cloud.ibm.com"
+`
+	conf, err := ParseConfigStrict(testLogger, data)
+	assert.Nil(t, err)
+	assert.True(t, conf.Server.DebugTrace)
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", conf.VPC.EndpointURL)
+
+	_, err = ParseConfigStrict(testLogger, typo)
+	if assert.NotNil(t, err) {
+		assert.True(t, errors.Is(err, ErrConfigUnknownKeys))
+		assert.Contains(t, err.Error(), "vpc.g2_riaas_endpoint_uri")
+	}
+
+	// Non strict parsing only warns about unknown keys
+	conf, err = ParseConfig(testLogger, typo)
+	assert.Nil(t, err)
+	assert.Empty(t, conf.VPC.G2EndpointURL)
+}
+
+func TestParseConfigLogLevels(t *testing.T) {
+	t.Log("Testing per module log levels parsing")
+
+	data := `
+[server]
+  log_levels = { auth = "debug", waiter = "warn" }
+  log_sampling_initial = 10
+  log_sampling_thereafter = 100
+`
+	conf, err := ParseConfigStrict(testLogger, data)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"auth": "debug", "waiter": "warn"}, conf.Server.LogLevels)
+	assert.Equal(t, 10, conf.Server.LogSamplingInitial)
+	assert.Equal(t, 100, conf.Server.LogSamplingThereafter)
+}
+
+func TestParseConfigVPCFile(t *testing.T) {
+	t.Log("Testing VPC file config parsing")
+
+	data := `
+[vpc_file]
+  vpc_file_enabled = true
+  vpc_file_provider_name = "VPC-FILE"
+  default_profile = "dp2"
+  access_control_mode = "security_group"
+`
+	conf, err := ParseConfigStrict(testLogger, data)
+	assert.Nil(t, err)
+	assert.True(t, conf.VPCFile.Enabled)
+	assert.Equal(t, "VPC-FILE", conf.VPCFile.VPCFileProviderName)
+	assert.Equal(t, "dp2", conf.VPCFile.DefaultProfile)
+	assert.Equal(t, "security_group", conf.VPCFile.AccessControlMode)
+}
+
+func TestParseConfigDeprecatedKeys(t *testing.T) {
+	t.Log("Testing deprecated config keys reporting")
+
+	countOf := func(name string) int {
+		for _, d := range deprecation.Deprecations() {
+			if d.Kind == deprecation.KindConfigKey && d.Name == name {
+				return d.Count
+			}
+		}
+		return -1
+	}
+	before := countOf("bluemix.refresh_token")
+	softlayerBefore := countOf("softlayer")
+
+	data := `
+[bluemix]
+  refresh_token = "token"
+`
+	_, err := ParseConfigStrict(testLogger, data)
+	assert.Nil(t, err)
+	assert.Equal(t, before+1, countOf("bluemix.refresh_token"))
+	assert.Equal(t, softlayerBefore, countOf("softlayer"))
+}
+
 func TestGetGoPath(t *testing.T) {
 	t.Log("Testing getting GOPATH")
 	goPath := "/tmp"
@@ -0,0 +1,180 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the credentials of a config section, so they can be backed by Vault,
+// Secrets Manager or rotated files instead of the static TOML fields
+type CredentialProvider interface {
+	// GetAPIKey returns the current API key
+	GetAPIKey(ctx context.Context) (string, error)
+
+	// GetToken returns the current token, empty if the provider only supplies an API key
+	GetToken(ctx context.Context) (string, error)
+
+	// OnRotate registers a hook called after the credentials are rotated, e.g. to invalidate cached tokens
+	OnRotate(hook func())
+}
+
+// StaticCredentialProvider supplies fixed credentials, they never rotate
+type StaticCredentialProvider struct {
+	APIKey string
+	Token  string
+}
+
+var _ CredentialProvider = &StaticCredentialProvider{}
+
+// GetAPIKey ...
+func (p *StaticCredentialProvider) GetAPIKey(ctx context.Context) (string, error) {
+	return p.APIKey, nil
+}
+
+// GetToken ...
+func (p *StaticCredentialProvider) GetToken(ctx context.Context) (string, error) {
+	return p.Token, nil
+}
+
+// OnRotate ...
+func (p *StaticCredentialProvider) OnRotate(hook func()) {}
+
+// FileCredentialProvider supplies the credentials read from files, e.g. a mounted secret rotated by an
+// operator. The files are read again by Refresh, which calls the rotation hooks when their content changed
+type FileCredentialProvider struct {
+	apiKeyFile string
+	tokenFile  string
+
+	mu     sync.Mutex
+	loaded bool
+	apiKey string
+	token  string
+	hooks  []func()
+}
+
+var _ CredentialProvider = &FileCredentialProvider{}
+
+// NewFileCredentialProvider returns a provider reading the API key and token from the files,
+// either file is optional but not both
+func NewFileCredentialProvider(apiKeyFile, tokenFile string) (*FileCredentialProvider, error) {
+	if apiKeyFile == "" && tokenFile == "" {
+		return nil, fmt.Errorf("an API key file or a token file is required")
+	}
+	p := &FileCredentialProvider{apiKeyFile: apiKeyFile, tokenFile: tokenFile}
+	if _, err := p.Refresh(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func readCredentialFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read credential file %s: %v", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Refresh reads the credential files again and returns whether the credentials rotated,
+// the rotation hooks are called (outside the lock) if they did
+func (p *FileCredentialProvider) Refresh() (bool, error) {
+	apiKey, err := readCredentialFile(p.apiKeyFile)
+	if err != nil {
+		return false, err
+	}
+	token, err := readCredentialFile(p.tokenFile)
+	if err != nil {
+		return false, err
+	}
+
+	p.mu.Lock()
+	rotated := p.loaded && (apiKey != p.apiKey || token != p.token)
+	p.loaded, p.apiKey, p.token = true, apiKey, token
+	hooks := append([]func(){}, p.hooks...)
+	p.mu.Unlock()
+
+	if rotated {
+		for _, hook := range hooks {
+			hook()
+		}
+	}
+	return rotated, nil
+}
+
+// Watch refreshes the credentials at the interval until ctx is done, read errors keep the current credentials
+func (p *FileCredentialProvider) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = p.Refresh()
+		}
+	}
+}
+
+// GetAPIKey ...
+func (p *FileCredentialProvider) GetAPIKey(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.apiKey, nil
+}
+
+// GetToken ...
+func (p *FileCredentialProvider) GetToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.token, nil
+}
+
+// OnRotate ...
+func (p *FileCredentialProvider) OnRotate(hook func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hooks = append(p.hooks, hook)
+}
+
+// GetAPIKey returns the API key of the credential provider if set, otherwise the gc_api_key (or g2_api_key)
+func (vpc *VPCProviderConfig) GetAPIKey(ctx context.Context) (string, error) {
+	if vpc.CredentialProvider != nil {
+		return vpc.CredentialProvider.GetAPIKey(ctx)
+	}
+	if vpc.APIKey != "" {
+		return vpc.APIKey, nil
+	}
+	return vpc.G2APIKey, nil
+}
+
+// GetAPIKey returns the API key of the credential provider if set, otherwise the iam_api_key
+func (b *BluemixConfig) GetAPIKey(ctx context.Context) (string, error) {
+	if b.CredentialProvider != nil {
+		return b.CredentialProvider.GetAPIKey(ctx)
+	}
+	return b.IamAPIKey, nil
+}
@@ -0,0 +1,90 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	apiKeyFile := filepath.Join(t.TempDir(), "apikey")
+	assert.Nil(t, os.WriteFile(apiKeyFile, []byte("key-1\n"), 0600))
+
+	_, err := NewFileCredentialProvider("", "")
+	assert.NotNil(t, err)
+	_, err = NewFileCredentialProvider(filepath.Join(t.TempDir(), "missing"), "")
+	assert.NotNil(t, err)
+
+	p, err := NewFileCredentialProvider(apiKeyFile, "")
+	assert.Nil(t, err)
+	rotations := 0
+	p.OnRotate(func() { rotations++ })
+	apiKey, _ := p.GetAPIKey(ctx)
+	assert.Equal(t, "key-1", apiKey)
+	token, _ := p.GetToken(ctx)
+	assert.Equal(t, "", token)
+
+	rotated, err := p.Refresh()
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+
+	assert.Nil(t, os.WriteFile(apiKeyFile, []byte("key-2"), 0600))
+	rotated, err = p.Refresh()
+	assert.Nil(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, 1, rotations)
+	apiKey, _ = p.GetAPIKey(ctx)
+	assert.Equal(t, "key-2", apiKey)
+
+	// read errors keep the current credentials
+	assert.Nil(t, os.Remove(apiKeyFile))
+	_, err = p.Refresh()
+	assert.NotNil(t, err)
+	apiKey, _ = p.GetAPIKey(ctx)
+	assert.Equal(t, "key-2", apiKey)
+}
+
+func TestConfigCredentialProvider(t *testing.T) {
+	ctx := context.Background()
+	vpc := &VPCProviderConfig{G2APIKey: "g2-key"}
+	apiKey, _ := vpc.GetAPIKey(ctx)
+	assert.Equal(t, "g2-key", apiKey)
+	vpc.APIKey = "gc-key"
+	apiKey, _ = vpc.GetAPIKey(ctx)
+	assert.Equal(t, "gc-key", apiKey)
+	vpc.CredentialProvider = &StaticCredentialProvider{APIKey: "vault-key"}
+	apiKey, _ = vpc.GetAPIKey(ctx)
+	assert.Equal(t, "vault-key", apiKey)
+
+	bluemix := &BluemixConfig{IamAPIKey: "iam-key"}
+	apiKey, _ = bluemix.GetAPIKey(ctx)
+	assert.Equal(t, "iam-key", apiKey)
+	bluemix.CredentialProvider = &StaticCredentialProvider{APIKey: "vault-key"}
+	apiKey, _ = bluemix.GetAPIKey(ctx)
+	assert.Equal(t, "vault-key", apiKey)
+
+	// no static API key is required with a credential provider
+	conf := &Config{VPC: &VPCProviderConfig{Enabled: true, EndpointURL: "https://us-south.iaas.cloud.ibm.com", CredentialProvider: &StaticCredentialProvider{APIKey: "vault-key"}}}
+	assert.False(t, conf.Validate().HasErrors())
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// dialContext is the signature of http.Transport DialContext
+type dialContext func(ctx context.Context, network, address string) (net.Conn, error)
+
+// newDialContext returns a dialer applying the DNS overrides and custom resolver of the options
+func newDialContext(options TransportOptions) dialContext {
+	dialer := &net.Dialer{KeepAlive: options.KeepAliveInterval}
+	if options.DNSResolver != "" {
+		resolverDialer := &net.Dialer{}
+		dialer.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return resolverDialer.DialContext(ctx, network, options.DNSResolver)
+			},
+		}
+	}
+	if len(options.DNSOverrides) == 0 {
+		return dialer.DialContext
+	}
+
+	overrides := make(map[string]string, len(options.DNSOverrides))
+	for host, ip := range options.DNSOverrides {
+		overrides[strings.ToLower(host)] = ip
+	}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err == nil {
+			if ip, ok := overrides[strings.ToLower(host)]; ok {
+				address = net.JoinHostPort(ip, port)
+			}
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+// validateDNSOptions checks the overrides map hostnames to IPs and the resolver is a host:port address
+func validateDNSOptions(options TransportOptions) error {
+	for host, ip := range options.DNSOverrides {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("dns_overrides of %s: '%s' is not an IP address", host, ip)
+		}
+	}
+	if options.DNSResolver != "" {
+		if _, _, err := net.SplitHostPort(options.DNSResolver); err != nil {
+			return fmt.Errorf("dns_resolver '%s' is not a host:port address", options.DNSResolver)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNSOverrides(t *testing.T) {
+	t.Log("Testing DNS overrides")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	vpc := &VPCProviderConfig{DNSOverrides: map[string]string{"Riaas.Example.Invalid": "127.0.0.1"}}
+	options, err := vpc.TransportOptions()
+	assert.Nil(t, err)
+
+	client, _ := GeneralCAHttpClientWithOptions(time.Minute, options)
+	resp, err := client.Get("http://riaas.example.invalid:" + port)
+	if assert.Nil(t, err) {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "riaas.example.invalid:"+port, string(body))
+	}
+}
+
+func TestValidateDNSOptions(t *testing.T) {
+	t.Log("Testing DNS options validation")
+
+	_, err := (&VPCProviderConfig{DNSOverrides: map[string]string{"riaas.example.invalid": "not-an-ip"}}).TransportOptions()
+	assert.NotNil(t, err)
+
+	_, err = (&VPCProviderConfig{DNSResolver: "10.0.0.2"}).TransportOptions()
+	assert.NotNil(t, err)
+
+	options, err := (&VPCProviderConfig{DNSResolver: "10.0.0.2:53"}).TransportOptions()
+	assert.Nil(t, err)
+	assert.NotNil(t, newDialContext(options))
+}
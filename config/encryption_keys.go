@@ -0,0 +1,65 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// EncryptionKey is an AES key identified by ID, the ID is stored with the data it encrypts so the
+// key can be rotated
+type EncryptionKey struct {
+	ID  string
+	Key []byte `json:"-"`
+}
+
+// ParseEncryptionKeys parses comma separated id:base64key entries, e.g. the persistence_encryption_keys
+// config value. Keys must be 16, 24 or 32 bytes (AES-128, AES-192 or AES-256)
+func ParseEncryptionKeys(value string) ([]EncryptionKey, error) {
+	keys := []EncryptionKey{}
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid encryption key entry, expected id:base64key")
+		}
+		id := parts[0]
+		if seen[id] {
+			return nil, fmt.Errorf("duplicate encryption key id '%s'", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("encryption key '%s' is not valid base64", id)
+		}
+		if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+			return nil, fmt.Errorf("encryption key '%s' must be 16, 24 or 32 bytes, got %d", id, len(key))
+		}
+		seen[id] = true
+		keys = append(keys, EncryptionKey{ID: id, Key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no encryption key is configured")
+	}
+	return keys, nil
+}
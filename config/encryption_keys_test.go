@@ -0,0 +1,52 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEncryptionKeys(t *testing.T) {
+	key32 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	key16 := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", 16)))
+
+	keys, err := ParseEncryptionKeys("v2:" + key32 + ", v1:" + key16)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(keys))
+	assert.Equal(t, "v2", keys[0].ID)
+	assert.Equal(t, 32, len(keys[0].Key))
+	assert.Equal(t, "v1", keys[1].ID)
+
+	for _, value := range []string{"", "v1", ":" + key32, "v1:not-base64!", "v1:" + base64.StdEncoding.EncodeToString([]byte("short")), "v1:" + key32 + ",v1:" + key16} {
+		_, err = ParseEncryptionKeys(value)
+		assert.NotNil(t, err, value)
+	}
+}
+
+func TestValidatePersistenceEncryptionKeys(t *testing.T) {
+	conf := &Config{Server: &ServerConfig{PersistenceEncryptionKeys: "v1:invalid"}}
+	results := conf.Validate()
+	assert.True(t, results.HasErrors())
+
+	conf.Server.PersistenceEncryptionKeys = "v1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	assert.False(t, conf.Validate().HasErrors())
+}
@@ -0,0 +1,136 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// envReferencePattern matches the names of the environment variables referenced in the config values
+var envReferencePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expandConfigEnv expands the environment variable references of the string values (including those of the
+// string maps and lists) of all config sections, see ExpandEnv. It is only applied to the configs setting
+// expand_env in their [server] section
+func expandConfigEnv(conf *Config) error {
+	return expandStructEnv(reflect.ValueOf(conf).Elem(), "")
+}
+
+func expandStructEnv(section reflect.Value, prefix string) error {
+	for i := 0; i < section.NumField(); i++ {
+		fieldType := section.Type().Field(i)
+		field := section.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		key := strings.Split(fieldType.Tag.Get("toml"), ",")[0]
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(fieldType.Name)
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if err := expandValueEnv(field, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandValueEnv(value reflect.Value, key string) error {
+	switch value.Kind() {
+	case reflect.String:
+		expanded, err := ExpandEnv(value.String())
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		value.SetString(expanded)
+	case reflect.Ptr:
+		if !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+			return expandStructEnv(value.Elem(), key)
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := expandValueEnv(value.Index(i), fmt.Sprintf("%s[%d]", key, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if value.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			expanded, err := ExpandEnv(iter.Value().String())
+			if err != nil {
+				return fmt.Errorf("%s.%v: %v", key, iter.Key(), err)
+			}
+			value.SetMapIndex(iter.Key(), reflect.ValueOf(expanded).Convert(value.Type().Elem()))
+		}
+	}
+	return nil
+}
+
+// ExpandEnv expands the ${NAME} and ${NAME:-default} references to environment variables of the config value,
+// e.g. gc_api_key = "${VPC_API_KEY}", so a config template serves several environments. The default replaces
+// an unset or empty variable, referencing an unset variable without default is an error. $${ is a literal ${,
+// the other $ are kept as is
+func ExpandEnv(value string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	var expanded strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			expanded.WriteString(value)
+			return expanded.String(), nil
+		}
+		if start > 0 && value[start-1] == '$' {
+			expanded.WriteString(value[:start-1] + "${")
+			value = value[start+2:]
+			continue
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated environment variable reference")
+		}
+		name, defaultValue, hasDefault := strings.Cut(value[start+2:start+end], ":-")
+		if !envReferencePattern.MatchString(name) {
+			return "", fmt.Errorf("invalid environment variable name '%s'", name)
+		}
+		env, found := os.LookupEnv(name)
+		if env == "" && hasDefault {
+			env = defaultValue
+		} else if !found {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		expanded.WriteString(value[:start] + env)
+		value = value[start+end+1:]
+	}
+}
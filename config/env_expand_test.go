@@ -0,0 +1,104 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TEST_EXPAND_REGION", "us-south")
+	t.Setenv("TEST_EXPAND_EMPTY", "")
+
+	testCases := []struct {
+		testcasename string
+		value        string
+		expected     string
+		expectErr    string
+	}{
+		{testcasename: "No reference", value: "pa$$word", expected: "pa$$word"},
+		{testcasename: "Reference", value: "https://${TEST_EXPAND_REGION}.iaas.cloud.ibm.com", expected: "https://us-south.iaas.cloud.ibm.com"},
+		{testcasename: "Several references", value: "${TEST_EXPAND_REGION}-${TEST_EXPAND_REGION}", expected: "us-south-us-south"},
+		{testcasename: "Default of unset variable", value: "${TEST_EXPAND_UNSET:-eu-de}", expected: "eu-de"},
+		{testcasename: "Default of empty variable", value: "${TEST_EXPAND_EMPTY:-eu-de}", expected: "eu-de"},
+		{testcasename: "Empty variable", value: "${TEST_EXPAND_EMPTY}", expected: ""},
+		{testcasename: "Escaped reference", value: "$${TEST_EXPAND_REGION}", expected: "${TEST_EXPAND_REGION}"},
+		{testcasename: "Unset variable", value: "${TEST_EXPAND_UNSET}", expectErr: "TEST_EXPAND_UNSET is not set"},
+		{testcasename: "Unterminated reference", value: "${TEST_EXPAND_REGION", expectErr: "unterminated"},
+		{testcasename: "Invalid name", value: "${1REGION}", expectErr: "invalid environment variable name"},
+	}
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			expanded, err := ExpandEnv(testcase.value)
+			if testcase.expectErr != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), testcase.expectErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, expanded)
+		})
+	}
+}
+
+func TestParseConfigExpandsEnv(t *testing.T) {
+	data := `
+[server]
+  expand_env = true
+  log_levels = { auth = "${TEST_EXPAND_LEVEL}" }
+[bluemix]
+  iam_url = "https://${TEST_EXPAND_IAM_HOST}"
+[vpc]
+  g2_api_key = "${TEST_EXPAND_API_KEY}"
+  g2_api_version = "${TEST_EXPAND_UNSET:-2022-05-01}"
+`
+	t.Setenv("TEST_EXPAND_LEVEL", "debug")
+	t.Setenv("TEST_EXPAND_IAM_HOST", "iam.cloud.ibm.com")
+	t.Setenv("TEST_EXPAND_API_KEY", "from-env")
+
+	conf, err := ParseConfig(testLogger, data)
+	assert.Nil(t, err)
+	assert.Equal(t, "debug", conf.Server.LogLevels["auth"])
+	assert.Equal(t, "https://iam.cloud.ibm.com", conf.Bluemix.IamURL)
+	assert.Equal(t, "from-env", conf.VPC.G2APIKey)
+	assert.Equal(t, "2022-05-01", conf.VPC.G2APIVersion)
+
+	_, err = ParseConfig(testLogger, `
+[server]
+  expand_env = true
+[vpc]
+  gc_api_key = "${TEST_EXPAND_UNSET}"
+`)
+	assert.True(t, errors.Is(err, ErrConfigEnv))
+	assert.Contains(t, err.Error(), "vpc.gc_api_key: environment variable TEST_EXPAND_UNSET is not set")
+
+	// the values are kept as is without expand_env
+	conf, err = ParseConfig(testLogger, `
+[server]
+[vpc]
+  gc_api_key = "${TEST_EXPAND_UNSET}"
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, "${TEST_EXPAND_UNSET}", conf.VPC.APIKey)
+}
@@ -0,0 +1,65 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"errors"
+)
+
+var (
+	// ErrConfigNotFound indicates the config file or secret could not be read
+	ErrConfigNotFound = errors.New("config not found")
+
+	// ErrConfigSyntax indicates the config is not valid TOML or does not match the config structure
+	ErrConfigSyntax = errors.New("config syntax error")
+
+	// ErrConfigUnknownKeys indicates the config contains unknown keys (strict parsing only)
+	ErrConfigUnknownKeys = errors.New("config contains unknown keys")
+
+	// ErrConfigEnv indicates the environment variables overriding the config could not be processed
+	ErrConfigEnv = errors.New("config environment processing error")
+)
+
+// ParseError is returned by the config loading functions, Kind is one of the ErrConfig* errors.
+// Use errors.Is to check the kind of the error and errors.As to access the underlying cause
+type ParseError struct {
+	Kind error
+	Err  error
+}
+
+// Error satisfies the error contract
+func (e *ParseError) Error() string {
+	if e.Err == nil {
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying cause
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of the error
+func (e *ParseError) Is(target error) bool {
+	return e.Kind == target
+}
+
+func newParseError(kind, err error) error {
+	return &ParseError{Kind: kind, Err: err}
+}
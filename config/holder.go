@@ -0,0 +1,165 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Snapshot is an immutable config with the sequence number of the change which produced it
+type Snapshot struct {
+	Config   *Config
+	Sequence uint64
+}
+
+// ConfigChangeHook is called with the previous and current snapshots after each change. The hooks are called
+// outside the lock of the holder, so they may update it, and the hooks of concurrent changes may run concurrently:
+// the sequences of the snapshots order them
+type ConfigChangeHook func(previous, current Snapshot)
+
+// Holder holds the current config for concurrent readers. The held config is never modified: changes are
+// applied to a copy which then replaces it atomically (copy-on-write), so readers never observe a partially
+// updated config. Readers must not modify the returned config either
+type Holder struct {
+	current atomic.Value // Snapshot
+
+	// mu serializes the writers and guards hooks
+	mu    sync.Mutex
+	hooks []ConfigChangeHook
+}
+
+// NewHolder returns a holder of a copy of conf, at sequence 0
+func NewHolder(conf *Config) *Holder {
+	h := &Holder{}
+	h.current.Store(Snapshot{Config: conf.Clone()})
+	return h
+}
+
+// GetCurrent returns the current config, it must not be modified
+func (h *Holder) GetCurrent() *Config {
+	return h.Snapshot().Config
+}
+
+// Snapshot returns the current config and its sequence number
+func (h *Holder) Snapshot() Snapshot {
+	return h.current.Load().(Snapshot)
+}
+
+// Sequence returns the sequence number of the current config, incremented by each change
+func (h *Holder) Sequence() uint64 {
+	return h.Snapshot().Sequence
+}
+
+// OnChange registers a hook called after each change
+func (h *Holder) OnChange(hook ConfigChangeHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// Store replaces the current config with a copy of conf, e.g. after reloading the config file
+func (h *Holder) Store(conf *Config) Snapshot {
+	return h.Update(func(current *Config) error {
+		*current = Config{}
+		if conf != nil {
+			*current = *conf.Clone()
+		}
+		return nil
+	})
+}
+
+// Update applies the mutation to a copy of the current config, and makes it current unless the mutation fails
+func (h *Holder) Update(mutate func(*Config) error) Snapshot {
+	snapshot, _ := h.TryUpdate(mutate)
+	return snapshot
+}
+
+// TryUpdate is Update returning the error of the mutation, the current snapshot is returned on error
+func (h *Holder) TryUpdate(mutate func(*Config) error) (Snapshot, error) {
+	h.mu.Lock()
+	previous := h.Snapshot()
+	next := previous.Config.Clone()
+	if next == nil {
+		next = &Config{}
+	}
+	if err := mutate(next); err != nil {
+		h.mu.Unlock()
+		return previous, err
+	}
+	current := Snapshot{Config: next, Sequence: previous.Sequence + 1}
+	h.current.Store(current)
+	hooks := append([]ConfigChangeHook{}, h.hooks...)
+	h.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(previous, current)
+	}
+	return current, nil
+}
+
+// Clone returns a deep copy of the config sections, their maps and slices. Credential providers are shared
+func (c *Config) Clone() *Config {
+	if c == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(c)).Interface().(*Config)
+}
+
+// deepCopy copies the pointers, structs, maps and slices of v, other values (including interfaces) are shared
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Elem().Type())
+		copied.Elem().Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"go.uber.org/zap"
+)
+
+// Reload parses the config file and makes it current if it is valid, the current config is kept otherwise
+func (h *Holder) Reload(logger *zap.Logger, path string) (Snapshot, error) {
+	conf, err := ParseConfigFile(logger, path)
+	if err != nil {
+		return h.Snapshot(), err
+	}
+	if err = conf.Validate().Err(); err != nil {
+		logger.Error("Not reloading invalid config", zap.String("path", path), zap.Error(err))
+		return h.Snapshot(), err
+	}
+	snapshot := h.Store(conf)
+	logger.Info("Reloaded config", zap.String("path", path), zap.Uint64("sequence", snapshot.Sequence))
+	return snapshot, nil
+}
@@ -0,0 +1,48 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHolderReload(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	path := filepath.Join(t.TempDir(), "libconfig.toml")
+	holder := NewHolder(&Config{})
+
+	assert.Nil(t, os.WriteFile(path, []byte("[vpc]\n  vpc_enabled = true\n  gc_riaas_endpoint_url = \"https://us-south.iaas.cloud.ibm.com\"\n  gc_api_key = \"key\"\n"), 0600))
+	snapshot, err := holder.Reload(logger, path)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), snapshot.Sequence)
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", holder.GetCurrent().VPC.EndpointURL)
+
+	// invalid configs are not reloaded
+	assert.Nil(t, os.WriteFile(path, []byte("[vpc]\n  vpc_enabled = true\n"), 0600))
+	_, err = holder.Reload(logger, path)
+	assert.NotNil(t, err)
+	assert.Equal(t, uint64(1), holder.Sequence())
+}
@@ -0,0 +1,124 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigClone(t *testing.T) {
+	credentials := &StaticCredentialProvider{APIKey: "key"}
+	conf := &Config{
+		Server: &ServerConfig{LogLevels: map[string]string{"auth": "debug"}},
+		VPC:    &VPCProviderConfig{EndpointURL: "https://a", ZoneEndpoints: map[string]string{"z1": "https://z1"}, CredentialProvider: credentials},
+	}
+	clone := conf.Clone()
+	clone.Server.LogLevels["auth"] = "warn"
+	clone.VPC.ZoneEndpoints["z2"] = "https://z2"
+	clone.VPC.EndpointURL = "https://b"
+
+	assert.Equal(t, "debug", conf.Server.LogLevels["auth"])
+	assert.Len(t, conf.VPC.ZoneEndpoints, 1)
+	assert.Equal(t, "https://a", conf.VPC.EndpointURL)
+	assert.Nil(t, clone.Bluemix)
+	assert.Equal(t, credentials, clone.VPC.CredentialProvider)
+	assert.Nil(t, (*Config)(nil).Clone())
+}
+
+func TestHolder(t *testing.T) {
+	conf := &Config{VPC: &VPCProviderConfig{EndpointURL: "https://a"}}
+	holder := NewHolder(conf)
+	conf.VPC.EndpointURL = "https://modified"
+	assert.Equal(t, "https://a", holder.GetCurrent().VPC.EndpointURL)
+	assert.Equal(t, uint64(0), holder.Sequence())
+
+	changes := []uint64{}
+	holder.OnChange(func(previous, current Snapshot) {
+		assert.Equal(t, previous.Sequence+1, current.Sequence)
+		changes = append(changes, current.Sequence)
+	})
+
+	before := holder.GetCurrent()
+	snapshot := holder.Update(func(c *Config) error {
+		c.VPC.EndpointURL = "https://b"
+		return nil
+	})
+	assert.Equal(t, uint64(1), snapshot.Sequence)
+	assert.Equal(t, "https://a", before.VPC.EndpointURL)
+	assert.Equal(t, "https://b", holder.GetCurrent().VPC.EndpointURL)
+
+	_, err := holder.TryUpdate(func(c *Config) error {
+		c.VPC.EndpointURL = "https://c"
+		return errors.New("invalid")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "https://b", holder.GetCurrent().VPC.EndpointURL)
+
+	holder.Store(&Config{IKS: &IKSConfig{Enabled: true}})
+	assert.Nil(t, holder.GetCurrent().VPC)
+	assert.Equal(t, []uint64{1, 2}, changes)
+}
+
+func TestHolderHookUpdates(t *testing.T) {
+	holder := NewHolder(&Config{VPC: &VPCProviderConfig{EndpointURL: "https://a"}})
+	// a hook normalizing the config updates the holder itself
+	holder.OnChange(func(previous, current Snapshot) {
+		if current.Config.VPC.EndpointURL == "https://b/" {
+			holder.Update(func(c *Config) error {
+				c.VPC.EndpointURL = "https://b"
+				return nil
+			})
+		}
+	})
+
+	holder.Update(func(c *Config) error {
+		c.VPC.EndpointURL = "https://b/"
+		return nil
+	})
+	assert.Equal(t, "https://b", holder.GetCurrent().VPC.EndpointURL)
+	assert.Equal(t, uint64(2), holder.Sequence())
+}
+
+func TestHolderConcurrentUpdates(t *testing.T) {
+	holder := NewHolder(&Config{VPC: &VPCProviderConfig{MaxRetryAttempt: 0, MaxRetryGap: 0}})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			holder.Update(func(c *Config) error {
+				// both fields always change together
+				c.VPC.MaxRetryAttempt++
+				c.VPC.MaxRetryGap++
+				return nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			current := holder.GetCurrent()
+			assert.Equal(t, current.VPC.MaxRetryAttempt, current.VPC.MaxRetryGap)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(10), holder.Sequence())
+	assert.Equal(t, 10, holder.GetCurrent().VPC.MaxRetryAttempt)
+}
@@ -0,0 +1,131 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/IBM/ibmcloud-volume-interface/lib/deprecation"
+	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
+	"github.com/IBM/secret-utils-lib/pkg/utils"
+	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
+)
+
+// ReadConfig loads the config from k8s secret ...
+func ReadConfig(k8sClient k8s_utils.KubernetesClient, logger *zap.Logger) (*Config, error) {
+	data, err := k8s_utils.GetSecretData(k8sClient, utils.STORAGE_SECRET_STORE_SECRET, utils.SECRET_STORE_FILE)
+	if err != nil {
+		logger.Error("Error reading config", zap.Error(err))
+		return nil, newParseError(ErrConfigNotFound, err)
+	}
+	conf, err := ParseConfig(logger, data)
+	if err != nil {
+		logger.Error("Error parsing config", zap.Error(err))
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// ParseConfig loads the config from file.
+// Unknown config keys are logged as warnings, use ParseConfigStrict to reject them.
+// The ${NAME} references to environment variables of the string values are only expanded when the config opts
+// in with expand_env in the [server] section (see ServerConfig.ExpandEnv), else they are kept verbatim.
+// The returned error is a *ParseError of kind ErrConfigSyntax or ErrConfigEnv
+func ParseConfig(logger *zap.Logger, data string) (*Config, error) {
+	return parseConfig(logger, data, false)
+}
+
+// ParseConfigStrict loads the config from file and returns an error of kind ErrConfigUnknownKeys if it
+// contains unknown keys, catching typos that would otherwise silently result in empty values
+func ParseConfigStrict(logger *zap.Logger, data string) (*Config, error) {
+	return parseConfig(logger, data, true)
+}
+
+// ParseConfigFile reads and parses the config file, a missing or unreadable file is reported as ErrConfigNotFound
+func ParseConfigFile(logger *zap.Logger, path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		logger.Error("Failed to read config file", zap.String("path", path), zap.Error(err))
+		return nil, newParseError(ErrConfigNotFound, err)
+	}
+	return ParseConfig(logger, string(data))
+}
+
+// parseConfig decodes the config and then applies the environment overrides, stopping at the first fatal error
+func parseConfig(logger *zap.Logger, data string, strict bool) (*Config, error) {
+	configData := new(Config)
+	meta, err := toml.Decode(data, configData)
+	if err != nil {
+		logger.Error("Failed to parse config", zap.Error(err))
+		return nil, newParseError(ErrConfigSyntax, err)
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, 0, len(undecoded))
+		for _, key := range undecoded {
+			keys = append(keys, key.String())
+		}
+		if strict {
+			err = newParseError(ErrConfigUnknownKeys, errors.New(strings.Join(keys, ", ")))
+			logger.Error("Failed to parse config", zap.Error(err))
+			return nil, err
+		}
+		logger.Warn("Ignoring unknown config keys", zap.Strings("keys", keys))
+	}
+
+	reportDeprecatedKeys(logger, meta)
+
+	if configData.Server != nil && configData.Server.ExpandEnv {
+		if err = expandConfigEnv(configData); err != nil {
+			logger.Error("Failed to expand environment variable references", zap.Error(err))
+			return nil, newParseError(ErrConfigEnv, err)
+		}
+	}
+
+	if err = envconfig.Process("", configData); err != nil {
+		logger.Error("Failed to gather environment config variable", zap.Error(err))
+		return nil, newParseError(ErrConfigEnv, err)
+	}
+
+	if err = processSecretEnv(configData); err != nil {
+		logger.Error("Failed to gather environment secret variable", zap.Error(err))
+		return nil, newParseError(ErrConfigEnv, err)
+	}
+
+	return configData, nil
+}
+
+// reportDeprecatedKeys records the deprecated keys set in the config to the deprecation registry
+func reportDeprecatedKeys(logger *zap.Logger, meta toml.MetaData) {
+	for _, d := range deprecation.Deprecations() {
+		if d.Kind != deprecation.KindConfigKey || !meta.IsDefined(strings.Split(d.Name, ".")...) {
+			continue
+		}
+		deprecation.Use(d.Kind, d.Name)
+		logger.Warn("Deprecated config key", zap.String("key", d.Name), zap.String("replacement", d.Replacement))
+	}
+}
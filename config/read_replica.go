@@ -0,0 +1,64 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// DefaultReadReplicaCooldown is the default time a failed read replica is bypassed
+const DefaultReadReplicaCooldown = 30 * time.Second
+
+// ReadEndpointURL returns the endpoint of the non-mutating calls: the read replica endpoint if set and use_vpe is
+// not set, else the RIaaS endpoint (see RIaaSEndpointURL)
+func (vpc *VPCProviderConfig) ReadEndpointURL() string {
+	if !vpc.UseVPE && vpc.ReadReplicaEndpointURL != "" {
+		return vpc.ReadReplicaEndpointURL
+	}
+	return vpc.RIaaSEndpointURL()
+}
+
+// ReadReplicaCooldownDuration returns the time a failed read replica is bypassed, DefaultReadReplicaCooldown if unset
+func (vpc *VPCProviderConfig) ReadReplicaCooldownDuration() (time.Duration, error) {
+	if vpc.ReadReplicaCooldown == "" {
+		return DefaultReadReplicaCooldown, nil
+	}
+	cooldown, err := time.ParseDuration(vpc.ReadReplicaCooldown)
+	if err != nil || cooldown < 0 {
+		return 0, fmt.Errorf("read_replica_cooldown '%s' is not a valid duration", vpc.ReadReplicaCooldown)
+	}
+	return cooldown, nil
+}
+
+// ValidateReadReplica validates the read replica endpoint is an https URL with a hostname and its cooldown a duration
+func (vpc *VPCProviderConfig) ValidateReadReplica() error {
+	if vpc.ReadReplicaEndpointURL == "" {
+		return nil
+	}
+	u, err := url.Parse(vpc.ReadReplicaEndpointURL)
+	if err != nil {
+		return fmt.Errorf("read_replica_endpoint_url is not a valid URL: %v", err)
+	}
+	if u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("read_replica_endpoint_url must be an https URL with a hostname")
+	}
+	_, err = vpc.ReadReplicaCooldownDuration()
+	return err
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEndpointURL(t *testing.T) {
+	vpc := &VPCProviderConfig{EndpointURL: "https://us-south.iaas.cloud.ibm.com"}
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", vpc.ReadEndpointURL())
+
+	vpc.ReadReplicaEndpointURL = "https://replica.us-south.iaas.cloud.ibm.com"
+	assert.Equal(t, "https://replica.us-south.iaas.cloud.ibm.com", vpc.ReadEndpointURL())
+
+	// The VPE gateway takes precedence
+	vpc.UseVPE = true
+	vpc.VPEEndpointURL = "https://vpe.example.com"
+	assert.Equal(t, "https://vpe.example.com", vpc.ReadEndpointURL())
+}
+
+func TestValidateReadReplica(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		endpoint     string
+		cooldown     string
+		expectErr    bool
+	}{
+		{testcasename: "Unset", endpoint: ""},
+		{testcasename: "Valid", endpoint: "https://replica.example.com", cooldown: "1m"},
+		{testcasename: "Not https", endpoint: "http://replica.example.com", expectErr: true},
+		{testcasename: "Invalid cooldown", endpoint: "https://replica.example.com", cooldown: "soon", expectErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			vpc := &VPCProviderConfig{ReadReplicaEndpointURL: testcase.endpoint, ReadReplicaCooldown: testcase.cooldown}
+			err := vpc.ValidateReadReplica()
+			assert.Equal(t, testcase.expectErr, err != nil)
+		})
+	}
+
+	cooldown, err := (&VPCProviderConfig{}).ReadReplicaCooldownDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultReadReplicaCooldown, cooldown)
+	cooldown, err = (&VPCProviderConfig{ReadReplicaCooldown: "2m"}).ReadReplicaCooldownDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Minute, cooldown)
+}
@@ -0,0 +1,70 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// The retry_backoff_strategy values
+const (
+	// BackoffConstant waits max_retry_gap between the retries
+	BackoffConstant = "constant"
+	// BackoffExponential multiplies the interval by retry_backoff_multiplier after each retry
+	BackoffExponential = "exponential"
+	// BackoffFibonacci grows the interval along the Fibonacci sequence
+	BackoffFibonacci = "fibonacci"
+	// BackoffDecorrelatedJitter waits a random interval between max_retry_gap and three times the previous interval
+	BackoffDecorrelatedJitter = "decorrelated-jitter"
+)
+
+// RetryMaxIntervalDuration returns the cap of the retry interval grown by the backoff, zero (unbounded) if unset
+func (vpc *VPCProviderConfig) RetryMaxIntervalDuration() (time.Duration, error) {
+	if vpc.RetryMaxInterval == "" {
+		return 0, nil
+	}
+	maxInterval, err := time.ParseDuration(vpc.RetryMaxInterval)
+	if err != nil || maxInterval < 0 {
+		return 0, fmt.Errorf("retry_max_interval '%s' is not a valid duration", vpc.RetryMaxInterval)
+	}
+	return maxInterval, nil
+}
+
+// ValidateRetryPolicy validates the retry backoff and the reason code attempts
+func (vpc *VPCProviderConfig) ValidateRetryPolicy() error {
+	switch vpc.RetryBackoffStrategy {
+	case "", BackoffConstant, BackoffExponential, BackoffFibonacci, BackoffDecorrelatedJitter:
+	default:
+		return fmt.Errorf("retry_backoff_strategy '%s' is not one of %s, %s, %s or %s", vpc.RetryBackoffStrategy,
+			BackoffConstant, BackoffExponential, BackoffFibonacci, BackoffDecorrelatedJitter)
+	}
+	if vpc.RetryBackoffMultiplier != 0 && vpc.RetryBackoffMultiplier < 1 {
+		return fmt.Errorf("retry_backoff_multiplier %v is less than 1", vpc.RetryBackoffMultiplier)
+	}
+	if vpc.RetryJitter < 0 || vpc.RetryJitter > 1 {
+		return fmt.Errorf("retry_jitter %v is not between 0 and 1", vpc.RetryJitter)
+	}
+	for code, attempts := range vpc.RetryReasonCodeAttempts {
+		if attempts < 1 {
+			return fmt.Errorf("retry_reason_code_attempts of %s is %d, at least 1 attempt is made", code, attempts)
+		}
+	}
+	_, err := vpc.RetryMaxIntervalDuration()
+	return err
+}
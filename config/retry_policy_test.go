@@ -0,0 +1,73 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateRetryPolicy(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		vpc          VPCProviderConfig
+		expectErr    bool
+	}{
+		{testcasename: "Unset", vpc: VPCProviderConfig{}},
+		{testcasename: "Exponential with jitter", vpc: VPCProviderConfig{RetryBackoffMultiplier: 2, RetryMaxInterval: "1m", RetryJitter: 0.2}},
+		{testcasename: "Fibonacci strategy", vpc: VPCProviderConfig{RetryBackoffStrategy: BackoffFibonacci}},
+		{testcasename: "Unknown strategy", vpc: VPCProviderConfig{RetryBackoffStrategy: "linear"}, expectErr: true},
+		{testcasename: "Shrinking multiplier", vpc: VPCProviderConfig{RetryBackoffMultiplier: 0.5}, expectErr: true},
+		{testcasename: "Invalid max interval", vpc: VPCProviderConfig{RetryMaxInterval: "later"}, expectErr: true},
+		{testcasename: "Jitter above 1", vpc: VPCProviderConfig{RetryJitter: 1.5}, expectErr: true},
+		{testcasename: "No attempt", vpc: VPCProviderConfig{RetryReasonCodeAttempts: map[string]int{"ErrorQuotaExceeded": 0}}, expectErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := testcase.vpc.ValidateRetryPolicy()
+			assert.Equal(t, testcase.expectErr, err != nil)
+		})
+	}
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	conf, err := ParseConfig(logger, `
+[vpc]
+max_retry_attempt = 5
+max_retry_gap = 2
+retry_backoff_strategy = "exponential"
+retry_backoff_multiplier = 2.0
+retry_max_interval = "30s"
+retry_jitter = 0.1
+retry_reason_code_attempts = { ErrorQuotaExceeded = 1 }
+`)
+	assert.Nil(t, err)
+	assert.Equal(t, BackoffExponential, conf.VPC.RetryBackoffStrategy)
+	assert.Equal(t, 2.0, conf.VPC.RetryBackoffMultiplier)
+	assert.Equal(t, map[string]int{"ErrorQuotaExceeded": 1}, conf.VPC.RetryReasonCodeAttempts)
+	maxInterval, err := conf.VPC.RetryMaxIntervalDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, maxInterval)
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	// secretEnvTag is the struct tag naming the secret of a config field, overridden by the environment variable
+	// of the name with the Base64EnvSuffix
+	secretEnvTag = "secretenv"
+
+	// Base64EnvSuffix is appended to the name of a secret to provide it base64 encoded in the environment,
+	// e.g. IAM_API_KEY_B64
+	Base64EnvSuffix = "_B64"
+)
+
+// processSecretEnv overrides the secret fields (tagged with secretenv) of all config sections from the base64
+// encoded environment variables. The plain variables (e.g. VPC_API_KEY) are not used, they would silently override
+// the config file values and its ${VPC_API_KEY} references (see ExpandEnv)
+func processSecretEnv(conf *Config) error {
+	sections := reflect.ValueOf(conf).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		if section.Kind() != reflect.Ptr || section.IsNil() || section.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		if err := processSectionSecretEnv(section.Elem()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func processSectionSecretEnv(section reflect.Value) error {
+	for i := 0; i < section.NumField(); i++ {
+		name := section.Type().Field(i).Tag.Get(secretEnvTag)
+		field := section.Field(i)
+		if name == "" || field.Kind() != reflect.String {
+			continue
+		}
+		value, found, err := lookupSecretEnv(name)
+		if err != nil {
+			return err
+		}
+		if found {
+			field.SetString(value)
+		}
+	}
+	return nil
+}
+
+// lookupSecretEnv returns the secret from NAME_B64, decoded
+func lookupSecretEnv(name string) (string, bool, error) {
+	encoded, ok := os.LookupEnv(name + Base64EnvSuffix)
+	if !ok {
+		return "", false, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", false, fmt.Errorf("%s%s is not valid base64: %v", name, Base64EnvSuffix, err)
+	}
+	return string(decoded), true, nil
+}
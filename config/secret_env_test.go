@@ -0,0 +1,54 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretEnvOverrides(t *testing.T) {
+	t.Log("Testing secret environment overrides")
+
+	data := `
+[bluemix]
+  iam_api_key = "from-file"
+[vpc]
+  gc_api_key = "from-file"
+`
+	t.Setenv("IAM_API_KEY", "plain-key")
+	t.Setenv("VPC_API_KEY", "plain-key")
+	t.Setenv("VPC_API_KEY_B64", base64.StdEncoding.EncodeToString([]byte("decoded-key")))
+
+	conf, err := ParseConfig(testLogger, data)
+	assert.Nil(t, err)
+	// the plain variables do not override the config file
+	assert.Equal(t, "from-file", conf.Bluemix.IamAPIKey)
+	assert.Equal(t, "decoded-key", conf.VPC.APIKey)
+
+	t.Setenv("G2_API_KEY_B64", "not base64!")
+	_, err = ParseConfig(testLogger, data)
+	assert.True(t, errors.Is(err, ErrConfigEnv))
+	assert.Contains(t, err.Error(), "G2_API_KEY_B64")
+}
@@ -0,0 +1,42 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftlayerSecretEnv(t *testing.T) {
+	data := `
+[softlayer]
+  softlayer_block_enabled = true
+  softlayer_username = "user"
+`
+	t.Setenv("SOFTLAYER_API_KEY_B64", base64.StdEncoding.EncodeToString([]byte("softlayer-key")))
+
+	conf, err := ParseConfigStrict(testLogger, data)
+	assert.Nil(t, err)
+	assert.Equal(t, "user", conf.Softlayer.SoftlayerUsername)
+	assert.Equal(t, "softlayer-key", conf.Softlayer.SoftlayerAPIKey)
+}
@@ -19,6 +19,7 @@ package config
 
 import (
 	"crypto/tls"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -57,3 +58,97 @@ func GeneralCAHttpClientWithTimeout(timeout time.Duration) (*http.Client, error)
 
 	return httpClient, nil
 }
+
+
+// TransportOptions tune the compression and keep-alive of an http.Transport
+type TransportOptions struct {
+	// DisableCompression stops requesting gzip compressed responses
+	DisableCompression bool
+	// MaxIdleConnsPerHost is the number of idle keep-alive connections kept per host, http.DefaultMaxIdleConnsPerHost if 0
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes idle keep-alive connections after this duration, never if 0
+	IdleConnTimeout time.Duration
+	// KeepAliveInterval is the TCP keep-alive probe interval, the net.Dialer default if 0
+	KeepAliveInterval time.Duration
+	// DNSOverrides maps hostnames to fixed IPs, bypassing DNS resolution
+	DNSOverrides map[string]string
+	// DNSResolver is the address (host:port) of the DNS server used instead of the system resolver
+	DNSResolver string
+}
+
+// TransportOptions returns the HTTP transport tuning of the VPC provider config
+func (vpc *VPCProviderConfig) TransportOptions() (TransportOptions, error) {
+	options := TransportOptions{
+		DisableCompression:  vpc.DisableCompression,
+		MaxIdleConnsPerHost: vpc.MaxIdleConnsPerHost,
+		DNSOverrides:        vpc.DNSOverrides,
+		DNSResolver:         vpc.DNSResolver,
+	}
+	if err := validateDNSOptions(options); err != nil {
+		return TransportOptions{}, err
+	}
+	durations := []struct {
+		key    string
+		value  string
+		target *time.Duration
+	}{
+		{"idle_conn_timeout", vpc.IdleConnTimeout, &options.IdleConnTimeout},
+		{"keep_alive_interval", vpc.KeepAliveInterval, &options.KeepAliveInterval},
+	}
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(duration.value)
+		if err != nil || d < 0 {
+			return TransportOptions{}, fmt.Errorf("%s '%s' is not a valid duration", duration.key, duration.value)
+		}
+		*duration.target = d
+	}
+	return options, nil
+}
+
+// GeneralCAHttpClientWithOptions returns an http.Client configured for general use with a tuned transport
+func GeneralCAHttpClientWithOptions(timeout time.Duration, options TransportOptions) (*http.Client, error) {
+	httpClient := &http.Client{
+
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion: tls.VersionTLS12, // Require TLS 1.2 or higher
+			},
+			Proxy:               http.ProxyFromEnvironment,
+			DialContext:         newDialContext(options),
+			DisableCompression:  options.DisableCompression,
+			MaxIdleConnsPerHost: options.MaxIdleConnsPerHost,
+			IdleConnTimeout:     options.IdleConnTimeout,
+		},
+
+		Timeout: timeout,
+	}
+
+	return httpClient, nil
+}
+
+// VPEHttpClientWithOptions returns the http.Client of GeneralCAHttpClientWithOptions for VPE gateways. If
+// tlsServerName is set, the gateway certificate is validated against it instead of the (non-standard) VPE hostname
+func VPEHttpClientWithOptions(timeout time.Duration, options TransportOptions, tlsServerName string) (*http.Client, error) {
+	httpClient, err := GeneralCAHttpClientWithOptions(timeout, options)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport.(*http.Transport).TLSClientConfig.ServerName = tlsServerName
+	return httpClient, nil
+}
+
+// HTTPClient returns the client of the VPC endpoints with the transport options of the config, the client of the
+// VPE gateways if use_vpe is set
+func (vpc *VPCProviderConfig) HTTPClient(timeout time.Duration) (*http.Client, error) {
+	options, err := vpc.TransportOptions()
+	if err != nil {
+		return nil, err
+	}
+	if vpc.UseVPE {
+		return VPEHttpClientWithOptions(timeout, options, vpc.VPETLSServerName)
+	}
+	return GeneralCAHttpClientWithOptions(timeout, options)
+}
@@ -18,6 +18,12 @@
 package config
 
 import (
+	"compress/gzip"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,3 +46,81 @@ func TestGeneralCAHttpClientWithTimeout(t *testing.T) {
 	assert.NotNil(t, client)
 	assert.Equal(t, client.Timeout, time.Duration(120))
 }
+
+func TestVPEHttpClientWithOptions(t *testing.T) {
+	t.Log("Testing VPEHttpClientWithOptions")
+
+	client, _ := VPEHttpClientWithOptions(120, TransportOptions{MaxIdleConnsPerHost: 10}, "us-south.iaas.cloud.ibm.com")
+
+	assert.NotNil(t, client)
+	assert.Equal(t, client.Timeout, time.Duration(120))
+	transport := client.Transport.(*http.Transport)
+	assert.Equal(t, "us-south.iaas.cloud.ibm.com", transport.TLSClientConfig.ServerName)
+	assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.NotNil(t, transport.Proxy)
+}
+
+func TestVPCProviderConfigHTTPClient(t *testing.T) {
+	vpc := &VPCProviderConfig{UseVPE: true, VPETLSServerName: "us-south.iaas.cloud.ibm.com", MaxIdleConnsPerHost: 10}
+	client, err := vpc.HTTPClient(time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, "us-south.iaas.cloud.ibm.com", client.Transport.(*http.Transport).TLSClientConfig.ServerName)
+	assert.Equal(t, 10, client.Transport.(*http.Transport).MaxIdleConnsPerHost)
+
+	vpc.UseVPE = false
+	client, err = vpc.HTTPClient(time.Minute)
+	assert.Nil(t, err)
+	assert.Empty(t, client.Transport.(*http.Transport).TLSClientConfig.ServerName)
+
+	vpc.IdleConnTimeout = "soon"
+	_, err = vpc.HTTPClient(time.Minute)
+	assert.NotNil(t, err)
+}
+
+func TestTransportOptions(t *testing.T) {
+	t.Log("Testing TransportOptions")
+
+	vpc := &VPCProviderConfig{MaxIdleConnsPerHost: 10, IdleConnTimeout: "90s", KeepAliveInterval: "30s"}
+	options, err := vpc.TransportOptions()
+	assert.Nil(t, err)
+	assert.False(t, options.DisableCompression)
+	assert.Equal(t, 10, options.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, options.IdleConnTimeout)
+	assert.Equal(t, 30*time.Second, options.KeepAliveInterval)
+
+	vpc.IdleConnTimeout = "forever"
+	_, err = vpc.TransportOptions()
+	assert.NotNil(t, err)
+}
+
+func TestGeneralCAHttpClientWithOptions(t *testing.T) {
+	t.Log("Testing GeneralCAHttpClientWithOptions")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			_, _ = w.Write([]byte("plain"))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte("compressed"))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	get := func(client *http.Client) string {
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	client, _ := GeneralCAHttpClientWithOptions(time.Minute, TransportOptions{MaxIdleConnsPerHost: 10, IdleConnTimeout: time.Minute})
+	assert.Equal(t, 10, client.Transport.(*http.Transport).MaxIdleConnsPerHost)
+	assert.Equal(t, "compressed", get(client))
+
+	client, _ = GeneralCAHttpClientWithOptions(time.Minute, TransportOptions{DisableCompression: true})
+	assert.Equal(t, "plain", get(client))
+}
@@ -0,0 +1,293 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity of a check result
+type Severity string
+
+const (
+	// SeverityInfo check passed or is informational
+	SeverityInfo = Severity("info")
+	// SeverityWarning check found a problem which does not prevent the provider from working
+	SeverityWarning = Severity("warning")
+	// SeverityError check found a problem which prevents the provider from working
+	SeverityError = Severity("error")
+)
+
+// CheckResult is the machine readable result of a validation or preflight check
+type CheckResult struct {
+	// ID identifies the check, e.g. vpc.endpoint
+	ID string `json:"id"`
+	// Severity of the result
+	Severity Severity `json:"severity"`
+	// Message describes the result
+	Message string `json:"message"`
+	// Remediation describes how to fix the problem, empty if the check passed
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// CheckResults are the results of all the checks run
+type CheckResults []CheckResult
+
+// HasErrors reports whether any check failed with SeverityError
+func (results CheckResults) HasErrors() bool {
+	for _, result := range results {
+		if result.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns an error listing the failed checks, nil if no check failed with SeverityError
+func (results CheckResults) Err() error {
+	var failed []string
+	for _, result := range results {
+		if result.Severity == SeverityError {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.ID, result.Message))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("configuration checks failed: %s", strings.Join(failed, "; "))
+}
+
+// JSON returns the results in JSON format
+func (results CheckResults) JSON() ([]byte, error) {
+	return json.MarshalIndent(results, "", "  ")
+}
+
+// logLevels are the valid log level names
+var logLevels = map[string]bool{"debug": true, "info": true, "warn": true, "error": true, "dpanic": true, "panic": true, "fatal": true}
+
+func passed(id, message string) CheckResult {
+	return CheckResult{ID: id, Severity: SeverityInfo, Message: message}
+}
+
+func failed(id string, severity Severity, message, remediation string) CheckResult {
+	return CheckResult{ID: id, Severity: severity, Message: message, Remediation: remediation}
+}
+
+// Validate checks the configuration without calling any service
+func (c *Config) Validate() CheckResults {
+	results := CheckResults{}
+	if c.Server != nil {
+		results = append(results, c.Server.validate()...)
+	}
+	if c.VPC != nil && c.VPC.Enabled {
+		results = append(results, c.VPC.validate(c.Bluemix.UsesTrustedProfile())...)
+	}
+	if c.VPCFile != nil && c.VPCFile.Enabled {
+		results = append(results, c.VPCFile.validate(c.VPC)...)
+	}
+	if c.ActivityTracker != nil && c.ActivityTracker.Enabled {
+		if err := c.ActivityTracker.ValidateActivityTracker(); err != nil {
+			results = append(results, failed("activity_tracker.sink", SeverityError, err.Error(),
+				"Set sink to log, or to file with the path of the events file"))
+		}
+	}
+	return results
+}
+
+func (s *ServerConfig) validate() CheckResults {
+	results := CheckResults{}
+	durations := []struct {
+		key   string
+		value string
+	}{
+		{"metrics_summary_interval", s.MetricsSummaryInterval},
+		{"max_operation_timeout", s.MaxOperationTimeout},
+		{"capability_refresh_interval", s.CapabilityRefreshInterval},
+		{"circuit_breaker_open_timeout", s.CircuitBreakerOpenTimeout},
+	}
+	for _, duration := range durations {
+		if duration.value == "" {
+			continue
+		}
+		id := "server." + duration.key
+		if d, err := time.ParseDuration(duration.value); err != nil || d <= 0 {
+			results = append(results, failed(id, SeverityError, fmt.Sprintf("%s '%s' is not a valid duration", duration.key, duration.value),
+				fmt.Sprintf("Set %s to a positive duration, e.g. \"5m\"", duration.key)))
+		} else {
+			results = append(results, passed(id, fmt.Sprintf("%s is %s", duration.key, d)))
+		}
+	}
+
+	if s.CircuitBreakerFailureThreshold < 0 {
+		results = append(results, failed("server.circuit_breaker_failure_threshold", SeverityError, "circuit_breaker_failure_threshold cannot be negative",
+			"Set circuit_breaker_failure_threshold to 0 (disabled) or a positive number of failures"))
+	}
+
+	if s.PersistenceEncryptionKeys != "" {
+		if keys, err := ParseEncryptionKeys(s.PersistenceEncryptionKeys); err != nil {
+			results = append(results, failed("server.persistence_encryption_keys", SeverityError, err.Error(),
+				"Set persistence_encryption_keys to comma separated id:base64key entries of 16, 24 or 32 byte keys"))
+		} else {
+			results = append(results, passed("server.persistence_encryption_keys", fmt.Sprintf("persistence is encrypted with key %s", keys[0].ID)))
+		}
+	}
+
+	for module, value := range s.LogLevels {
+		if !logLevels[strings.ToLower(value)] {
+			results = append(results, failed("server.log_levels", SeverityError, fmt.Sprintf("log level '%s' of module %s is not valid", value, module),
+				"Use one of debug, info, warn, error"))
+		}
+	}
+	return results
+}
+
+// validate validates the VPC config, no API key is required when authenticating with a trusted profile
+func (vpc *VPCProviderConfig) validate(trustedProfile bool) CheckResults {
+	results := CheckResults{}
+	if vpc.RIaaSEndpointURL() == "" {
+		results = append(results, failed("vpc.endpoint", SeverityError, "no VPC RIaaS endpoint is configured",
+			"Set gc_riaas_endpoint_url (or g2_riaas_endpoint_url, or vpe_riaas_endpoint_url with use_vpe)"))
+	} else {
+		results = append(results, passed("vpc.endpoint", fmt.Sprintf("VPC RIaaS endpoint is %s", vpc.RIaaSEndpointURL())))
+	}
+
+	if vpc.APIKey == "" && vpc.G2APIKey == "" && vpc.CredentialProvider == nil && !trustedProfile {
+		results = append(results, failed("vpc.api_key", SeverityError, "no VPC API key is configured",
+			"Set gc_api_key or g2_api_key, or the VPC_API_KEY_B64 / G2_API_KEY_B64 environment variable"))
+	}
+
+	if err := vpc.ValidateVPEConfig(); err != nil {
+		results = append(results, failed("vpc.vpe", SeverityError, err.Error(), "Fix the VPE gateway properties or unset use_vpe"))
+	}
+
+	if err := vpc.ValidateZoneEndpoints(); err != nil {
+		results = append(results, failed("vpc.zone_endpoints", SeverityError, err.Error(), "Set the zone endpoints to https URLs or remove them"))
+	}
+
+	if err := vpc.ValidateReadReplica(); err != nil {
+		results = append(results, failed("vpc.read_replica", SeverityError, err.Error(),
+			"Set read_replica_endpoint_url to an https URL and read_replica_cooldown to a duration, or unset them"))
+	}
+
+	if err := vpc.ValidateRetryPolicy(); err != nil {
+		results = append(results, failed("vpc.retry_policy", SeverityError, err.Error(),
+			"Set retry_backoff_strategy to exponential, decorrelated-jitter, constant or fibonacci, retry_backoff_multiplier to 1 or more, retry_max_interval to a duration, retry_jitter between 0 and 1 and the reason code attempts to 1 or more"))
+	}
+
+	if vpc.ReadRateLimitQPS < 0 || vpc.MutateRateLimitQPS < 0 || vpc.ReadRateLimitBurst < 0 || vpc.MutateRateLimitBurst < 0 {
+		results = append(results, failed("vpc.rate_limit", SeverityError, "rate limits cannot be negative", "Set the rate limits to 0 (disabled) or a positive value"))
+	}
+
+	if vpc.EndpointHealthProbeInterval != "" {
+		if d, err := time.ParseDuration(vpc.EndpointHealthProbeInterval); err != nil || d <= 0 {
+			results = append(results, failed("vpc.endpoint_health_probe", SeverityError, fmt.Sprintf("endpoint_health_probe_interval '%s' is not a valid duration", vpc.EndpointHealthProbeInterval),
+				"Set endpoint_health_probe_interval to a positive duration, e.g. \"30s\""))
+		} else if vpc.EndpointURL == "" || vpc.PrivateEndpointURL == "" {
+			results = append(results, failed("vpc.endpoint_health_probe", SeverityWarning, "endpoint health probing requires both gc_riaas_endpoint_url and gc_riaas_endpoint_private_url",
+				"Set both endpoints or unset endpoint_health_probe_interval"))
+		}
+	}
+	if vpc.EndpointFailoverThreshold < 0 || vpc.EndpointFailbackThreshold < 0 {
+		results = append(results, failed("vpc.endpoint_health_probe", SeverityError, "endpoint failover and failback thresholds cannot be negative",
+			"Set the thresholds to 0 (default) or a positive number of probes"))
+	}
+
+	if _, err := vpc.TransportOptions(); err != nil {
+		results = append(results, failed("vpc.transport", SeverityError, err.Error(), "Set idle_conn_timeout and keep_alive_interval to durations, e.g. \"90s\""))
+	}
+	return results
+}
+
+// validate validates the VPC file config, the shares are managed through the endpoints of the VPC config
+func (file *VPCFileConfig) validate(vpc *VPCProviderConfig) CheckResults {
+	results := CheckResults{}
+	if vpc == nil || !vpc.Enabled {
+		results = append(results, failed("vpc_file.vpc", SeverityError, "the VPC file provider requires the VPC provider",
+			"Set vpc_enabled and the endpoints of the [vpc] block, or unset vpc_file_enabled"))
+	}
+
+	switch file.AccessControlMode {
+	case "", "security_group", "vpc":
+	default:
+		results = append(results, failed("vpc_file.access_control_mode", SeverityError, fmt.Sprintf("access_control_mode '%s' is not valid", file.AccessControlMode),
+			"Set access_control_mode to security_group or vpc"))
+	}
+
+	if file.ShareTargetTimeout != "" {
+		if d, err := time.ParseDuration(file.ShareTargetTimeout); err != nil || d <= 0 {
+			results = append(results, failed("vpc_file.share_target_timeout", SeverityError, fmt.Sprintf("share_target_timeout '%s' is not a valid duration", file.ShareTargetTimeout),
+				"Set share_target_timeout to a positive duration, e.g. \"5m\""))
+		}
+	}
+	return results
+}
+
+// Preflight runs Validate and checks that the configured endpoints are reachable with the client.
+// Unreachable endpoints are reported as warnings, the network might not be ready yet
+func (c *Config) Preflight(ctx context.Context, client *http.Client) CheckResults {
+	results := c.Validate()
+	if c.VPC == nil || !c.VPC.Enabled {
+		return results
+	}
+	type reachabilityCheck struct {
+		id  string
+		url string
+	}
+	endpoints := []reachabilityCheck{
+		{"vpc.endpoint.reachable", c.VPC.RIaaSEndpointURL()},
+		{"vpc.token_exchange_endpoint.reachable", c.VPC.TokenExchangeEndpointURL()},
+	}
+	zones := make([]string, 0, len(c.VPC.ZoneEndpoints))
+	for zone := range c.VPC.ZoneEndpoints {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		// the zones using the region endpoint, e.g. with use_vpe, are already checked
+		if url := c.VPC.RIaaSEndpointURLForZone(zone); url != c.VPC.RIaaSEndpointURL() {
+			endpoints = append(endpoints, reachabilityCheck{"vpc.zone_endpoint.reachable." + zone, url})
+		}
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.url == "" {
+			continue
+		}
+		results = append(results, checkReachable(ctx, client, endpoint.id, endpoint.url))
+	}
+	return results
+}
+
+func checkReachable(ctx context.Context, client *http.Client, id, url string) CheckResult {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return failed(id, SeverityError, fmt.Sprintf("%s is not a valid URL: %v", url, err), "Fix the endpoint URL")
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return failed(id, SeverityWarning, fmt.Sprintf("%s is not reachable: %v", url, err),
+			"Check the network connectivity, DNS and proxy settings of the node")
+	}
+	_ = response.Body.Close()
+	return passed(id, fmt.Sprintf("%s is reachable", url))
+}
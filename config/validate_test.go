@@ -0,0 +1,136 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	conf := &Config{
+		Server: &ServerConfig{MaxOperationTimeout: "30m", MetricsSummaryInterval: "soon", LogLevels: map[string]string{"auth": "verbose"}, CircuitBreakerFailureThreshold: -1},
+		VPC:    &VPCProviderConfig{Enabled: true, MutateRateLimitQPS: -1, KeepAliveInterval: "often"},
+	}
+	results := conf.Validate()
+	assert.True(t, results.HasErrors())
+	assert.NotNil(t, results.Err())
+
+	severities := map[string]Severity{}
+	for _, result := range results {
+		severities[result.ID] = result.Severity
+		if result.Severity == SeverityError {
+			assert.NotEmpty(t, result.Remediation)
+		}
+	}
+	assert.Equal(t, SeverityInfo, severities["server.max_operation_timeout"])
+	assert.Equal(t, SeverityError, severities["server.metrics_summary_interval"])
+	assert.Equal(t, SeverityError, severities["server.log_levels"])
+	assert.Equal(t, SeverityError, severities["server.circuit_breaker_failure_threshold"])
+	assert.Equal(t, SeverityError, severities["vpc.endpoint"])
+	assert.Equal(t, SeverityError, severities["vpc.api_key"])
+	assert.Equal(t, SeverityError, severities["vpc.rate_limit"])
+	assert.Equal(t, SeverityError, severities["vpc.transport"])
+
+	data, err := results.JSON()
+	assert.Nil(t, err)
+	var decoded []map[string]string
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, len(results), len(decoded))
+	assert.NotEmpty(t, decoded[0]["id"])
+
+	conf = &Config{VPC: &VPCProviderConfig{Enabled: true, EndpointURL: "https://us-south.iaas.cloud.ibm.com", APIKey: "key"}}
+	assert.False(t, conf.Validate().HasErrors())
+	assert.Nil(t, conf.Validate().Err())
+
+	// no API key is required with a trusted profile
+	conf = &Config{Bluemix: &BluemixConfig{IamTrustedProfileID: "Profile-1"}, VPC: &VPCProviderConfig{Enabled: true, EndpointURL: "https://us-south.iaas.cloud.ibm.com"}}
+	assert.False(t, conf.Validate().HasErrors())
+}
+
+func TestPreflight(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	conf := &Config{VPC: &VPCProviderConfig{Enabled: true, EndpointURL: server.URL, TokenExchangeURL: "http://127.0.0.1:1", APIKey: "key"}}
+	results := conf.Preflight(context.Background(), server.Client())
+	assert.False(t, results.HasErrors())
+
+	severities := map[string]Severity{}
+	for _, result := range results {
+		severities[result.ID] = result.Severity
+	}
+	assert.Equal(t, SeverityInfo, severities["vpc.endpoint.reachable"])
+	assert.Equal(t, SeverityWarning, severities["vpc.token_exchange_endpoint.reachable"])
+}
+
+func TestPreflightZoneEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	conf := &Config{VPC: &VPCProviderConfig{Enabled: true, EndpointURL: server.URL, APIKey: "key",
+		ZoneEndpoints: map[string]string{"us-south-1": "http://127.0.0.1:1"}}}
+	severities := map[string]Severity{}
+	for _, result := range conf.Preflight(context.Background(), server.Client()) {
+		severities[result.ID] = result.Severity
+	}
+	assert.Equal(t, SeverityWarning, severities["vpc.zone_endpoint.reachable.us-south-1"])
+
+	// the VPE endpoint is used in all the zones
+	conf.VPC.UseVPE = true
+	conf.VPC.VPEEndpointURL = server.URL
+	severities = map[string]Severity{}
+	for _, result := range conf.Preflight(context.Background(), server.Client()) {
+		severities[result.ID] = result.Severity
+	}
+	assert.NotContains(t, severities, "vpc.zone_endpoint.reachable.us-south-1")
+}
+
+func TestValidateEndpointHealthProbe(t *testing.T) {
+	conf := &Config{VPC: &VPCProviderConfig{Enabled: true, EndpointURL: "https://public", APIKey: "key", EndpointHealthProbeInterval: "soon"}}
+	assert.True(t, conf.Validate().HasErrors())
+
+	conf.VPC.EndpointHealthProbeInterval = "30s"
+	results := conf.Validate()
+	assert.False(t, results.HasErrors())
+	out, _ := results.JSON()
+	assert.Contains(t, string(out), "vpc.endpoint_health_probe")
+}
+
+func TestValidateVPCFile(t *testing.T) {
+	conf := &Config{VPCFile: &VPCFileConfig{Enabled: true, AccessControlMode: "public", ShareTargetTimeout: "later"}}
+	results := conf.Validate()
+	severities := map[string]Severity{}
+	for _, result := range results {
+		severities[result.ID] = result.Severity
+	}
+	assert.Equal(t, SeverityError, severities["vpc_file.vpc"])
+	assert.Equal(t, SeverityError, severities["vpc_file.access_control_mode"])
+	assert.Equal(t, SeverityError, severities["vpc_file.share_target_timeout"])
+
+	conf = &Config{
+		VPC:     &VPCProviderConfig{Enabled: true, EndpointURL: "https://us-south.iaas.cloud.ibm.com", APIKey: "key"},
+		VPCFile: &VPCFileConfig{Enabled: true, AccessControlMode: "security_group", ShareTargetTimeout: "5m"},
+	}
+	assert.False(t, conf.Validate().HasErrors())
+}
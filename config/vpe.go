@@ -0,0 +1,81 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// RIaaSEndpointURL returns the RIaaS endpoint to be used, the VPE endpoint takes precedence if use_vpe is set.
+// If unspecified, GC will take precedence over G2 (if both are specified)
+func (vpc *VPCProviderConfig) RIaaSEndpointURL() string {
+	if vpc.UseVPE {
+		return vpc.VPEEndpointURL
+	}
+	if vpc.EndpointURL != "" {
+		return vpc.EndpointURL
+	}
+	return vpc.G2EndpointURL
+}
+
+// TokenExchangeEndpointURL returns the token exchange endpoint to be used, the VPE endpoint takes precedence if use_vpe is set.
+// If unspecified, GC will take precedence over G2 (if both are specified)
+func (vpc *VPCProviderConfig) TokenExchangeEndpointURL() string {
+	if vpc.UseVPE {
+		return vpc.VPETokenExchangeURL
+	}
+	if vpc.TokenExchangeURL != "" {
+		return vpc.TokenExchangeURL
+	}
+	return vpc.G2TokenExchangeURL
+}
+
+// ValidateVPEConfig validates the VPE endpoints when use_vpe is set.
+// VPE hostnames are not required to belong to a standard IBM Cloud domain, but they must be https URLs.
+// As certificates cannot be validated against an IP address, vpe_tls_server_name is required for IP based endpoints
+func (vpc *VPCProviderConfig) ValidateVPEConfig() error {
+	if !vpc.UseVPE {
+		return nil
+	}
+	endpoints := []struct {
+		key   string
+		value string
+	}{
+		{"vpe_riaas_endpoint_url", vpc.VPEEndpointURL},
+		{"vpe_token_exchange_endpoint_url", vpc.VPETokenExchangeURL},
+	}
+	for _, endpoint := range endpoints {
+		if endpoint.value == "" {
+			return fmt.Errorf("%s is required when use_vpe is set", endpoint.key)
+		}
+		u, err := url.Parse(endpoint.value)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid URL: %v", endpoint.key, err)
+		}
+		if u.Scheme != "https" || u.Hostname() == "" {
+			return fmt.Errorf("%s must be an https URL with a hostname", endpoint.key)
+		}
+		if net.ParseIP(u.Hostname()) != nil && vpc.VPETLSServerName == "" {
+			return errors.New("vpe_tls_server_name is required when a VPE endpoint is an IP address")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointSelection(t *testing.T) {
+	vpc := &VPCProviderConfig{
+		EndpointURL:         "https://us-south.iaas.cloud.ibm.com",
+		TokenExchangeURL:    "https://iam.cloud.ibm.com",
+		G2EndpointURL:       "https://g2.iaas.cloud.ibm.com",
+		G2TokenExchangeURL:  "https://g2.iam.cloud.ibm.com",
+		VPEEndpointURL:      "https://vpe-riaas.internal",
+		VPETokenExchangeURL: "https://vpe-iam.internal",
+	}
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", vpc.RIaaSEndpointURL())
+	assert.Equal(t, "https://iam.cloud.ibm.com", vpc.TokenExchangeEndpointURL())
+
+	vpc.EndpointURL, vpc.TokenExchangeURL = "", ""
+	assert.Equal(t, "https://g2.iaas.cloud.ibm.com", vpc.RIaaSEndpointURL())
+	assert.Equal(t, "https://g2.iam.cloud.ibm.com", vpc.TokenExchangeEndpointURL())
+
+	vpc.UseVPE = true
+	assert.Equal(t, "https://vpe-riaas.internal", vpc.RIaaSEndpointURL())
+	assert.Equal(t, "https://vpe-iam.internal", vpc.TokenExchangeEndpointURL())
+}
+
+func TestValidateVPEConfig(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		config       VPCProviderConfig
+		expectedErr  bool
+	}{
+		{
+			testcasename: "VPE disabled",
+			config:       VPCProviderConfig{},
+		},
+		{
+			testcasename: "Valid VPE hostnames",
+			config:       VPCProviderConfig{UseVPE: true, VPEEndpointURL: "https://riaas.vpe.internal", VPETokenExchangeURL: "https://iam.vpe.internal:8443"},
+		},
+		{
+			testcasename: "Missing VPE endpoint",
+			config:       VPCProviderConfig{UseVPE: true, VPETokenExchangeURL: "https://iam.vpe.internal"},
+			expectedErr:  true,
+		},
+		{
+			testcasename: "Non https VPE endpoint",
+			config:       VPCProviderConfig{UseVPE: true, VPEEndpointURL: "http://riaas.vpe.internal", VPETokenExchangeURL: "https://iam.vpe.internal"},
+			expectedErr:  true,
+		},
+		{
+			testcasename: "IP VPE endpoint without TLS server name",
+			config:       VPCProviderConfig{UseVPE: true, VPEEndpointURL: "https://10.0.0.5", VPETokenExchangeURL: "https://iam.vpe.internal"},
+			expectedErr:  true,
+		},
+		{
+			testcasename: "IP VPE endpoint with TLS server name",
+			config:       VPCProviderConfig{UseVPE: true, VPEEndpointURL: "https://10.0.0.5", VPETokenExchangeURL: "https://10.0.0.6", VPETLSServerName: "us-south.iaas.cloud.ibm.com"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := testcase.config.ValidateVPEConfig()
+			if testcase.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
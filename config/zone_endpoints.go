@@ -0,0 +1,57 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// RIaaSEndpointURLForZone returns the RIaaS endpoint to be used for the zone: the VPE endpoint if use_vpe is set,
+// else the endpoint of the zone in zone_endpoints if any, else the region endpoint (see RIaaSEndpointURL)
+func (vpc *VPCProviderConfig) RIaaSEndpointURLForZone(zone string) string {
+	if !vpc.UseVPE && zone != "" {
+		if endpoint := vpc.ZoneEndpoints[zone]; endpoint != "" {
+			return endpoint
+		}
+	}
+	return vpc.RIaaSEndpointURL()
+}
+
+// ValidateZoneEndpoints validates the zone endpoints are https URLs with a hostname
+func (vpc *VPCProviderConfig) ValidateZoneEndpoints() error {
+	zones := make([]string, 0, len(vpc.ZoneEndpoints))
+	for zone := range vpc.ZoneEndpoints {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		if zone == "" {
+			return fmt.Errorf("zone_endpoints has an endpoint without zone")
+		}
+		u, err := url.Parse(vpc.ZoneEndpoints[zone])
+		if err != nil {
+			return fmt.Errorf("zone_endpoints endpoint of zone %s is not a valid URL: %v", zone, err)
+		}
+		if u.Scheme != "https" || u.Hostname() == "" {
+			return fmt.Errorf("zone_endpoints endpoint of zone %s must be an https URL with a hostname", zone)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+//go:build !minimal
+// +build !minimal
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config ...
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestZoneEndpoints(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	conf, err := ParseConfigStrict(logger, `
+[vpc]
+  gc_riaas_endpoint_url = "https://us-south.iaas.cloud.ibm.com"
+  vpe_riaas_endpoint_url = "https://riaas.vpe.internal"
+  [vpc.zone_endpoints]
+    us-south-1 = "https://us-south-1.dl.internal"
+`)
+	assert.Nil(t, err)
+	vpc := conf.VPC
+	assert.Nil(t, vpc.ValidateZoneEndpoints())
+	assert.Equal(t, "https://us-south-1.dl.internal", vpc.RIaaSEndpointURLForZone("us-south-1"))
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", vpc.RIaaSEndpointURLForZone("us-south-2"))
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", vpc.RIaaSEndpointURLForZone(""))
+
+	vpc.UseVPE = true
+	assert.Equal(t, "https://riaas.vpe.internal", vpc.RIaaSEndpointURLForZone("us-south-1"))
+
+	vpc.ZoneEndpoints["us-south-2"] = "http://us-south-2.dl.internal"
+	assert.NotNil(t, vpc.ValidateZoneEndpoints())
+}
@@ -0,0 +1,98 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ctxkeys defines the typed context keys of the library and their accessors, so callers can not
+// collide with or mistype the keys
+package ctxkeys
+
+import "context"
+
+// Key is a typed context key. Keys are only equal to themselves, two keys created with the same name are distinct
+type Key[T any] struct {
+	name *string
+}
+
+// NewKey returns a new key, name is only used for debugging
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: &name}
+}
+
+// String returns the name of the key
+func (k Key[T]) String() string {
+	if k.name == nil {
+		return ""
+	}
+	return *k.name
+}
+
+// WithValue returns a context carrying the value for the key
+func (k Key[T]) WithValue(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// Value returns the value of the key carried by the context, if any
+func (k Key[T]) Value(ctx context.Context) (T, bool) {
+	var zero T
+	if ctx == nil {
+		return zero, false
+	}
+	value, ok := ctx.Value(k).(T)
+	if !ok {
+		return zero, false
+	}
+	return value, true
+}
+
+var (
+	requestIDKey = NewKey[string]("request-id")
+	metadataKey  = NewKey[map[string]string]("metadata")
+)
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return requestIDKey.WithValue(ctx, requestID)
+}
+
+// RequestID returns the request ID carried by the context, empty if none
+func RequestID(ctx context.Context) string {
+	requestID, _ := requestIDKey.Value(ctx)
+	return requestID
+}
+
+// WithMetadata returns a context carrying the metadata merged with the metadata already carried by ctx
+func WithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	merged := make(map[string]string)
+	for k, v := range Metadata(ctx) {
+		merged[k] = v
+	}
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	return metadataKey.WithValue(ctx, merged)
+}
+
+// Metadata returns a copy of the metadata carried by the context, nil if none
+func Metadata(ctx context.Context) map[string]string {
+	metadata, ok := metadataKey.Value(ctx)
+	if !ok {
+		return nil
+	}
+	copied := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,61 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ctxkeys ...
+package ctxkeys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	key := NewKey[int]("attempts")
+	same := NewKey[int]("attempts")
+	assert.Equal(t, "attempts", key.String())
+
+	ctx := key.WithValue(context.Background(), 3)
+	value, ok := key.Value(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	// keys with the same name do not collide
+	_, ok = same.Value(ctx)
+	assert.False(t, ok)
+
+	//nolint:staticcheck // nil context is tolerated by the accessors
+	_, ok = key.Value(nil)
+	assert.False(t, ok)
+}
+
+func TestRequestIDAndMetadata(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, RequestID(ctx))
+	assert.Nil(t, Metadata(ctx))
+
+	ctx = WithRequestID(ctx, "req-1")
+	assert.Equal(t, "req-1", RequestID(ctx))
+
+	ctx = WithMetadata(ctx, map[string]string{"pvc": "data", "namespace": "default"})
+	ctx = WithMetadata(ctx, map[string]string{"pvc": "logs"})
+	metadata := Metadata(ctx)
+	assert.Equal(t, map[string]string{"pvc": "logs", "namespace": "default"}, metadata)
+
+	metadata["pvc"] = "changed"
+	assert.Equal(t, "logs", Metadata(ctx)["pvc"])
+}
@@ -0,0 +1,210 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package deprecation reports the use of deprecated config keys, interfaces and providers, giving platform teams
+// machine-readable signals to plan their migrations instead of discovering the removals at upgrade time
+package deprecation
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kind is the kind of deprecated item
+type Kind string
+
+const (
+	// KindConfigKey is a config key, named by its dotted TOML path (e.g. bluemix.refresh_token)
+	KindConfigKey = Kind("config_key")
+	// KindInterface is an exported Go identifier or method
+	KindInterface = Kind("interface")
+	// KindProvider is a storage provider
+	KindProvider = Kind("provider")
+)
+
+// Notice describes a deprecated item
+type Notice struct {
+	Kind        Kind   `json:"kind"`
+	Name        string `json:"name"`
+	Message     string `json:"message"`
+	Replacement string `json:"replacement,omitempty"`
+
+	// RemovedIn is the release planned to remove the item, empty if not scheduled yet
+	RemovedIn string `json:"removed_in,omitempty"`
+}
+
+// Usage is a Notice with the uses recorded at runtime
+type Usage struct {
+	Notice
+	Count     int       `json:"count"`
+	FirstUsed time.Time `json:"first_used,omitempty"`
+	LastUsed  time.Time `json:"last_used,omitempty"`
+}
+
+// Hook is invoked on the first use of each deprecated item, the later uses are only counted
+type Hook func(Usage)
+
+// Known are the deprecations of this library, registered in the default registry
+var Known = []Notice{
+	{
+		Kind:        KindProvider,
+		Name:        "softlayer",
+		Message:     "classic infrastructure (Softlayer) block and file providers are deprecated",
+		Replacement: "VPC block and file providers",
+	},
+	{
+		Kind:        KindConfigKey,
+		Name:        "softlayer",
+		Message:     "the softlayer section configures the deprecated classic infrastructure providers",
+		Replacement: "vpc",
+	},
+	{
+		Kind:        KindConfigKey,
+		Name:        "bluemix.refresh_token",
+		Message:     "refresh token authentication is deprecated",
+		Replacement: "bluemix.iam_api_key or bluemix.iam_trusted_profile_id",
+	},
+	{
+		Kind:        KindInterface,
+		Name:        "provider.RequestID",
+		Message:     "the provider.RequestID context key is deprecated",
+		Replacement: "ctxkeys.WithRequestID and ctxkeys.RequestID",
+	},
+	{
+		Kind:        KindInterface,
+		Name:        "auth.ContextCredentialsFactory.ForRefreshToken",
+		Message:     "refresh token authentication is deprecated",
+		Replacement: "auth.ContextCredentialsFactory.ForIAMAPIKey",
+	},
+}
+
+type key struct {
+	kind Kind
+	name string
+}
+
+// Registry records the uses of the registered deprecations and notifies its hooks
+type Registry struct {
+	mu     sync.Mutex
+	usages map[key]*Usage
+	hooks  []Hook
+	now    func() time.Time
+}
+
+// NewRegistry returns a registry of the given deprecations
+func NewRegistry(notices ...Notice) *Registry {
+	r := &Registry{usages: map[key]*Usage{}, now: time.Now}
+	for _, n := range notices {
+		r.Register(n)
+	}
+	return r
+}
+
+// Register adds a deprecation, replacing the notice of an existing one but keeping its uses
+func (r *Registry) Register(n Notice) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := key{n.Kind, n.Name}
+	if u, ok := r.usages[k]; ok {
+		u.Notice = n
+		return
+	}
+	r.usages[k] = &Usage{Notice: n}
+}
+
+// OnUse adds a hook invoked on the first use of each deprecated item
+func (r *Registry) OnUse(hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook)
+}
+
+// Use records a use of the deprecated item and returns whether it is the first one.
+// An item which is not registered is recorded with an empty message, so no use goes unreported
+func (r *Registry) Use(kind Kind, name string) bool {
+	r.mu.Lock()
+	k := key{kind, name}
+	u, ok := r.usages[k]
+	if !ok {
+		u = &Usage{Notice: Notice{Kind: kind, Name: name}}
+		r.usages[k] = u
+	}
+	now := r.now()
+	u.Count++
+	u.LastUsed = now
+	first := u.Count == 1
+	if first {
+		u.FirstUsed = now
+	}
+	usage := *u
+	hooks := append([]Hook(nil), r.hooks...)
+	r.mu.Unlock()
+
+	// The hooks run unlocked, they may report further deprecations
+	if first {
+		for _, hook := range hooks {
+			hook(usage)
+		}
+	}
+	return first
+}
+
+// Deprecations returns the report of all the deprecations, used or not, sorted by kind and name
+func (r *Registry) Deprecations() []Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := make([]Usage, 0, len(r.usages))
+	for _, u := range r.usages {
+		report = append(report, *u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Kind != report[j].Kind {
+			return report[i].Kind < report[j].Kind
+		}
+		return report[i].Name < report[j].Name
+	})
+	return report
+}
+
+// Used returns the report of the deprecations used so far
+func (r *Registry) Used() []Usage {
+	used := []Usage{}
+	for _, u := range r.Deprecations() {
+		if u.Count > 0 {
+			used = append(used, u)
+		}
+	}
+	return used
+}
+
+// Default is the registry the library reports its deprecations to
+var Default = NewRegistry(Known...)
+
+// OnUse adds a hook to the default registry
+func OnUse(hook Hook) {
+	Default.OnUse(hook)
+}
+
+// Use records a use of a deprecated item in the default registry
+func Use(kind Kind, name string) bool {
+	return Default.Use(kind, name)
+}
+
+// Deprecations returns the report of the default registry
+func Deprecations() []Usage {
+	return Default.Deprecations()
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deprecation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryUse(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRegistry(Known...)
+	r.now = func() time.Time { return now }
+
+	var notified []Usage
+	r.OnUse(func(u Usage) { notified = append(notified, u) })
+
+	assert.True(t, r.Use(KindProvider, "softlayer"))
+	now = now.Add(time.Minute)
+	assert.False(t, r.Use(KindProvider, "softlayer"))
+
+	// The hooks are notified on the first use only
+	if assert.Len(t, notified, 1) {
+		assert.Equal(t, "softlayer", notified[0].Name)
+		assert.Equal(t, "VPC block and file providers", notified[0].Replacement)
+		assert.Equal(t, 1, notified[0].Count)
+	}
+
+	used := r.Used()
+	if assert.Len(t, used, 1) {
+		assert.Equal(t, 2, used[0].Count)
+		assert.Equal(t, now.Add(-time.Minute), used[0].FirstUsed)
+		assert.Equal(t, now, used[0].LastUsed)
+	}
+}
+
+func TestRegistryUnregistered(t *testing.T) {
+	r := NewRegistry()
+	assert.True(t, r.Use(KindInterface, "provider.Legacy"))
+	used := r.Used()
+	if assert.Len(t, used, 1) {
+		assert.Equal(t, KindInterface, used[0].Kind)
+		assert.Empty(t, used[0].Message)
+	}
+
+	// Registering afterwards keeps the recorded uses
+	r.Register(Notice{Kind: KindInterface, Name: "provider.Legacy", Message: "legacy"})
+	used = r.Used()
+	if assert.Len(t, used, 1) {
+		assert.Equal(t, "legacy", used[0].Message)
+		assert.Equal(t, 1, used[0].Count)
+	}
+}
+
+func TestRegistryDeprecations(t *testing.T) {
+	r := NewRegistry(Known...)
+	report := r.Deprecations()
+	assert.Len(t, report, len(Known))
+	assert.Empty(t, r.Used())
+	for i := 1; i < len(report); i++ {
+		prev, cur := report[i-1], report[i]
+		assert.True(t, prev.Kind < cur.Kind || (prev.Kind == cur.Kind && prev.Name < cur.Name))
+	}
+
+	// The report is machine-readable
+	data, err := json.Marshal(report[0])
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"kind":"config_key"`)
+	assert.Contains(t, string(data), `"count":0`)
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logging defines the minimal Logger interface of the library, with no dependency,
+// so consumers can supply their own logger. Build with -tags minimal to exclude zap
+package logging
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Field is a structured logging key value pair
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F returns a Field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Logger is the logging interface of the library
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+type nopLogger struct{}
+
+// Nop returns a Logger discarding all entries
+func Nop() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(msg string, fields ...Field) {}
+func (nopLogger) Info(msg string, fields ...Field)  {}
+func (nopLogger) Warn(msg string, fields ...Field)  {}
+func (nopLogger) Error(msg string, fields ...Field) {}
+
+type stdLogger struct {
+	logger *log.Logger
+	debug  bool
+}
+
+// NewStdLogger returns a Logger writing to the standard library logger, debug entries are dropped unless debug is set
+func NewStdLogger(logger *log.Logger, debug bool) Logger {
+	return &stdLogger{logger: logger, debug: debug}
+}
+
+func (l *stdLogger) Debug(msg string, fields ...Field) {
+	if l.debug {
+		l.print("DEBUG", msg, fields)
+	}
+}
+
+func (l *stdLogger) Info(msg string, fields ...Field)  { l.print("INFO", msg, fields) }
+func (l *stdLogger) Warn(msg string, fields ...Field)  { l.print("WARN", msg, fields) }
+func (l *stdLogger) Error(msg string, fields ...Field) { l.print("ERROR", msg, fields) }
+
+func (l *stdLogger) print(level, msg string, fields []Field) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for _, field := range fields {
+		fmt.Fprintf(&b, " %s=%v", field.Key, field.Value)
+	}
+	l.logger.Print(b.String())
+}
@@ -0,0 +1,41 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package logging ...
+package logging
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewStdLogger(log.New(buf, "", 0), false)
+	logger.Debug("hidden")
+	logger.Info("volume created", F("VolumeID", "vol-1"), F("Capacity", 10))
+	logger.Error("attach failed")
+	assert.Equal(t, "INFO volume created VolumeID=vol-1 Capacity=10\nERROR attach failed\n", buf.String())
+
+	buf.Reset()
+	NewStdLogger(log.New(buf, "", 0), true).Debug("shown")
+	assert.Equal(t, "DEBUG shown\n", buf.String())
+
+	Nop().Error("discarded")
+}
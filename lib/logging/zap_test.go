@@ -1,5 +1,8 @@
+//go:build !minimal
+// +build !minimal
+
 /**
- * Copyright 2020 IBM Corp.
+ * Copyright 2022 IBM Corp.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -14,21 +17,25 @@
  * limitations under the License.
  */
 
-// Package auth ...
-package auth
+// Package logging ...
+package logging
 
 import (
-	"go.uber.org/zap"
+	"testing"
 
-	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
-// ForIaaSAPIKey ...
-func (ccf *ContextCredentialsFactory) ForIaaSAPIKey(iamAccountID, userid, apikey string, logger *zap.Logger) (provider.ContextCredentials, error) {
-	return provider.ContextCredentials{
-		AuthType:     provider.IaaSAPIKey,
-		IAMAccountID: iamAccountID,
-		UserID:       userid,
-		Credential:   apikey,
-	}, nil
+func TestFromZap(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := FromZap(zap.New(core))
+	logger.Warn("slow request", F("Latency", "3s"))
+
+	entries := logs.All()
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, "slow request", entries[0].Message)
+		assert.Equal(t, "3s", entries[0].ContextMap()["Latency"])
+	}
 }
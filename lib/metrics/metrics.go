@@ -57,6 +57,46 @@ var (
 			Help:      "The number of library operation  failed due to an error.",
 		}, []string{"type"},
 	)
+
+	functionFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: pluginNamespace,
+			Name:      "functions_failed_total",
+			Help:      "The number of library operation failed, by operation.",
+		}, []string{"function"},
+	)
+
+	abandonedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: pluginNamespace,
+			Name:      "operations_abandoned_total",
+			Help:      "The number of library operation abandoned after exceeding the maximum operation timeout.",
+		}, []string{"function"},
+	)
+
+	endpointHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: pluginNamespace,
+			Name:      "endpoint_healthy",
+			Help:      "Whether the last health probe of the endpoint succeeded (1) or failed (0).",
+		}, []string{"endpoint"},
+	)
+
+	endpointSwitches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: pluginNamespace,
+			Name:      "endpoint_switches_total",
+			Help:      "The number of failovers and failbacks between endpoints.",
+		}, []string{"from", "to"},
+	)
+
+	latencyBreakdown = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: pluginNamespace,
+			Name:      "operation_latency_breakdown_seconds",
+			Help:      "Time spent by the last library operation in each layer (auth, rate limit, HTTP, backend wait).",
+		}, []string{"function", "layer"},
+	)
 )
 
 // RegisterAll registers all metrics.
@@ -64,6 +104,16 @@ func RegisterAll() {
 	prometheus.MustRegister(functionDuration)
 	prometheus.MustRegister(functionCount)
 	prometheus.MustRegister(errorsCount)
+	prometheus.MustRegister(functionFailures)
+	prometheus.MustRegister(abandonedCount)
+	prometheus.MustRegister(latencyBreakdown)
+	prometheus.MustRegister(endpointHealthy)
+	prometheus.MustRegister(endpointSwitches)
+	prometheus.MustRegister(payloadSize)
+	prometheus.MustRegister(largePayloads)
+	prometheus.MustRegister(rateLimiterWait)
+	prometheus.MustRegister(rateLimiterThrottled)
+	prometheus.MustRegister(defaultRecorder.Collectors()...)
 }
 
 // UpdateDurationFromStart records the duration of the step identified by the
@@ -91,3 +141,69 @@ func RegisterError(errType string, err error) {
 func RegisterFunction(label string) {
 	functionCount.WithLabelValues(label).Add(1.0)
 }
+
+// RecordOperation records the duration and the result of a completed operation identified by the label. Every
+// operation is counted by functions_total, the failed ones also by functions_failed_total. The duration is
+// recorded by the Recorder only (operation_latency_seconds by default), function_duration_seconds is the gauge
+// of UpdateDuration
+func RecordOperation(label string, start time.Time, err error) {
+	duration := time.Since(start)
+	functionCount.WithLabelValues(label).Add(1.0)
+	if err != nil {
+		functionFailures.WithLabelValues(label).Add(1.0)
+	}
+	Recorder().ObserveOperation(label, duration, ReasonCodeOf(err))
+	recordSummary(label, duration, err)
+}
+
+// RegisterAbandonedOperation records an operation abandoned after exceeding the maximum operation timeout
+func RegisterAbandonedOperation(label string) {
+	abandonedCount.WithLabelValues(label).Add(1.0)
+}
+
+// RecordLatencyBreakdown records the time spent by the operation in each layer
+func RecordLatencyBreakdown(label string, layers map[string]time.Duration) {
+	for layer, duration := range layers {
+		latencyBreakdown.WithLabelValues(label, layer).Set(duration.Seconds())
+	}
+}
+
+// SetEndpointHealth records the result of the last health probe of the endpoint
+func SetEndpointHealth(endpoint string, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1.0
+	}
+	endpointHealthy.WithLabelValues(endpoint).Set(value)
+}
+
+// RegisterEndpointSwitch records a failover or failback between endpoints
+func RegisterEndpointSwitch(from, to string) {
+	endpointSwitches.WithLabelValues(from, to).Add(1.0)
+}
+
+// VolumeExemplar returns the exemplar labels identifying the volume and the operation of a sample
+func VolumeExemplar(operation, volumeID string) prometheus.Labels {
+	return prometheus.Labels{"operation": operation, "volume_id": volumeID}
+}
+
+// RecordOperationWithExemplar is RecordOperation attaching the exemplar (e.g. VolumeExemplar) to the
+// recorded counters, so that aggregated dashboards can be drilled into specific volumes
+func RecordOperationWithExemplar(label string, start time.Time, err error, exemplar prometheus.Labels) {
+	duration := time.Since(start)
+	addWithExemplar(functionCount.WithLabelValues(label), exemplar)
+	if err != nil {
+		addWithExemplar(functionFailures.WithLabelValues(label), exemplar)
+	}
+	Recorder().ObserveOperation(label, duration, ReasonCodeOf(err))
+	recordSummary(label, duration, err)
+}
+
+// addWithExemplar increments the counter, with the exemplar if any and supported by the counter
+func addWithExemplar(counter prometheus.Counter, exemplar prometheus.Labels) {
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok && len(exemplar) > 0 {
+		adder.AddWithExemplar(1.0, exemplar)
+		return
+	}
+	counter.Add(1.0)
+}
@@ -0,0 +1,50 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordOperationWithExemplar(t *testing.T) {
+	before := testutil.ToFloat64(functionCount.WithLabelValues("ExemplarTest"))
+	RecordOperationWithExemplar("ExemplarTest", time.Now(), nil, VolumeExemplar("CreateVolume", "vol-1"))
+	assert.Equal(t, before+1, testutil.ToFloat64(functionCount.WithLabelValues("ExemplarTest")))
+
+	// the failed operations are counted in both counters, not as an error type
+	beforeFailures := testutil.ToFloat64(functionFailures.WithLabelValues("ExemplarTest"))
+	beforeErrors := testutil.ToFloat64(errorsCount.WithLabelValues("ExemplarTest"))
+	RecordOperationWithExemplar("ExemplarTest", time.Now(), errors.New("failed"), nil)
+	assert.Equal(t, before+2, testutil.ToFloat64(functionCount.WithLabelValues("ExemplarTest")))
+	assert.Equal(t, beforeFailures+1, testutil.ToFloat64(functionFailures.WithLabelValues("ExemplarTest")))
+	assert.Equal(t, beforeErrors, testutil.ToFloat64(errorsCount.WithLabelValues("ExemplarTest")))
+}
+
+func TestRecordOperation(t *testing.T) {
+	before := testutil.ToFloat64(functionCount.WithLabelValues("RecordTest"))
+	beforeFailures := testutil.ToFloat64(functionFailures.WithLabelValues("RecordTest"))
+	RecordOperation("RecordTest", time.Now(), nil)
+	RecordOperation("RecordTest", time.Now(), errors.New("failed"))
+	assert.Equal(t, before+2, testutil.ToFloat64(functionCount.WithLabelValues("RecordTest")))
+	assert.Equal(t, beforeFailures+1, testutil.ToFloat64(functionFailures.WithLabelValues("RecordTest")))
+}
@@ -0,0 +1,166 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	// DirectionRequest labels the outbound request payloads
+	DirectionRequest = "request"
+	// DirectionResponse labels the inbound response payloads
+	DirectionResponse = "response"
+
+	// DefaultLargePayloadThreshold is the payload size above which a warning is logged, in bytes
+	DefaultLargePayloadThreshold = 1 << 20
+)
+
+var (
+	payloadSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: pluginNamespace,
+			Name:      "payload_bytes",
+			Help:      "Size of the request and response payloads of the library operations.",
+			Buckets:   prometheus.ExponentialBuckets(256, 4, 9), // 256B to 16MiB
+		}, []string{"function", "direction"},
+	)
+
+	largePayloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: pluginNamespace,
+			Name:      "large_payloads_total",
+			Help:      "The number of payloads larger than the large payload threshold.",
+		}, []string{"function", "direction"},
+	)
+
+	largePayloadThreshold int64 = DefaultLargePayloadThreshold
+)
+
+// SetLargePayloadThreshold sets the payload size above which a warning is logged, a non-positive size disables the warnings
+func SetLargePayloadThreshold(bytes int64) {
+	atomic.StoreInt64(&largePayloadThreshold, bytes)
+}
+
+// RecordPayloadSize records the size of a request or response payload of the operation identified by the label.
+// It returns whether the payload is larger than the large payload threshold, which is then logged as a warning
+func RecordPayloadSize(logger *zap.Logger, label, direction string, bytes int64) bool {
+	if bytes < 0 {
+		return false
+	}
+	payloadSize.WithLabelValues(label, direction).Observe(float64(bytes))
+	threshold := atomic.LoadInt64(&largePayloadThreshold)
+	if threshold <= 0 || bytes <= threshold {
+		return false
+	}
+	largePayloads.WithLabelValues(label, direction).Add(1.0)
+	if logger != nil {
+		logger.Warn("Large payload", zap.String("function", label), zap.String("direction", direction),
+			zap.Int64("bytes", bytes), zap.Int64("threshold", threshold))
+	}
+	return true
+}
+
+// PayloadTransport records the request and response payload sizes of the requests sent through Base, e.g. the
+// IAM token exchanges. The clients of the providers opt in by wrapping their transport:
+//
+//	client := &http.Client{Transport: &metrics.PayloadTransport{Base: transport, Logger: logger}}
+type PayloadTransport struct {
+	// Base is the transport sending the requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+
+	// Label returns the label of the request, RequestLabel if nil
+	Label func(*http.Request) string
+
+	// Logger logs the large payload warnings, none are logged if nil
+	Logger *zap.Logger
+}
+
+var _ http.RoundTripper = &PayloadTransport{}
+
+// RoundTrip ...
+func (t *PayloadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	labelOf := t.Label
+	if labelOf == nil {
+		labelOf = RequestLabel
+	}
+	label := labelOf(req)
+
+	// The size of streamed request bodies is unknown (-1) and not recorded
+	if req.Body != nil && req.Body != http.NoBody {
+		RecordPayloadSize(t.Logger, label, DirectionRequest, req.ContentLength)
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	// The response is counted as read, the content length is unknown for chunked and compressed responses
+	resp.Body = &countingBody{ReadCloser: resp.Body, done: func(bytes int64) {
+		RecordPayloadSize(t.Logger, label, DirectionResponse, bytes)
+	}}
+	return resp, nil
+}
+
+// countingBody counts the bytes read and reports them once, at EOF or close
+type countingBody struct {
+	io.ReadCloser
+	bytes int64
+	once  sync.Once
+	done  func(int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.bytes += int64(n)
+	if err == io.EOF {
+		b.once.Do(func() { b.done(b.bytes) })
+	}
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() { b.done(b.bytes) })
+	return b.ReadCloser.Close()
+}
+
+// resourceSegment matches the path segments naming a collection (e.g. volume_attachments) or an API version
+var resourceSegment = regexp.MustCompile(`^([a-z_]+|v[0-9]+)$`)
+
+// RequestLabel labels the request with its method and path, the resource IDs of the path replaced by {id}
+// to bound the cardinality, e.g. GET /v1/instances/{id}/volume_attachments
+func RequestLabel(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "" && !resourceSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return req.Method + " /" + strings.Join(segments, "/")
+}
@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRequestLabel(t *testing.T) {
+	testCases := []struct {
+		testcasename string
+		method       string
+		url          string
+		expected     string
+	}{
+		{testcasename: "Collection", method: http.MethodGet, url: "https://us-south.iaas.cloud.ibm.com/v1/volumes?limit=50", expected: "GET /v1/volumes"},
+		{testcasename: "Resource", method: http.MethodDelete, url: "https://host/v1/volumes/r006-1234", expected: "DELETE /v1/volumes/{id}"},
+		{testcasename: "Sub collection", method: http.MethodPost, url: "https://host/v1/instances/0717_abc/volume_attachments", expected: "POST /v1/instances/{id}/volume_attachments"},
+	}
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			req := httptest.NewRequest(testcase.method, testcase.url, nil)
+			assert.Equal(t, testcase.expected, RequestLabel(req))
+		})
+	}
+}
+
+func TestRecordPayloadSize(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	SetLargePayloadThreshold(100)
+	defer SetLargePayloadThreshold(DefaultLargePayloadThreshold)
+
+	before := testutil.ToFloat64(largePayloads.WithLabelValues("PayloadTest", DirectionResponse))
+	assert.False(t, RecordPayloadSize(logger, "PayloadTest", DirectionResponse, 10))
+	assert.True(t, RecordPayloadSize(logger, "PayloadTest", DirectionResponse, 1000))
+	assert.False(t, RecordPayloadSize(logger, "PayloadTest", DirectionResponse, -1))
+	assert.Equal(t, before+1, testutil.ToFloat64(largePayloads.WithLabelValues("PayloadTest", DirectionResponse)))
+
+	SetLargePayloadThreshold(0)
+	assert.False(t, RecordPayloadSize(logger, "PayloadTest", DirectionResponse, 1000))
+}
+
+func TestPayloadTransport(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	SetLargePayloadThreshold(1024)
+	defer SetLargePayloadThreshold(DefaultLargePayloadThreshold)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer server.Close()
+
+	label := func(*http.Request) string { return "TransportTest" }
+	client := &http.Client{Transport: &PayloadTransport{Label: label, Logger: logger}}
+	beforeRequest := testutil.ToFloat64(largePayloads.WithLabelValues("TransportTest", DirectionRequest))
+	beforeResponse := testutil.ToFloat64(largePayloads.WithLabelValues("TransportTest", DirectionResponse))
+
+	resp, err := client.Post(server.URL+"/v1/volumes", "application/json", strings.NewReader(`{"name":"vol-1"}`))
+	assert.Nil(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Len(t, body, 2048)
+	assert.Nil(t, resp.Body.Close())
+
+	// Only the response is above the threshold, and it is reported once
+	assert.Equal(t, beforeRequest, testutil.ToFloat64(largePayloads.WithLabelValues("TransportTest", DirectionRequest)))
+	assert.Equal(t, beforeResponse+1, testutil.ToFloat64(largePayloads.WithLabelValues("TransportTest", DirectionResponse)))
+	assert.GreaterOrEqual(t, testutil.CollectAndCount(payloadSize), 2)
+}
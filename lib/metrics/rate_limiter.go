@@ -0,0 +1,51 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	rateLimiterWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: pluginNamespace,
+			Name:      "rate_limiter_wait_seconds",
+			Help:      "Time the provider API calls waited on the client side rate limiter, by operation class.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"class"},
+	)
+
+	rateLimiterThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: pluginNamespace,
+			Name:      "rate_limiter_throttled_total",
+			Help:      "The number of provider API calls delayed by the client side rate limiter, by operation class.",
+		}, []string{"class"},
+	)
+)
+
+// RecordRateLimiterWait records the time a call of the operation class (read, mutate) waited on the rate limiter
+func RecordRateLimiterWait(class string, wait time.Duration) {
+	rateLimiterWait.WithLabelValues(class).Observe(wait.Seconds())
+	if wait > 0 {
+		rateLimiterThrottled.WithLabelValues(class).Inc()
+	}
+}
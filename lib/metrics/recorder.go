@@ -0,0 +1,164 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricsRecorder records the operation, authentication and retry metrics of the library.
+// Use SetRecorder to record them with another backend than Prometheus
+type MetricsRecorder interface {
+	// ObserveOperation records the latency of a completed operation and, if it failed, the reason code of its error
+	ObserveOperation(operation string, duration time.Duration, reasonCode string)
+
+	// ObserveTokenExchange records the duration of an IAM token exchange, e.g. of an API key for an access token
+	ObserveTokenExchange(exchange string, duration time.Duration, failed bool)
+
+	// IncRetry records a retry of the operation
+	IncRetry(operation string)
+}
+
+// PrometheusRecorder is the MetricsRecorder recording Prometheus histograms and counters
+type PrometheusRecorder struct {
+	operationLatency      *prometheus.HistogramVec
+	operationErrors       *prometheus.CounterVec
+	tokenExchangeDuration *prometheus.HistogramVec
+	retries               *prometheus.CounterVec
+}
+
+var _ MetricsRecorder = &PrometheusRecorder{}
+
+// NewPrometheusRecorder returns a recorder of unregistered collectors, see Collectors
+func NewPrometheusRecorder() *PrometheusRecorder {
+	return &PrometheusRecorder{
+		operationLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: pluginNamespace,
+				Name:      "operation_latency_seconds",
+				Help:      "Latency of the library operations.",
+				Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+			}, []string{"operation"},
+		),
+		operationErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: pluginNamespace,
+				Name:      "operation_errors_total",
+				Help:      "The number of library operations failed, by reason code.",
+			}, []string{"operation", "reason_code"},
+		),
+		tokenExchangeDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: pluginNamespace,
+				Name:      "iam_token_exchange_duration_seconds",
+				Help:      "Duration of the IAM token exchanges.",
+				Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			}, []string{"exchange", "result"},
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: pluginNamespace,
+				Name:      "retries_total",
+				Help:      "The number of retries of the library operations.",
+			}, []string{"operation"},
+		),
+	}
+}
+
+// Collectors returns the collectors of the recorder, to register
+func (r *PrometheusRecorder) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{r.operationLatency, r.operationErrors, r.tokenExchangeDuration, r.retries}
+}
+
+// ObserveOperation ...
+func (r *PrometheusRecorder) ObserveOperation(operation string, duration time.Duration, reasonCode string) {
+	r.operationLatency.WithLabelValues(operation).Observe(duration.Seconds())
+	if reasonCode != "" {
+		r.operationErrors.WithLabelValues(operation, reasonCode).Add(1.0)
+	}
+}
+
+// ObserveTokenExchange ...
+func (r *PrometheusRecorder) ObserveTokenExchange(exchange string, duration time.Duration, failed bool) {
+	result := "success"
+	if failed {
+		result = "failure"
+	}
+	r.tokenExchangeDuration.WithLabelValues(exchange, result).Observe(duration.Seconds())
+}
+
+// IncRetry ...
+func (r *PrometheusRecorder) IncRetry(operation string) {
+	r.retries.WithLabelValues(operation).Add(1.0)
+}
+
+// NopRecorder discards the metrics
+type NopRecorder struct{}
+
+// ObserveOperation ...
+func (NopRecorder) ObserveOperation(operation string, duration time.Duration, reasonCode string) {}
+
+// ObserveTokenExchange ...
+func (NopRecorder) ObserveTokenExchange(exchange string, duration time.Duration, failed bool) {}
+
+// IncRetry ...
+func (NopRecorder) IncRetry(operation string) {}
+
+var (
+	// defaultRecorder is registered by RegisterAll
+	defaultRecorder = NewPrometheusRecorder()
+
+	recorderMu     sync.RWMutex
+	activeRecorder MetricsRecorder = defaultRecorder
+)
+
+// SetRecorder replaces the recorder of the library metrics, a nil recorder discards them
+func SetRecorder(recorder MetricsRecorder) {
+	if recorder == nil {
+		recorder = NopRecorder{}
+	}
+	recorderMu.Lock()
+	activeRecorder = recorder
+	recorderMu.Unlock()
+}
+
+// Recorder returns the recorder of the library metrics, the Prometheus recorder registered by RegisterAll by default
+func Recorder() MetricsRecorder {
+	recorderMu.RLock()
+	defer recorderMu.RUnlock()
+	return activeRecorder
+}
+
+// ReasonCodeOf returns the reason code of the error for ObserveOperation, empty if err is nil
+func ReasonCodeOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	var coded interface{ Code() reasoncode.ReasonCode }
+	if errors.As(err, &coded) {
+		if code := coded.Code(); code != "" {
+			return string(code)
+		}
+	}
+	return string(reasoncode.ErrorUnclassified)
+}
@@ -0,0 +1,88 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// capturingRecorder records the observed operations
+type capturingRecorder struct {
+	NopRecorder
+	mu         sync.Mutex
+	operations map[string]string
+}
+
+func (r *capturingRecorder) ObserveOperation(operation string, duration time.Duration, reasonCode string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.operations[operation] = reasonCode
+}
+
+type codedError struct{ code reasoncode.ReasonCode }
+
+func (e codedError) Error() string               { return string(e.code) }
+func (e codedError) Code() reasoncode.ReasonCode { return e.code }
+
+func TestSetRecorder(t *testing.T) {
+	recorder := &capturingRecorder{operations: map[string]string{}}
+	SetRecorder(recorder)
+	defer SetRecorder(defaultRecorder)
+	assert.Equal(t, recorder, Recorder())
+
+	durations := testutil.CollectAndCount(functionDuration)
+	RecordOperation("RecorderSuccess", time.Now(), nil)
+	RecordOperation("RecorderFailure", time.Now(), codedError{code: reasoncode.ErrorQuotaExceeded})
+	assert.Equal(t, map[string]string{"RecorderSuccess": "", "RecorderFailure": "ErrorQuotaExceeded"}, recorder.operations)
+
+	// the duration is only recorded by the recorder
+	assert.Equal(t, durations, testutil.CollectAndCount(functionDuration))
+
+	SetRecorder(nil)
+	assert.Equal(t, NopRecorder{}, Recorder())
+}
+
+func TestReasonCodeOf(t *testing.T) {
+	assert.Equal(t, "", ReasonCodeOf(nil))
+	assert.Equal(t, "ErrorUnclassified", ReasonCodeOf(errors.New("failed")))
+	assert.Equal(t, "ErrorResourceNotFound", ReasonCodeOf(fmt.Errorf("get: %w", codedError{code: reasoncode.ErrorResourceNotFound})))
+}
+
+func TestPrometheusRecorder(t *testing.T) {
+	recorder := NewPrometheusRecorder()
+	assert.Len(t, recorder.Collectors(), 4)
+
+	recorder.ObserveOperation("CreateVolume", time.Second, "")
+	recorder.ObserveOperation("CreateVolume", time.Second, "ErrorQuotaExceeded")
+	recorder.ObserveTokenExchange("access_token", time.Second, false)
+	recorder.IncRetry("IAMTokenExchange")
+	recorder.IncRetry("IAMTokenExchange")
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.operationErrors.WithLabelValues("CreateVolume", "ErrorQuotaExceeded")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(recorder.retries.WithLabelValues("IAMTokenExchange")))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.operationLatency))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.tokenExchangeDuration))
+}
@@ -0,0 +1,199 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"go.uber.org/zap"
+)
+
+// operationStats holds the samples of a single operation for the current summary interval
+type operationStats struct {
+	count     int
+	errors    int
+	latencies []time.Duration
+}
+
+// SummaryLogger periodically logs a summary line (ops/min, error rate, p95 latency) per operation.
+// It is intended for deployments without Prometheus
+type SummaryLogger struct {
+	logger   *zap.Logger
+	interval time.Duration
+
+	mu    sync.Mutex
+	stats map[string]*operationStats
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+var (
+	summaryMu     sync.RWMutex
+	activeSummary *SummaryLogger
+)
+
+// NewSummaryLogger returns a SummaryLogger which logs every interval once started
+func NewSummaryLogger(logger *zap.Logger, interval time.Duration) *SummaryLogger {
+	return &SummaryLogger{
+		logger:   logger,
+		interval: interval,
+		stats:    make(map[string]*operationStats),
+		stop:     make(chan struct{}),
+	}
+}
+
+// EnableSummaryLogger starts a SummaryLogger fed by all metrics recorded through this package, the previously
+// enabled one is stopped. A non-positive interval disables it. The returned logger (if any) must be stopped by
+// the caller
+func EnableSummaryLogger(logger *zap.Logger, interval time.Duration) *SummaryLogger {
+	if interval <= 0 {
+		return nil
+	}
+	s := NewSummaryLogger(logger, interval)
+	summaryMu.Lock()
+	previous := activeSummary
+	activeSummary = s
+	summaryMu.Unlock()
+	if previous != nil {
+		previous.Stop()
+	}
+	s.Start()
+	return s
+}
+
+// EnableSummaryLoggerFromConfig starts the SummaryLogger of the metrics_summary_interval server config value,
+// nil if it is not set
+func EnableSummaryLoggerFromConfig(server *config.ServerConfig, logger *zap.Logger) (*SummaryLogger, error) {
+	if server == nil || server.MetricsSummaryInterval == "" {
+		return nil, nil
+	}
+	interval, err := time.ParseDuration(server.MetricsSummaryInterval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid metrics summary interval '%s'", server.MetricsSummaryInterval)
+	}
+	return EnableSummaryLogger(logger, interval), nil
+}
+
+// Start begins the periodic emission of summary lines
+func (s *SummaryLogger) Start() {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Flush()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic emission and detaches the logger from the package level metrics
+func (s *SummaryLogger) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		summaryMu.Lock()
+		if activeSummary == s {
+			activeSummary = nil
+		}
+		summaryMu.Unlock()
+	})
+}
+
+// Record adds one operation sample to the current interval
+func (s *SummaryLogger) Record(label string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.statsFor(label)
+	stats.count++
+	if duration > 0 {
+		stats.latencies = append(stats.latencies, duration)
+	}
+	if err != nil {
+		stats.errors++
+	}
+}
+
+// Flush logs the summary of the current interval and starts a new one
+func (s *SummaryLogger) Flush() {
+	s.mu.Lock()
+	stats := s.stats
+	s.stats = make(map[string]*operationStats)
+	s.mu.Unlock()
+
+	labels := make([]string, 0, len(stats))
+	for label := range stats {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	minutes := s.interval.Minutes()
+	for _, label := range labels {
+		op := stats[label]
+		errorRate := 0.0
+		if op.count > 0 {
+			errorRate = float64(op.errors) / float64(op.count)
+		}
+		s.logger.Info("Library operation summary",
+			zap.String("operation", label),
+			zap.Float64("opsPerMinute", float64(op.count)/minutes),
+			zap.Float64("errorRate", errorRate),
+			zap.Duration("p95Latency", percentile(op.latencies, 0.95)))
+	}
+}
+
+// statsFor returns the stats of the label, the caller must hold s.mu
+func (s *SummaryLogger) statsFor(label string) *operationStats {
+	stats, ok := s.stats[label]
+	if !ok {
+		stats = &operationStats{}
+		s.stats[label] = stats
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the samples
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// recordSummary feeds the active SummaryLogger, if any
+func recordSummary(label string, duration time.Duration, err error) {
+	summaryMu.RLock()
+	s := activeSummary
+	summaryMu.RUnlock()
+	if s != nil {
+		s.Record(label, duration, err)
+	}
+}
@@ -0,0 +1,106 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics ...
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSummaryLoggerFlush(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	s := NewSummaryLogger(zap.New(core), time.Minute)
+
+	for i := 1; i <= 20; i++ {
+		s.Record("CreateVolume", time.Duration(i)*time.Second, nil)
+	}
+	s.Record("CreateVolume", 0, errors.New("failed"))
+	s.Record("AttachVolume", time.Second, nil)
+	s.Flush()
+
+	entries := logs.All()
+	assert.Equal(t, 2, len(entries))
+	fields := entries[1].ContextMap()
+	assert.Equal(t, "CreateVolume", fields["operation"])
+	assert.Equal(t, float64(21), fields["opsPerMinute"])
+	assert.InDelta(t, 1.0/21.0, fields["errorRate"], 0.0001)
+	assert.Equal(t, 19*time.Second, fields["p95Latency"])
+
+	// Samples are reset after each flush
+	s.Flush()
+	assert.Equal(t, 2, len(logs.All()))
+}
+
+func TestEnableSummaryLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	assert.Nil(t, EnableSummaryLogger(zap.New(core), 0))
+
+	s := EnableSummaryLogger(zap.New(core), time.Hour)
+	assert.NotNil(t, s)
+	RecordOperation("DeleteVolume", time.Now(), nil)
+	s.Flush()
+	assert.Equal(t, 1, logs.Len())
+
+	s.Stop()
+	s.Stop()
+	RecordOperation("DeleteVolume", time.Now(), nil)
+	s.Flush()
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestEnableSummaryLoggerStopsPrevious(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	first := EnableSummaryLogger(zap.New(core), time.Hour)
+	second := EnableSummaryLogger(zap.New(core), time.Hour)
+	defer second.Stop()
+
+	select {
+	case <-first.stop:
+	default:
+		t.Fatal("the previous summary logger is not stopped")
+	}
+	summaryMu.RLock()
+	assert.Equal(t, second, activeSummary)
+	summaryMu.RUnlock()
+}
+
+func TestEnableSummaryLoggerFromConfig(t *testing.T) {
+	core, _ := observer.New(zap.InfoLevel)
+	s, err := EnableSummaryLoggerFromConfig(&config.ServerConfig{}, zap.New(core))
+	assert.Nil(t, err)
+	assert.Nil(t, s)
+
+	_, err = EnableSummaryLoggerFromConfig(&config.ServerConfig{MetricsSummaryInterval: "soon"}, zap.New(core))
+	assert.NotNil(t, err)
+
+	s, err = EnableSummaryLoggerFromConfig(&config.ServerConfig{MetricsSummaryInterval: "5m"}, zap.New(core))
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Minute, s.interval)
+	s.Stop()
+}
+
+func TestPercentile(t *testing.T) {
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.95))
+	assert.Equal(t, time.Second, percentile([]time.Duration{time.Second}, 0.95))
+}
@@ -0,0 +1,113 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package node ...
+package node
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+const (
+	// DiskByIDDir is the directory of the udev links naming the block devices by their serial
+	DiskByIDDir = "/dev/disk/by-id"
+
+	// VirtioDevicePrefix is the prefix of the udev links of the virtio block devices
+	VirtioDevicePrefix = "virtio-"
+
+	// VirtioSerialLength is the maximum length of the serial of a virtio block device, the serial of a VPC volume
+	// attachment is its ID truncated to this length
+	VirtioSerialLength = 20
+)
+
+// ErrDeviceMismatch is returned when the device of an attachment is not the device of the intended volume
+var ErrDeviceMismatch = errors.New("device does not match the volume attachment")
+
+// vpcResourceIDPattern matches the VPC resource IDs, a 4 character prefix (e.g. the zone code of an attachment
+// or r006 of a volume) and a UUID
+var vpcResourceIDPattern = regexp.MustCompile(`^[0-9a-z]{4}-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// virtioSerialPattern matches the serials of the VPC attachments, the truncated attachment IDs
+var virtioSerialPattern = regexp.MustCompile(`^[0-9a-z]{4}-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]$`)
+
+// IsVPCResourceID returns true if the ID has the format of the VPC resource IDs
+func IsVPCResourceID(id string) bool {
+	return vpcResourceIDPattern.MatchString(id)
+}
+
+// VPCDeviceSerial returns the virtio serial of the block device of the VPC volume attachment
+func VPCDeviceSerial(attachmentID string) (string, error) {
+	if !IsVPCResourceID(attachmentID) {
+		return "", fmt.Errorf("'%s' is not a VPC volume attachment ID", attachmentID)
+	}
+	return attachmentID[:VirtioSerialLength], nil
+}
+
+// VPCDeviceIDPath returns the expected /dev/disk/by-id link of the block device of the VPC volume attachment,
+// e.g. /dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4 for the attachment 0717-a6d5b3a1-8a2e-4b9f-b0a3-5c8d9e0f1a2b
+func VPCDeviceIDPath(attachment provider.VolumeAttachmentResponse) (string, error) {
+	if attachment.VPCVolumeAttachment == nil {
+		return "", fmt.Errorf("the attachment of volume %s has no VPC volume attachment", attachment.VolumeID)
+	}
+	serial, err := VPCDeviceSerial(attachment.VPCVolumeAttachment.ID)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(DiskByIDDir, VirtioDevicePrefix+serial), nil
+}
+
+// ParseVPCDeviceID returns the serial of the /dev/disk/by-id link (or link name) of the block device of a VPC
+// volume attachment. Partition links (-part1) are rejected, the node plugins format whole devices
+func ParseVPCDeviceID(deviceID string) (string, error) {
+	dir, name := path.Split(deviceID)
+	if dir != "" && path.Clean(dir) != DiskByIDDir {
+		return "", fmt.Errorf("'%s' is not a link of %s", deviceID, DiskByIDDir)
+	}
+	if !strings.HasPrefix(name, VirtioDevicePrefix) {
+		return "", fmt.Errorf("'%s' is not a virtio device", deviceID)
+	}
+	serial := strings.TrimPrefix(name, VirtioDevicePrefix)
+	if !virtioSerialPattern.MatchString(serial) {
+		return "", fmt.Errorf("'%s' is not the device of a VPC volume attachment", deviceID)
+	}
+	return serial, nil
+}
+
+// ValidateVPCDevice returns an error wrapping ErrDeviceMismatch unless the /dev/disk/by-id link is the device of
+// the attachment of the volume, the node plugins call it before formatting the device
+func ValidateVPCDevice(deviceID string, volumeID string, attachment provider.VolumeAttachmentResponse) error {
+	if attachment.VolumeID != volumeID {
+		return fmt.Errorf("%w: the attachment is of volume %s, not %s", ErrDeviceMismatch, attachment.VolumeID, volumeID)
+	}
+	serial, err := ParseVPCDeviceID(deviceID)
+	if err != nil {
+		return err
+	}
+	expected, err := VPCDeviceIDPath(attachment)
+	if err != nil {
+		return err
+	}
+	if VirtioDevicePrefix+serial != path.Base(expected) {
+		return fmt.Errorf("%w: device %s of volume %s is expected at %s", ErrDeviceMismatch, deviceID, volumeID, expected)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package node ...
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func vpcAttachment(volumeID, attachmentID string) provider.VolumeAttachmentResponse {
+	return provider.VolumeAttachmentResponse{VolumeAttachmentRequest: provider.VolumeAttachmentRequest{
+		VolumeID:            volumeID,
+		InstanceID:          "0717_5c3a8b2e-7f41-4d2a-9c6e-1b8f0a3d2e4c",
+		VPCVolumeAttachment: &provider.VolumeAttachment{ID: attachmentID},
+	}}
+}
+
+func TestVPCDeviceIDPath(t *testing.T) {
+	testCases := []struct {
+		testcasename string
+		attachmentID string
+		expected     string
+		expectErr    bool
+	}{
+		{testcasename: "Dallas 1 attachment", attachmentID: "0717-a6d5b3a1-8a2e-4b9f-b0a3-5c8d9e0f1a2b", expected: "/dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4"},
+		{testcasename: "Frankfurt 2 attachment", attachmentID: "02c7-3f0e9d1c-b2a4-4e6f-8d0b-7a9c1e3f5b7d", expected: "/dev/disk/by-id/virtio-02c7-3f0e9d1c-b2a4-4"},
+		{testcasename: "Truncated ID", attachmentID: "0717-a6d5b3a1-8a2e-4", expectErr: true},
+		{testcasename: "Upper case ID", attachmentID: "0717-A6D5B3A1-8A2E-4B9F-B0A3-5C8D9E0F1A2B", expectErr: true},
+		{testcasename: "Empty ID", attachmentID: "", expectErr: true},
+	}
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			devicePath, err := VPCDeviceIDPath(vpcAttachment("r006-1f7c4c3b-9a2d-4e8f-b5a6-3c2d1e0f9a8b", testcase.attachmentID))
+			if testcase.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, devicePath)
+		})
+	}
+
+	_, err := VPCDeviceIDPath(provider.VolumeAttachmentResponse{})
+	assert.NotNil(t, err)
+}
+
+func TestParseVPCDeviceID(t *testing.T) {
+	testCases := []struct {
+		testcasename string
+		deviceID     string
+		expected     string
+		expectErr    bool
+	}{
+		{testcasename: "Link path", deviceID: "/dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4", expected: "0717-a6d5b3a1-8a2e-4"},
+		{testcasename: "Link name", deviceID: "virtio-0717-a6d5b3a1-8a2e-4", expected: "0717-a6d5b3a1-8a2e-4"},
+		{testcasename: "Partition link", deviceID: "/dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4-part1", expectErr: true},
+		{testcasename: "Kernel name", deviceID: "/dev/vdb", expectErr: true},
+		{testcasename: "Other directory", deviceID: "/dev/disk/by-path/virtio-0717-a6d5b3a1-8a2e-4", expectErr: true},
+		{testcasename: "SCSI link", deviceID: "/dev/disk/by-id/scsi-3600a098038304437415d4b6a59684a52", expectErr: true},
+		{testcasename: "Cloud-init disk", deviceID: "/dev/disk/by-id/virtio-cloud-init-", expectErr: true},
+	}
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			serial, err := ParseVPCDeviceID(testcase.deviceID)
+			if testcase.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, serial)
+		})
+	}
+}
+
+func TestValidateVPCDevice(t *testing.T) {
+	volumeID := "r006-1f7c4c3b-9a2d-4e8f-b5a6-3c2d1e0f9a8b"
+	attachment := vpcAttachment(volumeID, "0717-a6d5b3a1-8a2e-4b9f-b0a3-5c8d9e0f1a2b")
+
+	assert.Nil(t, ValidateVPCDevice("/dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4", volumeID, attachment))
+
+	err := ValidateVPCDevice("/dev/disk/by-id/virtio-0717-9b8c7d6e-5f4a-4", volumeID, attachment)
+	assert.True(t, errors.Is(err, ErrDeviceMismatch))
+	err = ValidateVPCDevice("/dev/disk/by-id/virtio-0717-a6d5b3a1-8a2e-4", "r006-0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", attachment)
+	assert.True(t, errors.Is(err, ErrDeviceMismatch))
+	err = ValidateVPCDevice("/dev/vdb", volumeID, attachment)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrDeviceMismatch))
+
+	assert.True(t, IsVPCResourceID(volumeID))
+	assert.False(t, IsVPCResourceID("vol-1"))
+}
@@ -0,0 +1,182 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/node"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+type DeviceScanner struct {
+	FindDevicePathStub        func(provider.VolumeAttachmentResponse) (string, error)
+	findDevicePathMutex       sync.RWMutex
+	findDevicePathArgsForCall []struct {
+		arg1 provider.VolumeAttachmentResponse
+	}
+	findDevicePathReturns struct {
+		result1 string
+		result2 error
+	}
+	findDevicePathReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RescanDevicesStub        func() error
+	rescanDevicesMutex       sync.RWMutex
+	rescanDevicesArgsForCall []struct {
+	}
+	rescanDevicesReturns struct {
+		result1 error
+	}
+	rescanDevicesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DeviceScanner) FindDevicePath(arg1 provider.VolumeAttachmentResponse) (string, error) {
+	fake.findDevicePathMutex.Lock()
+	ret, specificReturn := fake.findDevicePathReturnsOnCall[len(fake.findDevicePathArgsForCall)]
+	fake.findDevicePathArgsForCall = append(fake.findDevicePathArgsForCall, struct {
+		arg1 provider.VolumeAttachmentResponse
+	}{arg1})
+	stub := fake.FindDevicePathStub
+	fakeReturns := fake.findDevicePathReturns
+	fake.recordInvocation("FindDevicePath", []interface{}{arg1})
+	fake.findDevicePathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DeviceScanner) FindDevicePathCallCount() int {
+	fake.findDevicePathMutex.RLock()
+	defer fake.findDevicePathMutex.RUnlock()
+	return len(fake.findDevicePathArgsForCall)
+}
+
+func (fake *DeviceScanner) FindDevicePathCalls(stub func(provider.VolumeAttachmentResponse) (string, error)) {
+	fake.findDevicePathMutex.Lock()
+	defer fake.findDevicePathMutex.Unlock()
+	fake.FindDevicePathStub = stub
+}
+
+func (fake *DeviceScanner) FindDevicePathArgsForCall(i int) provider.VolumeAttachmentResponse {
+	fake.findDevicePathMutex.RLock()
+	defer fake.findDevicePathMutex.RUnlock()
+	argsForCall := fake.findDevicePathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DeviceScanner) FindDevicePathReturns(result1 string, result2 error) {
+	fake.findDevicePathMutex.Lock()
+	defer fake.findDevicePathMutex.Unlock()
+	fake.FindDevicePathStub = nil
+	fake.findDevicePathReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeviceScanner) FindDevicePathReturnsOnCall(i int, result1 string, result2 error) {
+	fake.findDevicePathMutex.Lock()
+	defer fake.findDevicePathMutex.Unlock()
+	fake.FindDevicePathStub = nil
+	if fake.findDevicePathReturnsOnCall == nil {
+		fake.findDevicePathReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.findDevicePathReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeviceScanner) RescanDevices() error {
+	fake.rescanDevicesMutex.Lock()
+	ret, specificReturn := fake.rescanDevicesReturnsOnCall[len(fake.rescanDevicesArgsForCall)]
+	fake.rescanDevicesArgsForCall = append(fake.rescanDevicesArgsForCall, struct {
+	}{})
+	stub := fake.RescanDevicesStub
+	fakeReturns := fake.rescanDevicesReturns
+	fake.recordInvocation("RescanDevices", []interface{}{})
+	fake.rescanDevicesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *DeviceScanner) RescanDevicesCallCount() int {
+	fake.rescanDevicesMutex.RLock()
+	defer fake.rescanDevicesMutex.RUnlock()
+	return len(fake.rescanDevicesArgsForCall)
+}
+
+func (fake *DeviceScanner) RescanDevicesCalls(stub func() error) {
+	fake.rescanDevicesMutex.Lock()
+	defer fake.rescanDevicesMutex.Unlock()
+	fake.RescanDevicesStub = stub
+}
+
+func (fake *DeviceScanner) RescanDevicesReturns(result1 error) {
+	fake.rescanDevicesMutex.Lock()
+	defer fake.rescanDevicesMutex.Unlock()
+	fake.RescanDevicesStub = nil
+	fake.rescanDevicesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DeviceScanner) RescanDevicesReturnsOnCall(i int, result1 error) {
+	fake.rescanDevicesMutex.Lock()
+	defer fake.rescanDevicesMutex.Unlock()
+	fake.RescanDevicesStub = nil
+	if fake.rescanDevicesReturnsOnCall == nil {
+		fake.rescanDevicesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rescanDevicesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DeviceScanner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findDevicePathMutex.RLock()
+	defer fake.findDevicePathMutex.RUnlock()
+	fake.rescanDevicesMutex.RLock()
+	defer fake.rescanDevicesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DeviceScanner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ node.DeviceScanner = new(DeviceScanner)
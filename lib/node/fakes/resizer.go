@@ -0,0 +1,199 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/node"
+)
+
+type Resizer struct {
+	NeedResizeStub        func(string, string) (bool, error)
+	needResizeMutex       sync.RWMutex
+	needResizeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	needResizeReturns struct {
+		result1 bool
+		result2 error
+	}
+	needResizeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ResizeStub        func(string, string) (bool, error)
+	resizeMutex       sync.RWMutex
+	resizeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	resizeReturns struct {
+		result1 bool
+		result2 error
+	}
+	resizeReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Resizer) NeedResize(arg1 string, arg2 string) (bool, error) {
+	fake.needResizeMutex.Lock()
+	ret, specificReturn := fake.needResizeReturnsOnCall[len(fake.needResizeArgsForCall)]
+	fake.needResizeArgsForCall = append(fake.needResizeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.NeedResizeStub
+	fakeReturns := fake.needResizeReturns
+	fake.recordInvocation("NeedResize", []interface{}{arg1, arg2})
+	fake.needResizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Resizer) NeedResizeCallCount() int {
+	fake.needResizeMutex.RLock()
+	defer fake.needResizeMutex.RUnlock()
+	return len(fake.needResizeArgsForCall)
+}
+
+func (fake *Resizer) NeedResizeCalls(stub func(string, string) (bool, error)) {
+	fake.needResizeMutex.Lock()
+	defer fake.needResizeMutex.Unlock()
+	fake.NeedResizeStub = stub
+}
+
+func (fake *Resizer) NeedResizeArgsForCall(i int) (string, string) {
+	fake.needResizeMutex.RLock()
+	defer fake.needResizeMutex.RUnlock()
+	argsForCall := fake.needResizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Resizer) NeedResizeReturns(result1 bool, result2 error) {
+	fake.needResizeMutex.Lock()
+	defer fake.needResizeMutex.Unlock()
+	fake.NeedResizeStub = nil
+	fake.needResizeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Resizer) NeedResizeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.needResizeMutex.Lock()
+	defer fake.needResizeMutex.Unlock()
+	fake.NeedResizeStub = nil
+	if fake.needResizeReturnsOnCall == nil {
+		fake.needResizeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.needResizeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Resizer) Resize(arg1 string, arg2 string) (bool, error) {
+	fake.resizeMutex.Lock()
+	ret, specificReturn := fake.resizeReturnsOnCall[len(fake.resizeArgsForCall)]
+	fake.resizeArgsForCall = append(fake.resizeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ResizeStub
+	fakeReturns := fake.resizeReturns
+	fake.recordInvocation("Resize", []interface{}{arg1, arg2})
+	fake.resizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Resizer) ResizeCallCount() int {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	return len(fake.resizeArgsForCall)
+}
+
+func (fake *Resizer) ResizeCalls(stub func(string, string) (bool, error)) {
+	fake.resizeMutex.Lock()
+	defer fake.resizeMutex.Unlock()
+	fake.ResizeStub = stub
+}
+
+func (fake *Resizer) ResizeArgsForCall(i int) (string, string) {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	argsForCall := fake.resizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Resizer) ResizeReturns(result1 bool, result2 error) {
+	fake.resizeMutex.Lock()
+	defer fake.resizeMutex.Unlock()
+	fake.ResizeStub = nil
+	fake.resizeReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Resizer) ResizeReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.resizeMutex.Lock()
+	defer fake.resizeMutex.Unlock()
+	fake.ResizeStub = nil
+	if fake.resizeReturnsOnCall == nil {
+		fake.resizeReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.resizeReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Resizer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.needResizeMutex.RLock()
+	defer fake.needResizeMutex.RUnlock()
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Resizer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ node.Resizer = new(Resizer)
@@ -0,0 +1,68 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package node defines the node side helpers used by the node plugins of the storage drivers.
+// The helpers needing syscalls are only defined as interfaces, their implementations live in the
+// drivers so that controller-only consumers can import this library on any platform. The device
+// identifier helpers (see VPCDeviceIDPath) are plain functions
+package node
+
+import (
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// DeviceScanner finds the block devices of attached volumes
+//
+//go:generate counterfeiter -o fakes/device_scanner.go --fake-name DeviceScanner . DeviceScanner
+type DeviceScanner interface {
+	// RescanDevices triggers a rescan of the block devices (e.g. SCSI bus rescan)
+	RescanDevices() error
+
+	// FindDevicePath returns the device path of the attached volume
+	FindDevicePath(attachment provider.VolumeAttachmentResponse) (string, error)
+}
+
+// Mounter mounts and unmounts volumes on the node
+//
+//go:generate counterfeiter -o fakes/mounter.go --fake-name Mounter . Mounter
+type Mounter interface {
+	// Mount mounts source to target with the given file system type and options
+	Mount(source string, target string, fsType string, options []string) error
+
+	// Unmount unmounts the target
+	Unmount(target string) error
+
+	// IsMountPoint returns true if the path is a mount point
+	IsMountPoint(path string) (bool, error)
+}
+
+// Resizer resizes the file system of expanded volumes
+//
+//go:generate counterfeiter -o fakes/resizer.go --fake-name Resizer . Resizer
+type Resizer interface {
+	// NeedResize returns true if the file system on the device is smaller than the device
+	NeedResize(devicePath string, mountPath string) (bool, error)
+
+	// Resize resizes the file system on the device to the device size
+	Resize(devicePath string, mountPath string) (bool, error)
+}
+
+// Helpers groups the node side helpers of a node plugin
+type Helpers struct {
+	DeviceScanner DeviceScanner
+	Mounter       Mounter
+	Resizer       Resizer
+}
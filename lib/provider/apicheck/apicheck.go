@@ -0,0 +1,141 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package apicheck verifies the implementations of the provider interfaces, naming the methods missing from
+// them. Downstream providers run it in their tests to check they implement the current interface set before
+// upgrading the library, e.g.
+//
+//	func TestSessionAPI(t *testing.T) {
+//		apicheck.CheckSession(t, &vpcSession{})
+//	}
+package apicheck
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+)
+
+// TestingT is the subset of testing.TB used by the checks
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Interfaces are the interfaces of the provider API, by name
+var Interfaces = map[string]reflect.Type{
+	"Context":                             reflect.TypeOf((*provider.Context)(nil)).Elem(),
+	"Session":                             reflect.TypeOf((*provider.Session)(nil)).Elem(),
+	"ContextSession":                      reflect.TypeOf((*provider.ContextSession)(nil)).Elem(),
+	"VolumeManager":                       reflect.TypeOf((*provider.VolumeManager)(nil)).Elem(),
+	"VolumeAttachManager":                 reflect.TypeOf((*provider.VolumeAttachManager)(nil)).Elem(),
+	"SnapshotManager":                     reflect.TypeOf((*provider.SnapshotManager)(nil)).Elem(),
+	"VolumeFileAccessPointManager":        reflect.TypeOf((*provider.VolumeFileAccessPointManager)(nil)).Elem(),
+	"FileShareManager":                    reflect.TypeOf((*provider.FileShareManager)(nil)).Elem(),
+	"VolumePerformanceStatsManager":       reflect.TypeOf((*provider.VolumePerformanceStatsManager)(nil)).Elem(),
+	"ResourceEventsManager":               reflect.TypeOf((*provider.ResourceEventsManager)(nil)).Elem(),
+	"DeletionProtectionManager":           reflect.TypeOf((*provider.DeletionProtectionManager)(nil)).Elem(),
+	"CapabilitiesManager":                 reflect.TypeOf((*provider.CapabilitiesManager)(nil)).Elem(),
+	"ContextVolumeManager":                reflect.TypeOf((*provider.ContextVolumeManager)(nil)).Elem(),
+	"ContextVolumeAttachManager":          reflect.TypeOf((*provider.ContextVolumeAttachManager)(nil)).Elem(),
+	"ContextSnapshotManager":              reflect.TypeOf((*provider.ContextSnapshotManager)(nil)).Elem(),
+	"ContextVolumeFileAccessPointManager": reflect.TypeOf((*provider.ContextVolumeFileAccessPointManager)(nil)).Elem(),
+	"ContextFileShareManager":             reflect.TypeOf((*provider.ContextFileShareManager)(nil)).Elem(),
+	"ContextCapabilitiesManager":          reflect.TypeOf((*provider.ContextCapabilitiesManager)(nil)).Elem(),
+	"Provider":                            reflect.TypeOf((*local.Provider)(nil)).Elem(),
+	"ContextCredentialsFactory":           reflect.TypeOf((*local.ContextCredentialsFactory)(nil)).Elem(),
+}
+
+// MissingMethods returns the methods of the interface (e.g. Interfaces["Session"]) impl does not implement,
+// sorted by name. A method implemented with another signature is named with both signatures
+func MissingMethods(impl interface{}, iface reflect.Type) []string {
+	if iface.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("apicheck: %v is not an interface", iface))
+	}
+	var missing []string
+	implType := reflect.TypeOf(impl)
+	for i := 0; i < iface.NumMethod(); i++ {
+		want := iface.Method(i)
+		if implType == nil {
+			missing = append(missing, want.Name)
+			continue
+		}
+		have, found := implType.MethodByName(want.Name)
+		if !found {
+			missing = append(missing, want.Name)
+			continue
+		}
+		if haveType := methodType(have); haveType != want.Type {
+			missing = append(missing, fmt.Sprintf("%s (have %v, want %v)", want.Name, haveType, want.Type))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// methodType returns the type of the method without its receiver, as the methods of an interface type
+func methodType(method reflect.Method) reflect.Type {
+	in := make([]reflect.Type, 0, method.Type.NumIn()-1)
+	for i := 1; i < method.Type.NumIn(); i++ {
+		in = append(in, method.Type.In(i))
+	}
+	out := make([]reflect.Type, 0, method.Type.NumOut())
+	for i := 0; i < method.Type.NumOut(); i++ {
+		out = append(out, method.Type.Out(i))
+	}
+	return reflect.FuncOf(in, out, method.Type.IsVariadic())
+}
+
+// Check fails the test with the methods of the named interfaces impl does not implement, returning whether it
+// implements them all
+func Check(t TestingT, impl interface{}, interfaceNames ...string) bool {
+	t.Helper()
+	complete := true
+	for _, name := range interfaceNames {
+		iface, found := Interfaces[name]
+		if !found {
+			t.Errorf("apicheck: unknown interface %s", name)
+			complete = false
+			continue
+		}
+		if missing := MissingMethods(impl, iface); len(missing) > 0 {
+			t.Errorf("%T does not implement provider %s, missing methods: %v", impl, name, missing)
+			complete = false
+		}
+	}
+	return complete
+}
+
+// CheckSession verifies the session implements provider.Session
+func CheckSession(t TestingT, sess interface{}) bool {
+	t.Helper()
+	return Check(t, sess, "Session")
+}
+
+// CheckContextSession verifies the session implements provider.ContextSession, with all the WithContext methods
+func CheckContextSession(t TestingT, sess interface{}) bool {
+	t.Helper()
+	return Check(t, sess, "ContextSession")
+}
+
+// CheckProvider verifies the provider implements local.Provider
+func CheckProvider(t TestingT, p interface{}) bool {
+	t.Helper()
+	return Check(t, p, "Provider")
+}
@@ -0,0 +1,77 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package apicheck ...
+package apicheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fakes"
+	localfakes "github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT records the errors of the checks
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// partialSession implements a few methods of the session, one with an outdated signature
+type partialSession struct{}
+
+func (partialSession) GetProviderDisplayName() provider.VolumeProvider { return "" }
+
+func (partialSession) Close() {}
+
+func (partialSession) GetVolume(id string) (provider.Volume, error) { return provider.Volume{}, nil }
+
+func TestCheck(t *testing.T) {
+	assert.True(t, CheckSession(t, &fake.FakeSession{}))
+	assert.True(t, Check(t, &fakes.Context{}, "Context", "VolumeManager", "FileShareManager"))
+	assert.True(t, CheckProvider(t, &localfakes.Provider{}))
+
+	recorder := &recordingT{}
+	assert.False(t, CheckContextSession(recorder, &fake.FakeSession{}))
+	if assert.Len(t, recorder.errors, 1) {
+		assert.Contains(t, recorder.errors[0], "CreateVolumeWithContext")
+	}
+
+	recorder = &recordingT{}
+	assert.False(t, Check(recorder, &fake.FakeSession{}, "NotAnInterface"))
+	assert.Len(t, recorder.errors, 1)
+}
+
+func TestMissingMethods(t *testing.T) {
+	missing := MissingMethods(partialSession{}, Interfaces["Session"])
+	assert.Contains(t, missing, "Stats")
+	assert.Contains(t, missing, "CreateVolume")
+	assert.NotContains(t, missing, "Close")
+	assert.Contains(t, missing, "GetVolume (have func(string) (provider.Volume, error), want func(string) (*provider.Volume, error))")
+
+	assert.Empty(t, MissingMethods(&fake.FakeSession{}, Interfaces["Context"]))
+	assert.Len(t, MissingMethods(nil, Interfaces["SnapshotManager"]), Interfaces["SnapshotManager"].NumMethod())
+	assert.Panics(t, func() { MissingMethods(partialSession{}, Interfaces["Session"].Method(0).Type) })
+}
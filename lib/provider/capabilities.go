@@ -0,0 +1,179 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import "reflect"
+
+const (
+	// FeatureAttachmentBandwidth is the feature of per attachment bandwidth allocation
+	FeatureAttachmentBandwidth = "attachmentBandwidth"
+
+	// FeatureBareMetalAttachment is the feature of attaching volumes to bare metal servers
+	FeatureBareMetalAttachment = "bareMetalAttachment"
+
+	// FeatureDeletionProtection is the feature of backend native volume deletion protection
+	FeatureDeletionProtection = "deletionProtection"
+
+	// FeatureBlockSize is the feature of choosing the logical sector size of block volumes (e.g. 4K native)
+	FeatureBlockSize = "blockSize"
+
+	// FeatureSnapshots is the feature of volume snapshots (SnapshotManager)
+	FeatureSnapshots = "snapshots"
+
+	// FeatureVolumeExpansion is the feature of expanding volumes (VolumeManager.ExpandVolume)
+	FeatureVolumeExpansion = "volumeExpansion"
+
+	// FeatureVolumeClone is the feature of creating a volume from another volume (CreateVolumeFromVolume)
+	FeatureVolumeClone = "volumeClone"
+
+	// FeatureCrossZoneSnapshotRestore is the feature of restoring a snapshot in another zone than its source volume
+	FeatureCrossZoneSnapshotRestore = "crossZoneSnapshotRestore"
+
+	// FeatureOnlineExpansion is the feature of expanding volumes while attached (see VolumeManager.SupportsOnlineExpansion)
+	FeatureOnlineExpansion = "onlineExpansion"
+
+	// FeatureMultiAttach is the feature of multi-attach (shareable) volumes attached to several instances at once
+	FeatureMultiAttach = "multiAttach"
+
+	// FeatureFileShares is the feature of VPC file shares (FileShareManager)
+	FeatureFileShares = "fileShares"
+
+	// LimitMaxAttachmentBandwidth is the limit of the bandwidth of one attachment, in megabits per second
+	LimitMaxAttachmentBandwidth = "maxAttachmentBandwidth"
+)
+
+// Capabilities are the volume profiles, features and limits available to the provider account
+type Capabilities struct {
+	// Profiles enabled for the account
+	Profiles []string `json:"profiles"`
+
+	// Features enabled for the account, e.g. encryptionInTransit
+	Features map[string]bool `json:"features,omitempty"`
+
+	// Limits of the account, e.g. maximum volume attachments per instance
+	Limits map[string]int64 `json:"limits,omitempty"`
+
+	// Zones are the constraints of the zones differing from the account, see InZone
+	Zones map[string]ZoneCapabilities `json:"zones,omitempty"`
+}
+
+// ZoneCapabilities are the constraints of a zone, overriding the capabilities of the account
+type ZoneCapabilities struct {
+	// Profiles available in the zone, all the profiles of the account if nil
+	Profiles []string `json:"profiles,omitempty"`
+
+	// Features enabled (true) or disabled (false) in the zone, the features of the account otherwise
+	Features map[string]bool `json:"features,omitempty"`
+
+	// Limits of the zone, the limits of the account otherwise
+	Limits map[string]int64 `json:"limits,omitempty"`
+}
+
+// CapabilitiesManager is implemented by the sessions discovering the capabilities of their backend, so the higher
+// layers (e.g. the CSI driver capabilities) are toggled without hardcoding them per provider type
+type CapabilitiesManager interface {
+	// GetCapabilities returns the profiles, features, limits and zone constraints available to the session
+	GetCapabilities() (*Capabilities, error)
+}
+
+// InZone returns the capabilities available in the zone: those of the account with the constraints of the zone.
+// The returned capabilities have no zones
+func (c *Capabilities) InZone(zone string) *Capabilities {
+	if c == nil {
+		return nil
+	}
+	result := &Capabilities{Profiles: c.Profiles, Features: map[string]bool{}, Limits: map[string]int64{}}
+	for name, enabled := range c.Features {
+		result.Features[name] = enabled
+	}
+	for name, limit := range c.Limits {
+		result.Limits[name] = limit
+	}
+	constraints, found := c.Zones[zone]
+	if !found {
+		return result
+	}
+	if constraints.Profiles != nil {
+		result.Profiles = nil
+		for _, profile := range constraints.Profiles {
+			if c.HasProfile(profile) {
+				result.Profiles = append(result.Profiles, profile)
+			}
+		}
+	}
+	for name, enabled := range constraints.Features {
+		result.Features[name] = enabled
+	}
+	for name, limit := range constraints.Limits {
+		result.Limits[name] = limit
+	}
+	return result
+}
+
+// SupportsSnapshots reports whether volume snapshots are supported
+func (c *Capabilities) SupportsSnapshots() bool {
+	return c.HasFeature(FeatureSnapshots)
+}
+
+// SupportsClone reports whether volumes can be created from other volumes
+func (c *Capabilities) SupportsClone() bool {
+	return c.HasFeature(FeatureVolumeClone)
+}
+
+// SupportsExpansion reports whether volumes can be expanded, detached if not SupportsOnlineExpansion
+func (c *Capabilities) SupportsExpansion() bool {
+	return c.HasFeature(FeatureVolumeExpansion) || c.SupportsOnlineExpansion()
+}
+
+// SupportsOnlineExpansion reports whether volumes can be expanded while attached
+func (c *Capabilities) SupportsOnlineExpansion() bool {
+	return c.HasFeature(FeatureOnlineExpansion)
+}
+
+// SupportsMultiAttach reports whether volumes can be attached to several instances at once
+func (c *Capabilities) SupportsMultiAttach() bool {
+	return c.HasFeature(FeatureMultiAttach)
+}
+
+// SupportsFileShares reports whether file shares are supported
+func (c *Capabilities) SupportsFileShares() bool {
+	return c.HasFeature(FeatureFileShares)
+}
+
+// HasProfile reports whether the profile is enabled
+func (c *Capabilities) HasProfile(name string) bool {
+	if c == nil {
+		return false
+	}
+	for _, profile := range c.Profiles {
+		if profile == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFeature reports whether the feature is enabled
+func (c *Capabilities) HasFeature(name string) bool {
+	return c != nil && c.Features[name]
+}
+
+// Equal reports whether both capabilities are the same
+func (c *Capabilities) Equal(other *Capabilities) bool {
+	return reflect.DeepEqual(c, other)
+}
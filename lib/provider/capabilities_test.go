@@ -0,0 +1,75 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesInZone(t *testing.T) {
+	capabilities := &Capabilities{
+		Profiles: []string{"general-purpose", "10iops-tier", "sdp"},
+		Features: map[string]bool{FeatureSnapshots: true, FeatureMultiAttach: true},
+		Limits:   map[string]int64{LimitMaxAttachmentBandwidth: 8000},
+		Zones: map[string]ZoneCapabilities{
+			"us-south-3": {
+				Profiles: []string{"general-purpose", "unknown"},
+				Features: map[string]bool{FeatureMultiAttach: false, FeatureVolumeClone: true},
+				Limits:   map[string]int64{LimitMaxAttachmentBandwidth: 4000},
+			},
+		},
+	}
+
+	zone := capabilities.InZone("us-south-3")
+	assert.Equal(t, []string{"general-purpose"}, zone.Profiles)
+	assert.True(t, zone.SupportsSnapshots())
+	assert.False(t, zone.SupportsMultiAttach())
+	assert.True(t, zone.SupportsClone())
+	assert.Equal(t, int64(4000), zone.Limits[LimitMaxAttachmentBandwidth])
+	assert.Nil(t, zone.Zones)
+
+	other := capabilities.InZone("us-south-1")
+	assert.Equal(t, capabilities.Profiles, other.Profiles)
+	assert.True(t, other.SupportsMultiAttach())
+	assert.False(t, other.SupportsClone())
+
+	// the account capabilities are not changed
+	assert.True(t, capabilities.SupportsMultiAttach())
+	assert.Equal(t, int64(8000), capabilities.Limits[LimitMaxAttachmentBandwidth])
+
+	var missing *Capabilities
+	assert.Nil(t, missing.InZone("us-south-1"))
+}
+
+func TestCapabilitiesSupports(t *testing.T) {
+	var missing *Capabilities
+	assert.False(t, missing.SupportsSnapshots())
+	assert.False(t, missing.SupportsExpansion())
+
+	capabilities := &Capabilities{Features: map[string]bool{FeatureOnlineExpansion: true, FeatureFileShares: true}}
+	assert.True(t, capabilities.SupportsExpansion())
+	assert.True(t, capabilities.SupportsOnlineExpansion())
+	assert.True(t, capabilities.SupportsFileShares())
+	assert.False(t, capabilities.SupportsSnapshots())
+
+	capabilities = &Capabilities{Features: map[string]bool{FeatureVolumeExpansion: true}}
+	assert.True(t, capabilities.SupportsExpansion())
+	assert.False(t, capabilities.SupportsOnlineExpansion())
+}
@@ -0,0 +1,344 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package conformance is the contract test suite of the provider.Session implementations. Out-of-tree providers
+// run it against their session to verify it follows the documented semantics of the interfaces, e.g.
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, conformance.Config{
+//			NewSession: func(t *testing.T) provider.Session { return newTestSession(t) },
+//			InstanceID: os.Getenv("TEST_INSTANCE_ID"),
+//		})
+//	}
+//
+// The checked semantics are:
+//   - a missing volume, snapshot or attachment is reported with an error of category provider.CategoryNotFound
+//   - deleting a deleted volume or snapshot succeeds or is reported as not found, never with another error
+//   - an attached volume cannot be deleted, its attachment is reported until it is detached
+//   - detaching a volume which is not attached succeeds or is reported as not found
+//   - the pages of ListVolumes hold at most limit volumes, and each volume is listed once
+package conformance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+)
+
+// DefaultVolumeCapacity is the capacity in GiB of the volumes created by the suite
+const DefaultVolumeCapacity = 10
+
+// Config configures the suite for a provider
+type Config struct {
+	// NewSession returns the session under test, it is called by each test
+	NewSession func(t *testing.T) provider.Session
+
+	// VolumeRequest returns the request creating the volume of the name, a DefaultVolumeCapacity volume if nil.
+	// Providers requiring a profile or a zone set them here
+	VolumeRequest func(name string) provider.Volume
+
+	// WaitForVolume waits for the created volume to be available (e.g. its status to be "available") before it is
+	// used by the tests, for providers creating the volumes asynchronously. The volumes are used right away if nil
+	WaitForVolume func(sess provider.Session, volume *provider.Volume) error
+
+	// InstanceID is the instance the attachment tests attach the volumes to, they are skipped if empty
+	InstanceID string
+
+	// Skip are the names of the tests not run, e.g. "SnapshotLifecycle"
+	Skip []string
+}
+
+// test is a test of the suite
+type test struct {
+	name string
+	run  func(t *testing.T, s *suite)
+}
+
+var tests = []test{
+	{name: "VolumeLifecycle", run: testVolumeLifecycle},
+	{name: "VolumeNotFound", run: testVolumeNotFound},
+	{name: "DeleteVolumeIdempotent", run: testDeleteVolumeIdempotent},
+	{name: "ListVolumesPaging", run: testListVolumesPaging},
+	{name: "AttachDetachOrdering", run: testAttachDetachOrdering},
+	{name: "DetachNotAttached", run: testDetachNotAttached},
+	{name: "SnapshotLifecycle", run: testSnapshotLifecycle},
+}
+
+// TestNames returns the names of the tests of the suite, in the order they are run
+func TestNames() []string {
+	names := make([]string, 0, len(tests))
+	for _, test := range tests {
+		names = append(names, test.name)
+	}
+	return names
+}
+
+// Run runs the tests of the suite as subtests of t
+func Run(t *testing.T, config Config) {
+	t.Helper()
+	if config.NewSession == nil {
+		t.Fatal("conformance: Config.NewSession is required")
+	}
+	skipped := map[string]bool{}
+	for _, name := range config.Skip {
+		skipped[name] = true
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if skipped[test.name] {
+				t.Skip("skipped by the config")
+			}
+			test.run(t, &suite{config: config, sess: config.NewSession(t)})
+		})
+	}
+}
+
+// suite is the state of a test
+type suite struct {
+	config Config
+	sess   provider.Session
+}
+
+// createVolume creates a volume deleted at the end of the test
+func (s *suite) createVolume(t *testing.T, name string) *provider.Volume {
+	t.Helper()
+	name = fmt.Sprintf("conformance-%s-%d", name, time.Now().UnixNano())
+	request := provider.Volume{Name: &name}
+	if s.config.VolumeRequest != nil {
+		request = s.config.VolumeRequest(name)
+	} else {
+		capacity := DefaultVolumeCapacity
+		request.Capacity = &capacity
+	}
+	volume, err := s.sess.CreateVolume(request)
+	if err != nil {
+		t.Fatalf("CreateVolume(%s) failed: %v", name, err)
+	}
+	if volume == nil || volume.VolumeID == "" {
+		t.Fatalf("CreateVolume(%s) returned no volume ID", name)
+	}
+	t.Cleanup(func() { _ = s.sess.DeleteVolume(volume) })
+	if s.config.WaitForVolume != nil {
+		if err := s.config.WaitForVolume(s.sess, volume); err != nil {
+			t.Fatalf("WaitForVolume(%s) failed: %v", volume.VolumeID, err)
+		}
+	}
+	return volume
+}
+
+// capabilities returns the capabilities of the session, nil if unknown
+func (s *suite) capabilities(t *testing.T) *provider.Capabilities {
+	capabilities, err := s.sess.GetCapabilities()
+	if err != nil {
+		t.Logf("GetCapabilities failed, assuming all the features are supported: %v", err)
+		return nil
+	}
+	return capabilities
+}
+
+// attachRequest returns the attachment request of the volume to the instance of the config, the test is skipped
+// if there is none
+func (s *suite) attachRequest(t *testing.T, volume *provider.Volume) provider.VolumeAttachmentRequest {
+	t.Helper()
+	if s.config.InstanceID == "" {
+		t.Skip("no Config.InstanceID to attach the volumes to")
+	}
+	return provider.VolumeAttachmentRequest{VolumeID: volume.VolumeID, InstanceID: s.config.InstanceID}
+}
+
+// expectNotFound fails the test if err is not a not found error
+func expectNotFound(t *testing.T, operation string, err error) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s succeeded, want a not found error", operation)
+	} else if category := util.ErrorCategoryOf(err); category != provider.CategoryNotFound {
+		t.Errorf("%s failed with a %s error, want a not found error: %v", operation, category, err)
+	}
+}
+
+// expectDeleted fails the test if err is neither nil nor a not found error
+func expectDeleted(t *testing.T, operation string, err error) {
+	t.Helper()
+	if err != nil && util.ErrorCategoryOf(err) != provider.CategoryNotFound {
+		t.Errorf("%s failed with a %s error, want success or a not found error: %v", operation, util.ErrorCategoryOf(err), err)
+	}
+}
+
+func testVolumeLifecycle(t *testing.T, s *suite) {
+	volume := s.createVolume(t, "lifecycle")
+
+	got, err := s.sess.GetVolume(volume.VolumeID)
+	if err != nil {
+		t.Fatalf("GetVolume(%s) failed: %v", volume.VolumeID, err)
+	}
+	if got == nil {
+		t.Fatalf("GetVolume(%s) returned no volume", volume.VolumeID)
+	}
+	if got.VolumeID != volume.VolumeID {
+		t.Errorf("GetVolume(%s) returned volume %s", volume.VolumeID, got.VolumeID)
+	}
+	if volume.Name != nil {
+		byName, err := s.sess.GetVolumeByName(*volume.Name)
+		if err != nil {
+			t.Errorf("GetVolumeByName(%s) failed: %v", *volume.Name, err)
+		} else if byName == nil {
+			t.Errorf("GetVolumeByName(%s) returned no volume", *volume.Name)
+		} else if byName.VolumeID != volume.VolumeID {
+			t.Errorf("GetVolumeByName(%s) returned volume %s, want %s", *volume.Name, byName.VolumeID, volume.VolumeID)
+		}
+	}
+
+	if err := s.sess.DeleteVolume(volume); err != nil {
+		t.Fatalf("DeleteVolume(%s) failed: %v", volume.VolumeID, err)
+	}
+	_, err = s.sess.GetVolume(volume.VolumeID)
+	expectNotFound(t, "GetVolume of the deleted volume", err)
+}
+
+func testVolumeNotFound(t *testing.T, s *suite) {
+	_, err := s.sess.GetVolume("conformance-missing-volume")
+	expectNotFound(t, "GetVolume of a missing volume", err)
+	_, err = s.sess.GetVolumeByName("conformance-missing-volume")
+	expectNotFound(t, "GetVolumeByName of a missing volume", err)
+}
+
+func testDeleteVolumeIdempotent(t *testing.T, s *suite) {
+	volume := s.createVolume(t, "delete")
+	if err := s.sess.DeleteVolume(volume); err != nil {
+		t.Fatalf("DeleteVolume(%s) failed: %v", volume.VolumeID, err)
+	}
+	expectDeleted(t, "DeleteVolume of the deleted volume", s.sess.DeleteVolume(volume))
+}
+
+func testListVolumesPaging(t *testing.T, s *suite) {
+	created := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		created[s.createVolume(t, fmt.Sprintf("list-%d", i)).VolumeID] = true
+	}
+
+	const limit = 2
+	listed := map[string]int{}
+	start := ""
+	for pages := 0; ; pages++ {
+		if pages > 1000 {
+			t.Fatalf("ListVolumes returned more than %d pages, the Next cursor does not advance", pages)
+		}
+		list, err := s.sess.ListVolumes(limit, start, nil)
+		if err != nil {
+			t.Fatalf("ListVolumes(%d, %q) failed: %v", limit, start, err)
+		}
+		if list == nil {
+			t.Fatalf("ListVolumes(%d, %q) returned no list", limit, start)
+		}
+		if len(list.Volumes) > limit {
+			t.Errorf("ListVolumes(%d, %q) returned %d volumes", limit, start, len(list.Volumes))
+		}
+		for _, volume := range list.Volumes {
+			listed[volume.VolumeID]++
+		}
+		if list.Next == "" || list.Next == start {
+			break
+		}
+		start = list.Next
+	}
+	for volumeID := range created {
+		if listed[volumeID] != 1 {
+			t.Errorf("ListVolumes listed volume %s %d times, want once", volumeID, listed[volumeID])
+		}
+	}
+}
+
+func testAttachDetachOrdering(t *testing.T, s *suite) {
+	volume := s.createVolume(t, "attach")
+	request := s.attachRequest(t, volume)
+
+	if _, err := s.sess.AttachVolume(request); err != nil {
+		t.Fatalf("AttachVolume(%s, %s) failed: %v", request.VolumeID, request.InstanceID, err)
+	}
+	detached := false
+	t.Cleanup(func() {
+		if !detached {
+			_, _ = s.sess.DetachVolume(request)
+			_ = s.sess.WaitForDetachVolume(request)
+		}
+	})
+	if _, err := s.sess.WaitForAttachVolume(request); err != nil {
+		t.Fatalf("WaitForAttachVolume(%s, %s) failed: %v", request.VolumeID, request.InstanceID, err)
+	}
+	if _, err := s.sess.GetVolumeAttachment(request); err != nil {
+		t.Errorf("GetVolumeAttachment of the attached volume failed: %v", err)
+	}
+	if err := s.sess.DeleteVolume(volume); err == nil {
+		t.Errorf("DeleteVolume of the attached volume %s succeeded", volume.VolumeID)
+	}
+
+	if _, err := s.sess.DetachVolume(request); err != nil {
+		t.Fatalf("DetachVolume(%s, %s) failed: %v", request.VolumeID, request.InstanceID, err)
+	}
+	if err := s.sess.WaitForDetachVolume(request); err != nil {
+		t.Fatalf("WaitForDetachVolume(%s, %s) failed: %v", request.VolumeID, request.InstanceID, err)
+	}
+	detached = true
+	_, err := s.sess.GetVolumeAttachment(request)
+	expectNotFound(t, "GetVolumeAttachment of the detached volume", err)
+	if err := s.sess.DeleteVolume(volume); err != nil {
+		t.Errorf("DeleteVolume of the detached volume %s failed: %v", volume.VolumeID, err)
+	}
+}
+
+func testDetachNotAttached(t *testing.T, s *suite) {
+	volume := s.createVolume(t, "detach")
+	request := s.attachRequest(t, volume)
+	_, err := s.sess.DetachVolume(request)
+	expectDeleted(t, "DetachVolume of a volume which is not attached", err)
+}
+
+func testSnapshotLifecycle(t *testing.T, s *suite) {
+	if capabilities := s.capabilities(t); capabilities != nil && len(capabilities.Features) > 0 && !capabilities.SupportsSnapshots() {
+		t.Skip("the provider does not support snapshots")
+	}
+	volume := s.createVolume(t, "snapshot")
+	snapshot, err := s.sess.CreateSnapshot(volume.VolumeID, provider.SnapshotParameters{Name: fmt.Sprintf("conformance-snapshot-%d", time.Now().UnixNano())})
+	if err != nil {
+		t.Fatalf("CreateSnapshot(%s) failed: %v", volume.VolumeID, err)
+	}
+	if snapshot == nil || snapshot.SnapshotID == "" {
+		t.Fatalf("CreateSnapshot(%s) returned no snapshot ID", volume.VolumeID)
+	}
+	t.Cleanup(func() { _ = s.sess.DeleteSnapshot(snapshot) })
+
+	got, err := s.sess.GetSnapshot(snapshot.SnapshotID)
+	if err != nil {
+		t.Fatalf("GetSnapshot(%s) failed: %v", snapshot.SnapshotID, err)
+	}
+	if got == nil {
+		t.Fatalf("GetSnapshot(%s) returned no snapshot", snapshot.SnapshotID)
+	}
+	if got.VolumeID != "" && got.VolumeID != volume.VolumeID {
+		t.Errorf("GetSnapshot(%s) returned the snapshot of volume %s, want %s", snapshot.SnapshotID, got.VolumeID, volume.VolumeID)
+	}
+
+	if err := s.sess.DeleteSnapshot(snapshot); err != nil {
+		t.Fatalf("DeleteSnapshot(%s) failed: %v", snapshot.SnapshotID, err)
+	}
+	expectDeleted(t, "DeleteSnapshot of the deleted snapshot", s.sess.DeleteSnapshot(snapshot))
+	_, err = s.sess.GetSnapshot(snapshot.SnapshotID)
+	expectNotFound(t, "GetSnapshot of the deleted snapshot", err)
+}
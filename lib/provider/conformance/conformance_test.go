@@ -0,0 +1,60 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package conformance ...
+package conformance
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemorySessionConformance(t *testing.T) {
+	store := fake.NewMemoryStore(fake.Quotas{})
+	Run(t, Config{
+		NewSession: func(t *testing.T) provider.Session { return fake.NewMemorySession(store) },
+		InstanceID: "instance-1",
+	})
+	assert.Equal(t, 0, store.Capacity().Usage().Volumes)
+}
+
+func TestRunWaitForVolume(t *testing.T) {
+	sess := fake.NewMemorySession(fake.NewMemoryStore(fake.Quotas{}))
+	waited := []string{}
+	Run(t, Config{
+		NewSession: func(t *testing.T) provider.Session { return sess },
+		WaitForVolume: func(sess provider.Session, volume *provider.Volume) error {
+			waited = append(waited, volume.VolumeID)
+			_, err := sess.GetVolume(volume.VolumeID)
+			return err
+		},
+		Skip: TestNames()[1:],
+	})
+	assert.Len(t, waited, 1)
+}
+
+func TestRunSkip(t *testing.T) {
+	sess := fake.NewMemorySession(fake.NewMemoryStore(fake.Quotas{}))
+	Run(t, Config{
+		NewSession: func(t *testing.T) provider.Session { return sess },
+		Skip:       TestNames()[1:],
+	})
+	assert.Equal(t, 1, sess.Store().Calls("CreateVolume"))
+	assert.Equal(t, 0, sess.Store().Calls("AttachVolume"))
+}
@@ -22,5 +22,6 @@ type RequestIDType string
 
 const (
 	// RequestID ...
+	// Deprecated: use ctxkeys.WithRequestID and ctxkeys.RequestID
 	RequestID RequestIDType = RequestIDType("request-id")
 )
@@ -0,0 +1,102 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContextVolumeManager is the VolumeManager honoring the deadline and cancellation of a context,
+// e.g. the gRPC request of a CSI driver
+type ContextVolumeManager interface {
+	CreateVolumeWithContext(ctx context.Context, VolumeRequest Volume) (*Volume, error)
+	CreateVolumeFromSnapshotWithContext(ctx context.Context, snapshot Snapshot, tags map[string]string) (*Volume, error)
+	CreateVolumeFromVolumeWithContext(ctx context.Context, cloneRequest VolumeCloneRequest) (*VolumeCloneResponse, error)
+	UpdateVolumeWithContext(ctx context.Context, volume Volume) error
+	DeleteVolumeWithContext(ctx context.Context, volume *Volume) error
+	GetVolumeWithContext(ctx context.Context, id string) (*Volume, error)
+	GetVolumeByNameWithContext(ctx context.Context, name string) (*Volume, error)
+	ListVolumesWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*VolumeList, error)
+	ListVolumesWithFiltersWithContext(ctx context.Context, limit int, start string, filters ListVolumesFilters) (*VolumeList, error)
+	GetVolumeByRequestIDWithContext(ctx context.Context, requestID string) (*Volume, error)
+	AuthorizeVolumeWithContext(ctx context.Context, volumeAuthorization VolumeAuthorization) error
+	ExpandVolumeWithContext(ctx context.Context, expandVolumeRequest ExpandVolumeRequest) (int64, error)
+	UpdateVolumeProfileWithContext(ctx context.Context, updateRequest VolumeProfileUpdateRequest) (*Volume, error)
+	UpdateVolumeIOPSWithContext(ctx context.Context, updateRequest VolumeIOPSUpdateRequest) (*Volume, error)
+	GetVolumeTagsWithContext(ctx context.Context, volumeID string) (*VolumeTags, error)
+	UpdateVolumeTagsWithContext(ctx context.Context, updateRequest VolumeTagsUpdateRequest) (*VolumeTags, error)
+}
+
+// ContextVolumeAttachManager is the VolumeAttachManager honoring the deadline and cancellation of a context
+type ContextVolumeAttachManager interface {
+	AttachVolumeWithContext(ctx context.Context, attachRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+	DetachVolumeWithContext(ctx context.Context, detachRequest VolumeAttachmentRequest) (*http.Response, error)
+	WaitForAttachVolumeWithContext(ctx context.Context, attachRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+	WaitForDetachVolumeWithContext(ctx context.Context, detachRequest VolumeAttachmentRequest) error
+	GetVolumeAttachmentWithContext(ctx context.Context, attachRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+	UpdateVolumeAttachmentWithContext(ctx context.Context, updateRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+	ListVolumeAttachmentsWithContext(ctx context.Context, volumeID string) ([]*VolumeAttachmentResponse, error)
+}
+
+// ContextSnapshotManager is the SnapshotManager honoring the deadline and cancellation of a context
+type ContextSnapshotManager interface {
+	CreateSnapshotWithContext(ctx context.Context, sourceVolumeID string, snapshotParameters SnapshotParameters) (*Snapshot, error)
+	DeleteSnapshotWithContext(ctx context.Context, snapshot *Snapshot) error
+	GetSnapshotWithContext(ctx context.Context, snapshotID string) (*Snapshot, error)
+	GetSnapshotByNameWithContext(ctx context.Context, snapshotName string) (*Snapshot, error)
+	ListSnapshotsWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*SnapshotList, error)
+	RestoreVolumeFromSnapshotWithContext(ctx context.Context, restoreRequest SnapshotRestoreRequest) (*SnapshotRestoreResponse, error)
+	GetSnapshotRestoreProgressWithContext(ctx context.Context, volumeID string) (*SnapshotRestoreProgress, error)
+}
+
+// ContextVolumeFileAccessPointManager is the VolumeFileAccessPointManager honoring the deadline and cancellation of a context
+type ContextVolumeFileAccessPointManager interface {
+	CreateVolumeAccessPointWithContext(ctx context.Context, accessPointRequest VolumeAccessPointRequest) (*VolumeAccessPointResponse, error)
+	DeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest VolumeAccessPointRequest) (*http.Response, error)
+	WaitForCreateVolumeAccessPointWithContext(ctx context.Context, accessPointRequest VolumeAccessPointRequest) (*VolumeAccessPointResponse, error)
+	WaitForDeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest VolumeAccessPointRequest) error
+	GetVolumeAccessPointWithContext(ctx context.Context, accessPointRequest VolumeAccessPointRequest) (*VolumeAccessPointResponse, error)
+}
+
+// ContextFileShareManager is the FileShareManager honoring the deadline and cancellation of a context
+type ContextFileShareManager interface {
+	CreateShareWithContext(ctx context.Context, shareRequest FileShareRequest) (*FileShare, error)
+	DeleteShareWithContext(ctx context.Context, shareID string) error
+	CreateShareTargetWithContext(ctx context.Context, targetRequest ShareTargetRequest) (*ShareTarget, error)
+	DeleteShareTargetWithContext(ctx context.Context, targetRequest ShareTargetRequest) error
+	ListSharesWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*FileShareList, error)
+	ExpandShareWithContext(ctx context.Context, expandRequest ExpandShareRequest) (int64, error)
+}
+
+// ContextCapabilitiesManager is the CapabilitiesManager honoring the deadline and cancellation of a context
+type ContextCapabilitiesManager interface {
+	GetCapabilitiesWithContext(ctx context.Context) (*Capabilities, error)
+}
+
+// ContextSession is optionally implemented by the sessions accepting a context on every operation, providers
+// thread it through their token exchange, retry and wait loops. Use util.NewContextSession for any Session
+type ContextSession interface {
+	Session
+	ContextVolumeManager
+	ContextVolumeAttachManager
+	ContextSnapshotManager
+	ContextVolumeFileAccessPointManager
+	ContextFileShareManager
+	ContextCapabilitiesManager
+}
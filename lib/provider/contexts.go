@@ -24,6 +24,10 @@ type Context interface {
 	VolumeAttachManager
 	SnapshotManager
 	VolumeFileAccessPointManager
+	FileShareManager
+	VolumePerformanceStatsManager
+	ResourceEventsManager
+	CapabilitiesManager
 }
 
 // Session is an Context that is notified when it is no longer required
@@ -36,4 +40,7 @@ type Session interface {
 
 	// Close is called when the Session is nolonger required
 	Close()
+
+	// Stats returns a snapshot of the session counters
+	Stats() ProviderStats
 }
@@ -43,3 +43,16 @@ type ContextCredentials struct {
 	// ContextID is an optional request/context/correlation identifier for diagnostics (need not be unique)
 	ContextID string
 }
+
+// CredentialsOverride holds the per-call credentials of an operation, e.g. from the secrets of a StorageClass,
+// which providers merge over the session configuration. Empty fields keep the configured values
+type CredentialsOverride struct {
+	APIKey           string `json:"-"` // Do not trace
+	ResourceGroupID  string
+	EncryptionKeyCRN string
+}
+
+// IsEmpty returns true if the override does not change any credential
+func (o CredentialsOverride) IsEmpty() bool {
+	return o.APIKey == "" && o.ResourceGroupID == "" && o.EncryptionKeyCRN == ""
+}
@@ -93,6 +93,14 @@ type Volume struct {
 	// IscsiTargetIPAddresses list of target IP addresses for iscsi. Applicable for Iscsi block storage only
 	IscsiTargetIPAddresses []string `json:"iscsiTargetIpAddresses,omitempty"`
 
+	// BlockSize is the logical sector size of a block volume in bytes (512 or 4096), the backend default if 0.
+	// Only for providers exposing the option (FeatureBlockSize)
+	BlockSize int `json:"blockSize,omitempty"`
+
+	// MultiAttach enables attaching the volume to several instances at once (shareable volume), with shared
+	// attachments. Only for providers exposing the option (FeatureMultiAttach)
+	MultiAttach bool `json:"multiAttach,omitempty"`
+
 	// Only for VPC volume provider
 	VPCVolume
 
@@ -116,9 +124,12 @@ type Snapshot struct {
 	// tags for the snapshot
 	SnapshotTags SnapshotTags `json:"tags,omitempty"`
 
-	// status of snapshot
+	// status of snapshot, a snapshot can only be restored once ready to use
 	ReadyToUse bool `json:"readyToUse"`
 
+	// RestoreSize is the minimum capacity of a volume restored from the snapshot, in bytes (0 if unknown)
+	RestoreSize int64 `json:"restoreSize,omitempty"`
+
 	// VPC contains vpc fields
 	VPC
 }
@@ -158,6 +169,175 @@ type ExpandVolumeRequest struct {
 	Capacity int64 `json:"capacity"`
 }
 
+// VolumeProfileUpdateRequest is the request of UpdateVolumeProfile
+type VolumeProfileUpdateRequest struct {
+	// VolumeID of the volume to update
+	VolumeID string `json:"volumeID"`
+
+	// Profile is the new profile of the volume
+	Profile string `json:"profile"`
+
+	// Iops of the volume, only for the profiles with custom IOPS
+	Iops *int `json:"iops,omitempty"`
+
+	// Bandwidth of the volume in megabits per second, only for the profiles with custom bandwidth
+	Bandwidth *int `json:"bandwidth,omitempty"`
+}
+
+// VolumeIOPSUpdateRequest is the request of UpdateVolumeIOPS
+type VolumeIOPSUpdateRequest struct {
+	// VolumeID of the volume to update
+	VolumeID string `json:"volumeID"`
+
+	// Iops is the new IOPS of the volume
+	Iops int `json:"iops"`
+
+	// Bandwidth of the volume in megabits per second, unchanged if nil
+	Bandwidth *int `json:"bandwidth,omitempty"`
+}
+
+// TagType is the IBM Cloud Global Tagging type of a tag
+type TagType string
+
+const (
+	// TagTypeUser tags are free form labels, e.g. the cluster and PVC metadata of the volume
+	TagTypeUser = TagType("user")
+	// TagTypeAccess tags are key:value tags defined in the account, scoping the IAM access policies
+	TagTypeAccess = TagType("access")
+)
+
+// VolumeTags are the tags of a volume by type. Global Tagging stores the tags in lower case
+type VolumeTags struct {
+	// VolumeID of the volume
+	VolumeID string `json:"volumeID"`
+
+	// UserTags of the volume
+	UserTags []string `json:"userTags"`
+
+	// AccessTags of the volume
+	AccessTags []string `json:"accessTags"`
+}
+
+// VolumeTagsUpdateRequest is the request of UpdateVolumeTags
+type VolumeTagsUpdateRequest struct {
+	// VolumeID of the volume to update
+	VolumeID string `json:"volumeID"`
+
+	// TagType of the attached and detached tags, TagTypeUser if empty
+	TagType TagType `json:"tagType,omitempty"`
+
+	// Attach are the tags to attach, attaching a tag which is attached already succeeds
+	Attach []string `json:"attach,omitempty"`
+
+	// Detach are the tags to detach, detaching a tag which is not attached succeeds
+	Detach []string `json:"detach,omitempty"`
+}
+
+// VolumeCloneRequest is the request of CreateVolumeFromVolume
+type VolumeCloneRequest struct {
+	// SourceVolumeID is the ID of the volume to clone
+	SourceVolumeID string `json:"sourceVolumeID"`
+
+	// Name of the new volume
+	Name *string `json:"name,omitempty"`
+
+	// Zone of the new volume, the zone of the source volume if empty
+	Zone string `json:"zone,omitempty"`
+
+	// Capacity of the new volume in GiB, the capacity of the source volume if nil. It cannot be smaller than the source
+	Capacity *int `json:"capacity,omitempty"`
+
+	// Iops of the new volume, the IOPS of the source volume if nil
+	Iops *string `json:"iops,omitempty"`
+
+	// Tags of the new volume
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// VolumeCloneResponse is the response of CreateVolumeFromVolume
+type VolumeCloneResponse struct {
+	// Volume is the new volume, it might still be provisioning
+	Volume *Volume `json:"volume"`
+
+	// SourceVolumeID is the ID of the cloned volume
+	SourceVolumeID string `json:"sourceVolumeID"`
+}
+
+// SnapshotRestoreRequest is the request of RestoreVolumeFromSnapshot
+type SnapshotRestoreRequest struct {
+	// SnapshotID is the ID of the snapshot to restore
+	SnapshotID string `json:"snapshotID"`
+
+	// Name of the new volume
+	Name *string `json:"name,omitempty"`
+
+	// Zone of the new volume, the zone of the source volume of the snapshot if empty
+	Zone string `json:"zone,omitempty"`
+
+	// SourceZone is the zone of the source volume of the snapshot, if known. The restore is cross zone if it differs from Zone
+	SourceZone string `json:"sourceZone,omitempty"`
+
+	// Capacity of the new volume in GiB, the restore size of the snapshot if nil. It cannot be smaller than the restore size
+	Capacity *int `json:"capacity,omitempty"`
+
+	// Iops of the new volume
+	Iops *string `json:"iops,omitempty"`
+
+	// Tags of the new volume
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// IsCrossZone returns true if the volume is restored in another zone than the source volume of the snapshot
+func (r SnapshotRestoreRequest) IsCrossZone() bool {
+	return r.Zone != "" && r.SourceZone != "" && r.Zone != r.SourceZone
+}
+
+// SnapshotRestorePhase is the phase of the restore of a volume from a snapshot
+type SnapshotRestorePhase string
+
+const (
+	// SnapshotRestorePending is the phase of a volume being created from the snapshot
+	SnapshotRestorePending = SnapshotRestorePhase("pending")
+
+	// SnapshotRestoreHydrating is the phase of a volume usable while the snapshot data is still being copied
+	SnapshotRestoreHydrating = SnapshotRestorePhase("hydrating")
+
+	// SnapshotRestoreCompleted is the phase of a volume with all the snapshot data restored
+	SnapshotRestoreCompleted = SnapshotRestorePhase("completed")
+
+	// SnapshotRestoreFailed is the phase of a volume which failed to be restored
+	SnapshotRestoreFailed = SnapshotRestorePhase("failed")
+)
+
+// SnapshotRestoreProgress is the progress of the restore of a volume from a snapshot
+type SnapshotRestoreProgress struct {
+	// VolumeID of the restored volume
+	VolumeID string `json:"volumeID"`
+
+	// Phase of the restore
+	Phase SnapshotRestorePhase `json:"phase"`
+
+	// PercentComplete of the data restored, from 0 to 100
+	PercentComplete int `json:"percentComplete"`
+}
+
+// IsDone returns true if the restore completed or failed
+func (p SnapshotRestoreProgress) IsDone() bool {
+	return p.Phase == SnapshotRestoreCompleted || p.Phase == SnapshotRestoreFailed
+}
+
+// SnapshotRestoreResponse is the response of RestoreVolumeFromSnapshot
+type SnapshotRestoreResponse struct {
+	// Volume is the new volume, it might still be provisioning
+	Volume *Volume `json:"volume"`
+
+	// SnapshotID is the ID of the restored snapshot
+	SnapshotID string `json:"snapshotID"`
+
+	// Progress of the restore when the volume was created
+	Progress SnapshotRestoreProgress `json:"progress"`
+}
+
 // SnapshotParameters ...
 type SnapshotParameters struct {
 	// Name of snapshot
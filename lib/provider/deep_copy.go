@@ -0,0 +1,116 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+// DeepCopy returns a copy of the volume sharing no pointer, slice or map with it, nil for a nil volume
+func (v *Volume) DeepCopy() *Volume {
+	if v == nil {
+		return nil
+	}
+	copied := *v
+	copied.Capacity = copyPointer(v.Capacity)
+	copied.Iops = copyPointer(v.Iops)
+	copied.Tier = copyPointer(v.Tier)
+	copied.ServiceOffering = copyPointer(v.ServiceOffering)
+	copied.Name = copyPointer(v.Name)
+	copied.BackendIPAddress = copyPointer(v.BackendIPAddress)
+	copied.FileNetworkMountAddress = copyPointer(v.FileNetworkMountAddress)
+	copied.VolumeNotes = copyMap(v.VolumeNotes)
+	copied.Attributes = copyMap(v.Attributes)
+	copied.IscsiTargetIPAddresses = copySlice(v.IscsiTargetIPAddresses)
+	copied.ResourceGroup = copyPointer(v.ResourceGroup)
+	copied.VolumeEncryptionKey = copyPointer(v.VolumeEncryptionKey)
+	copied.Profile = copyPointer(v.Profile)
+	copied.Tags = copySlice(v.Tags)
+	copied.AccessTags = copySlice(v.AccessTags)
+	if v.VolumeAttachments != nil {
+		attachments := copySlice(*v.VolumeAttachments)
+		copied.VolumeAttachments = &attachments
+	}
+	if v.VolumeAccessPoints != nil {
+		accessPoints := make([]VolumeAccessPoint, len(*v.VolumeAccessPoints))
+		for i, accessPoint := range *v.VolumeAccessPoints {
+			accessPoint.MountPath = copyPointer(accessPoint.MountPath)
+			accessPoint.VPC = copyPointer(accessPoint.VPC)
+			accessPoint.Zone = copyPointer(accessPoint.Zone)
+			accessPoint.CreatedAt = copyPointer(accessPoint.CreatedAt)
+			accessPoints[i] = accessPoint
+		}
+		copied.VolumeAccessPoints = &accessPoints
+	}
+	copied.InitialOwner = copyPointer(v.InitialOwner)
+	copied.AllowedTransitEncryptionModes = copySlice(v.AllowedTransitEncryptionModes)
+	return &copied
+}
+
+// DeepCopy returns a copy of the snapshot sharing no map with it, nil for a nil snapshot
+func (s *Snapshot) DeepCopy() *Snapshot {
+	if s == nil {
+		return nil
+	}
+	copied := *s
+	copied.SnapshotTags = copyMap(s.SnapshotTags)
+	return &copied
+}
+
+// DeepCopy returns a copy of the attachment sharing no pointer or map with it, nil for a nil attachment
+func (a *VolumeAttachmentResponse) DeepCopy() *VolumeAttachmentResponse {
+	if a == nil {
+		return nil
+	}
+	copied := *a
+	copied.VolumeAttachmentRequest = a.VolumeAttachmentRequest.DeepCopy()
+	copied.CreatedAt = copyPointer(a.CreatedAt)
+	return &copied
+}
+
+// DeepCopy returns a copy of the attachment request sharing no pointer or map with it
+func (r VolumeAttachmentRequest) DeepCopy() VolumeAttachmentRequest {
+	r.SoftlayerOptions = copyMap(r.SoftlayerOptions)
+	r.VPCVolumeAttachment = copyPointer(r.VPCVolumeAttachment)
+	if r.IKSVolumeAttachment != nil {
+		r.IKSVolumeAttachment = &IKSVolumeAttachment{ClusterID: copyPointer(r.IKSVolumeAttachment.ClusterID)}
+	}
+	return r
+}
+
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	copied := *p
+	return &copied
+}
+
+func copySlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+func copyMap[M ~map[K]V, K comparable, V any](m M) M {
+	if m == nil {
+		return nil
+	}
+	copied := make(M, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,64 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepCopy(t *testing.T) {
+	capacity, name, mountPath := 10, "vol", "/mnt"
+	volume := &Volume{VolumeID: "vol-1", Capacity: &capacity, Name: &name, Attributes: map[string]string{"a": "1"}}
+	volume.Tags = []string{"env:prod"}
+	volume.VolumeAttachments = &[]VolumeAttachment{{ID: "att-1"}}
+	volume.VolumeAccessPoints = &[]VolumeAccessPoint{{ID: "ap-1", MountPath: &mountPath}}
+
+	copied := volume.DeepCopy()
+	assert.Equal(t, volume, copied)
+	*copied.Capacity = 20
+	*copied.Name = "other"
+	copied.Attributes["a"] = "2"
+	copied.Tags[0] = "env:dev"
+	(*copied.VolumeAttachments)[0].ID = "att-2"
+	*(*copied.VolumeAccessPoints)[0].MountPath = "/other"
+	assert.Equal(t, 10, *volume.Capacity)
+	assert.Equal(t, "vol", *volume.Name)
+	assert.Equal(t, "1", volume.Attributes["a"])
+	assert.Equal(t, []string{"env:prod"}, volume.Tags)
+	assert.Equal(t, "att-1", (*volume.VolumeAttachments)[0].ID)
+	assert.Equal(t, "/mnt", *(*volume.VolumeAccessPoints)[0].MountPath)
+
+	snapshot := &Snapshot{SnapshotID: "snap-1", SnapshotTags: SnapshotTags{"a": "1"}}
+	copiedSnapshot := snapshot.DeepCopy()
+	copiedSnapshot.SnapshotTags["a"] = "2"
+	assert.Equal(t, "1", snapshot.SnapshotTags["a"])
+
+	now := time.Now()
+	attachment := &VolumeAttachmentResponse{CreatedAt: &now}
+	attachment.VPCVolumeAttachment = &VolumeAttachment{ID: "att-1"}
+	copiedAttachment := attachment.DeepCopy()
+	copiedAttachment.VPCVolumeAttachment.ID = "att-2"
+	assert.Equal(t, "att-1", attachment.VPCVolumeAttachment.ID)
+
+	assert.Nil(t, (*Volume)(nil).DeepCopy())
+	assert.Nil(t, (*Snapshot)(nil).DeepCopy())
+	assert.Nil(t, (*VolumeAttachmentResponse)(nil).DeepCopy())
+}
@@ -17,7 +17,11 @@
 // Package provider ...
 package provider
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"time"
+)
 
 //DefaultVolumeProvider Implementation
 type DefaultVolumeProvider struct {
@@ -51,6 +55,11 @@ func (volprov *DefaultVolumeProvider) CreateVolumeFromSnapshot(snapshot Snapshot
 	return nil, nil
 }
 
+// CreateVolumeFromVolume creates a clone of the volume
+func (volprov *DefaultVolumeProvider) CreateVolumeFromVolume(cloneRequest VolumeCloneRequest) (*VolumeCloneResponse, error) {
+	return nil, nil
+}
+
 //UpdateVolume the volume
 func (volprov *DefaultVolumeProvider) UpdateVolume(Volume) error {
 	return nil
@@ -78,6 +87,11 @@ func (volprov *DefaultVolumeProvider) ListVolumes(limit int, start string, tags
 	return nil, nil
 }
 
+// ListVolumesWithFilters lists the volumes matching the filters
+func (volprov *DefaultVolumeProvider) ListVolumesWithFilters(limit int, start string, filters ListVolumesFilters) (*VolumeList, error) {
+	return nil, nil
+}
+
 // GetVolumeByRequestID fetch the volume by request ID.
 // Request Id is the one that is returned when volume is provsioning request is
 // placed with Iaas provider.
@@ -112,6 +126,16 @@ func (volprov *DefaultVolumeProvider) GetVolumeAttachment(attachRequest VolumeAt
 	return nil, nil
 }
 
+// UpdateVolumeAttachment updates the given volume attachment
+func (volprov *DefaultVolumeProvider) UpdateVolumeAttachment(updateRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error) {
+	return nil, nil
+}
+
+// ListVolumeAttachments lists the attachments of the volume
+func (volprov *DefaultVolumeProvider) ListVolumeAttachments(volumeID string) ([]*VolumeAttachmentResponse, error) {
+	return nil, nil
+}
+
 //OrderSnapshot orders the snapshot
 func (volprov *DefaultVolumeProvider) OrderSnapshot(VolumeRequest Volume) error {
 	return nil
@@ -142,16 +166,56 @@ func (volprov *DefaultVolumeProvider) ListSnapshots(limit int, start string, tag
 	return nil, nil
 }
 
+// RestoreVolumeFromSnapshot creates a volume from the snapshot
+func (volprov *DefaultVolumeProvider) RestoreVolumeFromSnapshot(restoreRequest SnapshotRestoreRequest) (*SnapshotRestoreResponse, error) {
+	return nil, nil
+}
+
+// GetSnapshotRestoreProgress returns the progress of the restore of the volume
+func (volprov *DefaultVolumeProvider) GetSnapshotRestoreProgress(volumeID string) (*SnapshotRestoreProgress, error) {
+	return nil, nil
+}
+
 //ExpandVolume expand the volume with authorization by passing required information in the volume object
 func (volprov *DefaultVolumeProvider) ExpandVolume(expandVolumeRequest ExpandVolumeRequest) (int64, error) {
 	return 0, nil
 }
 
+// SupportsOnlineExpansion returns true if the volume can be expanded while attached
+func (volprov *DefaultVolumeProvider) SupportsOnlineExpansion(volume *Volume) bool {
+	return false
+}
+
+// UpdateVolumeProfile changes the profile of the volume
+func (volprov *DefaultVolumeProvider) UpdateVolumeProfile(updateRequest VolumeProfileUpdateRequest) (*Volume, error) {
+	return nil, nil
+}
+
+// UpdateVolumeIOPS changes the IOPS of the volume
+func (volprov *DefaultVolumeProvider) UpdateVolumeIOPS(updateRequest VolumeIOPSUpdateRequest) (*Volume, error) {
+	return nil, nil
+}
+
+// GetVolumeTags returns the tags of the volume
+func (volprov *DefaultVolumeProvider) GetVolumeTags(volumeID string) (*VolumeTags, error) {
+	return nil, nil
+}
+
+// UpdateVolumeTags updates the tags of the volume
+func (volprov *DefaultVolumeProvider) UpdateVolumeTags(updateRequest VolumeTagsUpdateRequest) (*VolumeTags, error) {
+	return nil, nil
+}
+
 //GetProviderDisplayName gets provider by displayname
 func (volprov *DefaultVolumeProvider) GetProviderDisplayName() VolumeProvider {
 	return ""
 }
 
+// Stats returns the session counters
+func (volprov *DefaultVolumeProvider) Stats() ProviderStats {
+	return ProviderStats{}
+}
+
 //Close is called when the Session is nolonger required
 func (volprov *DefaultVolumeProvider) Close() {
 }
@@ -182,3 +246,48 @@ func (volprov *DefaultVolumeProvider) WaitForDeleteVolumeAccessPoint(deleteAcces
 func (volprov *DefaultVolumeProvider) GetVolumeAccessPoint(accessPointRequest VolumeAccessPointRequest) (*VolumeAccessPointResponse, error) {
 	return nil, nil
 }
+
+// CreateShare creates the file share of the request
+func (volprov *DefaultVolumeProvider) CreateShare(shareRequest FileShareRequest) (*FileShare, error) {
+	return nil, nil
+}
+
+// DeleteShare deletes the file share
+func (volprov *DefaultVolumeProvider) DeleteShare(shareID string) error {
+	return nil
+}
+
+// CreateShareTarget creates the mount target of the share
+func (volprov *DefaultVolumeProvider) CreateShareTarget(targetRequest ShareTargetRequest) (*ShareTarget, error) {
+	return nil, nil
+}
+
+// DeleteShareTarget deletes the mount target of the share
+func (volprov *DefaultVolumeProvider) DeleteShareTarget(targetRequest ShareTargetRequest) error {
+	return nil
+}
+
+// ListShares lists the file shares with the tags
+func (volprov *DefaultVolumeProvider) ListShares(limit int, start string, tags map[string]string) (*FileShareList, error) {
+	return nil, nil
+}
+
+// ExpandShare expands the share to the capacity of the request
+func (volprov *DefaultVolumeProvider) ExpandShare(expandRequest ExpandShareRequest) (int64, error) {
+	return 0, nil
+}
+
+//GetVolumePerformanceStats returns the backend reported performance stats of the volume
+func (volprov *DefaultVolumeProvider) GetVolumePerformanceStats(ctx context.Context, volumeID string, window time.Duration) (*VolumePerformanceStats, error) {
+	return nil, nil
+}
+
+// GetCapabilities returns the capabilities of the session
+func (volprov *DefaultVolumeProvider) GetCapabilities() (*Capabilities, error) {
+	return nil, nil
+}
+
+// GetResourceEvents returns the backend activity and health events of the resource
+func (volprov *DefaultVolumeProvider) GetResourceEvents(ctx context.Context, resourceID string, since time.Time) ([]*ResourceEvent, error) {
+	return nil, nil
+}
@@ -18,7 +18,9 @@
 package provider
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -49,6 +51,17 @@ func TestGetProviderDisplayName(t *testing.T) {
 	assert.Empty(t, ccf.GetProviderDisplayName())
 }
 
+func TestStats(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	stats := ccf.Stats()
+	assert.Empty(t, stats.Operations)
+
+	data, err := stats.JSON()
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "operations")
+}
+
 func TestCreateVolume(t *testing.T) {
 	ccf := &DefaultVolumeProvider{sess: nil}
 
@@ -88,6 +101,21 @@ func TestGetVolumeAttachment(t *testing.T) {
 	volAttachment, _ := ccf.GetVolumeAttachment(VolumeAttachmentRequest{})
 	assert.Nil(t, volAttachment)
 }
+
+func TestUpdateVolumeAttachment(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	volAttachment, _ := ccf.UpdateVolumeAttachment(VolumeAttachmentRequest{})
+	assert.Nil(t, volAttachment)
+}
+
+func TestListVolumeAttachments(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	attachments, err := ccf.ListVolumeAttachments("vol-1")
+	assert.Nil(t, attachments)
+	assert.Nil(t, err)
+}
 func TestExpandVolume(t *testing.T) {
 	ccf := &DefaultVolumeProvider{sess: nil}
 
@@ -95,6 +123,68 @@ func TestExpandVolume(t *testing.T) {
 	assert.Equal(t, int64(0), res)
 }
 
+func TestSupportsOnlineExpansion(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	assert.False(t, ccf.SupportsOnlineExpansion(&Volume{VolumeID: "vol-1"}))
+}
+
+func TestUpdateVolumeProfile(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	volume, err := ccf.UpdateVolumeProfile(VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "custom"})
+	assert.Nil(t, volume)
+	assert.Nil(t, err)
+}
+
+func TestUpdateVolumeIOPS(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	volume, err := ccf.UpdateVolumeIOPS(VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 1000})
+	assert.Nil(t, volume)
+	assert.Nil(t, err)
+}
+
+func TestListVolumesWithFilters(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	volumes, err := ccf.ListVolumesWithFilters(50, "", ListVolumesFilters{ZoneName: "us-south-1"})
+	assert.Nil(t, volumes)
+	assert.Nil(t, err)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	capabilities, err := ccf.GetCapabilities()
+	assert.Nil(t, capabilities)
+	assert.Nil(t, err)
+}
+
+func TestGetVolumeTags(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	tags, err := ccf.GetVolumeTags("vol-1")
+	assert.Nil(t, tags)
+	assert.Nil(t, err)
+}
+
+func TestUpdateVolumeTags(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	tags, err := ccf.UpdateVolumeTags(VolumeTagsUpdateRequest{VolumeID: "vol-1", Attach: []string{"env:dev"}})
+	assert.Nil(t, tags)
+	assert.Nil(t, err)
+}
+
+func TestCreateVolumeFromVolume(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	res, err := ccf.CreateVolumeFromVolume(VolumeCloneRequest{SourceVolumeID: "vol-1"})
+	assert.Nil(t, res)
+	assert.Nil(t, err)
+}
+
 func TestCreateVolumeFromSnapshot(t *testing.T) {
 	ccf := &DefaultVolumeProvider{sess: nil}
 
@@ -135,6 +225,22 @@ func TestListSnapshots(t *testing.T) {
 	assert.Nil(t, listSnapWithID)
 }
 
+func TestRestoreVolumeFromSnapshot(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	res, err := ccf.RestoreVolumeFromSnapshot(SnapshotRestoreRequest{SnapshotID: "snap-1"})
+	assert.Nil(t, res)
+	assert.Nil(t, err)
+}
+
+func TestGetSnapshotRestoreProgress(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	progress, err := ccf.GetSnapshotRestoreProgress("vol-1")
+	assert.Nil(t, progress)
+	assert.Nil(t, err)
+}
+
 func TestUpdateVolume(t *testing.T) {
 	ccf := &DefaultVolumeProvider{sess: nil}
 
@@ -214,3 +320,57 @@ func TestGetVolumeAccessPoint(t *testing.T) {
 	accessPointResponse, _ := ccf.GetVolumeAccessPoint(VolumeAccessPointRequest{})
 	assert.Nil(t, accessPointResponse)
 }
+
+func TestCreateShare(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	share, _ := ccf.CreateShare(FileShareRequest{})
+	assert.Nil(t, share)
+}
+
+func TestDeleteShare(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	assert.Nil(t, ccf.DeleteShare("share-id"))
+}
+
+func TestCreateShareTarget(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	target, _ := ccf.CreateShareTarget(ShareTargetRequest{})
+	assert.Nil(t, target)
+}
+
+func TestDeleteShareTarget(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	assert.Nil(t, ccf.DeleteShareTarget(ShareTargetRequest{}))
+}
+
+func TestListShares(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	shares, _ := ccf.ListShares(10, "", nil)
+	assert.Nil(t, shares)
+}
+
+func TestExpandShare(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	capacity, _ := ccf.ExpandShare(ExpandShareRequest{})
+	assert.Equal(t, int64(0), capacity)
+}
+
+func TestGetVolumePerformanceStats(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	stats, _ := ccf.GetVolumePerformanceStats(context.TODO(), "volume-id", time.Minute)
+	assert.Nil(t, stats)
+}
+
+func TestGetResourceEvents(t *testing.T) {
+	ccf := &DefaultVolumeProvider{sess: nil}
+
+	events, _ := ccf.GetResourceEvents(context.TODO(), "volume-id", time.Now())
+	assert.Nil(t, events)
+}
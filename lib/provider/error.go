@@ -18,6 +18,8 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
 )
 
@@ -26,6 +28,9 @@ import (
 type Error struct {
 	// Fault ...
 	Fault Fault
+
+	// Cause is the error wrapped by this one (if applicable), reachable with errors.Is and errors.As
+	Cause error `json:"-"`
 }
 
 // Fault encodes a fault condition.
@@ -43,6 +48,18 @@ type Fault struct {
 
 	// Properties contains diagnostic properties (if applicable)
 	Properties map[string]string `json:"properties,omitempty"`
+
+	// RetryAfter is the suggested wait before retrying (if applicable)
+	RetryAfter time.Duration `json:"retryAfter,omitempty"`
+
+	// NotBefore is the time before which the operation should not be retried (if applicable)
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+
+	// Retryable marks the fault retryable even if its reason code is not (if applicable)
+	Retryable bool `json:"retryable,omitempty"`
+
+	// Kind overrides the fault kind of the reason code (if applicable)
+	Kind FaultKind `json:"kind,omitempty"`
 }
 
 // FaultResponse is an optional Fault
@@ -52,6 +69,28 @@ type FaultResponse struct {
 
 var _ error = Error{}
 
+// ErrInstanceNotFound is returned by attach/detach operations when the instance no longer exists.
+// Use errors.Is(err, ErrInstanceNotFound) to detect it
+var ErrInstanceNotFound = Error{Fault: Fault{ReasonCode: reasoncode.ErrorInstanceNotFound, Message: "Instance not found"}}
+
+// ErrAttachConflict is returned by attach operations when the volume is already attached to another instance
+var ErrAttachConflict = Error{Fault: Fault{ReasonCode: reasoncode.ErrorVolumeAttachConflict, Message: "Volume is attached to another instance"}}
+
+// ErrDeletionStuck is returned when a volume still exists after waiting for its deletion to complete
+var ErrDeletionStuck = Error{Fault: Fault{ReasonCode: reasoncode.ErrorDeletionStuck, Message: "Volume deletion did not complete"}}
+
+// ErrDeletionProtected is returned when deleting a volume protected against deletion without forcing it
+var ErrDeletionProtected = Error{Fault: Fault{ReasonCode: reasoncode.ErrorVolumeDeletionProtected, Message: "Volume is protected against deletion"}}
+
+// ErrOperationAbandoned is returned when an operation exceeds the global operation timeout
+var ErrOperationAbandoned = Error{Fault: Fault{ReasonCode: reasoncode.ErrorOperationAbandoned, Message: "Operation abandoned after exceeding the maximum operation timeout"}}
+
+// ErrWaiterCancelled is returned by a wait for an operation cancelled with util.WaiterRegistry.CancelWaiter
+var ErrWaiterCancelled = Error{Fault: Fault{ReasonCode: reasoncode.ErrorWaiterCancelled, Message: "Wait for the operation was cancelled"}}
+
+// ErrMaintenanceFreeze is returned by mutating operations while the library is read-only (see util.MaintenanceFreeze)
+var ErrMaintenanceFreeze = Error{Fault: Fault{ReasonCode: reasoncode.ErrorMaintenanceFreeze, Message: "Mutating operations are frozen for maintenance"}}
+
 // Error satisfies the error contract
 func (err Error) Error() string {
 	return err.Fault.Message
@@ -74,3 +113,42 @@ func (err Error) Wrapped() []string {
 func (err Error) Properties() map[string]string {
 	return err.Fault.Properties
 }
+
+// Is reports whether target is an Error with the same reason code, so that
+// errors.Is can be used against the exported error values
+func (err Error) Is(target error) bool {
+	t, ok := target.(Error)
+	return ok && t.Fault.ReasonCode != "" && t.Fault.ReasonCode == err.Fault.ReasonCode
+}
+
+// Unwrap returns the cause of the error, if any
+func (err Error) Unwrap() error {
+	return err.Cause
+}
+
+// Classification returns the classification of the reason code (see ErrorCategories), with the overrides of the fault
+func (err Error) Classification() ErrorClassification {
+	classification := ClassifyReasonCode(err.Code())
+	if err.Fault.Retryable {
+		classification.Retryable = true
+	}
+	if err.Fault.Kind != "" {
+		classification.Kind = err.Fault.Kind
+	}
+	return classification
+}
+
+// Category returns the category of the error
+func (err Error) Category() ErrorCategory {
+	return err.Classification().Category
+}
+
+// Retryable reports whether the operation can be retried after the error
+func (err Error) Retryable() bool {
+	return err.Classification().Retryable
+}
+
+// IsUserFault reports whether the caller is at fault, rather than the infrastructure
+func (err Error) IsUserFault() bool {
+	return err.Classification().Kind == FaultUser
+}
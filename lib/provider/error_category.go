@@ -0,0 +1,124 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// ErrorCategory groups the reason codes the callers branch on, independently of the provider
+type ErrorCategory string
+
+const (
+	// CategoryUnknown is the category of the unclassified errors
+	CategoryUnknown = ErrorCategory("Unknown")
+	// CategoryInvalidRequest is an invalid or incomplete request
+	CategoryInvalidRequest = ErrorCategory("InvalidRequest")
+	// CategoryUnsupported is a feature, method or provider which is not supported
+	CategoryUnsupported = ErrorCategory("Unsupported")
+	// CategoryNotFound is a volume, snapshot or instance which does not exist
+	CategoryNotFound = ErrorCategory("NotFound")
+	// CategoryAuthFailure is a failed authentication (expired or unknown credentials, locked account)
+	CategoryAuthFailure = ErrorCategory("AuthFailure")
+	// CategoryPermissionDenied is an authenticated caller lacking the permissions
+	CategoryPermissionDenied = ErrorCategory("PermissionDenied")
+	// CategoryQuotaExceeded is an account or resource group quota reached
+	CategoryQuotaExceeded = ErrorCategory("QuotaExceeded")
+	// CategoryInsufficientCapacity is a zone lacking the capacity, another zone might have it
+	CategoryInsufficientCapacity = ErrorCategory("InsufficientCapacity")
+	// CategoryConflict is a request conflicting with the state of the resource (attached, protected, ...)
+	CategoryConflict = ErrorCategory("Conflict")
+	// CategoryRateLimited is a request rejected by the rate limits
+	CategoryRateLimited = ErrorCategory("RateLimited")
+	// CategoryUnavailable is a backend which is temporarily unreachable or failing
+	CategoryUnavailable = ErrorCategory("Unavailable")
+	// CategoryTimeout is an operation which did not complete in time
+	CategoryTimeout = ErrorCategory("Timeout")
+	// CategoryInternal is an unexpected failure of the library or the backend
+	CategoryInternal = ErrorCategory("Internal")
+)
+
+// FaultKind tells whether the caller or the infrastructure is at fault
+type FaultKind string
+
+const (
+	// FaultUser is an error the caller must fix (request, credentials, quota), retrying as is does not help
+	FaultUser = FaultKind("user")
+	// FaultInfrastructure is an error of the backend or the library
+	FaultInfrastructure = FaultKind("infrastructure")
+)
+
+// ErrorClassification is the category, fault kind and retryability of a reason code
+type ErrorClassification struct {
+	Category  ErrorCategory `json:"category"`
+	Kind      FaultKind     `json:"kind"`
+	Retryable bool          `json:"retryable"`
+}
+
+// ErrorCategories maps the reason codes to their classification, the reason codes missing from the table are
+// CategoryUnknown infrastructure errors which are not retryable. Extend the table with every new reason code
+var ErrorCategories = map[reasoncode.ReasonCode]ErrorClassification{
+	reasoncode.ErrorUnclassified: {Category: CategoryUnknown, Kind: FaultInfrastructure},
+	reasoncode.ErrorPanic:        {Category: CategoryInternal, Kind: FaultInfrastructure},
+
+	reasoncode.ErrorTemporaryConnectionProblem: {Category: CategoryUnavailable, Kind: FaultInfrastructure, Retryable: true},
+	reasoncode.EndpointNotReachable:            {Category: CategoryUnavailable, Kind: FaultInfrastructure, Retryable: true},
+	reasoncode.ErrorCircuitOpen:                {Category: CategoryUnavailable, Kind: FaultInfrastructure},
+	reasoncode.ErrorRateLimitExceeded:          {Category: CategoryRateLimited, Kind: FaultInfrastructure, Retryable: true},
+	reasoncode.Timeout:                         {Category: CategoryTimeout, Kind: FaultInfrastructure, Retryable: true},
+	reasoncode.ErrorOperationAbandoned:         {Category: CategoryTimeout, Kind: FaultInfrastructure, Retryable: true},
+	reasoncode.ErrorWaiterCancelled:            {Category: CategoryTimeout, Kind: FaultUser},
+	reasoncode.ErrorMaintenanceFreeze:          {Category: CategoryUnavailable, Kind: FaultUser},
+	reasoncode.ErrorDeletionStuck:              {Category: CategoryTimeout, Kind: FaultInfrastructure, Retryable: true},
+
+	reasoncode.ErrorBadRequest:           {Category: CategoryInvalidRequest, Kind: FaultUser},
+	reasoncode.ErrorRequiredFieldMissing: {Category: CategoryInvalidRequest, Kind: FaultUser},
+	reasoncode.ErrorUnsupportedAuthType:  {Category: CategoryUnsupported, Kind: FaultUser},
+	reasoncode.ErrorUnsupportedMethod:    {Category: CategoryUnsupported, Kind: FaultUser},
+	reasoncode.ErrorUnsupportedFeature:   {Category: CategoryUnsupported, Kind: FaultUser},
+	reasoncode.ErrorUnknownProvider:      {Category: CategoryUnsupported, Kind: FaultUser},
+
+	reasoncode.ErrorResourceNotFound: {Category: CategoryNotFound, Kind: FaultUser},
+	reasoncode.ErrorInstanceNotFound: {Category: CategoryNotFound, Kind: FaultUser},
+
+	reasoncode.ErrorResourceAlreadyExists: {Category: CategoryConflict, Kind: FaultUser},
+
+	reasoncode.ErrorUnauthorised:                     {Category: CategoryAuthFailure, Kind: FaultUser},
+	reasoncode.ErrorFailedTokenExchange:              {Category: CategoryAuthFailure, Kind: FaultUser},
+	reasoncode.ErrorProviderAccountTemporarilyLocked: {Category: CategoryAuthFailure, Kind: FaultUser},
+	reasoncode.ErrorInsufficientPermissions:          {Category: CategoryPermissionDenied, Kind: FaultUser},
+	reasoncode.ErrorEncryptionKeyNotAuthorized:       {Category: CategoryPermissionDenied, Kind: FaultUser},
+
+	reasoncode.ErrorVolumeAttachConflict:    {Category: CategoryConflict, Kind: FaultUser},
+	reasoncode.ErrorVolumeDeletionProtected: {Category: CategoryConflict, Kind: FaultUser},
+	reasoncode.ErrorVolumeFenced:            {Category: CategoryConflict, Kind: FaultUser},
+	reasoncode.ErrorVolumeAttachFailed:      {Category: CategoryInternal, Kind: FaultInfrastructure},
+	reasoncode.ErrorVolumeDetachFailed:      {Category: CategoryInternal, Kind: FaultInfrastructure},
+	reasoncode.ErrorSnapshotPruneFailed:     {Category: CategoryInternal, Kind: FaultInfrastructure},
+
+	reasoncode.ErrorQuotaExceeded:        {Category: CategoryQuotaExceeded, Kind: FaultUser},
+	reasoncode.ErrorInsufficientCapacity: {Category: CategoryInsufficientCapacity, Kind: FaultInfrastructure, Retryable: true},
+}
+
+// ClassifyReasonCode returns the classification of the reason code in ErrorCategories
+func ClassifyReasonCode(code reasoncode.ReasonCode) ErrorClassification {
+	if classification, found := ErrorCategories[code]; found {
+		return classification
+	}
+	return ErrorClassification{Category: CategoryUnknown, Kind: FaultInfrastructure}
+}
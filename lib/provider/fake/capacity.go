@@ -0,0 +1,252 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake ...
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// Quotas are the simulated account quotas, a zero quota is unlimited
+type Quotas struct {
+	MaxVolumes     int
+	MaxCapacityGiB int
+	MaxSnapshots   int
+}
+
+// CapacityUsage is the simulated usage of the account and the free capacity of the zone pools
+type CapacityUsage struct {
+	Volumes     int
+	CapacityGiB int
+	Snapshots   int
+
+	// FreeCapacityGiB of the zones with a capacity pool
+	FreeCapacityGiB map[string]int
+}
+
+type simulatedVolume struct {
+	zone     string
+	capacity int
+}
+
+// CapacitySimulator simulates the account quotas and per zone capacity pools of a FakeSession, so autoscaler
+// and scheduler integrations can be tested under capacity pressure. Requests exceeding a quota fail with an
+// ErrorQuotaExceeded error, requests exceeding the pool of their zone with an ErrorInsufficientCapacity error,
+// wrapping the backend error payload
+type CapacitySimulator struct {
+	mu        sync.Mutex
+	quotas    Quotas
+	pools     map[string]int
+	volumes   map[string]simulatedVolume
+	snapshots map[string]bool
+	nextID    int
+}
+
+// NewCapacitySimulator returns a simulator with the quotas and no zone capacity pool (unlimited zones)
+func NewCapacitySimulator(quotas Quotas) *CapacitySimulator {
+	return &CapacitySimulator{
+		quotas:    quotas,
+		pools:     map[string]int{},
+		volumes:   map[string]simulatedVolume{},
+		snapshots: map[string]bool{},
+	}
+}
+
+// SetQuotas replaces the quotas, the existing resources are kept even if they exceed them
+func (s *CapacitySimulator) SetQuotas(quotas Quotas) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quotas = quotas
+}
+
+// SetZoneCapacity sets the total capacity of the pool of the zone, in GiB. The volumes of the zone use it up
+func (s *CapacitySimulator) SetZoneCapacity(zone string, capacityGiB int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pools[zone] = capacityGiB
+}
+
+// Usage returns the simulated usage
+func (s *CapacitySimulator) Usage() CapacityUsage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage := CapacityUsage{Volumes: len(s.volumes), Snapshots: len(s.snapshots), FreeCapacityGiB: map[string]int{}}
+	for _, volume := range s.volumes {
+		usage.CapacityGiB += volume.capacity
+	}
+	for zone := range s.pools {
+		usage.FreeCapacityGiB[zone] = s.freeCapacity(zone)
+	}
+	return usage
+}
+
+// Install sets the CreateVolume, ExpandVolume, DeleteVolume, CreateSnapshot and DeleteSnapshot stubs of
+// the session to the simulated ones
+func (s *CapacitySimulator) Install(sess *FakeSession) {
+	sess.CreateVolumeStub = s.CreateVolume
+	sess.ExpandVolumeStub = s.ExpandVolume
+	sess.DeleteVolumeStub = s.DeleteVolume
+	sess.CreateSnapshotStub = s.CreateSnapshot
+	sess.DeleteSnapshotStub = s.DeleteSnapshot
+}
+
+// CreateVolume creates the volume if the quotas and the pool of its zone allow it
+func (s *CapacitySimulator) CreateVolume(volumeRequest provider.Volume) (*provider.Volume, error) {
+	capacity := 0
+	if volumeRequest.Capacity != nil {
+		capacity = *volumeRequest.Capacity
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.quotas.MaxVolumes > 0 && len(s.volumes) >= s.quotas.MaxVolumes {
+		return nil, quotaExceeded(fmt.Sprintf("The quota of %d volumes of the account is exceeded", s.quotas.MaxVolumes))
+	}
+	if err := s.checkCapacity(volumeRequest.Az, capacity); err != nil {
+		return nil, err
+	}
+
+	s.nextID++
+	volume := volumeRequest
+	volume.VolumeID = fmt.Sprintf("fake-vol-%d", s.nextID)
+	s.volumes[volume.VolumeID] = simulatedVolume{zone: volume.Az, capacity: capacity}
+	return &volume, nil
+}
+
+// ExpandVolume expands the volume if the quotas and the pool of its zone allow the additional capacity
+func (s *CapacitySimulator) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	volume, ok := s.volumes[expandVolumeRequest.VolumeID]
+	if !ok {
+		return 0, volumeNotFound(expandVolumeRequest.VolumeID)
+	}
+	capacity := int(expandVolumeRequest.Capacity)
+	if capacity <= volume.capacity {
+		return int64(volume.capacity), nil
+	}
+	if err := s.checkCapacity(volume.zone, capacity-volume.capacity); err != nil {
+		return 0, err
+	}
+	volume.capacity = capacity
+	s.volumes[expandVolumeRequest.VolumeID] = volume
+	return int64(capacity), nil
+}
+
+// DeleteVolume deletes the volume, releasing its capacity
+func (s *CapacitySimulator) DeleteVolume(volume *provider.Volume) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.volumes[volume.VolumeID]; !ok {
+		return volumeNotFound(volume.VolumeID)
+	}
+	delete(s.volumes, volume.VolumeID)
+	return nil
+}
+
+// CreateSnapshot creates the snapshot of the volume if the snapshot quota allows it
+func (s *CapacitySimulator) CreateSnapshot(sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (*provider.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.volumes[sourceVolumeID]; !ok {
+		return nil, volumeNotFound(sourceVolumeID)
+	}
+	if s.quotas.MaxSnapshots > 0 && len(s.snapshots) >= s.quotas.MaxSnapshots {
+		return nil, quotaExceeded(fmt.Sprintf("The quota of %d snapshots of the account is exceeded", s.quotas.MaxSnapshots))
+	}
+	s.nextID++
+	snapshot := &provider.Snapshot{VolumeID: sourceVolumeID, SnapshotID: fmt.Sprintf("fake-snap-%d", s.nextID), SnapshotTags: snapshotParameters.SnapshotTags, ReadyToUse: true}
+	s.snapshots[snapshot.SnapshotID] = true
+	return snapshot, nil
+}
+
+// DeleteSnapshot deletes the snapshot
+func (s *CapacitySimulator) DeleteSnapshot(snapshot *provider.Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.snapshots[snapshot.SnapshotID] {
+		return backendError(reasoncode.ErrorResourceNotFound, "snapshot_not_found", fmt.Sprintf("Snapshot %s not found", snapshot.SnapshotID), nil)
+	}
+	delete(s.snapshots, snapshot.SnapshotID)
+	return nil
+}
+
+// checkCapacity returns an error if the capacity quota or the pool of the zone cannot hold the additional capacity,
+// the caller holds s.mu
+func (s *CapacitySimulator) checkCapacity(zone string, additional int) error {
+	if s.quotas.MaxCapacityGiB > 0 {
+		used := 0
+		for _, volume := range s.volumes {
+			used += volume.capacity
+		}
+		if used+additional > s.quotas.MaxCapacityGiB {
+			return quotaExceeded(fmt.Sprintf("The capacity quota of %d GiB of the account is exceeded", s.quotas.MaxCapacityGiB))
+		}
+	}
+	if _, ok := s.pools[zone]; ok && additional > s.freeCapacity(zone) {
+		return backendError(reasoncode.ErrorInsufficientCapacity, "insufficient_capacity",
+			fmt.Sprintf("The zone %s has insufficient capacity, %d GiB free", zone, s.freeCapacity(zone)), map[string]string{"Zone": zone})
+	}
+	return nil
+}
+
+// freeCapacity returns the free capacity of the pool of the zone, the caller holds s.mu
+func (s *CapacitySimulator) freeCapacity(zone string) int {
+	free := s.pools[zone]
+	for _, volume := range s.volumes {
+		if volume.zone == zone {
+			free -= volume.capacity
+		}
+	}
+	return free
+}
+
+// ZonesByFreeCapacity returns the zones with a capacity pool, the most free capacity first
+func (s *CapacitySimulator) ZonesByFreeCapacity() []string {
+	usage := s.Usage()
+	zones := make([]string, 0, len(usage.FreeCapacityGiB))
+	for zone := range usage.FreeCapacityGiB {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		if usage.FreeCapacityGiB[zones[i]] == usage.FreeCapacityGiB[zones[j]] {
+			return zones[i] < zones[j]
+		}
+		return usage.FreeCapacityGiB[zones[i]] > usage.FreeCapacityGiB[zones[j]]
+	})
+	return zones
+}
+
+func quotaExceeded(message string) error {
+	return backendError(reasoncode.ErrorQuotaExceeded, "over_quota", message, nil)
+}
+
+func volumeNotFound(volumeID string) error {
+	return backendError(reasoncode.ErrorResourceNotFound, "volume_not_found", fmt.Sprintf("Volume %s not found", volumeID), map[string]string{"VolumeID": volumeID})
+}
+
+// backendError returns the provider error wrapping the RIaaS error payload of the backend code, it is built like
+// util.NewErrorWithProperties which cannot be imported here (util tests use this package)
+func backendError(code reasoncode.ReasonCode, backendCode, message string, properties map[string]string) error {
+	payload := fmt.Sprintf(`{"errors":[{"code":%q,"message":%q}]}`, backendCode, message)
+	return provider.Error{Fault: provider.Fault{ReasonCode: code, Message: message, Properties: properties, Wrapped: []string{payload}}}
+}
@@ -0,0 +1,97 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake ...
+package fake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func reasonCode(err error) reasoncode.ReasonCode {
+	if perr, ok := err.(provider.Error); ok {
+		return perr.Fault.ReasonCode
+	}
+	return ""
+}
+
+func volumeRequest(zone string, capacity int) provider.Volume {
+	return provider.Volume{Az: zone, Capacity: &capacity}
+}
+
+func TestCapacitySimulatorQuotas(t *testing.T) {
+	simulator := NewCapacitySimulator(Quotas{MaxVolumes: 2, MaxCapacityGiB: 100, MaxSnapshots: 1})
+	sess := &FakeSession{}
+	simulator.Install(sess)
+
+	first, err := sess.CreateVolume(volumeRequest("us-south-1", 50))
+	assert.Nil(t, err)
+	_, err = sess.CreateVolume(volumeRequest("us-south-1", 60))
+	assert.Equal(t, reasoncode.ErrorQuotaExceeded, reasonCode(err))
+	second, err := sess.CreateVolume(volumeRequest("us-south-2", 50))
+	assert.Nil(t, err)
+	_, err = sess.CreateVolume(volumeRequest("us-south-2", 1))
+	assert.Equal(t, reasoncode.ErrorQuotaExceeded, reasonCode(err))
+	assert.True(t, strings.Contains(err.(provider.Error).Fault.Wrapped[0], `"code":"over_quota"`))
+
+	_, err = sess.ExpandVolume(provider.ExpandVolumeRequest{VolumeID: second.VolumeID, Capacity: 51})
+	assert.Equal(t, reasoncode.ErrorQuotaExceeded, reasonCode(err))
+
+	snapshot, err := sess.CreateSnapshot(first.VolumeID, provider.SnapshotParameters{})
+	assert.Nil(t, err)
+	_, err = sess.CreateSnapshot(first.VolumeID, provider.SnapshotParameters{})
+	assert.Equal(t, reasoncode.ErrorQuotaExceeded, reasonCode(err))
+	assert.Nil(t, sess.DeleteSnapshot(snapshot))
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, reasonCode(sess.DeleteSnapshot(snapshot)))
+
+	assert.Nil(t, sess.DeleteVolume(first))
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, reasonCode(sess.DeleteVolume(first)))
+	assert.Equal(t, CapacityUsage{Volumes: 1, CapacityGiB: 50, FreeCapacityGiB: map[string]int{}}, simulator.Usage())
+
+	// Raising the quota relieves the pressure
+	simulator.SetQuotas(Quotas{})
+	capacity, err := sess.ExpandVolume(provider.ExpandVolumeRequest{VolumeID: second.VolumeID, Capacity: 500})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(500), capacity)
+}
+
+func TestCapacitySimulatorZonePools(t *testing.T) {
+	simulator := NewCapacitySimulator(Quotas{})
+	simulator.SetZoneCapacity("us-south-1", 100)
+	simulator.SetZoneCapacity("us-south-2", 200)
+	sess := &FakeSession{}
+	simulator.Install(sess)
+
+	volume, err := sess.CreateVolume(volumeRequest("us-south-1", 80))
+	assert.Nil(t, err)
+	_, err = sess.CreateVolume(volumeRequest("us-south-1", 30))
+	assert.Equal(t, reasoncode.ErrorInsufficientCapacity, reasonCode(err))
+	assert.Equal(t, "us-south-1", err.(provider.Error).Fault.Properties["Zone"])
+	_, err = sess.ExpandVolume(provider.ExpandVolumeRequest{VolumeID: volume.VolumeID, Capacity: 120})
+	assert.Equal(t, reasoncode.ErrorInsufficientCapacity, reasonCode(err))
+
+	// Zones without a pool are unlimited
+	_, err = sess.CreateVolume(volumeRequest("us-south-3", 10000))
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]int{"us-south-1": 20, "us-south-2": 200}, simulator.Usage().FreeCapacityGiB)
+	assert.Equal(t, []string{"us-south-2", "us-south-1"}, simulator.ZonesByFreeCapacity())
+}
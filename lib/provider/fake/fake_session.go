@@ -2,8 +2,10 @@
 package fake
 
 import (
+	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 )
@@ -37,6 +39,32 @@ type FakeSession struct {
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct {
 	}
+	CreateShareStub        func(provider.FileShareRequest) (*provider.FileShare, error)
+	createShareMutex       sync.RWMutex
+	createShareArgsForCall []struct {
+		arg1 provider.FileShareRequest
+	}
+	createShareReturns struct {
+		result1 *provider.FileShare
+		result2 error
+	}
+	createShareReturnsOnCall map[int]struct {
+		result1 *provider.FileShare
+		result2 error
+	}
+	CreateShareTargetStub        func(provider.ShareTargetRequest) (*provider.ShareTarget, error)
+	createShareTargetMutex       sync.RWMutex
+	createShareTargetArgsForCall []struct {
+		arg1 provider.ShareTargetRequest
+	}
+	createShareTargetReturns struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}
+	createShareTargetReturnsOnCall map[int]struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}
 	CreateSnapshotStub        func(string, provider.SnapshotParameters) (*provider.Snapshot, error)
 	createSnapshotMutex       sync.RWMutex
 	createSnapshotArgsForCall []struct {
@@ -91,6 +119,41 @@ type FakeSession struct {
 		result1 *provider.Volume
 		result2 error
 	}
+	CreateVolumeFromVolumeStub        func(provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error)
+	createVolumeFromVolumeMutex       sync.RWMutex
+	createVolumeFromVolumeArgsForCall []struct {
+		arg1 provider.VolumeCloneRequest
+	}
+	createVolumeFromVolumeReturns struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}
+	createVolumeFromVolumeReturnsOnCall map[int]struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}
+	DeleteShareStub        func(string) error
+	deleteShareMutex       sync.RWMutex
+	deleteShareArgsForCall []struct {
+		arg1 string
+	}
+	deleteShareReturns struct {
+		result1 error
+	}
+	deleteShareReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteShareTargetStub        func(provider.ShareTargetRequest) error
+	deleteShareTargetMutex       sync.RWMutex
+	deleteShareTargetArgsForCall []struct {
+		arg1 provider.ShareTargetRequest
+	}
+	deleteShareTargetReturns struct {
+		result1 error
+	}
+	deleteShareTargetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteSnapshotStub        func(*provider.Snapshot) error
 	deleteSnapshotMutex       sync.RWMutex
 	deleteSnapshotArgsForCall []struct {
@@ -139,6 +202,19 @@ type FakeSession struct {
 		result1 *http.Response
 		result2 error
 	}
+	ExpandShareStub        func(provider.ExpandShareRequest) (int64, error)
+	expandShareMutex       sync.RWMutex
+	expandShareArgsForCall []struct {
+		arg1 provider.ExpandShareRequest
+	}
+	expandShareReturns struct {
+		result1 int64
+		result2 error
+	}
+	expandShareReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	ExpandVolumeStub        func(provider.ExpandVolumeRequest) (int64, error)
 	expandVolumeMutex       sync.RWMutex
 	expandVolumeArgsForCall []struct {
@@ -152,6 +228,18 @@ type FakeSession struct {
 		result1 int64
 		result2 error
 	}
+	GetCapabilitiesStub        func() (*provider.Capabilities, error)
+	getCapabilitiesMutex       sync.RWMutex
+	getCapabilitiesArgsForCall []struct {
+	}
+	getCapabilitiesReturns struct {
+		result1 *provider.Capabilities
+		result2 error
+	}
+	getCapabilitiesReturnsOnCall map[int]struct {
+		result1 *provider.Capabilities
+		result2 error
+	}
 	GetProviderDisplayNameStub        func() provider.VolumeProvider
 	getProviderDisplayNameMutex       sync.RWMutex
 	getProviderDisplayNameArgsForCall []struct {
@@ -162,6 +250,21 @@ type FakeSession struct {
 	getProviderDisplayNameReturnsOnCall map[int]struct {
 		result1 provider.VolumeProvider
 	}
+	GetResourceEventsStub        func(context.Context, string, time.Time) ([]*provider.ResourceEvent, error)
+	getResourceEventsMutex       sync.RWMutex
+	getResourceEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}
+	getResourceEventsReturns struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}
+	getResourceEventsReturnsOnCall map[int]struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}
 	GetSnapshotStub        func(string) (*provider.Snapshot, error)
 	getSnapshotMutex       sync.RWMutex
 	getSnapshotArgsForCall []struct {
@@ -188,6 +291,19 @@ type FakeSession struct {
 		result1 *provider.Snapshot
 		result2 error
 	}
+	GetSnapshotRestoreProgressStub        func(string) (*provider.SnapshotRestoreProgress, error)
+	getSnapshotRestoreProgressMutex       sync.RWMutex
+	getSnapshotRestoreProgressArgsForCall []struct {
+		arg1 string
+	}
+	getSnapshotRestoreProgressReturns struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}
+	getSnapshotRestoreProgressReturnsOnCall map[int]struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}
 	GetVolumeStub        func(string) (*provider.Volume, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -253,6 +369,49 @@ type FakeSession struct {
 		result1 *provider.Volume
 		result2 error
 	}
+	GetVolumePerformanceStatsStub        func(context.Context, string, time.Duration) (*provider.VolumePerformanceStats, error)
+	getVolumePerformanceStatsMutex       sync.RWMutex
+	getVolumePerformanceStatsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	getVolumePerformanceStatsReturns struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}
+	getVolumePerformanceStatsReturnsOnCall map[int]struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}
+	GetVolumeTagsStub        func(string) (*provider.VolumeTags, error)
+	getVolumeTagsMutex       sync.RWMutex
+	getVolumeTagsArgsForCall []struct {
+		arg1 string
+	}
+	getVolumeTagsReturns struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	getVolumeTagsReturnsOnCall map[int]struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	ListSharesStub        func(int, string, map[string]string) (*provider.FileShareList, error)
+	listSharesMutex       sync.RWMutex
+	listSharesArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 map[string]string
+	}
+	listSharesReturns struct {
+		result1 *provider.FileShareList
+		result2 error
+	}
+	listSharesReturnsOnCall map[int]struct {
+		result1 *provider.FileShareList
+		result2 error
+	}
 	ListSnapshotsStub        func(int, string, map[string]string) (*provider.SnapshotList, error)
 	listSnapshotsMutex       sync.RWMutex
 	listSnapshotsArgsForCall []struct {
@@ -268,6 +427,19 @@ type FakeSession struct {
 		result1 *provider.SnapshotList
 		result2 error
 	}
+	ListVolumeAttachmentsStub        func(string) ([]*provider.VolumeAttachmentResponse, error)
+	listVolumeAttachmentsMutex       sync.RWMutex
+	listVolumeAttachmentsArgsForCall []struct {
+		arg1 string
+	}
+	listVolumeAttachmentsReturns struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}
+	listVolumeAttachmentsReturnsOnCall map[int]struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}
 	ListVolumesStub        func(int, string, map[string]string) (*provider.VolumeList, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -283,6 +455,21 @@ type FakeSession struct {
 		result1 *provider.VolumeList
 		result2 error
 	}
+	ListVolumesWithFiltersStub        func(int, string, provider.ListVolumesFilters) (*provider.VolumeList, error)
+	listVolumesWithFiltersMutex       sync.RWMutex
+	listVolumesWithFiltersArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 provider.ListVolumesFilters
+	}
+	listVolumesWithFiltersReturns struct {
+		result1 *provider.VolumeList
+		result2 error
+	}
+	listVolumesWithFiltersReturnsOnCall map[int]struct {
+		result1 *provider.VolumeList
+		result2 error
+	}
 	ProviderNameStub        func() provider.VolumeProvider
 	providerNameMutex       sync.RWMutex
 	providerNameArgsForCall []struct {
@@ -293,6 +480,40 @@ type FakeSession struct {
 	providerNameReturnsOnCall map[int]struct {
 		result1 provider.VolumeProvider
 	}
+	RestoreVolumeFromSnapshotStub        func(provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error)
+	restoreVolumeFromSnapshotMutex       sync.RWMutex
+	restoreVolumeFromSnapshotArgsForCall []struct {
+		arg1 provider.SnapshotRestoreRequest
+	}
+	restoreVolumeFromSnapshotReturns struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}
+	restoreVolumeFromSnapshotReturnsOnCall map[int]struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}
+	StatsStub        func() provider.ProviderStats
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+	}
+	statsReturns struct {
+		result1 provider.ProviderStats
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 provider.ProviderStats
+	}
+	SupportsOnlineExpansionStub        func(*provider.Volume) bool
+	supportsOnlineExpansionMutex       sync.RWMutex
+	supportsOnlineExpansionArgsForCall []struct {
+		arg1 *provider.Volume
+	}
+	supportsOnlineExpansionReturns struct {
+		result1 bool
+	}
+	supportsOnlineExpansionReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	TypeStub        func() provider.VolumeType
 	typeMutex       sync.RWMutex
 	typeArgsForCall []struct {
@@ -314,6 +535,58 @@ type FakeSession struct {
 	updateVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateVolumeAttachmentStub        func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)
+	updateVolumeAttachmentMutex       sync.RWMutex
+	updateVolumeAttachmentArgsForCall []struct {
+		arg1 provider.VolumeAttachmentRequest
+	}
+	updateVolumeAttachmentReturns struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}
+	updateVolumeAttachmentReturnsOnCall map[int]struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}
+	UpdateVolumeIOPSStub        func(provider.VolumeIOPSUpdateRequest) (*provider.Volume, error)
+	updateVolumeIOPSMutex       sync.RWMutex
+	updateVolumeIOPSArgsForCall []struct {
+		arg1 provider.VolumeIOPSUpdateRequest
+	}
+	updateVolumeIOPSReturns struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	updateVolumeIOPSReturnsOnCall map[int]struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	UpdateVolumeProfileStub        func(provider.VolumeProfileUpdateRequest) (*provider.Volume, error)
+	updateVolumeProfileMutex       sync.RWMutex
+	updateVolumeProfileArgsForCall []struct {
+		arg1 provider.VolumeProfileUpdateRequest
+	}
+	updateVolumeProfileReturns struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	updateVolumeProfileReturnsOnCall map[int]struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	UpdateVolumeTagsStub        func(provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error)
+	updateVolumeTagsMutex       sync.RWMutex
+	updateVolumeTagsArgsForCall []struct {
+		arg1 provider.VolumeTagsUpdateRequest
+	}
+	updateVolumeTagsReturns struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	updateVolumeTagsReturnsOnCall map[int]struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
 	WaitForAttachVolumeStub        func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)
 	waitForAttachVolumeMutex       sync.RWMutex
 	waitForAttachVolumeArgsForCall []struct {
@@ -515,6 +788,134 @@ func (fake *FakeSession) CloseCalls(stub func()) {
 	fake.CloseStub = stub
 }
 
+func (fake *FakeSession) CreateShare(arg1 provider.FileShareRequest) (*provider.FileShare, error) {
+	fake.createShareMutex.Lock()
+	ret, specificReturn := fake.createShareReturnsOnCall[len(fake.createShareArgsForCall)]
+	fake.createShareArgsForCall = append(fake.createShareArgsForCall, struct {
+		arg1 provider.FileShareRequest
+	}{arg1})
+	stub := fake.CreateShareStub
+	fakeReturns := fake.createShareReturns
+	fake.recordInvocation("CreateShare", []interface{}{arg1})
+	fake.createShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) CreateShareCallCount() int {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	return len(fake.createShareArgsForCall)
+}
+
+func (fake *FakeSession) CreateShareCalls(stub func(provider.FileShareRequest) (*provider.FileShare, error)) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = stub
+}
+
+func (fake *FakeSession) CreateShareArgsForCall(i int) provider.FileShareRequest {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	argsForCall := fake.createShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) CreateShareReturns(result1 *provider.FileShare, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	fake.createShareReturns = struct {
+		result1 *provider.FileShare
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) CreateShareReturnsOnCall(i int, result1 *provider.FileShare, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	if fake.createShareReturnsOnCall == nil {
+		fake.createShareReturnsOnCall = make(map[int]struct {
+			result1 *provider.FileShare
+			result2 error
+		})
+	}
+	fake.createShareReturnsOnCall[i] = struct {
+		result1 *provider.FileShare
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) CreateShareTarget(arg1 provider.ShareTargetRequest) (*provider.ShareTarget, error) {
+	fake.createShareTargetMutex.Lock()
+	ret, specificReturn := fake.createShareTargetReturnsOnCall[len(fake.createShareTargetArgsForCall)]
+	fake.createShareTargetArgsForCall = append(fake.createShareTargetArgsForCall, struct {
+		arg1 provider.ShareTargetRequest
+	}{arg1})
+	stub := fake.CreateShareTargetStub
+	fakeReturns := fake.createShareTargetReturns
+	fake.recordInvocation("CreateShareTarget", []interface{}{arg1})
+	fake.createShareTargetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) CreateShareTargetCallCount() int {
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
+	return len(fake.createShareTargetArgsForCall)
+}
+
+func (fake *FakeSession) CreateShareTargetCalls(stub func(provider.ShareTargetRequest) (*provider.ShareTarget, error)) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = stub
+}
+
+func (fake *FakeSession) CreateShareTargetArgsForCall(i int) provider.ShareTargetRequest {
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
+	argsForCall := fake.createShareTargetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) CreateShareTargetReturns(result1 *provider.ShareTarget, result2 error) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = nil
+	fake.createShareTargetReturns = struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) CreateShareTargetReturnsOnCall(i int, result1 *provider.ShareTarget, result2 error) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = nil
+	if fake.createShareTargetReturnsOnCall == nil {
+		fake.createShareTargetReturnsOnCall = make(map[int]struct {
+			result1 *provider.ShareTarget
+			result2 error
+		})
+	}
+	fake.createShareTargetReturnsOnCall[i] = struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) CreateSnapshot(arg1 string, arg2 provider.SnapshotParameters) (*provider.Snapshot, error) {
 	fake.createSnapshotMutex.Lock()
 	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
@@ -773,77 +1174,80 @@ func (fake *FakeSession) CreateVolumeFromSnapshotReturnsOnCall(i int, result1 *p
 	}{result1, result2}
 }
 
-func (fake *FakeSession) DeleteSnapshot(arg1 *provider.Snapshot) error {
-	fake.deleteSnapshotMutex.Lock()
-	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
-	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
-		arg1 *provider.Snapshot
+func (fake *FakeSession) CreateVolumeFromVolume(arg1 provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	ret, specificReturn := fake.createVolumeFromVolumeReturnsOnCall[len(fake.createVolumeFromVolumeArgsForCall)]
+	fake.createVolumeFromVolumeArgsForCall = append(fake.createVolumeFromVolumeArgsForCall, struct {
+		arg1 provider.VolumeCloneRequest
 	}{arg1})
-	stub := fake.DeleteSnapshotStub
-	fakeReturns := fake.deleteSnapshotReturns
-	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1})
-	fake.deleteSnapshotMutex.Unlock()
+	stub := fake.CreateVolumeFromVolumeStub
+	fakeReturns := fake.createVolumeFromVolumeReturns
+	fake.recordInvocation("CreateVolumeFromVolume", []interface{}{arg1})
+	fake.createVolumeFromVolumeMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeSession) DeleteSnapshotCallCount() int {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	return len(fake.deleteSnapshotArgsForCall)
+func (fake *FakeSession) CreateVolumeFromVolumeCallCount() int {
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	return len(fake.createVolumeFromVolumeArgsForCall)
 }
 
-func (fake *FakeSession) DeleteSnapshotCalls(stub func(*provider.Snapshot) error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = stub
+func (fake *FakeSession) CreateVolumeFromVolumeCalls(stub func(provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error)) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = stub
 }
 
-func (fake *FakeSession) DeleteSnapshotArgsForCall(i int) *provider.Snapshot {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	argsForCall := fake.deleteSnapshotArgsForCall[i]
+func (fake *FakeSession) CreateVolumeFromVolumeArgsForCall(i int) provider.VolumeCloneRequest {
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	argsForCall := fake.createVolumeFromVolumeArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeSession) DeleteSnapshotReturns(result1 error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = nil
-	fake.deleteSnapshotReturns = struct {
-		result1 error
-	}{result1}
+func (fake *FakeSession) CreateVolumeFromVolumeReturns(result1 *provider.VolumeCloneResponse, result2 error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = nil
+	fake.createVolumeFromVolumeReturns = struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeSession) DeleteSnapshotReturnsOnCall(i int, result1 error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = nil
-	if fake.deleteSnapshotReturnsOnCall == nil {
-		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *FakeSession) CreateVolumeFromVolumeReturnsOnCall(i int, result1 *provider.VolumeCloneResponse, result2 error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = nil
+	if fake.createVolumeFromVolumeReturnsOnCall == nil {
+		fake.createVolumeFromVolumeReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeCloneResponse
+			result2 error
 		})
 	}
-	fake.deleteSnapshotReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.createVolumeFromVolumeReturnsOnCall[i] = struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeSession) DeleteVolume(arg1 *provider.Volume) error {
-	fake.deleteVolumeMutex.Lock()
-	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
-	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
-		arg1 *provider.Volume
+func (fake *FakeSession) DeleteShare(arg1 string) error {
+	fake.deleteShareMutex.Lock()
+	ret, specificReturn := fake.deleteShareReturnsOnCall[len(fake.deleteShareArgsForCall)]
+	fake.deleteShareArgsForCall = append(fake.deleteShareArgsForCall, struct {
+		arg1 string
 	}{arg1})
-	stub := fake.DeleteVolumeStub
-	fakeReturns := fake.deleteVolumeReturns
-	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
-	fake.deleteVolumeMutex.Unlock()
+	stub := fake.DeleteShareStub
+	fakeReturns := fake.deleteShareReturns
+	fake.recordInvocation("DeleteShare", []interface{}{arg1})
+	fake.deleteShareMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -853,36 +1257,219 @@ func (fake *FakeSession) DeleteVolume(arg1 *provider.Volume) error {
 	return fakeReturns.result1
 }
 
-func (fake *FakeSession) DeleteVolumeCallCount() int {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	return len(fake.deleteVolumeArgsForCall)
+func (fake *FakeSession) DeleteShareCallCount() int {
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	return len(fake.deleteShareArgsForCall)
 }
 
-func (fake *FakeSession) DeleteVolumeCalls(stub func(*provider.Volume) error) {
-	fake.deleteVolumeMutex.Lock()
-	defer fake.deleteVolumeMutex.Unlock()
-	fake.DeleteVolumeStub = stub
+func (fake *FakeSession) DeleteShareCalls(stub func(string) error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = stub
 }
 
-func (fake *FakeSession) DeleteVolumeArgsForCall(i int) *provider.Volume {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	argsForCall := fake.deleteVolumeArgsForCall[i]
+func (fake *FakeSession) DeleteShareArgsForCall(i int) string {
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	argsForCall := fake.deleteShareArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeSession) DeleteVolumeReturns(result1 error) {
-	fake.deleteVolumeMutex.Lock()
-	defer fake.deleteVolumeMutex.Unlock()
-	fake.DeleteVolumeStub = nil
-	fake.deleteVolumeReturns = struct {
+func (fake *FakeSession) DeleteShareReturns(result1 error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = nil
+	fake.deleteShareReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeSession) DeleteVolumeReturnsOnCall(i int, result1 error) {
-	fake.deleteVolumeMutex.Lock()
+func (fake *FakeSession) DeleteShareReturnsOnCall(i int, result1 error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = nil
+	if fake.deleteShareReturnsOnCall == nil {
+		fake.deleteShareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteShareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteShareTarget(arg1 provider.ShareTargetRequest) error {
+	fake.deleteShareTargetMutex.Lock()
+	ret, specificReturn := fake.deleteShareTargetReturnsOnCall[len(fake.deleteShareTargetArgsForCall)]
+	fake.deleteShareTargetArgsForCall = append(fake.deleteShareTargetArgsForCall, struct {
+		arg1 provider.ShareTargetRequest
+	}{arg1})
+	stub := fake.DeleteShareTargetStub
+	fakeReturns := fake.deleteShareTargetReturns
+	fake.recordInvocation("DeleteShareTarget", []interface{}{arg1})
+	fake.deleteShareTargetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSession) DeleteShareTargetCallCount() int {
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
+	return len(fake.deleteShareTargetArgsForCall)
+}
+
+func (fake *FakeSession) DeleteShareTargetCalls(stub func(provider.ShareTargetRequest) error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = stub
+}
+
+func (fake *FakeSession) DeleteShareTargetArgsForCall(i int) provider.ShareTargetRequest {
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
+	argsForCall := fake.deleteShareTargetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) DeleteShareTargetReturns(result1 error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = nil
+	fake.deleteShareTargetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteShareTargetReturnsOnCall(i int, result1 error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = nil
+	if fake.deleteShareTargetReturnsOnCall == nil {
+		fake.deleteShareTargetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteShareTargetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteSnapshot(arg1 *provider.Snapshot) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 *provider.Snapshot
+	}{arg1})
+	stub := fake.DeleteSnapshotStub
+	fakeReturns := fake.deleteSnapshotReturns
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1})
+	fake.deleteSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSession) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *FakeSession) DeleteSnapshotCalls(stub func(*provider.Snapshot) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *FakeSession) DeleteSnapshotArgsForCall(i int) *provider.Snapshot {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteVolume(arg1 *provider.Volume) error {
+	fake.deleteVolumeMutex.Lock()
+	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
+	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
+		arg1 *provider.Volume
+	}{arg1})
+	stub := fake.DeleteVolumeStub
+	fakeReturns := fake.deleteVolumeReturns
+	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
+	fake.deleteVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSession) DeleteVolumeCallCount() int {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	return len(fake.deleteVolumeArgsForCall)
+}
+
+func (fake *FakeSession) DeleteVolumeCalls(stub func(*provider.Volume) error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = stub
+}
+
+func (fake *FakeSession) DeleteVolumeArgsForCall(i int) *provider.Volume {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	argsForCall := fake.deleteVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) DeleteVolumeReturns(result1 error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = nil
+	fake.deleteVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSession) DeleteVolumeReturnsOnCall(i int, result1 error) {
+	fake.deleteVolumeMutex.Lock()
 	defer fake.deleteVolumeMutex.Unlock()
 	fake.DeleteVolumeStub = nil
 	if fake.deleteVolumeReturnsOnCall == nil {
@@ -1023,6 +1610,70 @@ func (fake *FakeSession) DetachVolumeReturnsOnCall(i int, result1 *http.Response
 	}{result1, result2}
 }
 
+func (fake *FakeSession) ExpandShare(arg1 provider.ExpandShareRequest) (int64, error) {
+	fake.expandShareMutex.Lock()
+	ret, specificReturn := fake.expandShareReturnsOnCall[len(fake.expandShareArgsForCall)]
+	fake.expandShareArgsForCall = append(fake.expandShareArgsForCall, struct {
+		arg1 provider.ExpandShareRequest
+	}{arg1})
+	stub := fake.ExpandShareStub
+	fakeReturns := fake.expandShareReturns
+	fake.recordInvocation("ExpandShare", []interface{}{arg1})
+	fake.expandShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) ExpandShareCallCount() int {
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
+	return len(fake.expandShareArgsForCall)
+}
+
+func (fake *FakeSession) ExpandShareCalls(stub func(provider.ExpandShareRequest) (int64, error)) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = stub
+}
+
+func (fake *FakeSession) ExpandShareArgsForCall(i int) provider.ExpandShareRequest {
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
+	argsForCall := fake.expandShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) ExpandShareReturns(result1 int64, result2 error) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = nil
+	fake.expandShareReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) ExpandShareReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = nil
+	if fake.expandShareReturnsOnCall == nil {
+		fake.expandShareReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.expandShareReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) ExpandVolume(arg1 provider.ExpandVolumeRequest) (int64, error) {
 	fake.expandVolumeMutex.Lock()
 	ret, specificReturn := fake.expandVolumeReturnsOnCall[len(fake.expandVolumeArgsForCall)]
@@ -1087,6 +1738,62 @@ func (fake *FakeSession) ExpandVolumeReturnsOnCall(i int, result1 int64, result2
 	}{result1, result2}
 }
 
+func (fake *FakeSession) GetCapabilities() (*provider.Capabilities, error) {
+	fake.getCapabilitiesMutex.Lock()
+	ret, specificReturn := fake.getCapabilitiesReturnsOnCall[len(fake.getCapabilitiesArgsForCall)]
+	fake.getCapabilitiesArgsForCall = append(fake.getCapabilitiesArgsForCall, struct {
+	}{})
+	stub := fake.GetCapabilitiesStub
+	fakeReturns := fake.getCapabilitiesReturns
+	fake.recordInvocation("GetCapabilities", []interface{}{})
+	fake.getCapabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) GetCapabilitiesCallCount() int {
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
+	return len(fake.getCapabilitiesArgsForCall)
+}
+
+func (fake *FakeSession) GetCapabilitiesCalls(stub func() (*provider.Capabilities, error)) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = stub
+}
+
+func (fake *FakeSession) GetCapabilitiesReturns(result1 *provider.Capabilities, result2 error) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = nil
+	fake.getCapabilitiesReturns = struct {
+		result1 *provider.Capabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetCapabilitiesReturnsOnCall(i int, result1 *provider.Capabilities, result2 error) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = nil
+	if fake.getCapabilitiesReturnsOnCall == nil {
+		fake.getCapabilitiesReturnsOnCall = make(map[int]struct {
+			result1 *provider.Capabilities
+			result2 error
+		})
+	}
+	fake.getCapabilitiesReturnsOnCall[i] = struct {
+		result1 *provider.Capabilities
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) GetProviderDisplayName() provider.VolumeProvider {
 	fake.getProviderDisplayNameMutex.Lock()
 	ret, specificReturn := fake.getProviderDisplayNameReturnsOnCall[len(fake.getProviderDisplayNameArgsForCall)]
@@ -1140,6 +1847,72 @@ func (fake *FakeSession) GetProviderDisplayNameReturnsOnCall(i int, result1 prov
 	}{result1}
 }
 
+func (fake *FakeSession) GetResourceEvents(arg1 context.Context, arg2 string, arg3 time.Time) ([]*provider.ResourceEvent, error) {
+	fake.getResourceEventsMutex.Lock()
+	ret, specificReturn := fake.getResourceEventsReturnsOnCall[len(fake.getResourceEventsArgsForCall)]
+	fake.getResourceEventsArgsForCall = append(fake.getResourceEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.GetResourceEventsStub
+	fakeReturns := fake.getResourceEventsReturns
+	fake.recordInvocation("GetResourceEvents", []interface{}{arg1, arg2, arg3})
+	fake.getResourceEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) GetResourceEventsCallCount() int {
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
+	return len(fake.getResourceEventsArgsForCall)
+}
+
+func (fake *FakeSession) GetResourceEventsCalls(stub func(context.Context, string, time.Time) ([]*provider.ResourceEvent, error)) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = stub
+}
+
+func (fake *FakeSession) GetResourceEventsArgsForCall(i int) (context.Context, string, time.Time) {
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
+	argsForCall := fake.getResourceEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSession) GetResourceEventsReturns(result1 []*provider.ResourceEvent, result2 error) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = nil
+	fake.getResourceEventsReturns = struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetResourceEventsReturnsOnCall(i int, result1 []*provider.ResourceEvent, result2 error) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = nil
+	if fake.getResourceEventsReturnsOnCall == nil {
+		fake.getResourceEventsReturnsOnCall = make(map[int]struct {
+			result1 []*provider.ResourceEvent
+			result2 error
+		})
+	}
+	fake.getResourceEventsReturnsOnCall[i] = struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) GetSnapshot(arg1 string) (*provider.Snapshot, error) {
 	fake.getSnapshotMutex.Lock()
 	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
@@ -1268,16 +2041,16 @@ func (fake *FakeSession) GetSnapshotByNameReturnsOnCall(i int, result1 *provider
 	}{result1, result2}
 }
 
-func (fake *FakeSession) GetVolume(arg1 string) (*provider.Volume, error) {
-	fake.getVolumeMutex.Lock()
-	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
-	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
+func (fake *FakeSession) GetSnapshotRestoreProgress(arg1 string) (*provider.SnapshotRestoreProgress, error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	ret, specificReturn := fake.getSnapshotRestoreProgressReturnsOnCall[len(fake.getSnapshotRestoreProgressArgsForCall)]
+	fake.getSnapshotRestoreProgressArgsForCall = append(fake.getSnapshotRestoreProgressArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.GetVolumeStub
-	fakeReturns := fake.getVolumeReturns
-	fake.recordInvocation("GetVolume", []interface{}{arg1})
-	fake.getVolumeMutex.Unlock()
+	stub := fake.GetSnapshotRestoreProgressStub
+	fakeReturns := fake.getSnapshotRestoreProgressReturns
+	fake.recordInvocation("GetSnapshotRestoreProgress", []interface{}{arg1})
+	fake.getSnapshotRestoreProgressMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -1287,31 +2060,95 @@ func (fake *FakeSession) GetVolume(arg1 string) (*provider.Volume, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeSession) GetVolumeCallCount() int {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	return len(fake.getVolumeArgsForCall)
+func (fake *FakeSession) GetSnapshotRestoreProgressCallCount() int {
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
+	return len(fake.getSnapshotRestoreProgressArgsForCall)
 }
 
-func (fake *FakeSession) GetVolumeCalls(stub func(string) (*provider.Volume, error)) {
-	fake.getVolumeMutex.Lock()
-	defer fake.getVolumeMutex.Unlock()
-	fake.GetVolumeStub = stub
+func (fake *FakeSession) GetSnapshotRestoreProgressCalls(stub func(string) (*provider.SnapshotRestoreProgress, error)) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = stub
 }
 
-func (fake *FakeSession) GetVolumeArgsForCall(i int) string {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	argsForCall := fake.getVolumeArgsForCall[i]
+func (fake *FakeSession) GetSnapshotRestoreProgressArgsForCall(i int) string {
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
+	argsForCall := fake.getSnapshotRestoreProgressArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeSession) GetVolumeReturns(result1 *provider.Volume, result2 error) {
-	fake.getVolumeMutex.Lock()
-	defer fake.getVolumeMutex.Unlock()
-	fake.GetVolumeStub = nil
-	fake.getVolumeReturns = struct {
-		result1 *provider.Volume
+func (fake *FakeSession) GetSnapshotRestoreProgressReturns(result1 *provider.SnapshotRestoreProgress, result2 error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = nil
+	fake.getSnapshotRestoreProgressReturns = struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetSnapshotRestoreProgressReturnsOnCall(i int, result1 *provider.SnapshotRestoreProgress, result2 error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = nil
+	if fake.getSnapshotRestoreProgressReturnsOnCall == nil {
+		fake.getSnapshotRestoreProgressReturnsOnCall = make(map[int]struct {
+			result1 *provider.SnapshotRestoreProgress
+			result2 error
+		})
+	}
+	fake.getSnapshotRestoreProgressReturnsOnCall[i] = struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetVolume(arg1 string) (*provider.Volume, error) {
+	fake.getVolumeMutex.Lock()
+	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
+	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetVolumeStub
+	fakeReturns := fake.getVolumeReturns
+	fake.recordInvocation("GetVolume", []interface{}{arg1})
+	fake.getVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) GetVolumeCallCount() int {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	return len(fake.getVolumeArgsForCall)
+}
+
+func (fake *FakeSession) GetVolumeCalls(stub func(string) (*provider.Volume, error)) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = stub
+}
+
+func (fake *FakeSession) GetVolumeArgsForCall(i int) string {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	argsForCall := fake.getVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) GetVolumeReturns(result1 *provider.Volume, result2 error) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = nil
+	fake.getVolumeReturns = struct {
+		result1 *provider.Volume
 		result2 error
 	}{result1, result2}
 }
@@ -1588,6 +2425,202 @@ func (fake *FakeSession) GetVolumeByRequestIDReturnsOnCall(i int, result1 *provi
 	}{result1, result2}
 }
 
+func (fake *FakeSession) GetVolumePerformanceStats(arg1 context.Context, arg2 string, arg3 time.Duration) (*provider.VolumePerformanceStats, error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	ret, specificReturn := fake.getVolumePerformanceStatsReturnsOnCall[len(fake.getVolumePerformanceStatsArgsForCall)]
+	fake.getVolumePerformanceStatsArgsForCall = append(fake.getVolumePerformanceStatsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.GetVolumePerformanceStatsStub
+	fakeReturns := fake.getVolumePerformanceStatsReturns
+	fake.recordInvocation("GetVolumePerformanceStats", []interface{}{arg1, arg2, arg3})
+	fake.getVolumePerformanceStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) GetVolumePerformanceStatsCallCount() int {
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	return len(fake.getVolumePerformanceStatsArgsForCall)
+}
+
+func (fake *FakeSession) GetVolumePerformanceStatsCalls(stub func(context.Context, string, time.Duration) (*provider.VolumePerformanceStats, error)) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = stub
+}
+
+func (fake *FakeSession) GetVolumePerformanceStatsArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	argsForCall := fake.getVolumePerformanceStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSession) GetVolumePerformanceStatsReturns(result1 *provider.VolumePerformanceStats, result2 error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = nil
+	fake.getVolumePerformanceStatsReturns = struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetVolumePerformanceStatsReturnsOnCall(i int, result1 *provider.VolumePerformanceStats, result2 error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = nil
+	if fake.getVolumePerformanceStatsReturnsOnCall == nil {
+		fake.getVolumePerformanceStatsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumePerformanceStats
+			result2 error
+		})
+	}
+	fake.getVolumePerformanceStatsReturnsOnCall[i] = struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetVolumeTags(arg1 string) (*provider.VolumeTags, error) {
+	fake.getVolumeTagsMutex.Lock()
+	ret, specificReturn := fake.getVolumeTagsReturnsOnCall[len(fake.getVolumeTagsArgsForCall)]
+	fake.getVolumeTagsArgsForCall = append(fake.getVolumeTagsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetVolumeTagsStub
+	fakeReturns := fake.getVolumeTagsReturns
+	fake.recordInvocation("GetVolumeTags", []interface{}{arg1})
+	fake.getVolumeTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) GetVolumeTagsCallCount() int {
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	return len(fake.getVolumeTagsArgsForCall)
+}
+
+func (fake *FakeSession) GetVolumeTagsCalls(stub func(string) (*provider.VolumeTags, error)) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = stub
+}
+
+func (fake *FakeSession) GetVolumeTagsArgsForCall(i int) string {
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	argsForCall := fake.getVolumeTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) GetVolumeTagsReturns(result1 *provider.VolumeTags, result2 error) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = nil
+	fake.getVolumeTagsReturns = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) GetVolumeTagsReturnsOnCall(i int, result1 *provider.VolumeTags, result2 error) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = nil
+	if fake.getVolumeTagsReturnsOnCall == nil {
+		fake.getVolumeTagsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeTags
+			result2 error
+		})
+	}
+	fake.getVolumeTagsReturnsOnCall[i] = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) ListShares(arg1 int, arg2 string, arg3 map[string]string) (*provider.FileShareList, error) {
+	fake.listSharesMutex.Lock()
+	ret, specificReturn := fake.listSharesReturnsOnCall[len(fake.listSharesArgsForCall)]
+	fake.listSharesArgsForCall = append(fake.listSharesArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 map[string]string
+	}{arg1, arg2, arg3})
+	stub := fake.ListSharesStub
+	fakeReturns := fake.listSharesReturns
+	fake.recordInvocation("ListShares", []interface{}{arg1, arg2, arg3})
+	fake.listSharesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) ListSharesCallCount() int {
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
+	return len(fake.listSharesArgsForCall)
+}
+
+func (fake *FakeSession) ListSharesCalls(stub func(int, string, map[string]string) (*provider.FileShareList, error)) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = stub
+}
+
+func (fake *FakeSession) ListSharesArgsForCall(i int) (int, string, map[string]string) {
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
+	argsForCall := fake.listSharesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSession) ListSharesReturns(result1 *provider.FileShareList, result2 error) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = nil
+	fake.listSharesReturns = struct {
+		result1 *provider.FileShareList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) ListSharesReturnsOnCall(i int, result1 *provider.FileShareList, result2 error) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = nil
+	if fake.listSharesReturnsOnCall == nil {
+		fake.listSharesReturnsOnCall = make(map[int]struct {
+			result1 *provider.FileShareList
+			result2 error
+		})
+	}
+	fake.listSharesReturnsOnCall[i] = struct {
+		result1 *provider.FileShareList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) ListSnapshots(arg1 int, arg2 string, arg3 map[string]string) (*provider.SnapshotList, error) {
 	fake.listSnapshotsMutex.Lock()
 	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
@@ -1654,6 +2687,70 @@ func (fake *FakeSession) ListSnapshotsReturnsOnCall(i int, result1 *provider.Sna
 	}{result1, result2}
 }
 
+func (fake *FakeSession) ListVolumeAttachments(arg1 string) ([]*provider.VolumeAttachmentResponse, error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	ret, specificReturn := fake.listVolumeAttachmentsReturnsOnCall[len(fake.listVolumeAttachmentsArgsForCall)]
+	fake.listVolumeAttachmentsArgsForCall = append(fake.listVolumeAttachmentsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListVolumeAttachmentsStub
+	fakeReturns := fake.listVolumeAttachmentsReturns
+	fake.recordInvocation("ListVolumeAttachments", []interface{}{arg1})
+	fake.listVolumeAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) ListVolumeAttachmentsCallCount() int {
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
+	return len(fake.listVolumeAttachmentsArgsForCall)
+}
+
+func (fake *FakeSession) ListVolumeAttachmentsCalls(stub func(string) ([]*provider.VolumeAttachmentResponse, error)) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = stub
+}
+
+func (fake *FakeSession) ListVolumeAttachmentsArgsForCall(i int) string {
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
+	argsForCall := fake.listVolumeAttachmentsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) ListVolumeAttachmentsReturns(result1 []*provider.VolumeAttachmentResponse, result2 error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = nil
+	fake.listVolumeAttachmentsReturns = struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) ListVolumeAttachmentsReturnsOnCall(i int, result1 []*provider.VolumeAttachmentResponse, result2 error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = nil
+	if fake.listVolumeAttachmentsReturnsOnCall == nil {
+		fake.listVolumeAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 []*provider.VolumeAttachmentResponse
+			result2 error
+		})
+	}
+	fake.listVolumeAttachmentsReturnsOnCall[i] = struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) ListVolumes(arg1 int, arg2 string, arg3 map[string]string) (*provider.VolumeList, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
@@ -1720,6 +2817,72 @@ func (fake *FakeSession) ListVolumesReturnsOnCall(i int, result1 *provider.Volum
 	}{result1, result2}
 }
 
+func (fake *FakeSession) ListVolumesWithFilters(arg1 int, arg2 string, arg3 provider.ListVolumesFilters) (*provider.VolumeList, error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	ret, specificReturn := fake.listVolumesWithFiltersReturnsOnCall[len(fake.listVolumesWithFiltersArgsForCall)]
+	fake.listVolumesWithFiltersArgsForCall = append(fake.listVolumesWithFiltersArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 provider.ListVolumesFilters
+	}{arg1, arg2, arg3})
+	stub := fake.ListVolumesWithFiltersStub
+	fakeReturns := fake.listVolumesWithFiltersReturns
+	fake.recordInvocation("ListVolumesWithFilters", []interface{}{arg1, arg2, arg3})
+	fake.listVolumesWithFiltersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) ListVolumesWithFiltersCallCount() int {
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
+	return len(fake.listVolumesWithFiltersArgsForCall)
+}
+
+func (fake *FakeSession) ListVolumesWithFiltersCalls(stub func(int, string, provider.ListVolumesFilters) (*provider.VolumeList, error)) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = stub
+}
+
+func (fake *FakeSession) ListVolumesWithFiltersArgsForCall(i int) (int, string, provider.ListVolumesFilters) {
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
+	argsForCall := fake.listVolumesWithFiltersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeSession) ListVolumesWithFiltersReturns(result1 *provider.VolumeList, result2 error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = nil
+	fake.listVolumesWithFiltersReturns = struct {
+		result1 *provider.VolumeList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) ListVolumesWithFiltersReturnsOnCall(i int, result1 *provider.VolumeList, result2 error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = nil
+	if fake.listVolumesWithFiltersReturnsOnCall == nil {
+		fake.listVolumesWithFiltersReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeList
+			result2 error
+		})
+	}
+	fake.listVolumesWithFiltersReturnsOnCall[i] = struct {
+		result1 *provider.VolumeList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) ProviderName() provider.VolumeProvider {
 	fake.providerNameMutex.Lock()
 	ret, specificReturn := fake.providerNameReturnsOnCall[len(fake.providerNameArgsForCall)]
@@ -1773,6 +2936,184 @@ func (fake *FakeSession) ProviderNameReturnsOnCall(i int, result1 provider.Volum
 	}{result1}
 }
 
+func (fake *FakeSession) RestoreVolumeFromSnapshot(arg1 provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	ret, specificReturn := fake.restoreVolumeFromSnapshotReturnsOnCall[len(fake.restoreVolumeFromSnapshotArgsForCall)]
+	fake.restoreVolumeFromSnapshotArgsForCall = append(fake.restoreVolumeFromSnapshotArgsForCall, struct {
+		arg1 provider.SnapshotRestoreRequest
+	}{arg1})
+	stub := fake.RestoreVolumeFromSnapshotStub
+	fakeReturns := fake.restoreVolumeFromSnapshotReturns
+	fake.recordInvocation("RestoreVolumeFromSnapshot", []interface{}{arg1})
+	fake.restoreVolumeFromSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) RestoreVolumeFromSnapshotCallCount() int {
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	return len(fake.restoreVolumeFromSnapshotArgsForCall)
+}
+
+func (fake *FakeSession) RestoreVolumeFromSnapshotCalls(stub func(provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error)) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = stub
+}
+
+func (fake *FakeSession) RestoreVolumeFromSnapshotArgsForCall(i int) provider.SnapshotRestoreRequest {
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	argsForCall := fake.restoreVolumeFromSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) RestoreVolumeFromSnapshotReturns(result1 *provider.SnapshotRestoreResponse, result2 error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = nil
+	fake.restoreVolumeFromSnapshotReturns = struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) RestoreVolumeFromSnapshotReturnsOnCall(i int, result1 *provider.SnapshotRestoreResponse, result2 error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = nil
+	if fake.restoreVolumeFromSnapshotReturnsOnCall == nil {
+		fake.restoreVolumeFromSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *provider.SnapshotRestoreResponse
+			result2 error
+		})
+	}
+	fake.restoreVolumeFromSnapshotReturnsOnCall[i] = struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) Stats() provider.ProviderStats {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+	}{})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSession) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeSession) StatsCalls(stub func() provider.ProviderStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeSession) StatsReturns(result1 provider.ProviderStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 provider.ProviderStats
+	}{result1}
+}
+
+func (fake *FakeSession) StatsReturnsOnCall(i int, result1 provider.ProviderStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 provider.ProviderStats
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 provider.ProviderStats
+	}{result1}
+}
+
+func (fake *FakeSession) SupportsOnlineExpansion(arg1 *provider.Volume) bool {
+	fake.supportsOnlineExpansionMutex.Lock()
+	ret, specificReturn := fake.supportsOnlineExpansionReturnsOnCall[len(fake.supportsOnlineExpansionArgsForCall)]
+	fake.supportsOnlineExpansionArgsForCall = append(fake.supportsOnlineExpansionArgsForCall, struct {
+		arg1 *provider.Volume
+	}{arg1})
+	stub := fake.SupportsOnlineExpansionStub
+	fakeReturns := fake.supportsOnlineExpansionReturns
+	fake.recordInvocation("SupportsOnlineExpansion", []interface{}{arg1})
+	fake.supportsOnlineExpansionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSession) SupportsOnlineExpansionCallCount() int {
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
+	return len(fake.supportsOnlineExpansionArgsForCall)
+}
+
+func (fake *FakeSession) SupportsOnlineExpansionCalls(stub func(*provider.Volume) bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = stub
+}
+
+func (fake *FakeSession) SupportsOnlineExpansionArgsForCall(i int) *provider.Volume {
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
+	argsForCall := fake.supportsOnlineExpansionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) SupportsOnlineExpansionReturns(result1 bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = nil
+	fake.supportsOnlineExpansionReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeSession) SupportsOnlineExpansionReturnsOnCall(i int, result1 bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = nil
+	if fake.supportsOnlineExpansionReturnsOnCall == nil {
+		fake.supportsOnlineExpansionReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.supportsOnlineExpansionReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeSession) Type() provider.VolumeType {
 	fake.typeMutex.Lock()
 	ret, specificReturn := fake.typeReturnsOnCall[len(fake.typeArgsForCall)]
@@ -1887,6 +3228,262 @@ func (fake *FakeSession) UpdateVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSession) UpdateVolumeAttachment(arg1 provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	ret, specificReturn := fake.updateVolumeAttachmentReturnsOnCall[len(fake.updateVolumeAttachmentArgsForCall)]
+	fake.updateVolumeAttachmentArgsForCall = append(fake.updateVolumeAttachmentArgsForCall, struct {
+		arg1 provider.VolumeAttachmentRequest
+	}{arg1})
+	stub := fake.UpdateVolumeAttachmentStub
+	fakeReturns := fake.updateVolumeAttachmentReturns
+	fake.recordInvocation("UpdateVolumeAttachment", []interface{}{arg1})
+	fake.updateVolumeAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) UpdateVolumeAttachmentCallCount() int {
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	return len(fake.updateVolumeAttachmentArgsForCall)
+}
+
+func (fake *FakeSession) UpdateVolumeAttachmentCalls(stub func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = stub
+}
+
+func (fake *FakeSession) UpdateVolumeAttachmentArgsForCall(i int) provider.VolumeAttachmentRequest {
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	argsForCall := fake.updateVolumeAttachmentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) UpdateVolumeAttachmentReturns(result1 *provider.VolumeAttachmentResponse, result2 error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = nil
+	fake.updateVolumeAttachmentReturns = struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeAttachmentReturnsOnCall(i int, result1 *provider.VolumeAttachmentResponse, result2 error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = nil
+	if fake.updateVolumeAttachmentReturnsOnCall == nil {
+		fake.updateVolumeAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeAttachmentResponse
+			result2 error
+		})
+	}
+	fake.updateVolumeAttachmentReturnsOnCall[i] = struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeIOPS(arg1 provider.VolumeIOPSUpdateRequest) (*provider.Volume, error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	ret, specificReturn := fake.updateVolumeIOPSReturnsOnCall[len(fake.updateVolumeIOPSArgsForCall)]
+	fake.updateVolumeIOPSArgsForCall = append(fake.updateVolumeIOPSArgsForCall, struct {
+		arg1 provider.VolumeIOPSUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeIOPSStub
+	fakeReturns := fake.updateVolumeIOPSReturns
+	fake.recordInvocation("UpdateVolumeIOPS", []interface{}{arg1})
+	fake.updateVolumeIOPSMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) UpdateVolumeIOPSCallCount() int {
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	return len(fake.updateVolumeIOPSArgsForCall)
+}
+
+func (fake *FakeSession) UpdateVolumeIOPSCalls(stub func(provider.VolumeIOPSUpdateRequest) (*provider.Volume, error)) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = stub
+}
+
+func (fake *FakeSession) UpdateVolumeIOPSArgsForCall(i int) provider.VolumeIOPSUpdateRequest {
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	argsForCall := fake.updateVolumeIOPSArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) UpdateVolumeIOPSReturns(result1 *provider.Volume, result2 error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = nil
+	fake.updateVolumeIOPSReturns = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeIOPSReturnsOnCall(i int, result1 *provider.Volume, result2 error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = nil
+	if fake.updateVolumeIOPSReturnsOnCall == nil {
+		fake.updateVolumeIOPSReturnsOnCall = make(map[int]struct {
+			result1 *provider.Volume
+			result2 error
+		})
+	}
+	fake.updateVolumeIOPSReturnsOnCall[i] = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeProfile(arg1 provider.VolumeProfileUpdateRequest) (*provider.Volume, error) {
+	fake.updateVolumeProfileMutex.Lock()
+	ret, specificReturn := fake.updateVolumeProfileReturnsOnCall[len(fake.updateVolumeProfileArgsForCall)]
+	fake.updateVolumeProfileArgsForCall = append(fake.updateVolumeProfileArgsForCall, struct {
+		arg1 provider.VolumeProfileUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeProfileStub
+	fakeReturns := fake.updateVolumeProfileReturns
+	fake.recordInvocation("UpdateVolumeProfile", []interface{}{arg1})
+	fake.updateVolumeProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) UpdateVolumeProfileCallCount() int {
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	return len(fake.updateVolumeProfileArgsForCall)
+}
+
+func (fake *FakeSession) UpdateVolumeProfileCalls(stub func(provider.VolumeProfileUpdateRequest) (*provider.Volume, error)) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = stub
+}
+
+func (fake *FakeSession) UpdateVolumeProfileArgsForCall(i int) provider.VolumeProfileUpdateRequest {
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	argsForCall := fake.updateVolumeProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) UpdateVolumeProfileReturns(result1 *provider.Volume, result2 error) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = nil
+	fake.updateVolumeProfileReturns = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeProfileReturnsOnCall(i int, result1 *provider.Volume, result2 error) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = nil
+	if fake.updateVolumeProfileReturnsOnCall == nil {
+		fake.updateVolumeProfileReturnsOnCall = make(map[int]struct {
+			result1 *provider.Volume
+			result2 error
+		})
+	}
+	fake.updateVolumeProfileReturnsOnCall[i] = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeTags(arg1 provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+	fake.updateVolumeTagsMutex.Lock()
+	ret, specificReturn := fake.updateVolumeTagsReturnsOnCall[len(fake.updateVolumeTagsArgsForCall)]
+	fake.updateVolumeTagsArgsForCall = append(fake.updateVolumeTagsArgsForCall, struct {
+		arg1 provider.VolumeTagsUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeTagsStub
+	fakeReturns := fake.updateVolumeTagsReturns
+	fake.recordInvocation("UpdateVolumeTags", []interface{}{arg1})
+	fake.updateVolumeTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSession) UpdateVolumeTagsCallCount() int {
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
+	return len(fake.updateVolumeTagsArgsForCall)
+}
+
+func (fake *FakeSession) UpdateVolumeTagsCalls(stub func(provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error)) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = stub
+}
+
+func (fake *FakeSession) UpdateVolumeTagsArgsForCall(i int) provider.VolumeTagsUpdateRequest {
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
+	argsForCall := fake.updateVolumeTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSession) UpdateVolumeTagsReturns(result1 *provider.VolumeTags, result2 error) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = nil
+	fake.updateVolumeTagsReturns = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSession) UpdateVolumeTagsReturnsOnCall(i int, result1 *provider.VolumeTags, result2 error) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = nil
+	if fake.updateVolumeTagsReturnsOnCall == nil {
+		fake.updateVolumeTagsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeTags
+			result2 error
+		})
+	}
+	fake.updateVolumeTagsReturnsOnCall[i] = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSession) WaitForAttachVolume(arg1 provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
 	fake.waitForAttachVolumeMutex.Lock()
 	ret, specificReturn := fake.waitForAttachVolumeReturnsOnCall[len(fake.waitForAttachVolumeArgsForCall)]
@@ -2146,6 +3743,10 @@ func (fake *FakeSession) Invocations() map[string][][]interface{} {
 	defer fake.authorizeVolumeMutex.RUnlock()
 	fake.closeMutex.RLock()
 	defer fake.closeMutex.RUnlock()
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
 	fake.createSnapshotMutex.RLock()
 	defer fake.createSnapshotMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
@@ -2154,6 +3755,12 @@ func (fake *FakeSession) Invocations() map[string][][]interface{} {
 	defer fake.createVolumeAccessPointMutex.RUnlock()
 	fake.createVolumeFromSnapshotMutex.RLock()
 	defer fake.createVolumeFromSnapshotMutex.RUnlock()
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
 	fake.deleteSnapshotMutex.RLock()
 	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
@@ -2162,14 +3769,22 @@ func (fake *FakeSession) Invocations() map[string][][]interface{} {
 	defer fake.deleteVolumeAccessPointMutex.RUnlock()
 	fake.detachVolumeMutex.RLock()
 	defer fake.detachVolumeMutex.RUnlock()
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
 	fake.expandVolumeMutex.RLock()
 	defer fake.expandVolumeMutex.RUnlock()
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
 	fake.getProviderDisplayNameMutex.RLock()
 	defer fake.getProviderDisplayNameMutex.RUnlock()
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
 	fake.getSnapshotMutex.RLock()
 	defer fake.getSnapshotMutex.RUnlock()
 	fake.getSnapshotByNameMutex.RLock()
 	defer fake.getSnapshotByNameMutex.RUnlock()
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
 	fake.getVolumeAccessPointMutex.RLock()
@@ -2180,16 +3795,40 @@ func (fake *FakeSession) Invocations() map[string][][]interface{} {
 	defer fake.getVolumeByNameMutex.RUnlock()
 	fake.getVolumeByRequestIDMutex.RLock()
 	defer fake.getVolumeByRequestIDMutex.RUnlock()
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
 	fake.listSnapshotsMutex.RLock()
 	defer fake.listSnapshotsMutex.RUnlock()
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
 	fake.providerNameMutex.RLock()
 	defer fake.providerNameMutex.RUnlock()
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
 	fake.updateVolumeMutex.RLock()
 	defer fake.updateVolumeMutex.RUnlock()
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
 	fake.waitForAttachVolumeMutex.RLock()
 	defer fake.waitForAttachVolumeMutex.RUnlock()
 	fake.waitForCreateVolumeAccessPointMutex.RLock()
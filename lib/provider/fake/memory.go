@@ -0,0 +1,917 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake ...
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+const (
+	// MemoryProviderName is the name of the in-memory provider
+	MemoryProviderName = provider.VolumeProvider("memory")
+
+	// MemoryVolumeType is the type of the in-memory volumes
+	MemoryVolumeType = provider.VolumeType("block")
+
+	// MemoryAttachmentAttached is the status of the in-memory attachments
+	MemoryAttachmentAttached = "attached"
+
+	// AnyOperation matches all the operations in the latencies and the injected errors of a MemoryStore
+	AnyOperation = ""
+)
+
+// ErrorHook is called before each operation of the sessions of a MemoryStore, the operation fails with the
+// returned error if not nil
+type ErrorHook func(operation string) error
+
+type memoryAttachmentKey struct {
+	volumeID   string
+	instanceID string
+}
+
+type injectedError struct {
+	err   error
+	times int
+}
+
+// MemoryStore is the in-memory backend of the MemorySession, shared by the sessions of a MemoryProvider. The
+// quotas and zone capacity pools are simulated by its CapacitySimulator. Tests configure per operation (the
+// session method name, e.g. CreateVolume) latencies and errors, and read the calls made
+type MemoryStore struct {
+	capacity *CapacitySimulator
+
+	mu          sync.Mutex
+	volumes     map[string]*provider.Volume
+	snapshots   map[string]*provider.Snapshot
+	attachments map[memoryAttachmentKey]*provider.VolumeAttachmentResponse
+	tags        map[string]*provider.VolumeTags
+	protected   map[string]bool
+	latencies   map[string]time.Duration
+	errors      map[string][]*injectedError
+	hook        ErrorHook
+	calls       map[string]int
+}
+
+// NewMemoryStore returns an empty store with the quotas
+func NewMemoryStore(quotas Quotas) *MemoryStore {
+	return &MemoryStore{
+		capacity:    NewCapacitySimulator(quotas),
+		volumes:     map[string]*provider.Volume{},
+		snapshots:   map[string]*provider.Snapshot{},
+		attachments: map[memoryAttachmentKey]*provider.VolumeAttachmentResponse{},
+		tags:        map[string]*provider.VolumeTags{},
+		protected:   map[string]bool{},
+		latencies:   map[string]time.Duration{},
+		errors:      map[string][]*injectedError{},
+		calls:       map[string]int{},
+	}
+}
+
+// Capacity returns the simulator of the quotas and zone capacity pools of the store
+func (m *MemoryStore) Capacity() *CapacitySimulator {
+	return m.capacity
+}
+
+// SetLatency delays the operation (AnyOperation for all of them) by the latency, the latency of the operation
+// is used over the one of AnyOperation
+func (m *MemoryStore) SetLatency(operation string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies[operation] = latency
+}
+
+// FailNext fails the next calls of the operation (AnyOperation for any of them) with err, times times
+func (m *MemoryStore) FailNext(operation string, err error, times int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[operation] = append(m.errors[operation], &injectedError{err: err, times: times})
+}
+
+// SetErrorHook sets the hook called before each operation, nil removes it
+func (m *MemoryStore) SetErrorHook(hook ErrorHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hook = hook
+}
+
+// Calls returns the number of calls of the operation, including the failed ones
+func (m *MemoryStore) Calls(operation string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[operation]
+}
+
+// begin counts the call of the operation, waits its latency and returns its injected error if any
+func (m *MemoryStore) begin(operation string) error {
+	m.mu.Lock()
+	m.calls[operation]++
+	latency, found := m.latencies[operation]
+	if !found {
+		latency = m.latencies[AnyOperation]
+	}
+	var err error
+	for _, key := range []string{operation, AnyOperation} {
+		if queued := m.errors[key]; len(queued) > 0 {
+			err = queued[0].err
+			if queued[0].times--; queued[0].times <= 0 {
+				m.errors[key] = queued[1:]
+			}
+			break
+		}
+	}
+	hook := m.hook
+	m.mu.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if err == nil && hook != nil {
+		err = hook(operation)
+	}
+	return err
+}
+
+// MemoryProvider is an in-memory local.Provider, for the tests of the consumers of the library which do not have
+// IBM Cloud credentials. Its sessions share the MemoryStore
+type MemoryProvider struct {
+	store *MemoryStore
+}
+
+var _ local.Provider = &MemoryProvider{}
+
+// NewMemoryProvider returns a provider of an empty store with the quotas
+func NewMemoryProvider(quotas Quotas) *MemoryProvider {
+	return &MemoryProvider{store: NewMemoryStore(quotas)}
+}
+
+// Store returns the store of the sessions
+func (p *MemoryProvider) Store() *MemoryStore {
+	return p.store
+}
+
+// OpenSession opens a session of the store, any non-empty credential is accepted
+func (p *MemoryProvider) OpenSession(ctx context.Context, credentials provider.ContextCredentials, logger *zap.Logger) (provider.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if credentials.Credential == "" {
+		return nil, backendError(reasoncode.ErrorUnauthorised, "not_authorized", "The memory provider requires a credential", nil)
+	}
+	return NewMemorySession(p.store), nil
+}
+
+// ContextCredentialsFactory returns the factory of the credentials of the memory sessions
+func (p *MemoryProvider) ContextCredentialsFactory(datacenter *string) (local.ContextCredentialsFactory, error) {
+	region := ""
+	if datacenter != nil {
+		region = *datacenter
+	}
+	return &memoryCredentialsFactory{region: region}, nil
+}
+
+// memoryCredentialsFactory builds the credentials of the memory sessions from an API key
+type memoryCredentialsFactory struct {
+	region string
+}
+
+// ForIaaSAPIKey ...
+func (f *memoryCredentialsFactory) ForIaaSAPIKey(iamAccountID, iaasUserID, iaasAPIKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IaaSAPIKey, Region: f.region, IAMAccountID: iamAccountID, UserID: iaasUserID, Credential: iaasAPIKey}, nil
+}
+
+// ForIAMAPIKey ...
+func (f *memoryCredentialsFactory) ForIAMAPIKey(iamAccountID, iamAPIKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IAMAPIKey, Region: f.region, IAMAccountID: iamAccountID, Credential: iamAPIKey}, nil
+}
+
+// ForIAMAccessToken ...
+func (f *memoryCredentialsFactory) ForIAMAccessToken(apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IAMAccessToken, Region: f.region, Credential: apiKey}, nil
+}
+
+// MemorySession is a provider.Session of a MemoryStore implementing the volume, attachment and snapshot
+// operations. The other operations (file shares, access points...) are those of provider.DefaultVolumeProvider.
+// The stored and returned resources are deep copies, changing the requests or the returned resources does not
+// change the store
+type MemorySession struct {
+	provider.DefaultVolumeProvider
+
+	store *MemoryStore
+
+	mu    sync.Mutex
+	stats map[string]provider.OperationCounts
+}
+
+var _ provider.Session = &MemorySession{}
+
+// NewMemorySession returns a session of the store
+func NewMemorySession(store *MemoryStore) *MemorySession {
+	return &MemorySession{store: store, stats: map[string]provider.OperationCounts{}}
+}
+
+// Store returns the store of the session
+func (s *MemorySession) Store() *MemoryStore {
+	return s.store
+}
+
+// run runs the operation after its latency and injected error, holding the lock of the store, and counts it in
+// the stats of the session
+func (s *MemorySession) run(operation string, fn func() error) error {
+	err := s.store.begin(operation)
+	if err == nil {
+		s.store.mu.Lock()
+		err = fn()
+		s.store.mu.Unlock()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := s.stats[operation]
+	if err != nil {
+		counts.Failure++
+	} else {
+		counts.Success++
+	}
+	s.stats[operation] = counts
+	return err
+}
+
+func snapshotNotFound(snapshotID string) error {
+	return backendError(reasoncode.ErrorResourceNotFound, "snapshot_not_found", fmt.Sprintf("Snapshot %s not found", snapshotID), map[string]string{"SnapshotID": snapshotID})
+}
+
+func attachmentNotFound(request provider.VolumeAttachmentRequest) error {
+	return backendError(reasoncode.ErrorResourceNotFound, "volume_attachment_not_found",
+		fmt.Sprintf("Volume %s is not attached to instance %s", request.VolumeID, request.InstanceID), map[string]string{"VolumeID": request.VolumeID, "InstanceID": request.InstanceID})
+}
+
+// volume returns the stored volume, the caller holds the lock of the store
+func (s *MemorySession) volume(volumeID string) (*provider.Volume, error) {
+	volume, found := s.store.volumes[volumeID]
+	if !found {
+		return nil, volumeNotFound(volumeID)
+	}
+	return volume, nil
+}
+
+// create stores the volume of the request, the caller holds the lock of the store
+func (s *MemorySession) create(volumeRequest provider.Volume) (*provider.Volume, error) {
+	if volumeRequest.Name != nil {
+		for _, existing := range s.store.volumes {
+			if existing.Name != nil && *existing.Name == *volumeRequest.Name {
+				return nil, backendError(reasoncode.ErrorResourceAlreadyExists, "validation_unique_failed", fmt.Sprintf("Volume name %s is already used", *volumeRequest.Name), nil)
+			}
+		}
+	}
+	volume, err := s.store.capacity.CreateVolume(*volumeRequest.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+	volume.Provider = MemoryProviderName
+	volume.VolumeType = MemoryVolumeType
+	volume.CreationTime = time.Now()
+	s.store.volumes[volume.VolumeID] = volume
+	return volume.DeepCopy(), nil
+}
+
+// ProviderName ...
+func (s *MemorySession) ProviderName() provider.VolumeProvider {
+	return MemoryProviderName
+}
+
+// Type ...
+func (s *MemorySession) Type() provider.VolumeType {
+	return MemoryVolumeType
+}
+
+// GetProviderDisplayName ...
+func (s *MemorySession) GetProviderDisplayName() provider.VolumeProvider {
+	return MemoryProviderName
+}
+
+// Close ...
+func (s *MemorySession) Close() {}
+
+// Stats returns the operation counts of the session
+func (s *MemorySession) Stats() provider.ProviderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := provider.ProviderStats{Operations: map[string]provider.OperationCounts{}}
+	for operation, counts := range s.stats {
+		stats.Operations[operation] = counts
+	}
+	return stats
+}
+
+// GetCapabilities returns the features of the store: snapshots, clones, online expansion and multi-attach
+func (s *MemorySession) GetCapabilities() (*provider.Capabilities, error) {
+	err := s.run("GetCapabilities", func() error { return nil })
+	if err != nil {
+		return nil, err
+	}
+	return &provider.Capabilities{Features: map[string]bool{
+		provider.FeatureSnapshots:       true,
+		provider.FeatureVolumeClone:     true,
+		provider.FeatureVolumeExpansion: true,
+		provider.FeatureOnlineExpansion: true,
+		provider.FeatureMultiAttach:     true,
+	}}, nil
+}
+
+// CreateVolume creates the volume if the quotas allow it, the names are unique
+func (s *MemorySession) CreateVolume(volumeRequest provider.Volume) (volume *provider.Volume, err error) {
+	err = s.run("CreateVolume", func() error {
+		volume, err = s.create(volumeRequest)
+		return err
+	})
+	return volume, err
+}
+
+// CreateVolumeFromSnapshot creates a volume of the capacity of the source volume of the snapshot
+func (s *MemorySession) CreateVolumeFromSnapshot(snapshot provider.Snapshot, tags map[string]string) (volume *provider.Volume, err error) {
+	err = s.run("CreateVolumeFromSnapshot", func() error {
+		stored, found := s.store.snapshots[snapshot.SnapshotID]
+		if !found {
+			return snapshotNotFound(snapshot.SnapshotID)
+		}
+		request := provider.Volume{SnapshotID: stored.SnapshotID, VolumeNotes: tags}
+		if source, found := s.store.volumes[stored.VolumeID]; found {
+			request.Capacity, request.Az = source.Capacity, source.Az
+		}
+		volume, err = s.create(request)
+		return err
+	})
+	return volume, err
+}
+
+// CreateVolumeFromVolume clones the volume
+func (s *MemorySession) CreateVolumeFromVolume(cloneRequest provider.VolumeCloneRequest) (response *provider.VolumeCloneResponse, err error) {
+	err = s.run("CreateVolumeFromVolume", func() error {
+		source, err := s.volume(cloneRequest.SourceVolumeID)
+		if err != nil {
+			return err
+		}
+		request := provider.Volume{Name: cloneRequest.Name, Capacity: source.Capacity, Iops: source.Iops, Az: source.Az, VolumeNotes: cloneRequest.Tags}
+		if cloneRequest.Zone != "" {
+			request.Az = cloneRequest.Zone
+		}
+		if cloneRequest.Capacity != nil {
+			request.Capacity = cloneRequest.Capacity
+		}
+		if cloneRequest.Iops != nil {
+			request.Iops = cloneRequest.Iops
+		}
+		volume, err := s.create(request)
+		if err != nil {
+			return err
+		}
+		response = &provider.VolumeCloneResponse{Volume: volume, SourceVolumeID: source.VolumeID}
+		return nil
+	})
+	return response, err
+}
+
+// UpdateVolume updates the name and the tags of the volume
+func (s *MemorySession) UpdateVolume(volumeRequest provider.Volume) error {
+	return s.run("UpdateVolume", func() error {
+		volume, err := s.volume(volumeRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		if volumeRequest.Name != nil {
+			name := *volumeRequest.Name
+			volume.Name = &name
+		}
+		if volumeRequest.Tags != nil {
+			volume.Tags = append([]string{}, volumeRequest.Tags...)
+		}
+		return nil
+	})
+}
+
+// DeleteVolume deletes the volume, unless it is attached or protected against deletion
+func (s *MemorySession) DeleteVolume(volume *provider.Volume) error {
+	return s.run("DeleteVolume", func() error {
+		if _, err := s.volume(volume.VolumeID); err != nil {
+			return err
+		}
+		if s.store.protected[volume.VolumeID] {
+			return backendError(reasoncode.ErrorVolumeDeletionProtected, "volume_deletion_protected", fmt.Sprintf("Volume %s is protected against deletion", volume.VolumeID), nil)
+		}
+		for key := range s.store.attachments {
+			if key.volumeID == volume.VolumeID {
+				return backendError(reasoncode.ErrorVolumeAttachConflict, "volume_in_use", fmt.Sprintf("Volume %s is attached to instance %s", volume.VolumeID, key.instanceID), nil)
+			}
+		}
+		if err := s.store.capacity.DeleteVolume(volume); err != nil {
+			return err
+		}
+		delete(s.store.volumes, volume.VolumeID)
+		delete(s.store.tags, volume.VolumeID)
+		return nil
+	})
+}
+
+// GetVolume returns the volume
+func (s *MemorySession) GetVolume(id string) (volume *provider.Volume, err error) {
+	err = s.run("GetVolume", func() error {
+		stored, err := s.volume(id)
+		if err == nil {
+			volume = stored.DeepCopy()
+		}
+		return err
+	})
+	return volume, err
+}
+
+// GetVolumeByName returns the volume of the name
+func (s *MemorySession) GetVolumeByName(name string) (volume *provider.Volume, err error) {
+	err = s.run("GetVolumeByName", func() error {
+		for _, stored := range s.store.volumes {
+			if stored.Name != nil && *stored.Name == name {
+				volume = stored.DeepCopy()
+				return nil
+			}
+		}
+		return backendError(reasoncode.ErrorResourceNotFound, "volume_not_found", fmt.Sprintf("Volume %s not found", name), nil)
+	})
+	return volume, err
+}
+
+// GetVolumeByRequestID returns the volume, the request IDs are the volume IDs
+func (s *MemorySession) GetVolumeByRequestID(requestID string) (volume *provider.Volume, err error) {
+	err = s.run("GetVolumeByRequestID", func() error {
+		stored, err := s.volume(requestID)
+		if err == nil {
+			volume = stored.DeepCopy()
+		}
+		return err
+	})
+	return volume, err
+}
+
+// listVolumes returns a page of limit volumes (all if not positive) from the start ID matching the filters, the
+// caller holds the lock of the store
+func (s *MemorySession) listVolumes(limit int, start string, filters provider.ListVolumesFilters) *provider.VolumeList {
+	ids := make([]string, 0, len(s.store.volumes))
+	for id, volume := range s.store.volumes {
+		if id >= start && filters.Matches(volume) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	list := &provider.VolumeList{Volumes: []*provider.Volume{}}
+	for i, id := range ids {
+		if limit > 0 && i == limit {
+			list.Next = id
+			break
+		}
+		list.Volumes = append(list.Volumes, s.store.volumes[id].DeepCopy())
+	}
+	return list
+}
+
+// ListVolumes lists the volumes by ID, filtered by the ListVolumesFilters keys of the tags
+func (s *MemorySession) ListVolumes(limit int, start string, tags map[string]string) (list *provider.VolumeList, err error) {
+	err = s.run("ListVolumes", func() error {
+		list = s.listVolumes(limit, start, provider.ListVolumesFiltersFromTags(tags))
+		return nil
+	})
+	return list, err
+}
+
+// ListVolumesWithFilters lists the volumes matching the filters by ID
+func (s *MemorySession) ListVolumesWithFilters(limit int, start string, filters provider.ListVolumesFilters) (list *provider.VolumeList, err error) {
+	err = s.run("ListVolumesWithFilters", func() error {
+		list = s.listVolumes(limit, start, filters)
+		return nil
+	})
+	return list, err
+}
+
+// AuthorizeVolume succeeds for an existing volume
+func (s *MemorySession) AuthorizeVolume(volumeAuthorization provider.VolumeAuthorization) error {
+	return s.run("AuthorizeVolume", func() error {
+		_, err := s.volume(volumeAuthorization.Volume.VolumeID)
+		return err
+	})
+}
+
+// ExpandVolume expands the volume if the quotas allow it, a smaller capacity leaves it unchanged
+func (s *MemorySession) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (capacity int64, err error) {
+	err = s.run("ExpandVolume", func() error {
+		volume, err := s.volume(expandVolumeRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		if capacity, err = s.store.capacity.ExpandVolume(expandVolumeRequest); err != nil {
+			return err
+		}
+		expanded := int(capacity)
+		volume.Capacity = &expanded
+		return nil
+	})
+	return capacity, err
+}
+
+// SupportsOnlineExpansion returns true, the volumes are expanded while attached
+func (s *MemorySession) SupportsOnlineExpansion(volume *provider.Volume) bool {
+	return true
+}
+
+// UpdateVolumeProfile changes the profile of the volume
+func (s *MemorySession) UpdateVolumeProfile(updateRequest provider.VolumeProfileUpdateRequest) (volume *provider.Volume, err error) {
+	err = s.run("UpdateVolumeProfile", func() error {
+		stored, err := s.volume(updateRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		stored.Profile = &provider.Profile{Name: updateRequest.Profile}
+		if updateRequest.Iops != nil {
+			iops := strconv.Itoa(*updateRequest.Iops)
+			stored.Iops = &iops
+		}
+		volume = stored.DeepCopy()
+		return nil
+	})
+	return volume, err
+}
+
+// UpdateVolumeIOPS changes the IOPS of the volume
+func (s *MemorySession) UpdateVolumeIOPS(updateRequest provider.VolumeIOPSUpdateRequest) (volume *provider.Volume, err error) {
+	err = s.run("UpdateVolumeIOPS", func() error {
+		stored, err := s.volume(updateRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		iops := strconv.Itoa(updateRequest.Iops)
+		stored.Iops = &iops
+		volume = stored.DeepCopy()
+		return nil
+	})
+	return volume, err
+}
+
+// volumeTags returns the tags of the volume, the caller holds the lock of the store
+func (s *MemorySession) volumeTags(volumeID string) (*provider.VolumeTags, error) {
+	if _, err := s.volume(volumeID); err != nil {
+		return nil, err
+	}
+	tags, found := s.store.tags[volumeID]
+	if !found {
+		tags = &provider.VolumeTags{VolumeID: volumeID, UserTags: []string{}, AccessTags: []string{}}
+		s.store.tags[volumeID] = tags
+	}
+	return tags, nil
+}
+
+// GetVolumeTags returns the tags of the volume
+func (s *MemorySession) GetVolumeTags(volumeID string) (tags *provider.VolumeTags, err error) {
+	err = s.run("GetVolumeTags", func() error {
+		stored, err := s.volumeTags(volumeID)
+		if err == nil {
+			tags = &provider.VolumeTags{VolumeID: volumeID, UserTags: append([]string{}, stored.UserTags...), AccessTags: append([]string{}, stored.AccessTags...)}
+		}
+		return err
+	})
+	return tags, err
+}
+
+// updateTags attaches and detaches the tags, in lower case like Global Tagging
+func updateTags(tags, attach, detach []string) []string {
+	set := map[string]bool{}
+	for _, tag := range tags {
+		set[tag] = true
+	}
+	for _, tag := range attach {
+		set[strings.ToLower(tag)] = true
+	}
+	for _, tag := range detach {
+		delete(set, strings.ToLower(tag))
+	}
+	updated := make([]string, 0, len(set))
+	for tag := range set {
+		updated = append(updated, tag)
+	}
+	sort.Strings(updated)
+	return updated
+}
+
+// UpdateVolumeTags attaches and detaches the tags of the type to the volume
+func (s *MemorySession) UpdateVolumeTags(updateRequest provider.VolumeTagsUpdateRequest) (tags *provider.VolumeTags, err error) {
+	err = s.run("UpdateVolumeTags", func() error {
+		stored, err := s.volumeTags(updateRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		if updateRequest.TagType == provider.TagTypeAccess {
+			stored.AccessTags = updateTags(stored.AccessTags, updateRequest.Attach, updateRequest.Detach)
+		} else {
+			stored.UserTags = updateTags(stored.UserTags, updateRequest.Attach, updateRequest.Detach)
+		}
+		tags = &provider.VolumeTags{VolumeID: stored.VolumeID, UserTags: append([]string{}, stored.UserTags...), AccessTags: append([]string{}, stored.AccessTags...)}
+		return nil
+	})
+	return tags, err
+}
+
+// SetDeletionProtection enables or disables the deletion protection of the volume
+func (s *MemorySession) SetDeletionProtection(ctx context.Context, volumeID string, enabled bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.run("SetDeletionProtection", func() error {
+		if _, err := s.volume(volumeID); err != nil {
+			return err
+		}
+		s.store.protected[volumeID] = enabled
+		return nil
+	})
+}
+
+// IsDeletionProtected returns whether the volume is protected against deletion
+func (s *MemorySession) IsDeletionProtected(ctx context.Context, volumeID string) (protected bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return false, err
+	}
+	err = s.run("IsDeletionProtected", func() error {
+		if _, err := s.volume(volumeID); err != nil {
+			return err
+		}
+		protected = s.store.protected[volumeID]
+		return nil
+	})
+	return protected, err
+}
+
+// AttachVolume attaches the volume to the instance, a volume without MultiAttach is attached to one instance
+func (s *MemorySession) AttachVolume(attachRequest provider.VolumeAttachmentRequest) (response *provider.VolumeAttachmentResponse, err error) {
+	err = s.run("AttachVolume", func() error {
+		volume, err := s.volume(attachRequest.VolumeID)
+		if err != nil {
+			return err
+		}
+		key := memoryAttachmentKey{volumeID: attachRequest.VolumeID, instanceID: attachRequest.InstanceID}
+		if existing, found := s.store.attachments[key]; found {
+			response = existing.DeepCopy()
+			return nil
+		}
+		for other := range s.store.attachments {
+			if other.volumeID == attachRequest.VolumeID && !volume.MultiAttach {
+				return backendError(reasoncode.ErrorVolumeAttachConflict, "volume_attachment_conflict",
+					fmt.Sprintf("Volume %s is attached to instance %s", attachRequest.VolumeID, other.instanceID), map[string]string{"InstanceID": other.instanceID})
+			}
+		}
+		now := time.Now()
+		attachment := &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: attachRequest.DeepCopy(), Status: MemoryAttachmentAttached, CreatedAt: &now}
+		s.store.attachments[key] = attachment
+		response = attachment.DeepCopy()
+		return nil
+	})
+	return response, err
+}
+
+// WaitForAttachVolume returns the attachment, the attachments are attached when created
+func (s *MemorySession) WaitForAttachVolume(attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return s.GetVolumeAttachment(attachRequest)
+}
+
+// GetVolumeAttachment returns the attachment of the volume to the instance
+func (s *MemorySession) GetVolumeAttachment(attachRequest provider.VolumeAttachmentRequest) (response *provider.VolumeAttachmentResponse, err error) {
+	err = s.run("GetVolumeAttachment", func() error {
+		attachment, found := s.store.attachments[memoryAttachmentKey{volumeID: attachRequest.VolumeID, instanceID: attachRequest.InstanceID}]
+		if !found {
+			return attachmentNotFound(attachRequest)
+		}
+		response = attachment.DeepCopy()
+		return nil
+	})
+	return response, err
+}
+
+// UpdateVolumeAttachment updates the options (e.g. the bandwidth) of the attachment
+func (s *MemorySession) UpdateVolumeAttachment(updateRequest provider.VolumeAttachmentRequest) (response *provider.VolumeAttachmentResponse, err error) {
+	err = s.run("UpdateVolumeAttachment", func() error {
+		key := memoryAttachmentKey{volumeID: updateRequest.VolumeID, instanceID: updateRequest.InstanceID}
+		attachment, found := s.store.attachments[key]
+		if !found {
+			return attachmentNotFound(updateRequest)
+		}
+		attachment.VolumeAttachmentRequest = updateRequest.DeepCopy()
+		response = attachment.DeepCopy()
+		return nil
+	})
+	return response, err
+}
+
+// ListVolumeAttachments lists the attachments of the volume by instance ID
+func (s *MemorySession) ListVolumeAttachments(volumeID string) (attachments []*provider.VolumeAttachmentResponse, err error) {
+	err = s.run("ListVolumeAttachments", func() error {
+		if _, err := s.volume(volumeID); err != nil {
+			return err
+		}
+		attachments = []*provider.VolumeAttachmentResponse{}
+		for key, attachment := range s.store.attachments {
+			if key.volumeID == volumeID {
+				attachments = append(attachments, attachment.DeepCopy())
+			}
+		}
+		sort.Slice(attachments, func(i, j int) bool { return attachments[i].InstanceID < attachments[j].InstanceID })
+		return nil
+	})
+	return attachments, err
+}
+
+// DetachVolume detaches the volume from the instance
+func (s *MemorySession) DetachVolume(detachRequest provider.VolumeAttachmentRequest) (response *http.Response, err error) {
+	err = s.run("DetachVolume", func() error {
+		key := memoryAttachmentKey{volumeID: detachRequest.VolumeID, instanceID: detachRequest.InstanceID}
+		if _, found := s.store.attachments[key]; !found {
+			return attachmentNotFound(detachRequest)
+		}
+		delete(s.store.attachments, key)
+		response = &http.Response{StatusCode: http.StatusAccepted}
+		return nil
+	})
+	return response, err
+}
+
+// WaitForDetachVolume returns when the volume is detached from the instance, the attachments are removed when
+// detached
+func (s *MemorySession) WaitForDetachVolume(detachRequest provider.VolumeAttachmentRequest) error {
+	return s.run("WaitForDetachVolume", func() error {
+		if _, found := s.store.attachments[memoryAttachmentKey{volumeID: detachRequest.VolumeID, instanceID: detachRequest.InstanceID}]; found {
+			return backendError(reasoncode.ErrorVolumeDetachFailed, "volume_attachment_exists",
+				fmt.Sprintf("Volume %s is still attached to instance %s", detachRequest.VolumeID, detachRequest.InstanceID), nil)
+		}
+		return nil
+	})
+}
+
+// CreateSnapshot creates a ready to use snapshot of the volume if the snapshot quota allows it
+func (s *MemorySession) CreateSnapshot(sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (snapshot *provider.Snapshot, err error) {
+	err = s.run("CreateSnapshot", func() error {
+		volume, err := s.volume(sourceVolumeID)
+		if err != nil {
+			return err
+		}
+		created, err := s.store.capacity.CreateSnapshot(sourceVolumeID, snapshotParameters)
+		if err != nil {
+			return err
+		}
+		created.Name = snapshotParameters.Name
+		created.SnapshotCreationTime = time.Now()
+		if volume.Capacity != nil {
+			created.SnapshotSize = int64(*volume.Capacity) << 30
+			created.RestoreSize = created.SnapshotSize
+		}
+		// the stored snapshot does not share the tags map of the parameters
+		s.store.snapshots[created.SnapshotID] = created.DeepCopy()
+		snapshot = created
+		return nil
+	})
+	return snapshot, err
+}
+
+// DeleteSnapshot deletes the snapshot
+func (s *MemorySession) DeleteSnapshot(snapshot *provider.Snapshot) error {
+	return s.run("DeleteSnapshot", func() error {
+		if err := s.store.capacity.DeleteSnapshot(snapshot); err != nil {
+			return err
+		}
+		delete(s.store.snapshots, snapshot.SnapshotID)
+		return nil
+	})
+}
+
+// GetSnapshot returns the snapshot
+func (s *MemorySession) GetSnapshot(snapshotID string) (snapshot *provider.Snapshot, err error) {
+	err = s.run("GetSnapshot", func() error {
+		stored, found := s.store.snapshots[snapshotID]
+		if !found {
+			return snapshotNotFound(snapshotID)
+		}
+		snapshot = stored.DeepCopy()
+		return nil
+	})
+	return snapshot, err
+}
+
+// GetSnapshotByName returns the snapshot of the name
+func (s *MemorySession) GetSnapshotByName(snapshotName string) (snapshot *provider.Snapshot, err error) {
+	err = s.run("GetSnapshotByName", func() error {
+		for _, stored := range s.store.snapshots {
+			if stored.Name == snapshotName {
+				snapshot = stored.DeepCopy()
+				return nil
+			}
+		}
+		return snapshotNotFound(snapshotName)
+	})
+	return snapshot, err
+}
+
+// ListSnapshots lists the snapshots by ID, the tags are the tags the snapshots have
+func (s *MemorySession) ListSnapshots(limit int, start string, tags map[string]string) (list *provider.SnapshotList, err error) {
+	err = s.run("ListSnapshots", func() error {
+		ids := []string{}
+		for id, snapshot := range s.store.snapshots {
+			if id >= start && hasTags(snapshot.SnapshotTags, tags) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		list = &provider.SnapshotList{Snapshots: []*provider.Snapshot{}}
+		for i, id := range ids {
+			if limit > 0 && i == limit {
+				list.Next = id
+				break
+			}
+			list.Snapshots = append(list.Snapshots, s.store.snapshots[id].DeepCopy())
+		}
+		return nil
+	})
+	return list, err
+}
+
+func hasTags(snapshotTags provider.SnapshotTags, tags map[string]string) bool {
+	for key, value := range tags {
+		if snapshotTags[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// RestoreVolumeFromSnapshot creates a volume from the snapshot, the restore completes immediately
+func (s *MemorySession) RestoreVolumeFromSnapshot(restoreRequest provider.SnapshotRestoreRequest) (response *provider.SnapshotRestoreResponse, err error) {
+	err = s.run("RestoreVolumeFromSnapshot", func() error {
+		snapshot, found := s.store.snapshots[restoreRequest.SnapshotID]
+		if !found {
+			return snapshotNotFound(restoreRequest.SnapshotID)
+		}
+		request := provider.Volume{Name: restoreRequest.Name, Iops: restoreRequest.Iops, Az: restoreRequest.Zone, SnapshotID: snapshot.SnapshotID, VolumeNotes: restoreRequest.Tags}
+		if source, found := s.store.volumes[snapshot.VolumeID]; found {
+			request.Capacity = source.Capacity
+			if request.Az == "" {
+				request.Az = source.Az
+			}
+		}
+		if restoreRequest.Capacity != nil {
+			request.Capacity = restoreRequest.Capacity
+		}
+		volume, err := s.create(request)
+		if err != nil {
+			return err
+		}
+		response = &provider.SnapshotRestoreResponse{
+			Volume:     volume,
+			SnapshotID: snapshot.SnapshotID,
+			Progress:   provider.SnapshotRestoreProgress{VolumeID: volume.VolumeID, Phase: provider.SnapshotRestoreCompleted, PercentComplete: 100},
+		}
+		return nil
+	})
+	return response, err
+}
+
+// GetSnapshotRestoreProgress returns the completed restore of a volume created from a snapshot
+func (s *MemorySession) GetSnapshotRestoreProgress(volumeID string) (progress *provider.SnapshotRestoreProgress, err error) {
+	err = s.run("GetSnapshotRestoreProgress", func() error {
+		volume, err := s.volume(volumeID)
+		if err != nil {
+			return err
+		}
+		if volume.SnapshotID == "" {
+			return backendError(reasoncode.ErrorBadRequest, "volume_not_restored", fmt.Sprintf("Volume %s is not restored from a snapshot", volumeID), nil)
+		}
+		progress = &provider.SnapshotRestoreProgress{VolumeID: volumeID, Phase: provider.SnapshotRestoreCompleted, PercentComplete: 100}
+		return nil
+	})
+	return progress, err
+}
@@ -0,0 +1,256 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fake ...
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/apicheck"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestMemoryProviderImplementsInterfaces(t *testing.T) {
+	p := NewMemoryProvider(Quotas{})
+	apicheck.CheckProvider(t, p)
+	apicheck.CheckSession(t, NewMemorySession(p.Store()))
+}
+
+func TestMemoryProviderOpenSession(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	p := NewMemoryProvider(Quotas{})
+	datacenter := "us-south"
+	factory, err := p.ContextCredentialsFactory(&datacenter)
+	assert.Nil(t, err)
+	credentials, err := factory.ForIAMAPIKey("account", "apikey", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "us-south", credentials.Region)
+
+	sess, err := p.OpenSession(context.Background(), credentials, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, MemoryProviderName, sess.ProviderName())
+
+	_, err = p.OpenSession(context.Background(), provider.ContextCredentials{}, logger)
+	assert.Equal(t, reasoncode.ErrorUnauthorised, reasonCode(err))
+}
+
+func TestMemorySessionVolumeLifecycle(t *testing.T) {
+	sess := NewMemorySession(NewMemoryStore(Quotas{}))
+	name := "pvc-1"
+	capacity := 10
+	volume, err := sess.CreateVolume(provider.Volume{Name: &name, Capacity: &capacity, Az: "us-south-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, MemoryProviderName, volume.Provider)
+
+	_, err = sess.CreateVolume(provider.Volume{Name: &name, Capacity: &capacity})
+	assert.Equal(t, reasoncode.ErrorResourceAlreadyExists, reasonCode(err))
+
+	byName, err := sess.GetVolumeByName(name)
+	assert.Nil(t, err)
+	assert.Equal(t, volume.VolumeID, byName.VolumeID)
+
+	expanded, err := sess.ExpandVolume(provider.ExpandVolumeRequest{VolumeID: volume.VolumeID, Capacity: 20})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), expanded)
+	stored, _ := sess.GetVolume(volume.VolumeID)
+	assert.Equal(t, 20, *stored.Capacity)
+	assert.Equal(t, 10, *volume.Capacity)
+
+	tags, err := sess.UpdateVolumeTags(provider.VolumeTagsUpdateRequest{VolumeID: volume.VolumeID, Attach: []string{"Env:Test"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"env:test"}, tags.UserTags)
+
+	assert.Nil(t, sess.SetDeletionProtection(context.Background(), volume.VolumeID, true))
+	err = sess.DeleteVolume(volume)
+	assert.Equal(t, reasoncode.ErrorVolumeDeletionProtected, reasonCode(err))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, sess.SetDeletionProtection(cancelled, volume.VolumeID, false))
+	assert.Nil(t, sess.SetDeletionProtection(context.Background(), volume.VolumeID, false))
+	assert.Nil(t, sess.DeleteVolume(volume))
+
+	_, err = sess.GetVolume(volume.VolumeID)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, reasonCode(err))
+	assert.Equal(t, provider.OperationCounts{Success: 1, Failure: 1}, sess.Stats().Operations["GetVolume"])
+}
+
+func TestMemorySessionCopies(t *testing.T) {
+	sess := NewMemorySession(NewMemoryStore(Quotas{}))
+	name, capacity := "pvc-1", 10
+	request := provider.Volume{Name: &name, Capacity: &capacity}
+	request.Tags = []string{"env:test"}
+	volume, err := sess.CreateVolume(request)
+	assert.Nil(t, err)
+
+	// changing the request or the returned volume does not change the store
+	name, capacity = "pvc-2", 20
+	request.Tags[0] = "env:prod"
+	*volume.Name = "pvc-3"
+	volume.Tags[0] = "env:dev"
+	stored, err := sess.GetVolume(volume.VolumeID)
+	assert.Nil(t, err)
+	assert.Equal(t, "pvc-1", *stored.Name)
+	assert.Equal(t, 10, *stored.Capacity)
+	assert.Equal(t, []string{"env:test"}, stored.Tags)
+
+	update := provider.Volume{VolumeID: volume.VolumeID}
+	update.Tags = []string{"env:stage"}
+	assert.Nil(t, sess.UpdateVolume(update))
+	update.Tags[0] = "env:prod"
+	stored, _ = sess.GetVolume(volume.VolumeID)
+	assert.Equal(t, []string{"env:stage"}, stored.Tags)
+}
+
+func TestMemorySessionListVolumes(t *testing.T) {
+	sess := NewMemorySession(NewMemoryStore(Quotas{}))
+	for _, zone := range []string{"us-south-1", "us-south-2", "us-south-1"} {
+		_, err := sess.CreateVolume(provider.Volume{Az: zone})
+		assert.Nil(t, err)
+	}
+
+	page, err := sess.ListVolumes(2, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(page.Volumes))
+	next, err := sess.ListVolumes(2, page.Next, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(next.Volumes))
+	assert.Equal(t, "", next.Next)
+
+	zoned, err := sess.ListVolumesWithFilters(0, "", provider.ListVolumesFilters{ZoneName: "us-south-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(zoned.Volumes))
+	tagged, err := sess.ListVolumes(0, "", map[string]string{provider.ListFilterZoneName: "us-south-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(tagged.Volumes))
+}
+
+func TestMemorySessionAttachments(t *testing.T) {
+	sess := NewMemorySession(NewMemoryStore(Quotas{}))
+	volume, _ := sess.CreateVolume(provider.Volume{})
+	first := provider.VolumeAttachmentRequest{VolumeID: volume.VolumeID, InstanceID: "instance-1"}
+	second := provider.VolumeAttachmentRequest{VolumeID: volume.VolumeID, InstanceID: "instance-2"}
+
+	attachment, err := sess.AttachVolume(first)
+	assert.Nil(t, err)
+	assert.Equal(t, MemoryAttachmentAttached, attachment.Status)
+	_, err = sess.AttachVolume(second)
+	assert.Equal(t, reasoncode.ErrorVolumeAttachConflict, reasonCode(err))
+	assert.Equal(t, reasoncode.ErrorVolumeAttachConflict, reasonCode(sess.DeleteVolume(volume)))
+
+	_, err = sess.WaitForAttachVolume(first)
+	assert.Nil(t, err)
+	attachments, err := sess.ListVolumeAttachments(volume.VolumeID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(attachments))
+
+	_, err = sess.DetachVolume(first)
+	assert.Nil(t, err)
+	assert.Nil(t, sess.WaitForDetachVolume(first))
+	_, err = sess.GetVolumeAttachment(first)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, reasonCode(err))
+	assert.Nil(t, sess.DeleteVolume(volume))
+
+	shared, _ := sess.CreateVolume(provider.Volume{MultiAttach: true})
+	for _, instanceID := range []string{"instance-1", "instance-2"} {
+		_, err = sess.AttachVolume(provider.VolumeAttachmentRequest{VolumeID: shared.VolumeID, InstanceID: instanceID})
+		assert.Nil(t, err)
+	}
+}
+
+func TestMemorySessionSnapshots(t *testing.T) {
+	sess := NewMemorySession(NewMemoryStore(Quotas{MaxSnapshots: 1}))
+	capacity := 10
+	volume, _ := sess.CreateVolume(provider.Volume{Capacity: &capacity, Az: "us-south-1"})
+
+	snapshot, err := sess.CreateSnapshot(volume.VolumeID, provider.SnapshotParameters{Name: "snap-1"})
+	assert.Nil(t, err)
+	assert.True(t, snapshot.ReadyToUse)
+	_, err = sess.CreateSnapshot(volume.VolumeID, provider.SnapshotParameters{Name: "snap-2"})
+	assert.Equal(t, reasoncode.ErrorQuotaExceeded, reasonCode(err))
+
+	byName, err := sess.GetSnapshotByName("snap-1")
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot.SnapshotID, byName.SnapshotID)
+
+	restored, err := sess.RestoreVolumeFromSnapshot(provider.SnapshotRestoreRequest{SnapshotID: snapshot.SnapshotID})
+	assert.Nil(t, err)
+	assert.Equal(t, 10, *restored.Volume.Capacity)
+	progress, err := sess.GetSnapshotRestoreProgress(restored.Volume.VolumeID)
+	assert.Nil(t, err)
+	assert.Equal(t, provider.SnapshotRestoreCompleted, progress.Phase)
+
+	list, err := sess.ListSnapshots(0, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(list.Snapshots))
+	assert.Nil(t, sess.DeleteSnapshot(snapshot))
+	_, err = sess.GetSnapshot(snapshot.SnapshotID)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, reasonCode(err))
+}
+
+func TestMemoryStoreErrorInjection(t *testing.T) {
+	store := NewMemoryStore(Quotas{})
+	sess := NewMemorySession(store)
+	injected := errors.New("injected")
+
+	store.FailNext("CreateVolume", injected, 2)
+	for i := 0; i < 2; i++ {
+		_, err := sess.CreateVolume(provider.Volume{})
+		assert.Equal(t, injected, err)
+	}
+	_, err := sess.CreateVolume(provider.Volume{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, store.Calls("CreateVolume"))
+
+	store.FailNext(AnyOperation, injected, 1)
+	_, err = sess.ListVolumes(0, "", nil)
+	assert.Equal(t, injected, err)
+
+	store.SetErrorHook(func(operation string) error {
+		if operation == "DeleteVolume" {
+			return injected
+		}
+		return nil
+	})
+	list, err := sess.ListVolumes(0, "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, injected, sess.DeleteVolume(list.Volumes[0]))
+	store.SetErrorHook(nil)
+	assert.Nil(t, sess.DeleteVolume(list.Volumes[0]))
+}
+
+func TestMemoryStoreLatency(t *testing.T) {
+	store := NewMemoryStore(Quotas{})
+	sess := NewMemorySession(store)
+	store.SetLatency(AnyOperation, 20*time.Millisecond)
+	store.SetLatency("GetVolume", 0)
+
+	start := time.Now()
+	volume, err := sess.CreateVolume(provider.Volume{})
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+
+	start = time.Now()
+	_, err = sess.GetVolume(volume.VolumeID)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 20*time.Millisecond)
+}
@@ -2,8 +2,10 @@
 package fakes
 
 import (
+	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 )
@@ -33,6 +35,32 @@ type Context struct {
 	authorizeVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateShareStub        func(provider.FileShareRequest) (*provider.FileShare, error)
+	createShareMutex       sync.RWMutex
+	createShareArgsForCall []struct {
+		arg1 provider.FileShareRequest
+	}
+	createShareReturns struct {
+		result1 *provider.FileShare
+		result2 error
+	}
+	createShareReturnsOnCall map[int]struct {
+		result1 *provider.FileShare
+		result2 error
+	}
+	CreateShareTargetStub        func(provider.ShareTargetRequest) (*provider.ShareTarget, error)
+	createShareTargetMutex       sync.RWMutex
+	createShareTargetArgsForCall []struct {
+		arg1 provider.ShareTargetRequest
+	}
+	createShareTargetReturns struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}
+	createShareTargetReturnsOnCall map[int]struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}
 	CreateSnapshotStub        func(string, provider.SnapshotParameters) (*provider.Snapshot, error)
 	createSnapshotMutex       sync.RWMutex
 	createSnapshotArgsForCall []struct {
@@ -87,6 +115,41 @@ type Context struct {
 		result1 *provider.Volume
 		result2 error
 	}
+	CreateVolumeFromVolumeStub        func(provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error)
+	createVolumeFromVolumeMutex       sync.RWMutex
+	createVolumeFromVolumeArgsForCall []struct {
+		arg1 provider.VolumeCloneRequest
+	}
+	createVolumeFromVolumeReturns struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}
+	createVolumeFromVolumeReturnsOnCall map[int]struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}
+	DeleteShareStub        func(string) error
+	deleteShareMutex       sync.RWMutex
+	deleteShareArgsForCall []struct {
+		arg1 string
+	}
+	deleteShareReturns struct {
+		result1 error
+	}
+	deleteShareReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteShareTargetStub        func(provider.ShareTargetRequest) error
+	deleteShareTargetMutex       sync.RWMutex
+	deleteShareTargetArgsForCall []struct {
+		arg1 provider.ShareTargetRequest
+	}
+	deleteShareTargetReturns struct {
+		result1 error
+	}
+	deleteShareTargetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteSnapshotStub        func(*provider.Snapshot) error
 	deleteSnapshotMutex       sync.RWMutex
 	deleteSnapshotArgsForCall []struct {
@@ -135,6 +198,19 @@ type Context struct {
 		result1 *http.Response
 		result2 error
 	}
+	ExpandShareStub        func(provider.ExpandShareRequest) (int64, error)
+	expandShareMutex       sync.RWMutex
+	expandShareArgsForCall []struct {
+		arg1 provider.ExpandShareRequest
+	}
+	expandShareReturns struct {
+		result1 int64
+		result2 error
+	}
+	expandShareReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	ExpandVolumeStub        func(provider.ExpandVolumeRequest) (int64, error)
 	expandVolumeMutex       sync.RWMutex
 	expandVolumeArgsForCall []struct {
@@ -148,6 +224,33 @@ type Context struct {
 		result1 int64
 		result2 error
 	}
+	GetCapabilitiesStub        func() (*provider.Capabilities, error)
+	getCapabilitiesMutex       sync.RWMutex
+	getCapabilitiesArgsForCall []struct {
+	}
+	getCapabilitiesReturns struct {
+		result1 *provider.Capabilities
+		result2 error
+	}
+	getCapabilitiesReturnsOnCall map[int]struct {
+		result1 *provider.Capabilities
+		result2 error
+	}
+	GetResourceEventsStub        func(context.Context, string, time.Time) ([]*provider.ResourceEvent, error)
+	getResourceEventsMutex       sync.RWMutex
+	getResourceEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}
+	getResourceEventsReturns struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}
+	getResourceEventsReturnsOnCall map[int]struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}
 	GetSnapshotStub        func(string) (*provider.Snapshot, error)
 	getSnapshotMutex       sync.RWMutex
 	getSnapshotArgsForCall []struct {
@@ -174,6 +277,19 @@ type Context struct {
 		result1 *provider.Snapshot
 		result2 error
 	}
+	GetSnapshotRestoreProgressStub        func(string) (*provider.SnapshotRestoreProgress, error)
+	getSnapshotRestoreProgressMutex       sync.RWMutex
+	getSnapshotRestoreProgressArgsForCall []struct {
+		arg1 string
+	}
+	getSnapshotRestoreProgressReturns struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}
+	getSnapshotRestoreProgressReturnsOnCall map[int]struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}
 	GetVolumeStub        func(string) (*provider.Volume, error)
 	getVolumeMutex       sync.RWMutex
 	getVolumeArgsForCall []struct {
@@ -239,6 +355,49 @@ type Context struct {
 		result1 *provider.Volume
 		result2 error
 	}
+	GetVolumePerformanceStatsStub        func(context.Context, string, time.Duration) (*provider.VolumePerformanceStats, error)
+	getVolumePerformanceStatsMutex       sync.RWMutex
+	getVolumePerformanceStatsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	getVolumePerformanceStatsReturns struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}
+	getVolumePerformanceStatsReturnsOnCall map[int]struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}
+	GetVolumeTagsStub        func(string) (*provider.VolumeTags, error)
+	getVolumeTagsMutex       sync.RWMutex
+	getVolumeTagsArgsForCall []struct {
+		arg1 string
+	}
+	getVolumeTagsReturns struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	getVolumeTagsReturnsOnCall map[int]struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	ListSharesStub        func(int, string, map[string]string) (*provider.FileShareList, error)
+	listSharesMutex       sync.RWMutex
+	listSharesArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 map[string]string
+	}
+	listSharesReturns struct {
+		result1 *provider.FileShareList
+		result2 error
+	}
+	listSharesReturnsOnCall map[int]struct {
+		result1 *provider.FileShareList
+		result2 error
+	}
 	ListSnapshotsStub        func(int, string, map[string]string) (*provider.SnapshotList, error)
 	listSnapshotsMutex       sync.RWMutex
 	listSnapshotsArgsForCall []struct {
@@ -254,6 +413,19 @@ type Context struct {
 		result1 *provider.SnapshotList
 		result2 error
 	}
+	ListVolumeAttachmentsStub        func(string) ([]*provider.VolumeAttachmentResponse, error)
+	listVolumeAttachmentsMutex       sync.RWMutex
+	listVolumeAttachmentsArgsForCall []struct {
+		arg1 string
+	}
+	listVolumeAttachmentsReturns struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}
+	listVolumeAttachmentsReturnsOnCall map[int]struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}
 	ListVolumesStub        func(int, string, map[string]string) (*provider.VolumeList, error)
 	listVolumesMutex       sync.RWMutex
 	listVolumesArgsForCall []struct {
@@ -269,6 +441,21 @@ type Context struct {
 		result1 *provider.VolumeList
 		result2 error
 	}
+	ListVolumesWithFiltersStub        func(int, string, provider.ListVolumesFilters) (*provider.VolumeList, error)
+	listVolumesWithFiltersMutex       sync.RWMutex
+	listVolumesWithFiltersArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 provider.ListVolumesFilters
+	}
+	listVolumesWithFiltersReturns struct {
+		result1 *provider.VolumeList
+		result2 error
+	}
+	listVolumesWithFiltersReturnsOnCall map[int]struct {
+		result1 *provider.VolumeList
+		result2 error
+	}
 	ProviderNameStub        func() provider.VolumeProvider
 	providerNameMutex       sync.RWMutex
 	providerNameArgsForCall []struct {
@@ -279,6 +466,30 @@ type Context struct {
 	providerNameReturnsOnCall map[int]struct {
 		result1 provider.VolumeProvider
 	}
+	RestoreVolumeFromSnapshotStub        func(provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error)
+	restoreVolumeFromSnapshotMutex       sync.RWMutex
+	restoreVolumeFromSnapshotArgsForCall []struct {
+		arg1 provider.SnapshotRestoreRequest
+	}
+	restoreVolumeFromSnapshotReturns struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}
+	restoreVolumeFromSnapshotReturnsOnCall map[int]struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}
+	SupportsOnlineExpansionStub        func(*provider.Volume) bool
+	supportsOnlineExpansionMutex       sync.RWMutex
+	supportsOnlineExpansionArgsForCall []struct {
+		arg1 *provider.Volume
+	}
+	supportsOnlineExpansionReturns struct {
+		result1 bool
+	}
+	supportsOnlineExpansionReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	TypeStub        func() provider.VolumeType
 	typeMutex       sync.RWMutex
 	typeArgsForCall []struct {
@@ -300,6 +511,58 @@ type Context struct {
 	updateVolumeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateVolumeAttachmentStub        func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)
+	updateVolumeAttachmentMutex       sync.RWMutex
+	updateVolumeAttachmentArgsForCall []struct {
+		arg1 provider.VolumeAttachmentRequest
+	}
+	updateVolumeAttachmentReturns struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}
+	updateVolumeAttachmentReturnsOnCall map[int]struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}
+	UpdateVolumeIOPSStub        func(provider.VolumeIOPSUpdateRequest) (*provider.Volume, error)
+	updateVolumeIOPSMutex       sync.RWMutex
+	updateVolumeIOPSArgsForCall []struct {
+		arg1 provider.VolumeIOPSUpdateRequest
+	}
+	updateVolumeIOPSReturns struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	updateVolumeIOPSReturnsOnCall map[int]struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	UpdateVolumeProfileStub        func(provider.VolumeProfileUpdateRequest) (*provider.Volume, error)
+	updateVolumeProfileMutex       sync.RWMutex
+	updateVolumeProfileArgsForCall []struct {
+		arg1 provider.VolumeProfileUpdateRequest
+	}
+	updateVolumeProfileReturns struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	updateVolumeProfileReturnsOnCall map[int]struct {
+		result1 *provider.Volume
+		result2 error
+	}
+	UpdateVolumeTagsStub        func(provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error)
+	updateVolumeTagsMutex       sync.RWMutex
+	updateVolumeTagsArgsForCall []struct {
+		arg1 provider.VolumeTagsUpdateRequest
+	}
+	updateVolumeTagsReturns struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
+	updateVolumeTagsReturnsOnCall map[int]struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}
 	WaitForAttachVolumeStub        func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)
 	waitForAttachVolumeMutex       sync.RWMutex
 	waitForAttachVolumeArgsForCall []struct {
@@ -477,6 +740,134 @@ func (fake *Context) AuthorizeVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Context) CreateShare(arg1 provider.FileShareRequest) (*provider.FileShare, error) {
+	fake.createShareMutex.Lock()
+	ret, specificReturn := fake.createShareReturnsOnCall[len(fake.createShareArgsForCall)]
+	fake.createShareArgsForCall = append(fake.createShareArgsForCall, struct {
+		arg1 provider.FileShareRequest
+	}{arg1})
+	stub := fake.CreateShareStub
+	fakeReturns := fake.createShareReturns
+	fake.recordInvocation("CreateShare", []interface{}{arg1})
+	fake.createShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) CreateShareCallCount() int {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	return len(fake.createShareArgsForCall)
+}
+
+func (fake *Context) CreateShareCalls(stub func(provider.FileShareRequest) (*provider.FileShare, error)) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = stub
+}
+
+func (fake *Context) CreateShareArgsForCall(i int) provider.FileShareRequest {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	argsForCall := fake.createShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) CreateShareReturns(result1 *provider.FileShare, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	fake.createShareReturns = struct {
+		result1 *provider.FileShare
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) CreateShareReturnsOnCall(i int, result1 *provider.FileShare, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	if fake.createShareReturnsOnCall == nil {
+		fake.createShareReturnsOnCall = make(map[int]struct {
+			result1 *provider.FileShare
+			result2 error
+		})
+	}
+	fake.createShareReturnsOnCall[i] = struct {
+		result1 *provider.FileShare
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) CreateShareTarget(arg1 provider.ShareTargetRequest) (*provider.ShareTarget, error) {
+	fake.createShareTargetMutex.Lock()
+	ret, specificReturn := fake.createShareTargetReturnsOnCall[len(fake.createShareTargetArgsForCall)]
+	fake.createShareTargetArgsForCall = append(fake.createShareTargetArgsForCall, struct {
+		arg1 provider.ShareTargetRequest
+	}{arg1})
+	stub := fake.CreateShareTargetStub
+	fakeReturns := fake.createShareTargetReturns
+	fake.recordInvocation("CreateShareTarget", []interface{}{arg1})
+	fake.createShareTargetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) CreateShareTargetCallCount() int {
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
+	return len(fake.createShareTargetArgsForCall)
+}
+
+func (fake *Context) CreateShareTargetCalls(stub func(provider.ShareTargetRequest) (*provider.ShareTarget, error)) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = stub
+}
+
+func (fake *Context) CreateShareTargetArgsForCall(i int) provider.ShareTargetRequest {
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
+	argsForCall := fake.createShareTargetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) CreateShareTargetReturns(result1 *provider.ShareTarget, result2 error) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = nil
+	fake.createShareTargetReturns = struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) CreateShareTargetReturnsOnCall(i int, result1 *provider.ShareTarget, result2 error) {
+	fake.createShareTargetMutex.Lock()
+	defer fake.createShareTargetMutex.Unlock()
+	fake.CreateShareTargetStub = nil
+	if fake.createShareTargetReturnsOnCall == nil {
+		fake.createShareTargetReturnsOnCall = make(map[int]struct {
+			result1 *provider.ShareTarget
+			result2 error
+		})
+	}
+	fake.createShareTargetReturnsOnCall[i] = struct {
+		result1 *provider.ShareTarget
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) CreateSnapshot(arg1 string, arg2 provider.SnapshotParameters) (*provider.Snapshot, error) {
 	fake.createSnapshotMutex.Lock()
 	ret, specificReturn := fake.createSnapshotReturnsOnCall[len(fake.createSnapshotArgsForCall)]
@@ -735,77 +1126,80 @@ func (fake *Context) CreateVolumeFromSnapshotReturnsOnCall(i int, result1 *provi
 	}{result1, result2}
 }
 
-func (fake *Context) DeleteSnapshot(arg1 *provider.Snapshot) error {
-	fake.deleteSnapshotMutex.Lock()
-	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
-	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
-		arg1 *provider.Snapshot
+func (fake *Context) CreateVolumeFromVolume(arg1 provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	ret, specificReturn := fake.createVolumeFromVolumeReturnsOnCall[len(fake.createVolumeFromVolumeArgsForCall)]
+	fake.createVolumeFromVolumeArgsForCall = append(fake.createVolumeFromVolumeArgsForCall, struct {
+		arg1 provider.VolumeCloneRequest
 	}{arg1})
-	stub := fake.DeleteSnapshotStub
-	fakeReturns := fake.deleteSnapshotReturns
-	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1})
-	fake.deleteSnapshotMutex.Unlock()
+	stub := fake.CreateVolumeFromVolumeStub
+	fakeReturns := fake.createVolumeFromVolumeReturns
+	fake.recordInvocation("CreateVolumeFromVolume", []interface{}{arg1})
+	fake.createVolumeFromVolumeMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *Context) DeleteSnapshotCallCount() int {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	return len(fake.deleteSnapshotArgsForCall)
+func (fake *Context) CreateVolumeFromVolumeCallCount() int {
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	return len(fake.createVolumeFromVolumeArgsForCall)
 }
 
-func (fake *Context) DeleteSnapshotCalls(stub func(*provider.Snapshot) error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = stub
+func (fake *Context) CreateVolumeFromVolumeCalls(stub func(provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error)) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = stub
 }
 
-func (fake *Context) DeleteSnapshotArgsForCall(i int) *provider.Snapshot {
-	fake.deleteSnapshotMutex.RLock()
-	defer fake.deleteSnapshotMutex.RUnlock()
-	argsForCall := fake.deleteSnapshotArgsForCall[i]
+func (fake *Context) CreateVolumeFromVolumeArgsForCall(i int) provider.VolumeCloneRequest {
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	argsForCall := fake.createVolumeFromVolumeArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *Context) DeleteSnapshotReturns(result1 error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = nil
-	fake.deleteSnapshotReturns = struct {
-		result1 error
-	}{result1}
+func (fake *Context) CreateVolumeFromVolumeReturns(result1 *provider.VolumeCloneResponse, result2 error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = nil
+	fake.createVolumeFromVolumeReturns = struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *Context) DeleteSnapshotReturnsOnCall(i int, result1 error) {
-	fake.deleteSnapshotMutex.Lock()
-	defer fake.deleteSnapshotMutex.Unlock()
-	fake.DeleteSnapshotStub = nil
-	if fake.deleteSnapshotReturnsOnCall == nil {
-		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
-			result1 error
+func (fake *Context) CreateVolumeFromVolumeReturnsOnCall(i int, result1 *provider.VolumeCloneResponse, result2 error) {
+	fake.createVolumeFromVolumeMutex.Lock()
+	defer fake.createVolumeFromVolumeMutex.Unlock()
+	fake.CreateVolumeFromVolumeStub = nil
+	if fake.createVolumeFromVolumeReturnsOnCall == nil {
+		fake.createVolumeFromVolumeReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeCloneResponse
+			result2 error
 		})
 	}
-	fake.deleteSnapshotReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+	fake.createVolumeFromVolumeReturnsOnCall[i] = struct {
+		result1 *provider.VolumeCloneResponse
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *Context) DeleteVolume(arg1 *provider.Volume) error {
-	fake.deleteVolumeMutex.Lock()
-	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
-	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
-		arg1 *provider.Volume
+func (fake *Context) DeleteShare(arg1 string) error {
+	fake.deleteShareMutex.Lock()
+	ret, specificReturn := fake.deleteShareReturnsOnCall[len(fake.deleteShareArgsForCall)]
+	fake.deleteShareArgsForCall = append(fake.deleteShareArgsForCall, struct {
+		arg1 string
 	}{arg1})
-	stub := fake.DeleteVolumeStub
-	fakeReturns := fake.deleteVolumeReturns
-	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
-	fake.deleteVolumeMutex.Unlock()
+	stub := fake.DeleteShareStub
+	fakeReturns := fake.deleteShareReturns
+	fake.recordInvocation("DeleteShare", []interface{}{arg1})
+	fake.deleteShareMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -815,39 +1209,222 @@ func (fake *Context) DeleteVolume(arg1 *provider.Volume) error {
 	return fakeReturns.result1
 }
 
-func (fake *Context) DeleteVolumeCallCount() int {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	return len(fake.deleteVolumeArgsForCall)
+func (fake *Context) DeleteShareCallCount() int {
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	return len(fake.deleteShareArgsForCall)
 }
 
-func (fake *Context) DeleteVolumeCalls(stub func(*provider.Volume) error) {
-	fake.deleteVolumeMutex.Lock()
-	defer fake.deleteVolumeMutex.Unlock()
-	fake.DeleteVolumeStub = stub
+func (fake *Context) DeleteShareCalls(stub func(string) error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = stub
 }
 
-func (fake *Context) DeleteVolumeArgsForCall(i int) *provider.Volume {
-	fake.deleteVolumeMutex.RLock()
-	defer fake.deleteVolumeMutex.RUnlock()
-	argsForCall := fake.deleteVolumeArgsForCall[i]
+func (fake *Context) DeleteShareArgsForCall(i int) string {
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	argsForCall := fake.deleteShareArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *Context) DeleteVolumeReturns(result1 error) {
-	fake.deleteVolumeMutex.Lock()
-	defer fake.deleteVolumeMutex.Unlock()
-	fake.DeleteVolumeStub = nil
-	fake.deleteVolumeReturns = struct {
+func (fake *Context) DeleteShareReturns(result1 error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = nil
+	fake.deleteShareReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *Context) DeleteVolumeReturnsOnCall(i int, result1 error) {
-	fake.deleteVolumeMutex.Lock()
-	defer fake.deleteVolumeMutex.Unlock()
-	fake.DeleteVolumeStub = nil
-	if fake.deleteVolumeReturnsOnCall == nil {
+func (fake *Context) DeleteShareReturnsOnCall(i int, result1 error) {
+	fake.deleteShareMutex.Lock()
+	defer fake.deleteShareMutex.Unlock()
+	fake.DeleteShareStub = nil
+	if fake.deleteShareReturnsOnCall == nil {
+		fake.deleteShareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteShareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteShareTarget(arg1 provider.ShareTargetRequest) error {
+	fake.deleteShareTargetMutex.Lock()
+	ret, specificReturn := fake.deleteShareTargetReturnsOnCall[len(fake.deleteShareTargetArgsForCall)]
+	fake.deleteShareTargetArgsForCall = append(fake.deleteShareTargetArgsForCall, struct {
+		arg1 provider.ShareTargetRequest
+	}{arg1})
+	stub := fake.DeleteShareTargetStub
+	fakeReturns := fake.deleteShareTargetReturns
+	fake.recordInvocation("DeleteShareTarget", []interface{}{arg1})
+	fake.deleteShareTargetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Context) DeleteShareTargetCallCount() int {
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
+	return len(fake.deleteShareTargetArgsForCall)
+}
+
+func (fake *Context) DeleteShareTargetCalls(stub func(provider.ShareTargetRequest) error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = stub
+}
+
+func (fake *Context) DeleteShareTargetArgsForCall(i int) provider.ShareTargetRequest {
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
+	argsForCall := fake.deleteShareTargetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) DeleteShareTargetReturns(result1 error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = nil
+	fake.deleteShareTargetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteShareTargetReturnsOnCall(i int, result1 error) {
+	fake.deleteShareTargetMutex.Lock()
+	defer fake.deleteShareTargetMutex.Unlock()
+	fake.DeleteShareTargetStub = nil
+	if fake.deleteShareTargetReturnsOnCall == nil {
+		fake.deleteShareTargetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteShareTargetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteSnapshot(arg1 *provider.Snapshot) error {
+	fake.deleteSnapshotMutex.Lock()
+	ret, specificReturn := fake.deleteSnapshotReturnsOnCall[len(fake.deleteSnapshotArgsForCall)]
+	fake.deleteSnapshotArgsForCall = append(fake.deleteSnapshotArgsForCall, struct {
+		arg1 *provider.Snapshot
+	}{arg1})
+	stub := fake.DeleteSnapshotStub
+	fakeReturns := fake.deleteSnapshotReturns
+	fake.recordInvocation("DeleteSnapshot", []interface{}{arg1})
+	fake.deleteSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Context) DeleteSnapshotCallCount() int {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	return len(fake.deleteSnapshotArgsForCall)
+}
+
+func (fake *Context) DeleteSnapshotCalls(stub func(*provider.Snapshot) error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = stub
+}
+
+func (fake *Context) DeleteSnapshotArgsForCall(i int) *provider.Snapshot {
+	fake.deleteSnapshotMutex.RLock()
+	defer fake.deleteSnapshotMutex.RUnlock()
+	argsForCall := fake.deleteSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) DeleteSnapshotReturns(result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	fake.deleteSnapshotReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteSnapshotReturnsOnCall(i int, result1 error) {
+	fake.deleteSnapshotMutex.Lock()
+	defer fake.deleteSnapshotMutex.Unlock()
+	fake.DeleteSnapshotStub = nil
+	if fake.deleteSnapshotReturnsOnCall == nil {
+		fake.deleteSnapshotReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSnapshotReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteVolume(arg1 *provider.Volume) error {
+	fake.deleteVolumeMutex.Lock()
+	ret, specificReturn := fake.deleteVolumeReturnsOnCall[len(fake.deleteVolumeArgsForCall)]
+	fake.deleteVolumeArgsForCall = append(fake.deleteVolumeArgsForCall, struct {
+		arg1 *provider.Volume
+	}{arg1})
+	stub := fake.DeleteVolumeStub
+	fakeReturns := fake.deleteVolumeReturns
+	fake.recordInvocation("DeleteVolume", []interface{}{arg1})
+	fake.deleteVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Context) DeleteVolumeCallCount() int {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	return len(fake.deleteVolumeArgsForCall)
+}
+
+func (fake *Context) DeleteVolumeCalls(stub func(*provider.Volume) error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = stub
+}
+
+func (fake *Context) DeleteVolumeArgsForCall(i int) *provider.Volume {
+	fake.deleteVolumeMutex.RLock()
+	defer fake.deleteVolumeMutex.RUnlock()
+	argsForCall := fake.deleteVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) DeleteVolumeReturns(result1 error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = nil
+	fake.deleteVolumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Context) DeleteVolumeReturnsOnCall(i int, result1 error) {
+	fake.deleteVolumeMutex.Lock()
+	defer fake.deleteVolumeMutex.Unlock()
+	fake.DeleteVolumeStub = nil
+	if fake.deleteVolumeReturnsOnCall == nil {
 		fake.deleteVolumeReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
@@ -985,6 +1562,70 @@ func (fake *Context) DetachVolumeReturnsOnCall(i int, result1 *http.Response, re
 	}{result1, result2}
 }
 
+func (fake *Context) ExpandShare(arg1 provider.ExpandShareRequest) (int64, error) {
+	fake.expandShareMutex.Lock()
+	ret, specificReturn := fake.expandShareReturnsOnCall[len(fake.expandShareArgsForCall)]
+	fake.expandShareArgsForCall = append(fake.expandShareArgsForCall, struct {
+		arg1 provider.ExpandShareRequest
+	}{arg1})
+	stub := fake.ExpandShareStub
+	fakeReturns := fake.expandShareReturns
+	fake.recordInvocation("ExpandShare", []interface{}{arg1})
+	fake.expandShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) ExpandShareCallCount() int {
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
+	return len(fake.expandShareArgsForCall)
+}
+
+func (fake *Context) ExpandShareCalls(stub func(provider.ExpandShareRequest) (int64, error)) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = stub
+}
+
+func (fake *Context) ExpandShareArgsForCall(i int) provider.ExpandShareRequest {
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
+	argsForCall := fake.expandShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) ExpandShareReturns(result1 int64, result2 error) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = nil
+	fake.expandShareReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) ExpandShareReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.expandShareMutex.Lock()
+	defer fake.expandShareMutex.Unlock()
+	fake.ExpandShareStub = nil
+	if fake.expandShareReturnsOnCall == nil {
+		fake.expandShareReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.expandShareReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) ExpandVolume(arg1 provider.ExpandVolumeRequest) (int64, error) {
 	fake.expandVolumeMutex.Lock()
 	ret, specificReturn := fake.expandVolumeReturnsOnCall[len(fake.expandVolumeArgsForCall)]
@@ -1049,6 +1690,128 @@ func (fake *Context) ExpandVolumeReturnsOnCall(i int, result1 int64, result2 err
 	}{result1, result2}
 }
 
+func (fake *Context) GetCapabilities() (*provider.Capabilities, error) {
+	fake.getCapabilitiesMutex.Lock()
+	ret, specificReturn := fake.getCapabilitiesReturnsOnCall[len(fake.getCapabilitiesArgsForCall)]
+	fake.getCapabilitiesArgsForCall = append(fake.getCapabilitiesArgsForCall, struct {
+	}{})
+	stub := fake.GetCapabilitiesStub
+	fakeReturns := fake.getCapabilitiesReturns
+	fake.recordInvocation("GetCapabilities", []interface{}{})
+	fake.getCapabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) GetCapabilitiesCallCount() int {
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
+	return len(fake.getCapabilitiesArgsForCall)
+}
+
+func (fake *Context) GetCapabilitiesCalls(stub func() (*provider.Capabilities, error)) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = stub
+}
+
+func (fake *Context) GetCapabilitiesReturns(result1 *provider.Capabilities, result2 error) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = nil
+	fake.getCapabilitiesReturns = struct {
+		result1 *provider.Capabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetCapabilitiesReturnsOnCall(i int, result1 *provider.Capabilities, result2 error) {
+	fake.getCapabilitiesMutex.Lock()
+	defer fake.getCapabilitiesMutex.Unlock()
+	fake.GetCapabilitiesStub = nil
+	if fake.getCapabilitiesReturnsOnCall == nil {
+		fake.getCapabilitiesReturnsOnCall = make(map[int]struct {
+			result1 *provider.Capabilities
+			result2 error
+		})
+	}
+	fake.getCapabilitiesReturnsOnCall[i] = struct {
+		result1 *provider.Capabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetResourceEvents(arg1 context.Context, arg2 string, arg3 time.Time) ([]*provider.ResourceEvent, error) {
+	fake.getResourceEventsMutex.Lock()
+	ret, specificReturn := fake.getResourceEventsReturnsOnCall[len(fake.getResourceEventsArgsForCall)]
+	fake.getResourceEventsArgsForCall = append(fake.getResourceEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.GetResourceEventsStub
+	fakeReturns := fake.getResourceEventsReturns
+	fake.recordInvocation("GetResourceEvents", []interface{}{arg1, arg2, arg3})
+	fake.getResourceEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) GetResourceEventsCallCount() int {
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
+	return len(fake.getResourceEventsArgsForCall)
+}
+
+func (fake *Context) GetResourceEventsCalls(stub func(context.Context, string, time.Time) ([]*provider.ResourceEvent, error)) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = stub
+}
+
+func (fake *Context) GetResourceEventsArgsForCall(i int) (context.Context, string, time.Time) {
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
+	argsForCall := fake.getResourceEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Context) GetResourceEventsReturns(result1 []*provider.ResourceEvent, result2 error) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = nil
+	fake.getResourceEventsReturns = struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetResourceEventsReturnsOnCall(i int, result1 []*provider.ResourceEvent, result2 error) {
+	fake.getResourceEventsMutex.Lock()
+	defer fake.getResourceEventsMutex.Unlock()
+	fake.GetResourceEventsStub = nil
+	if fake.getResourceEventsReturnsOnCall == nil {
+		fake.getResourceEventsReturnsOnCall = make(map[int]struct {
+			result1 []*provider.ResourceEvent
+			result2 error
+		})
+	}
+	fake.getResourceEventsReturnsOnCall[i] = struct {
+		result1 []*provider.ResourceEvent
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) GetSnapshot(arg1 string) (*provider.Snapshot, error) {
 	fake.getSnapshotMutex.Lock()
 	ret, specificReturn := fake.getSnapshotReturnsOnCall[len(fake.getSnapshotArgsForCall)]
@@ -1177,16 +1940,16 @@ func (fake *Context) GetSnapshotByNameReturnsOnCall(i int, result1 *provider.Sna
 	}{result1, result2}
 }
 
-func (fake *Context) GetVolume(arg1 string) (*provider.Volume, error) {
-	fake.getVolumeMutex.Lock()
-	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
-	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
+func (fake *Context) GetSnapshotRestoreProgress(arg1 string) (*provider.SnapshotRestoreProgress, error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	ret, specificReturn := fake.getSnapshotRestoreProgressReturnsOnCall[len(fake.getSnapshotRestoreProgressArgsForCall)]
+	fake.getSnapshotRestoreProgressArgsForCall = append(fake.getSnapshotRestoreProgressArgsForCall, struct {
 		arg1 string
 	}{arg1})
-	stub := fake.GetVolumeStub
-	fakeReturns := fake.getVolumeReturns
-	fake.recordInvocation("GetVolume", []interface{}{arg1})
-	fake.getVolumeMutex.Unlock()
+	stub := fake.GetSnapshotRestoreProgressStub
+	fakeReturns := fake.getSnapshotRestoreProgressReturns
+	fake.recordInvocation("GetSnapshotRestoreProgress", []interface{}{arg1})
+	fake.getSnapshotRestoreProgressMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -1196,28 +1959,92 @@ func (fake *Context) GetVolume(arg1 string) (*provider.Volume, error) {
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *Context) GetVolumeCallCount() int {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	return len(fake.getVolumeArgsForCall)
+func (fake *Context) GetSnapshotRestoreProgressCallCount() int {
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
+	return len(fake.getSnapshotRestoreProgressArgsForCall)
 }
 
-func (fake *Context) GetVolumeCalls(stub func(string) (*provider.Volume, error)) {
-	fake.getVolumeMutex.Lock()
-	defer fake.getVolumeMutex.Unlock()
-	fake.GetVolumeStub = stub
+func (fake *Context) GetSnapshotRestoreProgressCalls(stub func(string) (*provider.SnapshotRestoreProgress, error)) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = stub
 }
 
-func (fake *Context) GetVolumeArgsForCall(i int) string {
-	fake.getVolumeMutex.RLock()
-	defer fake.getVolumeMutex.RUnlock()
-	argsForCall := fake.getVolumeArgsForCall[i]
+func (fake *Context) GetSnapshotRestoreProgressArgsForCall(i int) string {
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
+	argsForCall := fake.getSnapshotRestoreProgressArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *Context) GetVolumeReturns(result1 *provider.Volume, result2 error) {
-	fake.getVolumeMutex.Lock()
-	defer fake.getVolumeMutex.Unlock()
+func (fake *Context) GetSnapshotRestoreProgressReturns(result1 *provider.SnapshotRestoreProgress, result2 error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = nil
+	fake.getSnapshotRestoreProgressReturns = struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetSnapshotRestoreProgressReturnsOnCall(i int, result1 *provider.SnapshotRestoreProgress, result2 error) {
+	fake.getSnapshotRestoreProgressMutex.Lock()
+	defer fake.getSnapshotRestoreProgressMutex.Unlock()
+	fake.GetSnapshotRestoreProgressStub = nil
+	if fake.getSnapshotRestoreProgressReturnsOnCall == nil {
+		fake.getSnapshotRestoreProgressReturnsOnCall = make(map[int]struct {
+			result1 *provider.SnapshotRestoreProgress
+			result2 error
+		})
+	}
+	fake.getSnapshotRestoreProgressReturnsOnCall[i] = struct {
+		result1 *provider.SnapshotRestoreProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetVolume(arg1 string) (*provider.Volume, error) {
+	fake.getVolumeMutex.Lock()
+	ret, specificReturn := fake.getVolumeReturnsOnCall[len(fake.getVolumeArgsForCall)]
+	fake.getVolumeArgsForCall = append(fake.getVolumeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetVolumeStub
+	fakeReturns := fake.getVolumeReturns
+	fake.recordInvocation("GetVolume", []interface{}{arg1})
+	fake.getVolumeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) GetVolumeCallCount() int {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	return len(fake.getVolumeArgsForCall)
+}
+
+func (fake *Context) GetVolumeCalls(stub func(string) (*provider.Volume, error)) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
+	fake.GetVolumeStub = stub
+}
+
+func (fake *Context) GetVolumeArgsForCall(i int) string {
+	fake.getVolumeMutex.RLock()
+	defer fake.getVolumeMutex.RUnlock()
+	argsForCall := fake.getVolumeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) GetVolumeReturns(result1 *provider.Volume, result2 error) {
+	fake.getVolumeMutex.Lock()
+	defer fake.getVolumeMutex.Unlock()
 	fake.GetVolumeStub = nil
 	fake.getVolumeReturns = struct {
 		result1 *provider.Volume
@@ -1497,6 +2324,202 @@ func (fake *Context) GetVolumeByRequestIDReturnsOnCall(i int, result1 *provider.
 	}{result1, result2}
 }
 
+func (fake *Context) GetVolumePerformanceStats(arg1 context.Context, arg2 string, arg3 time.Duration) (*provider.VolumePerformanceStats, error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	ret, specificReturn := fake.getVolumePerformanceStatsReturnsOnCall[len(fake.getVolumePerformanceStatsArgsForCall)]
+	fake.getVolumePerformanceStatsArgsForCall = append(fake.getVolumePerformanceStatsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.GetVolumePerformanceStatsStub
+	fakeReturns := fake.getVolumePerformanceStatsReturns
+	fake.recordInvocation("GetVolumePerformanceStats", []interface{}{arg1, arg2, arg3})
+	fake.getVolumePerformanceStatsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) GetVolumePerformanceStatsCallCount() int {
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	return len(fake.getVolumePerformanceStatsArgsForCall)
+}
+
+func (fake *Context) GetVolumePerformanceStatsCalls(stub func(context.Context, string, time.Duration) (*provider.VolumePerformanceStats, error)) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = stub
+}
+
+func (fake *Context) GetVolumePerformanceStatsArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	argsForCall := fake.getVolumePerformanceStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Context) GetVolumePerformanceStatsReturns(result1 *provider.VolumePerformanceStats, result2 error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = nil
+	fake.getVolumePerformanceStatsReturns = struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetVolumePerformanceStatsReturnsOnCall(i int, result1 *provider.VolumePerformanceStats, result2 error) {
+	fake.getVolumePerformanceStatsMutex.Lock()
+	defer fake.getVolumePerformanceStatsMutex.Unlock()
+	fake.GetVolumePerformanceStatsStub = nil
+	if fake.getVolumePerformanceStatsReturnsOnCall == nil {
+		fake.getVolumePerformanceStatsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumePerformanceStats
+			result2 error
+		})
+	}
+	fake.getVolumePerformanceStatsReturnsOnCall[i] = struct {
+		result1 *provider.VolumePerformanceStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetVolumeTags(arg1 string) (*provider.VolumeTags, error) {
+	fake.getVolumeTagsMutex.Lock()
+	ret, specificReturn := fake.getVolumeTagsReturnsOnCall[len(fake.getVolumeTagsArgsForCall)]
+	fake.getVolumeTagsArgsForCall = append(fake.getVolumeTagsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetVolumeTagsStub
+	fakeReturns := fake.getVolumeTagsReturns
+	fake.recordInvocation("GetVolumeTags", []interface{}{arg1})
+	fake.getVolumeTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) GetVolumeTagsCallCount() int {
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	return len(fake.getVolumeTagsArgsForCall)
+}
+
+func (fake *Context) GetVolumeTagsCalls(stub func(string) (*provider.VolumeTags, error)) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = stub
+}
+
+func (fake *Context) GetVolumeTagsArgsForCall(i int) string {
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	argsForCall := fake.getVolumeTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) GetVolumeTagsReturns(result1 *provider.VolumeTags, result2 error) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = nil
+	fake.getVolumeTagsReturns = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) GetVolumeTagsReturnsOnCall(i int, result1 *provider.VolumeTags, result2 error) {
+	fake.getVolumeTagsMutex.Lock()
+	defer fake.getVolumeTagsMutex.Unlock()
+	fake.GetVolumeTagsStub = nil
+	if fake.getVolumeTagsReturnsOnCall == nil {
+		fake.getVolumeTagsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeTags
+			result2 error
+		})
+	}
+	fake.getVolumeTagsReturnsOnCall[i] = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) ListShares(arg1 int, arg2 string, arg3 map[string]string) (*provider.FileShareList, error) {
+	fake.listSharesMutex.Lock()
+	ret, specificReturn := fake.listSharesReturnsOnCall[len(fake.listSharesArgsForCall)]
+	fake.listSharesArgsForCall = append(fake.listSharesArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 map[string]string
+	}{arg1, arg2, arg3})
+	stub := fake.ListSharesStub
+	fakeReturns := fake.listSharesReturns
+	fake.recordInvocation("ListShares", []interface{}{arg1, arg2, arg3})
+	fake.listSharesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) ListSharesCallCount() int {
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
+	return len(fake.listSharesArgsForCall)
+}
+
+func (fake *Context) ListSharesCalls(stub func(int, string, map[string]string) (*provider.FileShareList, error)) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = stub
+}
+
+func (fake *Context) ListSharesArgsForCall(i int) (int, string, map[string]string) {
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
+	argsForCall := fake.listSharesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Context) ListSharesReturns(result1 *provider.FileShareList, result2 error) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = nil
+	fake.listSharesReturns = struct {
+		result1 *provider.FileShareList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) ListSharesReturnsOnCall(i int, result1 *provider.FileShareList, result2 error) {
+	fake.listSharesMutex.Lock()
+	defer fake.listSharesMutex.Unlock()
+	fake.ListSharesStub = nil
+	if fake.listSharesReturnsOnCall == nil {
+		fake.listSharesReturnsOnCall = make(map[int]struct {
+			result1 *provider.FileShareList
+			result2 error
+		})
+	}
+	fake.listSharesReturnsOnCall[i] = struct {
+		result1 *provider.FileShareList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) ListSnapshots(arg1 int, arg2 string, arg3 map[string]string) (*provider.SnapshotList, error) {
 	fake.listSnapshotsMutex.Lock()
 	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
@@ -1563,6 +2586,70 @@ func (fake *Context) ListSnapshotsReturnsOnCall(i int, result1 *provider.Snapsho
 	}{result1, result2}
 }
 
+func (fake *Context) ListVolumeAttachments(arg1 string) ([]*provider.VolumeAttachmentResponse, error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	ret, specificReturn := fake.listVolumeAttachmentsReturnsOnCall[len(fake.listVolumeAttachmentsArgsForCall)]
+	fake.listVolumeAttachmentsArgsForCall = append(fake.listVolumeAttachmentsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ListVolumeAttachmentsStub
+	fakeReturns := fake.listVolumeAttachmentsReturns
+	fake.recordInvocation("ListVolumeAttachments", []interface{}{arg1})
+	fake.listVolumeAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) ListVolumeAttachmentsCallCount() int {
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
+	return len(fake.listVolumeAttachmentsArgsForCall)
+}
+
+func (fake *Context) ListVolumeAttachmentsCalls(stub func(string) ([]*provider.VolumeAttachmentResponse, error)) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = stub
+}
+
+func (fake *Context) ListVolumeAttachmentsArgsForCall(i int) string {
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
+	argsForCall := fake.listVolumeAttachmentsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) ListVolumeAttachmentsReturns(result1 []*provider.VolumeAttachmentResponse, result2 error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = nil
+	fake.listVolumeAttachmentsReturns = struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) ListVolumeAttachmentsReturnsOnCall(i int, result1 []*provider.VolumeAttachmentResponse, result2 error) {
+	fake.listVolumeAttachmentsMutex.Lock()
+	defer fake.listVolumeAttachmentsMutex.Unlock()
+	fake.ListVolumeAttachmentsStub = nil
+	if fake.listVolumeAttachmentsReturnsOnCall == nil {
+		fake.listVolumeAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 []*provider.VolumeAttachmentResponse
+			result2 error
+		})
+	}
+	fake.listVolumeAttachmentsReturnsOnCall[i] = struct {
+		result1 []*provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) ListVolumes(arg1 int, arg2 string, arg3 map[string]string) (*provider.VolumeList, error) {
 	fake.listVolumesMutex.Lock()
 	ret, specificReturn := fake.listVolumesReturnsOnCall[len(fake.listVolumesArgsForCall)]
@@ -1629,6 +2716,72 @@ func (fake *Context) ListVolumesReturnsOnCall(i int, result1 *provider.VolumeLis
 	}{result1, result2}
 }
 
+func (fake *Context) ListVolumesWithFilters(arg1 int, arg2 string, arg3 provider.ListVolumesFilters) (*provider.VolumeList, error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	ret, specificReturn := fake.listVolumesWithFiltersReturnsOnCall[len(fake.listVolumesWithFiltersArgsForCall)]
+	fake.listVolumesWithFiltersArgsForCall = append(fake.listVolumesWithFiltersArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 provider.ListVolumesFilters
+	}{arg1, arg2, arg3})
+	stub := fake.ListVolumesWithFiltersStub
+	fakeReturns := fake.listVolumesWithFiltersReturns
+	fake.recordInvocation("ListVolumesWithFilters", []interface{}{arg1, arg2, arg3})
+	fake.listVolumesWithFiltersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) ListVolumesWithFiltersCallCount() int {
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
+	return len(fake.listVolumesWithFiltersArgsForCall)
+}
+
+func (fake *Context) ListVolumesWithFiltersCalls(stub func(int, string, provider.ListVolumesFilters) (*provider.VolumeList, error)) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = stub
+}
+
+func (fake *Context) ListVolumesWithFiltersArgsForCall(i int) (int, string, provider.ListVolumesFilters) {
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
+	argsForCall := fake.listVolumesWithFiltersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Context) ListVolumesWithFiltersReturns(result1 *provider.VolumeList, result2 error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = nil
+	fake.listVolumesWithFiltersReturns = struct {
+		result1 *provider.VolumeList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) ListVolumesWithFiltersReturnsOnCall(i int, result1 *provider.VolumeList, result2 error) {
+	fake.listVolumesWithFiltersMutex.Lock()
+	defer fake.listVolumesWithFiltersMutex.Unlock()
+	fake.ListVolumesWithFiltersStub = nil
+	if fake.listVolumesWithFiltersReturnsOnCall == nil {
+		fake.listVolumesWithFiltersReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeList
+			result2 error
+		})
+	}
+	fake.listVolumesWithFiltersReturnsOnCall[i] = struct {
+		result1 *provider.VolumeList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) ProviderName() provider.VolumeProvider {
 	fake.providerNameMutex.Lock()
 	ret, specificReturn := fake.providerNameReturnsOnCall[len(fake.providerNameArgsForCall)]
@@ -1682,6 +2835,131 @@ func (fake *Context) ProviderNameReturnsOnCall(i int, result1 provider.VolumePro
 	}{result1}
 }
 
+func (fake *Context) RestoreVolumeFromSnapshot(arg1 provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	ret, specificReturn := fake.restoreVolumeFromSnapshotReturnsOnCall[len(fake.restoreVolumeFromSnapshotArgsForCall)]
+	fake.restoreVolumeFromSnapshotArgsForCall = append(fake.restoreVolumeFromSnapshotArgsForCall, struct {
+		arg1 provider.SnapshotRestoreRequest
+	}{arg1})
+	stub := fake.RestoreVolumeFromSnapshotStub
+	fakeReturns := fake.restoreVolumeFromSnapshotReturns
+	fake.recordInvocation("RestoreVolumeFromSnapshot", []interface{}{arg1})
+	fake.restoreVolumeFromSnapshotMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) RestoreVolumeFromSnapshotCallCount() int {
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	return len(fake.restoreVolumeFromSnapshotArgsForCall)
+}
+
+func (fake *Context) RestoreVolumeFromSnapshotCalls(stub func(provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error)) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = stub
+}
+
+func (fake *Context) RestoreVolumeFromSnapshotArgsForCall(i int) provider.SnapshotRestoreRequest {
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	argsForCall := fake.restoreVolumeFromSnapshotArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) RestoreVolumeFromSnapshotReturns(result1 *provider.SnapshotRestoreResponse, result2 error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = nil
+	fake.restoreVolumeFromSnapshotReturns = struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) RestoreVolumeFromSnapshotReturnsOnCall(i int, result1 *provider.SnapshotRestoreResponse, result2 error) {
+	fake.restoreVolumeFromSnapshotMutex.Lock()
+	defer fake.restoreVolumeFromSnapshotMutex.Unlock()
+	fake.RestoreVolumeFromSnapshotStub = nil
+	if fake.restoreVolumeFromSnapshotReturnsOnCall == nil {
+		fake.restoreVolumeFromSnapshotReturnsOnCall = make(map[int]struct {
+			result1 *provider.SnapshotRestoreResponse
+			result2 error
+		})
+	}
+	fake.restoreVolumeFromSnapshotReturnsOnCall[i] = struct {
+		result1 *provider.SnapshotRestoreResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) SupportsOnlineExpansion(arg1 *provider.Volume) bool {
+	fake.supportsOnlineExpansionMutex.Lock()
+	ret, specificReturn := fake.supportsOnlineExpansionReturnsOnCall[len(fake.supportsOnlineExpansionArgsForCall)]
+	fake.supportsOnlineExpansionArgsForCall = append(fake.supportsOnlineExpansionArgsForCall, struct {
+		arg1 *provider.Volume
+	}{arg1})
+	stub := fake.SupportsOnlineExpansionStub
+	fakeReturns := fake.supportsOnlineExpansionReturns
+	fake.recordInvocation("SupportsOnlineExpansion", []interface{}{arg1})
+	fake.supportsOnlineExpansionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Context) SupportsOnlineExpansionCallCount() int {
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
+	return len(fake.supportsOnlineExpansionArgsForCall)
+}
+
+func (fake *Context) SupportsOnlineExpansionCalls(stub func(*provider.Volume) bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = stub
+}
+
+func (fake *Context) SupportsOnlineExpansionArgsForCall(i int) *provider.Volume {
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
+	argsForCall := fake.supportsOnlineExpansionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) SupportsOnlineExpansionReturns(result1 bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = nil
+	fake.supportsOnlineExpansionReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *Context) SupportsOnlineExpansionReturnsOnCall(i int, result1 bool) {
+	fake.supportsOnlineExpansionMutex.Lock()
+	defer fake.supportsOnlineExpansionMutex.Unlock()
+	fake.SupportsOnlineExpansionStub = nil
+	if fake.supportsOnlineExpansionReturnsOnCall == nil {
+		fake.supportsOnlineExpansionReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.supportsOnlineExpansionReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *Context) Type() provider.VolumeType {
 	fake.typeMutex.Lock()
 	ret, specificReturn := fake.typeReturnsOnCall[len(fake.typeArgsForCall)]
@@ -1796,6 +3074,262 @@ func (fake *Context) UpdateVolumeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Context) UpdateVolumeAttachment(arg1 provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	ret, specificReturn := fake.updateVolumeAttachmentReturnsOnCall[len(fake.updateVolumeAttachmentArgsForCall)]
+	fake.updateVolumeAttachmentArgsForCall = append(fake.updateVolumeAttachmentArgsForCall, struct {
+		arg1 provider.VolumeAttachmentRequest
+	}{arg1})
+	stub := fake.UpdateVolumeAttachmentStub
+	fakeReturns := fake.updateVolumeAttachmentReturns
+	fake.recordInvocation("UpdateVolumeAttachment", []interface{}{arg1})
+	fake.updateVolumeAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) UpdateVolumeAttachmentCallCount() int {
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	return len(fake.updateVolumeAttachmentArgsForCall)
+}
+
+func (fake *Context) UpdateVolumeAttachmentCalls(stub func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = stub
+}
+
+func (fake *Context) UpdateVolumeAttachmentArgsForCall(i int) provider.VolumeAttachmentRequest {
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	argsForCall := fake.updateVolumeAttachmentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) UpdateVolumeAttachmentReturns(result1 *provider.VolumeAttachmentResponse, result2 error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = nil
+	fake.updateVolumeAttachmentReturns = struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeAttachmentReturnsOnCall(i int, result1 *provider.VolumeAttachmentResponse, result2 error) {
+	fake.updateVolumeAttachmentMutex.Lock()
+	defer fake.updateVolumeAttachmentMutex.Unlock()
+	fake.UpdateVolumeAttachmentStub = nil
+	if fake.updateVolumeAttachmentReturnsOnCall == nil {
+		fake.updateVolumeAttachmentReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeAttachmentResponse
+			result2 error
+		})
+	}
+	fake.updateVolumeAttachmentReturnsOnCall[i] = struct {
+		result1 *provider.VolumeAttachmentResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeIOPS(arg1 provider.VolumeIOPSUpdateRequest) (*provider.Volume, error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	ret, specificReturn := fake.updateVolumeIOPSReturnsOnCall[len(fake.updateVolumeIOPSArgsForCall)]
+	fake.updateVolumeIOPSArgsForCall = append(fake.updateVolumeIOPSArgsForCall, struct {
+		arg1 provider.VolumeIOPSUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeIOPSStub
+	fakeReturns := fake.updateVolumeIOPSReturns
+	fake.recordInvocation("UpdateVolumeIOPS", []interface{}{arg1})
+	fake.updateVolumeIOPSMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) UpdateVolumeIOPSCallCount() int {
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	return len(fake.updateVolumeIOPSArgsForCall)
+}
+
+func (fake *Context) UpdateVolumeIOPSCalls(stub func(provider.VolumeIOPSUpdateRequest) (*provider.Volume, error)) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = stub
+}
+
+func (fake *Context) UpdateVolumeIOPSArgsForCall(i int) provider.VolumeIOPSUpdateRequest {
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	argsForCall := fake.updateVolumeIOPSArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) UpdateVolumeIOPSReturns(result1 *provider.Volume, result2 error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = nil
+	fake.updateVolumeIOPSReturns = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeIOPSReturnsOnCall(i int, result1 *provider.Volume, result2 error) {
+	fake.updateVolumeIOPSMutex.Lock()
+	defer fake.updateVolumeIOPSMutex.Unlock()
+	fake.UpdateVolumeIOPSStub = nil
+	if fake.updateVolumeIOPSReturnsOnCall == nil {
+		fake.updateVolumeIOPSReturnsOnCall = make(map[int]struct {
+			result1 *provider.Volume
+			result2 error
+		})
+	}
+	fake.updateVolumeIOPSReturnsOnCall[i] = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeProfile(arg1 provider.VolumeProfileUpdateRequest) (*provider.Volume, error) {
+	fake.updateVolumeProfileMutex.Lock()
+	ret, specificReturn := fake.updateVolumeProfileReturnsOnCall[len(fake.updateVolumeProfileArgsForCall)]
+	fake.updateVolumeProfileArgsForCall = append(fake.updateVolumeProfileArgsForCall, struct {
+		arg1 provider.VolumeProfileUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeProfileStub
+	fakeReturns := fake.updateVolumeProfileReturns
+	fake.recordInvocation("UpdateVolumeProfile", []interface{}{arg1})
+	fake.updateVolumeProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) UpdateVolumeProfileCallCount() int {
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	return len(fake.updateVolumeProfileArgsForCall)
+}
+
+func (fake *Context) UpdateVolumeProfileCalls(stub func(provider.VolumeProfileUpdateRequest) (*provider.Volume, error)) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = stub
+}
+
+func (fake *Context) UpdateVolumeProfileArgsForCall(i int) provider.VolumeProfileUpdateRequest {
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	argsForCall := fake.updateVolumeProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) UpdateVolumeProfileReturns(result1 *provider.Volume, result2 error) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = nil
+	fake.updateVolumeProfileReturns = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeProfileReturnsOnCall(i int, result1 *provider.Volume, result2 error) {
+	fake.updateVolumeProfileMutex.Lock()
+	defer fake.updateVolumeProfileMutex.Unlock()
+	fake.UpdateVolumeProfileStub = nil
+	if fake.updateVolumeProfileReturnsOnCall == nil {
+		fake.updateVolumeProfileReturnsOnCall = make(map[int]struct {
+			result1 *provider.Volume
+			result2 error
+		})
+	}
+	fake.updateVolumeProfileReturnsOnCall[i] = struct {
+		result1 *provider.Volume
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeTags(arg1 provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+	fake.updateVolumeTagsMutex.Lock()
+	ret, specificReturn := fake.updateVolumeTagsReturnsOnCall[len(fake.updateVolumeTagsArgsForCall)]
+	fake.updateVolumeTagsArgsForCall = append(fake.updateVolumeTagsArgsForCall, struct {
+		arg1 provider.VolumeTagsUpdateRequest
+	}{arg1})
+	stub := fake.UpdateVolumeTagsStub
+	fakeReturns := fake.updateVolumeTagsReturns
+	fake.recordInvocation("UpdateVolumeTags", []interface{}{arg1})
+	fake.updateVolumeTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Context) UpdateVolumeTagsCallCount() int {
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
+	return len(fake.updateVolumeTagsArgsForCall)
+}
+
+func (fake *Context) UpdateVolumeTagsCalls(stub func(provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error)) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = stub
+}
+
+func (fake *Context) UpdateVolumeTagsArgsForCall(i int) provider.VolumeTagsUpdateRequest {
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
+	argsForCall := fake.updateVolumeTagsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Context) UpdateVolumeTagsReturns(result1 *provider.VolumeTags, result2 error) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = nil
+	fake.updateVolumeTagsReturns = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Context) UpdateVolumeTagsReturnsOnCall(i int, result1 *provider.VolumeTags, result2 error) {
+	fake.updateVolumeTagsMutex.Lock()
+	defer fake.updateVolumeTagsMutex.Unlock()
+	fake.UpdateVolumeTagsStub = nil
+	if fake.updateVolumeTagsReturnsOnCall == nil {
+		fake.updateVolumeTagsReturnsOnCall = make(map[int]struct {
+			result1 *provider.VolumeTags
+			result2 error
+		})
+	}
+	fake.updateVolumeTagsReturnsOnCall[i] = struct {
+		result1 *provider.VolumeTags
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Context) WaitForAttachVolume(arg1 provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
 	fake.waitForAttachVolumeMutex.Lock()
 	ret, specificReturn := fake.waitForAttachVolumeReturnsOnCall[len(fake.waitForAttachVolumeArgsForCall)]
@@ -2053,6 +3587,10 @@ func (fake *Context) Invocations() map[string][][]interface{} {
 	defer fake.attachVolumeMutex.RUnlock()
 	fake.authorizeVolumeMutex.RLock()
 	defer fake.authorizeVolumeMutex.RUnlock()
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	fake.createShareTargetMutex.RLock()
+	defer fake.createShareTargetMutex.RUnlock()
 	fake.createSnapshotMutex.RLock()
 	defer fake.createSnapshotMutex.RUnlock()
 	fake.createVolumeMutex.RLock()
@@ -2061,6 +3599,12 @@ func (fake *Context) Invocations() map[string][][]interface{} {
 	defer fake.createVolumeAccessPointMutex.RUnlock()
 	fake.createVolumeFromSnapshotMutex.RLock()
 	defer fake.createVolumeFromSnapshotMutex.RUnlock()
+	fake.createVolumeFromVolumeMutex.RLock()
+	defer fake.createVolumeFromVolumeMutex.RUnlock()
+	fake.deleteShareMutex.RLock()
+	defer fake.deleteShareMutex.RUnlock()
+	fake.deleteShareTargetMutex.RLock()
+	defer fake.deleteShareTargetMutex.RUnlock()
 	fake.deleteSnapshotMutex.RLock()
 	defer fake.deleteSnapshotMutex.RUnlock()
 	fake.deleteVolumeMutex.RLock()
@@ -2069,12 +3613,20 @@ func (fake *Context) Invocations() map[string][][]interface{} {
 	defer fake.deleteVolumeAccessPointMutex.RUnlock()
 	fake.detachVolumeMutex.RLock()
 	defer fake.detachVolumeMutex.RUnlock()
+	fake.expandShareMutex.RLock()
+	defer fake.expandShareMutex.RUnlock()
 	fake.expandVolumeMutex.RLock()
 	defer fake.expandVolumeMutex.RUnlock()
+	fake.getCapabilitiesMutex.RLock()
+	defer fake.getCapabilitiesMutex.RUnlock()
+	fake.getResourceEventsMutex.RLock()
+	defer fake.getResourceEventsMutex.RUnlock()
 	fake.getSnapshotMutex.RLock()
 	defer fake.getSnapshotMutex.RUnlock()
 	fake.getSnapshotByNameMutex.RLock()
 	defer fake.getSnapshotByNameMutex.RUnlock()
+	fake.getSnapshotRestoreProgressMutex.RLock()
+	defer fake.getSnapshotRestoreProgressMutex.RUnlock()
 	fake.getVolumeMutex.RLock()
 	defer fake.getVolumeMutex.RUnlock()
 	fake.getVolumeAccessPointMutex.RLock()
@@ -2085,16 +3637,38 @@ func (fake *Context) Invocations() map[string][][]interface{} {
 	defer fake.getVolumeByNameMutex.RUnlock()
 	fake.getVolumeByRequestIDMutex.RLock()
 	defer fake.getVolumeByRequestIDMutex.RUnlock()
+	fake.getVolumePerformanceStatsMutex.RLock()
+	defer fake.getVolumePerformanceStatsMutex.RUnlock()
+	fake.getVolumeTagsMutex.RLock()
+	defer fake.getVolumeTagsMutex.RUnlock()
+	fake.listSharesMutex.RLock()
+	defer fake.listSharesMutex.RUnlock()
 	fake.listSnapshotsMutex.RLock()
 	defer fake.listSnapshotsMutex.RUnlock()
+	fake.listVolumeAttachmentsMutex.RLock()
+	defer fake.listVolumeAttachmentsMutex.RUnlock()
 	fake.listVolumesMutex.RLock()
 	defer fake.listVolumesMutex.RUnlock()
+	fake.listVolumesWithFiltersMutex.RLock()
+	defer fake.listVolumesWithFiltersMutex.RUnlock()
 	fake.providerNameMutex.RLock()
 	defer fake.providerNameMutex.RUnlock()
+	fake.restoreVolumeFromSnapshotMutex.RLock()
+	defer fake.restoreVolumeFromSnapshotMutex.RUnlock()
+	fake.supportsOnlineExpansionMutex.RLock()
+	defer fake.supportsOnlineExpansionMutex.RUnlock()
 	fake.typeMutex.RLock()
 	defer fake.typeMutex.RUnlock()
 	fake.updateVolumeMutex.RLock()
 	defer fake.updateVolumeMutex.RUnlock()
+	fake.updateVolumeAttachmentMutex.RLock()
+	defer fake.updateVolumeAttachmentMutex.RUnlock()
+	fake.updateVolumeIOPSMutex.RLock()
+	defer fake.updateVolumeIOPSMutex.RUnlock()
+	fake.updateVolumeProfileMutex.RLock()
+	defer fake.updateVolumeProfileMutex.RUnlock()
+	fake.updateVolumeTagsMutex.RLock()
+	defer fake.updateVolumeTagsMutex.RUnlock()
 	fake.waitForAttachVolumeMutex.RLock()
 	defer fake.waitForAttachVolumeMutex.RUnlock()
 	fake.waitForCreateVolumeAccessPointMutex.RLock()
@@ -0,0 +1,147 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"time"
+)
+
+// FileShareManager manages the VPC file shares, alongside the block oriented VolumeManager
+type FileShareManager interface {
+	//CreateShare creates the file share of the request
+	CreateShare(shareRequest FileShareRequest) (*FileShare, error)
+
+	//DeleteShare deletes the file share, its share targets must be deleted first
+	DeleteShare(shareID string) error
+
+	//CreateShareTarget creates the mount target of the share in a VPC, or in a subnet for security group access
+	CreateShareTarget(targetRequest ShareTargetRequest) (*ShareTarget, error)
+
+	//DeleteShareTarget deletes the mount target of the share
+	DeleteShareTarget(targetRequest ShareTargetRequest) error
+
+	//ListShares lists the file shares with the tags, start is the Next of the previous page
+	ListShares(limit int, start string, tags map[string]string) (*FileShareList, error)
+
+	//ExpandShare expands the share to the capacity of the request and returns the new capacity, in GiB
+	ExpandShare(expandRequest ExpandShareRequest) (int64, error)
+}
+
+const (
+	//ShareAccessControlSecurityGroup limits the access to the share targets with the security groups of their network interface
+	ShareAccessControlSecurityGroup = "security_group"
+	//ShareAccessControlVPC allows the access to the share targets from the whole VPC
+	ShareAccessControlVPC = "vpc"
+)
+
+// FileShareRequest describes the file share to create
+type FileShareRequest struct {
+	Name string `json:"name"`
+
+	//Capacity of the share in GiB
+	Capacity *int `json:"capacity,omitempty"`
+
+	//Profile of the share, e.g. dp2
+	Profile string `json:"profile,omitempty"`
+
+	//Iops of the share, the profile default if nil
+	Iops *int `json:"iops,omitempty"`
+
+	Zone            string `json:"zone"`
+	ResourceGroupID string `json:"resourceGroupID,omitempty"`
+
+	//EncryptionKeyCRN of the customer managed root key, provider managed encryption if empty
+	EncryptionKeyCRN string `json:"encryptionKeyCRN,omitempty"`
+
+	//AccessControlMode is ShareAccessControlSecurityGroup or ShareAccessControlVPC, the configured default if empty
+	AccessControlMode string `json:"accessControlMode,omitempty"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// FileShare is a VPC file share
+type FileShare struct {
+	ID   string `json:"id"`
+	CRN  string `json:"crn,omitempty"`
+	Name string `json:"name"`
+
+	//Capacity of the share in GiB
+	Capacity int    `json:"capacity"`
+	Profile  string `json:"profile,omitempty"`
+	Iops     int    `json:"iops,omitempty"`
+	Zone     string `json:"zone"`
+
+	//Status is the lifecycle state of the share, e.g. stable
+	Status            string            `json:"status"`
+	AccessControlMode string            `json:"accessControlMode,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	CreatedAt         *time.Time        `json:"createdAt,omitempty"`
+
+	//Targets are the mount targets of the share
+	Targets []*ShareTarget `json:"targets,omitempty"`
+}
+
+// FileShareList is a page of file shares
+type FileShareList struct {
+	Next   string       `json:"next,omitempty"`
+	Shares []*FileShare `json:"shares"`
+}
+
+// ShareTargetRequest used for both create and delete share target
+type ShareTargetRequest struct {
+	//ShareID of the share to create the target for
+	ShareID string `json:"shareID"`
+
+	//ShareTargetID to delete
+	ShareTargetID string `json:"shareTargetID,omitempty"`
+
+	//Name of the target is optional
+	Name string `json:"name,omitempty"`
+
+	//VPCID is required with the ShareAccessControlVPC access control mode
+	VPCID string `json:"vpcID,omitempty"`
+
+	//SubnetID is required with the ShareAccessControlSecurityGroup access control mode
+	SubnetID string `json:"subnetID,omitempty"`
+
+	//SecurityGroupIDs of the target network interface, requires SubnetID
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+
+	//EncryptionInTransit requires transport encryption between the node and the target
+	EncryptionInTransit bool `json:"encryptionInTransit,omitempty"`
+}
+
+// ShareTarget is the mount target of a file share
+type ShareTarget struct {
+	ID        string     `json:"id"`
+	ShareID   string     `json:"shareID"`
+	Name      string     `json:"name,omitempty"`
+	VPCID     string     `json:"vpcID,omitempty"`
+	Status    string     `json:"status"`
+	MountPath string     `json:"mountPath,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// ExpandShareRequest ...
+type ExpandShareRequest struct {
+	//ShareID of the share to expand
+	ShareID string `json:"shareID"`
+
+	//Capacity is the new capacity of the share, in GiB
+	Capacity int64 `json:"capacity"`
+}
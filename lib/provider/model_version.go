@@ -0,0 +1,336 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+const (
+	// ModelSchemaVersion is the version of the JSON schema of the request and response models of the library. It
+	// is incremented, with a ModelShim per changed kind of model, when a field is renamed, removed or changes meaning
+	ModelSchemaVersion = 1
+
+	// MinModelSchemaVersion is the oldest version the models are converted from and to, it is the version of the
+	// payloads without version marker
+	MinModelSchemaVersion = 1
+)
+
+// VersionedModel is the JSON envelope of a model exchanged with another version of the library, e.g. between a
+// controller and a gRPC sidecar during a rolling upgrade
+type VersionedModel struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Kind          string          `json:"kind"`
+	Model         json.RawMessage `json:"model"`
+}
+
+// ModelFields are the JSON fields of a model, by name, converted by the shims
+type ModelFields map[string]json.RawMessage
+
+// ModelShim converts the fields of a kind of model between the versions Version-1 and Version of the schema. The
+// shims of a kind are applied to the models of the kind nested in other models too (e.g. the volumes of a
+// VolumeList), the containing kinds need no shim of their own unless their fields change. The nested models are
+// found with the field names of the current version, a shim renaming a field holding models converts them itself
+type ModelShim struct {
+	Kind    string
+	Version int
+
+	// Upgrade converts the fields of the version Version-1 to the version Version
+	Upgrade func(fields ModelFields) error
+
+	// Downgrade converts the fields of the version Version to the version Version-1
+	Downgrade func(fields ModelFields) error
+}
+
+// ModelKinds are the request and response models of the interfaces, by kind
+var ModelKinds = modelKinds(
+	Volume{}, VolumeList{}, VolumeAuthorization{}, ExpandVolumeRequest{}, VolumeProfileUpdateRequest{},
+	VolumeIOPSUpdateRequest{}, VolumeTags{}, VolumeTagsUpdateRequest{}, VolumeCloneRequest{}, VolumeCloneResponse{},
+	ListVolumesFilters{}, Snapshot{}, SnapshotList{}, SnapshotParameters{}, SnapshotRestoreRequest{},
+	SnapshotRestoreResponse{}, SnapshotRestoreProgress{}, VolumeAttachmentRequest{}, VolumeAttachmentResponse{},
+	VolumeAccessPointRequest{}, VolumeAccessPointResponse{}, FileShareRequest{}, FileShare{}, FileShareList{},
+	ShareTargetRequest{}, ShareTarget{}, ExpandShareRequest{}, Capabilities{}, VolumePerformanceStats{},
+	ResourceEvent{}, ProviderStats{}, Fault{},
+)
+
+func modelKinds(models ...interface{}) map[string]reflect.Type {
+	kinds := make(map[string]reflect.Type, len(models))
+	for _, model := range models {
+		modelType := reflect.TypeOf(model)
+		kinds[modelType.Name()] = modelType
+	}
+	return kinds
+}
+
+// ModelKind returns the kind of the model or of the model pointed to, "" if it is not one of ModelKinds
+func ModelKind(model interface{}) string {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || ModelKinds[modelType.Name()] != modelType {
+		return ""
+	}
+	return modelType.Name()
+}
+
+// ModelCodec marshals the models in a VersionedModel envelope and unmarshals the envelopes of other versions,
+// converting the models with the shims between the versions
+type ModelCodec struct {
+	version int
+	shims   map[string]map[int]ModelShim
+}
+
+// modelShims are the shims of the model changes since MinModelSchemaVersion
+var modelShims []ModelShim
+
+// DefaultModelCodec is the codec of the ModelSchemaVersion of the library
+var DefaultModelCodec = NewModelCodec(ModelSchemaVersion, modelShims...)
+
+// NewModelCodec returns the codec of the version of the schema, with the shims converting the models from and to
+// the previous versions
+func NewModelCodec(version int, shims ...ModelShim) *ModelCodec {
+	codec := &ModelCodec{version: version, shims: map[string]map[int]ModelShim{}}
+	for _, shim := range shims {
+		if codec.shims[shim.Kind] == nil {
+			codec.shims[shim.Kind] = map[int]ModelShim{}
+		}
+		codec.shims[shim.Kind][shim.Version] = shim
+	}
+	return codec
+}
+
+// Version returns the version of the schema of the codec
+func (c *ModelCodec) Version() int {
+	return c.version
+}
+
+// Marshal marshals the model with the version of the codec
+func (c *ModelCodec) Marshal(model interface{}) ([]byte, error) {
+	return c.MarshalFor(model, c.version)
+}
+
+// MarshalFor marshals the model with the version of the schema of the peer, downgrading it for an older peer. A
+// model for a newer peer is marshaled with the version of the codec, the peer upgrades it
+func (c *ModelCodec) MarshalFor(model interface{}, peerVersion int) ([]byte, error) {
+	kind := ModelKind(model)
+	if kind == "" {
+		return nil, modelError(reasoncode.ErrorBadRequest, fmt.Sprintf("%T is not a versioned model", model))
+	}
+	if peerVersion < MinModelSchemaVersion {
+		return nil, modelError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Model schema version %d is older than the supported version %d", peerVersion, MinModelSchemaVersion))
+	}
+	if peerVersion > c.version {
+		peerVersion = c.version
+	}
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	for version := c.version; version > peerVersion; version-- {
+		if data, err = c.convert(kind, version, data, false); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(VersionedModel{SchemaVersion: peerVersion, Kind: kind, Model: data})
+}
+
+// Unmarshal unmarshals the versioned model (or an unversioned payload of MinModelSchemaVersion) into the model
+// pointed to, upgrading it from an older version. A model of a newer version is unmarshaled as is, ignoring the
+// unknown fields, its peer is expected to marshal it for the version of the codec (see MarshalFor)
+func (c *ModelCodec) Unmarshal(data []byte, model interface{}) error {
+	kind := ModelKind(model)
+	if kind == "" || reflect.TypeOf(model).Kind() != reflect.Ptr {
+		return modelError(reasoncode.ErrorBadRequest, fmt.Sprintf("%T is not a pointer to a versioned model", model))
+	}
+	envelope, err := decodeVersionedModel(data)
+	if err != nil {
+		return err
+	}
+	if envelope.Kind != "" && envelope.Kind != kind {
+		return modelError(reasoncode.ErrorBadRequest, fmt.Sprintf("Model of kind %s cannot be unmarshaled into %s", envelope.Kind, kind))
+	}
+	if envelope.SchemaVersion < MinModelSchemaVersion {
+		return modelError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Model schema version %d is older than the supported version %d", envelope.SchemaVersion, MinModelSchemaVersion))
+	}
+	payload := []byte(envelope.Model)
+	for version := envelope.SchemaVersion + 1; version <= c.version; version++ {
+		if payload, err = c.convert(kind, version, payload, true); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(payload, model)
+}
+
+// decodeVersionedModel returns the envelope of the data, an envelope of MinModelSchemaVersion for an unversioned
+// payload
+func decodeVersionedModel(data []byte) (VersionedModel, error) {
+	var fields ModelFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return VersionedModel{}, err
+	}
+	_, versioned := fields["schemaVersion"]
+	if _, found := fields["model"]; !versioned || !found {
+		return VersionedModel{SchemaVersion: MinModelSchemaVersion, Model: data}, nil
+	}
+	var envelope VersionedModel
+	err := json.Unmarshal(data, &envelope)
+	return envelope, err
+}
+
+// convert upgrades the model from the version-1 to the version, or downgrades it from the version to the version-1
+func (c *ModelCodec) convert(kind string, version int, data []byte, upgrade bool) ([]byte, error) {
+	return c.convertValue(ModelKinds[kind], version, data, upgrade)
+}
+
+// convertValue converts the models of the JSON value of the type, the type being a model or a pointer, slice or
+// map of models
+func (c *ModelCodec) convertValue(valueType reflect.Type, version int, data []byte, upgrade bool) ([]byte, error) {
+	if !c.converts(valueType, version, map[reflect.Type]bool{}) || string(data) == "null" {
+		return data, nil
+	}
+	switch valueType.Kind() {
+	case reflect.Ptr:
+		return c.convertValue(valueType.Elem(), version, data, upgrade)
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return nil, err
+		}
+		for i, elem := range elems {
+			converted, err := c.convertValue(valueType.Elem(), version, elem, upgrade)
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = converted
+		}
+		return json.Marshal(elems)
+	case reflect.Map:
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return nil, err
+		}
+		for key, elem := range elems {
+			converted, err := c.convertValue(valueType.Elem(), version, elem, upgrade)
+			if err != nil {
+				return nil, err
+			}
+			elems[key] = converted
+		}
+		return json.Marshal(elems)
+	}
+
+	var fields ModelFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var shim ModelShim
+	if ModelKinds[valueType.Name()] == valueType {
+		shim = c.shims[valueType.Name()][version]
+	}
+	// the nested models are converted with the field names of the version
+	if upgrade && shim.Upgrade != nil {
+		if err := shim.Upgrade(fields); err != nil {
+			return nil, err
+		}
+	}
+	for name, fieldType := range modelFieldTypes(valueType) {
+		if value, found := fields[name]; found {
+			converted, err := c.convertValue(fieldType, version, value, upgrade)
+			if err != nil {
+				return nil, err
+			}
+			fields[name] = converted
+		}
+	}
+	if !upgrade && shim.Downgrade != nil {
+		if err := shim.Downgrade(fields); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// converts returns true if the values of the type hold models with shims of the version
+func (c *ModelCodec) converts(valueType reflect.Type, version int, visited map[reflect.Type]bool) bool {
+	switch valueType.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return c.converts(valueType.Elem(), version, visited)
+	case reflect.Struct:
+	default:
+		return false
+	}
+	if visited[valueType] {
+		return false
+	}
+	visited[valueType] = true
+	if _, found := c.shims[valueType.Name()][version]; found && ModelKinds[valueType.Name()] == valueType {
+		return true
+	}
+	for _, fieldType := range modelFieldTypes(valueType) {
+		if c.converts(fieldType, version, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// modelFieldTypes returns the types of the JSON fields of the struct type, by name, the fields of the embedded
+// structs included
+func modelFieldTypes(structType reflect.Type) map[string]reflect.Type {
+	fieldTypes := map[string]reflect.Type{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range modelFieldTypes(field.Type) {
+				if _, found := fieldTypes[embeddedName]; !found {
+					fieldTypes[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldTypes[name] = field.Type
+	}
+	return fieldTypes
+}
+
+func modelError(code reasoncode.ReasonCode, message string) error {
+	return Error{Fault: Fault{ReasonCode: code, Message: message}}
+}
+
+// MarshalVersioned marshals the model with the ModelSchemaVersion of the library
+func MarshalVersioned(model interface{}) ([]byte, error) {
+	return DefaultModelCodec.Marshal(model)
+}
+
+// UnmarshalVersioned unmarshals the versioned or unversioned model into the model pointed to
+func UnmarshalVersioned(data []byte, model interface{}) error {
+	return DefaultModelCodec.Unmarshal(data, model)
+}
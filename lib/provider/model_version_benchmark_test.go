@@ -0,0 +1,73 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchmarkVolumeList returns a list of volumes, as returned by the list calls
+func benchmarkVolumeList(size int) *VolumeList {
+	list := &VolumeList{Volumes: make([]*Volume, 0, size)}
+	for i := 0; i < size; i++ {
+		capacity := 10
+		volume := &Volume{VolumeID: fmt.Sprintf("r006-%04d", i), Capacity: &capacity, Az: "us-south-1"}
+		volume.Tags = []string{"env:prod"}
+		list.Volumes = append(list.Volumes, volume)
+	}
+	return list
+}
+
+func BenchmarkMarshalVersioned(b *testing.B) {
+	capacity := 10
+	volume := &Volume{VolumeID: "r006-1a2b3c4d", Capacity: &capacity, Az: "us-south-1"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = MarshalVersioned(volume)
+	}
+}
+
+func BenchmarkUnmarshalVersioned(b *testing.B) {
+	capacity := 10
+	data, _ := MarshalVersioned(&Volume{VolumeID: "r006-1a2b3c4d", Capacity: &capacity, Az: "us-south-1"})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var volume Volume
+		_ = UnmarshalVersioned(data, &volume)
+	}
+}
+
+func BenchmarkModelCodecDowngradeList(b *testing.B) {
+	codec := NewModelCodec(2, ModelShim{Kind: "Volume", Version: 2, Upgrade: renameField("zone", "az"), Downgrade: renameField("az", "zone")})
+	list := benchmarkVolumeList(100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = codec.MarshalFor(list, 1)
+	}
+}
+
+func BenchmarkModelCodecUpgradeList(b *testing.B) {
+	codec := NewModelCodec(2, ModelShim{Kind: "Volume", Version: 2, Upgrade: renameField("zone", "az"), Downgrade: renameField("az", "zone")})
+	data, _ := codec.MarshalFor(benchmarkVolumeList(100), 1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var list VolumeList
+		_ = codec.Unmarshal(data, &list)
+	}
+}
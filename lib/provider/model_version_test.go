@@ -0,0 +1,145 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelKinds(t *testing.T) {
+	for kind, modelType := range ModelKinds {
+		assert.Equal(t, reflect.Struct, modelType.Kind(), kind)
+		model := reflect.New(modelType)
+		assert.Equal(t, kind, ModelKind(model.Interface()))
+
+		data, err := MarshalVersioned(model.Interface())
+		assert.Nil(t, err, kind)
+		decoded := reflect.New(modelType)
+		assert.Nil(t, UnmarshalVersioned(data, decoded.Interface()), kind)
+	}
+	assert.Equal(t, "", ModelKind("volume"))
+	assert.Equal(t, "", ModelKind(nil))
+}
+
+func TestVersionedModelRoundTrip(t *testing.T) {
+	capacity := 10
+	volume := &Volume{VolumeID: "vol-1", Capacity: &capacity, Az: "us-south-1"}
+	data, err := MarshalVersioned(volume)
+	assert.Nil(t, err)
+
+	var envelope VersionedModel
+	assert.Nil(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, ModelSchemaVersion, envelope.SchemaVersion)
+	assert.Equal(t, "Volume", envelope.Kind)
+
+	var decoded Volume
+	assert.Nil(t, UnmarshalVersioned(data, &decoded))
+	assert.Equal(t, "vol-1", decoded.VolumeID)
+	assert.Equal(t, 10, *decoded.Capacity)
+
+	legacy, _ := json.Marshal(volume)
+	decoded = Volume{}
+	assert.Nil(t, UnmarshalVersioned(legacy, &decoded))
+	assert.Equal(t, "us-south-1", decoded.Az)
+
+	err = UnmarshalVersioned(data, &Snapshot{})
+	assert.True(t, errors.Is(err, Error{Fault: Fault{ReasonCode: reasoncode.ErrorBadRequest}}))
+	err = UnmarshalVersioned(data, decoded)
+	assert.NotNil(t, err)
+	_, err = MarshalVersioned(map[string]string{})
+	assert.NotNil(t, err)
+	_, err = DefaultModelCodec.MarshalFor(volume, 0)
+	assert.True(t, errors.Is(err, Error{Fault: Fault{ReasonCode: reasoncode.ErrorUnsupportedFeature}}))
+}
+
+// renameField returns a shim conversion renaming the field
+func renameField(from, to string) func(ModelFields) error {
+	return func(fields ModelFields) error {
+		if value, found := fields[from]; found {
+			fields[to] = value
+			delete(fields, from)
+		}
+		return nil
+	}
+}
+
+func TestModelCodecShims(t *testing.T) {
+	// the field "az" of the version 2 was named "zone" in the version 1
+	older := NewModelCodec(1)
+	newer := NewModelCodec(2, ModelShim{Kind: "Volume", Version: 2, Upgrade: renameField("zone", "az"), Downgrade: renameField("az", "zone")})
+	assert.Equal(t, 2, newer.Version())
+
+	volume := &Volume{VolumeID: "vol-1", Az: "us-south-1"}
+	data, err := newer.MarshalFor(volume, older.Version())
+	assert.Nil(t, err)
+	var envelope VersionedModel
+	assert.Nil(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, 1, envelope.SchemaVersion)
+	assert.Contains(t, string(envelope.Model), `"zone":"us-south-1"`)
+	assert.NotContains(t, string(envelope.Model), `"az"`)
+
+	var decoded Volume
+	assert.Nil(t, newer.Unmarshal(data, &decoded))
+	assert.Equal(t, "us-south-1", decoded.Az)
+
+	data, err = older.MarshalFor(volume, newer.Version())
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, 1, envelope.SchemaVersion)
+
+	data, err = newer.Marshal(volume)
+	assert.Nil(t, err)
+	decoded = Volume{}
+	assert.Nil(t, older.Unmarshal(data, &decoded))
+	assert.Equal(t, "vol-1", decoded.VolumeID)
+}
+
+func TestModelCodecNestedShims(t *testing.T) {
+	older := NewModelCodec(1)
+	newer := NewModelCodec(2, ModelShim{Kind: "Volume", Version: 2, Upgrade: renameField("zone", "az"), Downgrade: renameField("az", "zone")})
+
+	// the shims of the volumes apply to the volumes of the lists and responses
+	list := &VolumeList{Volumes: []*Volume{{VolumeID: "vol-1", Az: "us-south-1"}, nil}}
+	data, err := newer.MarshalFor(list, older.Version())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"zone":"us-south-1"`)
+	assert.NotContains(t, string(data), `"az"`)
+	var decodedList VolumeList
+	assert.Nil(t, newer.Unmarshal(data, &decodedList))
+	assert.Equal(t, "us-south-1", decodedList.Volumes[0].Az)
+	assert.Nil(t, decodedList.Volumes[1])
+
+	response := &VolumeCloneResponse{Volume: &Volume{VolumeID: "vol-2", Az: "us-south-2"}}
+	data, err = newer.MarshalFor(response, older.Version())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"zone":"us-south-2"`)
+	var decodedResponse VolumeCloneResponse
+	assert.Nil(t, newer.Unmarshal(data, &decodedResponse))
+	assert.Equal(t, "us-south-2", decodedResponse.Volume.Az)
+
+	// the kinds holding no volumes are not converted
+	data, err = newer.MarshalFor(&Snapshot{SnapshotID: "snap-1"}, older.Version())
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"snapshotID":"snap-1"`)
+}
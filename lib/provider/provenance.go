@@ -0,0 +1,137 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import "strings"
+
+const (
+	// ProvenanceTagPrefix is the prefix of the canonical provenance tag keys, made of the characters accepted by
+	// the tagging service (no '/')
+	ProvenanceTagPrefix = "ibm-cloud.kubernetes.io."
+
+	// ProvenanceTagClusterID is the tag key of the ID of the cluster which created the resource
+	ProvenanceTagClusterID = ProvenanceTagPrefix + "cluster-id"
+
+	// ProvenanceTagPVCName is the tag key of the name of the PVC the resource was created for
+	ProvenanceTagPVCName = ProvenanceTagPrefix + "pvc-name"
+
+	// ProvenanceTagNamespace is the tag key of the namespace of the PVC the resource was created for
+	ProvenanceTagNamespace = ProvenanceTagPrefix + "namespace"
+
+	provenanceTagSeparator = ":"
+)
+
+// Provenance identifies the cluster and PVC a volume or snapshot was created for, all IBM storage drivers
+// stamp it with the same tag keys so tooling can rely on it
+type Provenance struct {
+	ClusterID string `json:"clusterID,omitempty"`
+	PVCName   string `json:"pvcName,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// IsZero returns true if no provenance field is set
+func (p Provenance) IsZero() bool {
+	return p == Provenance{}
+}
+
+// IsProvenanceTagKey returns true for the canonical provenance tag keys
+func IsProvenanceTagKey(key string) bool {
+	switch key {
+	case ProvenanceTagClusterID, ProvenanceTagPVCName, ProvenanceTagNamespace:
+		return true
+	}
+	return false
+}
+
+// tagValues returns the provenance tag values by key, empty fields are omitted
+func (p Provenance) tagValues() map[string]string {
+	values := map[string]string{}
+	for key, value := range map[string]string{
+		ProvenanceTagClusterID: p.ClusterID,
+		ProvenanceTagPVCName:   p.PVCName,
+		ProvenanceTagNamespace: p.Namespace,
+	} {
+		if value != "" {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// set sets the provenance field of the tag key
+func (p *Provenance) set(key, value string) {
+	switch key {
+	case ProvenanceTagClusterID:
+		p.ClusterID = value
+	case ProvenanceTagPVCName:
+		p.PVCName = value
+	case ProvenanceTagNamespace:
+		p.Namespace = value
+	}
+}
+
+// SetProvenance stamps the provenance tags (key:value) on the volume, replacing existing provenance tags
+// of the set fields
+func (v *Volume) SetProvenance(provenance Provenance) {
+	values := provenance.tagValues()
+	tags := make([]string, 0, len(v.Tags)+len(values))
+	for _, tag := range v.Tags {
+		key := strings.SplitN(tag, provenanceTagSeparator, 2)[0]
+		if _, replaced := values[key]; !replaced {
+			tags = append(tags, tag)
+		}
+	}
+	for _, key := range []string{ProvenanceTagClusterID, ProvenanceTagPVCName, ProvenanceTagNamespace} {
+		if value, ok := values[key]; ok {
+			tags = append(tags, key+provenanceTagSeparator+value)
+		}
+	}
+	v.Tags = tags
+}
+
+// Provenance returns the provenance stamped on the volume tags
+func (v *Volume) Provenance() Provenance {
+	var provenance Provenance
+	for _, tag := range v.Tags {
+		parts := strings.SplitN(tag, provenanceTagSeparator, 2)
+		if len(parts) == 2 {
+			provenance.set(parts[0], parts[1])
+		}
+	}
+	return provenance
+}
+
+// SetProvenance stamps the provenance tags on the snapshot tags, replacing existing provenance tags
+// of the set fields
+func (t *SnapshotTags) SetProvenance(provenance Provenance) {
+	if *t == nil {
+		*t = SnapshotTags{}
+	}
+	for key, value := range provenance.tagValues() {
+		(*t)[key] = value
+	}
+}
+
+// Provenance returns the provenance stamped on the snapshot tags
+func (t SnapshotTags) Provenance() Provenance {
+	var provenance Provenance
+	for key, value := range t {
+		provenance.set(key, value)
+	}
+	return provenance
+}
@@ -0,0 +1,56 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumeProvenance(t *testing.T) {
+	volume := &Volume{}
+	volume.Tags = []string{"env:prod", ProvenanceTagPVCName + ":old-pvc"}
+	assert.True(t, volume.Provenance().PVCName == "old-pvc")
+
+	volume.SetProvenance(Provenance{ClusterID: "c1", PVCName: "pvc-1", Namespace: "default"})
+	assert.Equal(t, []string{
+		"env:prod",
+		"ibm-cloud.kubernetes.io.cluster-id:c1",
+		"ibm-cloud.kubernetes.io.pvc-name:pvc-1",
+		"ibm-cloud.kubernetes.io.namespace:default",
+	}, volume.Tags)
+	assert.Equal(t, Provenance{ClusterID: "c1", PVCName: "pvc-1", Namespace: "default"}, volume.Provenance())
+
+	// unset fields keep the existing tags
+	volume.SetProvenance(Provenance{PVCName: "pvc-2"})
+	assert.Equal(t, Provenance{ClusterID: "c1", PVCName: "pvc-2", Namespace: "default"}, volume.Provenance())
+	assert.Equal(t, 4, len(volume.Tags))
+}
+
+func TestSnapshotProvenance(t *testing.T) {
+	var tags SnapshotTags
+	assert.True(t, tags.Provenance().IsZero())
+
+	tags.SetProvenance(Provenance{ClusterID: "c1", Namespace: "default"})
+	assert.Equal(t, SnapshotTags{ProvenanceTagClusterID: "c1", ProvenanceTagNamespace: "default"}, tags)
+	assert.Equal(t, Provenance{ClusterID: "c1", Namespace: "default"}, tags.Provenance())
+
+	assert.True(t, IsProvenanceTagKey(ProvenanceTagPVCName))
+	assert.False(t, IsProvenanceTagKey("pvc-name"))
+}
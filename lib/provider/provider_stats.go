@@ -0,0 +1,55 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProviderStats is a snapshot of the provider session counters, intended for debugging endpoints
+type ProviderStats struct {
+	// Operations counts by operation type
+	Operations map[string]OperationCounts `json:"operations"`
+
+	// CacheHitRates by cache name, between 0 and 1
+	CacheHitRates map[string]float64 `json:"cacheHitRates"`
+
+	// RateLimiterWaits is the time spent waiting on the rate limiter by operation class
+	RateLimiterWaits map[string]time.Duration `json:"rateLimiterWaits"`
+
+	// TokenRefreshes is the number of token exchanges
+	TokenRefreshes int64 `json:"tokenRefreshes"`
+
+	// OpenCircuitBreakers are the names of the open circuit breakers
+	OpenCircuitBreakers []string `json:"openCircuitBreakers"`
+
+	// CollectedAt is the time of the snapshot
+	CollectedAt time.Time `json:"collectedAt"`
+}
+
+// OperationCounts are the results of an operation type
+type OperationCounts struct {
+	Success int64 `json:"success"`
+	Failure int64 `json:"failure"`
+}
+
+// JSON returns the stats in JSON format
+func (s ProviderStats) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
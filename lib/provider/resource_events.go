@@ -0,0 +1,64 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// ResourceEventsManager ...
+type ResourceEventsManager interface {
+	// GetResourceEvents returns the backend activity and health events of the volume or share since the given time,
+	// oldest first, for providers where the backend exposes activity tracker data
+	GetResourceEvents(ctx context.Context, resourceID string, since time.Time) ([]*ResourceEvent, error)
+}
+
+// ResourceEventSeverity ...
+type ResourceEventSeverity string
+
+const (
+	// EventSeverityNormal is a routine activity of the resource
+	EventSeverityNormal = ResourceEventSeverity("normal")
+	// EventSeverityWarning is a failed activity or a degraded health of the resource
+	EventSeverityWarning = ResourceEventSeverity("warning")
+	// EventSeverityCritical is an outage of the resource
+	EventSeverityCritical = ResourceEventSeverity("critical")
+)
+
+// ResourceEvent is an activity or health event reported by the backend for a resource
+type ResourceEvent struct {
+	ResourceID string `json:"resourceID"`
+
+	// Action of the event, e.g. is.volume.volume.update
+	Action string `json:"action"`
+
+	// Outcome of the action, e.g. success or failure
+	Outcome string `json:"outcome,omitempty"`
+
+	// Reason is the backend reason of the outcome
+	Reason string `json:"reason,omitempty"`
+
+	Severity ResourceEventSeverity `json:"severity"`
+
+	// Initiator of the action, e.g. the service ID of the driver
+	Initiator string `json:"initiator,omitempty"`
+
+	// Time stamp of the event in the backend
+	Time time.Time `json:"time"`
+}
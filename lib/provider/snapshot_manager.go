@@ -22,10 +22,10 @@ type SnapshotManager interface {
 	// Create the snapshot on the volume
 	CreateSnapshot(sourceVolumeID string, snapshotParameters SnapshotParameters) (*Snapshot, error)
 
-	// Delete the snapshot
+	// Delete the snapshot, deleting a deleted snapshot succeeds or returns an ErrorResourceNotFound error
 	DeleteSnapshot(*Snapshot) error
 
-	// Get the snapshot
+	// Get the snapshot, a missing snapshot returns an ErrorResourceNotFound error
 	GetSnapshot(snapshotID string) (*Snapshot, error)
 
 	// Get the snapshot By name
@@ -33,4 +33,11 @@ type SnapshotManager interface {
 
 	// Snapshot list by using tags
 	ListSnapshots(limit int, start string, tags map[string]string) (*SnapshotList, error)
+
+	// RestoreVolumeFromSnapshot creates a volume from the snapshot, possibly in another zone than its source volume.
+	// It is CreateVolumeFromSnapshot of the VolumeManager with the zone, capacity and IOPS of the new volume
+	RestoreVolumeFromSnapshot(restoreRequest SnapshotRestoreRequest) (*SnapshotRestoreResponse, error)
+
+	// GetSnapshotRestoreProgress returns the progress of the restore of the volume created from a snapshot
+	GetSnapshotRestoreProgress(volumeID string) (*SnapshotRestoreProgress, error)
 }
@@ -31,6 +31,26 @@ const (
 	NOTSUPPORTED = "Not supported"
 )
 
+// AttachmentTargetType is the type of server a volume is attached to
+type AttachmentTargetType string
+
+const (
+	// AttachmentTargetInstance is a virtual server instance (VSI)
+	AttachmentTargetInstance = AttachmentTargetType("instance")
+	// AttachmentTargetBareMetalServer is a bare metal server
+	AttachmentTargetBareMetalServer = AttachmentTargetType("bare_metal_server")
+)
+
+// AttachmentMode is the sharing mode of a volume attachment
+type AttachmentMode string
+
+const (
+	// AttachmentModeExclusive attaches the volume to a single instance, the default
+	AttachmentModeExclusive = AttachmentMode("exclusive")
+	// AttachmentModeShared attaches a multi-attach (shareable) volume to the instance alongside the other shared attachments
+	AttachmentModeShared = AttachmentMode("shared")
+)
+
 // VolumeAttachManager ...
 type VolumeAttachManager interface {
 	//Attach method attaches a volume/ fileset to a server
@@ -50,6 +70,12 @@ type VolumeAttachManager interface {
 
 	//GetAttachAttachment retirves the current status of given volume attach request
 	GetVolumeAttachment(attachRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+
+	//UpdateVolumeAttachment updates the mutable properties (e.g. bandwidth) of an existing attachment
+	UpdateVolumeAttachment(updateRequest VolumeAttachmentRequest) (*VolumeAttachmentResponse, error)
+
+	//ListVolumeAttachments returns the attachments of the volume, one per instance it is attached to
+	ListVolumeAttachments(volumeID string) ([]*VolumeAttachmentResponse, error)
 }
 
 // VolumeAttachmentResponse used for both attach and detach operation
@@ -58,12 +84,19 @@ type VolumeAttachmentResponse struct {
 	//Status status of the volume attachment success, failed, attached, attaching, detaching
 	Status    string     `json:"status,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// TraceID is the backend operation/trace ID of the attach request (e.g. the X-Request-ID), referenced by
+	// the waiter logs and the backend audit trail
+	TraceID string `json:"traceID,omitempty"`
 }
 
 // VolumeAttachmentRequest  used for both attach and detach operation
 type VolumeAttachmentRequest struct {
 	VolumeID   string `json:"volumeID"`
 	InstanceID string `json:"instanceID"`
+	// TargetType of InstanceID, a virtual server instance if empty
+	TargetType AttachmentTargetType `json:"targetType,omitempty"`
+	// Mode of the attachment, exclusive if empty. Shared attachments require a multi-attach volume
+	Mode AttachmentMode `json:"mode,omitempty"`
 	// Only for SL provider
 	SoftlayerOptions map[string]string `json:"softlayerOptions,omitempty"`
 	// Only for VPC provider
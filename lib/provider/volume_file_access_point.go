@@ -59,6 +59,30 @@ type VolumeAccessPointRequest struct {
 
 	//VPC to create AccessPoint for
 	VPCID string `json:"vpc_id,omitempty"`
+
+	//EncryptionInTransit requires transport encryption between the node and the AccessPoint
+	EncryptionInTransit bool `json:"encryptionInTransit,omitempty"`
+
+	//SecurityGroupIDs to attach to the AccessPoint network interface, requires SubnetID
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty"`
+
+	//ReservedIP to bind to the AccessPoint network interface, requires SubnetID
+	ReservedIP *ReservedIP `json:"primary_ip,omitempty"`
+}
+
+// ReservedIP identifies an existing reserved IP by ID, or describes a new one by address
+type ReservedIP struct {
+	//ID of an existing reserved IP
+	ID string `json:"id,omitempty"`
+
+	//Address of a new reserved IP, must belong to the subnet
+	Address string `json:"address,omitempty"`
+
+	//Name of a new reserved IP
+	Name string `json:"name,omitempty"`
+
+	//AutoDelete the new reserved IP when the AccessPoint is deleted
+	AutoDelete *bool `json:"auto_delete,omitempty"`
 }
 
 //VolumeAccessPointResponse used for both delete and create access point
@@ -0,0 +1,97 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import "strings"
+
+// Keys of the ListVolumesFilters in the tags of ListVolumes, see ListVolumesFilters.Tags
+const (
+	ListFilterZoneName        = "zone.name"
+	ListFilterNamePrefix      = "name_prefix"
+	ListFilterTag             = "tag"
+	ListFilterResourceGroupID = "resource_group.id"
+)
+
+// ListVolumesFilters are the server side filters of ListVolumesWithFilters, a volume is listed if it matches all
+// the filters set
+type ListVolumesFilters struct {
+	// ZoneName is the zone of the volumes, e.g. us-south-1
+	ZoneName string `json:"zoneName,omitempty"`
+	// NamePrefix is the beginning of the names of the volumes
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Tag is a user tag of the volumes, e.g. env:prod
+	Tag string `json:"tag,omitempty"`
+	// ResourceGroupID is the resource group of the volumes
+	ResourceGroupID string `json:"resourceGroupID,omitempty"`
+}
+
+// IsEmpty returns true if no filter is set
+func (f ListVolumesFilters) IsEmpty() bool {
+	return f == ListVolumesFilters{}
+}
+
+// Matches tells if the volume matches the filters, for the providers filtering client side
+func (f ListVolumesFilters) Matches(volume *Volume) bool {
+	if volume == nil {
+		return false
+	}
+	if f.ZoneName != "" && volume.Az != f.ZoneName {
+		return false
+	}
+	if f.NamePrefix != "" && (volume.Name == nil || !strings.HasPrefix(*volume.Name, f.NamePrefix)) {
+		return false
+	}
+	if f.ResourceGroupID != "" && (volume.ResourceGroup == nil || volume.ResourceGroup.ID != f.ResourceGroupID) {
+		return false
+	}
+	if f.Tag != "" {
+		for _, tag := range volume.Tags {
+			if tag == f.Tag {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Tags returns the filters set as the tags of ListVolumes, keyed by the ListFilter* keys
+func (f ListVolumesFilters) Tags() map[string]string {
+	tags := map[string]string{}
+	for key, value := range map[string]string{
+		ListFilterZoneName:        f.ZoneName,
+		ListFilterNamePrefix:      f.NamePrefix,
+		ListFilterTag:             f.Tag,
+		ListFilterResourceGroupID: f.ResourceGroupID,
+	} {
+		if value != "" {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
+// ListVolumesFiltersFromTags returns the filters of the tags of ListVolumes, the other tags are ignored
+func ListVolumesFiltersFromTags(tags map[string]string) ListVolumesFilters {
+	return ListVolumesFilters{
+		ZoneName:        tags[ListFilterZoneName],
+		NamePrefix:      tags[ListFilterNamePrefix],
+		Tag:             tags[ListFilterTag],
+		ResourceGroupID: tags[ListFilterResourceGroupID],
+	}
+}
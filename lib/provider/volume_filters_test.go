@@ -0,0 +1,62 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListVolumesFiltersMatches(t *testing.T) {
+	name := "pvc-data-1"
+	volume := &Volume{Az: "us-south-1", Name: &name}
+	volume.Tags = []string{"env:prod"}
+	volume.ResourceGroup = &ResourceGroup{ID: "rg-1"}
+
+	testcases := []struct {
+		testcasename string
+		filters      ListVolumesFilters
+		expected     bool
+	}{
+		{testcasename: "No filter", filters: ListVolumesFilters{}, expected: true},
+		{testcasename: "All filters", filters: ListVolumesFilters{ZoneName: "us-south-1", NamePrefix: "pvc-", Tag: "env:prod", ResourceGroupID: "rg-1"}, expected: true},
+		{testcasename: "Other zone", filters: ListVolumesFilters{ZoneName: "us-south-2"}},
+		{testcasename: "Other name", filters: ListVolumesFilters{NamePrefix: "data"}},
+		{testcasename: "Other tag", filters: ListVolumesFilters{Tag: "env:dev"}},
+		{testcasename: "Other resource group", filters: ListVolumesFilters{ResourceGroupID: "rg-2"}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			assert.Equal(t, testcase.expected, testcase.filters.Matches(volume))
+		})
+	}
+	assert.False(t, ListVolumesFilters{NamePrefix: "pvc-"}.Matches(&Volume{}))
+	assert.False(t, ListVolumesFilters{}.Matches(nil))
+}
+
+func TestListVolumesFiltersTags(t *testing.T) {
+	filters := ListVolumesFilters{ZoneName: "us-south-1", Tag: "env:prod"}
+	assert.False(t, filters.IsEmpty())
+	assert.True(t, ListVolumesFilters{}.IsEmpty())
+	tags := filters.Tags()
+	assert.Equal(t, map[string]string{ListFilterZoneName: "us-south-1", ListFilterTag: "env:prod"}, tags)
+	tags["other"] = "ignored"
+	assert.Equal(t, filters, ListVolumesFiltersFromTags(tags))
+	assert.Empty(t, ListVolumesFilters{}.Tags())
+}
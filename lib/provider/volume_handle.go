@@ -0,0 +1,36 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import "strings"
+
+// VolumeHandleSeparator separates the ResourceID fields in a volume handle
+const VolumeHandleSeparator = "/"
+
+// ResourceID identifies a volume across providers, its VolumeHandle is the provider/type/id string
+// stored in the PV
+type ResourceID struct {
+	Provider   VolumeProvider `json:"provider"`
+	VolumeType VolumeType     `json:"volumeType"`
+	ID         string         `json:"id"`
+}
+
+// VolumeHandle returns the volume handle of the resource
+func (r ResourceID) VolumeHandle() string {
+	return strings.Join([]string{string(r.Provider), string(r.VolumeType), r.ID}, VolumeHandleSeparator)
+}
@@ -17,6 +17,8 @@
 // Package provider ...
 package provider
 
+import "context"
+
 // VolumeManager ...
 type VolumeManager interface {
 	// Provider name
@@ -32,12 +34,16 @@ type VolumeManager interface {
 	// Create the volume from snapshot with snapshot tags
 	CreateVolumeFromSnapshot(snapshot Snapshot, tags map[string]string) (*Volume, error)
 
+	// CreateVolumeFromVolume creates a clone of the source volume, in the target zone and with the capacity overrides of the request
+	CreateVolumeFromVolume(cloneRequest VolumeCloneRequest) (*VolumeCloneResponse, error)
+
 	// UpdateVolume the volume
 	UpdateVolume(Volume) error
-	// Delete the volume
+	// Delete the volume, deleting a deleted volume succeeds or returns an ErrorResourceNotFound error.
+	// An attached volume is not deleted
 	DeleteVolume(*Volume) error
 
-	// Get the volume by using ID  //
+	// Get the volume by using ID, a missing volume returns an ErrorResourceNotFound error
 	GetVolume(id string) (*Volume, error)
 
 	// Get the volume by using Name,
@@ -48,6 +54,9 @@ type VolumeManager interface {
 	// Get volume lists by using filters
 	ListVolumes(limit int, start string, tags map[string]string) (*VolumeList, error)
 
+	// ListVolumesWithFilters lists the volumes matching the filters, filtered by the backend
+	ListVolumesWithFilters(limit int, start string, filters ListVolumesFilters) (*VolumeList, error)
+
 	// GetVolumeByRequestID fetch the volume by request ID.
 	// Request Id is the one that is returned when volume is provsioning request is
 	// placed with Iaas provider.
@@ -59,4 +68,29 @@ type VolumeManager interface {
 	// Volume operations
 	// Expand the volume with authorization by passing required information in the volume object
 	ExpandVolume(expandVolumeRequest ExpandVolumeRequest) (int64, error)
+
+	// SupportsOnlineExpansion returns true if the volume can be expanded while attached, without detaching it first
+	SupportsOnlineExpansion(volume *Volume) bool
+
+	// UpdateVolumeProfile changes the profile of the volume, with the IOPS and bandwidth of the new profile
+	UpdateVolumeProfile(updateRequest VolumeProfileUpdateRequest) (*Volume, error)
+
+	// UpdateVolumeIOPS changes the IOPS and bandwidth of a volume with a custom IOPS profile
+	UpdateVolumeIOPS(updateRequest VolumeIOPSUpdateRequest) (*Volume, error)
+
+	// GetVolumeTags returns the user and access management tags of the volume
+	GetVolumeTags(volumeID string) (*VolumeTags, error)
+
+	// UpdateVolumeTags attaches and detaches tags of one type to the volume, returning the updated tags
+	UpdateVolumeTags(updateRequest VolumeTagsUpdateRequest) (*VolumeTags, error)
+}
+
+// DeletionProtectionManager is optionally implemented by providers supporting backend native deletion
+// protection (see FeatureDeletionProtection), otherwise the library implements it with a volume tag
+type DeletionProtectionManager interface {
+	// SetDeletionProtection enables or disables the deletion protection of the volume
+	SetDeletionProtection(ctx context.Context, volumeID string, enabled bool) error
+
+	// IsDeletionProtected returns whether the volume is protected against deletion
+	IsDeletionProtected(ctx context.Context, volumeID string) (bool, error)
 }
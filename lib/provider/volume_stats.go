@@ -0,0 +1,53 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// VolumePerformanceStatsManager ...
+type VolumePerformanceStatsManager interface {
+	// GetVolumePerformanceStats returns the backend reported IOPS, throughput and latency of the volume
+	// averaged over the given window, for providers where monitoring APIs exist
+	GetVolumePerformanceStats(ctx context.Context, volumeID string, window time.Duration) (*VolumePerformanceStats, error)
+}
+
+// VolumePerformanceStats ...
+type VolumePerformanceStats struct {
+	VolumeID string `json:"volumeID"`
+
+	// Window the stats are averaged over
+	Window time.Duration `json:"window"`
+
+	// IOPS
+	ReadIOPS  float64 `json:"readIops"`
+	WriteIOPS float64 `json:"writeIops"`
+
+	// Throughput in bytes per second
+	ReadThroughput  float64 `json:"readThroughput"`
+	WriteThroughput float64 `json:"writeThroughput"`
+
+	// Average latencies
+	ReadLatency  time.Duration `json:"readLatency"`
+	WriteLatency time.Duration `json:"writeLatency"`
+
+	// Time stamp when the stats were collected by the backend
+	CollectedAt time.Time `json:"collectedAt"`
+}
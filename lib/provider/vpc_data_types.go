@@ -26,22 +26,39 @@ type VPCVolume struct {
 	VolumeEncryptionKey *VolumeEncryptionKey `json:"encryption_key,omitempty"`
 	Profile             *Profile             `json:"profile,omitempty"`
 	CRN                 string               `json:"crn,omitempty"`
+	// LifecycleState of the volume - pending, stable, updating, deleting, failed, suspended
+	LifecycleState string `json:"lifecycle_state,omitempty"`
 	VPCBlockVolume
 	VPCFileVolume
 }
 
+// VolumeLifecycleStateDeleting is the lifecycle state of a volume which is being deleted
+const VolumeLifecycleStateDeleting = "deleting"
+
 // VPCBlockVolume specific parameters
 type VPCBlockVolume struct {
 	Tags              []string            `json:"volume_tags,omitempty"`
 	VolumeAttachments *[]VolumeAttachment `json:"volume_attachments,omitempty"`
+
+	// AccessTags are the access management tags (key:value) of the volume, see TagTypeAccess
+	AccessTags []string `json:"access_tags,omitempty"`
 }
 
 // VPCFileVolume specific parameters
 type VPCFileVolume struct {
 	VolumeAccessPoints *[]VolumeAccessPoint `json:"volume_access_points,omitempty"`
 	InitialOwner       *InitialOwner        `json:"initial_owner,omitempty"`
+	// AllowedTransitEncryptionModes of the share - none, user_managed
+	AllowedTransitEncryptionModes []string `json:"allowed_transit_encryption_modes,omitempty"`
 }
 
+const (
+	// TransitEncryptionNone disables encryption in transit of a share access point
+	TransitEncryptionNone = "none"
+	// TransitEncryptionUserManaged enables encryption in transit (IPsec) of a share access point
+	TransitEncryptionUserManaged = "user_managed"
+)
+
 // VPC ...
 type VPC struct {
 	ID   string `json:"id"`
@@ -61,6 +78,8 @@ type VolumeAccessPoint struct {
 	VPC       *VPC       `json:"vpc,omitempty"`
 	Zone      *Zone      `json:"zone,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// TransitEncryption of the access point - none, user_managed
+	TransitEncryption string `json:"transit_encryption,omitempty"`
 }
 
 // Zone ...
@@ -108,6 +127,8 @@ type VolumeAttachment struct {
 	DeleteVolumeOnInstanceDelete bool `json:"delete_volume_on_instance_delete,omitempty"`
 	// device path for attachment
 	DevicePath string `json:"device_path,omitempty"`
+	// Bandwidth of the attachment in megabits per second, where supported. The instance default allocation if 0
+	Bandwidth int64 `json:"bandwidth,omitempty"`
 }
 
 // VolumeEncryptionKey ...
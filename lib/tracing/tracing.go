@@ -0,0 +1,127 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing ...
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer of the library spans
+const InstrumentationName = "github.com/IBM/ibmcloud-volume-interface"
+
+// Span attributes of the library
+const (
+	// AttributeOperation is the provider operation of the span, e.g. CreateVolume
+	AttributeOperation = attribute.Key("ibm.volume.operation")
+
+	// AttributeReasonCode is the reason code of the error of a failed operation
+	AttributeReasonCode = attribute.Key("ibm.volume.reason_code")
+
+	// AttributeTokenExchange is the kind of IAM token exchange, e.g. access_token
+	AttributeTokenExchange = attribute.Key("ibm.iam.token_exchange")
+)
+
+var (
+	providerMu     sync.RWMutex
+	tracerProvider trace.TracerProvider
+)
+
+// SetTracerProvider sets the provider of the library tracer. By default, and after setting nil, the global
+// OpenTelemetry provider is used, which records nothing until the application registers one with otel.SetTracerProvider
+func SetTracerProvider(provider trace.TracerProvider) {
+	providerMu.Lock()
+	tracerProvider = provider
+	providerMu.Unlock()
+}
+
+// Tracer returns the tracer of the library spans
+func Tracer() trace.Tracer {
+	providerMu.RLock()
+	provider := tracerProvider
+	providerMu.RUnlock()
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(InstrumentationName)
+}
+
+// StartOperation starts the span of a provider operation, as a child of the span of the caller's context if any.
+// The span must be ended with End
+func StartOperation(ctx context.Context, operation string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	attributes = append([]attribute.KeyValue{AttributeOperation.String(operation)}, attributes...)
+	return Tracer().Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+// End ends the span, recording err and its reason code if the operation failed
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if code := reasonCodeOf(err); code != "" {
+			span.SetAttributes(AttributeReasonCode.String(code))
+		}
+	}
+	span.End()
+}
+
+// reasonCodeOf returns the reason code of a provider error, empty for other errors
+func reasonCodeOf(err error) string {
+	var coded interface{ Code() reasoncode.ReasonCode }
+	if errors.As(err, &coded) {
+		return string(coded.Code())
+	}
+	return ""
+}
+
+// InjectHeaders adds the trace context of ctx to the headers of an outgoing request, with the global
+// OpenTelemetry propagator, so the spans of the backend join the trace of the caller
+func InjectHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Transport is the http.RoundTripper adding the trace context of the request context to the request headers
+type Transport struct {
+	// Base sends the requests, http.DefaultTransport if nil
+	Base http.RoundTripper
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// RoundTrip ...
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return base.RoundTrip(req)
+	}
+	traced := req.Clone(req.Context())
+	InjectHeaders(req.Context(), traced.Header)
+	return base.RoundTrip(traced)
+}
@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing ...
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { SetTracerProvider(nil) })
+	return recorder
+}
+
+func TestStartOperation(t *testing.T) {
+	recorder := newRecorder(t)
+
+	parentCtx, parent := Tracer().Start(context.Background(), "CSI.CreateVolume")
+	_, span := StartOperation(parentCtx, "CreateVolume")
+	End(span, provider.Error{Fault: provider.Fault{ReasonCode: "ErrorQuotaExceeded", Message: "quota"}})
+	parent.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "CreateVolume", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Contains(t, spans[0].Attributes(), AttributeOperation.String("CreateVolume"))
+		assert.Contains(t, spans[0].Attributes(), AttributeReasonCode.String("ErrorQuotaExceeded"))
+	}
+
+	_, span = StartOperation(context.Background(), "GetVolume")
+	End(span, errors.New("connection reset"))
+	_, span = StartOperation(context.Background(), "GetVolume")
+	End(span, nil)
+	spans = recorder.Ended()
+	assert.NotContains(t, spans[2].Attributes(), AttributeReasonCode.String(""))
+	assert.Equal(t, codes.Unset, spans[3].Status().Code)
+}
+
+func TestTransport(t *testing.T) {
+	newRecorder(t)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Empty(t, traceparent)
+
+	ctx, span := StartOperation(context.Background(), "GetVolume")
+	defer span.End()
+	_, err = client.Do(req.WithContext(ctx))
+	assert.Nil(t, err)
+	assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	assert.Empty(t, req.Header.Get("traceparent"))
+}
@@ -0,0 +1,72 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// ValidateAccessPointNetwork validates the network placement options of a volume access point request
+func ValidateAccessPointNetwork(request provider.VolumeAccessPointRequest) error {
+	if request.SubnetID != "" && request.VPCID != "" {
+		return accessPointNetworkError("Only one of subnet ID or VPC ID can be specified")
+	}
+	if request.SubnetID == "" && (len(request.SecurityGroupIDs) > 0 || request.ReservedIP != nil) {
+		return accessPointNetworkError("Subnet ID is required when security groups or reserved IP are specified")
+	}
+
+	seen := make(map[string]bool, len(request.SecurityGroupIDs))
+	for _, securityGroupID := range request.SecurityGroupIDs {
+		if securityGroupID == "" {
+			return accessPointNetworkError("Security group ID cannot be empty")
+		}
+		if seen[securityGroupID] {
+			return accessPointNetworkError(fmt.Sprintf("Duplicate security group ID %s", securityGroupID))
+		}
+		seen[securityGroupID] = true
+	}
+
+	return validateReservedIP(request.ReservedIP)
+}
+
+func validateReservedIP(reservedIP *provider.ReservedIP) error {
+	if reservedIP == nil {
+		return nil
+	}
+	if reservedIP.ID != "" {
+		if reservedIP.Address != "" || reservedIP.Name != "" || reservedIP.AutoDelete != nil {
+			return accessPointNetworkError("Reserved IP ID cannot be combined with address, name or auto delete")
+		}
+		return nil
+	}
+	if reservedIP.Address == "" {
+		return accessPointNetworkError("Reserved IP requires either an ID or an address")
+	}
+	if ip := net.ParseIP(reservedIP.Address); ip == nil || ip.To4() == nil {
+		return accessPointNetworkError(fmt.Sprintf("Invalid reserved IP address %s", reservedIP.Address))
+	}
+	return nil
+}
+
+func accessPointNetworkError(message string) error {
+	return NewError(reasoncode.ErrorBadRequest, message)
+}
@@ -0,0 +1,97 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAccessPointNetwork(t *testing.T) {
+	autoDelete := true
+	testCases := []struct {
+		testcasename string
+		request      provider.VolumeAccessPointRequest
+		expectErr    bool
+	}{
+		{
+			testcasename: "VPC mode",
+			request:      provider.VolumeAccessPointRequest{VPCID: "vpc-1"},
+		},
+		{
+			testcasename: "Subnet with security groups and new reserved IP",
+			request: provider.VolumeAccessPointRequest{
+				SubnetID:         "subnet-1",
+				SecurityGroupIDs: []string{"sg-1", "sg-2"},
+				ReservedIP:       &provider.ReservedIP{Address: "10.240.0.10", Name: "share-ip", AutoDelete: &autoDelete},
+			},
+		},
+		{
+			testcasename: "Subnet with existing reserved IP",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", ReservedIP: &provider.ReservedIP{ID: "rip-1"}},
+		},
+		{
+			testcasename: "Subnet and VPC",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", VPCID: "vpc-1"},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Security groups without subnet",
+			request:      provider.VolumeAccessPointRequest{VPCID: "vpc-1", SecurityGroupIDs: []string{"sg-1"}},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Duplicate security groups",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", SecurityGroupIDs: []string{"sg-1", "sg-1"}},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Empty security group",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", SecurityGroupIDs: []string{""}},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Reserved IP ID with address",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", ReservedIP: &provider.ReservedIP{ID: "rip-1", Address: "10.240.0.10"}},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Reserved IP without ID or address",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", ReservedIP: &provider.ReservedIP{Name: "share-ip"}},
+			expectErr:    true,
+		},
+		{
+			testcasename: "Invalid reserved IP address",
+			request:      provider.VolumeAccessPointRequest{SubnetID: "subnet-1", ReservedIP: &provider.ReservedIP{Address: "10.240.0"}},
+			expectErr:    true,
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateAccessPointNetwork(testcase.request)
+			if testcase.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
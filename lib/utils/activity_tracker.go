@@ -0,0 +1,301 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// ActivityEventTimeFormat is the time format of the activity tracker events
+const ActivityEventTimeFormat = "2006-01-02T15:04:05.00-0700"
+
+// Outcomes of the activity tracker events
+const (
+	ActivityOutcomeSuccess = "success"
+	ActivityOutcomeFailure = "failure"
+)
+
+// Severities of the activity tracker events
+const (
+	ActivitySeverityNormal   = "normal"
+	ActivitySeverityWarning  = "warning"
+	ActivitySeverityCritical = "critical"
+)
+
+// ActivityInitiator is the identity performing the operations, e.g. the service ID of the API key of the driver
+type ActivityInitiator struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	TypeURI string `json:"typeURI,omitempty"`
+	Host    string `json:"host,omitempty"`
+}
+
+// ActivityTarget is the resource of the operation
+type ActivityTarget struct {
+	// ID is the CRN of the resource
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	TypeURI string `json:"typeURI"`
+}
+
+// ActivityReason is the result of the operation, as an HTTP status
+type ActivityReason struct {
+	ReasonCode int    `json:"reasonCode"`
+	ReasonType string `json:"reasonType"`
+	// ReasonForFailure is the reason code of the failure, e.g. ErrorQuotaExceeded
+	ReasonForFailure string `json:"reasonForFailure,omitempty"`
+}
+
+// ActivityEvent is an IBM Cloud Activity Tracker event (a CADF event) of a mutating operation
+type ActivityEvent struct {
+	EventTime     string            `json:"eventTime"`
+	Action        string            `json:"action"`
+	Outcome       string            `json:"outcome"`
+	Severity      string            `json:"severity"`
+	Message       string            `json:"message"`
+	Initiator     ActivityInitiator `json:"initiator"`
+	Target        ActivityTarget    `json:"target"`
+	Reason        ActivityReason    `json:"reason"`
+	RequestData   map[string]string `json:"requestData,omitempty"`
+	CorrelationID string            `json:"correlationId,omitempty"`
+	DataEvent     bool              `json:"dataEvent"`
+}
+
+// ActivitySink delivers the activity tracker events to the audit pipeline of the account
+type ActivitySink interface {
+	Emit(event ActivityEvent) error
+}
+
+// LogActivitySink writes the events to the logger, for the deployments whose logs are routed to activity tracker
+type LogActivitySink struct {
+	logger *zap.Logger
+}
+
+// NewLogActivitySink returns a LogActivitySink of the logger
+func NewLogActivitySink(logger *zap.Logger) *LogActivitySink {
+	return &LogActivitySink{logger: logger}
+}
+
+// Emit ...
+func (s *LogActivitySink) Emit(event ActivityEvent) error {
+	s.logger.Info("Activity tracker event", zap.Reflect("event", event))
+	return nil
+}
+
+// FileActivitySink appends the events as JSON lines to a file, collected by the logging agent of the node
+type FileActivitySink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileActivitySink opens (or creates) the file of the events, readable by the owner only
+func NewFileActivitySink(path string) (*FileActivitySink, error) {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileActivitySink{file: file}, nil
+}
+
+// Emit ...
+func (s *FileActivitySink) Emit(event ActivityEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the file of the events
+func (s *FileActivitySink) Close() error {
+	return s.file.Close()
+}
+
+// ActivityTracker emits an activity tracker event per mutating operation run by RunMutation or a context session
+// with a context of WithActivityTracker, or run by a session of NewGuardedSession with the tracker. The sink
+// failures are logged, they never fail the operation
+type ActivityTracker struct {
+	sink        ActivitySink
+	serviceName string
+	location    string
+	accountID   string
+	logger      *zap.Logger
+}
+
+// NewActivityTracker returns an ActivityTracker of the sink, the target CRNs are made of the service name,
+// location (the region) and account ID
+func NewActivityTracker(sink ActivitySink, serviceName, location, accountID string, logger *zap.Logger) *ActivityTracker {
+	return &ActivityTracker{sink: sink, serviceName: serviceName, location: location, accountID: accountID, logger: logger}
+}
+
+// ActivityTrackerFromConfig returns the ActivityTracker of the [activity_tracker] configuration, nil if it is
+// not enabled. The location is the region of the provider. The tracker must be closed once it is no longer used
+func ActivityTrackerFromConfig(at *config.ActivityTrackerConfig, location string, logger *zap.Logger) (*ActivityTracker, error) {
+	if at == nil || !at.Enabled {
+		return nil, nil
+	}
+	if err := at.ValidateActivityTracker(); err != nil {
+		return nil, err
+	}
+	var sink ActivitySink = NewLogActivitySink(logger)
+	if at.Sink == config.ActivityTrackerSinkFile {
+		fileSink, err := NewFileActivitySink(at.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the activity tracker events file: %v", err)
+		}
+		sink = fileSink
+	}
+	return NewActivityTracker(sink, at.ServiceNameOrDefault(), location, at.AccountID, logger), nil
+}
+
+// splitOperation splits the operation (e.g. UpdateVolumeTags) into its verb (update) and resource type (volume)
+func splitOperation(operation string) (string, string) {
+	words := []string{}
+	start := 0
+	for i, r := range operation {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, strings.ToLower(operation[start:i]))
+			start = i
+		}
+	}
+	words = append(words, strings.ToLower(operation[start:]))
+	if len(words) == 1 {
+		return words[0], "volume"
+	}
+	return words[0], words[1]
+}
+
+// targetCRN returns the CRN of the resource, the target itself if it is already a CRN
+func (t *ActivityTracker) targetCRN(resourceType, target string) string {
+	if strings.HasPrefix(target, "crn:") {
+		return target
+	}
+	scope := ""
+	if t.accountID != "" {
+		scope = "a/" + t.accountID
+	}
+	return CRN{Version: "v1", CName: "bluemix", CType: "public", ServiceName: t.serviceName, Location: t.location,
+		Scope: scope, ResourceType: resourceType, Resource: target}.String()
+}
+
+// Event returns the event of the operation on the target completed with err
+func (t *ActivityTracker) Event(ctx context.Context, operation, target string, details map[string]string, err error) ActivityEvent {
+	verb, resourceType := splitOperation(operation)
+	initiator, found := activityInitiatorKey.Value(ctx)
+	if !found {
+		initiator = ActivityInitiator{ID: "unknown"}
+	}
+	event := ActivityEvent{
+		EventTime:   time.Now().Format(ActivityEventTimeFormat),
+		Action:      fmt.Sprintf("%s.%s.%s", t.serviceName, resourceType, verb),
+		Outcome:     ActivityOutcomeSuccess,
+		Severity:    ActivitySeverityNormal,
+		Message:     fmt.Sprintf("%s: %s %s %s", t.serviceName, verb, resourceType, target),
+		Initiator:   initiator,
+		Target:      ActivityTarget{ID: t.targetCRN(resourceType, target), Name: target, TypeURI: t.serviceName + "/" + resourceType},
+		Reason:      ActivityReason{ReasonCode: http.StatusOK, ReasonType: http.StatusText(http.StatusOK)},
+		RequestData: details,
+	}
+	if verb == "delete" {
+		event.Severity = ActivitySeverityCritical
+	}
+	if span := trace.SpanContextFromContext(ctx); span.HasTraceID() {
+		event.CorrelationID = span.TraceID().String()
+	}
+	if err != nil {
+		event.Outcome = ActivityOutcomeFailure
+		event.Severity = ActivitySeverityWarning
+		event.Message += " -failure"
+		status, found := activityCategoryStatus[ErrorCategoryOf(err)]
+		if !found {
+			status = http.StatusInternalServerError
+		}
+		event.Reason = ActivityReason{ReasonCode: status, ReasonType: http.StatusText(status), ReasonForFailure: string(ErrorReasonCode(err))}
+	}
+	return event
+}
+
+// activityCategoryStatus is the HTTP status of the failures of the events per error category, 500 if missing
+var activityCategoryStatus = map[provider.ErrorCategory]int{
+	provider.CategoryInvalidRequest:       http.StatusBadRequest,
+	provider.CategoryUnsupported:          http.StatusNotImplemented,
+	provider.CategoryNotFound:             http.StatusNotFound,
+	provider.CategoryAuthFailure:          http.StatusUnauthorized,
+	provider.CategoryPermissionDenied:     http.StatusForbidden,
+	provider.CategoryQuotaExceeded:        http.StatusForbidden,
+	provider.CategoryInsufficientCapacity: http.StatusServiceUnavailable,
+	provider.CategoryConflict:             http.StatusConflict,
+	provider.CategoryRateLimited:          http.StatusTooManyRequests,
+	provider.CategoryUnavailable:          http.StatusServiceUnavailable,
+	provider.CategoryTimeout:              http.StatusGatewayTimeout,
+}
+
+// Close closes the sink of the tracker if it is an io.Closer, e.g. a FileActivitySink
+func (t *ActivityTracker) Close() error {
+	if closer, isCloser := t.sink.(io.Closer); isCloser {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Record emits the event of the operation on the target completed with err
+func (t *ActivityTracker) Record(ctx context.Context, operation, target string, details map[string]string, err error) {
+	if emitErr := t.sink.Emit(t.Event(ctx, operation, target, details, err)); emitErr != nil {
+		t.logger.Warn("Failed to emit the activity tracker event", zap.String("operation", operation), zap.String("target", target), zap.Error(emitErr))
+	}
+}
+
+var (
+	activityTrackerKey   = ctxkeys.NewKey[*ActivityTracker]("activity-tracker")
+	activityInitiatorKey = ctxkeys.NewKey[ActivityInitiator]("activity-initiator")
+)
+
+// WithActivityTracker returns a context emitting the events of the mutations run with it to the tracker
+func WithActivityTracker(ctx context.Context, tracker *ActivityTracker) context.Context {
+	return activityTrackerKey.WithValue(ctx, tracker)
+}
+
+// ActivityTrackerFromContext returns the activity tracker attached to the context, if any
+func ActivityTrackerFromContext(ctx context.Context) (*ActivityTracker, bool) {
+	tracker, found := activityTrackerKey.Value(ctx)
+	return tracker, found && tracker != nil
+}
+
+// WithActivityInitiator returns a context recording the initiator in the events of the mutations run with it
+func WithActivityInitiator(ctx context.Context, initiator ActivityInitiator) context.Context {
+	return activityInitiatorKey.WithValue(ctx, initiator)
+}
@@ -0,0 +1,185 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// recordingActivitySink records the events emitted
+type recordingActivitySink struct {
+	events []ActivityEvent
+	err    error
+}
+
+func (s *recordingActivitySink) Emit(event ActivityEvent) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func TestActivityTrackerRunMutation(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sink := &recordingActivitySink{}
+	tracker := NewActivityTracker(sink, "is", "us-south", "abc123", logger)
+	ctx := WithActivityInitiator(WithActivityTracker(context.Background(), tracker), ActivityInitiator{ID: "iam-ServiceId-1", Name: "driver"})
+
+	assert.Nil(t, RunMutation(ctx, "ExpandVolume", "vol-1", map[string]string{"capacity": "20"}, func() error { return nil }))
+	assert.Len(t, sink.events, 1)
+	event := sink.events[0]
+	assert.Equal(t, "is.volume.expand", event.Action)
+	assert.Equal(t, ActivityOutcomeSuccess, event.Outcome)
+	assert.Equal(t, ActivitySeverityNormal, event.Severity)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-south:a/abc123::volume:vol-1", event.Target.ID)
+	assert.Equal(t, "is/volume", event.Target.TypeURI)
+	assert.Equal(t, "iam-ServiceId-1", event.Initiator.ID)
+	assert.Equal(t, 200, event.Reason.ReasonCode)
+	assert.Equal(t, map[string]string{"capacity": "20"}, event.RequestData)
+
+	err := RunMutation(ctx, "DeleteSnapshot", "snap-1", nil, func() error {
+		return NewError(reasoncode.ErrorResourceNotFound, "Snapshot not found")
+	})
+	assert.NotNil(t, err)
+	event = sink.events[1]
+	assert.Equal(t, "is.snapshot.delete", event.Action)
+	assert.Equal(t, ActivityOutcomeFailure, event.Outcome)
+	assert.Equal(t, ActivitySeverityWarning, event.Severity)
+	assert.Equal(t, 404, event.Reason.ReasonCode)
+	assert.Equal(t, string(reasoncode.ErrorResourceNotFound), event.Reason.ReasonForFailure)
+
+	// dry run mutations are planned, not performed
+	dryRun, _ := WithDryRun(ctx)
+	assert.Nil(t, RunMutation(dryRun, "DeleteVolume", "vol-1", nil, func() error { return nil }))
+	assert.Len(t, sink.events, 2)
+
+	// the sink failures do not fail the operation
+	sink.err = errors.New("sink unavailable")
+	assert.Nil(t, RunMutation(ctx, "DeleteVolume", "vol-1", nil, func() error { return nil }))
+	assert.Equal(t, ActivitySeverityCritical, sink.events[2].Severity)
+
+	assert.Nil(t, RunMutation(context.Background(), "DeleteVolume", "vol-1", nil, func() error { return nil }))
+	assert.Len(t, sink.events, 3)
+}
+
+func TestActivityTrackerSessions(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sink := &recordingActivitySink{}
+	tracker := NewActivityTracker(sink, "is", "us-south", "abc123", logger)
+	ctx := WithActivityTracker(context.Background(), tracker)
+
+	inner := &fake.FakeSession{}
+	csess := NewContextSession(inner)
+	_, err := csess.ExpandVolumeWithContext(ctx, provider.ExpandVolumeRequest{VolumeID: "vol-1"})
+	assert.Nil(t, err)
+	_, err = csess.GetVolumeWithContext(ctx, "vol-1")
+	assert.Nil(t, err)
+	assert.Len(t, sink.events, 1)
+	assert.Equal(t, "is.volume.expand", sink.events[0].Action)
+	assert.Equal(t, "vol-1", sink.events[0].Target.Name)
+
+	// the guarded sessions record their direct calls, and those of their context session once
+	sess := NewGuardedSession(inner, tracker)
+	inner.DeleteShareReturns(NewError(reasoncode.ErrorResourceNotFound, "Share not found"))
+	assert.NotNil(t, sess.DeleteShare("share-1"))
+	assert.Len(t, sink.events, 2)
+	assert.Equal(t, "is.share.delete", sink.events[1].Action)
+	assert.Equal(t, ActivityOutcomeFailure, sink.events[1].Outcome)
+	assert.Nil(t, NewContextSession(sess).DeleteVolumeWithContext(ctx, &provider.Volume{VolumeID: "vol-1"}))
+	assert.Len(t, sink.events, 3)
+	assert.Equal(t, "is.volume.delete", sink.events[2].Action)
+
+	// nothing is recorded without a tracker
+	assert.Nil(t, NewGuardedSession(inner, nil).DeleteVolume(nil))
+	assert.Len(t, sink.events, 3)
+}
+
+func TestActivityTrackerEvent(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	tracker := NewActivityTracker(&recordingActivitySink{}, "is", "us-south", "", logger)
+
+	event := tracker.Event(context.Background(), "AttachVolume", "crn:v1:bluemix:public:is:us-south-1:a/abc::volume:vol-1", nil, errors.New("failed"))
+	assert.Equal(t, "is.volume.attach", event.Action)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-south-1:a/abc::volume:vol-1", event.Target.ID)
+	assert.Equal(t, "unknown", event.Initiator.ID)
+	assert.Equal(t, 500, event.Reason.ReasonCode)
+	assert.Empty(t, event.CorrelationID)
+
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	event = tracker.Event(ctx, "Resize", "vol-1", nil, nil)
+	assert.Equal(t, "is.volume.resize", event.Action)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-south:::volume:vol-1", event.Target.ID)
+	assert.Equal(t, traceID.String(), event.CorrelationID)
+}
+
+func TestFileActivitySink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	sink, err := NewFileActivitySink(path)
+	assert.Nil(t, err)
+	assert.Nil(t, sink.Emit(ActivityEvent{Action: "is.volume.create"}))
+	assert.Nil(t, sink.Emit(ActivityEvent{Action: "is.volume.delete"}))
+	assert.Nil(t, sink.Close())
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+	event := ActivityEvent{}
+	assert.Nil(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "is.volume.delete", event.Action)
+}
+
+func TestActivityTrackerFromConfig(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	tracker, err := ActivityTrackerFromConfig(nil, "us-south", logger)
+	assert.Nil(t, err)
+	assert.Nil(t, tracker)
+	_, found := ActivityTrackerFromContext(WithActivityTracker(context.Background(), tracker))
+	assert.False(t, found)
+
+	tracker, err = ActivityTrackerFromConfig(&config.ActivityTrackerConfig{Enabled: true}, "us-south", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "is.volume.create", tracker.Event(context.Background(), "CreateVolume", "vol-1", nil, nil).Action)
+
+	path := filepath.Join(t.TempDir(), "events.log")
+	tracker, err = ActivityTrackerFromConfig(&config.ActivityTrackerConfig{Enabled: true, Sink: config.ActivityTrackerSinkFile, Path: path, ServiceName: "share"}, "us-south", logger)
+	assert.Nil(t, err)
+	tracker.Record(context.Background(), "CreateShare", "share-1", nil, nil)
+	assert.Nil(t, tracker.Close())
+	data, _ := os.ReadFile(path)
+	assert.Contains(t, string(data), "share.share.create")
+
+	_, err = ActivityTrackerFromConfig(&config.ActivityTrackerConfig{Enabled: true, Sink: "syslog"}, "us-south", logger)
+	assert.NotNil(t, err)
+	_, err = ActivityTrackerFromConfig(&config.ActivityTrackerConfig{Enabled: true, Sink: config.ActivityTrackerSinkFile, Path: filepath.Join(path, "missing", "events.log")}, "us-south", logger)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,175 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+)
+
+const (
+	// DefaultMaxPollInterval is the default longest interval of an adaptive poll
+	DefaultMaxPollInterval = 30 * time.Second
+
+	// minTransitionSamples is the number of observed transitions before the poll interval adapts
+	minTransitionSamples = 3
+
+	// transitionWindowDeviations is the number of mean deviations around the mean transition time polled densely
+	transitionWindowDeviations = 2
+)
+
+// transitionStats are the exponential moving averages of the duration of a transition and of its deviation
+type transitionStats struct {
+	samples   int
+	mean      float64
+	deviation float64
+}
+
+// TransitionTracker tracks the observed times of the state transitions of an operation, e.g. an attach
+// usually completing in 15–25s, to adapt the poll interval of the waits: sparse polls until the expected
+// window, dense polls within it, then polls backing off when the transition is late
+type TransitionTracker struct {
+	mu          sync.RWMutex
+	alpha       float64
+	maxInterval time.Duration
+	stats       map[string]*transitionStats
+}
+
+var transitionTrackerKey = ctxkeys.NewKey[*TransitionTracker]("transition-tracker")
+
+// NewTransitionTracker returns a tracker weighting new samples by alpha (0 < alpha <= 1, DefaultLatencySmoothing
+// for invalid values) and polling at most every maxInterval (DefaultMaxPollInterval if zero)
+func NewTransitionTracker(alpha float64, maxInterval time.Duration) *TransitionTracker {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultLatencySmoothing
+	}
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+	return &TransitionTracker{alpha: alpha, maxInterval: maxInterval, stats: make(map[string]*transitionStats)}
+}
+
+// WithTransitionTracker returns a context adapting the poll interval of the waits performed with it
+func WithTransitionTracker(ctx context.Context, tracker *TransitionTracker) context.Context {
+	return transitionTrackerKey.WithValue(ctx, tracker)
+}
+
+// TransitionTrackerFromContext returns the tracker attached to the context, if any
+func TransitionTrackerFromContext(ctx context.Context) (*TransitionTracker, bool) {
+	return transitionTrackerKey.Value(ctx)
+}
+
+// Observe records the time the transition of the operation took
+func (t *TransitionTracker) Observe(operation string, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats, ok := t.stats[operation]
+	if !ok {
+		t.stats[operation] = &transitionStats{samples: 1, mean: float64(duration)}
+		return
+	}
+	delta := float64(duration) - stats.mean
+	if delta < 0 {
+		stats.deviation += t.alpha * (-delta - stats.deviation)
+	} else {
+		stats.deviation += t.alpha * (delta - stats.deviation)
+	}
+	stats.mean += t.alpha * delta
+	stats.samples++
+}
+
+// ExpectedWindow returns the window the transition of the operation is expected to complete in, false until
+// enough transitions are observed
+func (t *TransitionTracker) ExpectedWindow(operation string) (from, to time.Duration, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	stats, found := t.stats[operation]
+	if !found || stats.samples < minTransitionSamples {
+		return 0, 0, false
+	}
+	spread := transitionWindowDeviations * stats.deviation
+	from = time.Duration(stats.mean - spread)
+	if from < 0 {
+		from = 0
+	}
+	return from, time.Duration(stats.mean + spread), true
+}
+
+// PollInterval returns the time to wait before the next poll of the operation, elapsed since the wait started.
+// interval is the dense interval, used within the expected window and until enough transitions are observed
+func (t *TransitionTracker) PollInterval(operation string, elapsed, interval time.Duration) time.Duration {
+	from, to, ok := t.ExpectedWindow(operation)
+	var next time.Duration
+	switch {
+	case !ok:
+		return interval
+	case elapsed < from:
+		// sparse polls until the window, the transition is unlikely to have completed
+		next = from - elapsed
+	case elapsed <= to:
+		return interval
+	default:
+		// the transition is late, back off
+		next = (elapsed - to) / 2
+	}
+	if next < interval {
+		next = interval
+	}
+	if next > t.maxInterval && t.maxInterval > interval {
+		next = t.maxInterval
+	}
+	return next
+}
+
+// pollInterval returns the time to wait before the next poll, adapted by the tracker of the context if any
+func pollInterval(ctx context.Context, operation string, elapsed, interval time.Duration) time.Duration {
+	if tracker, ok := TransitionTrackerFromContext(ctx); ok && tracker != nil {
+		return tracker.PollInterval(operation, elapsed, interval)
+	}
+	return interval
+}
+
+// observeTransition records the time the transition of the operation took in the tracker of the context, if any
+func observeTransition(ctx context.Context, operation string, duration time.Duration) {
+	if tracker, ok := TransitionTrackerFromContext(ctx); ok && tracker != nil {
+		tracker.Observe(operation, duration)
+	}
+}
+
+// adaptiveWait runs the wait of the provider for the operation, which polls at the interval of the provider: the
+// wait starts once the window the transition is expected to complete in opens, per the tracker of the context, and
+// its duration is recorded in the tracker
+func adaptiveWait[T any](ctx context.Context, operation string, wait func() (T, error)) (T, error) {
+	start := time.Now()
+	if delay := pollInterval(ctx, operation, 0, 0); delay > 0 {
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	value, err := wait()
+	if err == nil {
+		observeTransition(ctx, operation, time.Since(start))
+	}
+	return value, err
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTransitionTrackerPollInterval(t *testing.T) {
+	tracker := NewTransitionTracker(0.5, 10*time.Second)
+
+	// not enough transitions observed yet
+	tracker.Observe("attach", 20*time.Second)
+	assert.Equal(t, time.Second, tracker.PollInterval("attach", 0, time.Second))
+	tracker.Observe("attach", 16*time.Second)
+	tracker.Observe("attach", 24*time.Second)
+
+	from, to, ok := tracker.ExpectedWindow("attach")
+	assert.True(t, ok)
+	assert.Equal(t, 13*time.Second, from)
+	assert.Equal(t, 29*time.Second, to)
+
+	testcases := []struct {
+		testcasename string
+		elapsed      time.Duration
+		expected     time.Duration
+	}{
+		{testcasename: "Start of the wait", elapsed: 0, expected: 10 * time.Second},
+		{testcasename: "Before the window", elapsed: 10 * time.Second, expected: 3 * time.Second},
+		{testcasename: "Just before the window", elapsed: 12500 * time.Millisecond, expected: time.Second},
+		{testcasename: "Within the window", elapsed: 20 * time.Second, expected: time.Second},
+		{testcasename: "Just late", elapsed: 30 * time.Second, expected: time.Second},
+		{testcasename: "Late", elapsed: 37 * time.Second, expected: 4 * time.Second},
+		{testcasename: "Very late", elapsed: 5 * time.Minute, expected: 10 * time.Second},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			assert.Equal(t, testcase.expected, tracker.PollInterval("attach", testcase.elapsed, time.Second))
+		})
+	}
+
+	assert.Equal(t, time.Second, tracker.PollInterval("detach", 0, time.Second))
+	assert.Equal(t, time.Second, pollInterval(context.Background(), "attach", 0, time.Second))
+	assert.Equal(t, 10*time.Second, pollInterval(WithTransitionTracker(context.Background(), tracker), "attach", 0, time.Second))
+}
+
+func TestWaitForSnapshotRestoreObservesTransition(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetSnapshotRestoreProgressReturns(&provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestoreCompleted, PercentComplete: 100}, nil)
+	tracker := NewTransitionTracker(0, 0)
+	ctx := WithTransitionTracker(context.Background(), tracker)
+
+	for i := 0; i < minTransitionSamples; i++ {
+		_, err := WaitForSnapshotRestore(ctx, sess, "vol-1", time.Millisecond, nil, logger)
+		assert.Nil(t, err)
+	}
+	_, _, ok := tracker.ExpectedWindow("WaitForSnapshotRestore")
+	assert.True(t, ok)
+}
+
+func TestWaitForVolumeDeletionObservesTransition(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	tracker := NewTransitionTracker(0, 0)
+	ctx := WithTransitionTracker(context.Background(), tracker)
+
+	for i := 0; i < minTransitionSamples; i++ {
+		assert.Nil(t, WaitForVolumeDeletion(ctx, sess, "vol-1", time.Millisecond, time.Second, logger))
+	}
+	_, _, ok := tracker.ExpectedWindow("WaitForVolumeDeletion")
+	assert.True(t, ok)
+}
+
+func TestAttachWaitsAdapt(t *testing.T) {
+	sess := &fake.FakeSession{}
+	sess.WaitForAttachVolumeReturns(&provider.VolumeAttachmentResponse{}, nil)
+	tracker := NewTransitionTracker(0, time.Second)
+	ctx := WithTransitionTracker(context.Background(), tracker)
+	contextSess := NewContextSession(sess)
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"}
+
+	for i := 0; i < minTransitionSamples; i++ {
+		_, err := contextSess.WaitForAttachVolumeWithContext(ctx, request)
+		assert.Nil(t, err)
+		assert.Nil(t, contextSess.WaitForDetachVolumeWithContext(ctx, request))
+	}
+	_, _, ok := tracker.ExpectedWindow("WaitForAttachVolume")
+	assert.True(t, ok)
+	_, _, ok = tracker.ExpectedWindow("WaitForDetachVolume")
+	assert.True(t, ok)
+
+	// the wait of the provider starts once the attach is expected to complete
+	tracker = NewTransitionTracker(1, time.Second)
+	for i := 0; i < minTransitionSamples; i++ {
+		tracker.Observe("WaitForAttachVolume", 50*time.Millisecond)
+	}
+	ctx = WithTransitionTracker(context.Background(), tracker)
+	start := time.Now()
+	_, err := contextSess.WaitForAttachVolumeWithContext(ctx, request)
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = contextSess.WaitForAttachVolumeWithContext(cancelled, request)
+	assert.NotNil(t, err)
+	assert.Equal(t, minTransitionSamples+1, sess.WaitForAttachVolumeCallCount())
+}
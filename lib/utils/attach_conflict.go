@@ -0,0 +1,106 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// attachConflictPatterns are the backend error fragments reported when the volume of an attach request
+// is already attached to another instance
+var attachConflictPatterns = []string{
+	"already attached",
+	"attached to another instance",
+	"volume_attachment_conflict",
+}
+
+// DetectAttachConflict returns provider.ErrAttachConflict (wrapping the original error) if err indicates
+// that the volume is attached to another instance, otherwise err is returned unchanged
+func DetectAttachConflict(err error) error {
+	if err == nil || errors.Is(err, provider.ErrAttachConflict) {
+		return err
+	}
+	messages := append([]string{err.Error()}, ErrorDeepUnwrapString(err)...)
+	for _, msg := range messages {
+		msg = strings.ToLower(msg)
+		for _, pattern := range attachConflictPatterns {
+			if strings.Contains(msg, pattern) {
+				return NewError(reasoncode.ErrorVolumeAttachConflict, provider.ErrAttachConflict.Error(), err)
+			}
+		}
+	}
+	return err
+}
+
+// AttachConflictRecovery configures the recovery of attach conflicts
+type AttachConflictRecovery struct {
+	// Enabled gates the recovery, typically from the attach_detach_on_conflict config
+	Enabled bool
+
+	// AttachedInstance returns the instance the volume is currently attached to
+	AttachedInstance func(volumeID string) (string, error)
+
+	// IsStale reports whether the attachment to the instance can be safely broken, e.g. its node is gone.
+	// Attachments to live instances are never broken
+	IsStale func(instanceID string) (bool, error)
+}
+
+// AttachVolumeWithConflictRecovery attaches the volume. If the volume is attached to another, stale, instance
+// and recovery is enabled, it is force detached from that instance and the attach is retried once.
+// provider.ErrAttachConflict is returned if the conflict can not be recovered
+func AttachVolumeWithConflictRecovery(sess provider.VolumeAttachManager, attachRequest provider.VolumeAttachmentRequest, recovery AttachConflictRecovery, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	response, err := sess.AttachVolume(attachRequest)
+	err = DetectAttachConflict(err)
+	if !errors.Is(err, provider.ErrAttachConflict) || !recovery.Enabled || recovery.AttachedInstance == nil || recovery.IsStale == nil {
+		return response, err
+	}
+
+	volumeID := attachRequest.VolumeID
+	oldInstanceID, lookupErr := recovery.AttachedInstance(volumeID)
+	if lookupErr != nil || oldInstanceID == "" {
+		logger.Warn("Unable to find the instance the volume is attached to, not recovering attach conflict", zap.String("VolumeID", volumeID), ZapError(lookupErr))
+		return nil, err
+	}
+	if oldInstanceID == attachRequest.InstanceID {
+		logger.Info("Volume is already attached to the requested instance", zap.String("VolumeID", volumeID), zap.String("InstanceID", oldInstanceID))
+		return sess.GetVolumeAttachment(attachRequest)
+	}
+
+	stale, staleErr := recovery.IsStale(oldInstanceID)
+	if staleErr != nil || !stale {
+		logger.Warn("Volume is attached to a live instance, not recovering attach conflict", zap.String("VolumeID", volumeID), zap.String("AttachedInstanceID", oldInstanceID), ZapError(staleErr))
+		return nil, err
+	}
+
+	logger.Info("Recovering attach conflict, detaching volume from stale instance", zap.String("VolumeID", volumeID), zap.String("StaleInstanceID", oldInstanceID), zap.String("InstanceID", attachRequest.InstanceID))
+	detachRequest := provider.VolumeAttachmentRequest{VolumeID: volumeID, InstanceID: oldInstanceID}
+	if detachErr := ForceDetach(sess, detachRequest, logger); detachErr != nil {
+		logger.Error("Failed to detach volume from stale instance", zap.String("VolumeID", volumeID), zap.String("StaleInstanceID", oldInstanceID), ZapError(detachErr))
+		return nil, NewError(reasoncode.ErrorVolumeAttachConflict, provider.ErrAttachConflict.Error(), err, detachErr)
+	}
+	if waitErr := DetectDeletedInstance(sess.WaitForDetachVolume(detachRequest)); waitErr != nil && !errors.Is(waitErr, provider.ErrInstanceNotFound) {
+		logger.Error("Volume detach from stale instance did not complete", zap.String("VolumeID", volumeID), zap.String("StaleInstanceID", oldInstanceID), ZapError(waitErr))
+		return nil, NewError(reasoncode.ErrorVolumeAttachConflict, provider.ErrAttachConflict.Error(), err, waitErr)
+	}
+	return sess.AttachVolume(attachRequest)
+}
@@ -0,0 +1,92 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestDetectAttachConflict(t *testing.T) {
+	assert.Nil(t, DetectAttachConflict(nil))
+	other := errors.New("quota exceeded")
+	assert.Equal(t, other, DetectAttachConflict(other))
+
+	err := DetectAttachConflict(errors.New("The volume is already attached to an instance"))
+	assert.True(t, errors.Is(err, provider.ErrAttachConflict))
+	assert.Equal(t, err, DetectAttachConflict(err))
+}
+
+func TestAttachVolumeWithConflictRecovery(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "ins-new"}
+	conflict := errors.New("volume is attached to another instance")
+	stale := map[string]bool{"ins-old": true}
+	recovery := func(attachedTo string) AttachConflictRecovery {
+		return AttachConflictRecovery{
+			Enabled:          true,
+			AttachedInstance: func(volumeID string) (string, error) { return attachedTo, nil },
+			IsStale:          func(instanceID string) (bool, error) { return stale[instanceID], nil },
+		}
+	}
+
+	testCases := []struct {
+		testcasename  string
+		recovery      AttachConflictRecovery
+		detachErr     error
+		expectErr     bool
+		expectDetach  int
+		expectAttach  int
+		expectGetCall int
+	}{
+		{testcasename: "Recovery disabled", recovery: AttachConflictRecovery{}, expectErr: true, expectAttach: 1},
+		{testcasename: "Stale instance", recovery: recovery("ins-old"), expectDetach: 1, expectAttach: 2},
+		{testcasename: "Live instance", recovery: recovery("ins-live"), expectErr: true, expectAttach: 1},
+		{testcasename: "Already attached to requested instance", recovery: recovery("ins-new"), expectAttach: 1, expectGetCall: 1},
+		{testcasename: "Detach failed", recovery: recovery("ins-old"), detachErr: errors.New("detach failed"), expectErr: true, expectDetach: 1, expectAttach: 1},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			sess := &fake.FakeSession{}
+			sess.AttachVolumeReturnsOnCall(0, nil, conflict)
+			sess.AttachVolumeReturnsOnCall(1, &provider.VolumeAttachmentResponse{Status: "attaching"}, nil)
+			sess.GetVolumeAttachmentReturns(&provider.VolumeAttachmentResponse{Status: "attached"}, nil)
+			sess.DetachVolumeReturns(nil, testcase.detachErr)
+
+			response, err := AttachVolumeWithConflictRecovery(sess, request, testcase.recovery, logger)
+			if testcase.expectErr {
+				assert.True(t, errors.Is(err, provider.ErrAttachConflict))
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, response)
+			}
+			assert.Equal(t, testcase.expectDetach, sess.DetachVolumeCallCount())
+			assert.Equal(t, testcase.expectAttach, sess.AttachVolumeCallCount())
+			assert.Equal(t, testcase.expectGetCall, sess.GetVolumeAttachmentCallCount())
+			if testcase.expectDetach > 0 {
+				assert.Equal(t, "ins-old", sess.DetachVolumeArgsForCall(0).InstanceID)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// AttachmentBandwidth returns the bandwidth requested for the attachment, 0 for the instance default allocation
+func AttachmentBandwidth(request provider.VolumeAttachmentRequest) int64 {
+	if request.VPCVolumeAttachment == nil {
+		return 0
+	}
+	return request.VPCVolumeAttachment.Bandwidth
+}
+
+// ValidateAttachmentBandwidth returns an ErrorUnsupportedFeature error if the request allocates attachment
+// bandwidth but the capabilities do not support it, and an ErrorBadRequest error if the bandwidth is out of range
+func ValidateAttachmentBandwidth(request provider.VolumeAttachmentRequest, capabilities *provider.Capabilities) error {
+	bandwidth := AttachmentBandwidth(request)
+	if bandwidth == 0 {
+		return nil
+	}
+	if bandwidth < 0 {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid attachment bandwidth %d, it must be positive", bandwidth))
+	}
+	if !capabilities.HasFeature(provider.FeatureAttachmentBandwidth) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Attachment bandwidth allocation is not supported for volume %s", request.VolumeID))
+	}
+	if max, ok := capabilities.Limits[provider.LimitMaxAttachmentBandwidth]; ok && bandwidth > max {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Attachment bandwidth %d exceeds the maximum of %d", bandwidth, max))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAttachmentBandwidth(t *testing.T) {
+	supported := &provider.Capabilities{
+		Features: map[string]bool{provider.FeatureAttachmentBandwidth: true},
+		Limits:   map[string]int64{provider.LimitMaxAttachmentBandwidth: 4000},
+	}
+	request := func(bandwidth int64) provider.VolumeAttachmentRequest {
+		return provider.VolumeAttachmentRequest{VolumeID: "vol-1", VPCVolumeAttachment: &provider.VolumeAttachment{Bandwidth: bandwidth}}
+	}
+
+	testCases := []struct {
+		testcasename string
+		request      provider.VolumeAttachmentRequest
+		capabilities *provider.Capabilities
+		reasonCode   reasoncode.ReasonCode
+	}{
+		{testcasename: "Default allocation", request: provider.VolumeAttachmentRequest{}},
+		{testcasename: "Default allocation unsupported", request: request(0)},
+		{testcasename: "Supported", request: request(1000), capabilities: supported},
+		{testcasename: "Unsupported", request: request(1000), reasonCode: reasoncode.ErrorUnsupportedFeature},
+		{testcasename: "Exceeds maximum", request: request(8000), capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+		{testcasename: "Negative", request: request(-1), capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateAttachmentBandwidth(testcase.request, testcase.capabilities)
+			if testcase.reasonCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.reasonCode, ErrorReasonCode(err))
+			}
+		})
+	}
+	assert.Equal(t, int64(1000), AttachmentBandwidth(request(1000)))
+}
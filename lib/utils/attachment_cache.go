@@ -0,0 +1,387 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultAttachmentCacheTTL is the default lifetime of the attachments of an AttachmentCache
+	DefaultAttachmentCacheTTL = 5 * time.Second
+
+	// DefaultAttachmentCacheRetention is the default age after which the ConfigMap backend prunes the records
+	DefaultAttachmentCacheRetention = 10 * time.Minute
+
+	// MaxConfigMapUpdateAttempts is the number of updates of the ConfigMap backend before giving up on conflicts
+	MaxConfigMapUpdateAttempts = 5
+
+	// AttachmentCacheName is the cache name of the AttachmentCache lookups recorded by the StatsCollector
+	AttachmentCacheName = "attachment"
+)
+
+// ErrConfigMapConflict is returned by a ConfigMapClient updating a ConfigMap changed since it was read
+var ErrConfigMapConflict = errors.New("configmap was updated concurrently")
+
+// AttachmentCacheRecord is the attachment state of a volume and instance, as fetched at FetchedAt. A record without
+// attachment is an invalidated entry
+type AttachmentCacheRecord struct {
+	Attachment *provider.VolumeAttachmentResponse `json:"attachment,omitempty"`
+	FetchedAt  time.Time                          `json:"fetchedAt"`
+}
+
+// newerThan tells if the record was fetched after the other one (or the other one is missing)
+func (r AttachmentCacheRecord) newerThan(other *AttachmentCacheRecord) bool {
+	return other == nil || r.FetchedAt.After(other.FetchedAt)
+}
+
+// AttachmentCacheBackend stores the records of an AttachmentCache, it is shared by the components (e.g. controller
+// and node plugins) polling the same attachments. Store never replaces a record with an older one, so concurrent
+// updates converge to the latest observed state whatever their order
+type AttachmentCacheBackend interface {
+	// Load returns the record of the key, nil if missing
+	Load(ctx context.Context, key string) (*AttachmentCacheRecord, error)
+
+	// Store saves the record of the key, unless the stored record is newer
+	Store(ctx context.Context, key string, record AttachmentCacheRecord) error
+}
+
+// AttachmentCacheKey returns the backend key of the attachment of the volume to the instance, made of the characters
+// allowed in file names and ConfigMap keys. The IDs are escaped, so distinct IDs never share a key
+func AttachmentCacheKey(volumeID, instanceID string) string {
+	return escapeCacheKey(volumeID) + "." + escapeCacheKey(instanceID)
+}
+
+// escapeCacheKey returns the ID with the bytes other than letters, digits and '-' escaped as '_' and their two
+// hexadecimal digits, e.g. "a.b" is "a_2eb" and "a_b" is "a_5fb"
+func escapeCacheKey(id string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if c == '-' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			escaped.WriteByte(c)
+		} else {
+			fmt.Fprintf(&escaped, "_%02x", c)
+		}
+	}
+	return escaped.String()
+}
+
+// MemoryAttachmentCacheBackend shares the attachment records between the components of a process
+type MemoryAttachmentCacheBackend struct {
+	mu      sync.Mutex
+	records map[string]AttachmentCacheRecord
+}
+
+// NewMemoryAttachmentCacheBackend returns an empty MemoryAttachmentCacheBackend
+func NewMemoryAttachmentCacheBackend() *MemoryAttachmentCacheBackend {
+	return &MemoryAttachmentCacheBackend{records: make(map[string]AttachmentCacheRecord)}
+}
+
+// Load ...
+func (b *MemoryAttachmentCacheBackend) Load(ctx context.Context, key string) (*AttachmentCacheRecord, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	record, found := b.records[key]
+	if !found {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// Store ...
+func (b *MemoryAttachmentCacheBackend) Store(ctx context.Context, key string, record AttachmentCacheRecord) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if stored, found := b.records[key]; found && !record.newerThan(&stored) {
+		return nil
+	}
+	b.records[key] = record
+	return nil
+}
+
+// FileAttachmentCacheBackend shares the attachment records through a directory, e.g. a hostPath volume mounted by
+// the components of a node. Each update is written to its own file named after its fetch time, so concurrent
+// writers never overwrite each other: Load returns the newest record and removes the older ones
+type FileAttachmentCacheBackend struct {
+	dir string
+}
+
+// NewFileAttachmentCacheBackend returns a FileAttachmentCacheBackend of the directory, created if missing
+func NewFileAttachmentCacheBackend(dir string) (*FileAttachmentCacheBackend, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileAttachmentCacheBackend{dir: dir}, nil
+}
+
+// versions returns the files of the key per fetch time (unix nanoseconds) and the newest fetch time
+func (b *FileAttachmentCacheBackend) versions(key string) (map[int64]string, int64, error) {
+	paths, err := filepath.Glob(filepath.Join(b.dir, key+".*.json"))
+	if err != nil {
+		return nil, 0, err
+	}
+	versions := make(map[int64]string, len(paths))
+	newest := int64(-1)
+	for _, path := range paths {
+		version, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), key+"."), ".json"), 10, 64)
+		if err != nil {
+			// not a record of the key, e.g. a temporary file
+			continue
+		}
+		versions[version] = path
+		if version > newest {
+			newest = version
+		}
+	}
+	return versions, newest, nil
+}
+
+// Load ...
+func (b *FileAttachmentCacheBackend) Load(ctx context.Context, key string) (*AttachmentCacheRecord, error) {
+	versions, newest, err := b.versions(key)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	data, err := os.ReadFile(versions[newest])
+	if err != nil {
+		if os.IsNotExist(err) {
+			// pruned by a concurrent Load after a newer Store
+			return b.Load(ctx, key)
+		}
+		return nil, err
+	}
+	record := &AttachmentCacheRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("corrupted attachment cache record %s: %v", versions[newest], err)
+	}
+	for version, path := range versions {
+		if version < newest {
+			_ = os.Remove(path)
+		}
+	}
+	return record, nil
+}
+
+// Store ...
+func (b *FileAttachmentCacheBackend) Store(ctx context.Context, key string, record AttachmentCacheRecord) error {
+	_, newest, err := b.versions(key)
+	if err != nil {
+		return err
+	}
+	version := record.FetchedAt.UnixNano()
+	if version <= newest {
+		return nil
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(b.dir, key+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(b.dir, fmt.Sprintf("%s.%d.json", key, version)))
+}
+
+// ConfigMapClient reads and updates a Kubernetes ConfigMap, e.g. with the client-go CoreV1 ConfigMaps of the
+// namespace of the driver
+type ConfigMapClient interface {
+	// Get returns the data and resource version of the ConfigMap, nil data and an empty version if missing
+	Get(ctx context.Context, name string) (data map[string]string, resourceVersion string, err error)
+
+	// Update replaces the data of the ConfigMap of the resource version, or creates it for an empty version. It
+	// returns ErrConfigMapConflict if the ConfigMap was changed (or created) since this version was read
+	Update(ctx context.Context, name string, data map[string]string, resourceVersion string) error
+}
+
+// ConfigMapAttachmentCacheBackend shares the attachment records through a ConfigMap, between the controller and
+// the node plugins of a cluster. The updates are optimistic: a conflicting update is merged with the current
+// content and retried. Records older than the retention are pruned on update, to bound the ConfigMap size
+type ConfigMapAttachmentCacheBackend struct {
+	client    ConfigMapClient
+	name      string
+	retention time.Duration
+}
+
+// NewConfigMapAttachmentCacheBackend returns a ConfigMapAttachmentCacheBackend of the named ConfigMap,
+// DefaultAttachmentCacheRetention is used for a non-positive retention
+func NewConfigMapAttachmentCacheBackend(client ConfigMapClient, name string, retention time.Duration) *ConfigMapAttachmentCacheBackend {
+	if retention <= 0 {
+		retention = DefaultAttachmentCacheRetention
+	}
+	return &ConfigMapAttachmentCacheBackend{client: client, name: name, retention: retention}
+}
+
+func decodeAttachmentCacheRecord(data map[string]string, key string) (*AttachmentCacheRecord, error) {
+	value, found := data[key]
+	if !found {
+		return nil, nil
+	}
+	record := &AttachmentCacheRecord{}
+	if err := json.Unmarshal([]byte(value), record); err != nil {
+		return nil, fmt.Errorf("corrupted attachment cache record %s: %v", key, err)
+	}
+	return record, nil
+}
+
+// Load ...
+func (b *ConfigMapAttachmentCacheBackend) Load(ctx context.Context, key string) (*AttachmentCacheRecord, error) {
+	data, _, err := b.client.Get(ctx, b.name)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAttachmentCacheRecord(data, key)
+}
+
+// Store ...
+func (b *ConfigMapAttachmentCacheBackend) Store(ctx context.Context, key string, record AttachmentCacheRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		data, resourceVersion, err := b.client.Get(ctx, b.name)
+		if err != nil {
+			return err
+		}
+		// a corrupted record is replaced
+		if stored, _ := decodeAttachmentCacheRecord(data, key); !record.newerThan(stored) {
+			return nil
+		}
+		updated := map[string]string{key: string(value)}
+		cutoff := time.Now().Add(-b.retention)
+		for other, otherValue := range data {
+			stored, err := decodeAttachmentCacheRecord(data, other)
+			if other == key || err != nil || stored.FetchedAt.Before(cutoff) {
+				continue
+			}
+			updated[other] = otherValue
+		}
+		err = b.client.Update(ctx, b.name, updated, resourceVersion)
+		if !errors.Is(err, ErrConfigMapConflict) || attempt == MaxConfigMapUpdateAttempts {
+			return err
+		}
+	}
+}
+
+// AttachmentCache is a read through cache of the attachment states of a session, stored in a backend shared with
+// the other components polling the same attachments, so a single GET per TTL reaches the backend instead of one
+// per component. The backend errors are logged and the state is then fetched from the session
+type AttachmentCache struct {
+	sess    provider.ContextSession
+	backend AttachmentCacheBackend
+	ttl     time.Duration
+	stats   *StatsCollector
+	logger  *zap.Logger
+}
+
+// NewAttachmentCache returns an AttachmentCache of the session stored in the backend (a new in-memory backend if
+// nil), DefaultAttachmentCacheTTL is used for a non-positive ttl. The lookups are recorded by stats, if not nil, and
+// the backend errors logged by logger, if not nil
+func NewAttachmentCache(sess provider.Session, backend AttachmentCacheBackend, ttl time.Duration, stats *StatsCollector, logger *zap.Logger) *AttachmentCache {
+	if backend == nil {
+		backend = NewMemoryAttachmentCacheBackend()
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	if ttl <= 0 {
+		ttl = DefaultAttachmentCacheTTL
+	}
+	return &AttachmentCache{
+		sess:    NewContextSession(sess),
+		backend: backend,
+		ttl:     ttl,
+		stats:   stats,
+		logger:  logger,
+	}
+}
+
+// GetVolumeAttachment returns the attachment state, from the cache unless expired, invalidated or WithBypassCache
+// is set. A fetched state is stored for the other components
+func (c *AttachmentCache) GetVolumeAttachment(ctx context.Context, request provider.VolumeAttachmentRequest, options ...CacheOption) (*provider.VolumeAttachmentResponse, error) {
+	opts := cacheOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	key := AttachmentCacheKey(request.VolumeID, request.InstanceID)
+
+	if !opts.bypass {
+		record, err := c.backend.Load(ctx, key)
+		if err != nil {
+			c.logger.Warn("Failed to load the cached attachment", zap.String("key", key), zap.Error(err))
+		}
+		hit := record != nil && record.Attachment != nil && time.Since(record.FetchedAt) < c.ttl
+		c.stats.RecordCacheLookup(AttachmentCacheName, hit)
+		if hit {
+			return record.Attachment, nil
+		}
+	}
+
+	fetchedAt := time.Now()
+	attachment, err := c.sess.GetVolumeAttachmentWithContext(ctx, request)
+	if err != nil {
+		// the cached attachment might no longer exist
+		c.store(ctx, key, AttachmentCacheRecord{FetchedAt: fetchedAt})
+		return nil, err
+	}
+	c.store(ctx, key, AttachmentCacheRecord{Attachment: attachment, FetchedAt: fetchedAt})
+	return attachment, nil
+}
+
+// Observe stores an attachment state returned by another call, e.g. the attach or wait for attach of the
+// controller, so the node plugins do not fetch it again
+func (c *AttachmentCache) Observe(ctx context.Context, attachment *provider.VolumeAttachmentResponse) {
+	if attachment == nil {
+		return
+	}
+	c.store(ctx, AttachmentCacheKey(attachment.VolumeID, attachment.InstanceID), AttachmentCacheRecord{Attachment: attachment, FetchedAt: time.Now()})
+}
+
+// Invalidate removes the cached attachment of the volume to the instance, e.g. after a detach, for all the
+// components sharing the backend
+func (c *AttachmentCache) Invalidate(ctx context.Context, volumeID, instanceID string) {
+	c.store(ctx, AttachmentCacheKey(volumeID, instanceID), AttachmentCacheRecord{FetchedAt: time.Now()})
+}
+
+func (c *AttachmentCache) store(ctx context.Context, key string, record AttachmentCacheRecord) {
+	if err := c.backend.Store(ctx, key, record); err != nil {
+		c.logger.Warn("Failed to store the cached attachment", zap.String("key", key), zap.Error(err))
+	}
+}
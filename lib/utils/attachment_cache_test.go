@@ -0,0 +1,253 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func attachmentRecord(status string, fetchedAt time.Time) AttachmentCacheRecord {
+	return AttachmentCacheRecord{
+		Attachment: &provider.VolumeAttachmentResponse{
+			VolumeAttachmentRequest: provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"},
+			Status:                  status,
+		},
+		FetchedAt: fetchedAt,
+	}
+}
+
+// fakeConfigMapClient is a ConfigMapClient with resource versions, failing the first conflicts updates
+type fakeConfigMapClient struct {
+	mu        sync.Mutex
+	data      map[string]string
+	version   int
+	conflicts int
+	updates   int
+}
+
+func (c *fakeConfigMapClient) Get(ctx context.Context, name string) (map[string]string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data == nil {
+		return nil, "", nil
+	}
+	data := make(map[string]string, len(c.data))
+	for key, value := range c.data {
+		data[key] = value
+	}
+	return data, strconv.Itoa(c.version), nil
+}
+
+func (c *fakeConfigMapClient) Update(ctx context.Context, name string, data map[string]string, resourceVersion string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.updates++
+	if c.conflicts > 0 {
+		c.conflicts--
+		c.version++
+		return ErrConfigMapConflict
+	}
+	if (c.data == nil && resourceVersion != "") || (c.data != nil && resourceVersion != strconv.Itoa(c.version)) {
+		return ErrConfigMapConflict
+	}
+	c.data = data
+	c.version++
+	return nil
+}
+
+func TestAttachmentCacheKey(t *testing.T) {
+	assert.Equal(t, "r006-vol.0727_5finst", AttachmentCacheKey("r006-vol", "0727_inst"))
+	assert.Equal(t, "a_2fb.c_2ed", AttachmentCacheKey("a/b", "c.d"))
+	assert.NotEqual(t, AttachmentCacheKey("a.b", "c"), AttachmentCacheKey("a_b", "c"))
+}
+
+func TestAttachmentCacheBackends(t *testing.T) {
+	fileBackend, err := NewFileAttachmentCacheBackend(filepath.Join(t.TempDir(), "attachments"))
+	assert.Nil(t, err)
+	backends := map[string]AttachmentCacheBackend{
+		"Memory":    NewMemoryAttachmentCacheBackend(),
+		"File":      fileBackend,
+		"ConfigMap": NewConfigMapAttachmentCacheBackend(&fakeConfigMapClient{}, "attachments", 0),
+	}
+	now := time.Now()
+	ctx := context.Background()
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			record, err := backend.Load(ctx, "vol-1.inst-1")
+			assert.Nil(t, err)
+			assert.Nil(t, record)
+
+			// the newest record wins whatever the order of the updates
+			assert.Nil(t, backend.Store(ctx, "vol-1.inst-1", attachmentRecord("attached", now)))
+			assert.Nil(t, backend.Store(ctx, "vol-1.inst-1", attachmentRecord("attaching", now.Add(-time.Second))))
+			record, err = backend.Load(ctx, "vol-1.inst-1")
+			assert.Nil(t, err)
+			assert.Equal(t, "attached", record.Attachment.Status)
+			assert.True(t, now.Equal(record.FetchedAt))
+
+			assert.Nil(t, backend.Store(ctx, "vol-1.inst-1", AttachmentCacheRecord{FetchedAt: now.Add(time.Second)}))
+			record, err = backend.Load(ctx, "vol-1.inst-1")
+			assert.Nil(t, err)
+			assert.Nil(t, record.Attachment)
+
+			record, err = backend.Load(ctx, "vol-2.inst-1")
+			assert.Nil(t, err)
+			assert.Nil(t, record)
+		})
+	}
+}
+
+func TestFileAttachmentCacheBackendPrunes(t *testing.T) {
+	dir := t.TempDir()
+	backend, err := NewFileAttachmentCacheBackend(dir)
+	assert.Nil(t, err)
+	ctx := context.Background()
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, backend.Store(ctx, "vol-1.inst-1", attachmentRecord("attached", now.Add(time.Duration(i)*time.Second))))
+	}
+	assert.Nil(t, backend.Store(ctx, "vol-1.inst-2", attachmentRecord("attached", now)))
+	files, _ := os.ReadDir(dir)
+	assert.Equal(t, 4, len(files))
+
+	_, err = backend.Load(ctx, "vol-1.inst-1")
+	assert.Nil(t, err)
+	files, _ = os.ReadDir(dir)
+	assert.Equal(t, 2, len(files))
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "vol-1.inst-2."+strconv.FormatInt(now.Add(time.Minute).UnixNano(), 10)+".json"), []byte("{"), 0600))
+	_, err = backend.Load(ctx, "vol-1.inst-2")
+	assert.NotNil(t, err)
+}
+
+func TestConfigMapAttachmentCacheBackend(t *testing.T) {
+	client := &fakeConfigMapClient{conflicts: 2}
+	backend := NewConfigMapAttachmentCacheBackend(client, "attachments", time.Minute)
+	ctx := context.Background()
+	now := time.Now()
+
+	assert.Nil(t, backend.Store(ctx, "vol-1.inst-1", attachmentRecord("attached", now)))
+	assert.Equal(t, 3, client.updates)
+	record, err := backend.Load(ctx, "vol-1.inst-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "attached", record.Attachment.Status)
+
+	// old records are pruned on update
+	assert.Nil(t, backend.Store(ctx, "vol-2.inst-1", attachmentRecord("attached", now.Add(-time.Hour))))
+	assert.Nil(t, backend.Store(ctx, "vol-3.inst-1", attachmentRecord("attached", now)))
+	assert.Equal(t, 2, len(client.data))
+
+	client.conflicts = MaxConfigMapUpdateAttempts
+	assert.True(t, errors.Is(backend.Store(ctx, "vol-1.inst-1", attachmentRecord("attached", now.Add(time.Second))), ErrConfigMapConflict))
+}
+
+// failingAttachmentCacheBackend is an AttachmentCacheBackend failing all the calls
+type failingAttachmentCacheBackend struct{}
+
+func (failingAttachmentCacheBackend) Load(ctx context.Context, key string) (*AttachmentCacheRecord, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (failingAttachmentCacheBackend) Store(ctx context.Context, key string, record AttachmentCacheRecord) error {
+	return errors.New("unavailable")
+}
+
+func TestAttachmentCache(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx := context.Background()
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"}
+	attachment := &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request, Status: "attached"}
+
+	controllerSess := &fake.FakeSession{}
+	controllerSess.GetVolumeAttachmentReturns(attachment, nil)
+	nodeSess := &fake.FakeSession{}
+	nodeSess.GetVolumeAttachmentReturns(attachment, nil)
+	backend := NewMemoryAttachmentCacheBackend()
+	stats := NewStatsCollector()
+	controller := NewAttachmentCache(controllerSess, backend, time.Minute, stats, logger)
+	node := NewAttachmentCache(nodeSess, backend, time.Minute, stats, logger)
+
+	for i := 0; i < 3; i++ {
+		fetched, err := controller.GetVolumeAttachment(ctx, request)
+		assert.Nil(t, err)
+		assert.Equal(t, "attached", fetched.Status)
+		fetched, err = node.GetVolumeAttachment(ctx, request)
+		assert.Nil(t, err)
+		assert.Equal(t, "attached", fetched.Status)
+	}
+	assert.Equal(t, 1, controllerSess.GetVolumeAttachmentCallCount())
+	assert.Equal(t, 0, nodeSess.GetVolumeAttachmentCallCount())
+
+	_, err := node.GetVolumeAttachment(ctx, request, WithBypassCache())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, nodeSess.GetVolumeAttachmentCallCount())
+
+	controller.Invalidate(ctx, "vol-1", "inst-1")
+	_, _ = node.GetVolumeAttachment(ctx, request)
+	assert.Equal(t, 2, nodeSess.GetVolumeAttachmentCallCount())
+
+	// a failed lookup invalidates the attachment
+	nodeSess.GetVolumeAttachmentReturns(nil, errors.New("not found"))
+	_, err = node.GetVolumeAttachment(ctx, request, WithBypassCache())
+	assert.NotNil(t, err)
+	_, err = controller.GetVolumeAttachment(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, controllerSess.GetVolumeAttachmentCallCount())
+
+	detaching := &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request, Status: "detaching"}
+	controller.Observe(ctx, detaching)
+	fetched, err := node.GetVolumeAttachment(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, "detaching", fetched.Status)
+}
+
+func TestAttachmentCacheExpiryAndBackendFailure(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx := context.Background()
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"}
+	sess := &fake.FakeSession{}
+	sess.GetVolumeAttachmentReturns(&provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request}, nil)
+
+	cache := NewAttachmentCache(sess, nil, time.Millisecond, nil, logger)
+	_, _ = cache.GetVolumeAttachment(ctx, request)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = cache.GetVolumeAttachment(ctx, request)
+	assert.Equal(t, 2, sess.GetVolumeAttachmentCallCount())
+
+	failing := NewAttachmentCache(sess, failingAttachmentCacheBackend{}, 0, nil, logger)
+	_, err := failing.GetVolumeAttachment(ctx, request)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, sess.GetVolumeAttachmentCallCount())
+
+	// the backend errors are not logged without logger
+	failing = NewAttachmentCache(sess, failingAttachmentCacheBackend{}, 0, nil, nil)
+	_, err = failing.GetVolumeAttachment(ctx, request)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// AttachmentTarget returns the target type of the attachment request, AttachmentTargetInstance if not set
+func AttachmentTarget(request provider.VolumeAttachmentRequest) provider.AttachmentTargetType {
+	if request.TargetType == "" {
+		return provider.AttachmentTargetInstance
+	}
+	return request.TargetType
+}
+
+// AttachmentPath returns the VPC API path of the volume attachments of the request target
+func AttachmentPath(request provider.VolumeAttachmentRequest) string {
+	if AttachmentTarget(request) == provider.AttachmentTargetBareMetalServer {
+		return fmt.Sprintf("/bare_metal_servers/%s/volume_attachments", request.InstanceID)
+	}
+	return fmt.Sprintf("/instances/%s/volume_attachments", request.InstanceID)
+}
+
+// ValidateAttachmentTarget validates the attachment request against the constraints of its target type.
+// Bare metal attachments must be enabled in the capabilities and do not support bandwidth allocation nor
+// deleting the volume with the server
+func ValidateAttachmentTarget(request provider.VolumeAttachmentRequest, capabilities *provider.Capabilities) error {
+	switch AttachmentTarget(request) {
+	case provider.AttachmentTargetInstance:
+		return nil
+	case provider.AttachmentTargetBareMetalServer:
+		if !capabilities.HasFeature(provider.FeatureBareMetalAttachment) {
+			return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Attaching volume %s to bare metal servers is not supported", request.VolumeID))
+		}
+		if attachment := request.VPCVolumeAttachment; attachment != nil {
+			if attachment.Bandwidth != 0 {
+				return NewError(reasoncode.ErrorBadRequest, "Attachment bandwidth allocation is not supported for bare metal servers")
+			}
+			if attachment.DeleteVolumeOnInstanceDelete {
+				return NewError(reasoncode.ErrorBadRequest, "Deleting the volume with the bare metal server is not supported")
+			}
+		}
+		return nil
+	default:
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Unknown attachment target type %s", request.TargetType))
+	}
+}
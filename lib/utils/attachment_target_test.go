@@ -0,0 +1,67 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentPath(t *testing.T) {
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "server-1"}
+	assert.Equal(t, provider.AttachmentTargetInstance, AttachmentTarget(request))
+	assert.Equal(t, "/instances/server-1/volume_attachments", AttachmentPath(request))
+
+	request.TargetType = provider.AttachmentTargetBareMetalServer
+	assert.Equal(t, "/bare_metal_servers/server-1/volume_attachments", AttachmentPath(request))
+}
+
+func TestValidateAttachmentTarget(t *testing.T) {
+	supported := &provider.Capabilities{Features: map[string]bool{provider.FeatureBareMetalAttachment: true}}
+	bareMetal := func(attachment *provider.VolumeAttachment) provider.VolumeAttachmentRequest {
+		return provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "server-1", TargetType: provider.AttachmentTargetBareMetalServer, VPCVolumeAttachment: attachment}
+	}
+
+	testCases := []struct {
+		testcasename string
+		request      provider.VolumeAttachmentRequest
+		capabilities *provider.Capabilities
+		reasonCode   reasoncode.ReasonCode
+	}{
+		{testcasename: "Instance", request: provider.VolumeAttachmentRequest{VolumeID: "vol-1"}},
+		{testcasename: "Bare metal supported", request: bareMetal(nil), capabilities: supported},
+		{testcasename: "Bare metal unsupported", request: bareMetal(nil), reasonCode: reasoncode.ErrorUnsupportedFeature},
+		{testcasename: "Bare metal bandwidth", request: bareMetal(&provider.VolumeAttachment{Bandwidth: 1000}), capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+		{testcasename: "Bare metal delete with server", request: bareMetal(&provider.VolumeAttachment{DeleteVolumeOnInstanceDelete: true}), capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+		{testcasename: "Unknown target", request: provider.VolumeAttachmentRequest{TargetType: "mainframe"}, reasonCode: reasoncode.ErrorBadRequest},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateAttachmentTarget(testcase.request, testcase.capabilities)
+			if testcase.reasonCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.reasonCode, ErrorReasonCode(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// TraceIDProperty is the error property carrying the backend trace ID of the failed operation
+const TraceIDProperty = "TraceID"
+
+// AttachmentTraceLogger returns the logger annotated with the trace ID of the attach response, if any
+func AttachmentTraceLogger(logger *zap.Logger, response *provider.VolumeAttachmentResponse) *zap.Logger {
+	if response == nil || response.TraceID == "" {
+		return logger
+	}
+	return logger.With(zap.String(TraceIDProperty, response.TraceID))
+}
+
+// AttachVolumeAndWait attaches the volume and waits for the attachment. The backend trace ID of the attach is
+// logged by the waiter, kept in the returned response and added to the properties of a waiter error, so a
+// support ticket can reference a single ID from the driver log to the backend audit trail
+func AttachVolumeAndWait(sess provider.VolumeAttachManager, attachRequest provider.VolumeAttachmentRequest, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	return attachVolumeAndWait(attachRequest, logger, sess.AttachVolume, sess.WaitForAttachVolume)
+}
+
+// AttachVolumeAndWaitWithContext is AttachVolumeAndWait with the context variants of the session if it
+// implements provider.ContextVolumeAttachManager, so the attach and the wait stop when the context is done
+func AttachVolumeAndWaitWithContext(ctx context.Context, sess provider.VolumeAttachManager, attachRequest provider.VolumeAttachmentRequest, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	csess, isContextSession := sess.(provider.ContextVolumeAttachManager)
+	if !isContextSession {
+		return AttachVolumeAndWait(sess, attachRequest, logger)
+	}
+	attach := func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		return csess.AttachVolumeWithContext(ctx, request)
+	}
+	wait := func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		return csess.WaitForAttachVolumeWithContext(ctx, request)
+	}
+	return attachVolumeAndWait(attachRequest, logger, attach, wait)
+}
+
+type attachFunc func(attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error)
+
+func attachVolumeAndWait(attachRequest provider.VolumeAttachmentRequest, logger *zap.Logger, attach, wait attachFunc) (*provider.VolumeAttachmentResponse, error) {
+	attached, err := attach(attachRequest)
+	if err != nil {
+		return nil, err
+	}
+	traceLogger := AttachmentTraceLogger(logger, attached)
+	traceLogger.Info("Waiting for volume attachment", zap.String("VolumeID", attachRequest.VolumeID), zap.String("InstanceID", attachRequest.InstanceID))
+
+	response, err := wait(attachRequest)
+	if err != nil {
+		traceLogger.Error("Volume attachment did not complete", zap.String("VolumeID", attachRequest.VolumeID), zap.String("InstanceID", attachRequest.InstanceID), ZapError(err))
+		return nil, withTraceID(err, attached)
+	}
+	if response != nil && attached != nil && response.TraceID == "" {
+		response.TraceID = attached.TraceID
+	}
+	traceLogger.Info("Volume attached", zap.String("VolumeID", attachRequest.VolumeID), zap.String("InstanceID", attachRequest.InstanceID))
+	return response, nil
+}
+
+// withTraceID adds the trace ID of the response to the properties of a provider error
+func withTraceID(err error, response *provider.VolumeAttachmentResponse) error {
+	pErr, isPerr := err.(provider.Error)
+	if !isPerr || response == nil || response.TraceID == "" {
+		return err
+	}
+	properties := make(map[string]string, len(pErr.Fault.Properties)+1)
+	for k, v := range pErr.Fault.Properties {
+		properties[k] = v
+	}
+	properties[TraceIDProperty] = response.TraceID
+	pErr.Fault.Properties = properties
+	return pErr
+}
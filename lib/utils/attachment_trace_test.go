@@ -0,0 +1,56 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAttachVolumeAndWait(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "i-1"}
+
+	sess := &fake.FakeSession{}
+	sess.AttachVolumeReturns(&provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request, TraceID: "trace-1"}, nil)
+	sess.WaitForAttachVolumeReturns(&provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request, Status: "attached"}, nil)
+
+	response, err := AttachVolumeAndWait(sess, request, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "trace-1", response.TraceID)
+	assert.Equal(t, 2, logs.FilterField(zap.String(TraceIDProperty, "trace-1")).Len())
+
+	sess.WaitForAttachVolumeReturns(nil, NewError(reasoncode.ErrorVolumeAttachFailed, "timed out"))
+	_, err = AttachVolumeAndWait(sess, request, logger)
+	assert.Equal(t, "trace-1", ErrorToFault(err).Properties[TraceIDProperty])
+
+	sess.AttachVolumeReturns(nil, errors.New("attach failed"))
+	_, err = AttachVolumeAndWait(sess, request, logger)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, logger, AttachmentTraceLogger(logger, nil))
+}
@@ -0,0 +1,83 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// instanceNotFoundPatterns are the backend error fragments reported when the instance of an
+// attach/detach request has been deleted
+var instanceNotFoundPatterns = []string{
+	"instance not found",
+	"instance_not_found",
+	"instance does not exist",
+}
+
+// DetectDeletedInstance returns provider.ErrInstanceNotFound (wrapping the original error) if
+// err indicates that the instance no longer exists, otherwise err is returned unchanged
+func DetectDeletedInstance(err error) error {
+	if err == nil || errors.Is(err, provider.ErrInstanceNotFound) {
+		return err
+	}
+	messages := append([]string{err.Error()}, ErrorDeepUnwrapString(err)...)
+	for _, msg := range messages {
+		msg = strings.ToLower(msg)
+		for _, pattern := range instanceNotFoundPatterns {
+			if strings.Contains(msg, pattern) {
+				return NewError(reasoncode.ErrorInstanceNotFound, provider.ErrInstanceNotFound.Error(), err)
+			}
+		}
+	}
+	return err
+}
+
+// ForceDetach detaches the volume from the instance, treating a deleted instance as a successful detach
+func ForceDetach(sess provider.VolumeAttachManager, detachRequest provider.VolumeAttachmentRequest, logger *zap.Logger) error {
+	response, err := sess.DetachVolume(detachRequest)
+	if response != nil && response.Body != nil {
+		_ = response.Body.Close()
+	}
+	if err = DetectDeletedInstance(err); errors.Is(err, provider.ErrInstanceNotFound) {
+		logger.Info("Instance no longer exists, treating volume as detached", zap.String("VolumeID", detachRequest.VolumeID), zap.String("InstanceID", detachRequest.InstanceID))
+		return nil
+	}
+	return err
+}
+
+// CleanupAttachments releases all given volumes from the instance, typically after the instance has been deleted.
+// All volumes are processed, failures are returned as a single error wrapping each detach error
+func CleanupAttachments(sess provider.VolumeAttachManager, instanceID string, volumeIDs []string, logger *zap.Logger) error {
+	var errs []error
+	for _, volumeID := range volumeIDs {
+		err := ForceDetach(sess, provider.VolumeAttachmentRequest{VolumeID: volumeID, InstanceID: instanceID}, logger)
+		if err != nil {
+			logger.Error("Failed to cleanup volume attachment", zap.String("VolumeID", volumeID), zap.String("InstanceID", instanceID), ZapError(err))
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return NewError(reasoncode.ErrorVolumeDetachFailed, "Failed to cleanup volume attachments of instance "+instanceID, errs...)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestDetectDeletedInstance(t *testing.T) {
+	assert.Nil(t, DetectDeletedInstance(nil))
+
+	err := errors.New("some other error")
+	assert.Equal(t, err, DetectDeletedInstance(err))
+
+	err = DetectDeletedInstance(NewError(reasoncode.ErrorVolumeDetachFailed, "detach failed", errors.New("Instance not found")))
+	assert.True(t, errors.Is(err, provider.ErrInstanceNotFound))
+	assert.Equal(t, reasoncode.ErrorInstanceNotFound, ErrorReasonCode(err))
+
+	assert.True(t, errors.Is(DetectDeletedInstance(provider.ErrInstanceNotFound), provider.ErrInstanceNotFound))
+}
+
+func TestCleanupAttachments(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.DetachVolumeReturnsOnCall(0, nil, nil)
+	sess.DetachVolumeReturnsOnCall(1, nil, errors.New("instance_not_found"))
+	sess.DetachVolumeReturnsOnCall(2, nil, errors.New("internal error"))
+
+	err := CleanupAttachments(sess, "instance-1", []string{"vol-1", "vol-2", "vol-3"}, logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, reasoncode.ErrorVolumeDetachFailed, ErrorReasonCode(err))
+	assert.Equal(t, []string{"internal error"}, ErrorDeepUnwrapString(err))
+	assert.Equal(t, 3, sess.DetachVolumeCallCount())
+	assert.Equal(t, "instance-1", sess.DetachVolumeArgsForCall(2).InstanceID)
+
+	assert.Nil(t, CleanupAttachments(sess, "instance-1", []string{"vol-1"}, logger))
+}
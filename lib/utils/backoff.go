@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// DefaultBackoffMultiplier is the multiplier of the exponential backoff configured without multiplier
+const DefaultBackoffMultiplier = 2
+
+// BackoffStrategy shapes the intervals between the retries of a RetryPolicy, from its RetryInterval. The policy
+// caps each interval to its MaxInterval and adds its Jitter
+type BackoffStrategy interface {
+	// Interval returns the interval before the retry (1 for the first retry), previous is the capped interval
+	// before the previous retry (zero for the first retry)
+	Interval(retry int, base, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff waits the base interval between all the retries, e.g. for interactive operations
+type ConstantBackoff struct{}
+
+// Interval ...
+func (ConstantBackoff) Interval(retry int, base, previous time.Duration) time.Duration {
+	return base
+}
+
+// ExponentialBackoff multiplies the interval by Multiplier after each retry, it is constant if Multiplier is 1 or less
+type ExponentialBackoff struct {
+	Multiplier float64
+}
+
+// Interval ...
+func (b ExponentialBackoff) Interval(retry int, base, previous time.Duration) time.Duration {
+	if retry <= 1 || b.Multiplier <= 1 {
+		return base
+	}
+	return scaleDuration(previous, b.Multiplier)
+}
+
+// FibonacciBackoff grows the interval along the Fibonacci sequence (1, 1, 2, 3, 5, 8... times the base), slower
+// than the exponential backoff, e.g. for batch operations retried for long
+type FibonacciBackoff struct{}
+
+// Interval ...
+func (FibonacciBackoff) Interval(retry int, base, previous time.Duration) time.Duration {
+	a, b := 1.0, 1.0
+	for i := 2; i < retry; i++ {
+		a, b = b, a+b
+	}
+	return scaleDuration(base, b)
+}
+
+// DecorrelatedJitterBackoff waits a random interval between the base and three times the previous interval, so the
+// callers failing together spread their retries (see the "decorrelated jitter" of the AWS architecture blog)
+type DecorrelatedJitterBackoff struct{}
+
+// Interval ...
+func (DecorrelatedJitterBackoff) Interval(retry int, base, previous time.Duration) time.Duration {
+	upper := scaleDuration(previous, 3)
+	if upper <= base {
+		return base
+	}
+	return base + time.Duration(retryJitter()*float64(upper-base))
+}
+
+// scaleDuration multiplies the duration, saturating at the maximum duration
+func scaleDuration(d time.Duration, factor float64) time.Duration {
+	scaled := float64(d) * factor
+	if scaled >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(scaled)
+}
+
+// BackoffStrategyByName returns the backoff strategy of the retry_backoff_strategy configuration, the exponential
+// backoff uses the multiplier (DefaultBackoffMultiplier if not set). It returns nil for an empty name, the policy
+// then grows the interval by its Multiplier
+func BackoffStrategyByName(name string, multiplier float64) (BackoffStrategy, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case config.BackoffConstant:
+		return ConstantBackoff{}, nil
+	case config.BackoffExponential:
+		if multiplier == 0 {
+			multiplier = DefaultBackoffMultiplier
+		}
+		return ExponentialBackoff{Multiplier: multiplier}, nil
+	case config.BackoffFibonacci:
+		return FibonacciBackoff{}, nil
+	case config.BackoffDecorrelatedJitter:
+		return DecorrelatedJitterBackoff{}, nil
+	}
+	return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Unknown backoff strategy %s", name))
+}
+
+var backoffStrategyKey = ctxkeys.NewKey[BackoffStrategy]("backoff-strategy")
+
+// WithBackoffStrategy returns a context overriding the backoff strategy of the retry policy of the operations
+// performed with it, the other settings of the policy are kept
+func WithBackoffStrategy(ctx context.Context, strategy BackoffStrategy) context.Context {
+	return backoffStrategyKey.WithValue(ctx, strategy)
+}
+
+// BackoffStrategyFromContext returns the backoff strategy attached to the context, if any
+func BackoffStrategyFromContext(ctx context.Context) (BackoffStrategy, bool) {
+	return backoffStrategyKey.Value(ctx)
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func intervals(policy RetryPolicy, retries int) []time.Duration {
+	result := make([]time.Duration, 0, retries)
+	for retry := 1; retry <= retries; retry++ {
+		result = append(result, policy.Interval(retry))
+	}
+	return result
+}
+
+func TestBackoffStrategies(t *testing.T) {
+	previous := retryJitter
+	defer func() { retryJitter = previous }()
+	retryJitter = func() float64 { return 0.5 }
+
+	testcases := []struct {
+		testcasename string
+		backoff      BackoffStrategy
+		expected     []time.Duration
+	}{
+		{testcasename: "Constant", backoff: ConstantBackoff{}, expected: []time.Duration{time.Second, time.Second, time.Second, time.Second, time.Second, time.Second}},
+		{testcasename: "Exponential", backoff: ExponentialBackoff{Multiplier: 2}, expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}},
+		{testcasename: "Exponential without multiplier", backoff: ExponentialBackoff{}, expected: []time.Duration{time.Second, time.Second, time.Second, time.Second, time.Second, time.Second}},
+		{testcasename: "Fibonacci", backoff: FibonacciBackoff{}, expected: []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 8 * time.Second}},
+		{testcasename: "Decorrelated jitter", backoff: DecorrelatedJitterBackoff{}, expected: []time.Duration{time.Second, 2 * time.Second, 3500 * time.Millisecond, 5750 * time.Millisecond, 9125 * time.Millisecond, 10 * time.Second}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			policy := RetryPolicy{RetryInterval: time.Second, MaxInterval: 10 * time.Second, Backoff: testcase.backoff}
+			assert.Equal(t, testcase.expected, intervals(policy, len(testcase.expected)))
+		})
+	}
+}
+
+func TestBackoffStrategyOverflow(t *testing.T) {
+	policy := RetryPolicy{RetryInterval: time.Hour, Backoff: ExponentialBackoff{Multiplier: 10}}
+	assert.Equal(t, time.Duration(1<<63-1), policy.Interval(100))
+	policy.Backoff = FibonacciBackoff{}
+	assert.Equal(t, time.Duration(1<<63-1), policy.Interval(200))
+}
+
+func TestBackoffStrategyByName(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		name         string
+		multiplier   float64
+		expected     BackoffStrategy
+		expectErr    bool
+	}{
+		{testcasename: "Unset", name: ""},
+		{testcasename: "Constant", name: config.BackoffConstant, expected: ConstantBackoff{}},
+		{testcasename: "Exponential", name: config.BackoffExponential, multiplier: 1.5, expected: ExponentialBackoff{Multiplier: 1.5}},
+		{testcasename: "Exponential without multiplier", name: config.BackoffExponential, expected: ExponentialBackoff{Multiplier: DefaultBackoffMultiplier}},
+		{testcasename: "Fibonacci", name: config.BackoffFibonacci, expected: FibonacciBackoff{}},
+		{testcasename: "Decorrelated jitter", name: config.BackoffDecorrelatedJitter, expected: DecorrelatedJitterBackoff{}},
+		{testcasename: "Unknown", name: "linear", expectErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			backoff, err := BackoffStrategyByName(testcase.name, testcase.multiplier)
+			assert.Equal(t, testcase.expectErr, err != nil)
+			assert.Equal(t, testcase.expected, backoff)
+		})
+	}
+}
+
+func TestRetryPolicyFromConfigBackoffStrategy(t *testing.T) {
+	policy, err := RetryPolicyFromConfig(&config.VPCProviderConfig{MaxRetryAttempt: 3, MaxRetryGap: 1, RetryBackoffStrategy: config.BackoffFibonacci})
+	assert.Nil(t, err)
+	assert.Equal(t, FibonacciBackoff{}, policy.Backoff)
+	assert.Equal(t, 2*time.Second, policy.Interval(3))
+
+	_, err = RetryPolicyFromConfig(&config.VPCProviderConfig{RetryBackoffStrategy: "linear"})
+	assert.NotNil(t, err)
+}
+
+// recordingBackoff records the retries it shapes
+type recordingBackoff struct {
+	retries []int
+}
+
+func (b *recordingBackoff) Interval(retry int, base, previous time.Duration) time.Duration {
+	b.retries = append(b.retries, retry)
+	return time.Millisecond
+}
+
+func TestErrorRetryWithContextBackoffStrategy(t *testing.T) {
+	_, ok := BackoffStrategyFromContext(context.Background())
+	assert.False(t, ok)
+
+	logger, _ := zap.NewDevelopment()
+	retrier := NewErrorRetrier(3, time.Hour, logger)
+	backoff := &recordingBackoff{}
+	ctx := WithBackoffStrategy(context.Background(), backoff)
+	attempts := 0
+	err := retrier.ErrorRetryWithContext(ctx, func() (error, bool) {
+		attempts++
+		return errors.New("failed"), false
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.NotEmpty(t, backoff.retries)
+}
@@ -0,0 +1,75 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+func BenchmarkErrorRetry(b *testing.B) {
+	retrier := NewErrorRetrier(3, 0, zap.NewNop())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = retrier.ErrorRetry(func() (error, bool) { return nil, true })
+	}
+}
+
+func BenchmarkErrorRetryWithContext(b *testing.B) {
+	retrier := NewErrorRetrier(3, 0, zap.NewNop())
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 2})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = retrier.ErrorRetryWithContext(ctx, func() (error, bool) { return nil, true })
+	}
+}
+
+func BenchmarkErrorToFault(b *testing.B) {
+	err := NewError(reasoncode.ErrorRateLimitExceeded, "rate limited", errors.New("429 Too Many Requests"))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ErrorToFault(err)
+	}
+}
+
+func BenchmarkMigrateVolumeHandle(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = MigrateVolumeHandle("r006-1a2b3c4d", provider.VolumeProvider("VPC"))
+	}
+}
+
+func BenchmarkMaskVolume(b *testing.B) {
+	size := 10
+	volume := &provider.Volume{
+		VolumeID: "r006-1a2b3c4d",
+		Capacity: &size,
+	}
+	volume.Tags = []string{"env:prod", "team:storage"}
+	volume.CRN = "crn:v1:bluemix:public:is:us-south-1:a/account::volume:r006-1a2b3c4d"
+	mask := SensitiveFields
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = mask.MaskVolume(volume)
+	}
+}
@@ -0,0 +1,71 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// BlockSizeParameter is the StorageClass parameter selecting the logical sector size of block volumes
+const BlockSizeParameter = "blockSize"
+
+const (
+	// BlockSize512 is the 512 bytes logical sector size (512e)
+	BlockSize512 = 512
+	// BlockSize4K is the 4096 bytes logical sector size (4K native)
+	BlockSize4K = 4096
+)
+
+// ParseBlockSize returns the value of the blockSize StorageClass parameter in bytes, 0 (backend default) if not set.
+// "4k" and "4K" are accepted for 4096
+func ParseBlockSize(parameters map[string]string) (int, error) {
+	value, ok := parameters[BlockSizeParameter]
+	if !ok || value == "" {
+		return 0, nil
+	}
+	if value == "4k" || value == "4K" {
+		return BlockSize4K, nil
+	}
+	blockSize, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid value '%s' for parameter %s", value, BlockSizeParameter), err)
+	}
+	return blockSize, nil
+}
+
+// ValidateBlockSize returns an ErrorBadRequest error if the block size of the volume request is not 512 or 4096
+// and an ErrorUnsupportedFeature error if it is set but the capabilities do not support choosing it
+func ValidateBlockSize(volume provider.Volume, capabilities *provider.Capabilities) error {
+	if volume.BlockSize == 0 {
+		return nil
+	}
+	if volume.BlockSize != BlockSize512 && volume.BlockSize != BlockSize4K {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid block size %d, it must be %d or %d", volume.BlockSize, BlockSize512, BlockSize4K))
+	}
+	if !isBlockVolumeType(volume.VolumeType) {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Block size is only supported for block volumes, not %s", volume.VolumeType))
+	}
+	if !capabilities.HasFeature(provider.FeatureBlockSize) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Block size %d is not supported by the provider", volume.BlockSize))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlockSize(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		parameters   map[string]string
+		expected     int
+		expectedErr  bool
+	}{
+		{testcasename: "not set", parameters: map[string]string{}},
+		{testcasename: "512", parameters: map[string]string{BlockSizeParameter: "512"}, expected: 512},
+		{testcasename: "4k", parameters: map[string]string{BlockSizeParameter: "4k"}, expected: 4096},
+		{testcasename: "4096", parameters: map[string]string{BlockSizeParameter: "4096"}, expected: 4096},
+		{testcasename: "invalid", parameters: map[string]string{BlockSizeParameter: "big"}, expectedErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			blockSize, err := ParseBlockSize(testcase.parameters)
+			assert.Equal(t, testcase.expectedErr, err != nil)
+			assert.Equal(t, testcase.expected, blockSize)
+		})
+	}
+}
+
+func TestValidateBlockSize(t *testing.T) {
+	supported := &provider.Capabilities{Features: map[string]bool{provider.FeatureBlockSize: true}}
+	testcases := []struct {
+		testcasename string
+		volume       provider.Volume
+		capabilities *provider.Capabilities
+		reasonCode   reasoncode.ReasonCode
+	}{
+		{testcasename: "default", volume: provider.Volume{}, capabilities: nil},
+		{testcasename: "4K supported", volume: provider.Volume{BlockSize: 4096, VolumeType: "vpc-block"}, capabilities: supported},
+		{testcasename: "4K unsupported", volume: provider.Volume{BlockSize: 4096}, capabilities: &provider.Capabilities{}, reasonCode: reasoncode.ErrorUnsupportedFeature},
+		{testcasename: "invalid size", volume: provider.Volume{BlockSize: 1024}, capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+		{testcasename: "file volume", volume: provider.Volume{BlockSize: 4096, VolumeType: "vpc-share"}, capabilities: supported, reasonCode: reasoncode.ErrorBadRequest},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateBlockSize(testcase.volume, testcase.capabilities)
+			if testcase.reasonCode == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, testcase.reasonCode, ErrorReasonCode(err))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// BulkAttachment is one attachment of a bulk attach, it starts only once all the attachments it depends on
+// are attached (e.g. a data volume after the boot volume)
+type BulkAttachment struct {
+	// Name identifies the attachment in DependsOn, the volume ID if empty
+	Name string
+	// Request of the attachment
+	Request provider.VolumeAttachmentRequest
+	// DependsOn are the names of the attachments which must be attached first
+	DependsOn []string
+}
+
+// BulkAttachResult is the result of one attachment of a bulk attach
+type BulkAttachResult struct {
+	Name     string
+	Response *provider.VolumeAttachmentResponse
+	Err      error
+	// Skipped is set if the attachment did not start because a dependency failed or the context was done
+	Skipped bool
+}
+
+func (a BulkAttachment) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return a.Request.VolumeID
+}
+
+// attachmentLevels orders the attachments topologically: each level only depends on the previous levels.
+// An error is returned for duplicate names, unknown dependencies and cycles
+func attachmentLevels(attachments []BulkAttachment) ([][]int, error) {
+	index := make(map[string]int, len(attachments))
+	for i, attachment := range attachments {
+		name := attachment.name()
+		if _, duplicate := index[name]; duplicate {
+			return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Duplicate attachment '%s' in bulk attach", name))
+		}
+		index[name] = i
+	}
+
+	pending := make([]int, len(attachments))
+	dependents := make([][]int, len(attachments))
+	for i, attachment := range attachments {
+		for _, dependency := range attachment.DependsOn {
+			j, found := index[dependency]
+			if !found {
+				return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Attachment '%s' depends on unknown attachment '%s'", attachment.name(), dependency))
+			}
+			pending[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	levels := [][]int{}
+	level := []int{}
+	for i := range attachments {
+		if pending[i] == 0 {
+			level = append(level, i)
+		}
+	}
+	ordered := 0
+	for len(level) > 0 {
+		levels = append(levels, level)
+		ordered += len(level)
+		next := []int{}
+		for _, i := range level {
+			for _, dependent := range dependents[i] {
+				if pending[dependent]--; pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Ints(next)
+		level = next
+	}
+	if ordered != len(attachments) {
+		return nil, NewError(reasoncode.ErrorBadRequest, "Bulk attach dependencies contain a cycle")
+	}
+	return levels, nil
+}
+
+// BulkAttachVolumes attaches the volumes in dependency order: attachments without pending dependencies are
+// attached concurrently and waited for, then their dependents start. Dependents of a failed attachment are
+// skipped. The results are in the order of attachments, an error is returned if any attachment failed or was skipped.
+// The context variants of sess are used if it implements provider.ContextVolumeAttachManager (see NewContextSession),
+// the waits then stop at the deadline of ctx
+func BulkAttachVolumes(ctx context.Context, sess provider.VolumeAttachManager, attachments []BulkAttachment, logger *zap.Logger) ([]BulkAttachResult, error) {
+	levels, err := attachmentLevels(attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkAttachResult, len(attachments))
+	failed := make(map[string]bool)
+	for i, attachment := range attachments {
+		results[i].Name = attachment.name()
+	}
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+		for _, i := range level {
+			attachment := attachments[i]
+			if blocked := blockingDependency(attachment, failed); blocked != "" || ctx.Err() != nil {
+				results[i].Skipped = true
+				if blocked != "" {
+					results[i].Err = NewError(reasoncode.ErrorVolumeAttachFailed, fmt.Sprintf("Attachment '%s' skipped, its dependency '%s' failed", attachment.name(), blocked))
+				} else {
+					results[i].Err = ctx.Err()
+				}
+				continue
+			}
+			wg.Add(1)
+			go func(i int, attachment BulkAttachment) {
+				defer wg.Done()
+				results[i].Response, results[i].Err = attachAndWait(ctx, sess, attachment, logger)
+			}(i, attachment)
+		}
+		wg.Wait()
+		for _, i := range level {
+			if results[i].Err != nil {
+				failed[results[i].Name] = true
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, NewError(reasoncode.ErrorVolumeAttachFailed, fmt.Sprintf("%d of %d attachments failed or were skipped", len(failed), len(attachments)))
+	}
+	return results, nil
+}
+
+// blockingDependency returns the first failed dependency of the attachment, empty if none
+func blockingDependency(attachment BulkAttachment, failed map[string]bool) string {
+	for _, dependency := range attachment.DependsOn {
+		if failed[dependency] {
+			return dependency
+		}
+	}
+	return ""
+}
+
+func attachAndWait(ctx context.Context, sess provider.VolumeAttachManager, attachment BulkAttachment, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	response := &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: attachment.Request}
+	err := RunMutation(ctx, "AttachVolume", attachment.Request.VolumeID, map[string]string{"instanceID": attachment.Request.InstanceID}, func() error {
+		var err error
+		// the attach is recorded by RunMutation, not again by a context session
+		response, err = AttachVolumeAndWaitWithContext(WithActivityTracker(ctx, nil), sess, attachment.Request, logger)
+		return err
+	})
+	if err != nil {
+		logger.Error("Bulk attachment failed", zap.String("Attachment", attachment.name()), zap.String("VolumeID", attachment.Request.VolumeID), ZapError(err))
+		return nil, err
+	}
+	AttachmentTraceLogger(logger, response).Info("Bulk attachment attached", zap.String("Attachment", attachment.name()), zap.String("VolumeID", attachment.Request.VolumeID))
+	return response, nil
+}
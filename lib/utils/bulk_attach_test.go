@@ -0,0 +1,114 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func bulkAttachment(name string, dependsOn ...string) BulkAttachment {
+	return BulkAttachment{Name: name, Request: provider.VolumeAttachmentRequest{VolumeID: name, InstanceID: "i-1"}, DependsOn: dependsOn}
+}
+
+func TestAttachmentLevels(t *testing.T) {
+	levels, err := attachmentLevels([]BulkAttachment{bulkAttachment("data", "boot"), bulkAttachment("boot"), bulkAttachment("logs", "boot", "data")})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]int{{1}, {0}, {2}}, levels)
+
+	testcases := []struct {
+		testcasename string
+		attachments  []BulkAttachment
+	}{
+		{testcasename: "duplicate", attachments: []BulkAttachment{bulkAttachment("boot"), bulkAttachment("boot")}},
+		{testcasename: "unknown dependency", attachments: []BulkAttachment{bulkAttachment("data", "boot")}},
+		{testcasename: "cycle", attachments: []BulkAttachment{bulkAttachment("a", "b"), bulkAttachment("b", "a")}},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			_, err := attachmentLevels(testcase.attachments)
+			assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+		})
+	}
+}
+
+func TestBulkAttachVolumes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	var mu sync.Mutex
+	order := []string{}
+	sess := &fake.FakeSession{}
+	sess.AttachVolumeStub = func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, request.VolumeID)
+		if request.VolumeID == "broken" {
+			return nil, errors.New("attach failed")
+		}
+		return &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request}, nil
+	}
+	sess.WaitForAttachVolumeStub = func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		return &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request, Status: "attached"}, nil
+	}
+
+	results, err := BulkAttachVolumes(context.Background(), sess, []BulkAttachment{bulkAttachment("data", "boot"), bulkAttachment("boot")}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"boot", "data"}, order)
+	assert.Equal(t, "attached", results[0].Response.Status)
+
+	order = []string{}
+	results, err = BulkAttachVolumes(context.Background(), sess, []BulkAttachment{bulkAttachment("broken"), bulkAttachment("data", "broken"), bulkAttachment("other")}, logger)
+	assert.Equal(t, reasoncode.ErrorVolumeAttachFailed, ErrorReasonCode(err))
+	assert.NotNil(t, results[0].Err)
+	assert.True(t, results[1].Skipped)
+	assert.Nil(t, results[2].Err)
+	assert.NotContains(t, order, "data")
+
+	_, err = BulkAttachVolumes(context.Background(), sess, []BulkAttachment{bulkAttachment("a", "a")}, logger)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+}
+
+func TestBulkAttachVolumesWithContextSession(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	release := make(chan struct{})
+	defer close(release)
+	sess := &fake.FakeSession{}
+	sess.AttachVolumeStub = func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		return &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: request}, nil
+	}
+	sess.WaitForAttachVolumeStub = func(request provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		<-release
+		return nil, errors.New("released")
+	}
+
+	// the wait of the context session stops at the deadline instead of blocking on the session wait
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results, err := BulkAttachVolumes(ctx, NewContextSession(sess), []BulkAttachment{bulkAttachment("boot")}, logger)
+	assert.Equal(t, reasoncode.ErrorVolumeAttachFailed, ErrorReasonCode(err))
+	assert.True(t, errors.Is(results[0].Err, context.DeadlineExceeded), results[0].Err)
+	assert.Equal(t, 1, sess.AttachVolumeCallCount())
+}
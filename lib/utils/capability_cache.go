@@ -0,0 +1,151 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// DefaultCapabilityRefreshInterval is the default interval of the capability cache refresh
+const DefaultCapabilityRefreshInterval = time.Hour
+
+// CapabilitiesFetcher fetches the current provider capabilities
+type CapabilitiesFetcher func() (*provider.Capabilities, error)
+
+// SessionCapabilitiesFetcher returns the fetcher of the capabilities of the session, see provider.CapabilitiesManager
+func SessionCapabilitiesFetcher(sess provider.CapabilitiesManager) CapabilitiesFetcher {
+	return sess.GetCapabilities
+}
+
+// CapabilitiesChangeHook is called with the previous and current capabilities when they change
+type CapabilitiesChangeHook func(previous, current *provider.Capabilities)
+
+// CapabilityCache caches the provider capabilities and refreshes them in background, so long running
+// drivers pick up newly enabled profiles and features without restart
+type CapabilityCache struct {
+	fetch    CapabilitiesFetcher
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu           sync.RWMutex
+	capabilities *provider.Capabilities
+	hooks        []CapabilitiesChangeHook
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// ParseCapabilityRefreshInterval parses the capability_refresh_interval config value,
+// DefaultCapabilityRefreshInterval is returned for an empty value
+func ParseCapabilityRefreshInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultCapabilityRefreshInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid capability refresh interval '%s'", value)
+	}
+	return interval, nil
+}
+
+// NewCapabilityCache returns a cache refreshed every interval once started,
+// DefaultCapabilityRefreshInterval is used for a non-positive interval
+func NewCapabilityCache(fetch CapabilitiesFetcher, interval time.Duration, logger *zap.Logger) *CapabilityCache {
+	if interval <= 0 {
+		interval = DefaultCapabilityRefreshInterval
+	}
+	return &CapabilityCache{
+		fetch:    fetch,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+}
+
+// OnChange registers a hook called (synchronously, from the refreshing goroutine) when the capabilities change
+func (c *CapabilityCache) OnChange(hook CapabilitiesChangeHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook)
+}
+
+// Get returns the cached capabilities, nil if they were never fetched
+func (c *CapabilityCache) Get() *provider.Capabilities {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.capabilities
+}
+
+// Refresh fetches the capabilities and calls the change hooks if they changed. The cached capabilities
+// are kept if the fetch fails
+func (c *CapabilityCache) Refresh() error {
+	capabilities, err := c.fetch()
+	if err != nil {
+		c.logger.Warn("Failed to refresh provider capabilities, keeping cached capabilities", ZapError(err))
+		return err
+	}
+
+	c.mu.Lock()
+	previous := c.capabilities
+	changed := !previous.Equal(capabilities)
+	c.capabilities = capabilities
+	hooks := append([]CapabilitiesChangeHook(nil), c.hooks...)
+	c.mu.Unlock()
+
+	if changed && previous != nil {
+		c.logger.Info("Provider capabilities changed", zap.Reflect("Previous", previous), zap.Reflect("Current", capabilities))
+		for _, hook := range hooks {
+			hook(previous, capabilities)
+		}
+	}
+	return nil
+}
+
+// Start fetches the capabilities and begins the background refresh. The error of the initial fetch is
+// returned, the background refresh is started regardless
+func (c *CapabilityCache) Start() error {
+	err := c.Refresh()
+	c.startOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(c.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					_ = c.Refresh()
+				case <-c.stop:
+					return
+				}
+			}
+		}()
+	})
+	return err
+}
+
+// Stop stops the background refresh
+func (c *CapabilityCache) Stop() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
@@ -0,0 +1,119 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestParseCapabilityRefreshInterval(t *testing.T) {
+	interval, err := ParseCapabilityRefreshInterval("")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultCapabilityRefreshInterval, interval)
+
+	interval, err = ParseCapabilityRefreshInterval("10m")
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, interval)
+
+	_, err = ParseCapabilityRefreshInterval("-1m")
+	assert.NotNil(t, err)
+}
+
+func TestCapabilityCacheRefresh(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	results := []*provider.Capabilities{
+		{Profiles: []string{"general-purpose"}},
+		{Profiles: []string{"general-purpose"}},
+		nil,
+		{Profiles: []string{"general-purpose", "sdp"}},
+	}
+	calls := 0
+	fetch := func() (*provider.Capabilities, error) {
+		defer func() { calls++ }()
+		if results[calls] == nil {
+			return nil, errors.New("fetch failed")
+		}
+		return results[calls], nil
+	}
+
+	cache := NewCapabilityCache(fetch, 0, logger)
+	changes := 0
+	cache.OnChange(func(previous, current *provider.Capabilities) {
+		changes++
+		assert.False(t, previous.HasProfile("sdp"))
+		assert.True(t, current.HasProfile("sdp"))
+	})
+
+	assert.Nil(t, cache.Get())
+	assert.Nil(t, cache.Refresh())
+	assert.True(t, cache.Get().HasProfile("general-purpose"))
+	assert.Nil(t, cache.Refresh())
+	assert.NotNil(t, cache.Refresh())
+	assert.True(t, cache.Get().HasProfile("general-purpose"))
+	assert.Equal(t, 0, changes)
+	assert.Nil(t, cache.Refresh())
+	assert.Equal(t, 1, changes)
+	assert.True(t, cache.Get().HasProfile("sdp"))
+}
+
+func TestCapabilityCacheStart(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	var calls int32
+	fetch := func() (*provider.Capabilities, error) {
+		n := atomic.AddInt32(&calls, 1)
+		return &provider.Capabilities{Limits: map[string]int64{"attachments": int64(n)}}, nil
+	}
+	cache := NewCapabilityCache(fetch, 10*time.Millisecond, logger)
+	changed := make(chan struct{}, 10)
+	cache.OnChange(func(previous, current *provider.Capabilities) {
+		changed <- struct{}{}
+	})
+
+	assert.Nil(t, cache.Start())
+	defer cache.Stop()
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("capabilities were not refreshed in background")
+	}
+	assert.True(t, cache.Get().Limits["attachments"] > 1)
+}
+
+func TestSessionCapabilitiesFetcher(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetCapabilitiesReturns(&provider.Capabilities{Features: map[string]bool{provider.FeatureSnapshots: true}}, nil)
+
+	cache := NewCapabilityCache(SessionCapabilitiesFetcher(sess), 0, logger)
+	assert.Nil(t, cache.Refresh())
+	assert.True(t, cache.Get().SupportsSnapshots())
+	assert.Equal(t, 1, sess.GetCapabilitiesCallCount())
+
+	capabilities, err := NewContextSession(sess).GetCapabilitiesWithContext(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, capabilities.SupportsSnapshots())
+}
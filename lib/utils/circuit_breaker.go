@@ -0,0 +1,239 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// DefaultCircuitBreakerOpenTimeout is the default time the calls to an endpoint fast-fail once its circuit opened
+const DefaultCircuitBreakerOpenTimeout = 30 * time.Second
+
+// EndpointProperty is the error property carrying the endpoint of the failed call
+const EndpointProperty = "Endpoint"
+
+// CircuitState is the state of the circuit of an endpoint
+type CircuitState string
+
+const (
+	// CircuitClosed lets the calls through
+	CircuitClosed = CircuitState("closed")
+
+	// CircuitOpen fast-fails the calls
+	CircuitOpen = CircuitState("open")
+
+	// CircuitHalfOpen lets a single probe call through, closing the circuit if it succeeds
+	CircuitHalfOpen = CircuitState("half-open")
+)
+
+// endpointCircuit is the circuit of an endpoint
+type endpointCircuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// CircuitBreaker fast-fails the calls to an endpoint after failureThreshold consecutive failures (5xx responses,
+// timeouts and connection errors), preventing retry storms during regional outages. After openTimeout a single
+// probe call is let through, which closes the circuit if it succeeds or opens it again
+type CircuitBreaker struct {
+	failureThreshold int
+	openTimeout      time.Duration
+	logger           *zap.Logger
+	now              func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*endpointCircuit
+}
+
+// NewCircuitBreaker returns a breaker opening after failureThreshold consecutive failures for openTimeout
+// (DefaultCircuitBreakerOpenTimeout if zero). A nil breaker is returned if failureThreshold is zero or less,
+// which lets all the calls through
+func NewCircuitBreaker(failureThreshold int, openTimeout time.Duration, logger *zap.Logger) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		return nil
+	}
+	if openTimeout <= 0 {
+		openTimeout = DefaultCircuitBreakerOpenTimeout
+	}
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		logger:           logger,
+		now:              time.Now,
+		circuits:         make(map[string]*endpointCircuit),
+	}
+}
+
+// NewCircuitBreakerFromConfig returns the breaker of the circuit_breaker_failure_threshold and
+// circuit_breaker_open_timeout server config values, nil if it is disabled
+func NewCircuitBreakerFromConfig(server *config.ServerConfig, logger *zap.Logger) (*CircuitBreaker, error) {
+	if server == nil || server.CircuitBreakerFailureThreshold <= 0 {
+		return nil, nil
+	}
+	openTimeout := DefaultCircuitBreakerOpenTimeout
+	if server.CircuitBreakerOpenTimeout != "" {
+		timeout, err := time.ParseDuration(server.CircuitBreakerOpenTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid circuit breaker open timeout '%s'", server.CircuitBreakerOpenTimeout)
+		}
+		openTimeout = timeout
+	}
+	return NewCircuitBreaker(server.CircuitBreakerFailureThreshold, openTimeout, logger), nil
+}
+
+// Allow returns an ErrorCircuitOpen error, with the remaining open time as RetryAfter, if the calls to the
+// endpoint must fast-fail. Every allowed call must be followed by Record
+func (b *CircuitBreaker) Allow(endpoint string) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit, ok := b.circuits[endpoint]
+	if !ok || circuit.state == CircuitClosed {
+		return nil
+	}
+	if circuit.state == CircuitOpen {
+		remaining := circuit.openedAt.Add(b.openTimeout).Sub(b.now())
+		if remaining > 0 {
+			return b.circuitOpenError(endpoint, remaining)
+		}
+		circuit.state = CircuitHalfOpen
+		b.logger.Info("Circuit half-open, probing the endpoint", zap.String(EndpointProperty, endpoint))
+	}
+	if circuit.probing {
+		return b.circuitOpenError(endpoint, 0)
+	}
+	circuit.probing = true
+	return nil
+}
+
+// Record records the outcome of an allowed call to the endpoint
+func (b *CircuitBreaker) Record(endpoint string, failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit, ok := b.circuits[endpoint]
+	if !ok {
+		if !failed {
+			return
+		}
+		circuit = &endpointCircuit{state: CircuitClosed}
+		b.circuits[endpoint] = circuit
+	}
+	halfOpen := circuit.state == CircuitHalfOpen
+	circuit.probing = false
+	if !failed {
+		if circuit.state != CircuitClosed {
+			b.logger.Info("Circuit closed, the endpoint recovered", zap.String(EndpointProperty, endpoint))
+		}
+		delete(b.circuits, endpoint)
+		return
+	}
+	circuit.failures++
+	if halfOpen || (circuit.state == CircuitClosed && circuit.failures >= b.failureThreshold) {
+		circuit.state = CircuitOpen
+		circuit.openedAt = b.now()
+		b.logger.Warn("Circuit opened, the calls to the endpoint fast-fail", zap.String(EndpointProperty, endpoint),
+			zap.Int("ConsecutiveFailures", circuit.failures), zap.Duration("OpenTimeout", b.openTimeout))
+	}
+}
+
+// release ends an allowed call without outcome, e.g. cancelled by the caller
+func (b *CircuitBreaker) release(endpoint string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if circuit, ok := b.circuits[endpoint]; ok {
+		circuit.probing = false
+	}
+}
+
+// State returns the state of the circuit of the endpoint
+func (b *CircuitBreaker) State(endpoint string) CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	circuit, ok := b.circuits[endpoint]
+	if !ok {
+		return CircuitClosed
+	}
+	if circuit.state == CircuitOpen && !b.now().Before(circuit.openedAt.Add(b.openTimeout)) {
+		return CircuitHalfOpen
+	}
+	return circuit.state
+}
+
+func (b *CircuitBreaker) circuitOpenError(endpoint string, retryAfter time.Duration) error {
+	err := NewErrorWithProperties(reasoncode.ErrorCircuitOpen,
+		fmt.Sprintf("The circuit of endpoint %s is open after consecutive failures, the call was not sent", endpoint),
+		map[string]string{EndpointProperty: endpoint})
+	return WithRetryAfter(err, retryAfter)
+}
+
+// Transport returns an http.RoundTripper sending the requests with base (http.DefaultTransport if nil) through
+// the breaker, by request host. Use it for the HTTP clients of the RIaaS and IAM endpoints, the IAM token
+// exchanges use it with the CircuitBreaker of their iam.AuthConfiguration
+func (b *CircuitBreaker) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if b == nil {
+		return base
+	}
+	return &circuitBreakerTransport{base: base, breaker: b}
+}
+
+// circuitBreakerTransport sends the requests through the circuit breaker
+type circuitBreakerTransport struct {
+	base    http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+var _ http.RoundTripper = &circuitBreakerTransport{}
+
+// RoundTrip ...
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := req.URL.Host
+	if err := t.breaker.Allow(endpoint); err != nil {
+		return nil, err
+	}
+	response, err := t.base.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// cancelled by the caller, the endpoint is not at fault
+		t.breaker.release(endpoint)
+	case err != nil:
+		t.breaker.Record(endpoint, true)
+	default:
+		t.breaker.Record(endpoint, response.StatusCode >= http.StatusInternalServerError)
+	}
+	return response, err
+}
@@ -0,0 +1,128 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	breaker := NewCircuitBreaker(3, time.Minute, logger)
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+	endpoint := "us-south.iaas.cloud.ibm.com"
+
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, breaker.Allow(endpoint))
+		breaker.Record(endpoint, true)
+	}
+	assert.Equal(t, CircuitClosed, breaker.State(endpoint))
+
+	// a success resets the consecutive failures
+	assert.Nil(t, breaker.Allow(endpoint))
+	breaker.Record(endpoint, false)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, breaker.Allow(endpoint))
+		breaker.Record(endpoint, true)
+	}
+	assert.Equal(t, CircuitOpen, breaker.State(endpoint))
+
+	err := breaker.Allow(endpoint)
+	assert.Equal(t, reasoncode.ErrorCircuitOpen, ErrorReasonCode(err))
+	assert.Equal(t, time.Minute, RetryHint(err))
+	assert.Equal(t, endpoint, err.(provider.Error).Fault.Properties[EndpointProperty])
+	assert.False(t, IsRetryableError(err))
+	assert.Nil(t, breaker.Allow("other.iaas.cloud.ibm.com"))
+
+	// a single probe once the open timeout elapsed, a failed probe opens the circuit again
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(endpoint))
+	assert.Nil(t, breaker.Allow(endpoint))
+	assert.NotNil(t, breaker.Allow(endpoint))
+	breaker.Record(endpoint, true)
+	assert.Equal(t, CircuitOpen, breaker.State(endpoint))
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, breaker.Allow(endpoint))
+	breaker.Record(endpoint, false)
+	assert.Equal(t, CircuitClosed, breaker.State(endpoint))
+	assert.Nil(t, breaker.Allow(endpoint))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	var breaker *CircuitBreaker
+	assert.Nil(t, breaker.Allow("endpoint"))
+	breaker.Record("endpoint", true)
+	assert.Equal(t, CircuitClosed, breaker.State("endpoint"))
+	assert.Equal(t, http.DefaultTransport, breaker.Transport(nil))
+
+	breaker, err := NewCircuitBreakerFromConfig(&config.ServerConfig{}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, breaker)
+	breaker, err = NewCircuitBreakerFromConfig(&config.ServerConfig{CircuitBreakerFailureThreshold: 5, CircuitBreakerOpenTimeout: "10s"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Second, breaker.openTimeout)
+	_, err = NewCircuitBreakerFromConfig(&config.ServerConfig{CircuitBreakerFailureThreshold: 5, CircuitBreakerOpenTimeout: "soon"}, logger)
+	assert.NotNil(t, err)
+}
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(2, time.Minute, logger)
+	client := &http.Client{Transport: breaker.Transport(nil)}
+	for i := 0; i < 2; i++ {
+		response, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		_ = response.Body.Close()
+	}
+	_, err := client.Get(server.URL)
+	var pErr provider.Error
+	assert.True(t, errors.As(err, &pErr))
+	assert.Equal(t, reasoncode.ErrorCircuitOpen, pErr.Code())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// a call cancelled by the caller is not a failure of the endpoint
+	breaker = NewCircuitBreaker(1, time.Minute, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err = (&http.Client{Transport: breaker.Transport(nil)}).Do(request)
+	assert.NotNil(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State(request.URL.Host))
+}
@@ -0,0 +1,331 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/tracing"
+)
+
+// NewContextSession returns sess if it implements provider.ContextSession, otherwise an adapter of sess.
+// The adapter checks the context before each call, and stops waiting for the reads and waits when the context
+// is done (abandoning the call). Mutations are never abandoned once sent, their outcome would be unknown
+func NewContextSession(sess provider.Session) provider.ContextSession {
+	if csess, ok := sess.(provider.ContextSession); ok {
+		return csess
+	}
+	return &contextSession{Session: sess}
+}
+
+// contextSession adapts a Session not accepting a context
+type contextSession struct {
+	provider.Session
+}
+
+var _ provider.ContextSession = &contextSession{}
+
+// dispatchWithContext calls fn unless the context is done or the mutations are frozen for maintenance, in a span
+// of the operation. The completed operation on the target is recorded by the activity tracker of the context, if
+// any (see WithActivityTracker), unless the session records its events itself (see NewGuardedSession)
+func dispatchWithContext[T any](ctx context.Context, s *contextSession, operation, target string, fn func() (T, error)) (value T, err error) {
+	_, span := tracing.StartOperation(ctx, operation)
+	defer func() { tracing.End(span, err) }()
+	if err = ctx.Err(); err != nil {
+		return value, err
+	}
+	if err = DefaultMaintenanceFreeze.Check(operation); err != nil {
+		return value, err
+	}
+	value, err = fn()
+	if tracker, found := ActivityTrackerFromContext(ctx); found && !recordsActivity(s.Session) {
+		tracker.Record(ctx, operation, target, nil, err)
+	}
+	return value, err
+}
+
+// callResult is the result of a call run by callWithContext
+type callResult[T any] struct {
+	value T
+	err   error
+}
+
+// callWithContext calls fn in a span of the operation, returning early with the error of the context when it is done first
+func callWithContext[T any](ctx context.Context, operation string, fn func() (T, error)) (value T, err error) {
+	_, span := tracing.StartOperation(ctx, operation)
+	defer func() { tracing.End(span, err) }()
+	if ctx.Done() == nil {
+		return fn()
+	}
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	done := make(chan callResult[T], 1)
+	go func() {
+		value, err := fn()
+		done <- callResult[T]{value: value, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// noValue adapts the operations only returning an error
+func noValue(fn func() error) func() (struct{}, error) {
+	return func() (struct{}, error) { return struct{}{}, fn() }
+}
+
+// CreateVolumeWithContext ...
+func (s *contextSession) CreateVolumeWithContext(ctx context.Context, volumeRequest provider.Volume) (*provider.Volume, error) {
+	return dispatchWithContext(ctx, s, "CreateVolume", SafeStringValue(volumeRequest.Name), func() (*provider.Volume, error) { return s.CreateVolume(volumeRequest) })
+}
+
+// CreateVolumeFromSnapshotWithContext ...
+func (s *contextSession) CreateVolumeFromSnapshotWithContext(ctx context.Context, snapshot provider.Snapshot, tags map[string]string) (*provider.Volume, error) {
+	return dispatchWithContext(ctx, s, "CreateVolumeFromSnapshot", snapshot.SnapshotID, func() (*provider.Volume, error) { return s.CreateVolumeFromSnapshot(snapshot, tags) })
+}
+
+// CreateVolumeFromVolumeWithContext ...
+func (s *contextSession) CreateVolumeFromVolumeWithContext(ctx context.Context, cloneRequest provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error) {
+	return dispatchWithContext(ctx, s, "CreateVolumeFromVolume", cloneRequest.SourceVolumeID, func() (*provider.VolumeCloneResponse, error) { return s.CreateVolumeFromVolume(cloneRequest) })
+}
+
+// UpdateVolumeWithContext ...
+func (s *contextSession) UpdateVolumeWithContext(ctx context.Context, volume provider.Volume) error {
+	_, err := dispatchWithContext(ctx, s, "UpdateVolume", volume.VolumeID, noValue(func() error { return s.UpdateVolume(volume) }))
+	return err
+}
+
+// DeleteVolumeWithContext ...
+func (s *contextSession) DeleteVolumeWithContext(ctx context.Context, volume *provider.Volume) error {
+	_, err := dispatchWithContext(ctx, s, "DeleteVolume", volumeIDOf(volume), noValue(func() error { return s.DeleteVolume(volume) }))
+	return err
+}
+
+// GetVolumeWithContext ...
+func (s *contextSession) GetVolumeWithContext(ctx context.Context, id string) (*provider.Volume, error) {
+	return callWithContext(ctx, "GetVolume", func() (*provider.Volume, error) { return s.GetVolume(id) })
+}
+
+// GetVolumeByNameWithContext ...
+func (s *contextSession) GetVolumeByNameWithContext(ctx context.Context, name string) (*provider.Volume, error) {
+	return callWithContext(ctx, "GetVolumeByName", func() (*provider.Volume, error) { return s.GetVolumeByName(name) })
+}
+
+// ListVolumesWithContext ...
+func (s *contextSession) ListVolumesWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*provider.VolumeList, error) {
+	return callWithContext(ctx, "ListVolumes", func() (*provider.VolumeList, error) { return s.ListVolumes(limit, start, tags) })
+}
+
+// ListVolumesWithFiltersWithContext ...
+func (s *contextSession) ListVolumesWithFiltersWithContext(ctx context.Context, limit int, start string, filters provider.ListVolumesFilters) (*provider.VolumeList, error) {
+	return callWithContext(ctx, "ListVolumesWithFilters", func() (*provider.VolumeList, error) { return s.ListVolumesWithFilters(limit, start, filters) })
+}
+
+// GetVolumeByRequestIDWithContext ...
+func (s *contextSession) GetVolumeByRequestIDWithContext(ctx context.Context, requestID string) (*provider.Volume, error) {
+	return callWithContext(ctx, "GetVolumeByRequestID", func() (*provider.Volume, error) { return s.GetVolumeByRequestID(requestID) })
+}
+
+// AuthorizeVolumeWithContext ...
+func (s *contextSession) AuthorizeVolumeWithContext(ctx context.Context, volumeAuthorization provider.VolumeAuthorization) error {
+	_, err := dispatchWithContext(ctx, s, "AuthorizeVolume", volumeAuthorization.Volume.VolumeID, noValue(func() error { return s.AuthorizeVolume(volumeAuthorization) }))
+	return err
+}
+
+// ExpandVolumeWithContext ...
+func (s *contextSession) ExpandVolumeWithContext(ctx context.Context, expandVolumeRequest provider.ExpandVolumeRequest) (int64, error) {
+	return dispatchWithContext(ctx, s, "ExpandVolume", expandVolumeRequest.VolumeID, func() (int64, error) { return s.ExpandVolume(expandVolumeRequest) })
+}
+
+// UpdateVolumeProfileWithContext ...
+func (s *contextSession) UpdateVolumeProfileWithContext(ctx context.Context, updateRequest provider.VolumeProfileUpdateRequest) (*provider.Volume, error) {
+	return dispatchWithContext(ctx, s, "UpdateVolumeProfile", updateRequest.VolumeID, func() (*provider.Volume, error) { return s.UpdateVolumeProfile(updateRequest) })
+}
+
+// UpdateVolumeIOPSWithContext ...
+func (s *contextSession) UpdateVolumeIOPSWithContext(ctx context.Context, updateRequest provider.VolumeIOPSUpdateRequest) (*provider.Volume, error) {
+	return dispatchWithContext(ctx, s, "UpdateVolumeIOPS", updateRequest.VolumeID, func() (*provider.Volume, error) { return s.UpdateVolumeIOPS(updateRequest) })
+}
+
+// GetVolumeTagsWithContext ...
+func (s *contextSession) GetVolumeTagsWithContext(ctx context.Context, volumeID string) (*provider.VolumeTags, error) {
+	return callWithContext(ctx, "GetVolumeTags", func() (*provider.VolumeTags, error) { return s.GetVolumeTags(volumeID) })
+}
+
+// UpdateVolumeTagsWithContext ...
+func (s *contextSession) UpdateVolumeTagsWithContext(ctx context.Context, updateRequest provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+	return dispatchWithContext(ctx, s, "UpdateVolumeTags", updateRequest.VolumeID, func() (*provider.VolumeTags, error) { return s.UpdateVolumeTags(updateRequest) })
+}
+
+// AttachVolumeWithContext ...
+func (s *contextSession) AttachVolumeWithContext(ctx context.Context, attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return dispatchWithContext(ctx, s, "AttachVolume", attachRequest.VolumeID, func() (*provider.VolumeAttachmentResponse, error) { return s.AttachVolume(attachRequest) })
+}
+
+// DetachVolumeWithContext ...
+func (s *contextSession) DetachVolumeWithContext(ctx context.Context, detachRequest provider.VolumeAttachmentRequest) (*http.Response, error) {
+	return dispatchWithContext(ctx, s, "DetachVolume", detachRequest.VolumeID, func() (*http.Response, error) { return s.DetachVolume(detachRequest) })
+}
+
+// WaitForAttachVolumeWithContext ...
+func (s *contextSession) WaitForAttachVolumeWithContext(ctx context.Context, attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return RunWaiter(ctx, "WaitForAttachVolume", attachRequest.VolumeID, func(ctx context.Context) (*provider.VolumeAttachmentResponse, error) {
+		return adaptiveWait(ctx, "WaitForAttachVolume", func() (*provider.VolumeAttachmentResponse, error) {
+			return callWithContext(ctx, "WaitForAttachVolume", func() (*provider.VolumeAttachmentResponse, error) { return s.WaitForAttachVolume(attachRequest) })
+		})
+	})
+}
+
+// WaitForDetachVolumeWithContext ...
+func (s *contextSession) WaitForDetachVolumeWithContext(ctx context.Context, detachRequest provider.VolumeAttachmentRequest) error {
+	_, err := RunWaiter(ctx, "WaitForDetachVolume", detachRequest.VolumeID, func(ctx context.Context) (struct{}, error) {
+		return adaptiveWait(ctx, "WaitForDetachVolume", func() (struct{}, error) {
+			return callWithContext(ctx, "WaitForDetachVolume", noValue(func() error { return s.WaitForDetachVolume(detachRequest) }))
+		})
+	})
+	return err
+}
+
+// GetVolumeAttachmentWithContext ...
+func (s *contextSession) GetVolumeAttachmentWithContext(ctx context.Context, attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return callWithContext(ctx, "GetVolumeAttachment", func() (*provider.VolumeAttachmentResponse, error) { return s.GetVolumeAttachment(attachRequest) })
+}
+
+// UpdateVolumeAttachmentWithContext ...
+func (s *contextSession) UpdateVolumeAttachmentWithContext(ctx context.Context, updateRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return dispatchWithContext(ctx, s, "UpdateVolumeAttachment", updateRequest.VolumeID, func() (*provider.VolumeAttachmentResponse, error) { return s.UpdateVolumeAttachment(updateRequest) })
+}
+
+// ListVolumeAttachmentsWithContext ...
+func (s *contextSession) ListVolumeAttachmentsWithContext(ctx context.Context, volumeID string) ([]*provider.VolumeAttachmentResponse, error) {
+	return callWithContext(ctx, "ListVolumeAttachments", func() ([]*provider.VolumeAttachmentResponse, error) { return s.ListVolumeAttachments(volumeID) })
+}
+
+// CreateSnapshotWithContext ...
+func (s *contextSession) CreateSnapshotWithContext(ctx context.Context, sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (*provider.Snapshot, error) {
+	return dispatchWithContext(ctx, s, "CreateSnapshot", sourceVolumeID, func() (*provider.Snapshot, error) { return s.CreateSnapshot(sourceVolumeID, snapshotParameters) })
+}
+
+// DeleteSnapshotWithContext ...
+func (s *contextSession) DeleteSnapshotWithContext(ctx context.Context, snapshot *provider.Snapshot) error {
+	_, err := dispatchWithContext(ctx, s, "DeleteSnapshot", snapshotIDOf(snapshot), noValue(func() error { return s.DeleteSnapshot(snapshot) }))
+	return err
+}
+
+// GetSnapshotWithContext ...
+func (s *contextSession) GetSnapshotWithContext(ctx context.Context, snapshotID string) (*provider.Snapshot, error) {
+	return callWithContext(ctx, "GetSnapshot", func() (*provider.Snapshot, error) { return s.GetSnapshot(snapshotID) })
+}
+
+// GetSnapshotByNameWithContext ...
+func (s *contextSession) GetSnapshotByNameWithContext(ctx context.Context, snapshotName string) (*provider.Snapshot, error) {
+	return callWithContext(ctx, "GetSnapshotByName", func() (*provider.Snapshot, error) { return s.GetSnapshotByName(snapshotName) })
+}
+
+// ListSnapshotsWithContext ...
+func (s *contextSession) ListSnapshotsWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*provider.SnapshotList, error) {
+	return callWithContext(ctx, "ListSnapshots", func() (*provider.SnapshotList, error) { return s.ListSnapshots(limit, start, tags) })
+}
+
+// RestoreVolumeFromSnapshotWithContext ...
+func (s *contextSession) RestoreVolumeFromSnapshotWithContext(ctx context.Context, restoreRequest provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error) {
+	return dispatchWithContext(ctx, s, "RestoreVolumeFromSnapshot", restoreRequest.SnapshotID, func() (*provider.SnapshotRestoreResponse, error) { return s.RestoreVolumeFromSnapshot(restoreRequest) })
+}
+
+// GetSnapshotRestoreProgressWithContext ...
+func (s *contextSession) GetSnapshotRestoreProgressWithContext(ctx context.Context, volumeID string) (*provider.SnapshotRestoreProgress, error) {
+	return callWithContext(ctx, "GetSnapshotRestoreProgress", func() (*provider.SnapshotRestoreProgress, error) { return s.GetSnapshotRestoreProgress(volumeID) })
+}
+
+// CreateVolumeAccessPointWithContext ...
+func (s *contextSession) CreateVolumeAccessPointWithContext(ctx context.Context, accessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return dispatchWithContext(ctx, s, "CreateVolumeAccessPoint", accessPointRequest.VolumeID, func() (*provider.VolumeAccessPointResponse, error) {
+		return s.CreateVolumeAccessPoint(accessPointRequest)
+	})
+}
+
+// DeleteVolumeAccessPointWithContext ...
+func (s *contextSession) DeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest provider.VolumeAccessPointRequest) (*http.Response, error) {
+	return dispatchWithContext(ctx, s, "DeleteVolumeAccessPoint", deleteAccessPointRequest.VolumeID, func() (*http.Response, error) { return s.DeleteVolumeAccessPoint(deleteAccessPointRequest) })
+}
+
+// WaitForCreateVolumeAccessPointWithContext ...
+func (s *contextSession) WaitForCreateVolumeAccessPointWithContext(ctx context.Context, accessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return RunWaiter(ctx, "WaitForCreateVolumeAccessPoint", accessPointRequest.VolumeID, func(ctx context.Context) (*provider.VolumeAccessPointResponse, error) {
+		return callWithContext(ctx, "WaitForCreateVolumeAccessPoint", func() (*provider.VolumeAccessPointResponse, error) {
+			return s.WaitForCreateVolumeAccessPoint(accessPointRequest)
+		})
+	})
+}
+
+// WaitForDeleteVolumeAccessPointWithContext ...
+func (s *contextSession) WaitForDeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest provider.VolumeAccessPointRequest) error {
+	_, err := RunWaiter(ctx, "WaitForDeleteVolumeAccessPoint", deleteAccessPointRequest.VolumeID, func(ctx context.Context) (struct{}, error) {
+		return callWithContext(ctx, "WaitForDeleteVolumeAccessPoint", noValue(func() error { return s.WaitForDeleteVolumeAccessPoint(deleteAccessPointRequest) }))
+	})
+	return err
+}
+
+// GetVolumeAccessPointWithContext ...
+func (s *contextSession) GetVolumeAccessPointWithContext(ctx context.Context, accessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return callWithContext(ctx, "GetVolumeAccessPoint", func() (*provider.VolumeAccessPointResponse, error) { return s.GetVolumeAccessPoint(accessPointRequest) })
+}
+
+// CreateShareWithContext ...
+func (s *contextSession) CreateShareWithContext(ctx context.Context, shareRequest provider.FileShareRequest) (*provider.FileShare, error) {
+	return dispatchWithContext(ctx, s, "CreateShare", shareRequest.Name, func() (*provider.FileShare, error) { return s.CreateShare(shareRequest) })
+}
+
+// DeleteShareWithContext ...
+func (s *contextSession) DeleteShareWithContext(ctx context.Context, shareID string) error {
+	_, err := dispatchWithContext(ctx, s, "DeleteShare", shareID, noValue(func() error { return s.DeleteShare(shareID) }))
+	return err
+}
+
+// CreateShareTargetWithContext ...
+func (s *contextSession) CreateShareTargetWithContext(ctx context.Context, targetRequest provider.ShareTargetRequest) (*provider.ShareTarget, error) {
+	return dispatchWithContext(ctx, s, "CreateShareTarget", targetRequest.ShareID, func() (*provider.ShareTarget, error) { return s.CreateShareTarget(targetRequest) })
+}
+
+// DeleteShareTargetWithContext ...
+func (s *contextSession) DeleteShareTargetWithContext(ctx context.Context, targetRequest provider.ShareTargetRequest) error {
+	_, err := dispatchWithContext(ctx, s, "DeleteShareTarget", targetRequest.ShareID, noValue(func() error { return s.DeleteShareTarget(targetRequest) }))
+	return err
+}
+
+// ListSharesWithContext ...
+func (s *contextSession) ListSharesWithContext(ctx context.Context, limit int, start string, tags map[string]string) (*provider.FileShareList, error) {
+	return callWithContext(ctx, "ListShares", func() (*provider.FileShareList, error) { return s.ListShares(limit, start, tags) })
+}
+
+// ExpandShareWithContext ...
+func (s *contextSession) ExpandShareWithContext(ctx context.Context, expandRequest provider.ExpandShareRequest) (int64, error) {
+	return dispatchWithContext(ctx, s, "ExpandShare", expandRequest.ShareID, func() (int64, error) { return s.ExpandShare(expandRequest) })
+}
+
+// GetCapabilitiesWithContext ...
+func (s *contextSession) GetCapabilitiesWithContext(ctx context.Context) (*provider.Capabilities, error) {
+	return callWithContext(ctx, "GetCapabilities", func() (*provider.Capabilities, error) { return s.GetCapabilities() })
+}
@@ -0,0 +1,100 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewContextSession(t *testing.T) {
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(&provider.Volume{VolumeID: "vol-1"}, nil)
+	csess := NewContextSession(sess)
+	assert.Equal(t, csess, NewContextSession(csess))
+
+	volume, err := csess.GetVolumeWithContext(context.Background(), "vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-1", volume.VolumeID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = csess.GetVolumeWithContext(ctx, "vol-1")
+	assert.True(t, errors.Is(err, context.Canceled))
+	err = csess.DeleteVolumeWithContext(ctx, &provider.Volume{VolumeID: "vol-1"})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, sess.DeleteVolumeCallCount())
+	assert.Equal(t, 1, sess.GetVolumeCallCount())
+}
+
+func TestContextSessionWaitDeadline(t *testing.T) {
+	sess := &fake.FakeSession{}
+	release := make(chan struct{})
+	defer close(release)
+	sess.WaitForAttachVolumeStub = func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		<-release
+		return &provider.VolumeAttachmentResponse{Status: "attached"}, nil
+	}
+	sess.AttachVolumeReturns(&provider.VolumeAttachmentResponse{Status: "attaching"}, nil)
+	csess := NewContextSession(sess)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	response, err := csess.AttachVolumeWithContext(ctx, provider.VolumeAttachmentRequest{VolumeID: "vol-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "attaching", response.Status)
+
+	_, err = csess.WaitForAttachVolumeWithContext(ctx, provider.VolumeAttachmentRequest{VolumeID: "vol-1"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestContextSessionSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(nil)
+
+	sess := &fake.FakeSession{}
+	sess.CreateVolumeReturns(nil, provider.Error{Fault: provider.Fault{ReasonCode: "ErrorQuotaExceeded"}})
+	csess := NewContextSession(sess)
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "CSI.CreateVolume")
+	_, err := csess.CreateVolumeWithContext(ctx, provider.Volume{})
+	assert.NotNil(t, err)
+	_, err = csess.GetVolumeWithContext(ctx, "vol-1")
+	assert.Nil(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 3) {
+		assert.Equal(t, "CreateVolume", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "GetVolume", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	}
+}
@@ -0,0 +1,110 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+const (
+	// CSISecretAPIKey is the CSI secret field with the API key of the call
+	CSISecretAPIKey = "apiKey"
+
+	// CSISecretResourceGroup is the CSI secret field with the resource group ID of the created resources
+	CSISecretResourceGroup = "resourceGroup"
+
+	// CSISecretEncryptionKey is the CSI secret field with the CRN of the root key encrypting the created volumes
+	CSISecretEncryptionKey = "encryptionKey"
+)
+
+var credentialsOverrideKey = ctxkeys.NewKey[provider.CredentialsOverride]("credentials-override")
+
+// ParseCSISecrets returns the credentials override from the secrets of a CSI request, the other fields are ignored.
+// An encryption key which is not the CRN of a root key is an ErrorBadRequest error (see ParseEncryptionKeyCRN)
+func ParseCSISecrets(secrets map[string]string) (provider.CredentialsOverride, error) {
+	override := provider.CredentialsOverride{
+		APIKey:           strings.TrimSpace(secrets[CSISecretAPIKey]),
+		ResourceGroupID:  strings.TrimSpace(secrets[CSISecretResourceGroup]),
+		EncryptionKeyCRN: strings.TrimSpace(secrets[CSISecretEncryptionKey]),
+	}
+	if override.EncryptionKeyCRN != "" {
+		if _, err := ParseEncryptionKeyCRN(override.EncryptionKeyCRN); err != nil {
+			return provider.CredentialsOverride{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid %s secret, expected a key CRN", CSISecretEncryptionKey), err)
+		}
+	}
+	return override, nil
+}
+
+// WithCredentialsOverride returns a context carrying the credentials override of the operations performed with it
+func WithCredentialsOverride(ctx context.Context, override provider.CredentialsOverride) context.Context {
+	return credentialsOverrideKey.WithValue(ctx, override)
+}
+
+// CredentialsOverrideFromContext returns the credentials override attached to the context, if any
+func CredentialsOverrideFromContext(ctx context.Context) (provider.CredentialsOverride, bool) {
+	return credentialsOverrideKey.Value(ctx)
+}
+
+// MergeCredentialsOverride returns a copy of the VPC configuration with the override applied, the configuration
+// itself is not modified and is returned as is when the override is empty
+func MergeCredentialsOverride(vpc *config.VPCProviderConfig, override provider.CredentialsOverride) *config.VPCProviderConfig {
+	if vpc == nil || override.IsEmpty() {
+		return vpc
+	}
+	merged := (&config.Config{VPC: vpc}).Clone().VPC
+	if override.APIKey != "" {
+		merged.APIKey = override.APIKey
+		merged.G2APIKey = override.APIKey
+		merged.CredentialProvider = &config.StaticCredentialProvider{APIKey: override.APIKey}
+	}
+	if override.ResourceGroupID != "" {
+		merged.ResourceGroupID = override.ResourceGroupID
+		merged.G2ResourceGroupID = override.ResourceGroupID
+	}
+	return merged
+}
+
+// ApplyCredentialsOverride sets the resource group and the encryption key of the override on the volume to create,
+// the values already set on the volume are kept
+func ApplyCredentialsOverride(volume *provider.Volume, override provider.CredentialsOverride) {
+	if volume == nil {
+		return
+	}
+	if override.ResourceGroupID != "" && (volume.ResourceGroup == nil || (volume.ResourceGroup.ID == "" && volume.ResourceGroup.Name == "")) {
+		volume.ResourceGroup = &provider.ResourceGroup{ID: override.ResourceGroupID}
+	}
+	if override.EncryptionKeyCRN != "" && (volume.VolumeEncryptionKey == nil || volume.VolumeEncryptionKey.CRN == "") {
+		volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: override.EncryptionKeyCRN}
+	}
+}
+
+// ApplyCredentialsOverrideToContextCredentials returns the context credentials using the API key of the override
+func ApplyCredentialsOverrideToContextCredentials(creds provider.ContextCredentials, override provider.CredentialsOverride) provider.ContextCredentials {
+	if override.APIKey != "" {
+		creds.AuthType = provider.IAMAPIKey
+		creds.Credential = override.APIKey
+	}
+	return creds
+}
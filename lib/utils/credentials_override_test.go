@@ -0,0 +1,127 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSISecrets(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		secrets      map[string]string
+		expected     provider.CredentialsOverride
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "All fields",
+			secrets:      map[string]string{CSISecretAPIKey: " key ", CSISecretResourceGroup: "rg-1", CSISecretEncryptionKey: "crn:v1:bluemix:public:kms:us-south:a/acc:inst:key:k1", "other": "x"},
+			expected:     provider.CredentialsOverride{APIKey: "key", ResourceGroupID: "rg-1", EncryptionKeyCRN: "crn:v1:bluemix:public:kms:us-south:a/acc:inst:key:k1"},
+		},
+		{
+			testcasename: "No secrets",
+			secrets:      nil,
+			expected:     provider.CredentialsOverride{},
+		},
+		{
+			testcasename: "Invalid encryption key",
+			secrets:      map[string]string{CSISecretEncryptionKey: "k1"},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			override, err := ParseCSISecrets(testcase.secrets)
+			if testcase.expectedCode != "" {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, override)
+		})
+	}
+}
+
+func TestCredentialsOverrideContext(t *testing.T) {
+	_, ok := CredentialsOverrideFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithCredentialsOverride(context.Background(), provider.CredentialsOverride{ResourceGroupID: "rg-1"})
+	override, ok := CredentialsOverrideFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "rg-1", override.ResourceGroupID)
+}
+
+func TestMergeCredentialsOverride(t *testing.T) {
+	vpc := &config.VPCProviderConfig{APIKey: "session-key", G2APIKey: "session-key", ResourceGroupID: "rg-session", G2ResourceGroupID: "rg-session"}
+
+	assert.Same(t, vpc, MergeCredentialsOverride(vpc, provider.CredentialsOverride{}))
+	assert.Nil(t, MergeCredentialsOverride(nil, provider.CredentialsOverride{APIKey: "key"}))
+
+	merged := MergeCredentialsOverride(vpc, provider.CredentialsOverride{APIKey: "call-key", ResourceGroupID: "rg-call"})
+	assert.Equal(t, "call-key", merged.G2APIKey)
+	assert.Equal(t, "rg-call", merged.G2ResourceGroupID)
+	apiKey, err := merged.GetAPIKey(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "call-key", apiKey)
+
+	// The session configuration is unchanged
+	assert.Equal(t, "session-key", vpc.G2APIKey)
+	assert.Equal(t, "rg-session", vpc.G2ResourceGroupID)
+	assert.Nil(t, vpc.CredentialProvider)
+
+	merged = MergeCredentialsOverride(vpc, provider.CredentialsOverride{EncryptionKeyCRN: "crn:key"})
+	assert.Equal(t, "session-key", merged.G2APIKey)
+	assert.Equal(t, "rg-session", merged.G2ResourceGroupID)
+}
+
+func TestApplyCredentialsOverride(t *testing.T) {
+	override := provider.CredentialsOverride{ResourceGroupID: "rg-call", EncryptionKeyCRN: "crn:key"}
+
+	volume := &provider.Volume{}
+	ApplyCredentialsOverride(volume, override)
+	assert.Equal(t, "rg-call", volume.ResourceGroup.ID)
+	assert.Equal(t, "crn:key", volume.VolumeEncryptionKey.CRN)
+
+	volume = &provider.Volume{}
+	volume.ResourceGroup = &provider.ResourceGroup{ID: "rg-volume"}
+	volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "crn:volume"}
+	ApplyCredentialsOverride(volume, override)
+	assert.Equal(t, "rg-volume", volume.ResourceGroup.ID)
+	assert.Equal(t, "crn:volume", volume.VolumeEncryptionKey.CRN)
+
+	ApplyCredentialsOverride(nil, override)
+}
+
+func TestApplyCredentialsOverrideToContextCredentials(t *testing.T) {
+	creds := provider.ContextCredentials{AuthType: provider.IAMAccessToken, Credential: "token", IAMAccountID: "acc"}
+
+	assert.Equal(t, creds, ApplyCredentialsOverrideToContextCredentials(creds, provider.CredentialsOverride{ResourceGroupID: "rg"}))
+
+	merged := ApplyCredentialsOverrideToContextCredentials(creds, provider.CredentialsOverride{APIKey: "call-key"})
+	assert.Equal(t, provider.IAMAPIKey, merged.AuthType)
+	assert.Equal(t, "call-key", merged.Credential)
+	assert.Equal(t, "acc", merged.IAMAccountID)
+}
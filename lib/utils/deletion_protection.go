@@ -0,0 +1,124 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// DeletionProtectionTag marks a volume protected against deletion when the provider has no native deletion
+// protection
+var DeletionProtectionTag = TagFromKeyValue("deletion-protection", "enabled")
+
+// DeleteOptions are the options of DeleteVolumeWithProtection
+type DeleteOptions struct {
+	// Force deletes the volume even if it is protected against deletion
+	Force bool
+}
+
+// IsDeletionProtectionTagged returns whether the volume carries the DeletionProtectionTag
+func IsDeletionProtectionTagged(volume *provider.Volume) bool {
+	if volume == nil {
+		return false
+	}
+	for _, tag := range volume.Tags {
+		if tag == DeletionProtectionTag {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDeletionProtection enables or disables the deletion protection of the volume, natively if the session
+// implements provider.DeletionProtectionManager, otherwise with the DeletionProtectionTag
+func SetDeletionProtection(ctx context.Context, sess provider.VolumeManager, volumeID string, enabled bool, logger *zap.Logger) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	logger.Info("Setting volume deletion protection", zap.String("volumeID", volumeID), zap.Bool("enabled", enabled))
+	details := map[string]string{"enabled": strconv.FormatBool(enabled)}
+	if manager, isManager := sess.(provider.DeletionProtectionManager); isManager {
+		return RunMutation(ctx, "SetDeletionProtection", volumeID, details, func() error {
+			return manager.SetDeletionProtection(ctx, volumeID, enabled)
+		})
+	}
+
+	volume, err := sess.GetVolume(volumeID)
+	if err != nil {
+		return err
+	}
+	if IsDeletionProtectionTagged(volume) == enabled {
+		return nil
+	}
+	tags := make([]string, 0, len(volume.Tags)+1)
+	for _, tag := range volume.Tags {
+		if tag != DeletionProtectionTag {
+			tags = append(tags, tag)
+		}
+	}
+	if enabled {
+		tags = append(tags, DeletionProtectionTag)
+	}
+	update := provider.Volume{VolumeID: volumeID}
+	update.Tags = tags
+	return RunMutation(ctx, "UpdateVolume", volumeID, details, func() error {
+		return sess.UpdateVolume(update)
+	})
+}
+
+// IsDeletionProtected returns whether the volume is protected against deletion
+func IsDeletionProtected(ctx context.Context, sess provider.VolumeManager, volumeID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if manager, isManager := sess.(provider.DeletionProtectionManager); isManager {
+		return manager.IsDeletionProtected(ctx, volumeID)
+	}
+	volume, err := sess.GetVolume(volumeID)
+	if err != nil {
+		return false, err
+	}
+	return IsDeletionProtectionTagged(volume), nil
+}
+
+// DeleteVolumeWithProtection deletes the volume unless it is protected against deletion, in which case
+// provider.ErrDeletionProtected is returned. options.Force deletes protected volumes
+func DeleteVolumeWithProtection(ctx context.Context, sess provider.VolumeManager, volume *provider.Volume, options DeleteOptions, logger *zap.Logger) error {
+	protected, err := IsDeletionProtected(ctx, sess, volume.VolumeID)
+	if err != nil {
+		return err
+	}
+	if protected {
+		if !options.Force {
+			logger.Warn("Refusing to delete volume protected against deletion", zap.String("volumeID", volume.VolumeID))
+			return NewErrorWithProperties(reasoncode.ErrorVolumeDeletionProtected,
+				fmt.Sprintf("Volume %s is protected against deletion, disable the protection or force the deletion", volume.VolumeID),
+				map[string]string{VolumeIDProperty: volume.VolumeID})
+		}
+		logger.Warn("Force deleting volume protected against deletion", zap.String("volumeID", volume.VolumeID))
+	}
+	return RunMutation(ctx, "DeleteVolume", volume.VolumeID, map[string]string{"force": strconv.FormatBool(options.Force)}, func() error {
+		return sess.DeleteVolume(volume)
+	})
+}
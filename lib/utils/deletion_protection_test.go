@@ -0,0 +1,107 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type nativeProtectionSession struct {
+	*fake.FakeSession
+	protected map[string]bool
+}
+
+func (s *nativeProtectionSession) SetDeletionProtection(ctx context.Context, volumeID string, enabled bool) error {
+	s.protected[volumeID] = enabled
+	return nil
+}
+
+func (s *nativeProtectionSession) IsDeletionProtected(ctx context.Context, volumeID string) (bool, error) {
+	return s.protected[volumeID], nil
+}
+
+func taggedVolume(id string, tags ...string) *provider.Volume {
+	volume := &provider.Volume{VolumeID: id}
+	volume.Tags = tags
+	return volume
+}
+
+func TestSetDeletionProtectionWithTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx := context.Background()
+
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(taggedVolume("vol-1", "env:prod"), nil)
+	assert.Nil(t, SetDeletionProtection(ctx, sess, "vol-1", true, logger))
+	assert.Equal(t, 1, sess.UpdateVolumeCallCount())
+	assert.Equal(t, []string{"env:prod", DeletionProtectionTag}, sess.UpdateVolumeArgsForCall(0).Tags)
+
+	// already protected, no update
+	sess.GetVolumeReturns(taggedVolume("vol-1", "env:prod", DeletionProtectionTag), nil)
+	assert.Nil(t, SetDeletionProtection(ctx, sess, "vol-1", true, logger))
+	assert.Equal(t, 1, sess.UpdateVolumeCallCount())
+
+	assert.Nil(t, SetDeletionProtection(ctx, sess, "vol-1", false, logger))
+	assert.Equal(t, []string{"env:prod"}, sess.UpdateVolumeArgsForCall(1).Tags)
+
+	sess.GetVolumeReturns(nil, errors.New("not found"))
+	assert.NotNil(t, SetDeletionProtection(ctx, sess, "vol-1", true, logger))
+}
+
+func TestSetDeletionProtectionNative(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &nativeProtectionSession{FakeSession: &fake.FakeSession{}, protected: map[string]bool{}}
+
+	assert.Nil(t, SetDeletionProtection(context.Background(), sess, "vol-1", true, logger))
+	assert.True(t, sess.protected["vol-1"])
+	assert.Equal(t, 0, sess.GetVolumeCallCount())
+
+	protected, err := IsDeletionProtected(context.Background(), sess, "vol-1")
+	assert.Nil(t, err)
+	assert.True(t, protected)
+}
+
+func TestDeleteVolumeWithProtection(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx := context.Background()
+	volume := taggedVolume("vol-1", DeletionProtectionTag)
+
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(volume, nil)
+	err := DeleteVolumeWithProtection(ctx, sess, volume, DeleteOptions{}, logger)
+	assert.True(t, errors.Is(err, provider.ErrDeletionProtected))
+	assert.Equal(t, 0, sess.DeleteVolumeCallCount())
+
+	assert.Nil(t, DeleteVolumeWithProtection(ctx, sess, volume, DeleteOptions{Force: true}, logger))
+	assert.Equal(t, 1, sess.DeleteVolumeCallCount())
+
+	sess.GetVolumeReturns(taggedVolume("vol-2"), nil)
+	assert.Nil(t, DeleteVolumeWithProtection(ctx, sess, taggedVolume("vol-2"), DeleteOptions{}, logger))
+	assert.Equal(t, 2, sess.DeleteVolumeCallCount())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.NotNil(t, DeleteVolumeWithProtection(cancelled, sess, volume, DeleteOptions{}, logger))
+}
@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+)
+
+var dryRunKey = ctxkeys.NewKey[*DryRunPlan]("dry-run")
+
+// PlannedStep is a mutating step simulated in dry run
+type PlannedStep struct {
+	Operation string            `json:"operation"`
+	Target    string            `json:"target"`
+	Details   map[string]string `json:"details,omitempty"`
+	PlannedAt time.Time         `json:"plannedAt"`
+}
+
+// DryRunPlan records the mutating steps simulated by the helpers run with a dry run context
+type DryRunPlan struct {
+	mu    sync.Mutex
+	steps []PlannedStep
+}
+
+// WithDryRun returns a context marking the operations run with it as dry run, and the plan recording
+// their simulated mutating steps
+func WithDryRun(ctx context.Context) (context.Context, *DryRunPlan) {
+	plan := &DryRunPlan{}
+	return dryRunKey.WithValue(ctx, plan), plan
+}
+
+// IsDryRun returns true if the context is marked as dry run
+func IsDryRun(ctx context.Context) bool {
+	plan, _ := dryRunKey.Value(ctx)
+	return plan != nil
+}
+
+// Steps returns the planned steps in the order they were recorded
+func (p *DryRunPlan) Steps() []PlannedStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedStep(nil), p.steps...)
+}
+
+// JSON returns the planned steps as JSON, the plan output of the dry run
+func (p *DryRunPlan) JSON() ([]byte, error) {
+	return json.MarshalIndent(p.Steps(), "", "  ")
+}
+
+func (p *DryRunPlan) record(step PlannedStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, step)
+}
+
+// RunMutation runs the mutating step, or only records it in the plan if the context is marked as dry run.
+// Helpers and provider implementations wrap every mutating call with it. The completed step is recorded by the
+// activity tracker of the context, if any (see WithActivityTracker). The step is refused during a maintenance
+// freeze (see SetReadOnly), only the dry run plans are still recorded
+func RunMutation(ctx context.Context, operation, target string, details map[string]string, mutate func() error) error {
+	if plan, _ := dryRunKey.Value(ctx); plan != nil {
+		plan.record(PlannedStep{Operation: operation, Target: target, Details: details, PlannedAt: time.Now()})
+		return nil
+	}
+	if err := DefaultMaintenanceFreeze.Check(operation); err != nil {
+		return err
+	}
+	err := mutate()
+	if tracker, found := ActivityTrackerFromContext(ctx); found {
+		tracker.Record(ctx, operation, target, details, err)
+	}
+	return err
+}
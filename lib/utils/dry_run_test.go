@@ -0,0 +1,92 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRunMutation(t *testing.T) {
+	calls := 0
+	mutate := func() error {
+		calls++
+		return errors.New("mutation failed")
+	}
+
+	assert.False(t, IsDryRun(context.Background()))
+	assert.NotNil(t, RunMutation(context.Background(), "DeleteVolume", "vol-1", nil, mutate))
+	assert.Equal(t, 1, calls)
+
+	ctx, plan := WithDryRun(context.Background())
+	assert.True(t, IsDryRun(ctx))
+	assert.Nil(t, RunMutation(ctx, "DeleteVolume", "vol-1", map[string]string{"force": "true"}, mutate))
+	assert.Equal(t, 1, calls)
+
+	steps := plan.Steps()
+	assert.Equal(t, 1, len(steps))
+	assert.Equal(t, "DeleteVolume", steps[0].Operation)
+	assert.Equal(t, "vol-1", steps[0].Target)
+	assert.Equal(t, "true", steps[0].Details["force"])
+
+	out, err := plan.JSON()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(string(out), `"operation": "DeleteVolume"`))
+}
+
+func TestDryRunHelpers(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ctx, plan := WithDryRun(context.Background())
+
+	sess := &fake.FakeSession{}
+	volume := taggedVolume("vol-1")
+	sess.GetVolumeReturns(volume, nil)
+	assert.Nil(t, SetDeletionProtection(ctx, sess, "vol-1", true, logger))
+	assert.Nil(t, DeleteVolumeWithProtection(ctx, sess, volume, DeleteOptions{}, logger))
+	response, err := AttachVolumeWithZoneLimit(ctx, sess, NewZoneSemaphore(1), "us-south-1", provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "i-1"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "i-1", response.InstanceID)
+
+	now := time.Now()
+	sess.ListSnapshotsReturns(&provider.SnapshotList{Snapshots: []*provider.Snapshot{
+		{VolumeID: "vol-1", SnapshotID: "snap-1", SnapshotCreationTime: now.Add(-2 * time.Hour)},
+		{VolumeID: "vol-1", SnapshotID: "snap-2", SnapshotCreationTime: now.Add(-time.Hour)},
+	}}, nil)
+	report, err := PruneSnapshots(ctx, sess, "vol-1", 1, 0, false, logger)
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+
+	assert.Equal(t, 0, sess.UpdateVolumeCallCount())
+	assert.Equal(t, 0, sess.DeleteVolumeCallCount())
+	assert.Equal(t, 0, sess.AttachVolumeCallCount())
+	assert.Equal(t, 0, sess.DeleteSnapshotCallCount())
+
+	operations := []string{}
+	for _, step := range plan.Steps() {
+		operations = append(operations, step.Operation+" "+step.Target)
+	}
+	assert.Equal(t, []string{"UpdateVolume vol-1", "DeleteVolume vol-1", "AttachVolume vol-1", "DeleteSnapshot snap-1"}, operations)
+}
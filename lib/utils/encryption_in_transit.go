@@ -0,0 +1,78 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// EncryptionInTransitParameter is the StorageClass parameter requiring encryption in transit
+const EncryptionInTransitParameter = "encryptionInTransit"
+
+// encryptionInTransitProfiles are the share profiles supporting encryption in transit, used when the
+// share does not report its allowed transit encryption modes
+var encryptionInTransitProfiles = map[string]bool{
+	"dp2": true,
+}
+
+// ParseEncryptionInTransit returns the value of the encryptionInTransit StorageClass parameter, false if not set
+func ParseEncryptionInTransit(parameters map[string]string) (bool, error) {
+	value, ok := parameters[EncryptionInTransitParameter]
+	if !ok || value == "" {
+		return false, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid value '%s' for parameter %s", value, EncryptionInTransitParameter), err)
+	}
+	return enabled, nil
+}
+
+// SupportsEncryptionInTransit reports whether access points of the volume (share) can use encryption in transit
+func SupportsEncryptionInTransit(volume provider.Volume) bool {
+	if len(volume.AllowedTransitEncryptionModes) > 0 {
+		for _, mode := range volume.AllowedTransitEncryptionModes {
+			if mode == provider.TransitEncryptionUserManaged {
+				return true
+			}
+		}
+		return false
+	}
+	return volume.Profile != nil && encryptionInTransitProfiles[volume.Profile.Name]
+}
+
+// ValidateEncryptionInTransit returns an ErrorUnsupportedFeature error if the access point request requires
+// encryption in transit but the volume (share) does not support it
+func ValidateEncryptionInTransit(volume provider.Volume, request provider.VolumeAccessPointRequest) error {
+	if request.EncryptionInTransit && !SupportsEncryptionInTransit(volume) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Encryption in transit is not supported by volume %s", volume.VolumeID))
+	}
+	return nil
+}
+
+// TransitEncryptionMode returns the access point transit encryption mode for the request
+func TransitEncryptionMode(request provider.VolumeAccessPointRequest) string {
+	if request.EncryptionInTransit {
+		return provider.TransitEncryptionUserManaged
+	}
+	return provider.TransitEncryptionNone
+}
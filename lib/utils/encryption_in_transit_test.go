@@ -0,0 +1,60 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEncryptionInTransit(t *testing.T) {
+	enabled, err := ParseEncryptionInTransit(map[string]string{})
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	enabled, err = ParseEncryptionInTransit(map[string]string{EncryptionInTransitParameter: "true"})
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	_, err = ParseEncryptionInTransit(map[string]string{EncryptionInTransitParameter: "maybe"})
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+}
+
+func TestValidateEncryptionInTransit(t *testing.T) {
+	request := provider.VolumeAccessPointRequest{EncryptionInTransit: true}
+
+	share := provider.Volume{VolumeID: "share-1"}
+	share.AllowedTransitEncryptionModes = []string{provider.TransitEncryptionNone, provider.TransitEncryptionUserManaged}
+	assert.Nil(t, ValidateEncryptionInTransit(share, request))
+
+	share.AllowedTransitEncryptionModes = []string{provider.TransitEncryptionNone}
+	assert.Equal(t, reasoncode.ErrorUnsupportedFeature, ErrorReasonCode(ValidateEncryptionInTransit(share, request)))
+	assert.Nil(t, ValidateEncryptionInTransit(share, provider.VolumeAccessPointRequest{}))
+
+	share.AllowedTransitEncryptionModes = nil
+	share.Profile = &provider.Profile{Name: "dp2"}
+	assert.Nil(t, ValidateEncryptionInTransit(share, request))
+	share.Profile = &provider.Profile{Name: "tier-3iops"}
+	assert.NotNil(t, ValidateEncryptionInTransit(share, request))
+
+	assert.Equal(t, provider.TransitEncryptionUserManaged, TransitEncryptionMode(request))
+	assert.Equal(t, provider.TransitEncryptionNone, TransitEncryptionMode(provider.VolumeAccessPointRequest{}))
+}
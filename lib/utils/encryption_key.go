@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+const (
+	// KeyProtectServiceName is the CRN service name of the Key Protect keys (BYOK)
+	KeyProtectServiceName = "kms"
+
+	// HyperProtectCryptoServiceName is the CRN service name of the Hyper Protect Crypto Services keys (KYOK)
+	HyperProtectCryptoServiceName = "hs-crypto"
+
+	// EncryptionKeyCRNAttribute is the volume attribute with the CRN of the root key encrypting the volume
+	EncryptionKeyCRNAttribute = "encryptionKeyCRN"
+
+	// crnSegments is the number of segments of a CRN
+	// (crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource)
+	crnSegments = 10
+)
+
+// CRN is a parsed Cloud Resource Name
+type CRN struct {
+	Version         string
+	CName           string
+	CType           string
+	ServiceName     string
+	Location        string
+	Scope           string
+	ServiceInstance string
+	ResourceType    string
+	Resource        string
+}
+
+// ParseCRN parses the CRN, an ErrorBadRequest error if it has not the ten segments of a CRN
+func ParseCRN(crn string) (CRN, error) {
+	segments := strings.Split(crn, ":")
+	if len(segments) != crnSegments || segments[0] != "crn" {
+		return CRN{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid CRN '%s'", crn))
+	}
+	return CRN{
+		Version:         segments[1],
+		CName:           segments[2],
+		CType:           segments[3],
+		ServiceName:     segments[4],
+		Location:        segments[5],
+		Scope:           segments[6],
+		ServiceInstance: segments[7],
+		ResourceType:    segments[8],
+		Resource:        segments[9],
+	}, nil
+}
+
+// String returns the CRN string
+func (c CRN) String() string {
+	return strings.Join([]string{"crn", c.Version, c.CName, c.CType, c.ServiceName, c.Location, c.Scope, c.ServiceInstance, c.ResourceType, c.Resource}, ":")
+}
+
+// ParseEncryptionKeyCRN parses the CRN of a customer managed root key, a key of a Key Protect (BYOK) or Hyper
+// Protect Crypto Services (KYOK) instance. It returns an ErrorBadRequest error if the CRN is not the CRN of a key
+func ParseEncryptionKeyCRN(crn string) (CRN, error) {
+	parsed, err := ParseCRN(strings.TrimSpace(crn))
+	if err != nil {
+		return CRN{}, err
+	}
+	if parsed.ServiceName != KeyProtectServiceName && parsed.ServiceName != HyperProtectCryptoServiceName {
+		return CRN{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("CRN '%s' is not a Key Protect or Hyper Protect Crypto Services CRN", crn))
+	}
+	if parsed.ResourceType != "key" || parsed.ServiceInstance == "" || parsed.Resource == "" {
+		return CRN{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("CRN '%s' is not the CRN of a root key", crn))
+	}
+	return parsed, nil
+}
+
+// ValidateEncryptionKey returns an ErrorBadRequest error if the encryption key of the volume to create is set but
+// is not the CRN of a root key, e.g. before CreateVolume
+func ValidateEncryptionKey(volume provider.Volume) error {
+	if volume.VolumeEncryptionKey == nil || volume.VolumeEncryptionKey.CRN == "" {
+		return nil
+	}
+	_, err := ParseEncryptionKeyCRN(volume.VolumeEncryptionKey.CRN)
+	return err
+}
+
+// IsEncryptionKeyNotAuthorized returns true if the error is an ErrorEncryptionKeyNotAuthorized error, the storage
+// service must be authorized to read the key (IAM service to service authorization) before retrying
+func IsEncryptionKeyNotAuthorized(err error) bool {
+	return ErrorReasonCode(err) == reasoncode.ErrorEncryptionKeyNotAuthorized
+}
+
+// SetEncryptionKeyAttribute publishes the CRN of the encryption key of the volume in its attributes (e.g. the CSI
+// volume context), so the key round trips to the volumes created from it. Provider managed encryption has no attribute
+func SetEncryptionKeyAttribute(volume *provider.Volume) {
+	if volume == nil || volume.VolumeEncryptionKey == nil || volume.VolumeEncryptionKey.CRN == "" {
+		return
+	}
+	if volume.Attributes == nil {
+		volume.Attributes = map[string]string{}
+	}
+	volume.Attributes[EncryptionKeyCRNAttribute] = volume.VolumeEncryptionKey.CRN
+}
+
+// EncryptionKeyFromAttributes returns the encryption key published in the volume attributes, nil if none
+func EncryptionKeyFromAttributes(attributes map[string]string) *provider.VolumeEncryptionKey {
+	crn := strings.TrimSpace(attributes[EncryptionKeyCRNAttribute])
+	if crn == "" {
+		return nil
+	}
+	return &provider.VolumeEncryptionKey{CRN: crn}
+}
@@ -0,0 +1,95 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	keyProtectCRN = "crn:v1:bluemix:public:kms:us-south:a/account:2d7b1c9e-inst:key:02fd6835-key"
+	hpcsCRN       = "crn:v1:bluemix:public:hs-crypto:us-south:a/account:8c4c2f1a-inst:key:5b1e9e7d-key"
+)
+
+func TestParseEncryptionKeyCRN(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		crn          string
+		expectedErr  bool
+	}{
+		{testcasename: "Key Protect key", crn: keyProtectCRN},
+		{testcasename: "Hyper Protect Crypto Services key", crn: hpcsCRN},
+		{testcasename: "Not a CRN", crn: "02fd6835-key", expectedErr: true},
+		{testcasename: "Missing segments", crn: "crn:v1:bluemix:public:kms:us-south:a/account:inst:key", expectedErr: true},
+		{testcasename: "Other service", crn: "crn:v1:bluemix:public:is:us-south-1:a/account::volume:r006-vol", expectedErr: true},
+		{testcasename: "Not a key", crn: "crn:v1:bluemix:public:kms:us-south:a/account:inst:instance:inst", expectedErr: true},
+		{testcasename: "Missing instance", crn: "crn:v1:bluemix:public:kms:us-south:a/account::key:02fd6835-key", expectedErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			parsed, err := ParseEncryptionKeyCRN(testcase.crn)
+			if testcase.expectedErr {
+				assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "key", parsed.ResourceType)
+			assert.Equal(t, testcase.crn, parsed.String())
+		})
+	}
+}
+
+func TestValidateEncryptionKey(t *testing.T) {
+	volume := provider.Volume{}
+	assert.Nil(t, ValidateEncryptionKey(volume))
+
+	volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: hpcsCRN}
+	assert.Nil(t, ValidateEncryptionKey(volume))
+
+	volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "crn:key"}
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateEncryptionKey(volume)))
+}
+
+func TestEncryptionKeyNotAuthorized(t *testing.T) {
+	err := DefaultErrorClassifier.Classify(errors.New(`{"errors":[{"code":"encryption_key_not_authorized","message":"The service is not authorized to use the encryption key"}]}`))
+	assert.True(t, IsEncryptionKeyNotAuthorized(err))
+	assert.Equal(t, provider.CategoryPermissionDenied, ErrorCategoryOf(err))
+	assert.False(t, IsRetryableError(err))
+	assert.False(t, IsEncryptionKeyNotAuthorized(NewError(reasoncode.ErrorInsufficientPermissions, "denied")))
+}
+
+func TestEncryptionKeyAttributeRoundTrip(t *testing.T) {
+	volume := &provider.Volume{VolumeID: "vol-1"}
+	SetEncryptionKeyAttribute(volume)
+	assert.Nil(t, volume.Attributes)
+	assert.Nil(t, EncryptionKeyFromAttributes(volume.Attributes))
+
+	volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: keyProtectCRN}
+	SetEncryptionKeyAttribute(volume)
+	assert.Equal(t, &provider.VolumeEncryptionKey{CRN: keyProtectCRN}, EncryptionKeyFromAttributes(volume.Attributes))
+
+	masked := FieldMask{FieldCRN}.MaskVolume(volume)
+	assert.Nil(t, masked.VolumeEncryptionKey)
+	assert.NotContains(t, masked.Attributes, EncryptionKeyCRNAttribute)
+	assert.Contains(t, volume.Attributes, EncryptionKeyCRNAttribute)
+}
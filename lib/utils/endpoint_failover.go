@@ -0,0 +1,164 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultEndpointFailoverThreshold is the default number of consecutive failed probes of the preferred
+	// endpoint before failing over
+	DefaultEndpointFailoverThreshold = 3
+
+	// DefaultEndpointFailbackThreshold is the default number of consecutive successful probes of the preferred
+	// endpoint before failing back
+	DefaultEndpointFailbackThreshold = 5
+)
+
+// EndpointProbe checks the health of the endpoint, a nil error is healthy
+type EndpointProbe func(ctx context.Context, endpoint string) error
+
+// HTTPEndpointProbe returns a probe sending a HEAD request to the endpoint, any response but a 5xx is healthy
+func HTTPEndpointProbe(client *http.Client) EndpointProbe {
+	return func(ctx context.Context, endpoint string) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+		if err != nil {
+			return err
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			return err
+		}
+		_ = response.Body.Close()
+		if response.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("endpoint %s returned %s", endpoint, response.Status)
+		}
+		return nil
+	}
+}
+
+// EndpointFailover selects between a preferred (e.g. private) and a fallback (e.g. public) endpoint with active
+// health probing. Hysteresis avoids flapping: it fails over after failoverThreshold consecutive failed probes
+// of the preferred endpoint and fails back after failbackThreshold consecutive successful ones
+type EndpointFailover struct {
+	preferred         string
+	fallback          string
+	probe             EndpointProbe
+	failoverThreshold int
+	failbackThreshold int
+	logger            *zap.Logger
+
+	mu        sync.RWMutex
+	active    string
+	failures  int
+	successes int
+}
+
+// NewEndpointFailover returns a failover using the preferred endpoint until probes fail, non-positive thresholds
+// are replaced by the defaults
+func NewEndpointFailover(preferred, fallback string, probe EndpointProbe, failoverThreshold, failbackThreshold int, logger *zap.Logger) *EndpointFailover {
+	if failoverThreshold <= 0 {
+		failoverThreshold = DefaultEndpointFailoverThreshold
+	}
+	if failbackThreshold <= 0 {
+		failbackThreshold = DefaultEndpointFailbackThreshold
+	}
+	return &EndpointFailover{
+		preferred:         preferred,
+		fallback:          fallback,
+		probe:             probe,
+		failoverThreshold: failoverThreshold,
+		failbackThreshold: failbackThreshold,
+		logger:            logger,
+		active:            preferred,
+	}
+}
+
+// NewEndpointFailoverFromConfig returns the failover between the private (preferred) and public endpoints of the
+// VPC config and its probe interval. A nil failover is returned if probing is not enabled or an endpoint is missing
+func NewEndpointFailoverFromConfig(vpc *config.VPCProviderConfig, probe EndpointProbe, logger *zap.Logger) (*EndpointFailover, time.Duration, error) {
+	if vpc == nil || vpc.EndpointHealthProbeInterval == "" || vpc.EndpointURL == "" || vpc.PrivateEndpointURL == "" {
+		return nil, 0, nil
+	}
+	interval, err := time.ParseDuration(vpc.EndpointHealthProbeInterval)
+	if err != nil || interval <= 0 {
+		return nil, 0, fmt.Errorf("invalid endpoint health probe interval '%s'", vpc.EndpointHealthProbeInterval)
+	}
+	return NewEndpointFailover(vpc.PrivateEndpointURL, vpc.EndpointURL, probe, vpc.EndpointFailoverThreshold, vpc.EndpointFailbackThreshold, logger), interval, nil
+}
+
+// Active returns the endpoint to use
+func (f *EndpointFailover) Active() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.active
+}
+
+// Probe probes both endpoints and fails over or back if a threshold is reached. It is meant to run as a
+// background task (see TaskScheduler), an error is returned if no endpoint is healthy
+func (f *EndpointFailover) Probe(ctx context.Context) error {
+	preferredErr := f.probe(ctx, f.preferred)
+	metrics.SetEndpointHealth(f.preferred, preferredErr == nil)
+	var fallbackErr error
+	if f.fallback != "" {
+		fallbackErr = f.probe(ctx, f.fallback)
+		metrics.SetEndpointHealth(f.fallback, fallbackErr == nil)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if preferredErr != nil {
+		f.failures++
+		f.successes = 0
+		if f.active == f.preferred && f.fallback != "" && fallbackErr == nil && f.failures >= f.failoverThreshold {
+			f.switchTo(f.fallback, preferredErr)
+		}
+	} else {
+		f.successes++
+		f.failures = 0
+		if f.active != f.preferred && f.successes >= f.failbackThreshold {
+			f.switchTo(f.preferred, nil)
+		}
+	}
+
+	if preferredErr != nil && (f.fallback == "" || fallbackErr != nil) {
+		return fmt.Errorf("no healthy endpoint: %v", preferredErr)
+	}
+	return nil
+}
+
+// switchTo changes the active endpoint, the caller holds f.mu
+func (f *EndpointFailover) switchTo(endpoint string, cause error) {
+	from := f.active
+	f.active = endpoint
+	metrics.RegisterEndpointSwitch(from, endpoint)
+	if cause != nil {
+		f.logger.Warn("Failing over to fallback endpoint", zap.String("From", from), zap.String("To", endpoint), zap.Int("FailedProbes", f.failures), ZapError(cause))
+		return
+	}
+	f.logger.Info("Failing back to preferred endpoint", zap.String("From", from), zap.String("To", endpoint), zap.Int("SuccessfulProbes", f.successes))
+}
@@ -0,0 +1,106 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestEndpointFailover(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	healthy := map[string]bool{"private": true, "public": true}
+	probe := func(ctx context.Context, endpoint string) error {
+		if !healthy[endpoint] {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+	failover := NewEndpointFailover("private", "public", probe, 2, 3, logger)
+	ctx := context.Background()
+	assert.Equal(t, "private", failover.Active())
+
+	// one failure is below the failover threshold
+	healthy["private"] = false
+	assert.Nil(t, failover.Probe(ctx))
+	assert.Equal(t, "private", failover.Active())
+	assert.Nil(t, failover.Probe(ctx))
+	assert.Equal(t, "public", failover.Active())
+
+	// failback only after 3 consecutive successes
+	healthy["private"] = true
+	assert.Nil(t, failover.Probe(ctx))
+	assert.Nil(t, failover.Probe(ctx))
+	healthy["private"] = false
+	assert.Nil(t, failover.Probe(ctx))
+	healthy["private"] = true
+	for i := 0; i < 2; i++ {
+		assert.Nil(t, failover.Probe(ctx))
+		assert.Equal(t, "public", failover.Active())
+	}
+	assert.Nil(t, failover.Probe(ctx))
+	assert.Equal(t, "private", failover.Active())
+
+	// no failover to an unhealthy fallback
+	healthy["private"], healthy["public"] = false, false
+	for i := 0; i < 3; i++ {
+		assert.NotNil(t, failover.Probe(ctx))
+	}
+	assert.Equal(t, "private", failover.Active())
+}
+
+func TestHTTPEndpointProbe(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	probe := HTTPEndpointProbe(server.Client())
+	assert.Nil(t, probe(context.Background(), server.URL))
+	status = http.StatusServiceUnavailable
+	assert.NotNil(t, probe(context.Background(), server.URL))
+	assert.NotNil(t, probe(context.Background(), "://invalid"))
+}
+
+func TestNewEndpointFailoverFromConfig(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	probe := func(ctx context.Context, endpoint string) error { return nil }
+
+	failover, _, err := NewEndpointFailoverFromConfig(&config.VPCProviderConfig{EndpointURL: "https://public"}, probe, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, failover)
+
+	vpc := &config.VPCProviderConfig{EndpointURL: "https://public", PrivateEndpointURL: "https://private", EndpointHealthProbeInterval: "30s"}
+	failover, interval, err := NewEndpointFailoverFromConfig(vpc, probe, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, interval)
+	assert.Equal(t, "https://private", failover.Active())
+
+	vpc.EndpointHealthProbeInterval = "soon"
+	_, _, err = NewEndpointFailoverFromConfig(vpc, probe, logger)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultLatencySmoothing is the default weight of a new sample in the exponential moving average
+const DefaultLatencySmoothing = 0.2
+
+// EndpointLatencyTracker tracks the exponential moving average (EMA) latency of each endpoint
+type EndpointLatencyTracker struct {
+	mu        sync.RWMutex
+	alpha     float64
+	latencies map[string]time.Duration
+}
+
+// NewEndpointLatencyTracker returns a tracker weighting new samples by alpha (0 < alpha <= 1),
+// DefaultLatencySmoothing is used for invalid values
+func NewEndpointLatencyTracker(alpha float64) *EndpointLatencyTracker {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultLatencySmoothing
+	}
+	return &EndpointLatencyTracker{alpha: alpha, latencies: make(map[string]time.Duration)}
+}
+
+// Observe records the latency of a call to the endpoint
+func (t *EndpointLatencyTracker) Observe(endpoint string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current, ok := t.latencies[endpoint]
+	if !ok {
+		t.latencies[endpoint] = latency
+		return
+	}
+	t.latencies[endpoint] = time.Duration(t.alpha*float64(latency) + (1-t.alpha)*float64(current))
+}
+
+// GetEndpointLatencies returns a copy of the current EMA latency of each observed endpoint
+func (t *EndpointLatencyTracker) GetEndpointLatencies() map[string]time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	latencies := make(map[string]time.Duration, len(t.latencies))
+	for endpoint, latency := range t.latencies {
+		latencies[endpoint] = latency
+	}
+	return latencies
+}
+
+// PreferredEndpoint returns the faster of the two endpoints. The primary endpoint is returned
+// if the secondary one is not configured or either of them has not been observed yet
+func (t *EndpointLatencyTracker) PreferredEndpoint(primary, secondary string) string {
+	if secondary == "" {
+		return primary
+	}
+	if primary == "" {
+		return secondary
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	primaryLatency, primaryOK := t.latencies[primary]
+	secondaryLatency, secondaryOK := t.latencies[secondary]
+	if primaryOK && secondaryOK && secondaryLatency < primaryLatency {
+		return secondary
+	}
+	return primary
+}
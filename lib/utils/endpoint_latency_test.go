@@ -0,0 +1,50 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointLatencyTracker(t *testing.T) {
+	tracker := NewEndpointLatencyTracker(0.5)
+	tracker.Observe("public", 100*time.Millisecond)
+	tracker.Observe("public", 200*time.Millisecond)
+	tracker.Observe("private", 120*time.Millisecond)
+
+	latencies := tracker.GetEndpointLatencies()
+	assert.Equal(t, 150*time.Millisecond, latencies["public"])
+	assert.Equal(t, 120*time.Millisecond, latencies["private"])
+
+	assert.Equal(t, "private", tracker.PreferredEndpoint("public", "private"))
+	assert.Equal(t, "public", tracker.PreferredEndpoint("public", ""))
+	assert.Equal(t, "private", tracker.PreferredEndpoint("", "private"))
+	assert.Equal(t, "public", tracker.PreferredEndpoint("public", "unknown"))
+
+	// Copies are returned
+	latencies["public"] = 0
+	assert.Equal(t, 150*time.Millisecond, tracker.GetEndpointLatencies()["public"])
+}
+
+func TestNewEndpointLatencyTrackerDefaults(t *testing.T) {
+	assert.Equal(t, DefaultLatencySmoothing, NewEndpointLatencyTracker(0).alpha)
+	assert.Equal(t, DefaultLatencySmoothing, NewEndpointLatencyTracker(2).alpha)
+}
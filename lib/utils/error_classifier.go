@@ -0,0 +1,258 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// ErrorClass is the classification of a backend error
+type ErrorClass struct {
+	ReasonCode reasoncode.ReasonCode
+}
+
+// Retryable reports whether the operation can be retried after an error of the class, per the category of its
+// reason code (see provider.ErrorCategories)
+func (c ErrorClass) Retryable() bool {
+	return provider.ClassifyReasonCode(c.ReasonCode).Retryable
+}
+
+// ErrorClassRule classifies the backend errors with the error code (exact match, case insensitive)
+// or the message pattern (fragment, case insensitive)
+type ErrorClassRule struct {
+	Code    string
+	Pattern string
+	Class   ErrorClass
+}
+
+var (
+	classRateLimited   = ErrorClass{ReasonCode: reasoncode.ErrorRateLimitExceeded}
+	classTemporary     = ErrorClass{ReasonCode: reasoncode.ErrorTemporaryConnectionProblem}
+	classUnauthorised  = ErrorClass{ReasonCode: reasoncode.ErrorUnauthorised}
+	classTokenExchange = ErrorClass{ReasonCode: reasoncode.ErrorFailedTokenExchange}
+	classPermissions   = ErrorClass{ReasonCode: reasoncode.ErrorInsufficientPermissions}
+	classEncryptionKey = ErrorClass{ReasonCode: reasoncode.ErrorEncryptionKeyNotAuthorized}
+	classLocked        = ErrorClass{ReasonCode: reasoncode.ErrorProviderAccountTemporarilyLocked}
+	classNotFound      = ErrorClass{ReasonCode: reasoncode.ErrorResourceNotFound}
+	classInstance      = ErrorClass{ReasonCode: reasoncode.ErrorInstanceNotFound}
+	classConflict      = ErrorClass{ReasonCode: reasoncode.ErrorVolumeAttachConflict}
+	classBadRequest    = ErrorClass{ReasonCode: reasoncode.ErrorBadRequest}
+	classQuota         = ErrorClass{ReasonCode: reasoncode.ErrorQuotaExceeded}
+	classCapacity      = ErrorClass{ReasonCode: reasoncode.ErrorInsufficientCapacity}
+)
+
+// DefaultErrorClassRules are the known RIaaS and IAM error codes and messages. Code rules are evaluated
+// before the pattern rules, so extend the table when the backend introduces a new error string
+var DefaultErrorClassRules = []ErrorClassRule{
+	// RIaaS error codes
+	{Code: "rate_limit_exceeded", Class: classRateLimited},
+	{Code: "too_many_requests", Class: classRateLimited},
+	{Code: "internal_error", Class: classTemporary},
+	{Code: "service_unavailable", Class: classTemporary},
+	{Code: "gateway_timeout", Class: classTemporary},
+	{Code: "not_authenticated", Class: classUnauthorised},
+	{Code: "token_expired", Class: classUnauthorised},
+	{Code: "encryption_key_not_authorized", Class: classEncryptionKey},
+	{Code: "not_authorized", Class: classPermissions},
+	{Code: "forbidden", Class: classPermissions},
+	{Code: "volume_not_found", Class: classNotFound},
+	{Code: "snapshot_not_found", Class: classNotFound},
+	{Code: "not_found", Class: classNotFound},
+	{Code: "instance_not_found", Class: classInstance},
+	{Code: "volume_attachment_conflict", Class: classConflict},
+	{Code: "over_quota", Class: classQuota},
+	{Code: "quota_exceeded", Class: classQuota},
+	{Code: "insufficient_capacity", Class: classCapacity},
+	{Code: "validation_invalid_argument", Class: classBadRequest},
+	{Code: "validation_required_field_missing", Class: ErrorClass{ReasonCode: reasoncode.ErrorRequiredFieldMissing}},
+	{Code: "bad_field", Class: classBadRequest},
+
+	// IAM error codes
+	{Code: "BXNIM0415E", Class: classTokenExchange}, // Provided API key could not be found
+	{Code: "BXNIM0407E", Class: classTokenExchange}, // Provided user not found or active
+	{Code: "BXNIM0408E", Class: classUnauthorised},  // Provided API key expired
+	{Code: "BXNIM0109E", Class: classLocked},        // Account is locked
+
+	// Message patterns
+	{Pattern: "rate limit", Class: classRateLimited},
+	{Pattern: "too many requests", Class: classRateLimited},
+	{Pattern: "connection reset", Class: classTemporary},
+	{Pattern: "connection refused", Class: classTemporary},
+	{Pattern: "i/o timeout", Class: classTemporary},
+	{Pattern: "tls handshake timeout", Class: classTemporary},
+	{Pattern: "unexpected eof", Class: classTemporary},
+	{Pattern: "service unavailable", Class: classTemporary},
+	{Pattern: "bad gateway", Class: classTemporary},
+	{Pattern: "temporarily locked", Class: classLocked},
+	{Pattern: "api key could not be found", Class: classTokenExchange},
+	{Pattern: "not authorized to use the encryption key", Class: classEncryptionKey},
+	{Pattern: "insufficient permissions", Class: classPermissions},
+	{Pattern: "not authorized", Class: classPermissions},
+	{Pattern: "instance not found", Class: classInstance},
+	{Pattern: "already attached", Class: classConflict},
+	{Pattern: "attached to another instance", Class: classConflict},
+	{Pattern: "quota exceeded", Class: classQuota},
+	{Pattern: "insufficient capacity", Class: classCapacity},
+}
+
+// ErrorClassifier classifies backend errors with a rule table
+type ErrorClassifier struct {
+	rules []ErrorClassRule
+}
+
+// NewErrorClassifier returns a classifier of the rules, DefaultErrorClassRules if none
+func NewErrorClassifier(rules ...ErrorClassRule) *ErrorClassifier {
+	if len(rules) == 0 {
+		rules = DefaultErrorClassRules
+	}
+	return &ErrorClassifier{rules: rules}
+}
+
+// DefaultErrorClassifier classifies with DefaultErrorClassRules
+var DefaultErrorClassifier = NewErrorClassifier()
+
+// backendErrorPayload is the error payload of RIaaS ({"errors":[{"code","message"}]}) and IAM ({"errorCode","errorMessage"})
+type backendErrorPayload struct {
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	ErrorCode    string `json:"errorCode"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// payloadCodes returns the error codes and messages of the JSON payload embedded in the message, if any
+func payloadCodes(message string) (codes []string, messages []string) {
+	start := strings.Index(message, "{")
+	end := strings.LastIndex(message, "}")
+	if start < 0 || end < start {
+		return nil, nil
+	}
+	payload := backendErrorPayload{}
+	if json.Unmarshal([]byte(message[start:end+1]), &payload) != nil {
+		return nil, nil
+	}
+	for _, e := range payload.Errors {
+		codes = append(codes, e.Code)
+		messages = append(messages, e.Message)
+	}
+	if payload.ErrorCode != "" {
+		codes = append(codes, payload.ErrorCode)
+		messages = append(messages, payload.ErrorMessage)
+	}
+	return codes, messages
+}
+
+// ClassifyMessages returns the class of the first rule matching the backend error messages (raw messages or JSON payloads)
+func (c *ErrorClassifier) ClassifyMessages(messages ...string) (ErrorClass, bool) {
+	var codes []string
+	for _, message := range messages {
+		payloadCodes, payloadMessages := payloadCodes(message)
+		codes = append(codes, payloadCodes...)
+		messages = append(messages, payloadMessages...)
+	}
+	for _, rule := range c.rules {
+		for _, code := range codes {
+			if rule.Code != "" && strings.EqualFold(rule.Code, code) {
+				return rule.Class, true
+			}
+		}
+	}
+	for _, rule := range c.rules {
+		for _, message := range messages {
+			if rule.Pattern != "" && strings.Contains(strings.ToLower(message), rule.Pattern) {
+				return rule.Class, true
+			}
+		}
+	}
+	return ErrorClass{ReasonCode: reasoncode.ErrorUnclassified}, false
+}
+
+// ClassifyResponse classifies a backend error response, falling back on the HTTP status code if no rule matches the body
+func (c *ErrorClassifier) ClassifyResponse(statusCode int, body []byte) ErrorClass {
+	if class, found := c.ClassifyMessages(string(body)); found {
+		return class
+	}
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return classRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return classTemporary
+	case statusCode == http.StatusUnauthorized:
+		return classUnauthorised
+	case statusCode == http.StatusForbidden:
+		return classPermissions
+	case statusCode == http.StatusNotFound:
+		return classNotFound
+	case statusCode >= http.StatusBadRequest:
+		return classBadRequest
+	}
+	return ErrorClass{ReasonCode: reasoncode.ErrorUnclassified}
+}
+
+// Classify returns err classified: errors with a reason code are returned unchanged, other errors matching
+// a rule are wrapped in a provider.Error of the reason code of the rule
+func (c *ErrorClassifier) Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	if code := ErrorReasonCode(err); code != "" && code != reasoncode.ErrorUnclassified {
+		return err
+	}
+	class, found := c.ClassifyMessages(append([]string{err.Error()}, ErrorDeepUnwrapString(err)...)...)
+	if !found {
+		return err
+	}
+	return NewError(class.ReasonCode, err.Error(), err)
+}
+
+// ClassifyError classifies err with DefaultErrorClassifier
+func ClassifyError(err error) error {
+	return DefaultErrorClassifier.Classify(err)
+}
+
+// IsRetryableError reports whether err (classified with DefaultErrorClassifier) can be retried, see provider.ErrorCategories
+func IsRetryableError(err error) bool {
+	return err != nil && ErrorClassificationOf(err).Retryable
+}
+
+// ErrorClassificationOf returns the category, fault kind and retryability of err (classified with
+// DefaultErrorClassifier), from the outermost provider.Error of its chain
+func ErrorClassificationOf(err error) provider.ErrorClassification {
+	var pErr provider.Error
+	if err == nil || !errors.As(ClassifyError(err), &pErr) {
+		return provider.ClassifyReasonCode(reasoncode.ErrorUnclassified)
+	}
+	return pErr.Classification()
+}
+
+// ErrorCategoryOf returns the category of err, e.g. provider.CategoryQuotaExceeded
+func ErrorCategoryOf(err error) provider.ErrorCategory {
+	return ErrorClassificationOf(err).Category
+}
+
+// IsUserError reports whether the caller is at fault for err, rather than the infrastructure
+func IsUserError(err error) bool {
+	return err != nil && ErrorClassificationOf(err).Kind == provider.FaultUser
+}
@@ -0,0 +1,149 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+// capturedBackendError is a captured RIaaS or IAM error response of testdata/backend_errors.json
+type capturedBackendError struct {
+	Name       string                `json:"name"`
+	Status     int                   `json:"status"`
+	Payload    string                `json:"payload"`
+	ReasonCode reasoncode.ReasonCode `json:"reasonCode"`
+	Retryable  bool                  `json:"retryable"`
+}
+
+func TestClassifyResponseCorpus(t *testing.T) {
+	data, err := os.ReadFile("testdata/backend_errors.json")
+	assert.Nil(t, err)
+	corpus := []capturedBackendError{}
+	assert.Nil(t, json.Unmarshal(data, &corpus))
+	assert.NotEmpty(t, corpus)
+
+	for _, captured := range corpus {
+		t.Run(captured.Name, func(t *testing.T) {
+			class := DefaultErrorClassifier.ClassifyResponse(captured.Status, []byte(captured.Payload))
+			assert.Equal(t, captured.ReasonCode, class.ReasonCode)
+			assert.Equal(t, captured.Retryable, class.Retryable())
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		err          error
+		expectedCode reasoncode.ReasonCode
+		retryable    bool
+	}{
+		{
+			testcasename: "Wrapped RIaaS payload",
+			err:          NewError(reasoncode.ErrorUnclassified, "list volumes failed", errors.New(`{"errors":[{"code":"rate_limit_exceeded","message":"Rate limit exceeded"}]}`)),
+			expectedCode: reasoncode.ErrorRateLimitExceeded,
+			retryable:    true,
+		},
+		{
+			testcasename: "Transport error",
+			err:          errors.New("Get \"https://us-south.iaas.cloud.ibm.com/v1/volumes\": read tcp 10.0.0.1:443: connection reset by peer"),
+			expectedCode: reasoncode.ErrorTemporaryConnectionProblem,
+			retryable:    true,
+		},
+		{
+			testcasename: "Already classified",
+			err:          NewError(reasoncode.ErrorBadRequest, "rate limit is not a valid parameter"),
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Unknown",
+			err:          errors.New("something new"),
+			expectedCode: reasoncode.ErrorUnclassified,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			classified := ClassifyError(testcase.err)
+			assert.Equal(t, testcase.expectedCode, ErrorReasonCode(classified))
+			assert.Equal(t, testcase.retryable, IsRetryableError(testcase.err))
+		})
+	}
+	assert.Nil(t, ClassifyError(nil))
+	assert.False(t, IsRetryableError(nil))
+}
+
+func TestCustomErrorClassifier(t *testing.T) {
+	classifier := NewErrorClassifier(ErrorClassRule{Code: "quota_exceeded", Class: ErrorClass{ReasonCode: reasoncode.ErrorBadRequest}})
+	class, found := classifier.ClassifyMessages(`{"errors":[{"code":"QUOTA_EXCEEDED","message":"Quota exceeded"}]}`)
+	assert.True(t, found)
+	assert.Equal(t, reasoncode.ErrorBadRequest, class.ReasonCode)
+
+	_, found = classifier.ClassifyMessages("rate limit exceeded")
+	assert.False(t, found)
+}
+
+func TestErrorCategoryOf(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		err          error
+		category     provider.ErrorCategory
+		userFault    bool
+		retryable    bool
+	}{
+		{testcasename: "Quota exceeded", err: errors.New(`{"errors":[{"code":"over_quota","message":"quota"}]}`), category: provider.CategoryQuotaExceeded, userFault: true},
+		{testcasename: "Auth failure", err: NewError(reasoncode.ErrorFailedTokenExchange, "token exchange failed"), category: provider.CategoryAuthFailure, userFault: true},
+		{testcasename: "Not found", err: provider.ErrInstanceNotFound, category: provider.CategoryNotFound, userFault: true},
+		{testcasename: "Wrapped rate limited", err: fmt.Errorf("list volumes: %w", NewError(reasoncode.ErrorRateLimitExceeded, "rate limited")), category: provider.CategoryRateLimited, retryable: true},
+		{testcasename: "Unknown", err: errors.New("something happened"), category: provider.CategoryUnknown},
+		{testcasename: "Nil", err: nil, category: provider.CategoryUnknown},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			assert.Equal(t, testcase.category, ErrorCategoryOf(testcase.err))
+			assert.Equal(t, testcase.userFault, IsUserError(testcase.err))
+			assert.Equal(t, testcase.retryable, ErrorClassificationOf(testcase.err).Retryable)
+		})
+	}
+}
+
+func TestErrorCategoriesCoverClassRules(t *testing.T) {
+	for _, rule := range DefaultErrorClassRules {
+		_, found := provider.ErrorCategories[rule.Class.ReasonCode]
+		assert.True(t, found, "reason code %s has no category", rule.Class.ReasonCode)
+	}
+}
+
+func TestErrorFaultOverrides(t *testing.T) {
+	err := NewError(reasoncode.ErrorBadRequest, "bad request").(provider.Error)
+	assert.False(t, err.Retryable())
+	assert.True(t, err.IsUserFault())
+
+	err.Fault.Retryable = true
+	err.Fault.Kind = provider.FaultInfrastructure
+	assert.True(t, err.Retryable())
+	assert.False(t, err.IsUserFault())
+	assert.Equal(t, provider.CategoryInvalidRequest, err.Category())
+}
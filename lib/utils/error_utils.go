@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
 	"go.uber.org/zap"
@@ -31,6 +32,7 @@ import (
 // NewError returns an error that is implemented by provider.Error.
 // If optional wrapped errors are a provider.Error, this preserves all child wrapped
 // errors in depth-first order.
+// The first wrapped error is the cause, reachable with errors.Is and errors.As.
 func NewError(code reasoncode.ReasonCode, msg string, wrapped ...error) error {
 	return NewErrorWithProperties(code, msg, nil, wrapped...)
 }
@@ -44,8 +46,12 @@ func NewErrorWithProperties(code reasoncode.ReasonCode, msg string, properties m
 		code = "" // TODO: ErrorUnclassified
 	}
 	var werrs []string
+	var cause error
 	for _, w := range wrapped {
 		if w != nil {
+			if cause == nil {
+				cause = w
+			}
 			werrs = append(werrs, w.Error())
 			if p, isPerr := w.(provider.Error); isPerr {
 				werrs = append(werrs, p.Wrapped()...)
@@ -59,6 +65,7 @@ func NewErrorWithProperties(code reasoncode.ReasonCode, msg string, properties m
 			Properties: properties,
 			Wrapped:    werrs,
 		},
+		Cause: cause,
 	}
 }
 
@@ -137,6 +144,12 @@ type ErrorRetrier struct {
 	MaxAttempts   int
 	RetryInterval time.Duration
 	Logger        *zap.Logger
+
+	// Operation labels the retries recorded in the metrics, "unknown" if empty
+	Operation string
+
+	// Policy, if set, is used instead of MaxAttempts and RetryInterval, e.g. for an exponential backoff
+	Policy *RetryPolicy
 }
 
 //NewErrorRetrier return new ErrorRetrier
@@ -148,8 +161,19 @@ func NewErrorRetrier(maxAttempt int, retryInterval time.Duration, logger *zap.Lo
 	}
 }
 
+// NewErrorRetrierWithPolicy returns a new ErrorRetrier retrying with the policy, e.g. from RetryPolicyFromConfig
+func NewErrorRetrierWithPolicy(policy RetryPolicy, logger *zap.Logger) *ErrorRetrier {
+	return &ErrorRetrier{
+		MaxAttempts:   policy.MaxAttempts,
+		RetryInterval: policy.RetryInterval,
+		Logger:        logger,
+		Policy:        &policy,
+	}
+}
+
 //ErrorRetry path for retry logic with logger passed in
 func (er *ErrorRetrier) ErrorRetry(funcToRetry func() (error, bool)) error {
+	policy := er.retryPolicy()
 	var err error
 	var shouldStop bool
 	for i := 0; ; i++ {
@@ -162,12 +186,22 @@ func (er *ErrorRetrier) ErrorRetry(funcToRetry func() (error, bool)) error {
 			return err
 		}
 		//Stop if out of retries
-		if i >= (er.MaxAttempts - 1) {
+		if i >= (policy.Attempts(err) - 1) {
 			break
 		}
-		time.Sleep(er.RetryInterval)
+		time.Sleep(policy.Wait(i+1, err))
 		er.Logger.Warn("retrying after Error:", zap.Error(err))
+		er.recordRetry()
 	}
 	//error set by name above so no need to explicitly return it
 	return err
 }
+
+// recordRetry records a retry of the operation in the metrics
+func (er *ErrorRetrier) recordRetry() {
+	operation := er.Operation
+	if operation == "" {
+		operation = "unknown"
+	}
+	metrics.Recorder().IncRetry(operation)
+}
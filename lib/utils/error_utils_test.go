@@ -140,6 +140,22 @@ func TestNewErrorUnwrapString(t *testing.T) {
 		ErrorDeepUnwrapString(NewError("MyCode", "My message", wrapped3, nil, wrapped2)))
 }
 
+func TestNewErrorCause(t *testing.T) {
+	cause := fmt.Errorf("dial: %w", errors.New("connection refused"))
+	err := NewError(reasoncode.ErrorTemporaryConnectionProblem, "Failed to reach the endpoint", nil, cause)
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, cause, errors.Unwrap(err))
+
+	// The reason code of a wrapped provider.Error remains reachable
+	err = NewError(reasoncode.ErrorVolumeAttachFailed, "Attach failed", provider.ErrInstanceNotFound)
+	assert.True(t, errors.Is(err, provider.ErrInstanceNotFound))
+	var pErr provider.Error
+	assert.True(t, errors.As(err, &pErr))
+	assert.Equal(t, reasoncode.ErrorVolumeAttachFailed, pErr.Code())
+
+	assert.Nil(t, errors.Unwrap(NewError("MyCode", "My message")))
+}
+
 func TestErrorReasonCode(t *testing.T) {
 	assert.Equal(t, reasoncode.ErrorUnclassified, ErrorReasonCode(errors.New("test")))
 	assert.Equal(t, reasoncode.ErrorUnclassified, ErrorReasonCode(provider.Error{}))
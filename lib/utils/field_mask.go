@@ -0,0 +1,153 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// MaskedField is a group of potentially sensitive fields of volumes and snapshots
+type MaskedField string
+
+const (
+	// FieldTags are the volume and snapshot tags, including the access management tags
+	FieldTags = MaskedField("tags")
+	// FieldCRN are the CRN and href of the resource and the CRN of its encryption key
+	FieldCRN = MaskedField("crn")
+	// FieldNotes are the volume notes and provider attributes
+	FieldNotes = MaskedField("notes")
+	// FieldNetwork are the backend addresses, mount addresses and iSCSI targets
+	FieldNetwork = MaskedField("network")
+	// FieldAttachments are the volume attachments and access points
+	FieldAttachments = MaskedField("attachments")
+)
+
+// FieldMask lists the field groups removed from the results of Get/List, so management planes can return
+// only non-sensitive fields to constrained callers. All fields are returned for an empty mask
+type FieldMask []MaskedField
+
+// SensitiveFields masks all sensitive field groups
+var SensitiveFields = FieldMask{FieldTags, FieldCRN, FieldNotes, FieldNetwork, FieldAttachments}
+
+func (m FieldMask) masks(field MaskedField) bool {
+	for _, masked := range m {
+		if masked == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskVolume returns a copy of the volume without the masked fields, the volume itself is not modified
+func (m FieldMask) MaskVolume(volume *provider.Volume) *provider.Volume {
+	if volume == nil || len(m) == 0 {
+		return volume
+	}
+	masked := *volume
+	if m.masks(FieldTags) {
+		masked.Tags = nil
+		masked.AccessTags = nil
+	}
+	if m.masks(FieldCRN) {
+		masked.CRN = ""
+		masked.Href = ""
+		masked.VolumeEncryptionKey = nil
+		if _, published := masked.Attributes[EncryptionKeyCRNAttribute]; published {
+			attributes := make(map[string]string, len(masked.Attributes))
+			for key, value := range masked.Attributes {
+				if key != EncryptionKeyCRNAttribute {
+					attributes[key] = value
+				}
+			}
+			masked.Attributes = attributes
+		}
+	}
+	if m.masks(FieldNotes) {
+		masked.VolumeNotes = nil
+		masked.Attributes = nil
+	}
+	if m.masks(FieldNetwork) {
+		masked.BackendIPAddress = nil
+		masked.FileNetworkMountAddress = nil
+		masked.IscsiTargetIPAddresses = nil
+	}
+	if m.masks(FieldAttachments) {
+		masked.VolumeAttachments = nil
+		masked.VolumeAccessPoints = nil
+	}
+	return &masked
+}
+
+// MaskSnapshot returns a copy of the snapshot without the masked fields, the snapshot itself is not modified
+func (m FieldMask) MaskSnapshot(snapshot *provider.Snapshot) *provider.Snapshot {
+	if snapshot == nil || len(m) == 0 {
+		return snapshot
+	}
+	masked := *snapshot
+	if m.masks(FieldTags) {
+		masked.SnapshotTags = nil
+	}
+	if m.masks(FieldCRN) {
+		masked.CRN = ""
+		masked.Href = ""
+	}
+	return &masked
+}
+
+func (m FieldMask) maskVolumes(volumes []*provider.Volume) []*provider.Volume {
+	if len(m) == 0 {
+		return volumes
+	}
+	masked := make([]*provider.Volume, 0, len(volumes))
+	for _, volume := range volumes {
+		masked = append(masked, m.MaskVolume(volume))
+	}
+	return masked
+}
+
+func (m FieldMask) maskSnapshots(snapshots []*provider.Snapshot) []*provider.Snapshot {
+	if len(m) == 0 {
+		return snapshots
+	}
+	masked := make([]*provider.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		masked = append(masked, m.MaskSnapshot(snapshot))
+	}
+	return masked
+}
+
+// GetVolumeWithFieldMask gets the volume without the masked fields
+func GetVolumeWithFieldMask(sess provider.VolumeManager, volumeID string, mask FieldMask, logger *zap.Logger) (*provider.Volume, error) {
+	volume, err := sess.GetVolume(volumeID)
+	if err != nil {
+		logger.Error("Failed to get volume", zap.String("VolumeID", volumeID), ZapError(err))
+		return nil, err
+	}
+	return mask.MaskVolume(volume), nil
+}
+
+// GetSnapshotWithFieldMask gets the snapshot without the masked fields
+func GetSnapshotWithFieldMask(sess provider.SnapshotManager, snapshotID string, mask FieldMask, logger *zap.Logger) (*provider.Snapshot, error) {
+	snapshot, err := sess.GetSnapshot(snapshotID)
+	if err != nil {
+		logger.Error("Failed to get snapshot", zap.String("SnapshotID", snapshotID), ZapError(err))
+		return nil, err
+	}
+	return mask.MaskSnapshot(snapshot), nil
+}
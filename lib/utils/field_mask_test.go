@@ -0,0 +1,106 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func sensitiveVolume() *provider.Volume {
+	address := "10.0.0.1"
+	attachments := []provider.VolumeAttachment{{ID: "att-1"}}
+	volume := &provider.Volume{VolumeID: "vol-1", Region: "us-south", Attributes: map[string]string{"a": "b"}, BackendIPAddress: &address}
+	volume.Tags = []string{"env:prod"}
+	volume.AccessTags = []string{"project:storage"}
+	volume.CRN = "crn:v1:vol-1"
+	volume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "crn:v1:key"}
+	volume.VolumeAttachments = &attachments
+	return volume
+}
+
+func TestFieldMask(t *testing.T) {
+	volume := sensitiveVolume()
+	assert.Equal(t, volume, FieldMask{}.MaskVolume(volume))
+
+	masked := SensitiveFields.MaskVolume(volume)
+	assert.Equal(t, "vol-1", masked.VolumeID)
+	assert.Equal(t, "us-south", masked.Region)
+	assert.Nil(t, masked.Tags)
+	assert.Empty(t, masked.CRN)
+	assert.Nil(t, masked.VolumeEncryptionKey)
+	assert.Nil(t, masked.Attributes)
+	assert.Nil(t, masked.BackendIPAddress)
+	assert.Nil(t, masked.VolumeAttachments)
+	// original untouched
+	assert.Equal(t, "crn:v1:vol-1", volume.CRN)
+	assert.Equal(t, []string{"env:prod"}, volume.Tags)
+
+	tagsOnly := FieldMask{FieldTags}.MaskVolume(volume)
+	assert.Nil(t, tagsOnly.Tags)
+	assert.Nil(t, tagsOnly.AccessTags)
+	assert.Equal(t, []string{"project:storage"}, volume.AccessTags)
+	assert.Equal(t, "crn:v1:vol-1", tagsOnly.CRN)
+
+	snapshot := &provider.Snapshot{SnapshotID: "snap-1", SnapshotTags: provider.SnapshotTags{"a": "b"}}
+	snapshot.CRN = "crn:v1:snap-1"
+	maskedSnapshot := SensitiveFields.MaskSnapshot(snapshot)
+	assert.Nil(t, maskedSnapshot.SnapshotTags)
+	assert.Empty(t, maskedSnapshot.CRN)
+	assert.Equal(t, "snap-1", maskedSnapshot.SnapshotID)
+	assert.Nil(t, SensitiveFields.MaskSnapshot(nil))
+}
+
+func TestGetAndListWithFieldMask(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(sensitiveVolume(), nil)
+	volume, err := GetVolumeWithFieldMask(sess, "vol-1", SensitiveFields, logger)
+	assert.Nil(t, err)
+	assert.Empty(t, volume.CRN)
+
+	sess.GetVolumeReturns(nil, errors.New("not found"))
+	_, err = GetVolumeWithFieldMask(sess, "vol-1", SensitiveFields, logger)
+	assert.NotNil(t, err)
+
+	snapshot := &provider.Snapshot{SnapshotID: "snap-1", SnapshotTags: provider.SnapshotTags{"a": "b"}}
+	sess.GetSnapshotReturns(snapshot, nil)
+	maskedSnapshot, err := GetSnapshotWithFieldMask(sess, "snap-1", FieldMask{FieldTags}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, maskedSnapshot.SnapshotTags)
+
+	sess.ListVolumesReturns(&provider.VolumeList{Volumes: []*provider.Volume{sensitiveVolume()}}, nil)
+	volumes, err := ListAllVolumes(sess, ListOptions{FieldMask: SensitiveFields}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, volumes[0].Tags)
+
+	page, _, err := ListVolumesPage(sess, "", ListOptions{FieldMask: FieldMask{FieldCRN}})
+	assert.Nil(t, err)
+	assert.Empty(t, page[0].CRN)
+	assert.Equal(t, []string{"env:prod"}, page[0].Tags)
+
+	sess.ListSnapshotsReturns(&provider.SnapshotList{Snapshots: []*provider.Snapshot{snapshot}}, nil)
+	snapshots, err := ListAllSnapshots(sess, ListOptions{FieldMask: SensitiveFields}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, snapshots[0].SnapshotTags)
+}
@@ -0,0 +1,95 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReasonCodeGRPCCodes are the gRPC codes of the reason codes which differ from the code of their category
+var ReasonCodeGRPCCodes = map[reasoncode.ReasonCode]codes.Code{
+	reasoncode.ErrorResourceAlreadyExists: codes.AlreadyExists,
+	reasoncode.ErrorWaiterCancelled:       codes.Canceled,
+	reasoncode.ErrorOperationAbandoned:    codes.Aborted,
+	reasoncode.ErrorUnsupportedFeature:    codes.InvalidArgument,
+}
+
+// CategoryGRPCCodes are the gRPC codes of the error categories, following the CSI spec where it defines the code
+// (e.g. ResourceExhausted for a lack of capacity, FailedPrecondition for a volume published to another node)
+var CategoryGRPCCodes = map[provider.ErrorCategory]codes.Code{
+	provider.CategoryUnknown:              codes.Internal,
+	provider.CategoryInvalidRequest:       codes.InvalidArgument,
+	provider.CategoryUnsupported:          codes.Unimplemented,
+	provider.CategoryNotFound:             codes.NotFound,
+	provider.CategoryAuthFailure:          codes.Unauthenticated,
+	provider.CategoryPermissionDenied:     codes.PermissionDenied,
+	provider.CategoryQuotaExceeded:        codes.ResourceExhausted,
+	provider.CategoryInsufficientCapacity: codes.ResourceExhausted,
+	provider.CategoryConflict:             codes.FailedPrecondition,
+	provider.CategoryRateLimited:          codes.Unavailable,
+	provider.CategoryUnavailable:          codes.Unavailable,
+	provider.CategoryTimeout:              codes.DeadlineExceeded,
+	provider.CategoryInternal:             codes.Internal,
+}
+
+// GRPCCode returns the canonical gRPC code of err (classified with DefaultErrorClassifier): the code of its
+// reason code in ReasonCodeGRPCCodes, else the code of its category in CategoryGRPCCodes. A gRPC status error
+// keeps its code, and the context errors are Canceled and DeadlineExceeded
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, isStatus := status.FromError(err); isStatus && s.Code() != codes.Unknown {
+		return s.Code()
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	}
+
+	var pErr provider.Error
+	if errors.As(ClassifyError(err), &pErr) {
+		if code, found := ReasonCodeGRPCCodes[pErr.Code()]; found {
+			return code
+		}
+	}
+	if code, found := CategoryGRPCCodes[ErrorCategoryOf(err)]; found {
+		return code
+	}
+	return codes.Internal
+}
+
+// GRPCStatusError returns err as a gRPC status error of the code GRPCCode and the message of err, nil if err is nil.
+// CSI drivers can return it as is from their RPC handlers
+func GRPCStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, isStatus := status.FromError(err); isStatus {
+		return err
+	}
+	return status.Error(GRPCCode(err), err.Error())
+}
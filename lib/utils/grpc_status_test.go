@@ -0,0 +1,79 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCCode(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		err          error
+		expected     codes.Code
+	}{
+		{testcasename: "Nil", err: nil, expected: codes.OK},
+		{testcasename: "Quota exceeded", err: NewError(reasoncode.ErrorQuotaExceeded, "quota exceeded"), expected: codes.ResourceExhausted},
+		{testcasename: "Insufficient capacity", err: errors.New("insufficient capacity in zone us-south-1"), expected: codes.ResourceExhausted},
+		{testcasename: "Not found", err: NewError(reasoncode.ErrorResourceNotFound, "volume not found"), expected: codes.NotFound},
+		{testcasename: "Already exists", err: NewError(reasoncode.ErrorResourceAlreadyExists, "volume exists"), expected: codes.AlreadyExists},
+		{testcasename: "Unauthenticated", err: NewError(reasoncode.ErrorFailedTokenExchange, "token exchange failed"), expected: codes.Unauthenticated},
+		{testcasename: "Permission denied", err: NewError(reasoncode.ErrorInsufficientPermissions, "not authorized"), expected: codes.PermissionDenied},
+		{testcasename: "Attach conflict", err: provider.ErrAttachConflict, expected: codes.FailedPrecondition},
+		{testcasename: "Rate limited", err: fmt.Errorf("list: %w", NewError(reasoncode.ErrorRateLimitExceeded, "rate limited")), expected: codes.Unavailable},
+		{testcasename: "Bad request", err: NewError(reasoncode.ErrorBadRequest, "bad request"), expected: codes.InvalidArgument},
+		{testcasename: "Context deadline", err: fmt.Errorf("wait: %w", context.DeadlineExceeded), expected: codes.DeadlineExceeded},
+		{testcasename: "Context canceled", err: context.Canceled, expected: codes.Canceled},
+		{testcasename: "gRPC status", err: status.Error(codes.Aborted, "aborted"), expected: codes.Aborted},
+		{testcasename: "Unknown", err: errors.New("something happened"), expected: codes.Internal},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			assert.Equal(t, testcase.expected, GRPCCode(testcase.err))
+		})
+	}
+}
+
+func TestGRPCCodesCoverCategories(t *testing.T) {
+	for code, classification := range provider.ErrorCategories {
+		_, found := CategoryGRPCCodes[classification.Category]
+		assert.True(t, found, "category %s of %s has no gRPC code", classification.Category, code)
+	}
+}
+
+func TestGRPCStatusError(t *testing.T) {
+	assert.Nil(t, GRPCStatusError(nil))
+
+	err := GRPCStatusError(NewError(reasoncode.ErrorResourceNotFound, "Volume vol-1 not found"))
+	s, isStatus := status.FromError(err)
+	assert.True(t, isStatus)
+	assert.Equal(t, codes.NotFound, s.Code())
+	assert.Equal(t, "Volume vol-1 not found", s.Message())
+
+	statusErr := status.Error(codes.Aborted, "aborted")
+	assert.Equal(t, statusErr, GRPCStatusError(statusErr))
+}
@@ -0,0 +1,231 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// NewGuardedSession returns the session refusing its mutating operations while the DefaultMaintenanceFreeze is
+// enabled, the reads are passed through. The completed mutating operations are recorded by the tracker, unless it
+// is nil, including those of the context session of NewContextSession (the tracker of their context is then not
+// used). The provider.DeletionProtectionManager of the session is kept
+func NewGuardedSession(sess provider.Session, tracker *ActivityTracker) provider.Session {
+	guarded := &guardedSession{Session: sess, tracker: tracker}
+	if manager, isManager := sess.(provider.DeletionProtectionManager); isManager {
+		return &guardedProtectionSession{guardedSession: guarded, manager: manager}
+	}
+	return guarded
+}
+
+// guardedSession checks the maintenance freeze before each mutating operation of the session, and records the
+// completed ones with its tracker
+type guardedSession struct {
+	provider.Session
+	tracker *ActivityTracker
+}
+
+var _ provider.Session = &guardedSession{}
+
+// guarded calls fn unless the mutations are frozen for maintenance, and records the operation on the target
+func guarded[T any](s *guardedSession, operation, target string, fn func() (T, error)) (value T, err error) {
+	if err = DefaultMaintenanceFreeze.Check(operation); err != nil {
+		return value, err
+	}
+	value, err = fn()
+	if s.tracker != nil {
+		s.tracker.Record(context.Background(), operation, target, nil, err)
+	}
+	return value, err
+}
+
+// guardedErr is guarded for the operations returning only an error
+func guardedErr(s *guardedSession, operation, target string, fn func() error) error {
+	_, err := guarded(s, operation, target, noValue(fn))
+	return err
+}
+
+// recordsActivity returns true if the session records the events of its mutating operations
+func recordsActivity(sess provider.Session) bool {
+	guarded, isGuarded := sess.(interface{ activityTracker() *ActivityTracker })
+	return isGuarded && guarded.activityTracker() != nil
+}
+
+func (s *guardedSession) activityTracker() *ActivityTracker {
+	return s.tracker
+}
+
+// volumeIDOf returns the ID of the volume, empty if it is nil
+func volumeIDOf(volume *provider.Volume) string {
+	if volume == nil {
+		return ""
+	}
+	return volume.VolumeID
+}
+
+// snapshotIDOf returns the ID of the snapshot, empty if it is nil
+func snapshotIDOf(snapshot *provider.Snapshot) string {
+	if snapshot == nil {
+		return ""
+	}
+	return snapshot.SnapshotID
+}
+
+// CreateVolume ...
+func (s *guardedSession) CreateVolume(volumeRequest provider.Volume) (*provider.Volume, error) {
+	return guarded(s, "CreateVolume", SafeStringValue(volumeRequest.Name), func() (*provider.Volume, error) { return s.Session.CreateVolume(volumeRequest) })
+}
+
+// CreateVolumeFromSnapshot ...
+func (s *guardedSession) CreateVolumeFromSnapshot(snapshot provider.Snapshot, tags map[string]string) (*provider.Volume, error) {
+	return guarded(s, "CreateVolumeFromSnapshot", snapshot.SnapshotID, func() (*provider.Volume, error) { return s.Session.CreateVolumeFromSnapshot(snapshot, tags) })
+}
+
+// CreateVolumeFromVolume ...
+func (s *guardedSession) CreateVolumeFromVolume(cloneRequest provider.VolumeCloneRequest) (*provider.VolumeCloneResponse, error) {
+	return guarded(s, "CreateVolumeFromVolume", cloneRequest.SourceVolumeID, func() (*provider.VolumeCloneResponse, error) { return s.Session.CreateVolumeFromVolume(cloneRequest) })
+}
+
+// UpdateVolume ...
+func (s *guardedSession) UpdateVolume(volume provider.Volume) error {
+	return guardedErr(s, "UpdateVolume", volume.VolumeID, func() error { return s.Session.UpdateVolume(volume) })
+}
+
+// DeleteVolume ...
+func (s *guardedSession) DeleteVolume(volume *provider.Volume) error {
+	return guardedErr(s, "DeleteVolume", volumeIDOf(volume), func() error { return s.Session.DeleteVolume(volume) })
+}
+
+// AuthorizeVolume ...
+func (s *guardedSession) AuthorizeVolume(volumeAuthorization provider.VolumeAuthorization) error {
+	return guardedErr(s, "AuthorizeVolume", volumeAuthorization.Volume.VolumeID, func() error { return s.Session.AuthorizeVolume(volumeAuthorization) })
+}
+
+// ExpandVolume ...
+func (s *guardedSession) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (int64, error) {
+	return guarded(s, "ExpandVolume", expandVolumeRequest.VolumeID, func() (int64, error) { return s.Session.ExpandVolume(expandVolumeRequest) })
+}
+
+// UpdateVolumeProfile ...
+func (s *guardedSession) UpdateVolumeProfile(updateRequest provider.VolumeProfileUpdateRequest) (*provider.Volume, error) {
+	return guarded(s, "UpdateVolumeProfile", updateRequest.VolumeID, func() (*provider.Volume, error) { return s.Session.UpdateVolumeProfile(updateRequest) })
+}
+
+// UpdateVolumeIOPS ...
+func (s *guardedSession) UpdateVolumeIOPS(updateRequest provider.VolumeIOPSUpdateRequest) (*provider.Volume, error) {
+	return guarded(s, "UpdateVolumeIOPS", updateRequest.VolumeID, func() (*provider.Volume, error) { return s.Session.UpdateVolumeIOPS(updateRequest) })
+}
+
+// UpdateVolumeTags ...
+func (s *guardedSession) UpdateVolumeTags(updateRequest provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+	return guarded(s, "UpdateVolumeTags", updateRequest.VolumeID, func() (*provider.VolumeTags, error) { return s.Session.UpdateVolumeTags(updateRequest) })
+}
+
+// AttachVolume ...
+func (s *guardedSession) AttachVolume(attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return guarded(s, "AttachVolume", attachRequest.VolumeID, func() (*provider.VolumeAttachmentResponse, error) { return s.Session.AttachVolume(attachRequest) })
+}
+
+// DetachVolume ...
+func (s *guardedSession) DetachVolume(detachRequest provider.VolumeAttachmentRequest) (*http.Response, error) {
+	return guarded(s, "DetachVolume", detachRequest.VolumeID, func() (*http.Response, error) { return s.Session.DetachVolume(detachRequest) })
+}
+
+// UpdateVolumeAttachment ...
+func (s *guardedSession) UpdateVolumeAttachment(updateRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return guarded(s, "UpdateVolumeAttachment", updateRequest.VolumeID, func() (*provider.VolumeAttachmentResponse, error) {
+		return s.Session.UpdateVolumeAttachment(updateRequest)
+	})
+}
+
+// CreateSnapshot ...
+func (s *guardedSession) CreateSnapshot(sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (*provider.Snapshot, error) {
+	return guarded(s, "CreateSnapshot", sourceVolumeID, func() (*provider.Snapshot, error) {
+		return s.Session.CreateSnapshot(sourceVolumeID, snapshotParameters)
+	})
+}
+
+// DeleteSnapshot ...
+func (s *guardedSession) DeleteSnapshot(snapshot *provider.Snapshot) error {
+	return guardedErr(s, "DeleteSnapshot", snapshotIDOf(snapshot), func() error { return s.Session.DeleteSnapshot(snapshot) })
+}
+
+// RestoreVolumeFromSnapshot ...
+func (s *guardedSession) RestoreVolumeFromSnapshot(restoreRequest provider.SnapshotRestoreRequest) (*provider.SnapshotRestoreResponse, error) {
+	return guarded(s, "RestoreVolumeFromSnapshot", restoreRequest.SnapshotID, func() (*provider.SnapshotRestoreResponse, error) {
+		return s.Session.RestoreVolumeFromSnapshot(restoreRequest)
+	})
+}
+
+// CreateVolumeAccessPoint ...
+func (s *guardedSession) CreateVolumeAccessPoint(accessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return guarded(s, "CreateVolumeAccessPoint", accessPointRequest.VolumeID, func() (*provider.VolumeAccessPointResponse, error) {
+		return s.Session.CreateVolumeAccessPoint(accessPointRequest)
+	})
+}
+
+// DeleteVolumeAccessPoint ...
+func (s *guardedSession) DeleteVolumeAccessPoint(deleteAccessPointRequest provider.VolumeAccessPointRequest) (*http.Response, error) {
+	return guarded(s, "DeleteVolumeAccessPoint", deleteAccessPointRequest.VolumeID, func() (*http.Response, error) { return s.Session.DeleteVolumeAccessPoint(deleteAccessPointRequest) })
+}
+
+// CreateShare ...
+func (s *guardedSession) CreateShare(shareRequest provider.FileShareRequest) (*provider.FileShare, error) {
+	return guarded(s, "CreateShare", shareRequest.Name, func() (*provider.FileShare, error) { return s.Session.CreateShare(shareRequest) })
+}
+
+// DeleteShare ...
+func (s *guardedSession) DeleteShare(shareID string) error {
+	return guardedErr(s, "DeleteShare", shareID, func() error { return s.Session.DeleteShare(shareID) })
+}
+
+// CreateShareTarget ...
+func (s *guardedSession) CreateShareTarget(targetRequest provider.ShareTargetRequest) (*provider.ShareTarget, error) {
+	return guarded(s, "CreateShareTarget", targetRequest.ShareID, func() (*provider.ShareTarget, error) { return s.Session.CreateShareTarget(targetRequest) })
+}
+
+// DeleteShareTarget ...
+func (s *guardedSession) DeleteShareTarget(targetRequest provider.ShareTargetRequest) error {
+	return guardedErr(s, "DeleteShareTarget", targetRequest.ShareID, func() error { return s.Session.DeleteShareTarget(targetRequest) })
+}
+
+// ExpandShare ...
+func (s *guardedSession) ExpandShare(expandRequest provider.ExpandShareRequest) (int64, error) {
+	return guarded(s, "ExpandShare", expandRequest.ShareID, func() (int64, error) { return s.Session.ExpandShare(expandRequest) })
+}
+
+// guardedProtectionSession is the guardedSession of a session implementing provider.DeletionProtectionManager
+type guardedProtectionSession struct {
+	*guardedSession
+	manager provider.DeletionProtectionManager
+}
+
+var _ provider.DeletionProtectionManager = &guardedProtectionSession{}
+
+// SetDeletionProtection ...
+func (s *guardedProtectionSession) SetDeletionProtection(ctx context.Context, volumeID string, enabled bool) error {
+	return guardedErr(s.guardedSession, "SetDeletionProtection", volumeID, func() error { return s.manager.SetDeletionProtection(ctx, volumeID, enabled) })
+}
+
+// IsDeletionProtected ...
+func (s *guardedProtectionSession) IsDeletionProtected(ctx context.Context, volumeID string) (bool, error) {
+	return s.manager.IsDeletionProtected(ctx, volumeID)
+}
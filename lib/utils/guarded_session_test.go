@@ -0,0 +1,74 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardedSession(t *testing.T) {
+	defer SetReadOnly(false, "")
+	inner := &fake.FakeSession{}
+	sess := NewGuardedSession(inner, nil)
+	_, isManager := sess.(provider.DeletionProtectionManager)
+	assert.False(t, isManager)
+
+	_, err := sess.CreateVolume(provider.Volume{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inner.CreateVolumeCallCount())
+
+	SetReadOnly(true, "upgrade")
+	_, err = sess.CreateVolume(provider.Volume{})
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	err = sess.DeleteShare("share-1")
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	_, err = sess.DetachVolume(provider.VolumeAttachmentRequest{VolumeID: "vol-1"})
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	assert.Equal(t, 1, inner.CreateVolumeCallCount())
+	assert.Equal(t, 0, inner.DeleteShareCallCount())
+	assert.Equal(t, 0, inner.DetachVolumeCallCount())
+
+	_, err = sess.GetVolume("vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inner.GetVolumeCallCount())
+}
+
+func TestGuardedSessionDeletionProtection(t *testing.T) {
+	defer SetReadOnly(false, "")
+	store := fake.NewMemoryStore(fake.Quotas{})
+	sess := NewGuardedSession(fake.NewMemorySession(store), nil)
+	manager, isManager := sess.(provider.DeletionProtectionManager)
+	assert.True(t, isManager)
+
+	volume, err := sess.CreateVolume(provider.Volume{})
+	assert.Nil(t, err)
+	assert.Nil(t, manager.SetDeletionProtection(context.Background(), volume.VolumeID, true))
+
+	SetReadOnly(true, "upgrade")
+	err = manager.SetDeletionProtection(context.Background(), volume.VolumeID, false)
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	protected, err := manager.IsDeletionProtected(context.Background(), volume.VolumeID)
+	assert.Nil(t, err)
+	assert.True(t, protected)
+}
@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// InventoryReport is the result of reconciling the volumes tagged for a cluster with the volumes in use by it
+type InventoryReport struct {
+	// InUse volumes are tagged for the cluster and in use
+	InUse []string `json:"inUse"`
+	// Orphaned volumes are tagged for the cluster but not in use
+	Orphaned []string `json:"orphaned"`
+	// Foreign volumes are in use but not tagged for the cluster
+	Foreign []string `json:"foreign"`
+	// GeneratedAt is the time the report was generated
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// ReconcileVolumeInventory lists all volumes matching the cluster tags (pageSize volumes per call, see PageLimit) and
+// cross-references them with inUseVolumeIDs. All lists in the report are sorted
+func ReconcileVolumeInventory(sess provider.VolumeManager, tags map[string]string, inUseVolumeIDs []string, pageSize int, logger *zap.Logger) (*InventoryReport, error) {
+	inUse := make(map[string]bool, len(inUseVolumeIDs))
+	for _, id := range inUseVolumeIDs {
+		inUse[id] = true
+	}
+
+	report := &InventoryReport{InUse: []string{}, Orphaned: []string{}, Foreign: []string{}, GeneratedAt: time.Now().UTC()}
+	tagged := make(map[string]bool)
+	start := ""
+	for {
+		volumes, err := sess.ListVolumes(PageLimit(pageSize), start, tags)
+		if err != nil {
+			logger.Error("Failed to list volumes for inventory reconciliation", ZapError(err))
+			return nil, err
+		}
+		if volumes == nil {
+			break
+		}
+		for _, volume := range volumes.Volumes {
+			if volume == nil || tagged[volume.VolumeID] {
+				continue
+			}
+			tagged[volume.VolumeID] = true
+			if inUse[volume.VolumeID] {
+				report.InUse = append(report.InUse, volume.VolumeID)
+			} else {
+				report.Orphaned = append(report.Orphaned, volume.VolumeID)
+			}
+		}
+		if volumes.Next == "" || volumes.Next == start {
+			break
+		}
+		start = volumes.Next
+	}
+
+	for id := range inUse {
+		if !tagged[id] {
+			report.Foreign = append(report.Foreign, id)
+		}
+	}
+	sort.Strings(report.InUse)
+	sort.Strings(report.Orphaned)
+	sort.Strings(report.Foreign)
+	logger.Info("Volume inventory reconciled", zap.Int("InUse", len(report.InUse)), zap.Int("Orphaned", len(report.Orphaned)), zap.Int("Foreign", len(report.Foreign)))
+	return report, nil
+}
+
+// JSON returns the report in JSON format
+func (r *InventoryReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
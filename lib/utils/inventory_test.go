@@ -0,0 +1,58 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestReconcileVolumeInventory(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	tags := map[string]string{"cluster": "abc"}
+	sess := &fake.FakeSession{}
+	sess.ListVolumesReturnsOnCall(0, &provider.VolumeList{Next: "page-2", Volumes: []*provider.Volume{{VolumeID: "vol-3"}, {VolumeID: "vol-1"}}}, nil)
+	sess.ListVolumesReturnsOnCall(1, &provider.VolumeList{Volumes: []*provider.Volume{{VolumeID: "vol-2"}}}, nil)
+
+	report, err := ReconcileVolumeInventory(sess, tags, []string{"vol-1", "vol-9"}, 2, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vol-1"}, report.InUse)
+	assert.Equal(t, []string{"vol-2", "vol-3"}, report.Orphaned)
+	assert.Equal(t, []string{"vol-9"}, report.Foreign)
+
+	limit, start, listTags := sess.ListVolumesArgsForCall(1)
+	assert.Equal(t, 2, limit)
+	assert.Equal(t, "page-2", start)
+	assert.Equal(t, tags, listTags)
+
+	data, err := report.JSON()
+	assert.Nil(t, err)
+	var decoded map[string]interface{}
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, []interface{}{"vol-9"}, decoded["foreign"])
+
+	sess.ListVolumesReturns(nil, errors.New("list failed"))
+	_, err = ReconcileVolumeInventory(sess, tags, nil, 2, logger)
+	assert.NotNil(t, err)
+}
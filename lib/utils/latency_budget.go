@@ -0,0 +1,189 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+const (
+	// LatencyLayerAuth is the time spent obtaining IAM tokens
+	LatencyLayerAuth = "auth"
+
+	// LatencyLayerRateLimit is the time spent waiting on the rate limiter
+	LatencyLayerRateLimit = "rateLimit"
+
+	// LatencyLayerHTTP is the time spent in provider API calls
+	LatencyLayerHTTP = "http"
+
+	// LatencyLayerBackendWait is the time spent polling the backend for a state (e.g. attached, deleted)
+	LatencyLayerBackendWait = "backendWait"
+
+	// LatencyLayerUnaccounted is the elapsed time not charged to any layer
+	LatencyLayerUnaccounted = "unaccounted"
+
+	// latencyPropertyPrefix prefixes the breakdown properties added to provider errors
+	latencyPropertyPrefix = "latency."
+)
+
+var latencyBudgetKey = ctxkeys.NewKey[*LatencyBudget]("latency-budget")
+
+// LatencyBudget is carried in the context of an operation, each layer charges the time it spent against it so
+// the breakdown shows where a slow operation spent its time. A nil budget ignores all charges
+type LatencyBudget struct {
+	total time.Duration
+	start time.Time
+
+	mu      sync.Mutex
+	charges map[string]time.Duration
+}
+
+// LatencyBreakdown is the time spent by an operation in each layer
+type LatencyBreakdown struct {
+	Budget  time.Duration            `json:"budget"`
+	Elapsed time.Duration            `json:"elapsed"`
+	Layers  map[string]time.Duration `json:"layers"`
+}
+
+// NewLatencyBudget returns a budget of total starting now, a non-positive total is unlimited
+func NewLatencyBudget(total time.Duration) *LatencyBudget {
+	return &LatencyBudget{
+		total:   total,
+		start:   time.Now(),
+		charges: map[string]time.Duration{},
+	}
+}
+
+// WithLatencyBudget returns a context carrying the budget
+func WithLatencyBudget(ctx context.Context, budget *LatencyBudget) context.Context {
+	return latencyBudgetKey.WithValue(ctx, budget)
+}
+
+// LatencyBudgetFromContext returns the budget carried by the context, nil if none
+func LatencyBudgetFromContext(ctx context.Context) *LatencyBudget {
+	budget, _ := latencyBudgetKey.Value(ctx)
+	return budget
+}
+
+// Charge adds the time spent in the layer
+func (b *LatencyBudget) Charge(layer string, duration time.Duration) {
+	if b == nil || duration <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.charges[layer] += duration
+}
+
+// Track starts timing the layer, the returned function charges the elapsed time
+//
+//	defer budget.Track(LatencyLayerHTTP)()
+func (b *LatencyBudget) Track(layer string) func() {
+	start := time.Now()
+	return func() {
+		b.Charge(layer, time.Since(start))
+	}
+}
+
+// Remaining returns the time left in the budget, negative once exceeded. It is 0 for an unlimited budget
+func (b *LatencyBudget) Remaining() time.Duration {
+	if b == nil || b.total <= 0 {
+		return 0
+	}
+	return b.total - time.Since(b.start)
+}
+
+// Exceeded returns true if the operation took longer than the budget
+func (b *LatencyBudget) Exceeded() bool {
+	return b != nil && b.total > 0 && time.Since(b.start) > b.total
+}
+
+// Breakdown returns the time charged by layer so far, the elapsed time not charged to any layer is
+// reported as LatencyLayerUnaccounted
+func (b *LatencyBudget) Breakdown() LatencyBreakdown {
+	if b == nil {
+		return LatencyBreakdown{Layers: map[string]time.Duration{}}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	breakdown := LatencyBreakdown{Budget: b.total, Elapsed: time.Since(b.start), Layers: map[string]time.Duration{}}
+	charged := time.Duration(0)
+	for layer, duration := range b.charges {
+		breakdown.Layers[layer] = duration
+		charged += duration
+	}
+	if unaccounted := breakdown.Elapsed - charged; unaccounted > 0 {
+		breakdown.Layers[LatencyLayerUnaccounted] = unaccounted
+	}
+	return breakdown
+}
+
+// String returns the breakdown as "elapsed 1m30s (http=20s, backendWait=1m5s ...)", layers sorted by name
+func (b LatencyBreakdown) String() string {
+	layers := make([]string, 0, len(b.Layers))
+	for layer := range b.Layers {
+		layers = append(layers, layer)
+	}
+	sort.Strings(layers)
+	parts := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		parts = append(parts, fmt.Sprintf("%s=%s", layer, b.Layers[layer].Round(time.Millisecond)))
+	}
+	return fmt.Sprintf("elapsed %s (%s)", b.Elapsed.Round(time.Millisecond), strings.Join(parts, ", "))
+}
+
+// AttachLatencyBreakdown attaches the breakdown of the budget carried by ctx to the error: as "latency.<layer>"
+// properties of a provider error, appended to the message of any other error
+func AttachLatencyBreakdown(ctx context.Context, err error) error {
+	budget := LatencyBudgetFromContext(ctx)
+	if err == nil || budget == nil {
+		return err
+	}
+	breakdown := budget.Breakdown()
+
+	if pErr, isPerr := err.(provider.Error); isPerr {
+		properties := make(map[string]string, len(pErr.Fault.Properties)+len(breakdown.Layers))
+		for k, v := range pErr.Fault.Properties {
+			properties[k] = v
+		}
+		for layer, duration := range breakdown.Layers {
+			properties[latencyPropertyPrefix+layer] = duration.String()
+		}
+		properties[latencyPropertyPrefix+"elapsed"] = breakdown.Elapsed.String()
+		pErr.Fault.Properties = properties
+		return pErr
+	}
+	return fmt.Errorf("%w, latency %s", err, breakdown)
+}
+
+// RecordLatencyBreakdown records the breakdown of the budget carried by ctx in the metrics of the operation
+func RecordLatencyBreakdown(ctx context.Context, operation string) {
+	if budget := LatencyBudgetFromContext(ctx); budget != nil {
+		metrics.RecordLatencyBreakdown(operation, budget.Breakdown().Layers)
+	}
+}
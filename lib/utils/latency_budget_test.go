@@ -0,0 +1,94 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	budget := NewLatencyBudget(time.Hour)
+	ctx := WithLatencyBudget(context.Background(), budget)
+	assert.Equal(t, budget, LatencyBudgetFromContext(ctx))
+
+	LatencyBudgetFromContext(ctx).Charge(LatencyLayerAuth, 2*time.Second)
+	budget.Charge(LatencyLayerHTTP, time.Second)
+	budget.Charge(LatencyLayerHTTP, time.Second)
+	stop := budget.Track(LatencyLayerBackendWait)
+	time.Sleep(5 * time.Millisecond)
+	stop()
+
+	breakdown := budget.Breakdown()
+	assert.Equal(t, 2*time.Second, breakdown.Layers[LatencyLayerAuth])
+	assert.Equal(t, 2*time.Second, breakdown.Layers[LatencyLayerHTTP])
+	assert.True(t, breakdown.Layers[LatencyLayerBackendWait] >= 5*time.Millisecond)
+	assert.Equal(t, time.Hour, breakdown.Budget)
+	assert.False(t, budget.Exceeded())
+	assert.True(t, budget.Remaining() > 0)
+	assert.True(t, strings.Contains(breakdown.String(), "auth=2s, backendWait="))
+
+	rl := NewRateLimiter(0, 0, 0, 0)
+	rl.WaitContext(ctx, ReadOperation)
+
+	exceeded := NewLatencyBudget(time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	assert.True(t, exceeded.Exceeded())
+	assert.True(t, exceeded.Breakdown().Layers[LatencyLayerUnaccounted] > 0)
+}
+
+func TestLatencyBudgetNil(t *testing.T) {
+	var budget *LatencyBudget
+	budget.Charge(LatencyLayerHTTP, time.Second)
+	budget.Track(LatencyLayerHTTP)()
+	assert.False(t, budget.Exceeded())
+	assert.Equal(t, time.Duration(0), budget.Remaining())
+	assert.Empty(t, budget.Breakdown().Layers)
+	assert.Nil(t, LatencyBudgetFromContext(context.Background()))
+
+	err := errors.New("attach failed")
+	assert.Equal(t, err, AttachLatencyBreakdown(context.Background(), err))
+	RecordLatencyBreakdown(context.Background(), "AttachVolume")
+}
+
+func TestAttachLatencyBreakdown(t *testing.T) {
+	budget := NewLatencyBudget(0)
+	budget.Charge(LatencyLayerBackendWait, time.Minute)
+	ctx := WithLatencyBudget(context.Background(), budget)
+
+	err := AttachLatencyBreakdown(ctx, NewErrorWithProperties(reasoncode.ErrorBadRequest, "attach failed", map[string]string{"volumeID": "vol-1"}))
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+	properties := ErrorToFault(err).Properties
+	assert.Equal(t, "vol-1", properties["volumeID"])
+	assert.Equal(t, "1m0s", properties["latency.backendWait"])
+	assert.NotEmpty(t, properties["latency.elapsed"])
+
+	plain := errors.New("attach failed")
+	err = AttachLatencyBreakdown(ctx, plain)
+	assert.True(t, errors.Is(err, plain))
+	assert.True(t, strings.Contains(err.Error(), "backendWait=1m0s"))
+	assert.Nil(t, AttachLatencyBreakdown(ctx, nil))
+
+	RecordLatencyBreakdown(ctx, "AttachVolume")
+}
@@ -0,0 +1,192 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"sort"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// SortBy is the ordering applied to list results
+type SortBy string
+
+const (
+	// SortByCreationTime orders by creation time, then ID. This is the default order
+	SortByCreationTime = SortBy("creationTime")
+	// SortByID orders by ID
+	SortByID = SortBy("id")
+	// SortByName orders by name, then ID
+	SortByName = SortBy("name")
+)
+
+// ListOptions are the options of the ListAll* helpers
+type ListOptions struct {
+	// Limit is the page size of each backend call, bounded by PageLimit (DefaultPageLimit if 0)
+	Limit int
+	// Tags to filter the results by
+	Tags map[string]string
+	// Filters of the volumes, applied by the backend (ListVolumesWithFilters). They cannot be set with Tags
+	Filters provider.ListVolumesFilters
+	// SortBy is the ordering of the results, SortByCreationTime if empty
+	SortBy SortBy
+	// FieldMask removes field groups from the results, all fields are returned if empty
+	FieldMask FieldMask
+}
+
+// SortVolumes sorts volumes in place. The order is stable and does not depend on the backend order:
+// ties are broken by VolumeID
+func SortVolumes(volumes []*provider.Volume, sortBy SortBy) {
+	sort.SliceStable(volumes, func(i, j int) bool {
+		a, b := volumes[i], volumes[j]
+		switch sortBy {
+		case SortByID:
+		case SortByName:
+			if nameA, nameB := stringValue(a.Name), stringValue(b.Name); nameA != nameB {
+				return nameA < nameB
+			}
+		default:
+			if !a.CreationTime.Equal(b.CreationTime) {
+				return a.CreationTime.Before(b.CreationTime)
+			}
+		}
+		return a.VolumeID < b.VolumeID
+	})
+}
+
+// SortSnapshots sorts snapshots in place, ties are broken by SnapshotID. Snapshots have no name,
+// SortByName orders by ID
+func SortSnapshots(snapshots []*provider.Snapshot, sortBy SortBy) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		a, b := snapshots[i], snapshots[j]
+		if sortBy != SortByID && sortBy != SortByName && !a.SnapshotCreationTime.Equal(b.SnapshotCreationTime) {
+			return a.SnapshotCreationTime.Before(b.SnapshotCreationTime)
+		}
+		return a.SnapshotID < b.SnapshotID
+	})
+}
+
+// SortVolumeAttachments sorts attachments in place by creation time (unknown first), then VolumeID and InstanceID
+func SortVolumeAttachments(attachments []*provider.VolumeAttachmentResponse, sortBy SortBy) {
+	sort.SliceStable(attachments, func(i, j int) bool {
+		a, b := attachments[i], attachments[j]
+		if sortBy != SortByID && sortBy != SortByName {
+			if createdA, createdB := timeValue(a.CreatedAt), timeValue(b.CreatedAt); !createdA.Equal(createdB) {
+				return createdA.Before(createdB)
+			}
+		}
+		if a.VolumeID != b.VolumeID {
+			return a.VolumeID < b.VolumeID
+		}
+		return a.InstanceID < b.InstanceID
+	})
+}
+
+// listVolumes lists the page of volumes from start, with the filters of the options if set
+func (options ListOptions) listVolumes(sess provider.VolumeManager, start string) (*provider.VolumeList, error) {
+	if options.Filters.IsEmpty() {
+		return sess.ListVolumes(PageLimit(options.Limit), start, options.Tags)
+	}
+	if len(options.Tags) > 0 {
+		return nil, NewError(reasoncode.ErrorBadRequest, "Volumes are listed either by tags or by filters")
+	}
+	return sess.ListVolumesWithFilters(PageLimit(options.Limit), start, options.Filters)
+}
+
+// volumeFilterTags returns the tags, or the filters as tags, the volumes are listed by
+func (options ListOptions) volumeFilterTags() map[string]string {
+	if options.Filters.IsEmpty() {
+		return options.Tags
+	}
+	return options.Filters.Tags()
+}
+
+// ListAllVolumes lists all pages of volumes matching the options and returns them in a stable order
+func ListAllVolumes(sess provider.VolumeManager, options ListOptions, logger *zap.Logger) ([]*provider.Volume, error) {
+	result := []*provider.Volume{}
+	seen := make(map[string]bool)
+	start := ""
+	for {
+		volumes, err := options.listVolumes(sess, start)
+		if err != nil {
+			logger.Error("Failed to list volumes", ZapError(err))
+			return nil, err
+		}
+		if volumes == nil {
+			break
+		}
+		for _, volume := range volumes.Volumes {
+			if volume != nil && !seen[volume.VolumeID] {
+				seen[volume.VolumeID] = true
+				result = append(result, volume)
+			}
+		}
+		if volumes.Next == "" || volumes.Next == start {
+			break
+		}
+		start = volumes.Next
+	}
+	SortVolumes(result, options.SortBy)
+	return options.FieldMask.maskVolumes(result), nil
+}
+
+// ListAllSnapshots lists all pages of snapshots matching the options and returns them in a stable order
+func ListAllSnapshots(sess provider.SnapshotManager, options ListOptions, logger *zap.Logger) ([]*provider.Snapshot, error) {
+	result := []*provider.Snapshot{}
+	seen := make(map[string]bool)
+	start := ""
+	for {
+		snapshots, err := sess.ListSnapshots(PageLimit(options.Limit), start, options.Tags)
+		if err != nil {
+			logger.Error("Failed to list snapshots", ZapError(err))
+			return nil, err
+		}
+		if snapshots == nil {
+			break
+		}
+		for _, snapshot := range snapshots.Snapshots {
+			if snapshot != nil && !seen[snapshot.SnapshotID] {
+				seen[snapshot.SnapshotID] = true
+				result = append(result, snapshot)
+			}
+		}
+		if snapshots.Next == "" || snapshots.Next == start {
+			break
+		}
+		start = snapshots.Next
+	}
+	SortSnapshots(result, options.SortBy)
+	return options.FieldMask.maskSnapshots(result), nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func timeValue(value *time.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return *value
+}
@@ -0,0 +1,125 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSortVolumes(t *testing.T) {
+	now := time.Now()
+	nameA, nameB := "a", "b"
+	volumes := []*provider.Volume{
+		{VolumeID: "vol-3", CreationTime: now, Name: &nameA},
+		{VolumeID: "vol-2", CreationTime: now, Name: &nameB},
+		{VolumeID: "vol-1", CreationTime: now.Add(time.Minute), Name: &nameA},
+	}
+
+	SortVolumes(volumes, "")
+	assert.Equal(t, []string{"vol-2", "vol-3", "vol-1"}, volumeIDs(volumes))
+	SortVolumes(volumes, SortByID)
+	assert.Equal(t, []string{"vol-1", "vol-2", "vol-3"}, volumeIDs(volumes))
+	SortVolumes(volumes, SortByName)
+	assert.Equal(t, []string{"vol-1", "vol-3", "vol-2"}, volumeIDs(volumes))
+}
+
+func TestSortSnapshotsAndAttachments(t *testing.T) {
+	now := time.Now()
+	snapshots := []*provider.Snapshot{
+		{SnapshotID: "snap-2", SnapshotCreationTime: now},
+		{SnapshotID: "snap-1", SnapshotCreationTime: now.Add(time.Minute)},
+	}
+	SortSnapshots(snapshots, SortByCreationTime)
+	assert.Equal(t, "snap-2", snapshots[0].SnapshotID)
+	SortSnapshots(snapshots, SortByID)
+	assert.Equal(t, "snap-1", snapshots[0].SnapshotID)
+
+	attachments := []*provider.VolumeAttachmentResponse{
+		{VolumeAttachmentRequest: provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "ins-2"}, CreatedAt: &now},
+		{VolumeAttachmentRequest: provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "ins-1"}, CreatedAt: &now},
+		{VolumeAttachmentRequest: provider.VolumeAttachmentRequest{VolumeID: "vol-2", InstanceID: "ins-1"}},
+	}
+	SortVolumeAttachments(attachments, SortByCreationTime)
+	assert.Equal(t, "vol-2", attachments[0].VolumeID)
+	assert.Equal(t, "ins-1", attachments[1].InstanceID)
+}
+
+func TestListAllVolumes(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.ListVolumesReturnsOnCall(0, &provider.VolumeList{Next: "page-2", Volumes: []*provider.Volume{{VolumeID: "vol-3"}, {VolumeID: "vol-1"}}}, nil)
+	sess.ListVolumesReturnsOnCall(1, &provider.VolumeList{Volumes: []*provider.Volume{{VolumeID: "vol-1"}, {VolumeID: "vol-2"}}}, nil)
+
+	volumes, err := ListAllVolumes(sess, ListOptions{Limit: 2, SortBy: SortByID}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vol-1", "vol-2", "vol-3"}, volumeIDs(volumes))
+
+	sess.ListVolumesReturns(nil, errors.New("list failed"))
+	_, err = ListAllVolumes(sess, ListOptions{}, logger)
+	assert.NotNil(t, err)
+}
+
+func TestListAllVolumesWithFilters(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.ListVolumesWithFiltersReturns(&provider.VolumeList{Volumes: []*provider.Volume{{VolumeID: "vol-1"}}}, nil)
+	filters := provider.ListVolumesFilters{ZoneName: "us-south-1", ResourceGroupID: "rg-1"}
+
+	volumes, err := ListAllVolumes(sess, ListOptions{Filters: filters}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"vol-1"}, volumeIDs(volumes))
+	assert.Equal(t, 0, sess.ListVolumesCallCount())
+	limit, _, listFilters := sess.ListVolumesWithFiltersArgsForCall(0)
+	assert.Equal(t, DefaultPageLimit, limit)
+	assert.Equal(t, filters, listFilters)
+
+	_, err = ListAllVolumes(sess, ListOptions{Filters: filters, Tags: map[string]string{"env": "dev"}}, logger)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+}
+
+func TestListAllSnapshots(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.ListSnapshotsReturnsOnCall(0, &provider.SnapshotList{Next: "page-2", Snapshots: []*provider.Snapshot{{SnapshotID: "snap-2"}}}, nil)
+	sess.ListSnapshotsReturnsOnCall(1, &provider.SnapshotList{Snapshots: []*provider.Snapshot{{SnapshotID: "snap-1"}}}, nil)
+
+	snapshots, err := ListAllSnapshots(sess, ListOptions{}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(snapshots))
+	assert.Equal(t, "snap-1", snapshots[0].SnapshotID)
+
+	sess.ListSnapshotsReturns(nil, errors.New("list failed"))
+	_, err = ListAllSnapshots(sess, ListOptions{}, logger)
+	assert.NotNil(t, err)
+}
+
+func volumeIDs(volumes []*provider.Volume) []string {
+	ids := make([]string, 0, len(volumes))
+	for _, volume := range volumes {
+		ids = append(ids, volume.VolumeID)
+	}
+	return ids
+}
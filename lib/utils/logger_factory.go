@@ -0,0 +1,95 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Log modules of the library, used as logger names and keys of the log_levels config
+const (
+	LogModuleAuth    = "auth"
+	LogModuleConfig  = "config"
+	LogModuleMetrics = "metrics"
+	LogModuleWaiter  = "waiter"
+)
+
+// LogSampling configures the sampling of repeated log entries, see zapcore.NewSamplerWithOptions
+type LogSampling struct {
+	Tick       time.Duration
+	Initial    int
+	Thereafter int
+}
+
+// LoggerFactory creates named module loggers, each with its own level
+type LoggerFactory struct {
+	encoder      zapcore.Encoder
+	out          zapcore.WriteSyncer
+	defaultLevel zapcore.Level
+	moduleLevels map[string]zapcore.Level
+	sampling     *LogSampling
+}
+
+// NewLoggerFactory returns a LoggerFactory writing encoded entries to out. moduleLevels overrides
+// defaultLevel per module (e.g. {"auth": "debug"}), sampling (if not nil) applies to all loggers
+func NewLoggerFactory(encoder zapcore.Encoder, out zapcore.WriteSyncer, defaultLevel zapcore.Level, moduleLevels map[string]string, sampling *LogSampling) (*LoggerFactory, error) {
+	levels := make(map[string]zapcore.Level, len(moduleLevels))
+	for module, value := range moduleLevels {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("invalid log level '%s' for module %s", value, module)
+		}
+		levels[module] = level
+	}
+	return &LoggerFactory{
+		encoder:      encoder,
+		out:          out,
+		defaultLevel: defaultLevel,
+		moduleLevels: levels,
+		sampling:     sampling,
+	}, nil
+}
+
+// NewSampling returns the LogSampling of the config values, nil (no sampling) if thereafter is not positive
+func NewSampling(initial, thereafter int) *LogSampling {
+	if thereafter <= 0 {
+		return nil
+	}
+	return &LogSampling{Tick: time.Second, Initial: initial, Thereafter: thereafter}
+}
+
+// Level returns the level of the module
+func (f *LoggerFactory) Level(module string) zapcore.Level {
+	if level, ok := f.moduleLevels[module]; ok {
+		return level
+	}
+	return f.defaultLevel
+}
+
+// Logger returns a logger named after the module, logging at the module level
+func (f *LoggerFactory) Logger(module string) *zap.Logger {
+	core := zapcore.NewCore(f.encoder.Clone(), f.out, f.Level(module))
+	if f.sampling != nil {
+		core = zapcore.NewSamplerWithOptions(core, f.sampling.Tick, f.sampling.Initial, f.sampling.Thereafter)
+	}
+	return zap.New(core, zap.AddCaller()).Named(module)
+}
@@ -0,0 +1,66 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggerFactory(t *testing.T) {
+	buf := &bytes.Buffer{}
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	factory, err := NewLoggerFactory(encoder, zapcore.AddSync(buf), zapcore.InfoLevel, map[string]string{LogModuleAuth: "debug", LogModuleWaiter: "warn"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, zapcore.DebugLevel, factory.Level(LogModuleAuth))
+	assert.Equal(t, zapcore.InfoLevel, factory.Level(LogModuleMetrics))
+
+	factory.Logger(LogModuleAuth).Debug("auth debug")
+	factory.Logger(LogModuleWaiter).Info("waiter info")
+	factory.Logger(LogModuleMetrics).Debug("metrics debug")
+	factory.Logger(LogModuleMetrics).Info("metrics info")
+
+	output := buf.String()
+	assert.True(t, strings.Contains(output, "auth debug"))
+	assert.True(t, strings.Contains(output, `"logger":"auth"`))
+	assert.False(t, strings.Contains(output, "waiter info"))
+	assert.False(t, strings.Contains(output, "metrics debug"))
+	assert.True(t, strings.Contains(output, "metrics info"))
+
+	_, err = NewLoggerFactory(encoder, zapcore.AddSync(buf), zapcore.InfoLevel, map[string]string{LogModuleAuth: "verbose"}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoggerFactorySampling(t *testing.T) {
+	assert.Nil(t, NewSampling(10, 0))
+
+	buf := &bytes.Buffer{}
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	factory, err := NewLoggerFactory(encoder, zapcore.AddSync(buf), zapcore.InfoLevel, nil, NewSampling(2, 100))
+	assert.Nil(t, err)
+	logger := factory.Logger(LogModuleWaiter)
+	for i := 0; i < 10; i++ {
+		logger.Info("polling volume")
+	}
+	assert.Equal(t, 2, strings.Count(buf.String(), "polling volume"))
+}
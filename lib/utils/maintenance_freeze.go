@@ -0,0 +1,151 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// FreezeReasonProperty is the error property carrying the reason of the maintenance freeze
+const FreezeReasonProperty = "FreezeReason"
+
+// MaintenanceFreeze makes the library read-only during change-freeze windows. While it is enabled the mutating
+// operations (the RunMutation steps, the mutations of the context sessions and of the sessions of NewGuardedSession) fail with an error matching
+// provider.ErrMaintenanceFreeze, and the reads continue, so the driver pods keep running. It is toggled at runtime
+// with Enable and Disable
+type MaintenanceFreeze struct {
+	now func() time.Time
+
+	mu      sync.RWMutex
+	enabled bool
+	reason  string
+	since   time.Time
+}
+
+// MaintenanceFreezeStatus is the state of a MaintenanceFreeze
+type MaintenanceFreezeStatus struct {
+	Enabled bool      `json:"enabled"`
+	Reason  string    `json:"reason,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+}
+
+// DefaultMaintenanceFreeze is the freeze checked by the mutating operations, see SetReadOnly
+var DefaultMaintenanceFreeze = NewMaintenanceFreeze()
+
+// NewMaintenanceFreeze returns a disabled freeze
+func NewMaintenanceFreeze() *MaintenanceFreeze {
+	return &MaintenanceFreeze{now: time.Now}
+}
+
+// Enable freezes the mutating operations, the reason (e.g. a change ticket) is reported in their errors.
+// Enabling an enabled freeze only updates its reason
+func (f *MaintenanceFreeze) Enable(reason string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.enabled {
+		f.enabled, f.since = true, f.now()
+	}
+	f.reason = reason
+}
+
+// Disable lifts the freeze
+func (f *MaintenanceFreeze) Disable() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enabled, f.reason, f.since = false, "", time.Time{}
+}
+
+// Status returns the state of the freeze
+func (f *MaintenanceFreeze) Status() MaintenanceFreezeStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return MaintenanceFreezeStatus{Enabled: f.enabled, Reason: f.reason, Since: f.since}
+}
+
+// Check returns an ErrorMaintenanceFreeze error, matching provider.ErrMaintenanceFreeze with errors.Is, if the
+// freeze is enabled
+func (f *MaintenanceFreeze) Check(operation string) error {
+	status := f.Status()
+	if !status.Enabled {
+		return nil
+	}
+	message := fmt.Sprintf("%s refused, mutating operations are frozen for maintenance since %s", operation, status.Since.UTC().Format(time.RFC3339))
+	if status.Reason != "" {
+		message += ": " + status.Reason
+	}
+	return NewErrorWithProperties(reasoncode.ErrorMaintenanceFreeze, message,
+		map[string]string{OperationProperty: operation, FreezeReasonProperty: status.Reason})
+}
+
+// SetReadOnly enables (with the reason) or disables the DefaultMaintenanceFreeze
+func SetReadOnly(enabled bool, reason string) {
+	if enabled {
+		DefaultMaintenanceFreeze.Enable(reason)
+	} else {
+		DefaultMaintenanceFreeze.Disable()
+	}
+}
+
+// IsReadOnly returns true if the DefaultMaintenanceFreeze is enabled
+func IsReadOnly() bool {
+	return DefaultMaintenanceFreeze.Status().Enabled
+}
+
+// ReadOnlyFromConfig applies the read_only and read_only_reason server config values to the
+// DefaultMaintenanceFreeze, at startup or when the config is reloaded
+func ReadOnlyFromConfig(server *config.ServerConfig) {
+	if server == nil {
+		SetReadOnly(false, "")
+		return
+	}
+	SetReadOnly(server.ReadOnly, server.ReadOnlyReason)
+}
+
+// FollowReadOnlyConfig applies the read_only server config value of the current config of the holder, then again
+// each time a change (e.g. a Reload of the config file) modifies it. The changes not modifying it keep the freeze
+// toggled at runtime
+func FollowReadOnlyConfig(holder *config.Holder) {
+	ReadOnlyFromConfig(holder.GetCurrent().Server)
+	holder.OnChange(func(previous, current config.Snapshot) {
+		previousServer, currentServer := serverOf(previous.Config), serverOf(current.Config)
+		if readOnlyOf(previousServer) != readOnlyOf(currentServer) {
+			ReadOnlyFromConfig(currentServer)
+		}
+	})
+}
+
+// serverOf returns the server config of the config, nil if there is none
+func serverOf(conf *config.Config) *config.ServerConfig {
+	if conf == nil {
+		return nil
+	}
+	return conf.Server
+}
+
+// readOnlyOf returns the read_only and read_only_reason values of the server config
+func readOnlyOf(server *config.ServerConfig) [2]string {
+	if server == nil || !server.ReadOnly {
+		return [2]string{}
+	}
+	return [2]string{"true", server.ReadOnlyReason}
+}
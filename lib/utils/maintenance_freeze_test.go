@@ -0,0 +1,112 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceFreeze(t *testing.T) {
+	freeze := NewMaintenanceFreeze()
+	now := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+	freeze.now = func() time.Time { return now }
+	assert.Nil(t, freeze.Check("DeleteVolume"))
+
+	freeze.Enable("CHG-1")
+	now = now.Add(time.Hour)
+	freeze.Enable("CHG-2")
+	status := freeze.Status()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, "CHG-2", status.Reason)
+	assert.Equal(t, now.Add(-time.Hour), status.Since)
+
+	err := freeze.Check("DeleteVolume")
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	assert.Equal(t, reasoncode.ErrorMaintenanceFreeze, ErrorReasonCode(err))
+	assert.Equal(t, provider.CategoryUnavailable, ErrorCategoryOf(err))
+	assert.True(t, strings.Contains(err.Error(), "since 2022-05-01T10:00:00Z: CHG-2"))
+	assert.Equal(t, "DeleteVolume", err.(provider.Error).Properties()[OperationProperty])
+
+	freeze.Disable()
+	assert.Equal(t, MaintenanceFreezeStatus{}, freeze.Status())
+	assert.Nil(t, freeze.Check("DeleteVolume"))
+}
+
+func TestReadOnlyMutations(t *testing.T) {
+	defer SetReadOnly(false, "")
+	ReadOnlyFromConfig(&config.ServerConfig{ReadOnly: true, ReadOnlyReason: "upgrade"})
+	assert.True(t, IsReadOnly())
+
+	calls := 0
+	mutate := func() error {
+		calls++
+		return nil
+	}
+	err := RunMutation(context.Background(), "DeleteVolume", "vol-1", nil, mutate)
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	assert.Equal(t, 0, calls)
+	ctx, plan := WithDryRun(context.Background())
+	assert.Nil(t, RunMutation(ctx, "DeleteVolume", "vol-1", nil, mutate))
+	assert.Equal(t, 1, len(plan.Steps()))
+
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(&provider.Volume{VolumeID: "vol-1"}, nil)
+	csess := NewContextSession(sess)
+	_, err = csess.CreateVolumeWithContext(context.Background(), provider.Volume{})
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	assert.Equal(t, 0, sess.CreateVolumeCallCount())
+	volume, err := csess.GetVolumeWithContext(context.Background(), "vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-1", volume.VolumeID)
+
+	ReadOnlyFromConfig(nil)
+	assert.False(t, IsReadOnly())
+	assert.Nil(t, RunMutation(context.Background(), "DeleteVolume", "vol-1", nil, mutate))
+	assert.Equal(t, 1, calls)
+}
+
+func TestFollowReadOnlyConfig(t *testing.T) {
+	defer SetReadOnly(false, "")
+	holder := config.NewHolder(&config.Config{Server: &config.ServerConfig{ReadOnly: true, ReadOnlyReason: "upgrade"}})
+	FollowReadOnlyConfig(holder)
+	assert.Equal(t, "upgrade", DefaultMaintenanceFreeze.Status().Reason)
+
+	holder.Update(func(conf *config.Config) error {
+		conf.Server.ReadOnly = false
+		return nil
+	})
+	assert.False(t, IsReadOnly())
+
+	// the changes not modifying read_only keep the runtime freeze
+	SetReadOnly(true, "incident")
+	holder.Update(func(conf *config.Config) error {
+		conf.Server.DebugTrace = true
+		return nil
+	})
+	assert.Equal(t, "incident", DefaultMaintenanceFreeze.Status().Reason)
+}
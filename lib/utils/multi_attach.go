@@ -0,0 +1,99 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// AttachedInstanceProperty is the error property holding the instance a conflicting attachment is attached to
+const AttachedInstanceProperty = "AttachedInstanceID"
+
+// AttachmentModeOf returns the mode of the attachment, AttachmentModeExclusive if not set
+func AttachmentModeOf(request provider.VolumeAttachmentRequest) provider.AttachmentMode {
+	if request.Mode == "" {
+		return provider.AttachmentModeExclusive
+	}
+	return request.Mode
+}
+
+// ValidateAttachMode checks the attach request against the volume and its existing attachments. A shared attachment
+// returns an ErrorUnsupportedFeature error if the capabilities do not support multi-attach and an ErrorBadRequest error
+// if the volume is not a multi-attach volume. An attachment to another instance conflicts unless both are shared,
+// the error is then provider.ErrAttachConflict. An existing attachment to the same instance never conflicts
+func ValidateAttachMode(request provider.VolumeAttachmentRequest, volume *provider.Volume, existing []*provider.VolumeAttachmentResponse, capabilities *provider.Capabilities) error {
+	mode := AttachmentModeOf(request)
+	switch mode {
+	case provider.AttachmentModeExclusive:
+	case provider.AttachmentModeShared:
+		if !capabilities.HasFeature(provider.FeatureMultiAttach) {
+			return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Shared attachment of volume %s is not supported by the provider", request.VolumeID))
+		}
+		if volume != nil && !volume.MultiAttach {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Volume %s is not a multi-attach volume, it cannot be attached in shared mode", request.VolumeID))
+		}
+	default:
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid attachment mode '%s'", mode))
+	}
+
+	for _, attachment := range existing {
+		if attachment == nil || attachment.InstanceID == request.InstanceID {
+			continue
+		}
+		if mode == provider.AttachmentModeShared && AttachmentModeOf(attachment.VolumeAttachmentRequest) == provider.AttachmentModeShared {
+			continue
+		}
+		return NewErrorWithProperties(reasoncode.ErrorVolumeAttachConflict,
+			fmt.Sprintf("%s: volume %s has a %s attachment to instance %s", provider.ErrAttachConflict.Error(), request.VolumeID, AttachmentModeOf(attachment.VolumeAttachmentRequest), attachment.InstanceID),
+			map[string]string{VolumeIDProperty: request.VolumeID, AttachedInstanceProperty: attachment.InstanceID})
+	}
+	return nil
+}
+
+// AttachVolumeWithMode attaches the volume after checking the attachment mode against the volume and its existing
+// attachments (see ValidateAttachMode), conflicts reported by the backend are also returned as provider.ErrAttachConflict
+func AttachVolumeWithMode(sess provider.Session, request provider.VolumeAttachmentRequest, capabilities *provider.Capabilities, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	var volume *provider.Volume
+	if AttachmentModeOf(request) == provider.AttachmentModeShared {
+		var err error
+		if volume, err = sess.GetVolume(request.VolumeID); err != nil {
+			logger.Error("Failed to get the volume to attach", zap.String("VolumeID", request.VolumeID), ZapError(err))
+			return nil, err
+		}
+	}
+	existing, err := sess.ListVolumeAttachments(request.VolumeID)
+	if err != nil {
+		logger.Error("Failed to list the attachments of the volume", zap.String("VolumeID", request.VolumeID), ZapError(err))
+		return nil, err
+	}
+	if err := ValidateAttachMode(request, volume, existing, capabilities); err != nil {
+		logger.Warn("Attachment rejected", zap.String("VolumeID", request.VolumeID), zap.String("InstanceID", request.InstanceID), zap.String("Mode", string(AttachmentModeOf(request))), ZapError(err))
+		return nil, err
+	}
+
+	response, err := sess.AttachVolume(request)
+	if err != nil {
+		logger.Error("Failed to attach the volume", zap.String("VolumeID", request.VolumeID), zap.String("InstanceID", request.InstanceID), ZapError(err))
+		return nil, DetectAttachConflict(err)
+	}
+	return response, nil
+}
@@ -0,0 +1,138 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func attachment(instanceID string, mode provider.AttachmentMode) *provider.VolumeAttachmentResponse {
+	return &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: instanceID, Mode: mode}}
+}
+
+func TestValidateAttachMode(t *testing.T) {
+	multiAttach := &provider.Capabilities{Features: map[string]bool{provider.FeatureMultiAttach: true}}
+	shareable := &provider.Volume{VolumeID: "vol-1", MultiAttach: true}
+
+	testcases := []struct {
+		testcasename string
+		request      provider.VolumeAttachmentRequest
+		volume       *provider.Volume
+		existing     []*provider.VolumeAttachmentResponse
+		capabilities *provider.Capabilities
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "Exclusive on unattached volume",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"},
+		},
+		{
+			testcasename: "Exclusive on the same instance",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"},
+			existing:     []*provider.VolumeAttachmentResponse{attachment("inst-1", "")},
+		},
+		{
+			testcasename: "Exclusive on another instance",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2"},
+			existing:     []*provider.VolumeAttachmentResponse{attachment("inst-1", "")},
+			expectedCode: reasoncode.ErrorVolumeAttachConflict,
+		},
+		{
+			testcasename: "Shared alongside shared",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2", Mode: provider.AttachmentModeShared},
+			volume:       shareable,
+			existing:     []*provider.VolumeAttachmentResponse{attachment("inst-1", provider.AttachmentModeShared)},
+			capabilities: multiAttach,
+		},
+		{
+			testcasename: "Shared alongside exclusive",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2", Mode: provider.AttachmentModeShared},
+			volume:       shareable,
+			existing:     []*provider.VolumeAttachmentResponse{attachment("inst-1", provider.AttachmentModeExclusive)},
+			capabilities: multiAttach,
+			expectedCode: reasoncode.ErrorVolumeAttachConflict,
+		},
+		{
+			testcasename: "Shared on volume without multi-attach",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1", Mode: provider.AttachmentModeShared},
+			volume:       &provider.Volume{VolumeID: "vol-1"},
+			capabilities: multiAttach,
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Shared not supported",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1", Mode: provider.AttachmentModeShared},
+			volume:       shareable,
+			expectedCode: reasoncode.ErrorUnsupportedFeature,
+		},
+		{
+			testcasename: "Invalid mode",
+			request:      provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1", Mode: "readonly"},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateAttachMode(testcase.request, testcase.volume, testcase.existing, testcase.capabilities)
+			if testcase.expectedCode == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			if testcase.expectedCode == reasoncode.ErrorVolumeAttachConflict {
+				assert.True(t, errors.Is(err, provider.ErrAttachConflict))
+				assert.Equal(t, "inst-1", err.(provider.Error).Fault.Properties[AttachedInstanceProperty])
+			}
+		})
+	}
+}
+
+func TestAttachVolumeWithMode(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	multiAttach := &provider.Capabilities{Features: map[string]bool{provider.FeatureMultiAttach: true}}
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(&provider.Volume{VolumeID: "vol-1", MultiAttach: true}, nil)
+	sess.ListVolumeAttachmentsReturns([]*provider.VolumeAttachmentResponse{attachment("inst-1", provider.AttachmentModeShared)}, nil)
+	sess.AttachVolumeReturns(attachment("inst-2", provider.AttachmentModeShared), nil)
+
+	request := provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2", Mode: provider.AttachmentModeShared}
+	response, err := AttachVolumeWithMode(sess, request, multiAttach, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "inst-2", response.InstanceID)
+	assert.Equal(t, request, sess.AttachVolumeArgsForCall(0))
+
+	_, err = AttachVolumeWithMode(sess, provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2"}, multiAttach, logger)
+	assert.True(t, errors.Is(err, provider.ErrAttachConflict))
+	assert.Equal(t, 1, sess.AttachVolumeCallCount())
+
+	sess.ListVolumeAttachmentsReturns(nil, nil)
+	sess.AttachVolumeReturns(nil, errors.New("volume_attachment_conflict"))
+	_, err = AttachVolumeWithMode(sess, provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-2"}, multiAttach, logger)
+	assert.True(t, errors.Is(err, provider.ErrAttachConflict))
+
+	sess.ListVolumeAttachmentsReturns(nil, errors.New("unreachable"))
+	_, err = AttachVolumeWithMode(sess, request, multiAttach, logger)
+	assert.NotNil(t, err)
+}
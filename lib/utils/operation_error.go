@@ -0,0 +1,81 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+const (
+	// OperationProperty is the error property holding the provider operation
+	OperationProperty = "Operation"
+
+	// VolumeIDProperty is the error property holding the volume ID
+	VolumeIDProperty = "VolumeID"
+
+	// VolumeNameProperty is the error property holding the volume name
+	VolumeNameProperty = "VolumeName"
+)
+
+// WrapOperationError attaches the operation and the volume ID/name to the error message and, for a
+// provider.Error, to its properties. The error type and reason code are preserved
+func WrapOperationError(err error, operation, volumeID, volumeName string) error {
+	if err == nil {
+		return nil
+	}
+	tags := map[string]string{OperationProperty: operation, VolumeIDProperty: volumeID, VolumeNameProperty: volumeName}
+	suffix := operationTags(operation, volumeID, volumeName)
+
+	switch e := err.(type) {
+	case provider.Error:
+		properties := make(map[string]string, len(e.Fault.Properties)+len(tags))
+		for k, v := range e.Fault.Properties {
+			properties[k] = v
+		}
+		for k, v := range tags {
+			if v != "" {
+				properties[k] = v
+			}
+		}
+		e.Fault.Properties = properties
+		e.Fault.Message = e.Fault.Message + " " + suffix
+		return e
+	case Message:
+		e.Description = e.Description + " " + suffix
+		return e
+	}
+	return fmt.Errorf("%w %s", err, suffix)
+}
+
+// operationTags formats the non empty tags, e.g. "[operation=DeleteVolume, volumeID=r006-1234]"
+func operationTags(operation, volumeID, volumeName string) string {
+	var tags []string
+	if operation != "" {
+		tags = append(tags, "operation="+operation)
+	}
+	if volumeID != "" {
+		tags = append(tags, "volumeID="+volumeID)
+	}
+	if volumeName != "" {
+		tags = append(tags, "volumeName="+volumeName)
+	}
+	return "[" + strings.Join(tags, ", ") + "]"
+}
@@ -0,0 +1,46 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapOperationError(t *testing.T) {
+	assert.Nil(t, WrapOperationError(nil, "DeleteVolume", "vol-1", ""))
+
+	err := WrapOperationError(NewErrorWithProperties(reasoncode.ErrorBadRequest, "Bad request", map[string]string{"a": "b"}), "DeleteVolume", "vol-1", "")
+	perr := err.(provider.Error)
+	assert.Equal(t, "Bad request [operation=DeleteVolume, volumeID=vol-1]", perr.Error())
+	assert.Equal(t, reasoncode.ErrorBadRequest, perr.Code())
+	assert.Equal(t, map[string]string{"a": "b", OperationProperty: "DeleteVolume", VolumeIDProperty: "vol-1"}, perr.Properties())
+
+	err = WrapOperationError(Message{Type: EntityNotFound, Description: "Volume not found"}, "GetVolume", "", "pvc-1")
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "Volume not found [operation=GetVolume, volumeName=pvc-1]")
+
+	cause := errors.New("connection reset")
+	err = WrapOperationError(cause, "AttachVolume", "vol-1", "pvc-1")
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, "connection reset [operation=AttachVolume, volumeID=vol-1, volumeName=pvc-1]", err.Error())
+}
@@ -0,0 +1,74 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// DefaultMaxOperationTimeout is the default ceiling of any operation
+const DefaultMaxOperationTimeout = 30 * time.Minute
+
+// ParseMaxOperationTimeout parses the max_operation_timeout config value, DefaultMaxOperationTimeout is
+// returned for an empty value
+func ParseMaxOperationTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultMaxOperationTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid max operation timeout '%s'", value)
+	}
+	return timeout, nil
+}
+
+// RunWithTimeoutGuardrail runs the operation with a context bounded by maxTimeout. If the operation does not
+// return within maxTimeout, even when it ignores its context, provider.ErrOperationAbandoned is returned
+// and the operation is recorded as abandoned in the metrics. The abandoned operation keeps running in
+// background, its result is discarded
+func RunWithTimeoutGuardrail(ctx context.Context, operation string, maxTimeout time.Duration, fn func(ctx context.Context) error, logger *zap.Logger) error {
+	if maxTimeout <= 0 {
+		maxTimeout = DefaultMaxOperationTimeout
+	}
+	guardedCtx, cancel := context.WithTimeout(ctx, maxTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(guardedCtx)
+	}()
+
+	timer := time.NewTimer(maxTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		logger.Error("Operation abandoned after exceeding the maximum operation timeout", zap.String("operation", operation), zap.Duration("maxTimeout", maxTimeout))
+		metrics.RegisterAbandonedOperation(operation)
+		return NewErrorWithProperties(reasoncode.ErrorOperationAbandoned,
+			fmt.Sprintf("Operation %s abandoned after exceeding the maximum operation timeout of %s", operation, maxTimeout),
+			map[string]string{OperationProperty: operation})
+	}
+}
@@ -0,0 +1,70 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestParseMaxOperationTimeout(t *testing.T) {
+	timeout, err := ParseMaxOperationTimeout("")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultMaxOperationTimeout, timeout)
+
+	timeout, err = ParseMaxOperationTimeout("10m")
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, timeout)
+
+	_, err = ParseMaxOperationTimeout("-1m")
+	assert.NotNil(t, err)
+	_, err = ParseMaxOperationTimeout("ten minutes")
+	assert.NotNil(t, err)
+}
+
+func TestRunWithTimeoutGuardrail(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	failure := errors.New("failed")
+
+	err := RunWithTimeoutGuardrail(context.Background(), "CreateVolume", time.Second, func(ctx context.Context) error { return failure }, logger)
+	assert.Equal(t, failure, err)
+
+	// Operation ignoring its context is abandoned
+	block := make(chan struct{})
+	defer close(block)
+	err = RunWithTimeoutGuardrail(context.Background(), "CreateVolume", 10*time.Millisecond, func(ctx context.Context) error {
+		<-block
+		return nil
+	}, logger)
+	assert.True(t, errors.Is(err, provider.ErrOperationAbandoned))
+
+	// Operation context carries the ceiling
+	err = RunWithTimeoutGuardrail(context.Background(), "CreateVolume", time.Hour, func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.True(t, time.Until(deadline) <= time.Hour)
+		return nil
+	}, logger)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,181 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// PageCursorVersion is the schema version of the page cursors issued by this library
+const PageCursorVersion = 1
+
+const (
+	// DefaultPageLimit is the page size of the list calls without limit
+	DefaultPageLimit = 50
+
+	// MaxPageLimit is the maximum page size of the list calls, the maximum limit of the VPC API
+	MaxPageLimit = 100
+)
+
+// PageCursor is the resumable position of a paginated listing. It is serialized as an opaque token which
+// remains valid across process restarts, as long as the listing filter is unchanged
+type PageCursor struct {
+	// Version is the schema version of the cursor
+	Version int `json:"v"`
+	// FilterHash identifies the filter the cursor was issued for
+	FilterHash string `json:"f"`
+	// Start is the backend start token of the next page
+	Start string `json:"s"`
+	// IssuedAt is the time the cursor was issued
+	IssuedAt time.Time `json:"t"`
+}
+
+// FilterHash returns the hash identifying a listing filter
+func FilterHash(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sum := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(sum, "%q=%q;", key, tags[key])
+	}
+	return hex.EncodeToString(sum.Sum(nil)[:8])
+}
+
+// NewPageCursor returns the cursor of the backend start token for the filter
+func NewPageCursor(tags map[string]string, start string) PageCursor {
+	return PageCursor{Version: PageCursorVersion, FilterHash: FilterHash(tags), Start: start, IssuedAt: time.Now().UTC()}
+}
+
+// Encode returns the opaque token of the cursor
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor decodes a token issued by Encode and checks it was issued for the filter, and not more than
+// maxAge ago (no expiry if 0). An ErrorBadRequest error is returned for invalid, foreign or expired tokens
+func DecodePageCursor(token string, tags map[string]string, maxAge time.Duration) (PageCursor, error) {
+	var cursor PageCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil {
+		return PageCursor{}, NewError(reasoncode.ErrorBadRequest, "Invalid page cursor", err)
+	}
+	if cursor.Version != PageCursorVersion {
+		return PageCursor{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Unsupported page cursor version %d", cursor.Version))
+	}
+	if cursor.FilterHash != FilterHash(tags) {
+		return PageCursor{}, NewError(reasoncode.ErrorBadRequest, "Page cursor was issued for a different filter")
+	}
+	if maxAge > 0 && time.Since(cursor.IssuedAt) > maxAge {
+		return PageCursor{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Page cursor expired, it was issued at %s", cursor.IssuedAt.Format(time.RFC3339)))
+	}
+	return cursor, nil
+}
+
+// PageLimit returns the page size of a list call with the limit: DefaultPageLimit if not positive, at most
+// MaxPageLimit, so a listing never loads all the resources of the account in one call
+func PageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
+
+// listPage lists one page from the position of the cursor token with list, returning the items and the token of
+// the next page (empty after the last page)
+func listPage[T any](token string, tags map[string]string, list func(start string) ([]T, string, error)) ([]T, string, error) {
+	start := ""
+	if token != "" {
+		cursor, err := DecodePageCursor(token, tags, 0)
+		if err != nil {
+			return nil, "", err
+		}
+		start = cursor.Start
+	}
+	items, next, err := list(start)
+	if err != nil {
+		return nil, "", err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	if next == "" || next == start {
+		return items, "", nil
+	}
+	return items, NewPageCursor(tags, next).Encode(), nil
+}
+
+// ListVolumesPage lists one page of volumes (at most PageLimit of the options limit) from the position of the cursor
+// token (the first page if empty). The returned token resumes the listing, it is empty after the last page
+func ListVolumesPage(sess provider.VolumeManager, token string, options ListOptions) ([]*provider.Volume, string, error) {
+	volumes, next, err := listPage(token, options.volumeFilterTags(), func(start string) ([]*provider.Volume, string, error) {
+		volumes, err := options.listVolumes(sess, start)
+		if err != nil || volumes == nil {
+			return nil, "", err
+		}
+		return volumes.Volumes, volumes.Next, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return options.FieldMask.maskVolumes(volumes), next, nil
+}
+
+// ListSnapshotsPage lists one page of snapshots like ListVolumesPage
+func ListSnapshotsPage(sess provider.SnapshotManager, token string, options ListOptions) ([]*provider.Snapshot, string, error) {
+	snapshots, next, err := listPage(token, options.Tags, func(start string) ([]*provider.Snapshot, string, error) {
+		snapshots, err := sess.ListSnapshots(PageLimit(options.Limit), start, options.Tags)
+		if err != nil || snapshots == nil {
+			return nil, "", err
+		}
+		return snapshots.Snapshots, snapshots.Next, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return options.FieldMask.maskSnapshots(snapshots), next, nil
+}
+
+// ListSharesPage lists one page of file shares like ListVolumesPage, the field mask does not apply to the shares
+func ListSharesPage(sess provider.FileShareManager, token string, options ListOptions) ([]*provider.FileShare, string, error) {
+	return listPage(token, options.Tags, func(start string) ([]*provider.FileShare, string, error) {
+		shares, err := sess.ListShares(PageLimit(options.Limit), start, options.Tags)
+		if err != nil || shares == nil {
+			return nil, "", err
+		}
+		return shares.Shares, shares.Next, nil
+	})
+}
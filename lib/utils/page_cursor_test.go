@@ -0,0 +1,148 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageCursor(t *testing.T) {
+	tags := map[string]string{"cluster": "abc", "zone": "us-south-1"}
+	assert.Equal(t, FilterHash(tags), FilterHash(map[string]string{"zone": "us-south-1", "cluster": "abc"}))
+	assert.NotEqual(t, FilterHash(tags), FilterHash(nil))
+
+	token := NewPageCursor(tags, "start-2").Encode()
+	cursor, err := DecodePageCursor(token, tags, time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, "start-2", cursor.Start)
+	assert.Equal(t, PageCursorVersion, cursor.Version)
+
+	_, err = DecodePageCursor(token, map[string]string{"cluster": "other"}, 0)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+
+	_, err = DecodePageCursor("not-a-cursor", tags, 0)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+
+	old := NewPageCursor(tags, "start-2")
+	old.IssuedAt = time.Now().Add(-2 * time.Hour)
+	_, err = DecodePageCursor(old.Encode(), tags, time.Hour)
+	assert.NotNil(t, err)
+
+	future := NewPageCursor(tags, "start-2")
+	future.Version = PageCursorVersion + 1
+	_, err = DecodePageCursor(future.Encode(), tags, 0)
+	assert.NotNil(t, err)
+}
+
+func TestListVolumesPage(t *testing.T) {
+	tags := map[string]string{"cluster": "abc"}
+	sess := &fake.FakeSession{}
+	sess.ListVolumesReturnsOnCall(0, &provider.VolumeList{Next: "start-2", Volumes: []*provider.Volume{{VolumeID: "vol-1"}}}, nil)
+	sess.ListVolumesReturnsOnCall(1, &provider.VolumeList{Volumes: []*provider.Volume{{VolumeID: "vol-2"}}}, nil)
+
+	volumes, token, err := ListVolumesPage(sess, "", ListOptions{Limit: 1, Tags: tags})
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-1", volumes[0].VolumeID)
+	assert.NotEmpty(t, token)
+
+	// the token survives a restart, only its string form is kept
+	volumes, token, err = ListVolumesPage(sess, string([]byte(token)), ListOptions{Limit: 1, Tags: tags})
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-2", volumes[0].VolumeID)
+	assert.Empty(t, token)
+	_, start, _ := sess.ListVolumesArgsForCall(1)
+	assert.Equal(t, "start-2", start)
+
+	_, _, err = ListVolumesPage(sess, "bogus", ListOptions{Tags: tags})
+	assert.NotNil(t, err)
+}
+
+func TestListVolumesPageWithFilters(t *testing.T) {
+	sess := &fake.FakeSession{}
+	sess.ListVolumesWithFiltersReturns(&provider.VolumeList{Next: "start-2", Volumes: []*provider.Volume{{VolumeID: "vol-1"}}}, nil)
+	options := ListOptions{Filters: provider.ListVolumesFilters{NamePrefix: "pvc-"}}
+
+	_, token, err := ListVolumesPage(sess, "", options)
+	assert.Nil(t, err)
+	_, _, err = ListVolumesPage(sess, token, options)
+	assert.Nil(t, err)
+	_, start, _ := sess.ListVolumesWithFiltersArgsForCall(1)
+	assert.Equal(t, "start-2", start)
+
+	// the token is bound to the filters
+	_, _, err = ListVolumesPage(sess, token, ListOptions{Filters: provider.ListVolumesFilters{NamePrefix: "data-"}})
+	assert.NotNil(t, err)
+}
+
+func TestPageLimit(t *testing.T) {
+	assert.Equal(t, DefaultPageLimit, PageLimit(0))
+	assert.Equal(t, DefaultPageLimit, PageLimit(-1))
+	assert.Equal(t, 10, PageLimit(10))
+	assert.Equal(t, MaxPageLimit, PageLimit(5000))
+}
+
+func TestListSnapshotsPage(t *testing.T) {
+	sess := &fake.FakeSession{}
+	sess.ListSnapshotsReturnsOnCall(0, &provider.SnapshotList{Next: "start-2", Snapshots: []*provider.Snapshot{{SnapshotID: "snap-1"}}}, nil)
+	sess.ListSnapshotsReturnsOnCall(1, nil, nil)
+
+	snapshots, token, err := ListSnapshotsPage(sess, "", ListOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "snap-1", snapshots[0].SnapshotID)
+	limit, _, _ := sess.ListSnapshotsArgsForCall(0)
+	assert.Equal(t, DefaultPageLimit, limit)
+
+	snapshots, token, err = ListSnapshotsPage(sess, token, ListOptions{})
+	assert.Nil(t, err)
+	assert.Empty(t, snapshots)
+	assert.Empty(t, token)
+	_, start, _ := sess.ListSnapshotsArgsForCall(1)
+	assert.Equal(t, "start-2", start)
+}
+
+func TestListSharesPage(t *testing.T) {
+	tags := map[string]string{"cluster": "abc"}
+	sess := &fake.FakeSession{}
+	sess.ListSharesReturnsOnCall(0, &provider.FileShareList{Next: "start-2", Shares: []*provider.FileShare{{ID: "share-1"}}}, nil)
+	sess.ListSharesReturnsOnCall(1, &provider.FileShareList{Next: "start-2", Shares: []*provider.FileShare{{ID: "share-2"}}}, nil)
+
+	shares, token, err := ListSharesPage(sess, "", ListOptions{Limit: 500, Tags: tags})
+	assert.Nil(t, err)
+	assert.Equal(t, "share-1", shares[0].ID)
+	limit, _, _ := sess.ListSharesArgsForCall(0)
+	assert.Equal(t, MaxPageLimit, limit)
+
+	// a page returning its own start as next is the last page
+	shares, token, err = ListSharesPage(sess, token, ListOptions{Tags: tags})
+	assert.Nil(t, err)
+	assert.Equal(t, "share-2", shares[0].ID)
+	assert.Empty(t, token)
+
+	// the token of a listing is rejected for another filter
+	sess.ListSharesReturnsOnCall(2, &provider.FileShareList{Next: "start-2"}, nil)
+	_, token, _ = ListSharesPage(sess, "", ListOptions{Tags: tags})
+	assert.NotEmpty(t, token)
+	_, _, err = ListSharesPage(sess, token, ListOptions{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,214 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+)
+
+// OperationClass classifies provider API calls for rate limiting
+type OperationClass string
+
+const (
+	// ReadOperation is a non mutating call (get, list, poll)
+	ReadOperation = OperationClass("read")
+
+	// MutateOperation is a call changing a resource (create, delete, attach, detach ...)
+	MutateOperation = OperationClass("mutate")
+)
+
+// TokenBucket is a token bucket rate limiter refilled at qps tokens per second, holding at most burst tokens
+type TokenBucket struct {
+	mu     sync.Mutex
+	qps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket. A non-positive qps disables the limit
+func NewTokenBucket(qps float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{qps: qps, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Reserve takes a token and returns how long the caller has to wait before using it
+func (b *TokenBucket) Reserve() time.Duration {
+	if b == nil || b.qps <= 0 {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.qps
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.qps * float64(time.Second))
+}
+
+// Cancel returns a token taken by Reserve and not used, e.g. when the caller stops waiting for it
+func (b *TokenBucket) Cancel() {
+	if b == nil || b.qps <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Wait blocks until a token is available and returns the time spent waiting
+func (b *TokenBucket) Wait() time.Duration {
+	wait := b.Reserve()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return wait
+}
+
+// RateLimiter limits provider API calls with independent buckets for read and mutate operations,
+// so that list/poll traffic never starves attach/detach calls
+type RateLimiter struct {
+	read   *TokenBucket
+	mutate *TokenBucket
+	stats  *StatsCollector
+}
+
+// NewRateLimiter returns a RateLimiter, a non-positive qps disables the limit of the operation class
+func NewRateLimiter(readQPS float64, readBurst int, mutateQPS float64, mutateBurst int) *RateLimiter {
+	return &RateLimiter{
+		read:   NewTokenBucket(readQPS, readBurst),
+		mutate: NewTokenBucket(mutateQPS, mutateBurst),
+	}
+}
+
+// NewRateLimiterFromConfig returns the RateLimiter of the read and mutate rate limits of the VPC config
+func NewRateLimiterFromConfig(vpc *config.VPCProviderConfig) *RateLimiter {
+	return NewRateLimiter(vpc.ReadRateLimitQPS, vpc.ReadRateLimitBurst, vpc.MutateRateLimitQPS, vpc.MutateRateLimitBurst)
+}
+
+// SetStatsCollector records the time spent waiting in the collector
+func (rl *RateLimiter) SetStatsCollector(stats *StatsCollector) {
+	rl.stats = stats
+}
+
+// bucket returns the bucket of the operation class
+func (rl *RateLimiter) bucket(class OperationClass) *TokenBucket {
+	if class == ReadOperation {
+		return rl.read
+	}
+	return rl.mutate
+}
+
+// recordWait records the time spent waiting in the stats collector and the metrics
+func (rl *RateLimiter) recordWait(class OperationClass, wait time.Duration) {
+	rl.stats.RecordRateLimiterWait(class, wait)
+	metrics.RecordRateLimiterWait(string(class), wait)
+}
+
+// Wait blocks until the operation class is allowed to proceed and returns the time spent waiting
+func (rl *RateLimiter) Wait(class OperationClass) time.Duration {
+	if rl == nil {
+		return 0
+	}
+	wait := rl.bucket(class).Wait()
+	rl.recordWait(class, wait)
+	return wait
+}
+
+// WaitContext is Wait charging the time spent waiting to the latency budget carried by ctx, if any
+func (rl *RateLimiter) WaitContext(ctx context.Context, class OperationClass) time.Duration {
+	wait := rl.Wait(class)
+	LatencyBudgetFromContext(ctx).Charge(LatencyLayerRateLimit, wait)
+	return wait
+}
+
+// WaitWithContext is WaitContext returning the error of the context if it is done before the operation class is
+// allowed to proceed, e.g. the deadline of a CSI request during a mass detach. The token reserved for the
+// operation is returned to the bucket in that case, it does not delay the next operations
+func (rl *RateLimiter) WaitWithContext(ctx context.Context, class OperationClass) (time.Duration, error) {
+	if rl == nil {
+		return 0, ctx.Err()
+	}
+	var waited time.Duration
+	var err error
+	bucket := rl.bucket(class)
+	if wait := bucket.Reserve(); wait > 0 {
+		start := time.Now()
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			bucket.Cancel()
+		case <-timer.C:
+		}
+		timer.Stop()
+		waited = time.Since(start)
+	}
+	rl.recordWait(class, waited)
+	LatencyBudgetFromContext(ctx).Charge(LatencyLayerRateLimit, waited)
+	return waited, err
+}
+
+// Transport returns an http.RoundTripper sending the requests with base (http.DefaultTransport if nil) once
+// allowed by the limiter, the GET and HEAD requests are read operations and the others mutate operations
+func (rl *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if rl == nil {
+		return base
+	}
+	return &rateLimitedTransport{base: base, limiter: rl}
+}
+
+// rateLimitedTransport sends the requests once allowed by the rate limiter
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+var _ http.RoundTripper = &rateLimitedTransport{}
+
+// RoundTrip ...
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	class := MutateOperation
+	if IsReadRequest(req) {
+		class = ReadOperation
+	}
+	if _, err := t.limiter.WaitWithContext(req.Context(), class); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(10, 2)
+	assert.Equal(t, time.Duration(0), b.Reserve())
+	assert.Equal(t, time.Duration(0), b.Reserve())
+	wait := b.Reserve()
+	assert.True(t, wait > 50*time.Millisecond && wait <= 100*time.Millisecond, wait.String())
+
+	unlimited := NewTokenBucket(0, 0)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, time.Duration(0), unlimited.Reserve())
+	}
+}
+
+func TestRateLimiterSeparatesClasses(t *testing.T) {
+	rl := NewRateLimiter(1, 1, 0, 0)
+	assert.Equal(t, time.Duration(0), rl.Wait(ReadOperation))
+	assert.True(t, rl.read.Reserve() > 0)
+
+	// Exhausted read bucket does not affect mutate operations
+	assert.Equal(t, time.Duration(0), rl.Wait(MutateOperation))
+
+	var nilLimiter *RateLimiter
+	assert.Equal(t, time.Duration(0), nilLimiter.Wait(ReadOperation))
+}
+
+func TestRateLimiterWaitWithContext(t *testing.T) {
+	rl := NewRateLimiter(0.1, 1, 0, 0)
+	wait, err := rl.WaitWithContext(context.Background(), ReadOperation)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// the next read token is 10s away, the wait stops at the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	wait, err = rl.WaitWithContext(ctx, ReadOperation)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, wait >= 20*time.Millisecond && wait < time.Second, wait.String())
+
+	// the token of the cancelled wait is returned, the next read token is still about 10s away and not 20s
+	next := rl.bucket(ReadOperation).Reserve()
+	assert.True(t, next > 9*time.Second && next <= 10*time.Second, next.String())
+
+	var nilLimiter *RateLimiter
+	_, err = nilLimiter.WaitWithContext(context.Background(), MutateOperation)
+	assert.Nil(t, err)
+}
+
+func TestRateLimiterTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	rl := NewRateLimiterFromConfig(&config.VPCProviderConfig{ReadRateLimitQPS: 0.1, ReadRateLimitBurst: 1})
+	client := &http.Client{Transport: rl.Transport(nil)}
+
+	response, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	_ = response.Body.Close()
+
+	// the read bucket is exhausted, the mutate calls are not limited
+	response, err = client.Post(server.URL, "application/json", nil)
+	assert.Nil(t, err)
+	_ = response.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	_, err = client.Do(request)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
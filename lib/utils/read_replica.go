@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"go.uber.org/zap"
+)
+
+// IsReadRequest reports whether the request does not mutate any resource (GET and HEAD), i.e. a ReadOperation
+func IsReadRequest(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// ReadReplicaTransport routes the non-mutating requests to a read replica endpoint, offloading the inventory and
+// poll traffic from the primary endpoint. A request failing on the replica (transport error or 5xx) is sent to
+// its primary endpoint, and the replica is bypassed for the cooldown. A resource not found on the replica is read
+// from the primary endpoint too, the replica lagging behind it (e.g. right after the resource creation)
+type ReadReplicaTransport struct {
+	base     http.RoundTripper
+	replica  *url.URL
+	cooldown time.Duration
+	logger   *zap.Logger
+	now      func() time.Time
+
+	mu          sync.Mutex
+	bypassUntil time.Time
+}
+
+var _ http.RoundTripper = &ReadReplicaTransport{}
+
+// NewReadReplicaTransport returns a transport sending the read requests through base to the scheme and host of
+// the replica endpoint, http.DefaultTransport if base is nil
+func NewReadReplicaTransport(base http.RoundTripper, replicaEndpoint string, cooldown time.Duration, logger *zap.Logger) (*ReadReplicaTransport, error) {
+	replica, err := url.Parse(replicaEndpoint)
+	if err != nil || replica.Host == "" {
+		return nil, fmt.Errorf("invalid read replica endpoint '%s'", replicaEndpoint)
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &ReadReplicaTransport{base: base, replica: replica, cooldown: cooldown, logger: logger, now: time.Now}, nil
+}
+
+// NewReadReplicaTransportFromConfig returns the read replica transport of the VPC config, base itself if no read
+// replica is configured or the VPE gateway is used
+func NewReadReplicaTransportFromConfig(vpc *config.VPCProviderConfig, base http.RoundTripper, logger *zap.Logger) (http.RoundTripper, error) {
+	if vpc == nil || vpc.ReadEndpointURL() == vpc.RIaaSEndpointURL() {
+		return base, nil
+	}
+	if err := vpc.ValidateReadReplica(); err != nil {
+		return nil, err
+	}
+	cooldown, _ := vpc.ReadReplicaCooldownDuration()
+	return NewReadReplicaTransport(base, vpc.ReadReplicaEndpointURL, cooldown, logger)
+}
+
+// RoundTrip ...
+func (t *ReadReplicaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !IsReadRequest(req) || t.bypassed() {
+		return t.base.RoundTrip(req)
+	}
+
+	replicaReq := req.Clone(req.Context())
+	replicaReq.URL.Scheme = t.replica.Scheme
+	replicaReq.URL.Host = t.replica.Host
+	replicaReq.Host = ""
+	resp, err := t.base.RoundTrip(replicaReq)
+	if err == nil && resp.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		t.logger.Debug("Resource not found on the read replica, reading it from the primary endpoint",
+			zap.String("Replica", t.replica.Host), zap.String("Path", req.URL.Path))
+		return t.base.RoundTrip(req)
+	}
+	if err == nil && resp.StatusCode < http.StatusInternalServerError {
+		return resp, nil
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	if err == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		err = fmt.Errorf("read replica returned %s", resp.Status)
+	}
+	t.bypass(req.URL.Host, err)
+	return t.base.RoundTrip(req)
+}
+
+// bypassed reports whether the replica failed during the cooldown
+func (t *ReadReplicaTransport) bypassed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.now().Before(t.bypassUntil)
+}
+
+// bypass sends the read requests to the primary endpoint for the cooldown
+func (t *ReadReplicaTransport) bypass(primary string, cause error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bypassUntil = t.now().Add(t.cooldown)
+	metrics.RegisterEndpointSwitch(t.replica.Host, primary)
+	t.logger.Warn("Read replica failed, falling back to the primary endpoint", zap.String("Replica", t.replica.Host),
+		zap.String("Primary", primary), zap.Duration("Cooldown", t.cooldown), ZapError(cause))
+}
@@ -0,0 +1,114 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// endpointServer counts the requests it serves, with the given status
+func endpointServer(status *int32, served *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(served, 1)
+		w.WriteHeader(int(atomic.LoadInt32(status)))
+	}))
+}
+
+func TestReadReplicaTransport(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	var primaryStatus, replicaStatus int32 = http.StatusOK, http.StatusOK
+	var primaryServed, replicaServed int32
+	primary := endpointServer(&primaryStatus, &primaryServed)
+	defer primary.Close()
+	replica := endpointServer(&replicaStatus, &replicaServed)
+	defer replica.Close()
+
+	transport, err := NewReadReplicaTransport(nil, replica.URL, time.Minute, logger)
+	assert.Nil(t, err)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	// Reads go to the replica, mutations to the primary
+	resp, err := client.Get(primary.URL + "/v1/volumes")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, err = client.Post(primary.URL+"/v1/volumes", "application/json", strings.NewReader("{}"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&replicaServed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&primaryServed))
+
+	// A failed read falls back to the primary, which serves the reads during the cooldown
+	atomic.StoreInt32(&replicaStatus, http.StatusServiceUnavailable)
+	resp, err = client.Get(primary.URL + "/v1/volumes")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	_, err = client.Get(primary.URL + "/v1/volumes")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&replicaServed))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&primaryServed))
+
+	// After the cooldown the replica is used again
+	atomic.StoreInt32(&replicaStatus, http.StatusOK)
+	now = now.Add(2 * time.Minute)
+	_, err = client.Get(primary.URL + "/v1/volumes")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&replicaServed))
+
+	// A resource not found on the lagging replica is read from the primary, the replica is not bypassed
+	atomic.StoreInt32(&replicaStatus, http.StatusNotFound)
+	resp, err = client.Get(primary.URL + "/v1/volumes/vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&primaryServed))
+	atomic.StoreInt32(&replicaStatus, http.StatusOK)
+	_, err = client.Get(primary.URL + "/v1/volumes")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&replicaServed))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&primaryServed))
+}
+
+func TestNewReadReplicaTransportFromConfig(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	base := http.DefaultTransport
+
+	transport, err := NewReadReplicaTransportFromConfig(&config.VPCProviderConfig{EndpointURL: "https://us-south.iaas.cloud.ibm.com"}, base, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, base, transport)
+
+	vpc := &config.VPCProviderConfig{EndpointURL: "https://us-south.iaas.cloud.ibm.com", ReadReplicaEndpointURL: "https://replica.example.com", ReadReplicaCooldown: "10s"}
+	transport, err = NewReadReplicaTransportFromConfig(vpc, base, logger)
+	assert.Nil(t, err)
+	if assert.IsType(t, &ReadReplicaTransport{}, transport) {
+		assert.Equal(t, 10*time.Second, transport.(*ReadReplicaTransport).cooldown)
+	}
+
+	vpc.ReadReplicaEndpointURL = "http://replica.example.com"
+	_, err = NewReadReplicaTransportFromConfig(vpc, base, logger)
+	assert.NotNil(t, err)
+}
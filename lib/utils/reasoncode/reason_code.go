@@ -35,6 +35,22 @@ const (
 	// ErrorRateLimitExceeded indicates IaaS API rate limit has been exceeded
 	// (Caller can continue to retry indefinitely)
 	ErrorRateLimitExceeded = ReasonCode("ErrorRateLimitExceeded")
+
+	// ErrorCircuitOpen indicates the call was not sent as the recent calls to the endpoint consistently failed
+	// (Caller can retry after the RetryAfter of the error, once the endpoint recovers)
+	ErrorCircuitOpen = ReasonCode("ErrorCircuitOpen")
+
+	// ErrorOperationAbandoned indicates the operation exceeded the global operation timeout and was abandoned
+	// (Outcome of the operation is unknown, caller must check the resource state before retrying)
+	ErrorOperationAbandoned = ReasonCode("ErrorOperationAbandoned")
+
+	// ErrorWaiterCancelled indicates the wait for the operation was cancelled, e.g. by an administrator
+	// (Outcome of the operation is unknown, caller must check the resource state before retrying)
+	ErrorWaiterCancelled = ReasonCode("ErrorWaiterCancelled")
+
+	// ErrorMaintenanceFreeze indicates the mutating operation was refused as the library is read-only during a
+	// maintenance freeze (Caller can retry once the freeze is lifted)
+	ErrorMaintenanceFreeze = ReasonCode("ErrorMaintenanceFreeze")
 )
 
 // -- General provider API (RPC) errors ---
@@ -56,6 +72,18 @@ const (
 	// ErrorUnsupportedMethod indicates the requested Provider API method is not supported
 	// (Caller can treat this as a fatal failure)
 	ErrorUnsupportedMethod = ReasonCode("ErrorUnsupportedMethod")
+
+	// ErrorResourceNotFound indicates the resource (volume, snapshot, ...) of the request does not exist
+	// (Caller can treat this as a fatal failure)
+	ErrorResourceNotFound = ReasonCode("ErrorResourceNotFound")
+
+	// ErrorUnsupportedFeature indicates the requested feature is not supported by the volume/share profile or provider
+	// (Caller can treat this as a fatal failure)
+	ErrorUnsupportedFeature = ReasonCode("ErrorUnsupportedFeature")
+
+	// ErrorResourceAlreadyExists indicates a resource with the same name but a different specification already exists
+	// (Caller can treat this as a fatal failure)
+	ErrorResourceAlreadyExists = ReasonCode("ErrorResourceAlreadyExists")
 )
 
 // -- Authentication and authorization problems --
@@ -74,6 +102,10 @@ const (
 	// ErrorUnauthorised indicates an IaaS authorisation error
 	ErrorUnauthorised = ReasonCode("ErrorUnauthorised")
 
+	// ErrorEncryptionKeyNotAuthorized indicates the storage service is not authorized to use the customer managed
+	// encryption key (missing service to service authorization to Key Protect or Hyper Protect Crypto Services)
+	ErrorEncryptionKeyNotAuthorized = ReasonCode("ErrorEncryptionKeyNotAuthorized")
+
 	// ErrorFailedTokenExchange indicates an IAM token exchange problem
 	ErrorFailedTokenExchange = ReasonCode("ErrorFailedTokenExchange")
 
@@ -91,4 +123,30 @@ const (
 	ErrorVolumeAttachFailed = ReasonCode("ErrorVolumeAttachFailed")
 	//ErrorVolumeDetachFailed indicates if volume detach from instance is failed
 	ErrorVolumeDetachFailed = ReasonCode("ErrorVolumeDetachFailed")
+	//ErrorInstanceNotFound indicates the instance (VSI) of an attach/detach request no longer exists
+	ErrorInstanceNotFound = ReasonCode("ErrorInstanceNotFound")
+	//ErrorVolumeAttachConflict indicates the volume is already attached to another instance
+	ErrorVolumeAttachConflict = ReasonCode("ErrorVolumeAttachConflict")
+)
+
+// Volume lifecycle problems
+const (
+	//ErrorDeletionStuck indicates the volume still exists after waiting for its deletion to complete
+	ErrorDeletionStuck = ReasonCode("ErrorDeletionStuck")
+	//ErrorSnapshotPruneFailed indicates some snapshots could not be pruned
+	ErrorSnapshotPruneFailed = ReasonCode("ErrorSnapshotPruneFailed")
+	//ErrorVolumeDeletionProtected indicates the volume is protected against deletion
+	ErrorVolumeDeletionProtected = ReasonCode("ErrorVolumeDeletionProtected")
+	//ErrorVolumeFenced indicates the volume is owned by another controller holding an unexpired fence lease
+	ErrorVolumeFenced = ReasonCode("ErrorVolumeFenced")
+)
+
+// Capacity problems
+const (
+	//ErrorQuotaExceeded indicates the request exceeds a quota of the account (e.g. number of volumes, total capacity)
+	//(Caller must free resources or raise the quota before retrying)
+	ErrorQuotaExceeded = ReasonCode("ErrorQuotaExceeded")
+	//ErrorInsufficientCapacity indicates the zone has not enough capacity left for the request
+	//(Caller can retry later or in another zone)
+	ErrorInsufficientCapacity = ReasonCode("ErrorInsufficientCapacity")
 )
@@ -0,0 +1,200 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// DefaultResourceCacheTTL is the default lifetime of the volumes and snapshots of a ResourceCache
+const DefaultResourceCacheTTL = 30 * time.Second
+
+// MaxResourceCacheEntries is the number of resources cached by a ResourceCache, the expired resources then the
+// resources expiring first are evicted to cache more
+const MaxResourceCacheEntries = 4096
+
+// Cache names of the ResourceCache lookups recorded by the StatsCollector
+const (
+	VolumeCacheName   = "volume"
+	SnapshotCacheName = "snapshot"
+)
+
+type cacheOptions struct {
+	bypass bool
+}
+
+// CacheOption is an option of the ResourceCache lookups
+type CacheOption func(*cacheOptions)
+
+// WithBypassCache fetches the resource from the backend even if cached, and caches the fetched resource
+func WithBypassCache() CacheOption {
+	return func(o *cacheOptions) { o.bypass = true }
+}
+
+type resourceCacheKey struct {
+	kind string
+	id   string
+}
+
+type resourceCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// ResourceCache is a read through cache of the volumes and snapshots of a session, entries expire after the TTL.
+// Controllers reacting to external changes invalidate or bypass the cache for the changed resource only. The
+// lookups return copies of the cached resources, callers may modify them
+type ResourceCache struct {
+	sess       provider.ContextSession
+	ttl        time.Duration
+	maxEntries int
+	stats      *StatsCollector
+	logger     *zap.Logger
+
+	mu      sync.Mutex
+	entries map[resourceCacheKey]*resourceCacheEntry
+	// generation is incremented by the invalidations, a resource fetched before an invalidation is not cached
+	generation uint64
+}
+
+// NewResourceCache returns a ResourceCache of the session, DefaultResourceCacheTTL is used for a non-positive ttl.
+// The lookups are recorded by stats, if not nil
+func NewResourceCache(sess provider.Session, ttl time.Duration, stats *StatsCollector, logger *zap.Logger) *ResourceCache {
+	if ttl <= 0 {
+		ttl = DefaultResourceCacheTTL
+	}
+	return &ResourceCache{
+		sess:       NewContextSession(sess),
+		ttl:        ttl,
+		maxEntries: MaxResourceCacheEntries,
+		stats:      stats,
+		logger:     logger,
+		entries:    make(map[resourceCacheKey]*resourceCacheEntry),
+	}
+}
+
+// get returns the cached resource, or fetches and caches it if missing, expired or bypassed
+func (c *ResourceCache) get(kind, id string, fetch func() (interface{}, error), options []CacheOption) (interface{}, error) {
+	opts := cacheOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+	key := resourceCacheKey{kind: kind, id: id}
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+	if !opts.bypass {
+		hit := found && time.Now().Before(entry.expiresAt)
+		c.stats.RecordCacheLookup(kind, hit)
+		if hit {
+			return entry.value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		// the cached resource might no longer exist
+		c.InvalidateCache(id)
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the resource was invalidated while fetched, the fetched resource might be stale
+	if c.generation != generation {
+		return value, nil
+	}
+	if _, found := c.entries[key]; !found && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = &resourceCacheEntry{value: value, expiresAt: time.Now().Add(c.ttl)}
+	return value, nil
+}
+
+// evict removes the expired resources, or the resource expiring first if none expired. The caller holds the lock
+func (c *ResourceCache) evict() {
+	now := time.Now()
+	var first resourceCacheKey
+	var firstExpiry time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		} else if firstExpiry.IsZero() || entry.expiresAt.Before(firstExpiry) {
+			first, firstExpiry = key, entry.expiresAt
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, first)
+	}
+}
+
+// GetVolume returns the volume, from the cache unless expired or WithBypassCache is set
+func (c *ResourceCache) GetVolume(ctx context.Context, id string, options ...CacheOption) (*provider.Volume, error) {
+	value, err := c.get(VolumeCacheName, id, func() (interface{}, error) {
+		return c.sess.GetVolumeWithContext(ctx, id)
+	}, options)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*provider.Volume).DeepCopy(), nil
+}
+
+// GetSnapshot returns the snapshot, from the cache unless expired or WithBypassCache is set
+func (c *ResourceCache) GetSnapshot(ctx context.Context, id string, options ...CacheOption) (*provider.Snapshot, error) {
+	value, err := c.get(SnapshotCacheName, id, func() (interface{}, error) {
+		return c.sess.GetSnapshotWithContext(ctx, id)
+	}, options)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*provider.Snapshot).DeepCopy(), nil
+}
+
+// InvalidateCache removes the cached resource (volume or snapshot) of the ID, the next lookup fetches it
+func (c *ResourceCache) InvalidateCache(resourceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for key := range c.entries {
+		if key.id == resourceID {
+			delete(c.entries, key)
+			c.logger.Debug("Invalidated cached resource", zap.String("kind", key.kind), zap.String("ResourceID", resourceID))
+		}
+	}
+}
+
+// Flush removes all cached resources
+func (c *ResourceCache) Flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.entries = make(map[resourceCacheKey]*resourceCacheEntry)
+}
+
+// Len returns the number of cached resources, including the expired ones
+func (c *ResourceCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,141 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestResourceCache(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetVolumeStub = func(id string) (*provider.Volume, error) {
+		return &provider.Volume{VolumeID: id}, nil
+	}
+	sess.GetSnapshotReturns(&provider.Snapshot{SnapshotID: "snap-1"}, nil)
+	stats := NewStatsCollector()
+	cache := NewResourceCache(sess, time.Minute, stats, logger)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		volume, err := cache.GetVolume(ctx, "vol-1")
+		assert.Nil(t, err)
+		assert.Equal(t, "vol-1", volume.VolumeID)
+	}
+	assert.Equal(t, 1, sess.GetVolumeCallCount())
+	_, _ = cache.GetVolume(ctx, "vol-2")
+	_, _ = cache.GetSnapshot(ctx, "snap-1")
+	assert.Equal(t, 3, cache.Len())
+
+	_, err := cache.GetVolume(ctx, "vol-1", WithBypassCache())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, sess.GetVolumeCallCount())
+
+	cache.InvalidateCache("vol-1")
+	assert.Equal(t, 2, cache.Len())
+	_, _ = cache.GetVolume(ctx, "vol-2")
+	assert.Equal(t, 3, sess.GetVolumeCallCount())
+	_, _ = cache.GetVolume(ctx, "vol-1")
+	assert.Equal(t, 4, sess.GetVolumeCallCount())
+
+	assert.InDelta(t, 3.0/6.0, stats.Snapshot().CacheHitRates[VolumeCacheName], 0.01)
+
+	cache.Flush()
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestResourceCacheErrors(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturnsOnCall(0, &provider.Volume{VolumeID: "vol-1"}, nil)
+	sess.GetVolumeReturnsOnCall(1, nil, errors.New("volume not found"))
+	cache := NewResourceCache(sess, 0, nil, logger)
+	ctx := context.Background()
+
+	_, err := cache.GetVolume(ctx, "vol-1")
+	assert.Nil(t, err)
+	_, err = cache.GetVolume(ctx, "vol-1", WithBypassCache())
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, cache.Len())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = cache.GetVolume(cancelled, "vol-1")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestResourceCacheCopies(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	volume := &provider.Volume{VolumeID: "vol-1"}
+	volume.Tags = []string{"env:prod"}
+	sess.GetVolumeReturns(volume, nil)
+	cache := NewResourceCache(sess, time.Minute, nil, logger)
+
+	cached, err := cache.GetVolume(context.Background(), "vol-1")
+	assert.Nil(t, err)
+	cached.Tags[0] = "env:dev"
+	cached, _ = cache.GetVolume(context.Background(), "vol-1")
+	assert.Equal(t, []string{"env:prod"}, cached.Tags)
+	assert.Equal(t, 1, sess.GetVolumeCallCount())
+}
+
+func TestResourceCacheInvalidatedFetch(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	cache := NewResourceCache(sess, time.Minute, nil, logger)
+	sess.GetVolumeStub = func(id string) (*provider.Volume, error) {
+		// the volume changes and is invalidated while fetched
+		cache.InvalidateCache(id)
+		return &provider.Volume{VolumeID: id}, nil
+	}
+	_, err := cache.GetVolume(context.Background(), "vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestResourceCacheEviction(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetVolumeStub = func(id string) (*provider.Volume, error) {
+		return &provider.Volume{VolumeID: id}, nil
+	}
+	cache := NewResourceCache(sess, time.Minute, nil, logger)
+	cache.maxEntries = 2
+	ctx := context.Background()
+
+	_, _ = cache.GetVolume(ctx, "vol-1")
+	_, _ = cache.GetVolume(ctx, "vol-2")
+	cache.entries[resourceCacheKey{kind: VolumeCacheName, id: "vol-2"}].expiresAt = time.Now().Add(-time.Second)
+	_, _ = cache.GetVolume(ctx, "vol-3")
+	assert.Equal(t, 2, cache.Len())
+	assert.NotContains(t, cache.entries, resourceCacheKey{kind: VolumeCacheName, id: "vol-2"})
+
+	// no resource expired, the resource expiring first is evicted
+	_, _ = cache.GetVolume(ctx, "vol-4")
+	assert.Equal(t, 2, cache.Len())
+	assert.NotContains(t, cache.entries, resourceCacheKey{kind: VolumeCacheName, id: "vol-1"})
+}
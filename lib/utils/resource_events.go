@@ -0,0 +1,69 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// isFailureEvent returns true for the events explaining a failure: failed actions and degraded health
+func isFailureEvent(event *provider.ResourceEvent) bool {
+	return event.Severity == provider.EventSeverityWarning || event.Severity == provider.EventSeverityCritical ||
+		strings.EqualFold(event.Outcome, "failure")
+}
+
+// FailureReasons returns the backend reasons of the failure events of the resource since the given time, newest first
+// and at most max of them (all if max is not positive), e.g. to include them in the PVC events of a failed operation.
+// The events are supplemental: an error fetching them is logged and no reasons are returned
+func FailureReasons(ctx context.Context, sess provider.ResourceEventsManager, resourceID string, since time.Time, max int, logger *zap.Logger) []string {
+	events, err := sess.GetResourceEvents(ctx, resourceID, since)
+	if err != nil {
+		logger.Warn("Failed to get the backend events of the resource", zap.String("ResourceID", resourceID), ZapError(err))
+		return nil
+	}
+	reasons := []string{}
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if event == nil || !isFailureEvent(event) {
+			continue
+		}
+		reasons = append(reasons, formatResourceEvent(event))
+		if max > 0 && len(reasons) == max {
+			break
+		}
+	}
+	return reasons
+}
+
+// formatResourceEvent formats the event for a PVC event message, e.g. "2022-06-01T10:00:00Z is.volume.volume.update failure: capacity exceeded"
+func formatResourceEvent(event *provider.ResourceEvent) string {
+	message := fmt.Sprintf("%s %s", event.Time.UTC().Format(time.RFC3339), event.Action)
+	if event.Outcome != "" {
+		message += " " + event.Outcome
+	}
+	if event.Reason != "" {
+		message += ": " + event.Reason
+	}
+	return message
+}
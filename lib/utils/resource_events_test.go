@@ -0,0 +1,56 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestFailureReasons(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	start := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	sess := &fake.FakeSession{}
+	sess.GetResourceEventsReturns([]*provider.ResourceEvent{
+		{ResourceID: "vol-1", Action: "is.volume.volume.create", Outcome: "success", Severity: provider.EventSeverityNormal, Time: start},
+		{ResourceID: "vol-1", Action: "is.volume.volume.update", Outcome: "failure", Reason: "capacity exceeded", Severity: provider.EventSeverityNormal, Time: start.Add(time.Minute)},
+		nil,
+		{ResourceID: "vol-1", Action: "is.volume.volume.health", Reason: "degraded", Severity: provider.EventSeverityWarning, Time: start.Add(2 * time.Minute)},
+	}, nil)
+
+	reasons := FailureReasons(context.TODO(), sess, "vol-1", start, 0, logger)
+	assert.Equal(t, []string{
+		"2022-06-01T10:02:00Z is.volume.volume.health: degraded",
+		"2022-06-01T10:01:00Z is.volume.volume.update failure: capacity exceeded",
+	}, reasons)
+	_, resourceID, since := sess.GetResourceEventsArgsForCall(0)
+	assert.Equal(t, "vol-1", resourceID)
+	assert.Equal(t, start, since)
+
+	assert.Len(t, FailureReasons(context.TODO(), sess, "vol-1", start, 1, logger), 1)
+
+	sess.GetResourceEventsReturns(nil, errors.New("activity tracker unavailable"))
+	assert.Nil(t, FailureReasons(context.TODO(), sess, "vol-1", start, 0, logger))
+}
@@ -0,0 +1,105 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// retryHintError annotates an error which is not a provider.Error with retry hints
+type retryHintError struct {
+	err        error
+	retryAfter time.Duration
+	notBefore  *time.Time
+}
+
+func (e *retryHintError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryHintError) Unwrap() error {
+	return e.err
+}
+
+// WithRetryAfter annotates the error with the suggested wait before retrying, e.g. from a Retry-After header
+func WithRetryAfter(err error, retryAfter time.Duration) error {
+	return withRetryHint(err, retryAfter, nil)
+}
+
+// WithNotBefore annotates the error with the time before which the operation should not be retried,
+// e.g. the end of a known backend cooldown
+func WithNotBefore(err error, notBefore time.Time) error {
+	return withRetryHint(err, 0, &notBefore)
+}
+
+func withRetryHint(err error, retryAfter time.Duration, notBefore *time.Time) error {
+	if err == nil {
+		return nil
+	}
+	if pErr, isPerr := err.(provider.Error); isPerr {
+		if retryAfter > 0 {
+			pErr.Fault.RetryAfter = retryAfter
+		}
+		if notBefore != nil {
+			pErr.Fault.NotBefore = notBefore
+		}
+		return pErr
+	}
+	return &retryHintError{err: err, retryAfter: retryAfter, notBefore: notBefore}
+}
+
+// RetryHint returns how long to wait before retrying after the error according to its retry hints,
+// 0 if it has none or they are elapsed
+func RetryHint(err error) time.Duration {
+	var retryAfter time.Duration
+	var notBefore *time.Time
+
+	var pErr provider.Error
+	var hintErr *retryHintError
+	switch {
+	case errors.As(err, &hintErr):
+		retryAfter, notBefore = hintErr.retryAfter, hintErr.notBefore
+	case errors.As(err, &pErr):
+		retryAfter, notBefore = pErr.Fault.RetryAfter, pErr.Fault.NotBefore
+	default:
+		return 0
+	}
+
+	if notBefore != nil {
+		if untilNotBefore := time.Until(*notBefore); untilNotBefore > retryAfter {
+			return untilNotBefore
+		}
+	}
+	return retryAfter
+}
+
+// retryWait returns the wait before the next retry: the retry interval, or longer if the error hints so. The hint
+// is capped by maxInterval (unbounded if zero), so a server cannot stall the retries indefinitely
+func retryWait(interval, maxInterval time.Duration, err error) time.Duration {
+	hint := RetryHint(err)
+	if maxInterval > 0 && hint > maxInterval {
+		hint = maxInterval
+	}
+	if hint > interval {
+		return hint
+	}
+	return interval
+}
@@ -0,0 +1,72 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRetryHint(t *testing.T) {
+	assert.Nil(t, WithRetryAfter(nil, time.Second))
+	assert.Equal(t, time.Duration(0), RetryHint(errors.New("no hint")))
+
+	plain := errors.New("rate limited")
+	hinted := WithRetryAfter(plain, 2*time.Second)
+	assert.Equal(t, "rate limited", hinted.Error())
+	assert.True(t, errors.Is(hinted, plain))
+	assert.Equal(t, 2*time.Second, RetryHint(hinted))
+	assert.Equal(t, 2*time.Second, RetryHint(fmt.Errorf("create failed: %w", hinted)))
+
+	pErr := WithNotBefore(NewError(reasoncode.ErrorRateLimitExceeded, "cooldown"), time.Now().Add(time.Minute))
+	assert.Equal(t, reasoncode.ErrorRateLimitExceeded, ErrorReasonCode(pErr))
+	assert.True(t, RetryHint(pErr) > 50*time.Second)
+	assert.NotNil(t, ErrorToFault(pErr).NotBefore)
+
+	elapsed := WithNotBefore(plain, time.Now().Add(-time.Minute))
+	assert.Equal(t, time.Duration(0), RetryHint(elapsed))
+
+	assert.Equal(t, time.Second, retryWait(time.Second, 0, plain))
+	assert.Equal(t, 2*time.Second, retryWait(time.Second, 0, hinted))
+
+	// the hint is capped by the max interval of the policy
+	assert.Equal(t, 1500*time.Millisecond, retryWait(time.Second, 1500*time.Millisecond, hinted))
+	assert.Equal(t, 30*time.Second, RetryPolicy{RetryInterval: time.Second, MaxInterval: 30 * time.Second}.Wait(1, pErr))
+}
+
+func TestErrorRetryHonorsRetryHint(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	retrier := NewErrorRetrier(2, time.Millisecond, logger)
+
+	start := time.Now()
+	attempts := 0
+	err := retrier.ErrorRetryWithContext(context.Background(), func() (error, bool) {
+		attempts++
+		return WithRetryAfter(errors.New("busy"), 50*time.Millisecond), false
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+}
@@ -0,0 +1,196 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy describes how an operation is retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+
+	// RetryInterval is the time to wait between the attempts, before the first retry with a backoff
+	RetryInterval time.Duration
+
+	// Multiplier grows the interval after each retry (exponential backoff), e.g. 2 doubles it. The interval is
+	// constant if Multiplier is 1 or less. It only applies without Backoff
+	Multiplier float64
+
+	// Backoff shapes the intervals between the retries, an ExponentialBackoff of Multiplier if nil
+	Backoff BackoffStrategy
+
+	// MaxInterval caps the interval grown by the Multiplier, unbounded if zero
+	MaxInterval time.Duration
+
+	// Jitter randomizes each wait by up to this fraction of the interval (0 to 1), so the concurrent callers
+	// failing together do not retry together
+	Jitter float64
+
+	// ReasonCodeAttempts overrides MaxAttempts for the errors of a reason code, e.g. 1 not to retry ErrorQuotaExceeded
+	ReasonCodeAttempts map[reasoncode.ReasonCode]int
+}
+
+// retryJitter returns a random number in [0, 1) for the jitter of the retries
+var retryJitter = rand.Float64
+
+// Interval returns the interval before the retry (1 for the first retry), shaped by the backoff strategy, capped by
+// MaxInterval and without jitter
+func (p RetryPolicy) Interval(retry int) time.Duration {
+	strategy := p.Backoff
+	if strategy == nil {
+		strategy = ExponentialBackoff{Multiplier: p.Multiplier}
+	}
+	interval := time.Duration(0)
+	for i := 1; i == 1 || i <= retry; i++ {
+		interval = strategy.Interval(i, p.RetryInterval, interval)
+		if p.MaxInterval > 0 && interval > p.MaxInterval {
+			interval = p.MaxInterval
+		}
+	}
+	return interval
+}
+
+// Wait returns the time to wait before the retry after err: the interval with its jitter, or the retry hint of
+// err capped by MaxInterval if it is longer (see RetryHint)
+func (p RetryPolicy) Wait(retry int, err error) time.Duration {
+	interval := p.Interval(retry)
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		interval += time.Duration((2*retryJitter() - 1) * jitter * float64(interval))
+	}
+	return retryWait(interval, p.MaxInterval, err)
+}
+
+// Attempts returns the total number of attempts of an operation failing with err, MaxAttempts unless overridden
+// for the reason code of err
+func (p RetryPolicy) Attempts(err error) int {
+	if attempts, ok := p.ReasonCodeAttempts[ErrorReasonCode(err)]; ok && err != nil {
+		return attempts
+	}
+	return p.MaxAttempts
+}
+
+// RetryPolicyFromConfig returns the retry policy of the VPC calls: max_retry_attempt attempts, max_retry_gap
+// seconds between them, shaped and randomized by the retry backoff of the configuration
+func RetryPolicyFromConfig(vpc *config.VPCProviderConfig) (RetryPolicy, error) {
+	if err := vpc.ValidateRetryPolicy(); err != nil {
+		return RetryPolicy{}, err
+	}
+	maxInterval, _ := vpc.RetryMaxIntervalDuration()
+	backoff, err := BackoffStrategyByName(vpc.RetryBackoffStrategy, vpc.RetryBackoffMultiplier)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+	policy := RetryPolicy{
+		MaxAttempts:   vpc.MaxRetryAttempt,
+		RetryInterval: time.Duration(vpc.MaxRetryGap) * time.Second,
+		Multiplier:    vpc.RetryBackoffMultiplier,
+		Backoff:       backoff,
+		MaxInterval:   maxInterval,
+		Jitter:        vpc.RetryJitter,
+	}
+	if len(vpc.RetryReasonCodeAttempts) > 0 {
+		policy.ReasonCodeAttempts = make(map[reasoncode.ReasonCode]int, len(vpc.RetryReasonCodeAttempts))
+		for code, attempts := range vpc.RetryReasonCodeAttempts {
+			policy.ReasonCodeAttempts[reasoncode.ReasonCode(code)] = attempts
+		}
+	}
+	return policy, nil
+}
+
+// NewErrorRetrierFromConfig returns a new ErrorRetrier retrying with the retry policy of the configuration
+func NewErrorRetrierFromConfig(vpc *config.VPCProviderConfig, logger *zap.Logger) (*ErrorRetrier, error) {
+	policy, err := RetryPolicyFromConfig(vpc)
+	if err != nil {
+		return nil, err
+	}
+	return NewErrorRetrierWithPolicy(policy, logger), nil
+}
+
+// NoRetryPolicy performs a single attempt, e.g. for deletes during namespace teardown
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+var retryPolicyKey = ctxkeys.NewKey[RetryPolicy]("retry-policy")
+
+// WithRetryPolicy returns a context overriding the retry policy of the operations performed with it
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return retryPolicyKey.WithValue(ctx, policy)
+}
+
+// RetryPolicyFromContext returns the retry policy attached to the context, if any
+func RetryPolicyFromContext(ctx context.Context) (RetryPolicy, bool) {
+	return retryPolicyKey.Value(ctx)
+}
+
+// retryPolicy returns the policy of the retrier, Policy if set else MaxAttempts and RetryInterval
+func (er *ErrorRetrier) retryPolicy() RetryPolicy {
+	if er.Policy != nil {
+		return *er.Policy
+	}
+	return RetryPolicy{MaxAttempts: er.MaxAttempts, RetryInterval: er.RetryInterval}
+}
+
+// ErrorRetryWithContext is ErrorRetry honoring the retry policy and backoff strategy attached to the context
+// (which override those of the retrier) and stopping the retries when the context is done. A nil context is
+// context.Background()
+func (er *ErrorRetrier) ErrorRetryWithContext(ctx context.Context, funcToRetry func() (error, bool)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	policy := er.retryPolicy()
+	if override, ok := RetryPolicyFromContext(ctx); ok {
+		er.Logger.Debug("Using retry policy from context", zap.Int("MaxAttempts", override.MaxAttempts), zap.Duration("RetryInterval", override.RetryInterval))
+		policy = override
+	}
+	if backoff, ok := BackoffStrategyFromContext(ctx); ok {
+		policy.Backoff = backoff
+	}
+
+	var err error
+	var shouldStop bool
+	for i := 0; ; i++ {
+		err, shouldStop = funcToRetry()
+		er.Logger.Debug("Retry Function Result", zap.Error(err), zap.Bool("shouldStop", shouldStop))
+		if shouldStop || err == nil {
+			break
+		}
+		//Stop if out of retries
+		if i >= (policy.Attempts(err) - 1) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			er.Logger.Warn("Context done, not retrying after Error:", zap.Error(err))
+			return err
+		case <-time.After(policy.Wait(i+1, err)):
+		}
+		er.Logger.Warn("retrying after Error:", zap.Error(err))
+		er.recordRetry()
+	}
+	return err
+}
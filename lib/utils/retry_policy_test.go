@@ -0,0 +1,177 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRetryPolicyFromContext(t *testing.T) {
+	_, ok := RetryPolicyFromContext(context.Background())
+	assert.False(t, ok)
+
+	policy, ok := RetryPolicyFromContext(WithRetryPolicy(context.Background(), NoRetryPolicy))
+	assert.True(t, ok)
+	assert.Equal(t, NoRetryPolicy, policy)
+}
+
+func TestErrorRetryWithContext(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	retrier := NewErrorRetrier(3, time.Millisecond, logger)
+	attempts := 0
+	failing := func() (error, bool) {
+		attempts++
+		return errors.New("failed"), false
+	}
+
+	assert.NotNil(t, retrier.ErrorRetryWithContext(context.Background(), failing))
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	assert.NotNil(t, retrier.ErrorRetryWithContext(WithRetryPolicy(context.Background(), NoRetryPolicy), failing))
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 5, RetryInterval: time.Millisecond})
+	assert.NotNil(t, retrier.ErrorRetryWithContext(ctx, failing))
+	assert.Equal(t, 5, attempts)
+
+	attempts = 0
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NotNil(t, retrier.ErrorRetryWithContext(cancelled, failing))
+	assert.Equal(t, 1, attempts)
+
+	assert.Nil(t, retrier.ErrorRetryWithContext(context.Background(), func() (error, bool) { return nil, false }))
+
+	attempts = 0
+	var nilCtx context.Context
+	assert.NotNil(t, retrier.ErrorRetryWithContext(nilCtx, failing))
+	assert.Equal(t, 3, attempts)
+}
+
+// retryCountingRecorder counts the retries recorded per operation
+type retryCountingRecorder struct {
+	metrics.NopRecorder
+	retries map[string]int
+}
+
+func (r *retryCountingRecorder) IncRetry(operation string) {
+	r.retries[operation]++
+}
+
+func TestErrorRetryRecordsRetries(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	recorder := &retryCountingRecorder{retries: map[string]int{}}
+	previous := metrics.Recorder()
+	metrics.SetRecorder(recorder)
+	defer metrics.SetRecorder(previous)
+
+	retrier := NewErrorRetrier(3, time.Millisecond, logger)
+	retrier.Operation = "GetVolume"
+	failing := func() (error, bool) { return errors.New("failed"), false }
+	assert.NotNil(t, retrier.ErrorRetryWithContext(context.Background(), failing))
+	assert.NotNil(t, NewErrorRetrier(2, time.Millisecond, logger).ErrorRetry(failing))
+	assert.Equal(t, map[string]int{"GetVolume": 2, "unknown": 1}, recorder.retries)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 6, RetryInterval: time.Second, Multiplier: 2, MaxInterval: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.Interval(1))
+	assert.Equal(t, 2*time.Second, policy.Interval(2))
+	assert.Equal(t, 4*time.Second, policy.Interval(3))
+	assert.Equal(t, 5*time.Second, policy.Interval(4))
+	assert.Equal(t, 5*time.Second, policy.Interval(100))
+	assert.Equal(t, time.Second, RetryPolicy{RetryInterval: time.Second}.Interval(3))
+
+	previous := retryJitter
+	defer func() { retryJitter = previous }()
+	policy.Jitter = 0.5
+	retryJitter = func() float64 { return 0 }
+	assert.Equal(t, 1*time.Second, policy.Wait(2, errors.New("failed")))
+	retryJitter = func() float64 { return 0.75 }
+	assert.Equal(t, 2500*time.Millisecond, policy.Wait(2, errors.New("failed")))
+}
+
+func TestRetryPolicyReasonCodeAttempts(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	policy := RetryPolicy{MaxAttempts: 4, RetryInterval: time.Millisecond, ReasonCodeAttempts: map[reasoncode.ReasonCode]int{"ErrorQuotaExceeded": 1}}
+	retrier := NewErrorRetrierWithPolicy(policy, logger)
+
+	attempts := 0
+	quota := func() (error, bool) {
+		attempts++
+		return provider.Error{Fault: provider.Fault{ReasonCode: "ErrorQuotaExceeded"}}, false
+	}
+	assert.NotNil(t, retrier.ErrorRetry(quota))
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	failing := func() (error, bool) {
+		attempts++
+		return errors.New("failed"), false
+	}
+	assert.NotNil(t, retrier.ErrorRetryWithContext(context.Background(), failing))
+	assert.Equal(t, 4, attempts)
+}
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	vpc := &config.VPCProviderConfig{
+		MaxRetryAttempt:         5,
+		MaxRetryGap:             2,
+		RetryBackoffMultiplier:  2,
+		RetryMaxInterval:        "30s",
+		RetryJitter:             0.1,
+		RetryReasonCodeAttempts: map[string]int{"ErrorQuotaExceeded": 1},
+	}
+	policy, err := RetryPolicyFromConfig(vpc)
+	assert.Nil(t, err)
+	assert.Equal(t, RetryPolicy{
+		MaxAttempts:        5,
+		RetryInterval:      2 * time.Second,
+		Multiplier:         2,
+		MaxInterval:        30 * time.Second,
+		Jitter:             0.1,
+		ReasonCodeAttempts: map[reasoncode.ReasonCode]int{"ErrorQuotaExceeded": 1},
+	}, policy)
+
+	vpc.RetryJitter = 2
+	_, err = RetryPolicyFromConfig(vpc)
+	assert.NotNil(t, err)
+}
+
+func TestNewErrorRetrierFromConfig(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	retrier, err := NewErrorRetrierFromConfig(&config.VPCProviderConfig{MaxRetryAttempt: 2, MaxRetryGap: 1}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, retrier.retryPolicy().MaxAttempts)
+	assert.Equal(t, time.Second, retrier.retryPolicy().RetryInterval)
+
+	_, err = NewErrorRetrierFromConfig(&config.VPCProviderConfig{RetryBackoffStrategy: "linear"}, logger)
+	assert.NotNil(t, err)
+}
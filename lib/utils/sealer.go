@@ -0,0 +1,202 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+)
+
+// sealedFormatVersion is the version of the sealed envelope format
+const sealedFormatVersion = 1
+
+// ErrUnknownEncryptionKey is returned when opening data sealed with a key which is no longer configured
+var ErrUnknownEncryptionKey = errors.New("data is sealed with an unknown encryption key")
+
+// KeyProvider provides the encryption keys of the persisted state, the first key is the active one.
+// config.Config keys are provided by StaticKeyProvider, a Key Protect backed provider returns the unwrapped
+// data encryption keys
+type KeyProvider interface {
+	EncryptionKeys() ([]config.EncryptionKey, error)
+}
+
+// StaticKeyProvider provides fixed keys, e.g. parsed from the persistence_encryption_keys config
+type StaticKeyProvider []config.EncryptionKey
+
+// EncryptionKeys ...
+func (p StaticKeyProvider) EncryptionKeys() ([]config.EncryptionKey, error) {
+	if len(p) == 0 {
+		return nil, errors.New("no encryption key is configured")
+	}
+	return p, nil
+}
+
+type sealedEnvelope struct {
+	Version int    `json:"v"`
+	KeyID   string `json:"kid"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// Sealer encrypts and authenticates data persisted to disk with AES-GCM. Data sealed with a previous key
+// can be opened as long as the key is provided, and is re-sealed with the active key by ReadSealedFile
+type Sealer struct {
+	keys KeyProvider
+
+	mu      sync.RWMutex
+	active  string
+	ciphers map[string]cipher.AEAD
+}
+
+// NewSealer returns a sealer with the keys of the provider
+func NewSealer(keys KeyProvider) (*Sealer, error) {
+	s := &Sealer{keys: keys}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload fetches the keys from the provider again, e.g. after a key rotation
+func (s *Sealer) Reload() error {
+	keys, err := s.keys.EncryptionKeys()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return errors.New("no encryption key is configured")
+	}
+	ciphers := make(map[string]cipher.AEAD, len(keys))
+	for _, key := range keys {
+		block, err := aes.NewCipher(key.Key)
+		if err != nil {
+			return fmt.Errorf("invalid encryption key '%s': %v", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return fmt.Errorf("invalid encryption key '%s': %v", key.ID, err)
+		}
+		ciphers[key.ID] = aead
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active = keys[0].ID
+	s.ciphers = ciphers
+	return nil
+}
+
+// ActiveKeyID returns the ID of the key sealing new data
+func (s *Sealer) ActiveKeyID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.active
+}
+
+// Seal encrypts the data with the active key
+func (s *Sealer) Seal(plaintext []byte) ([]byte, error) {
+	s.mu.RLock()
+	keyID, aead := s.active, s.ciphers[s.active]
+	s.mu.RUnlock()
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	envelope := sealedEnvelope{Version: sealedFormatVersion, KeyID: keyID, Nonce: nonce}
+	envelope.Data = aead.Seal(nil, nonce, plaintext, []byte(keyID))
+	return json.Marshal(envelope)
+}
+
+// Open decrypts sealed data, stale is set if it was sealed with another key than the active one and should
+// be sealed again
+func (s *Sealer) Open(sealed []byte) (plaintext []byte, stale bool, err error) {
+	var envelope sealedEnvelope
+	if err := json.Unmarshal(sealed, &envelope); err != nil || envelope.Version != sealedFormatVersion {
+		return nil, false, errors.New("data is not sealed")
+	}
+
+	s.mu.RLock()
+	aead, found := s.ciphers[envelope.KeyID]
+	active := s.active
+	s.mu.RUnlock()
+	if !found {
+		return nil, false, fmt.Errorf("%w '%s'", ErrUnknownEncryptionKey, envelope.KeyID)
+	}
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return nil, false, errors.New("sealed data is corrupted")
+	}
+	plaintext, err = aead.Open(nil, envelope.Nonce, envelope.Data, []byte(envelope.KeyID))
+	if err != nil {
+		return nil, false, errors.New("sealed data is corrupted or tampered")
+	}
+	return plaintext, envelope.KeyID != active, nil
+}
+
+// WriteSealedFile seals the data and writes it atomically to path, readable by the owner only
+func WriteSealedFile(path string, sealer *Sealer, data []byte) error {
+	sealed, err := sealer.Seal(data)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(sealed); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadSealedFile reads and opens the file written by WriteSealedFile. A file sealed with a previous key is
+// re-sealed with the active key, so old keys can be removed once all files are rotated
+func ReadSealedFile(path string, sealer *Sealer) ([]byte, error) {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, stale, err := sealer.Open(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if stale {
+		if err := WriteSealedFile(path, sealer, data); err != nil {
+			return nil, fmt.Errorf("failed to re-seal %s with the active key: %v", path, err)
+		}
+	}
+	return data, nil
+}
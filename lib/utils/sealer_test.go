@@ -0,0 +1,98 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(id string, b byte) config.EncryptionKey {
+	return config.EncryptionKey{ID: id, Key: bytes.Repeat([]byte{b}, 32)}
+}
+
+func TestSealer(t *testing.T) {
+	sealer, err := NewSealer(StaticKeyProvider{testKey("v1", 1)})
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", sealer.ActiveKeyID())
+
+	sealed, err := sealer.Seal([]byte("secret state"))
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(sealed, []byte("secret state")))
+
+	data, stale, err := sealer.Open(sealed)
+	assert.Nil(t, err)
+	assert.False(t, stale)
+	assert.Equal(t, "secret state", string(data))
+
+	// tampered data
+	tampered := bytes.Replace(sealed, []byte(`"data":"`), []byte(`"data":"AA`), 1)
+	_, _, err = sealer.Open(tampered)
+	assert.NotNil(t, err)
+
+	_, _, err = sealer.Open([]byte("plain"))
+	assert.NotNil(t, err)
+
+	// rotation: v2 is active, v1 sealed data is stale
+	rotated, err := NewSealer(StaticKeyProvider{testKey("v2", 2), testKey("v1", 1)})
+	assert.Nil(t, err)
+	data, stale, err = rotated.Open(sealed)
+	assert.Nil(t, err)
+	assert.True(t, stale)
+	assert.Equal(t, "secret state", string(data))
+
+	// v1 removed
+	removed, _ := NewSealer(StaticKeyProvider{testKey("v2", 2)})
+	_, _, err = removed.Open(sealed)
+	assert.True(t, errors.Is(err, ErrUnknownEncryptionKey))
+
+	_, err = NewSealer(StaticKeyProvider{})
+	assert.NotNil(t, err)
+	_, err = NewSealer(StaticKeyProvider{{ID: "bad", Key: []byte("short")}})
+	assert.NotNil(t, err)
+}
+
+func TestSealedFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	sealer, _ := NewSealer(StaticKeyProvider{testKey("v1", 1)})
+	assert.Nil(t, WriteSealedFile(path, sealer, []byte(`{"a":1}`)))
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	rotated, _ := NewSealer(StaticKeyProvider{testKey("v2", 2), testKey("v1", 1)})
+	data, err := ReadSealedFile(path, rotated)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	// re-sealed with v2, readable without v1
+	onlyV2, _ := NewSealer(StaticKeyProvider{testKey("v2", 2)})
+	data, err = ReadSealedFile(path, onlyV2)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	_, err = ReadSealedFile(filepath.Join(t.TempDir(), "missing"), onlyV2)
+	assert.NotNil(t, err)
+}
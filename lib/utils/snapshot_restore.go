@@ -0,0 +1,130 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// bytesPerGiB converts the volume capacities in GiB to bytes
+const bytesPerGiB = int64(1024 * 1024 * 1024)
+
+// ValidateRestoreRequest returns an ErrorRequiredFieldMissing error if the request has no snapshot, an ErrorBadRequest
+// error if the snapshot is not ready to use or the capacity is smaller than its restore size, and an ErrorUnsupportedFeature
+// error for a cross zone restore the capabilities do not support. The snapshot is optional, it is only checked if set
+func ValidateRestoreRequest(request provider.SnapshotRestoreRequest, snapshot *provider.Snapshot, capabilities *provider.Capabilities) error {
+	if request.SnapshotID == "" {
+		return NewError(reasoncode.ErrorRequiredFieldMissing, "Snapshot ID is required to restore a snapshot")
+	}
+	if request.Capacity != nil && *request.Capacity <= 0 {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid restore capacity %d", *request.Capacity))
+	}
+	if snapshot != nil {
+		if !snapshot.ReadyToUse {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Snapshot %s is not ready to use", request.SnapshotID))
+		}
+		if request.Capacity != nil && int64(*request.Capacity)*bytesPerGiB < snapshot.RestoreSize {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Restore capacity %d GiB is smaller than the restore size %d bytes of the snapshot %s", *request.Capacity, snapshot.RestoreSize, request.SnapshotID))
+		}
+	}
+	if request.IsCrossZone() && !capabilities.HasFeature(provider.FeatureCrossZoneSnapshotRestore) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Restoring snapshot %s from zone %s in zone %s is not supported by the provider", request.SnapshotID, request.SourceZone, request.Zone))
+	}
+	return nil
+}
+
+// RestoreSnapshot validates the request against the snapshot and creates the volume from it.
+// In dry run, the restore is only planned and a nil response returned
+func RestoreSnapshot(ctx context.Context, sess provider.SnapshotManager, request provider.SnapshotRestoreRequest, capabilities *provider.Capabilities, logger *zap.Logger) (*provider.SnapshotRestoreResponse, error) {
+	var snapshot *provider.Snapshot
+	if request.SnapshotID != "" {
+		var err error
+		if snapshot, err = sess.GetSnapshot(request.SnapshotID); err != nil {
+			logger.Error("Failed to get the snapshot to restore", zap.String("SnapshotID", request.SnapshotID), ZapError(err))
+			return nil, err
+		}
+	}
+	if err := ValidateRestoreRequest(request, snapshot, capabilities); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"zone": request.Zone, "sourceZone": request.SourceZone}
+	if request.Zone == "" {
+		details["zone"] = request.SourceZone
+	}
+	if request.Capacity != nil {
+		details["capacity"] = strconv.Itoa(*request.Capacity)
+	}
+	var response *provider.SnapshotRestoreResponse
+	err := RunMutation(ctx, "RestoreVolumeFromSnapshot", request.SnapshotID, details, func() error {
+		var err error
+		response, err = sess.RestoreVolumeFromSnapshot(request)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to restore the snapshot", zap.String("SnapshotID", request.SnapshotID), ZapError(err))
+		return nil, err
+	}
+	if response != nil && response.Volume != nil {
+		logger.Info("Restored snapshot", zap.String("SnapshotID", request.SnapshotID), zap.String("VolumeID", response.Volume.VolumeID))
+	}
+	return response, nil
+}
+
+// WaitForSnapshotRestore polls the restore progress of the volume every interval until the restore is done, reporting
+// each progress to onProgress (if set). A failed restore returns an ErrorUnclassified error, the wait is registered
+// in the waiter registry of the context and stops when the context is done. The interval adapts to the restore
+// times observed by the transition tracker of the context, if any
+func WaitForSnapshotRestore(ctx context.Context, sess provider.SnapshotManager, volumeID string, interval time.Duration, onProgress func(provider.SnapshotRestoreProgress), logger *zap.Logger) (*provider.SnapshotRestoreProgress, error) {
+	return RunWaiter(ctx, "WaitForSnapshotRestore", volumeID, func(ctx context.Context) (*provider.SnapshotRestoreProgress, error) {
+		start := time.Now()
+		for {
+			progress, err := sess.GetSnapshotRestoreProgress(volumeID)
+			if err != nil {
+				logger.Error("Failed to get the snapshot restore progress", zap.String("VolumeID", volumeID), ZapError(err))
+				return nil, err
+			}
+			if progress != nil {
+				logger.Debug("Snapshot restore progress", zap.String("VolumeID", volumeID), zap.String("Phase", string(progress.Phase)), zap.Int("PercentComplete", progress.PercentComplete))
+				if onProgress != nil {
+					onProgress(*progress)
+				}
+				if progress.Phase == provider.SnapshotRestoreFailed {
+					return progress, NewErrorWithProperties(reasoncode.ErrorUnclassified,
+						fmt.Sprintf("Snapshot restore of volume %s failed", volumeID), map[string]string{VolumeIDProperty: volumeID})
+				}
+				if progress.IsDone() {
+					observeTransition(ctx, "WaitForSnapshotRestore", time.Since(start))
+					return progress, nil
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return progress, ctx.Err()
+			case <-time.After(pollInterval(ctx, "WaitForSnapshotRestore", time.Since(start), interval)):
+			}
+		}
+	})
+}
@@ -0,0 +1,148 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateRestoreRequest(t *testing.T) {
+	size10, size5, zero := 10, 5, 0
+	crossZone := &provider.Capabilities{Features: map[string]bool{provider.FeatureCrossZoneSnapshotRestore: true}}
+	snapshot := &provider.Snapshot{SnapshotID: "snap-1", ReadyToUse: true, RestoreSize: 8 * 1024 * 1024 * 1024}
+
+	testcases := []struct {
+		testcasename string
+		request      provider.SnapshotRestoreRequest
+		snapshot     *provider.Snapshot
+		capabilities *provider.Capabilities
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "Valid restore",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Zone: "us-south-1", SourceZone: "us-south-1", Capacity: &size10},
+			snapshot:     snapshot,
+		},
+		{
+			testcasename: "Valid cross zone restore",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Zone: "us-south-2", SourceZone: "us-south-1"},
+			snapshot:     snapshot,
+			capabilities: crossZone,
+		},
+		{
+			testcasename: "Missing snapshot",
+			request:      provider.SnapshotRestoreRequest{},
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+		{
+			testcasename: "Snapshot not ready",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1"},
+			snapshot:     &provider.Snapshot{SnapshotID: "snap-1"},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Smaller than restore size",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Capacity: &size5},
+			snapshot:     snapshot,
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Invalid capacity",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Capacity: &zero},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Cross zone unsupported",
+			request:      provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Zone: "us-south-2", SourceZone: "us-south-1"},
+			snapshot:     snapshot,
+			expectedCode: reasoncode.ErrorUnsupportedFeature,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateRestoreRequest(testcase.request, testcase.snapshot, testcase.capabilities)
+			if testcase.expectedCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			}
+		})
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	size10 := 10
+	sess := &fake.FakeSession{}
+	sess.GetSnapshotReturns(&provider.Snapshot{SnapshotID: "snap-1", ReadyToUse: true}, nil)
+	sess.RestoreVolumeFromSnapshotReturns(&provider.SnapshotRestoreResponse{Volume: &provider.Volume{VolumeID: "vol-2"}, SnapshotID: "snap-1"}, nil)
+
+	request := provider.SnapshotRestoreRequest{SnapshotID: "snap-1", Zone: "us-south-1", Capacity: &size10}
+	response, err := RestoreSnapshot(context.Background(), sess, request, nil, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-2", response.Volume.VolumeID)
+	assert.Equal(t, request, sess.RestoreVolumeFromSnapshotArgsForCall(0))
+
+	ctx, plan := WithDryRun(context.Background())
+	response, err = RestoreSnapshot(ctx, sess, provider.SnapshotRestoreRequest{SnapshotID: "snap-1", SourceZone: "us-south-1"}, nil, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, 1, sess.RestoreVolumeFromSnapshotCallCount())
+	steps := plan.Steps()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "us-south-1", steps[0].Details["zone"])
+
+	sess.GetSnapshotReturns(nil, errors.New("not found"))
+	_, err = RestoreSnapshot(context.Background(), sess, request, nil, logger)
+	assert.NotNil(t, err)
+}
+
+func TestWaitForSnapshotRestore(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.GetSnapshotRestoreProgressReturnsOnCall(0, &provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestorePending}, nil)
+	sess.GetSnapshotRestoreProgressReturnsOnCall(1, &provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestoreHydrating, PercentComplete: 40}, nil)
+	sess.GetSnapshotRestoreProgressReturnsOnCall(2, &provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestoreCompleted, PercentComplete: 100}, nil)
+
+	var reported []int
+	progress, err := WaitForSnapshotRestore(context.Background(), sess, "vol-1", time.Millisecond, func(p provider.SnapshotRestoreProgress) {
+		reported = append(reported, p.PercentComplete)
+	}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, provider.SnapshotRestoreCompleted, progress.Phase)
+	assert.Equal(t, []int{0, 40, 100}, reported)
+
+	sess.GetSnapshotRestoreProgressReturnsOnCall(3, &provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestoreFailed}, nil)
+	_, err = WaitForSnapshotRestore(context.Background(), sess, "vol-1", time.Millisecond, nil, logger)
+	assert.Equal(t, reasoncode.ErrorUnclassified, ErrorReasonCode(err))
+
+	sess.GetSnapshotRestoreProgressReturnsOnCall(4, &provider.SnapshotRestoreProgress{VolumeID: "vol-1", Phase: provider.SnapshotRestoreHydrating}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = WaitForSnapshotRestore(ctx, sess, "vol-1", time.Hour, nil, logger)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
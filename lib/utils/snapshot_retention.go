@@ -0,0 +1,153 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// DefaultPruneParallelism is the maximum number of concurrent snapshot deletions of PruneSnapshots
+const DefaultPruneParallelism = 4
+
+// PruneReport is the result of PruneSnapshots
+type PruneReport struct {
+	// VolumeID is the source volume of the snapshots
+	VolumeID string `json:"volumeID"`
+	// DryRun is set if no snapshot was deleted
+	DryRun bool `json:"dryRun"`
+	// Kept snapshots, newest first
+	Kept []string `json:"kept"`
+	// Pruned snapshots (or to be pruned in dry run), newest first
+	Pruned []string `json:"pruned"`
+	// Failed snapshot deletions, by snapshot ID
+	Failed map[string]string `json:"failed,omitempty"`
+	// Skipped snapshots were not deleted because the context was done
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// PruneSnapshots deletes the snapshots of sourceVolumeID except the keepLast newest ones, which are older than
+// olderThan (any age if 0). In dry run (dryRun or a WithDryRun context) the report lists the snapshots to be
+// pruned without deleting them, and they are recorded in the dry run plan.
+// Deletions run with bounded parallelism, an error is returned if any deletion failed or was skipped
+func PruneSnapshots(ctx context.Context, sess provider.SnapshotManager, sourceVolumeID string, keepLast int, olderThan time.Duration, dryRun bool, logger *zap.Logger) (*PruneReport, error) {
+	if keepLast < 0 || olderThan < 0 {
+		return nil, NewError(reasoncode.ErrorBadRequest, "keepLast and olderThan cannot be negative")
+	}
+	snapshots, err := ListAllSnapshots(sess, ListOptions{SortBy: SortByCreationTime}, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun = dryRun || IsDryRun(ctx)
+	report := &PruneReport{VolumeID: sourceVolumeID, DryRun: dryRun, Kept: []string{}, Pruned: []string{}}
+	candidates := selectSnapshotsToPrune(snapshots, sourceVolumeID, keepLast, olderThan, time.Now(), report)
+	if dryRun || len(candidates) == 0 {
+		report.Pruned = snapshotIDs(candidates)
+		for _, snapshotID := range report.Pruned {
+			_ = RunMutation(ctx, "DeleteSnapshot", snapshotID, map[string]string{"volumeID": sourceVolumeID}, func() error { return nil })
+		}
+		logger.Info("Snapshot pruning planned", zap.String("VolumeID", sourceVolumeID), zap.Bool("DryRun", dryRun), zap.Int("Kept", len(report.Kept)), zap.Int("Pruned", len(report.Pruned)))
+		return report, nil
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, DefaultPruneParallelism)
+	pruned := make(map[string]bool)
+	for _, snapshot := range candidates {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			mu.Lock()
+			report.Skipped = append(report.Skipped, snapshot.SnapshotID)
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(snapshot *provider.Snapshot) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			err := sess.DeleteSnapshot(snapshot)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if report.Failed == nil {
+					report.Failed = make(map[string]string)
+				}
+				report.Failed[snapshot.SnapshotID] = err.Error()
+				logger.Warn("Failed to prune snapshot", zap.String("SnapshotID", snapshot.SnapshotID), ZapError(err))
+				return
+			}
+			pruned[snapshot.SnapshotID] = true
+		}(snapshot)
+	}
+	wg.Wait()
+
+	for _, snapshot := range candidates {
+		if pruned[snapshot.SnapshotID] {
+			report.Pruned = append(report.Pruned, snapshot.SnapshotID)
+		}
+	}
+	logger.Info("Snapshots pruned", zap.String("VolumeID", sourceVolumeID), zap.Int("Kept", len(report.Kept)), zap.Int("Pruned", len(report.Pruned)), zap.Int("Failed", len(report.Failed)), zap.Int("Skipped", len(report.Skipped)))
+	if len(report.Failed) > 0 || len(report.Skipped) > 0 {
+		return report, NewError(reasoncode.ErrorSnapshotPruneFailed, fmt.Sprintf("Failed to prune %d and skipped %d snapshots of volume %s", len(report.Failed), len(report.Skipped), sourceVolumeID))
+	}
+	return report, nil
+}
+
+// selectSnapshotsToPrune returns the snapshots of the volume to prune newest first, and records the kept ones in the report
+func selectSnapshotsToPrune(snapshots []*provider.Snapshot, volumeID string, keepLast int, olderThan time.Duration, now time.Time, report *PruneReport) []*provider.Snapshot {
+	own := []*provider.Snapshot{}
+	for _, snapshot := range snapshots {
+		if snapshot.VolumeID == volumeID {
+			own = append(own, snapshot)
+		}
+	}
+	// newest first
+	sort.SliceStable(own, func(i, j int) bool {
+		return own[i].SnapshotCreationTime.After(own[j].SnapshotCreationTime)
+	})
+
+	candidates := []*provider.Snapshot{}
+	for i, snapshot := range own {
+		if i < keepLast || (olderThan > 0 && now.Sub(snapshot.SnapshotCreationTime) < olderThan) {
+			report.Kept = append(report.Kept, snapshot.SnapshotID)
+			continue
+		}
+		candidates = append(candidates, snapshot)
+	}
+	return candidates
+}
+
+func snapshotIDs(snapshots []*provider.Snapshot) []string {
+	ids := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.SnapshotID)
+	}
+	return ids
+}
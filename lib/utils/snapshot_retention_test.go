@@ -0,0 +1,93 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func pruneTestSession() *fake.FakeSession {
+	now := time.Now()
+	day := 24 * time.Hour
+	sess := &fake.FakeSession{}
+	sess.ListSnapshotsReturns(&provider.SnapshotList{Snapshots: []*provider.Snapshot{
+		{VolumeID: "vol-1", SnapshotID: "snap-1", SnapshotCreationTime: now.Add(-10 * day)},
+		{VolumeID: "vol-1", SnapshotID: "snap-2", SnapshotCreationTime: now.Add(-5 * day)},
+		{VolumeID: "vol-1", SnapshotID: "snap-3", SnapshotCreationTime: now.Add(-2 * day)},
+		{VolumeID: "vol-1", SnapshotID: "snap-4", SnapshotCreationTime: now.Add(-1 * day)},
+		{VolumeID: "vol-2", SnapshotID: "snap-5", SnapshotCreationTime: now.Add(-10 * day)},
+	}}, nil)
+	return sess
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	sess := pruneTestSession()
+	report, err := PruneSnapshots(context.Background(), sess, "vol-1", 1, 3*24*time.Hour, true, logger)
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{"snap-4", "snap-3"}, report.Kept)
+	assert.Equal(t, []string{"snap-2", "snap-1"}, report.Pruned)
+	assert.Equal(t, 0, sess.DeleteSnapshotCallCount())
+
+	report, err = PruneSnapshots(context.Background(), sess, "vol-1", 2, 0, false, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"snap-2", "snap-1"}, report.Pruned)
+	assert.Equal(t, 2, sess.DeleteSnapshotCallCount())
+
+	sess = pruneTestSession()
+	sess.DeleteSnapshotStub = func(snapshot *provider.Snapshot) error {
+		if snapshot.SnapshotID == "snap-1" {
+			return errors.New("delete failed")
+		}
+		return nil
+	}
+	report, err = PruneSnapshots(context.Background(), sess, "vol-1", 0, 0, false, logger)
+	assert.Equal(t, reasoncode.ErrorSnapshotPruneFailed, ErrorReasonCode(err))
+	assert.Equal(t, []string{"snap-4", "snap-3", "snap-2"}, report.Pruned)
+	assert.Contains(t, report.Failed, "snap-1")
+
+	_, err = PruneSnapshots(context.Background(), sess, "vol-1", -1, 0, false, logger)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+}
+
+func TestPruneSnapshotsCancelled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := pruneTestSession()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := PruneSnapshots(ctx, sess, "vol-1", 0, 0, false, logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, 4, len(report.Skipped))
+	assert.Equal(t, 0, sess.DeleteSnapshotCallCount())
+
+	sess.ListSnapshotsReturns(nil, errors.New("list failed"))
+	_, err = PruneSnapshots(context.Background(), sess, "vol-1", 0, 0, false, logger)
+	assert.NotNil(t, err)
+}
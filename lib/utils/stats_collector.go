@@ -0,0 +1,146 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// StatsCollector collects the counters returned by provider.Session Stats(). It is safe for concurrent use
+// and safe to call on nil
+type StatsCollector struct {
+	mu                  sync.Mutex
+	operations          map[string]*provider.OperationCounts
+	cacheHits           map[string]int64
+	cacheLookups        map[string]int64
+	rateLimiterWaits    map[string]time.Duration
+	tokenRefreshes      int64
+	openCircuitBreakers map[string]bool
+}
+
+// NewStatsCollector returns an empty StatsCollector
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{
+		operations:          make(map[string]*provider.OperationCounts),
+		cacheHits:           make(map[string]int64),
+		cacheLookups:        make(map[string]int64),
+		rateLimiterWaits:    make(map[string]time.Duration),
+		openCircuitBreakers: make(map[string]bool),
+	}
+}
+
+// RecordOperation counts the result of an operation
+func (c *StatsCollector) RecordOperation(operation string, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts, ok := c.operations[operation]
+	if !ok {
+		counts = &provider.OperationCounts{}
+		c.operations[operation] = counts
+	}
+	if err != nil {
+		counts.Failure++
+	} else {
+		counts.Success++
+	}
+}
+
+// RecordCacheLookup counts a lookup of the named cache
+func (c *StatsCollector) RecordCacheLookup(cache string, hit bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cacheLookups[cache]++
+	if hit {
+		c.cacheHits[cache]++
+	}
+}
+
+// RecordRateLimiterWait adds the time spent waiting on the rate limiter
+func (c *StatsCollector) RecordRateLimiterWait(class OperationClass, wait time.Duration) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimiterWaits[string(class)] += wait
+}
+
+// RecordTokenRefresh counts a token exchange
+func (c *StatsCollector) RecordTokenRefresh() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokenRefreshes++
+}
+
+// SetCircuitBreakerOpen records the state of the named circuit breaker
+func (c *StatsCollector) SetCircuitBreakerOpen(name string, open bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if open {
+		c.openCircuitBreakers[name] = true
+	} else {
+		delete(c.openCircuitBreakers, name)
+	}
+}
+
+// Snapshot returns a copy of the counters
+func (c *StatsCollector) Snapshot() provider.ProviderStats {
+	stats := provider.ProviderStats{
+		Operations:          map[string]provider.OperationCounts{},
+		CacheHitRates:       map[string]float64{},
+		RateLimiterWaits:    map[string]time.Duration{},
+		OpenCircuitBreakers: []string{},
+		CollectedAt:         time.Now().UTC(),
+	}
+	if c == nil {
+		return stats
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for operation, counts := range c.operations {
+		stats.Operations[operation] = *counts
+	}
+	for cache, lookups := range c.cacheLookups {
+		stats.CacheHitRates[cache] = float64(c.cacheHits[cache]) / float64(lookups)
+	}
+	for class, wait := range c.rateLimiterWaits {
+		stats.RateLimiterWaits[class] = wait
+	}
+	stats.TokenRefreshes = c.tokenRefreshes
+	for name := range c.openCircuitBreakers {
+		stats.OpenCircuitBreakers = append(stats.OpenCircuitBreakers, name)
+	}
+	sort.Strings(stats.OpenCircuitBreakers)
+	return stats
+}
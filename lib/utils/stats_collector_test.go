@@ -0,0 +1,64 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsCollector(t *testing.T) {
+	collector := NewStatsCollector()
+	collector.RecordOperation("CreateVolume", nil)
+	collector.RecordOperation("CreateVolume", errors.New("quota exceeded"))
+	collector.RecordOperation("DeleteVolume", nil)
+	collector.RecordCacheLookup("token", true)
+	collector.RecordCacheLookup("token", true)
+	collector.RecordCacheLookup("token", false)
+	collector.RecordCacheLookup("token", true)
+	collector.RecordTokenRefresh()
+	collector.SetCircuitBreakerOpen("riaas", true)
+	collector.SetCircuitBreakerOpen("iam", true)
+	collector.SetCircuitBreakerOpen("iam", false)
+
+	limiter := NewRateLimiter(1000, 1, 0, 0)
+	limiter.SetStatsCollector(collector)
+	limiter.Wait(ReadOperation)
+	limiter.Wait(ReadOperation)
+
+	stats := collector.Snapshot()
+	assert.Equal(t, int64(1), stats.Operations["CreateVolume"].Success)
+	assert.Equal(t, int64(1), stats.Operations["CreateVolume"].Failure)
+	assert.Equal(t, int64(1), stats.Operations["DeleteVolume"].Success)
+	assert.Equal(t, 0.75, stats.CacheHitRates["token"])
+	assert.Equal(t, int64(1), stats.TokenRefreshes)
+	assert.Equal(t, []string{"riaas"}, stats.OpenCircuitBreakers)
+	assert.True(t, stats.RateLimiterWaits[string(ReadOperation)] > 0)
+	assert.Equal(t, time.Duration(0), stats.RateLimiterWaits[string(MutateOperation)])
+
+	data, err := stats.JSON()
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), "CreateVolume")
+
+	var nilCollector *StatsCollector
+	nilCollector.RecordOperation("CreateVolume", nil)
+	assert.Empty(t, nilCollector.Snapshot().Operations)
+}
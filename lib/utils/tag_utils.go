@@ -0,0 +1,128 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+const (
+	// MaxTagLength is the maximum length of a tag accepted by the IBM Cloud tagging service
+	MaxTagLength = 128
+
+	// TagSeparator separates the key and the value of a key:value tag
+	TagSeparator = ":"
+)
+
+// isValidTagChar reports whether the tagging service accepts the character (A-Z, a-z, 0-9, space, _, -, . and :)
+func isValidTagChar(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == ' ' || c == '_' || c == '-' || c == '.' || c == ':'
+}
+
+// TagFromKeyValue builds a key:value tag
+func TagFromKeyValue(key, value string) string {
+	return key + TagSeparator + value
+}
+
+// SplitTag splits a key:value tag, value is empty for plain tags
+func SplitTag(tag string) (key, value string) {
+	parts := strings.SplitN(tag, TagSeparator, 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// ValidateTag returns an error if the tag would be rejected by the tagging service
+func ValidateTag(tag string) error {
+	if strings.TrimSpace(tag) == "" {
+		return NewError(reasoncode.ErrorBadRequest, "Tag must not be empty")
+	}
+	if len(tag) > MaxTagLength {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Tag '%s' exceeds the maximum length of %d characters", tag, MaxTagLength))
+	}
+	for _, c := range tag {
+		if !isValidTagChar(c) {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Tag '%s' contains the invalid character '%c'", tag, c))
+		}
+	}
+	if strings.Count(tag, TagSeparator) > 1 {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Tag '%s' must be in key:value format with a single separator", tag))
+	}
+	if key, value := SplitTag(tag); strings.Contains(tag, TagSeparator) && (strings.TrimSpace(key) == "" || strings.TrimSpace(value) == "") {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Tag '%s' must have a non empty key and value", tag))
+	}
+	return nil
+}
+
+// NormalizeTag trims and lower cases the tag (the tagging service is case insensitive) and validates it.
+// If sanitize is set, invalid characters and additional separators are replaced by '_' and the tag is truncated
+// to MaxTagLength instead of returning an error
+func NormalizeTag(tag string, sanitize bool) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if sanitize {
+		tag = sanitizeTag(tag)
+	}
+	if err := ValidateTag(tag); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes all tags (see NormalizeTag) and removes duplicates, preserving the order
+func NormalizeTags(tags []string, sanitize bool) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		t, err := NormalizeTag(tag, sanitize)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	return normalized, nil
+}
+
+// sanitizeTag replaces the characters rejected by the tagging service
+func sanitizeTag(tag string) string {
+	var b strings.Builder
+	separatorSeen := false
+	for _, c := range tag {
+		switch {
+		case c == ':' && !separatorSeen:
+			separatorSeen = true
+			b.WriteRune(c)
+		case c == ':' || !isValidTagChar(c):
+			b.WriteRune('_')
+		default:
+			b.WriteRune(c)
+		}
+	}
+	sanitized := b.String()
+	if len(sanitized) > MaxTagLength {
+		sanitized = strings.TrimSpace(sanitized[:MaxTagLength])
+	}
+	return sanitized
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTag(t *testing.T) {
+	testcases := []struct {
+		tag         string
+		expectedErr bool
+	}{
+		{tag: "env:prod"},
+		{tag: "cluster-id.1_a"},
+		{tag: "", expectedErr: true},
+		{tag: "   ", expectedErr: true},
+		{tag: strings.Repeat("a", MaxTagLength+1), expectedErr: true},
+		{tag: "pvc/name", expectedErr: true},
+		{tag: "ibm-cloud.kubernetes.io.pvc-name:pvc-1"},
+		{tag: "ibm-cloud.kubernetes.io/pvc-name:pvc-1", expectedErr: true},
+		{tag: "ibm-cloud.kubernetes.io.pvc-name:a/b", expectedErr: true},
+		{tag: "a:b:c", expectedErr: true},
+		{tag: ":value", expectedErr: true},
+		{tag: "key:", expectedErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.tag, func(t *testing.T) {
+			err := ValidateTag(testcase.tag)
+			if testcase.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tag, err := NormalizeTag("  Env:Prod ", false)
+	assert.Nil(t, err)
+	assert.Equal(t, "env:prod", tag)
+
+	_, err = NormalizeTag("namespace:kube/system", false)
+	assert.NotNil(t, err)
+
+	tag, err = NormalizeTag(" IBM-Cloud.Kubernetes.io.Namespace:kube/system ", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "ibm-cloud.kubernetes.io.namespace:kube_system", tag)
+
+	tag, err = NormalizeTag("ibm-cloud.kubernetes.io/namespace:default", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "ibm-cloud.kubernetes.io_namespace:default", tag)
+
+	tag, err = NormalizeTag("namespace:kube/system:x", true)
+	assert.Nil(t, err)
+	assert.Equal(t, "namespace:kube_system_x", tag)
+
+	tag, err = NormalizeTag(strings.Repeat("a", MaxTagLength+10), true)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxTagLength, len(tag))
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{"A:b", "a:B", "c"}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a:b", "c"}, tags)
+
+	_, err = NormalizeTags([]string{"a", "b*"}, false)
+	assert.NotNil(t, err)
+}
+
+func TestSplitTag(t *testing.T) {
+	key, value := SplitTag(TagFromKeyValue("pvc", "my-claim"))
+	assert.Equal(t, "pvc", key)
+	assert.Equal(t, "my-claim", value)
+
+	key, value = SplitTag("plain")
+	assert.Equal(t, "plain", key)
+	assert.Equal(t, "", value)
+}
@@ -0,0 +1,216 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BackgroundTask is a periodic task run by the TaskScheduler, ctx is cancelled when the scheduler stops
+type BackgroundTask func(ctx context.Context) error
+
+// TaskOption configures a background task
+type TaskOption func(*scheduledTask)
+
+// LeaderOnly runs the task only while the scheduler is the leader, e.g. orphan scans and quota
+// reconcilers which must not run concurrently from every driver replica
+func LeaderOnly() TaskOption {
+	return func(task *scheduledTask) {
+		task.leaderOnly = true
+	}
+}
+
+// TaskStatus is the observable state of a background task
+type TaskStatus struct {
+	Name       string        `json:"name"`
+	Interval   time.Duration `json:"interval"`
+	LeaderOnly bool          `json:"leaderOnly,omitempty"`
+	Runs       int64         `json:"runs"`
+	Failures   int64         `json:"failures"`
+	Skipped    int64         `json:"skipped"`
+	LastRun    time.Time     `json:"lastRun,omitempty"`
+	LastError  string        `json:"lastError,omitempty"`
+}
+
+type scheduledTask struct {
+	name       string
+	interval   time.Duration
+	fn         BackgroundTask
+	leaderOnly bool
+
+	mu     sync.Mutex
+	status TaskStatus
+}
+
+// TaskScheduler runs registered background tasks (cache refreshers, orphan scans, quota reconcilers ...)
+// periodically between Start and Stop, which are meant to be tied to the provider session lifecycle
+type TaskScheduler struct {
+	logger *zap.Logger
+	leader int32
+
+	mu      sync.Mutex
+	tasks   map[string]*scheduledTask
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+// NewTaskScheduler returns a scheduler which is not the leader until SetLeader(true)
+func NewTaskScheduler(logger *zap.Logger) *TaskScheduler {
+	return &TaskScheduler{
+		logger: logger,
+		tasks:  map[string]*scheduledTask{},
+	}
+}
+
+// SetLeader sets whether this scheduler is the leader, LeaderOnly tasks are skipped while it is not
+func (s *TaskScheduler) SetLeader(isLeader bool) {
+	var leader int32
+	if isLeader {
+		leader = 1
+	}
+	atomic.StoreInt32(&s.leader, leader)
+}
+
+// IsLeader returns whether this scheduler is the leader
+func (s *TaskScheduler) IsLeader() bool {
+	return atomic.LoadInt32(&s.leader) == 1
+}
+
+// RegisterBackgroundTask registers a task run every interval, it begins immediately if the scheduler is started
+func (s *TaskScheduler) RegisterBackgroundTask(name string, interval time.Duration, fn BackgroundTask, options ...TaskOption) error {
+	if name == "" || fn == nil || interval <= 0 {
+		return fmt.Errorf("invalid background task '%s': a name, a function and a positive interval are required", name)
+	}
+
+	task := &scheduledTask{name: name, interval: interval, fn: fn}
+	for _, option := range options {
+		option(task)
+	}
+	task.status = TaskStatus{Name: name, Interval: interval, LeaderOnly: task.leaderOnly}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return fmt.Errorf("cannot register background task '%s': scheduler is stopped", name)
+	}
+	if _, exists := s.tasks[name]; exists {
+		return fmt.Errorf("background task '%s' is already registered", name)
+	}
+	s.tasks[name] = task
+	if s.ctx != nil {
+		s.run(task)
+	}
+	return nil
+}
+
+// Start begins running the registered tasks, it is a no-op if already started or stopped
+func (s *TaskScheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx != nil || s.stopped {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	for _, task := range s.tasks {
+		s.run(task)
+	}
+}
+
+// Stop cancels the running tasks and waits for them to return, the scheduler cannot be restarted
+func (s *TaskScheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
+
+// Status returns the status of the registered tasks sorted by name
+func (s *TaskScheduler) Status() []TaskStatus {
+	s.mu.Lock()
+	statuses := make([]TaskStatus, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		task.mu.Lock()
+		statuses = append(statuses, task.status)
+		task.mu.Unlock()
+	}
+	s.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// run starts the task goroutine, the caller holds s.mu
+func (s *TaskScheduler) run(task *scheduledTask) {
+	ctx := s.ctx
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(task.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce(ctx, task)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (s *TaskScheduler) runOnce(ctx context.Context, task *scheduledTask) {
+	if task.leaderOnly && !s.IsLeader() {
+		task.mu.Lock()
+		task.status.Skipped++
+		task.mu.Unlock()
+		return
+	}
+
+	start := time.Now()
+	err := task.fn(ctx)
+
+	task.mu.Lock()
+	task.status.Runs++
+	task.status.LastRun = start
+	task.status.LastError = ""
+	if err != nil {
+		task.status.Failures++
+		task.status.LastError = err.Error()
+	}
+	task.mu.Unlock()
+
+	if err != nil {
+		s.logger.Warn("Background task failed", zap.String("Task", task.name), zap.Duration("Duration", time.Since(start)), ZapError(err))
+		return
+	}
+	s.logger.Debug("Background task completed", zap.String("Task", task.name), zap.Duration("Duration", time.Since(start)))
+}
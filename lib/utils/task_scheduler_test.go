@@ -0,0 +1,87 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTaskSchedulerRegister(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	scheduler := NewTaskScheduler(logger)
+	noop := func(ctx context.Context) error { return nil }
+
+	assert.Nil(t, scheduler.RegisterBackgroundTask("orphan-scan", time.Minute, noop, LeaderOnly()))
+	assert.NotNil(t, scheduler.RegisterBackgroundTask("orphan-scan", time.Minute, noop))
+	assert.NotNil(t, scheduler.RegisterBackgroundTask("", time.Minute, noop))
+	assert.NotNil(t, scheduler.RegisterBackgroundTask("no-interval", 0, noop))
+	assert.NotNil(t, scheduler.RegisterBackgroundTask("no-func", time.Minute, nil))
+
+	status := scheduler.Status()
+	assert.Equal(t, 1, len(status))
+	assert.Equal(t, "orphan-scan", status[0].Name)
+	assert.True(t, status[0].LeaderOnly)
+
+	scheduler.Stop()
+	assert.NotNil(t, scheduler.RegisterBackgroundTask("after-stop", time.Minute, noop))
+}
+
+func TestTaskSchedulerRun(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	scheduler := NewTaskScheduler(logger)
+
+	var refreshes, scans int32
+	assert.Nil(t, scheduler.RegisterBackgroundTask("cache-refresh", 5*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&refreshes, 1)
+		return errors.New("refresh failed")
+	}))
+	assert.Nil(t, scheduler.RegisterBackgroundTask("orphan-scan", 5*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&scans, 1)
+		return nil
+	}, LeaderOnly()))
+
+	scheduler.Start()
+	time.Sleep(30 * time.Millisecond)
+	assert.True(t, atomic.LoadInt32(&refreshes) > 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&scans))
+
+	scheduler.SetLeader(true)
+	assert.True(t, scheduler.IsLeader())
+	time.Sleep(30 * time.Millisecond)
+	scheduler.Stop()
+	assert.True(t, atomic.LoadInt32(&scans) > 0)
+
+	status := scheduler.Status()
+	assert.Equal(t, "cache-refresh", status[0].Name)
+	assert.Equal(t, status[0].Runs, status[0].Failures)
+	assert.Equal(t, "refresh failed", status[0].LastError)
+	assert.True(t, status[1].Skipped > 0)
+	assert.True(t, status[1].Runs > 0)
+
+	// no run once stopped
+	ran := atomic.LoadInt32(&refreshes)
+	time.Sleep(15 * time.Millisecond)
+	assert.Equal(t, ran, atomic.LoadInt32(&refreshes))
+}
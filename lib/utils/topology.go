@@ -0,0 +1,137 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// The topology keys of the CSI drivers, in order of precedence when reading segments
+const (
+	// TopologyKeyRegion and TopologyKeyZone are the well-known Kubernetes topology labels
+	TopologyKeyRegion = "topology.kubernetes.io/region"
+	TopologyKeyZone   = "topology.kubernetes.io/zone"
+
+	// TopologyKeyBetaRegion and TopologyKeyBetaZone are the deprecated beta labels still set on older clusters
+	TopologyKeyBetaRegion = "failure-domain.beta.kubernetes.io/region"
+	TopologyKeyBetaZone   = "failure-domain.beta.kubernetes.io/zone"
+
+	// TopologyKeyIBMRegion and TopologyKeyIBMZone are the documented IBM Cloud labels of the worker nodes
+	TopologyKeyIBMRegion = "ibm-cloud.kubernetes.io/region"
+	TopologyKeyIBMZone   = "ibm-cloud.kubernetes.io/zone"
+)
+
+var (
+	regionTopologyKeys = []string{TopologyKeyRegion, TopologyKeyBetaRegion, TopologyKeyIBMRegion}
+	zoneTopologyKeys   = []string{TopologyKeyZone, TopologyKeyBetaZone, TopologyKeyIBMZone}
+)
+
+// Topology is the region and zone of a volume or share
+type Topology struct {
+	Region string `json:"region,omitempty"`
+	Zone   string `json:"zone,omitempty"`
+}
+
+// RegionOfZone returns the region of a VPC zone name, e.g. us-south for us-south-1, empty if the zone is not numbered
+func RegionOfZone(zone string) string {
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 || i == len(zone)-1 || strings.Trim(zone[i+1:], "0123456789") != "" {
+		return ""
+	}
+	return zone[:i]
+}
+
+// VolumeTopology returns the topology of the volume, the region derived from its zone (Az) if Region is empty
+func VolumeTopology(volume *provider.Volume) Topology {
+	if volume == nil {
+		return Topology{}
+	}
+	topology := Topology{Region: volume.Region, Zone: volume.Az}
+	if topology.Region == "" {
+		topology.Region = RegionOfZone(topology.Zone)
+	}
+	return topology
+}
+
+// ShareTopology returns the topology of the file share, its region derived from its zone
+func ShareTopology(share *provider.FileShare) Topology {
+	if share == nil {
+		return Topology{}
+	}
+	return Topology{Region: RegionOfZone(share.Zone), Zone: share.Zone}
+}
+
+// Segments returns the CSI topology segments of the topology, with the well-known Kubernetes keys only
+// as the CSI drivers publish them in NodeGetInfo
+func (t Topology) Segments() map[string]string {
+	segments := map[string]string{}
+	if t.Region != "" {
+		segments[TopologyKeyRegion] = t.Region
+	}
+	if t.Zone != "" {
+		segments[TopologyKeyZone] = t.Zone
+	}
+	return segments
+}
+
+// ToCSITopology returns the CSI AccessibleTopology segments of the volume
+func ToCSITopology(volume *provider.Volume) map[string]string {
+	return VolumeTopology(volume).Segments()
+}
+
+// FromCSITopology returns the topology of the CSI segments, reading the well-known keys first, then the beta and
+// the IBM Cloud keys. The region is derived from the zone if no region key is set.
+// An ErrorBadRequest error is returned if the segments have no zone, or keys of different zones
+func FromCSITopology(segments map[string]string) (Topology, error) {
+	topology := Topology{}
+	var err error
+	if topology.Zone, err = topologyValue(segments, zoneTopologyKeys); err != nil {
+		return Topology{}, err
+	}
+	if topology.Zone == "" {
+		return Topology{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("No zone in the topology segments, expected one of %s", strings.Join(zoneTopologyKeys, ", ")))
+	}
+	if topology.Region, err = topologyValue(segments, regionTopologyKeys); err != nil {
+		return Topology{}, err
+	}
+	if topology.Region == "" {
+		topology.Region = RegionOfZone(topology.Zone)
+	}
+	return topology, nil
+}
+
+// topologyValue returns the value of the first key set in the segments, an error if another key has another value
+func topologyValue(segments map[string]string, keys []string) (string, error) {
+	value := ""
+	for _, key := range keys {
+		v := segments[key]
+		if v == "" {
+			continue
+		}
+		if value == "" {
+			value = v
+		} else if v != value {
+			return "", NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Conflicting topology segments %s=%s and %s", key, v, value))
+		}
+	}
+	return value, nil
+}
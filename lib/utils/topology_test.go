@@ -0,0 +1,72 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionOfZone(t *testing.T) {
+	assert.Equal(t, "us-south", RegionOfZone("us-south-1"))
+	assert.Equal(t, "eu-de", RegionOfZone("eu-de-3"))
+	assert.Equal(t, "", RegionOfZone("dal10"))
+	assert.Equal(t, "", RegionOfZone("us-south-"))
+	assert.Equal(t, "", RegionOfZone(""))
+}
+
+func TestToCSITopology(t *testing.T) {
+	volume := &provider.Volume{Az: "us-south-2"}
+	assert.Equal(t, map[string]string{TopologyKeyRegion: "us-south", TopologyKeyZone: "us-south-2"}, ToCSITopology(volume))
+
+	volume.Region = "us-south"
+	assert.Equal(t, Topology{Region: "us-south", Zone: "us-south-2"}, VolumeTopology(volume))
+	assert.Empty(t, ToCSITopology(nil))
+
+	assert.Equal(t, Topology{Region: "jp-tok", Zone: "jp-tok-1"}, ShareTopology(&provider.FileShare{Zone: "jp-tok-1"}))
+}
+
+func TestFromCSITopology(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		segments     map[string]string
+		expected     Topology
+		expectErr    bool
+	}{
+		{testcasename: "Well-known keys", segments: map[string]string{TopologyKeyRegion: "us-south", TopologyKeyZone: "us-south-1"}, expected: Topology{Region: "us-south", Zone: "us-south-1"}},
+		{testcasename: "Beta keys", segments: map[string]string{TopologyKeyBetaZone: "eu-gb-2"}, expected: Topology{Region: "eu-gb", Zone: "eu-gb-2"}},
+		{testcasename: "IBM keys", segments: map[string]string{TopologyKeyIBMRegion: "ca-tor", TopologyKeyIBMZone: "ca-tor-3"}, expected: Topology{Region: "ca-tor", Zone: "ca-tor-3"}},
+		{testcasename: "Duplicated keys", segments: map[string]string{TopologyKeyZone: "us-east-1", TopologyKeyBetaZone: "us-east-1"}, expected: Topology{Region: "us-east", Zone: "us-east-1"}},
+		{testcasename: "Conflicting keys", segments: map[string]string{TopologyKeyZone: "us-east-1", TopologyKeyBetaZone: "us-east-2"}, expectErr: true},
+		{testcasename: "No zone", segments: map[string]string{TopologyKeyRegion: "us-east"}, expectErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			topology, err := FromCSITopology(testcase.segments)
+			if testcase.expectErr {
+				assert.True(t, errors.Is(err, provider.Error{Fault: provider.Fault{ReasonCode: "ErrorBadRequest"}}))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, topology)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// ValidateCloneRequest returns an ErrorRequiredFieldMissing error if the request has no source volume, an ErrorBadRequest
+// error if the capacity is smaller than the capacity of the source, and an ErrorUnsupportedFeature error if the
+// capabilities do not support cloning. The source is optional, the capacity is only checked against it if set
+func ValidateCloneRequest(request provider.VolumeCloneRequest, source *provider.Volume, capabilities *provider.Capabilities) error {
+	if request.SourceVolumeID == "" {
+		return NewError(reasoncode.ErrorRequiredFieldMissing, "Source volume ID is required to clone a volume")
+	}
+	if request.Capacity != nil {
+		if *request.Capacity <= 0 {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid clone capacity %d", *request.Capacity))
+		}
+		if source != nil && source.Capacity != nil && *request.Capacity < *source.Capacity {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Clone capacity %d GiB is smaller than the capacity %d GiB of the source volume %s", *request.Capacity, *source.Capacity, request.SourceVolumeID))
+		}
+	}
+	if !capabilities.HasFeature(provider.FeatureVolumeClone) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, "Volume cloning is not supported by the provider")
+	}
+	return nil
+}
+
+// CloneVolume validates the request against the source volume and creates the clone.
+// In dry run, the clone is only planned and a nil response returned
+func CloneVolume(ctx context.Context, sess provider.VolumeManager, request provider.VolumeCloneRequest, capabilities *provider.Capabilities, logger *zap.Logger) (*provider.VolumeCloneResponse, error) {
+	var source *provider.Volume
+	if request.SourceVolumeID != "" {
+		var err error
+		if source, err = sess.GetVolume(request.SourceVolumeID); err != nil {
+			logger.Error("Failed to get the source volume of the clone", zap.String("SourceVolumeID", request.SourceVolumeID), ZapError(err))
+			return nil, err
+		}
+	}
+	if err := ValidateCloneRequest(request, source, capabilities); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"zone": request.Zone}
+	if request.Zone == "" && source != nil {
+		details["zone"] = source.Az
+	}
+	if request.Capacity != nil {
+		details["capacity"] = strconv.Itoa(*request.Capacity)
+	}
+	var response *provider.VolumeCloneResponse
+	err := RunMutation(ctx, "CreateVolumeFromVolume", request.SourceVolumeID, details, func() error {
+		var err error
+		response, err = sess.CreateVolumeFromVolume(request)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to clone the volume", zap.String("SourceVolumeID", request.SourceVolumeID), ZapError(err))
+		return nil, err
+	}
+	if response != nil && response.Volume != nil {
+		logger.Info("Cloned volume", zap.String("SourceVolumeID", request.SourceVolumeID), zap.String("VolumeID", response.Volume.VolumeID))
+	}
+	return response, nil
+}
@@ -0,0 +1,108 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateCloneRequest(t *testing.T) {
+	size10, size5, zero := 10, 5, 0
+	capable := &provider.Capabilities{Features: map[string]bool{provider.FeatureVolumeClone: true}}
+	source := &provider.Volume{VolumeID: "vol-1", Capacity: &size10}
+
+	testcases := []struct {
+		testcasename string
+		request      provider.VolumeCloneRequest
+		capabilities *provider.Capabilities
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "Valid clone",
+			request:      provider.VolumeCloneRequest{SourceVolumeID: "vol-1", Capacity: &size10},
+			capabilities: capable,
+		},
+		{
+			testcasename: "Missing source",
+			request:      provider.VolumeCloneRequest{},
+			capabilities: capable,
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+		{
+			testcasename: "Smaller than source",
+			request:      provider.VolumeCloneRequest{SourceVolumeID: "vol-1", Capacity: &size5},
+			capabilities: capable,
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Invalid capacity",
+			request:      provider.VolumeCloneRequest{SourceVolumeID: "vol-1", Capacity: &zero},
+			capabilities: capable,
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Unsupported",
+			request:      provider.VolumeCloneRequest{SourceVolumeID: "vol-1"},
+			expectedCode: reasoncode.ErrorUnsupportedFeature,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateCloneRequest(testcase.request, source, testcase.capabilities)
+			if testcase.expectedCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			}
+		})
+	}
+}
+
+func TestCloneVolume(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	size10, size20 := 10, 20
+	capable := &provider.Capabilities{Features: map[string]bool{provider.FeatureVolumeClone: true}}
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(&provider.Volume{VolumeID: "vol-1", Capacity: &size10, Az: "us-south-1"}, nil)
+	sess.CreateVolumeFromVolumeReturns(&provider.VolumeCloneResponse{Volume: &provider.Volume{VolumeID: "vol-2"}, SourceVolumeID: "vol-1"}, nil)
+
+	request := provider.VolumeCloneRequest{SourceVolumeID: "vol-1", Zone: "us-south-2", Capacity: &size20}
+	response, err := CloneVolume(context.Background(), sess, request, capable, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "vol-2", response.Volume.VolumeID)
+	assert.Equal(t, request, sess.CreateVolumeFromVolumeArgsForCall(0))
+
+	ctx, plan := WithDryRun(context.Background())
+	response, err = CloneVolume(ctx, sess, provider.VolumeCloneRequest{SourceVolumeID: "vol-1"}, capable, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, response)
+	assert.Equal(t, 1, sess.CreateVolumeFromVolumeCallCount())
+	steps := plan.Steps()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "us-south-1", steps[0].Details["zone"])
+
+	_, err = CloneVolume(context.Background(), sess, provider.VolumeCloneRequest{SourceVolumeID: "vol-1"}, nil, logger)
+	assert.Equal(t, reasoncode.ErrorUnsupportedFeature, ErrorReasonCode(err))
+}
@@ -0,0 +1,92 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// VolumeExpansion is the outcome of an expansion shared by the controller and node components
+type VolumeExpansion struct {
+	// Capacity of the volume after the expansion, in GiB
+	Capacity int64 `json:"capacity"`
+
+	// NodeExpansionRequired is true if the file system must be resized on the node, i.e. for block volumes
+	NodeExpansionRequired bool `json:"nodeExpansionRequired"`
+}
+
+// isBlockVolumeType returns true for the block volume types, an empty type is a block volume
+func isBlockVolumeType(volumeType provider.VolumeType) bool {
+	return volumeType == "" || volumeType == "block" || volumeType == "vpc-block"
+}
+
+// hasAttachments returns true if the volume is attached to any instance
+func hasAttachments(volume *provider.Volume) bool {
+	return volume.VolumeAttachments != nil && len(*volume.VolumeAttachments) > 0
+}
+
+// ExpandVolume expands the volume to the capacity of the request. An attached volume is only expanded if the
+// provider supports its online expansion, otherwise an ErrorUnsupportedFeature error is returned so that the
+// caller detaches it first. A volume already at least as large is not expanded again.
+// In dry run, the expansion is only planned and a nil expansion returned
+func ExpandVolume(ctx context.Context, sess provider.VolumeManager, request provider.ExpandVolumeRequest, logger *zap.Logger) (*VolumeExpansion, error) {
+	if request.VolumeID == "" {
+		return nil, NewError(reasoncode.ErrorRequiredFieldMissing, "Volume ID is required to expand a volume")
+	}
+	if request.Capacity <= 0 {
+		return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid expansion capacity %d", request.Capacity))
+	}
+	volume, err := sess.GetVolume(request.VolumeID)
+	if err != nil {
+		logger.Error("Failed to get the volume to expand", zap.String("VolumeID", request.VolumeID), ZapError(err))
+		return nil, err
+	}
+
+	expansion := &VolumeExpansion{Capacity: request.Capacity, NodeExpansionRequired: isBlockVolumeType(volume.VolumeType)}
+	if volume.Capacity != nil && int64(*volume.Capacity) >= request.Capacity {
+		logger.Info("Volume already expanded", zap.String("VolumeID", request.VolumeID), zap.Int("Capacity", *volume.Capacity))
+		expansion.Capacity = int64(*volume.Capacity)
+		return expansion, nil
+	}
+	if hasAttachments(volume) && !sess.SupportsOnlineExpansion(volume) {
+		return nil, NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Volume %s must be detached to be expanded, online expansion is not supported", request.VolumeID))
+	}
+
+	err = RunMutation(ctx, "ExpandVolume", request.VolumeID, map[string]string{"capacity": strconv.FormatInt(request.Capacity, 10)}, func() error {
+		capacity, err := sess.ExpandVolume(request)
+		if err == nil && capacity > 0 {
+			expansion.Capacity = capacity
+		}
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to expand the volume", zap.String("VolumeID", request.VolumeID), ZapError(err))
+		return nil, err
+	}
+	if IsDryRun(ctx) {
+		return nil, nil
+	}
+	logger.Info("Expanded volume", zap.String("VolumeID", request.VolumeID), zap.Int64("Capacity", expansion.Capacity), zap.Bool("NodeExpansionRequired", expansion.NodeExpansionRequired))
+	return expansion, nil
+}
@@ -0,0 +1,131 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestExpandVolume(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	size10 := 10
+	attached := &provider.Volume{VolumeID: "vol-1", VolumeType: "vpc-block", Capacity: &size10}
+	attached.VolumeAttachments = &[]provider.VolumeAttachment{{}}
+
+	testcases := []struct {
+		testcasename     string
+		request          provider.ExpandVolumeRequest
+		volume           *provider.Volume
+		online           bool
+		expected         *VolumeExpansion
+		expectedCode     reasoncode.ReasonCode
+		expectedExpanded int
+	}{
+		{
+			testcasename:     "Detached block volume",
+			request:          provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20},
+			volume:           &provider.Volume{VolumeID: "vol-1", VolumeType: "vpc-block", Capacity: &size10},
+			expected:         &VolumeExpansion{Capacity: 20, NodeExpansionRequired: true},
+			expectedExpanded: 1,
+		},
+		{
+			testcasename:     "File volume",
+			request:          provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20},
+			volume:           &provider.Volume{VolumeID: "vol-1", VolumeType: "vpc-share", Capacity: &size10},
+			expected:         &VolumeExpansion{Capacity: 20},
+			expectedExpanded: 1,
+		},
+		{
+			testcasename:     "Attached volume with online expansion",
+			request:          provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20},
+			volume:           attached,
+			online:           true,
+			expected:         &VolumeExpansion{Capacity: 20, NodeExpansionRequired: true},
+			expectedExpanded: 1,
+		},
+		{
+			testcasename: "Attached volume without online expansion",
+			request:      provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20},
+			volume:       attached,
+			expectedCode: reasoncode.ErrorUnsupportedFeature,
+		},
+		{
+			testcasename: "Already expanded",
+			request:      provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 5},
+			volume:       attached,
+			expected:     &VolumeExpansion{Capacity: 10, NodeExpansionRequired: true},
+		},
+		{
+			testcasename: "Missing volume ID",
+			request:      provider.ExpandVolumeRequest{Capacity: 20},
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+		{
+			testcasename: "Invalid capacity",
+			request:      provider.ExpandVolumeRequest{VolumeID: "vol-1"},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			sess := &fake.FakeSession{}
+			sess.GetVolumeReturns(testcase.volume, nil)
+			sess.SupportsOnlineExpansionReturns(testcase.online)
+			sess.ExpandVolumeReturns(testcase.request.Capacity, nil)
+
+			expansion, err := ExpandVolume(context.Background(), sess, testcase.request, logger)
+			if testcase.expectedCode != "" {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, testcase.expected, expansion)
+			}
+			assert.Equal(t, testcase.expectedExpanded, sess.ExpandVolumeCallCount())
+		})
+	}
+}
+
+func TestExpandVolumeErrors(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	size10 := 10
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(&provider.Volume{VolumeID: "vol-1", Capacity: &size10}, nil)
+	sess.ExpandVolumeReturns(0, errors.New("quota exceeded"))
+
+	_, err := ExpandVolume(context.Background(), sess, provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20}, logger)
+	assert.NotNil(t, err)
+
+	ctx, plan := WithDryRun(context.Background())
+	expansion, err := ExpandVolume(ctx, sess, provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, expansion)
+	assert.Len(t, plan.Steps(), 1)
+	assert.Equal(t, 1, sess.ExpandVolumeCallCount())
+
+	sess.GetVolumeReturns(nil, errors.New("not found"))
+	_, err = ExpandVolume(context.Background(), sess, provider.ExpandVolumeRequest{VolumeID: "vol-1", Capacity: 20}, logger)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,200 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+const (
+	// FenceOwnerTagKey is the key of the tag naming the controller (e.g. the cluster ID) owning the volume
+	FenceOwnerTagKey = "volume-fence-owner"
+
+	// FenceExpiryTagKey is the key of the tag carrying the expiry of the fence lease, in Unix seconds
+	FenceExpiryTagKey = "volume-fence-expiry"
+
+	// DefaultFenceLease is the default duration of a fence lease
+	DefaultFenceLease = 10 * time.Minute
+)
+
+// FenceOwnerProperty is the error property naming the controller owning a fenced volume
+const FenceOwnerProperty = "FenceOwner"
+
+// VolumeFence fences the volumes with backend tags, so two controllers accidentally managing the same volumes
+// (e.g. during a migration between drivers) do not issue conflicting operations: the ownership tag and its
+// lease expiry are checked, then claimed or renewed, before mutating a volume. The tags are attached and detached
+// without a conditional write, so two controllers claiming a volume at the same time may both attach their owner
+// tag: the ownership is read back after the claim, the controller losing the tie-break of VolumeFenceOwner detaches
+// its owner tag and fails. The read back narrows the race without closing it, the tagging service being eventually
+// consistent a concurrent claim may only be visible after both controllers read the tags back
+type VolumeFence struct {
+	owner  string
+	lease  time.Duration
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewVolumeFence returns the fence of the owner (e.g. the cluster ID, normalized as a tag value) with leases of
+// lease (DefaultFenceLease if zero)
+func NewVolumeFence(owner string, lease time.Duration, logger *zap.Logger) (*VolumeFence, error) {
+	tag, err := NormalizeTag(TagFromKeyValue(FenceOwnerTagKey, owner), true)
+	if err != nil {
+		return nil, err
+	}
+	if lease <= 0 {
+		lease = DefaultFenceLease
+	}
+	_, owner = SplitTag(tag)
+	return &VolumeFence{owner: owner, lease: lease, logger: logger, now: time.Now}, nil
+}
+
+// VolumeFenceOwner returns the owner of the volume fence and the expiry of its lease, fenced is false if the
+// volume carries no fence tags. When concurrent claims left several owner tags the lowest owner wins, so all
+// the controllers agree on it, and the latest expiry is returned
+func VolumeFenceOwner(volume *provider.Volume) (owner string, expiry time.Time, fenced bool) {
+	if volume == nil {
+		return "", time.Time{}, false
+	}
+	for _, tag := range volume.Tags {
+		key, value := SplitTag(tag)
+		switch key {
+		case FenceOwnerTagKey:
+			if !fenced || value < owner {
+				owner, fenced = value, true
+			}
+		case FenceExpiryTagKey:
+			if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && time.Unix(seconds, 0).After(expiry) {
+				expiry = time.Unix(seconds, 0)
+			}
+		}
+	}
+	return owner, expiry, fenced
+}
+
+// Check returns an ErrorVolumeFenced error if the volume is owned by another controller whose lease is not
+// expired, the error is not to be retried before the lease expiry
+func (f *VolumeFence) Check(volume *provider.Volume) error {
+	owner, expiry, fenced := VolumeFenceOwner(volume)
+	if !fenced || owner == f.owner || !f.now().Before(expiry) {
+		return nil
+	}
+	err := NewErrorWithProperties(reasoncode.ErrorVolumeFenced,
+		fmt.Sprintf("Volume %s is fenced by %s until %s", volume.VolumeID, owner, expiry.UTC().Format(time.RFC3339)),
+		map[string]string{VolumeIDProperty: volume.VolumeID, FenceOwnerProperty: owner})
+	return WithNotBefore(err, expiry)
+}
+
+// Acquire claims the fence of the volume, or renews its lease when less than half of it remains. An
+// ErrorVolumeFenced error is returned if another controller owns the volume
+func (f *VolumeFence) Acquire(ctx context.Context, sess provider.VolumeManager, volumeID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	volume, err := sess.GetVolume(volumeID)
+	if err != nil {
+		return err
+	}
+	if err := f.Check(volume); err != nil {
+		f.logger.Warn("Volume is fenced by another controller", zap.String("volumeID", volumeID), ZapError(err))
+		return err
+	}
+	owner, expiry, fenced := VolumeFenceOwner(volume)
+	if fenced && owner == f.owner && expiry.Sub(f.now()) > f.lease/2 {
+		return nil
+	}
+
+	expiry = f.now().Add(f.lease)
+	f.logger.Info("Claiming volume fence", zap.String("volumeID", volumeID), zap.String("owner", f.owner), zap.Time("expiry", expiry))
+	ownerTag := TagFromKeyValue(FenceOwnerTagKey, f.owner)
+	attach := []string{ownerTag, TagFromKeyValue(FenceExpiryTagKey, strconv.FormatInt(expiry.Unix(), 10))}
+	if err := f.updateTags(ctx, sess, volumeID, attach, fenceTags(volume.Tags, attach...)); err != nil || IsDryRun(ctx) {
+		return err
+	}
+
+	// a controller claiming the volume concurrently attached its owner tag too
+	volume, err = sess.GetVolume(volumeID)
+	if err != nil {
+		return err
+	}
+	if err := f.Check(volume); err != nil {
+		f.logger.Warn("Volume was claimed concurrently by another controller", zap.String("volumeID", volumeID), ZapError(err))
+		if detachErr := f.updateTags(ctx, sess, volumeID, nil, []string{ownerTag}); detachErr != nil {
+			f.logger.Warn("Failed to detach the volume fence owner tag", zap.String("volumeID", volumeID), ZapError(detachErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// Release removes the fence of the volume if it is owned by the fence
+func (f *VolumeFence) Release(ctx context.Context, sess provider.VolumeManager, volumeID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	volume, err := sess.GetVolume(volumeID)
+	if err != nil {
+		return err
+	}
+	if owner, _, fenced := VolumeFenceOwner(volume); !fenced || owner != f.owner {
+		return nil
+	}
+	f.logger.Info("Releasing volume fence", zap.String("volumeID", volumeID), zap.String("owner", f.owner))
+	return f.updateTags(ctx, sess, volumeID, nil, fenceTags(volume.Tags))
+}
+
+// RunFenced acquires the fence of the volume, then runs the mutation of it
+func (f *VolumeFence) RunFenced(ctx context.Context, sess provider.VolumeManager, volumeID string, mutate func() error) error {
+	if err := f.Acquire(ctx, sess, volumeID); err != nil {
+		return err
+	}
+	return mutate()
+}
+
+// updateTags attaches and detaches the fence tags, leaving the other tags of the volume untouched
+func (f *VolumeFence) updateTags(ctx context.Context, sess provider.VolumeManager, volumeID string, attach, detach []string) error {
+	update := provider.VolumeTagsUpdateRequest{VolumeID: volumeID, TagType: provider.TagTypeUser, Attach: attach, Detach: detach}
+	return RunMutation(ctx, "UpdateVolumeTags", volumeID, map[string]string{"fence": f.owner}, func() error {
+		_, err := sess.UpdateVolumeTags(update)
+		return err
+	})
+}
+
+// fenceTags returns the fence tags of the volume but the kept ones
+func fenceTags(tags []string, kept ...string) []string {
+	fence := make([]string, 0, 2)
+next:
+	for _, tag := range tags {
+		if key, _ := SplitTag(tag); key != FenceOwnerTagKey && key != FenceExpiryTagKey {
+			continue
+		}
+		for _, k := range kept {
+			if tag == k {
+				continue next
+			}
+		}
+		fence = append(fence, tag)
+	}
+	return fence
+}
@@ -0,0 +1,151 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// taggedVolumeSession returns a session applying the tag updates to the volume
+func taggedVolumeSession(tags ...string) (*fake.FakeSession, *provider.Volume) {
+	volume := &provider.Volume{VolumeID: "vol-1"}
+	volume.Tags = tags
+	sess := &fake.FakeSession{}
+	sess.GetVolumeStub = func(string) (*provider.Volume, error) {
+		current := *volume
+		current.Tags = append([]string(nil), volume.Tags...)
+		return &current, nil
+	}
+	sess.UpdateVolumeTagsStub = func(update provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+		volume.Tags = applyTagsUpdate(volume.Tags, update)
+		return &provider.VolumeTags{VolumeID: volume.VolumeID, UserTags: volume.Tags}, nil
+	}
+	return sess, volume
+}
+
+// applyTagsUpdate returns the tags with the tags of the update attached and detached
+func applyTagsUpdate(tags []string, update provider.VolumeTagsUpdateRequest) []string {
+	updated := []string{}
+	for _, tag := range tags {
+		detached := false
+		for _, d := range update.Detach {
+			detached = detached || d == tag
+		}
+		if !detached {
+			updated = append(updated, tag)
+		}
+	}
+	for _, tag := range update.Attach {
+		found := false
+		for _, t := range updated {
+			found = found || t == tag
+		}
+		if !found {
+			updated = append(updated, tag)
+		}
+	}
+	return updated
+}
+
+func TestVolumeFence(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	now := time.Unix(1700000000, 0)
+	fence, err := NewVolumeFence("Cluster-A", time.Minute, logger)
+	assert.Nil(t, err)
+	fence.now = func() time.Time { return now }
+	other, _ := NewVolumeFence("cluster-b", time.Minute, logger)
+	other.now = fence.now
+
+	sess, volume := taggedVolumeSession("env:prod")
+	assert.Nil(t, fence.Acquire(context.Background(), sess, "vol-1"))
+	owner, expiry, fenced := VolumeFenceOwner(volume)
+	assert.True(t, fenced)
+	assert.Equal(t, "cluster-a", owner)
+	assert.Equal(t, now.Add(time.Minute), expiry)
+	assert.Contains(t, volume.Tags, "env:prod")
+
+	// the lease is only renewed once half of it elapsed
+	assert.Nil(t, fence.Acquire(context.Background(), sess, "vol-1"))
+	assert.Equal(t, 1, sess.UpdateVolumeTagsCallCount())
+	assert.Equal(t, provider.TagTypeUser, sess.UpdateVolumeTagsArgsForCall(0).TagType)
+	assert.Equal(t, 0, sess.UpdateVolumeCallCount())
+
+	err = other.RunFenced(context.Background(), sess, "vol-1", func() error {
+		t.Fatal("mutation of a fenced volume")
+		return nil
+	})
+	assert.Equal(t, reasoncode.ErrorVolumeFenced, ErrorReasonCode(err))
+	assert.Equal(t, "cluster-a", err.(provider.Error).Fault.Properties[FenceOwnerProperty])
+	assert.Equal(t, now.Add(time.Minute), *err.(provider.Error).Fault.NotBefore)
+
+	// cluster-b takes over an expired lease
+	now = now.Add(time.Hour)
+	mutated := false
+	assert.Nil(t, other.RunFenced(context.Background(), sess, "vol-1", func() error {
+		mutated = true
+		return nil
+	}))
+	assert.True(t, mutated)
+	owner, _, _ = VolumeFenceOwner(volume)
+	assert.Equal(t, "cluster-b", owner)
+	assert.Len(t, volume.Tags, 3)
+
+	// only the owner releases the fence
+	assert.Nil(t, fence.Release(context.Background(), sess, "vol-1"))
+	_, _, fenced = VolumeFenceOwner(volume)
+	assert.True(t, fenced)
+	assert.Nil(t, other.Release(context.Background(), sess, "vol-1"))
+	_, _, fenced = VolumeFenceOwner(volume)
+	assert.False(t, fenced)
+	assert.Equal(t, []string{"env:prod"}, volume.Tags)
+}
+
+func TestVolumeFenceConcurrentClaim(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	fence, _ := NewVolumeFence("cluster-b", time.Minute, logger)
+	sess, volume := taggedVolumeSession()
+	sess.UpdateVolumeTagsStub = func(update provider.VolumeTagsUpdateRequest) (*provider.VolumeTags, error) {
+		if sess.UpdateVolumeTagsCallCount() == 1 {
+			// cluster-a claims the volume at the same time, both owner tags are attached
+			update.Attach = append(update.Attach, TagFromKeyValue(FenceOwnerTagKey, "cluster-a"),
+				TagFromKeyValue(FenceExpiryTagKey, strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)))
+		}
+		volume.Tags = applyTagsUpdate(volume.Tags, update)
+		return nil, nil
+	}
+	err := fence.Acquire(context.Background(), sess, "vol-1")
+	assert.Equal(t, reasoncode.ErrorVolumeFenced, ErrorReasonCode(err))
+
+	// cluster-b lost the tie-break and detached its owner tag
+	assert.Equal(t, 2, sess.UpdateVolumeTagsCallCount())
+	assert.NotContains(t, volume.Tags, TagFromKeyValue(FenceOwnerTagKey, "cluster-b"))
+	owner, _, _ := VolumeFenceOwner(volume)
+	assert.Equal(t, "cluster-a", owner)
+
+	_, err = NewVolumeFence("", time.Minute, logger)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+const (
+	// LegacyVolumeType is the volume type of the legacy (IKS block classic) volume handles
+	LegacyVolumeType = provider.VolumeType("block")
+
+	// DefaultLegacyProviderName is the provider of the legacy volume handles if none is configured
+	DefaultLegacyProviderName = provider.VolumeProvider("SOFTLAYER-BLOCK")
+)
+
+// IsLegacyVolumeHandle returns true for a legacy (IKS block classic) volume handle, i.e. the numeric
+// classic volume ID
+func IsLegacyVolumeHandle(handle string) bool {
+	if handle == "" {
+		return false
+	}
+	for _, c := range handle {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseVolumeHandle parses a provider/type/id volume handle
+func ParseVolumeHandle(handle string) (provider.ResourceID, error) {
+	parts := strings.Split(handle, provider.VolumeHandleSeparator)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return provider.ResourceID{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid volume handle '%s', expected provider/type/id", handle))
+	}
+	return provider.ResourceID{
+		Provider:   provider.VolumeProvider(parts[0]),
+		VolumeType: provider.VolumeType(parts[1]),
+		ID:         parts[2],
+	}, nil
+}
+
+// LegacyVolumeHandleToResourceID converts a legacy volume handle to the ResourceID of the classic block
+// provider, DefaultLegacyProviderName is used for an empty providerName
+func LegacyVolumeHandleToResourceID(handle string, providerName provider.VolumeProvider) (provider.ResourceID, error) {
+	if !IsLegacyVolumeHandle(handle) {
+		return provider.ResourceID{}, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid legacy volume handle '%s', expected a numeric volume ID", handle))
+	}
+	if providerName == "" {
+		providerName = DefaultLegacyProviderName
+	}
+	return provider.ResourceID{Provider: providerName, VolumeType: LegacyVolumeType, ID: handle}, nil
+}
+
+// ResourceIDToLegacyVolumeHandle converts the ResourceID of a classic block volume back to its legacy
+// volume handle, e.g. to roll back a driver migration
+func ResourceIDToLegacyVolumeHandle(resourceID provider.ResourceID) (string, error) {
+	if resourceID.VolumeType != LegacyVolumeType || !IsLegacyVolumeHandle(resourceID.ID) {
+		return "", NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Volume handle '%s' has no legacy format", resourceID.VolumeHandle()))
+	}
+	return resourceID.ID, nil
+}
+
+// MigrateVolumeHandle returns the provider/type/id volume handle of a legacy or already migrated handle,
+// so PVs can be migrated in place without being recreated
+func MigrateVolumeHandle(handle string, providerName provider.VolumeProvider) (string, error) {
+	if IsLegacyVolumeHandle(handle) {
+		resourceID, err := LegacyVolumeHandleToResourceID(handle, providerName)
+		if err != nil {
+			return "", err
+		}
+		return resourceID.VolumeHandle(), nil
+	}
+	resourceID, err := ParseVolumeHandle(handle)
+	if err != nil {
+		return "", err
+	}
+	return resourceID.VolumeHandle(), nil
+}
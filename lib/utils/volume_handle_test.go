@@ -0,0 +1,72 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLegacyVolumeHandle(t *testing.T) {
+	assert.True(t, IsLegacyVolumeHandle("12345678"))
+	assert.False(t, IsLegacyVolumeHandle(""))
+	assert.False(t, IsLegacyVolumeHandle("r006-1234"))
+
+	resourceID, err := LegacyVolumeHandleToResourceID("12345678", "")
+	assert.Nil(t, err)
+	assert.Equal(t, provider.ResourceID{Provider: DefaultLegacyProviderName, VolumeType: LegacyVolumeType, ID: "12345678"}, resourceID)
+	assert.Equal(t, "SOFTLAYER-BLOCK/block/12345678", resourceID.VolumeHandle())
+
+	handle, err := ResourceIDToLegacyVolumeHandle(resourceID)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678", handle)
+
+	_, err = LegacyVolumeHandleToResourceID("r006-1234", "")
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+
+	_, err = ResourceIDToLegacyVolumeHandle(provider.ResourceID{Provider: "VPC-BLOCK", VolumeType: "vpc-block", ID: "r006-1234"})
+	assert.NotNil(t, err)
+}
+
+func TestMigrateVolumeHandle(t *testing.T) {
+	testcases := []struct {
+		testcasename string
+		handle       string
+		expected     string
+		expectedErr  bool
+	}{
+		{testcasename: "legacy", handle: "12345678", expected: "IKS-BLOCK/block/12345678"},
+		{testcasename: "already migrated", handle: "IKS-BLOCK/block/12345678", expected: "IKS-BLOCK/block/12345678"},
+		{testcasename: "invalid", handle: "IKS-BLOCK/12345678", expectedErr: true},
+		{testcasename: "empty", handle: "", expectedErr: true},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			handle, err := MigrateVolumeHandle(testcase.handle, "IKS-BLOCK")
+			assert.Equal(t, testcase.expectedErr, err != nil)
+			assert.Equal(t, testcase.expected, handle)
+		})
+	}
+
+	resourceID, err := ParseVolumeHandle("IKS-BLOCK/block/12345678")
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678", resourceID.ID)
+}
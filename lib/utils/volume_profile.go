@@ -0,0 +1,214 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// CapacityIOPSRange is the IOPS range of the volumes of a capacity range, capacities in GiB
+type CapacityIOPSRange struct {
+	MinCapacity int
+	MaxCapacity int
+	MinIOPS     int
+	MaxIOPS     int
+}
+
+// ProfileBounds are the IOPS and bandwidth which can be set on the volumes of a profile
+type ProfileBounds struct {
+	// CustomIOPS is true if the IOPS of the volumes can be set, they are fixed by the profile otherwise
+	CustomIOPS bool
+
+	// IOPSByCapacity bounds the IOPS by the capacity of the volume
+	IOPSByCapacity []CapacityIOPSRange
+
+	// MinBandwidth and MaxBandwidth bound the bandwidth in megabits per second, it cannot be set if MaxBandwidth is 0
+	MinBandwidth int
+	MaxBandwidth int
+}
+
+// DefaultProfileBounds are the bounds of the VPC block volume profiles, the tiered profiles have fixed IOPS
+var DefaultProfileBounds = map[string]ProfileBounds{
+	"general-purpose": {},
+	"5iops-tier":      {},
+	"10iops-tier":     {},
+	"custom": {
+		CustomIOPS: true,
+		IOPSByCapacity: []CapacityIOPSRange{
+			{MinCapacity: 10, MaxCapacity: 39, MinIOPS: 100, MaxIOPS: 1000},
+			{MinCapacity: 40, MaxCapacity: 79, MinIOPS: 100, MaxIOPS: 2000},
+			{MinCapacity: 80, MaxCapacity: 99, MinIOPS: 100, MaxIOPS: 4000},
+			{MinCapacity: 100, MaxCapacity: 499, MinIOPS: 100, MaxIOPS: 6000},
+			{MinCapacity: 500, MaxCapacity: 999, MinIOPS: 100, MaxIOPS: 10000},
+			{MinCapacity: 1000, MaxCapacity: 1999, MinIOPS: 100, MaxIOPS: 20000},
+			{MinCapacity: 2000, MaxCapacity: 3999, MinIOPS: 200, MaxIOPS: 40000},
+			{MinCapacity: 4000, MaxCapacity: 7999, MinIOPS: 300, MaxIOPS: 40000},
+			{MinCapacity: 8000, MaxCapacity: 9999, MinIOPS: 500, MaxIOPS: 48000},
+			{MinCapacity: 10000, MaxCapacity: 16000, MinIOPS: 1000, MaxIOPS: 48000},
+		},
+	},
+	"sdp": {
+		CustomIOPS: true,
+		IOPSByCapacity: []CapacityIOPSRange{
+			{MinCapacity: 1, MaxCapacity: 32000, MinIOPS: 3000, MaxIOPS: 64000},
+		},
+		MinBandwidth: 1000,
+		MaxBandwidth: 8192,
+	},
+}
+
+// validate returns an ErrorBadRequest error if the IOPS or the bandwidth are out of the profile bounds for the capacity
+func (b ProfileBounds) validate(profile string, capacity *int, iops, bandwidth *int) error {
+	if iops != nil {
+		if !b.CustomIOPS {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("IOPS cannot be set on the volumes of profile %s", profile))
+		}
+		if capacity != nil {
+			for _, r := range b.IOPSByCapacity {
+				if *capacity < r.MinCapacity || *capacity > r.MaxCapacity {
+					continue
+				}
+				if *iops < r.MinIOPS || *iops > r.MaxIOPS {
+					return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("IOPS %d out of the range %d-%d of profile %s for a capacity of %d GiB", *iops, r.MinIOPS, r.MaxIOPS, profile, *capacity))
+				}
+			}
+		}
+	}
+	if bandwidth != nil {
+		if b.MaxBandwidth == 0 {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Bandwidth cannot be set on the volumes of profile %s", profile))
+		}
+		if *bandwidth < b.MinBandwidth || *bandwidth > b.MaxBandwidth {
+			return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Bandwidth %d out of the range %d-%d of profile %s", *bandwidth, b.MinBandwidth, b.MaxBandwidth, profile))
+		}
+	}
+	return nil
+}
+
+// ValidateProfileUpdate returns an ErrorRequiredFieldMissing error if the request has no volume or profile, an ErrorUnsupportedFeature
+// error if the profile is not in the capabilities (when set), and an ErrorBadRequest error if the IOPS or bandwidth are out of the
+// bounds of the profile for the capacity of the volume. The volume is optional, the profiles without bounds are not checked
+func ValidateProfileUpdate(request provider.VolumeProfileUpdateRequest, volume *provider.Volume, bounds map[string]ProfileBounds, capabilities *provider.Capabilities) error {
+	if request.VolumeID == "" || request.Profile == "" {
+		return NewError(reasoncode.ErrorRequiredFieldMissing, "Volume ID and profile are required to update the volume profile")
+	}
+	if capabilities != nil && !capabilities.HasProfile(request.Profile) {
+		return NewError(reasoncode.ErrorUnsupportedFeature, fmt.Sprintf("Profile %s is not enabled for the account", request.Profile))
+	}
+	profileBounds, ok := bounds[request.Profile]
+	if !ok {
+		return nil
+	}
+	var capacity *int
+	if volume != nil {
+		capacity = volume.Capacity
+	}
+	return profileBounds.validate(request.Profile, capacity, request.Iops, request.Bandwidth)
+}
+
+// ValidateIOPSUpdate returns an ErrorRequiredFieldMissing error if the request has no volume, and an ErrorBadRequest error
+// if the IOPS are not positive or the IOPS or bandwidth are out of the bounds of the profile of the volume
+func ValidateIOPSUpdate(request provider.VolumeIOPSUpdateRequest, volume *provider.Volume, bounds map[string]ProfileBounds) error {
+	if request.VolumeID == "" {
+		return NewError(reasoncode.ErrorRequiredFieldMissing, "Volume ID is required to update the volume IOPS")
+	}
+	if request.Iops <= 0 {
+		return NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid IOPS %d", request.Iops))
+	}
+	if volume == nil || volume.Profile == nil {
+		return nil
+	}
+	profileBounds, ok := bounds[volume.Profile.Name]
+	if !ok {
+		return nil
+	}
+	return profileBounds.validate(volume.Profile.Name, volume.Capacity, &request.Iops, request.Bandwidth)
+}
+
+// UpdateVolumeProfile validates the request against the volume and the DefaultProfileBounds and changes the profile.
+// In dry run, the update is only planned and a nil volume returned
+func UpdateVolumeProfile(ctx context.Context, sess provider.VolumeManager, request provider.VolumeProfileUpdateRequest, capabilities *provider.Capabilities, logger *zap.Logger) (*provider.Volume, error) {
+	var volume *provider.Volume
+	if request.VolumeID != "" {
+		var err error
+		if volume, err = sess.GetVolume(request.VolumeID); err != nil {
+			logger.Error("Failed to get the volume to update", zap.String("VolumeID", request.VolumeID), ZapError(err))
+			return nil, err
+		}
+	}
+	if err := ValidateProfileUpdate(request, volume, DefaultProfileBounds, capabilities); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"profile": request.Profile}
+	if request.Iops != nil {
+		details["iops"] = strconv.Itoa(*request.Iops)
+	}
+	if request.Bandwidth != nil {
+		details["bandwidth"] = strconv.Itoa(*request.Bandwidth)
+	}
+	var updated *provider.Volume
+	err := RunMutation(ctx, "UpdateVolumeProfile", request.VolumeID, details, func() error {
+		var err error
+		updated, err = sess.UpdateVolumeProfile(request)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to update the volume profile", zap.String("VolumeID", request.VolumeID), zap.String("Profile", request.Profile), ZapError(err))
+		return nil, err
+	}
+	return updated, nil
+}
+
+// UpdateVolumeIOPS validates the request against the volume and the DefaultProfileBounds and changes the IOPS.
+// In dry run, the update is only planned and a nil volume returned
+func UpdateVolumeIOPS(ctx context.Context, sess provider.VolumeManager, request provider.VolumeIOPSUpdateRequest, logger *zap.Logger) (*provider.Volume, error) {
+	var volume *provider.Volume
+	if request.VolumeID != "" {
+		var err error
+		if volume, err = sess.GetVolume(request.VolumeID); err != nil {
+			logger.Error("Failed to get the volume to update", zap.String("VolumeID", request.VolumeID), ZapError(err))
+			return nil, err
+		}
+	}
+	if err := ValidateIOPSUpdate(request, volume, DefaultProfileBounds); err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"iops": strconv.Itoa(request.Iops)}
+	if request.Bandwidth != nil {
+		details["bandwidth"] = strconv.Itoa(*request.Bandwidth)
+	}
+	var updated *provider.Volume
+	err := RunMutation(ctx, "UpdateVolumeIOPS", request.VolumeID, details, func() error {
+		var err error
+		updated, err = sess.UpdateVolumeIOPS(request)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to update the volume IOPS", zap.String("VolumeID", request.VolumeID), zap.Int("Iops", request.Iops), ZapError(err))
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,142 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateProfileUpdate(t *testing.T) {
+	size50 := 50
+	iops1000, iops5000, bandwidth2000 := 1000, 5000, 2000
+	volume := &provider.Volume{VolumeID: "vol-1", Capacity: &size50}
+	capabilities := &provider.Capabilities{Profiles: []string{"custom", "general-purpose"}}
+
+	testcases := []struct {
+		testcasename string
+		request      provider.VolumeProfileUpdateRequest
+		capabilities *provider.Capabilities
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "Custom IOPS in range",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "custom", Iops: &iops1000},
+			capabilities: capabilities,
+		},
+		{
+			testcasename: "Tiered profile",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "general-purpose"},
+		},
+		{
+			testcasename: "Unknown profile is not checked",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "future-profile", Iops: &iops5000},
+		},
+		{
+			testcasename: "Custom IOPS out of range for the capacity",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "custom", Iops: &iops5000},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "IOPS on tiered profile",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "10iops-tier", Iops: &iops1000},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Bandwidth on custom profile",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "custom", Bandwidth: &bandwidth2000},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Profile not enabled",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "sdp"},
+			capabilities: capabilities,
+			expectedCode: reasoncode.ErrorUnsupportedFeature,
+		},
+		{
+			testcasename: "Missing profile",
+			request:      provider.VolumeProfileUpdateRequest{VolumeID: "vol-1"},
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateProfileUpdate(testcase.request, volume, DefaultProfileBounds, testcase.capabilities)
+			if testcase.expectedCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			}
+		})
+	}
+}
+
+func TestValidateIOPSUpdate(t *testing.T) {
+	size100 := 100
+	bandwidth2000, bandwidth9000 := 2000, 9000
+	sdp := &provider.Volume{VolumeID: "vol-1", Capacity: &size100}
+	sdp.Profile = &provider.Profile{Name: "sdp"}
+	tiered := &provider.Volume{VolumeID: "vol-1", Capacity: &size100}
+	tiered.Profile = &provider.Profile{Name: "5iops-tier"}
+
+	assert.Nil(t, ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 10000, Bandwidth: &bandwidth2000}, sdp, DefaultProfileBounds))
+	assert.Nil(t, ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 10000}, nil, DefaultProfileBounds))
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 1000}, sdp, DefaultProfileBounds)))
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 10000, Bandwidth: &bandwidth9000}, sdp, DefaultProfileBounds)))
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 500}, tiered, DefaultProfileBounds)))
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1"}, sdp, DefaultProfileBounds)))
+	assert.Equal(t, reasoncode.ErrorRequiredFieldMissing, ErrorReasonCode(ValidateIOPSUpdate(provider.VolumeIOPSUpdateRequest{Iops: 1000}, sdp, DefaultProfileBounds)))
+}
+
+func TestUpdateVolumeProfileAndIOPS(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	size100, iops3000 := 100, 3000
+	volume := &provider.Volume{VolumeID: "vol-1", Capacity: &size100}
+	volume.Profile = &provider.Profile{Name: "custom"}
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturns(volume, nil)
+	sess.UpdateVolumeProfileReturns(volume, nil)
+	sess.UpdateVolumeIOPSReturns(volume, nil)
+
+	updated, err := UpdateVolumeProfile(context.Background(), sess, provider.VolumeProfileUpdateRequest{VolumeID: "vol-1", Profile: "custom", Iops: &iops3000}, nil, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, volume, updated)
+	assert.Equal(t, 1, sess.UpdateVolumeProfileCallCount())
+
+	updated, err = UpdateVolumeIOPS(context.Background(), sess, provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 3000}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, volume, updated)
+
+	_, err = UpdateVolumeIOPS(context.Background(), sess, provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 9000}, logger)
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(err))
+	assert.Equal(t, 1, sess.UpdateVolumeIOPSCallCount())
+
+	ctx, plan := WithDryRun(context.Background())
+	updated, err = UpdateVolumeIOPS(ctx, sess, provider.VolumeIOPSUpdateRequest{VolumeID: "vol-1", Iops: 3000}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, updated)
+	steps := plan.Steps()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "3000", steps[0].Details["iops"])
+}
@@ -0,0 +1,167 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// MaxVolumeTags is the maximum number of tags of one type attached to a volume by the tagging service
+const MaxVolumeTags = 1000
+
+// normalizeTypedTags normalizes the tags of the type (see NormalizeTags). It returns an ErrorBadRequest error if a tag
+// is invalid, an access tag is not in key:value format, or there are more than MaxVolumeTags tags
+func normalizeTypedTags(tagType provider.TagType, tags []string) ([]string, error) {
+	if tagType != provider.TagTypeUser && tagType != provider.TagTypeAccess {
+		return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Unknown tag type %s", tagType))
+	}
+	normalized, err := NormalizeTags(tags, false)
+	if err != nil {
+		return nil, err
+	}
+	if tagType == provider.TagTypeAccess {
+		for _, tag := range normalized {
+			if !strings.Contains(tag, TagSeparator) {
+				return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Access tag '%s' must be in key:value format", tag))
+			}
+		}
+	}
+	if len(normalized) > MaxVolumeTags {
+		return nil, NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("A volume can have at most %d %s tags", MaxVolumeTags, tagType))
+	}
+	return normalized, nil
+}
+
+// ValidateVolumeTags returns an ErrorBadRequest error if the user or access tags of the volume request would be
+// rejected by the tagging service, e.g. before CreateVolume
+func ValidateVolumeTags(volume provider.Volume) error {
+	if _, err := normalizeTypedTags(provider.TagTypeUser, volume.Tags); err != nil {
+		return err
+	}
+	_, err := normalizeTypedTags(provider.TagTypeAccess, volume.AccessTags)
+	return err
+}
+
+// ValidateTagsUpdate returns an ErrorRequiredFieldMissing error if the request has no volume or no tag, and an
+// ErrorBadRequest error if a tag would be rejected by the tagging service
+func ValidateTagsUpdate(request provider.VolumeTagsUpdateRequest) error {
+	_, err := normalizeTagsUpdate(request)
+	return err
+}
+
+// normalizeTagsUpdate returns the validated request with the user tag type if none and the tags normalized
+func normalizeTagsUpdate(request provider.VolumeTagsUpdateRequest) (provider.VolumeTagsUpdateRequest, error) {
+	if request.VolumeID == "" {
+		return request, NewError(reasoncode.ErrorRequiredFieldMissing, "Volume ID is required to update the volume tags")
+	}
+	if len(request.Attach) == 0 && len(request.Detach) == 0 {
+		return request, NewError(reasoncode.ErrorRequiredFieldMissing, "No tag to attach or detach")
+	}
+	if request.TagType == "" {
+		request.TagType = provider.TagTypeUser
+	}
+	var err error
+	if request.Attach, err = normalizeTypedTags(request.TagType, request.Attach); err != nil {
+		return request, err
+	}
+	request.Detach, err = normalizeTypedTags(request.TagType, request.Detach)
+	return request, err
+}
+
+// UpdateVolumeTags validates and normalizes the request and updates the tags of the volume.
+// In dry run, the update is only planned and nil tags returned
+func UpdateVolumeTags(ctx context.Context, sess provider.VolumeManager, request provider.VolumeTagsUpdateRequest, logger *zap.Logger) (*provider.VolumeTags, error) {
+	request, err := normalizeTagsUpdate(request)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]string{"tagType": string(request.TagType), "attach": strings.Join(request.Attach, ","), "detach": strings.Join(request.Detach, ",")}
+	var updated *provider.VolumeTags
+	err = RunMutation(ctx, "UpdateVolumeTags", request.VolumeID, details, func() error {
+		var err error
+		updated, err = sess.UpdateVolumeTags(request)
+		return err
+	})
+	if err != nil {
+		logger.Error("Failed to update the volume tags", zap.String("VolumeID", request.VolumeID), zap.String("TagType", string(request.TagType)), ZapError(err))
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ReconcileVolumeTags makes the tags of the type of the volume match the desired tags, e.g. the cluster and PVC
+// metadata tags applied at create time: the desired tags missing are attached, and the key:value tags with the key
+// of a desired tag but another value are detached. The other tags of the volume are kept. The tags are returned
+// unchanged if they match already, nil in dry run
+func ReconcileVolumeTags(ctx context.Context, sess provider.VolumeManager, volumeID string, tagType provider.TagType, desired []string, logger *zap.Logger) (*provider.VolumeTags, error) {
+	if tagType == "" {
+		tagType = provider.TagTypeUser
+	}
+	desired, err := normalizeTypedTags(tagType, desired)
+	if err != nil {
+		return nil, err
+	}
+	current, err := sess.GetVolumeTags(volumeID)
+	if err != nil {
+		logger.Error("Failed to get the volume tags", zap.String("VolumeID", volumeID), ZapError(err))
+		return nil, err
+	}
+	var currentTags []string
+	if current != nil {
+		currentTags = current.UserTags
+		if tagType == provider.TagTypeAccess {
+			currentTags = current.AccessTags
+		}
+	}
+	// the tags read back are normalized by the tagging service
+	attached := make(map[string]bool, len(currentTags))
+	for _, tag := range currentTags {
+		attached[strings.ToLower(tag)] = true
+	}
+
+	request := provider.VolumeTagsUpdateRequest{VolumeID: volumeID, TagType: tagType}
+	wanted := make(map[string]bool, len(desired))
+	desiredKeys := map[string]bool{}
+	for _, tag := range desired {
+		wanted[tag] = true
+		if key, value := SplitTag(tag); value != "" {
+			desiredKeys[key] = true
+		}
+		if !attached[tag] {
+			request.Attach = append(request.Attach, tag)
+		}
+	}
+	for tag := range attached {
+		if key, value := SplitTag(tag); value != "" && desiredKeys[key] && !wanted[tag] {
+			request.Detach = append(request.Detach, tag)
+		}
+	}
+	sort.Strings(request.Detach)
+	if len(request.Attach) == 0 && len(request.Detach) == 0 {
+		return current, nil
+	}
+	return UpdateVolumeTags(ctx, sess, request, logger)
+}
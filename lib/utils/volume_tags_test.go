@@ -0,0 +1,149 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestValidateTagsUpdate(t *testing.T) {
+	tooMany := make([]string, MaxVolumeTags+1)
+	for i := range tooMany {
+		tooMany[i] = "tag-" + strings.Repeat("a", i%100) + string(rune('a'+i/100))
+	}
+
+	testcases := []struct {
+		testcasename string
+		request      provider.VolumeTagsUpdateRequest
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "User tags",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", Attach: []string{"Cluster:abc", "prod"}, Detach: []string{"dev"}},
+		},
+		{
+			testcasename: "Access tags",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", TagType: provider.TagTypeAccess, Attach: []string{"project:storage"}},
+		},
+		{
+			testcasename: "Access tag without value",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", TagType: provider.TagTypeAccess, Attach: []string{"storage"}},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Invalid character",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", Attach: []string{"env=dev"}},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Unknown tag type",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", TagType: "service", Attach: []string{"env:dev"}},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Too many tags",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", Attach: tooMany},
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "No tag",
+			request:      provider.VolumeTagsUpdateRequest{VolumeID: "vol-1"},
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+		{
+			testcasename: "Missing volume",
+			request:      provider.VolumeTagsUpdateRequest{Attach: []string{"prod"}},
+			expectedCode: reasoncode.ErrorRequiredFieldMissing,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			err := ValidateTagsUpdate(testcase.request)
+			if testcase.expectedCode == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.Equal(t, testcase.expectedCode, ErrorReasonCode(err))
+			}
+		})
+	}
+}
+
+func TestValidateVolumeTags(t *testing.T) {
+	volume := provider.Volume{}
+	volume.Tags = []string{"cluster:abc", "prod"}
+	volume.AccessTags = []string{"project:storage"}
+	assert.Nil(t, ValidateVolumeTags(volume))
+
+	volume.AccessTags = []string{"storage"}
+	assert.Equal(t, reasoncode.ErrorBadRequest, ErrorReasonCode(ValidateVolumeTags(volume)))
+}
+
+func TestUpdateVolumeTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	tags := &provider.VolumeTags{VolumeID: "vol-1", UserTags: []string{"env:dev"}}
+	sess.UpdateVolumeTagsReturns(tags, nil)
+
+	updated, err := UpdateVolumeTags(context.Background(), sess, provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", Attach: []string{" ENV:dev", "env:dev"}}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, tags, updated)
+	request := sess.UpdateVolumeTagsArgsForCall(0)
+	assert.Equal(t, provider.TagTypeUser, request.TagType)
+	assert.Equal(t, []string{"env:dev"}, request.Attach)
+
+	ctx, plan := WithDryRun(context.Background())
+	updated, err = UpdateVolumeTags(ctx, sess, provider.VolumeTagsUpdateRequest{VolumeID: "vol-1", Detach: []string{"env:dev"}}, logger)
+	assert.Nil(t, err)
+	assert.Nil(t, updated)
+	assert.Equal(t, 1, sess.UpdateVolumeTagsCallCount())
+	steps := plan.Steps()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "env:dev", steps[0].Details["detach"])
+}
+
+func TestReconcileVolumeTags(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	current := &provider.VolumeTags{VolumeID: "vol-1", UserTags: []string{"pvc:old", "owner:team-a", "manual"}, AccessTags: []string{"project:storage"}}
+	sess.GetVolumeTagsReturns(current, nil)
+	sess.UpdateVolumeTagsReturns(&provider.VolumeTags{VolumeID: "vol-1"}, nil)
+
+	_, err := ReconcileVolumeTags(context.Background(), sess, "vol-1", provider.TagTypeUser, []string{"PVC:new", "cluster:abc", "manual"}, logger)
+	assert.Nil(t, err)
+	request := sess.UpdateVolumeTagsArgsForCall(0)
+	assert.Equal(t, []string{"pvc:new", "cluster:abc"}, request.Attach)
+	assert.Equal(t, []string{"pvc:old"}, request.Detach)
+
+	// the access tags match already
+	tags, err := ReconcileVolumeTags(context.Background(), sess, "vol-1", provider.TagTypeAccess, []string{"project:storage"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, current, tags)
+	assert.Equal(t, 1, sess.UpdateVolumeTagsCallCount())
+
+	sess.GetVolumeTagsReturns(nil, NewError(reasoncode.ErrorResourceNotFound, "Volume not found"))
+	_, err = ReconcileVolumeTags(context.Background(), sess, "vol-1", provider.TagTypeUser, []string{"cluster:abc"}, logger)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, ErrorReasonCode(err))
+}
@@ -0,0 +1,85 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"go.uber.org/zap"
+)
+
+// IsNotFound reports whether the error returned by the provider means the entity does not exist
+func IsNotFound(err error) bool {
+	return err != nil && GetErrorType(err) == EntityNotFound
+}
+
+// WaitForVolumeDeletion polls the volume every interval until it no longer exists, a volume in the
+// deleting lifecycle state is still waited for. If the volume still exists after timeout the
+// returned error is provider.ErrDeletionStuck, so that stuck deletions can be reported distinctly.
+// The wait is registered in the waiter registry of the context and stops when the context is done, the interval
+// adapts to the deletion times observed by the transition tracker of the context, if any
+func WaitForVolumeDeletion(ctx context.Context, sess provider.VolumeManager, volumeID string, interval, timeout time.Duration, logger *zap.Logger) error {
+	_, err := RunWaiter(ctx, "WaitForVolumeDeletion", volumeID, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, waitForVolumeDeletion(ctx, sess, volumeID, interval, timeout, logger)
+	})
+	return err
+}
+
+func waitForVolumeDeletion(ctx context.Context, sess provider.VolumeManager, volumeID string, interval, timeout time.Duration, logger *zap.Logger) error {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	lifecycleState := ""
+	var lastErr error
+	for {
+		volume, err := sess.GetVolume(volumeID)
+		switch {
+		case IsNotFound(err), err == nil && volume == nil:
+			logger.Info("Volume deletion completed", zap.String("VolumeID", volumeID))
+			observeTransition(ctx, "WaitForVolumeDeletion", time.Since(start))
+			return nil
+		case err != nil:
+			lastErr = err
+			logger.Warn("Failed to get volume while waiting for deletion", zap.String("VolumeID", volumeID), ZapError(err))
+		default:
+			lifecycleState = volume.LifecycleState
+			logger.Debug("Volume not yet deleted", zap.String("VolumeID", volumeID), zap.String("LifecycleState", lifecycleState))
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			break
+		}
+		next := pollInterval(ctx, "WaitForVolumeDeletion", time.Since(start), interval)
+		if remaining := time.Until(deadline); next > remaining {
+			next = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(next):
+		}
+	}
+
+	logger.Error("Volume deletion did not complete", zap.String("VolumeID", volumeID), zap.String("LifecycleState", lifecycleState), zap.Duration("Timeout", timeout))
+	return NewErrorWithProperties(reasoncode.ErrorDeletionStuck,
+		fmt.Sprintf("Volume %s deletion did not complete within %s", volumeID, timeout),
+		map[string]string{"VolumeID": volumeID, "LifecycleState": lifecycleState}, lastErr)
+}
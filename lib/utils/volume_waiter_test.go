@@ -0,0 +1,75 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWaitForVolumeDeletion(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	deleting := &provider.Volume{VolumeID: "vol-1", VPCVolume: provider.VPCVolume{LifecycleState: provider.VolumeLifecycleStateDeleting}}
+
+	// Volume goes away after a few polls
+	sess := &fake.FakeSession{}
+	sess.GetVolumeReturnsOnCall(0, deleting, nil)
+	sess.GetVolumeReturnsOnCall(1, nil, errors.New("temporary failure"))
+	sess.GetVolumeReturnsOnCall(2, nil, Message{Type: EntityNotFound})
+	assert.Nil(t, WaitForVolumeDeletion(context.Background(), sess, "vol-1", time.Millisecond, time.Second, logger))
+	assert.Equal(t, 3, sess.GetVolumeCallCount())
+
+	// Volume stays in deleting state
+	sess = &fake.FakeSession{}
+	sess.GetVolumeReturns(deleting, nil)
+	err := WaitForVolumeDeletion(context.Background(), sess, "vol-1", time.Millisecond, 10*time.Millisecond, logger)
+	assert.True(t, errors.Is(err, provider.ErrDeletionStuck))
+	assert.Equal(t, provider.VolumeLifecycleStateDeleting, err.(provider.Error).Properties()["LifecycleState"])
+}
+
+func TestWaitForVolumeDeletionCancelled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := NewWaiterRegistry()
+	ctx := WithWaiterRegistry(context.Background(), registry)
+	sess := &fake.FakeSession{}
+	sess.GetVolumeStub = func(id string) (*provider.Volume, error) {
+		if pending := registry.Pending(); len(pending) == 1 {
+			assert.Equal(t, "WaitForVolumeDeletion", pending[0].Operation)
+			assert.Equal(t, id, pending[0].ResourceID)
+			assert.Nil(t, registry.CancelWaiter(pending[0].ID))
+		}
+		return &provider.Volume{VolumeID: id}, nil
+	}
+	err := WaitForVolumeDeletion(ctx, sess, "vol-1", time.Millisecond, time.Minute, logger)
+	assert.True(t, errors.Is(err, provider.ErrWaiterCancelled))
+	assert.Equal(t, 1, sess.GetVolumeCallCount())
+	assert.Empty(t, registry.Pending())
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.False(t, IsNotFound(nil))
+	assert.False(t, IsNotFound(errors.New("not found")))
+	assert.True(t, IsNotFound(Message{Type: EntityNotFound}))
+}
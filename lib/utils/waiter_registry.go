@@ -0,0 +1,148 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/ctxkeys"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// WaiterInfo describes a pending wait for an operation
+type WaiterInfo struct {
+	ID         string    `json:"id"`
+	Operation  string    `json:"operation"`
+	ResourceID string    `json:"resourceID,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+}
+
+type pendingWaiter struct {
+	info      WaiterInfo
+	cancel    context.CancelFunc
+	cancelled int32
+}
+
+// WaiterRegistry keeps the set of pending waits so that drivers can expose them, e.g. on an admin endpoint,
+// and cancel a wait which is stuck during incident response
+type WaiterRegistry struct {
+	nextID  uint64
+	mu      sync.Mutex
+	waiters map[string]*pendingWaiter
+}
+
+var waiterRegistryKey = ctxkeys.NewKey[*WaiterRegistry]("waiter-registry")
+
+// NewWaiterRegistry returns an empty registry
+func NewWaiterRegistry() *WaiterRegistry {
+	return &WaiterRegistry{waiters: map[string]*pendingWaiter{}}
+}
+
+// WithWaiterRegistry returns a context registering the waits performed with it in the registry
+func WithWaiterRegistry(ctx context.Context, registry *WaiterRegistry) context.Context {
+	return waiterRegistryKey.WithValue(ctx, registry)
+}
+
+// WaiterRegistryFromContext returns the registry attached to the context, if any
+func WaiterRegistryFromContext(ctx context.Context) (*WaiterRegistry, bool) {
+	return waiterRegistryKey.Value(ctx)
+}
+
+// Pending returns the pending waits, oldest first
+func (r *WaiterRegistry) Pending() []WaiterInfo {
+	r.mu.Lock()
+	pending := make([]WaiterInfo, 0, len(r.waiters))
+	for _, waiter := range r.waiters {
+		pending = append(pending, waiter.info)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].StartedAt.Equal(pending[j].StartedAt) {
+			return pending[i].ID < pending[j].ID
+		}
+		return pending[i].StartedAt.Before(pending[j].StartedAt)
+	})
+	return pending
+}
+
+// CancelWaiter cancels the pending wait, which returns a provider.ErrWaiterCancelled error. An
+// ErrorResourceNotFound error is returned if there is no such pending wait
+func (r *WaiterRegistry) CancelWaiter(id string) error {
+	r.mu.Lock()
+	waiter, ok := r.waiters[id]
+	r.mu.Unlock()
+	if !ok {
+		return NewError(reasoncode.ErrorResourceNotFound, fmt.Sprintf("No pending waiter %s", id))
+	}
+	atomic.StoreInt32(&waiter.cancelled, 1)
+	waiter.cancel()
+	return nil
+}
+
+func (r *WaiterRegistry) register(ctx context.Context, operation, resourceID string) (context.Context, *pendingWaiter, func()) {
+	waitCtx, cancel := context.WithCancel(ctx)
+	waiter := &pendingWaiter{
+		info: WaiterInfo{
+			ID:         "waiter-" + strconv.FormatUint(atomic.AddUint64(&r.nextID, 1), 10),
+			Operation:  operation,
+			ResourceID: resourceID,
+			StartedAt:  time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	r.mu.Lock()
+	r.waiters[waiter.info.ID] = waiter
+	r.mu.Unlock()
+
+	return waitCtx, waiter, func() {
+		r.mu.Lock()
+		delete(r.waiters, waiter.info.ID)
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// RunWaiter runs the wait for the operation on the resource, registered in the registry attached to the
+// context (if any) while it is pending. fn must return when its context is done, a wait cancelled with
+// CancelWaiter returns a provider.ErrWaiterCancelled error
+func RunWaiter[T any](ctx context.Context, operation, resourceID string, fn func(ctx context.Context) (T, error)) (T, error) {
+	registry, ok := WaiterRegistryFromContext(ctx)
+	if !ok || registry == nil {
+		return fn(ctx)
+	}
+
+	waitCtx, waiter, done := registry.register(ctx, operation, resourceID)
+	defer done()
+
+	value, err := fn(waitCtx)
+	if atomic.LoadInt32(&waiter.cancelled) == 1 && ctx.Err() == nil {
+		var zero T
+		return zero, NewErrorWithProperties(reasoncode.ErrorWaiterCancelled,
+			fmt.Sprintf("Wait for %s of %s was cancelled", operation, resourceID),
+			map[string]string{OperationProperty: operation, "ResourceID": resourceID})
+	}
+	return value, err
+}
@@ -0,0 +1,108 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWaiterWithoutRegistry(t *testing.T) {
+	value, err := RunWaiter(context.Background(), "WaitForAttachVolume", "vol-1", func(ctx context.Context) (string, error) {
+		return "attached", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "attached", value)
+}
+
+func TestWaiterRegistryPendingAndCancel(t *testing.T) {
+	registry := NewWaiterRegistry()
+	ctx := WithWaiterRegistry(context.Background(), registry)
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, err := RunWaiter(ctx, "WaitForDetachVolume", "vol-1", func(ctx context.Context) (struct{}, error) {
+			close(started)
+			<-ctx.Done()
+			return struct{}{}, ctx.Err()
+		})
+		result <- err
+	}()
+	<-started
+
+	pending := registry.Pending()
+	assert.Equal(t, 1, len(pending))
+	assert.Equal(t, "WaitForDetachVolume", pending[0].Operation)
+	assert.Equal(t, "vol-1", pending[0].ResourceID)
+	assert.False(t, pending[0].StartedAt.IsZero())
+
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, ErrorReasonCode(registry.CancelWaiter("waiter-unknown")))
+	assert.Nil(t, registry.CancelWaiter(pending[0].ID))
+
+	err := <-result
+	assert.True(t, errors.Is(err, provider.ErrWaiterCancelled))
+	assert.Equal(t, 0, len(registry.Pending()))
+}
+
+func TestWaiterRegistryCallerCancel(t *testing.T) {
+	registry := NewWaiterRegistry()
+	ctx, cancel := context.WithCancel(WithWaiterRegistry(context.Background(), registry))
+	cancel()
+
+	_, err := RunWaiter(ctx, "WaitForAttachVolume", "vol-1", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, ctx.Err()
+	})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, len(registry.Pending()))
+}
+
+func TestContextSessionWaiterCancel(t *testing.T) {
+	sess := &fake.FakeSession{}
+	release := make(chan struct{})
+	defer close(release)
+	sess.WaitForAttachVolumeStub = func(provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+		<-release
+		return &provider.VolumeAttachmentResponse{Status: "attached"}, nil
+	}
+	registry := NewWaiterRegistry()
+	ctx := WithWaiterRegistry(context.Background(), registry)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := NewContextSession(sess).WaitForAttachVolumeWithContext(ctx, provider.VolumeAttachmentRequest{VolumeID: "vol-1", InstanceID: "inst-1"})
+		result <- err
+	}()
+
+	var pending []WaiterInfo
+	for i := 0; i < 100 && len(pending) == 0; i++ {
+		time.Sleep(5 * time.Millisecond)
+		pending = registry.Pending()
+	}
+	assert.Equal(t, 1, len(pending))
+	assert.Equal(t, "WaitForAttachVolume", pending[0].Operation)
+	assert.Nil(t, registry.CancelWaiter(pending[0].ID))
+	assert.Equal(t, reasoncode.ErrorWaiterCancelled, ErrorReasonCode(<-result))
+}
@@ -0,0 +1,92 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// ZoneSemaphore bounds the number of concurrent operations per zone
+type ZoneSemaphore struct {
+	limit int
+
+	mu    sync.Mutex
+	zones map[string]chan struct{}
+}
+
+// NewZoneSemaphore returns a semaphore allowing limit concurrent operations per zone, a non-positive limit is unbounded
+func NewZoneSemaphore(limit int) *ZoneSemaphore {
+	return &ZoneSemaphore{limit: limit, zones: make(map[string]chan struct{})}
+}
+
+func (s *ZoneSemaphore) slots(zone string) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	slots, ok := s.zones[zone]
+	if !ok {
+		slots = make(chan struct{}, s.limit)
+		s.zones[zone] = slots
+	}
+	return slots
+}
+
+// Acquire blocks until a slot of the zone is available or ctx is done. The returned release function must be
+// called once the operation completes. It is safe to call on a nil semaphore
+func (s *ZoneSemaphore) Acquire(ctx context.Context, zone string) (func(), error) {
+	if s == nil || s.limit <= 0 {
+		return func() {}, nil
+	}
+	slots := s.slots(zone)
+	select {
+	case slots <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-slots }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InFlight returns the number of operations holding a slot of the zone
+func (s *ZoneSemaphore) InFlight(zone string) int {
+	if s == nil || s.limit <= 0 {
+		return 0
+	}
+	return len(s.slots(zone))
+}
+
+// AttachVolumeWithZoneLimit attaches the volume once a slot of the zone is available. In dry run (see WithDryRun)
+// the attach is only planned and the request is echoed in the response
+func AttachVolumeWithZoneLimit(ctx context.Context, sess provider.VolumeAttachManager, semaphore *ZoneSemaphore, zone string, attachRequest provider.VolumeAttachmentRequest, logger *zap.Logger) (*provider.VolumeAttachmentResponse, error) {
+	response := &provider.VolumeAttachmentResponse{VolumeAttachmentRequest: attachRequest}
+	err := RunMutation(ctx, "AttachVolume", attachRequest.VolumeID, map[string]string{"instanceID": attachRequest.InstanceID, "zone": zone}, func() error {
+		release, err := semaphore.Acquire(ctx, zone)
+		if err != nil {
+			logger.Warn("Gave up waiting for an attach slot in zone", zap.String("Zone", zone), zap.String("VolumeID", attachRequest.VolumeID), ZapError(err))
+			response = nil
+			return err
+		}
+		defer release()
+		response, err = sess.AttachVolume(attachRequest)
+		return err
+	})
+	return response, err
+}
@@ -0,0 +1,77 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package util ...
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestZoneSemaphore(t *testing.T) {
+	semaphore := NewZoneSemaphore(1)
+
+	release, err := semaphore.Acquire(context.Background(), "us-south-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, semaphore.InFlight("us-south-1"))
+
+	// other zones are not limited by us-south-1
+	releaseOther, err := semaphore.Acquire(context.Background(), "us-south-2")
+	assert.Nil(t, err)
+	releaseOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = semaphore.Acquire(ctx, "us-south-1")
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	release()
+	release()
+	assert.Equal(t, 0, semaphore.InFlight("us-south-1"))
+
+	var unbounded *ZoneSemaphore
+	release, err = unbounded.Acquire(context.Background(), "us-south-1")
+	assert.Nil(t, err)
+	release()
+	assert.Equal(t, 0, NewZoneSemaphore(0).InFlight("us-south-1"))
+}
+
+func TestAttachVolumeWithZoneLimit(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	sess := &fake.FakeSession{}
+	sess.AttachVolumeReturns(&provider.VolumeAttachmentResponse{Status: "attaching"}, nil)
+	semaphore := NewZoneSemaphore(1)
+
+	response, err := AttachVolumeWithZoneLimit(context.Background(), sess, semaphore, "us-south-1", provider.VolumeAttachmentRequest{VolumeID: "vol-1"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "attaching", response.Status)
+	assert.Equal(t, 0, semaphore.InFlight("us-south-1"))
+
+	release, _ := semaphore.Acquire(context.Background(), "us-south-1")
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = AttachVolumeWithZoneLimit(ctx, sess, semaphore, "us-south-1", provider.VolumeAttachmentRequest{VolumeID: "vol-2"}, logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, sess.AttachVolumeCallCount())
+}
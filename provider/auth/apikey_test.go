@@ -23,17 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
-	"go.uber.org/zap"
 )
 
-var (
-	logger *zap.Logger
-)
-
-func init() {
-	logger, _ = zap.NewDevelopment()
-}
-
 func TestForIaaSAPIKey(t *testing.T) {
 	account := "account1"
 	username := "user1"
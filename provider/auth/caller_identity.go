@@ -0,0 +1,44 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+)
+
+// GetCallerIdentity returns the IAM identity (service ID, user or trusted profile, and account) behind the
+// API key, so logs, audit records and preflight reports can confirm which identity the driver uses
+func (ccf *ContextCredentialsFactory) GetCallerIdentity(ctx context.Context, apiKey string, logger *zap.Logger) (*iam.CallerIdentity, error) {
+	accessToken, err := iam.ExchangeAPIKeyForAccessToken(ctx, ccf.TokenExchangeService, apiKey, logger)
+	if err != nil {
+		logger.Error("Unable to retrieve IAM access token from IAM API key", local.ZapError(err))
+		return nil, err
+	}
+	identity, err := iam.CallerIdentityFromAccessToken(*accessToken)
+	if err != nil {
+		logger.Error("Unable to retrieve the caller identity from the IAM access token", local.ZapError(err))
+		return nil, err
+	}
+	logger.Info("Resolved caller identity", zap.String("identity", identity.String()))
+	return identity, nil
+}
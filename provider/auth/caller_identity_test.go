@@ -0,0 +1,62 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
+)
+
+type fakeTokenExchangeService struct {
+	iam.TokenExchangeService
+	accessToken *iam.AccessToken
+	err         error
+}
+
+func (f *fakeTokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey string, logger *zap.Logger) (*iam.AccessToken, error) {
+	return f.accessToken, f.err
+}
+
+func TestGetCallerIdentity(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iam_id":   "iam-ServiceId-1",
+		"sub_type": "ServiceId",
+		"account":  map[string]interface{}{"bss": "acc1"},
+	}).SignedString([]byte("aabbccdd"))
+
+	ccf := &ContextCredentialsFactory{TokenExchangeService: &fakeTokenExchangeService{accessToken: &iam.AccessToken{Token: token}}}
+	identity, err := ccf.GetCallerIdentity(context.Background(), "apikey", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, &iam.CallerIdentity{IAMID: "iam-ServiceId-1", Type: iam.IdentityTypeServiceID, AccountID: "acc1"}, identity)
+
+	ccf = &ContextCredentialsFactory{TokenExchangeService: &fakeTokenExchangeService{err: errors.New("exchange failed")}}
+	_, err = ccf.GetCallerIdentity(context.Background(), "apikey", logger)
+	assert.NotNil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ccf.GetCallerIdentity(ctx, "apikey", logger)
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,97 @@
+//go:build !nosoftlayer
+// +build !nosoftlayer
+
+/**
+ * Copyright 2020 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/deprecation"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// ForIaaSAPIKey ...
+func (ccf *ContextCredentialsFactory) ForIaaSAPIKey(iamAccountID, userid, apikey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{
+		AuthType:     provider.IaaSAPIKey,
+		IAMAccountID: iamAccountID,
+		UserID:       userid,
+		Credential:   apikey,
+	}, nil
+}
+
+// ForRefreshToken ...
+// Deprecated: refresh token authentication is reported to the deprecation hooks, use ForIAMAPIKey
+func (ccf *ContextCredentialsFactory) ForRefreshToken(refreshToken string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return ccf.ForRefreshTokenWithContext(context.Background(), refreshToken, logger)
+}
+
+// ForRefreshTokenWithContext is ForRefreshToken honoring the deadline and cancellation of ctx
+func (ccf *ContextCredentialsFactory) ForRefreshTokenWithContext(ctx context.Context, refreshToken string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	deprecation.Use(deprecation.KindInterface, "auth.ContextCredentialsFactory.ForRefreshToken")
+	accessToken, err := iam.ExchangeRefreshToken(ctx, ccf.TokenExchangeService, refreshToken, logger)
+	if err != nil {
+		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
+		logger.Error("Unable to retrieve access token from refresh token", local.ZapError(err))
+		return provider.ContextCredentials{}, err
+	}
+
+	imsToken, err := iam.ExchangeAccessToken(ctx, ccf.TokenExchangeService, *accessToken, logger)
+	if err != nil {
+		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
+		logger.Error("Unable to retrieve IAM token from access token", local.ZapError(err))
+		return provider.ContextCredentials{}, err
+	}
+
+	return forIMSToken("", imsToken), nil
+}
+
+// ForIAMAPIKey ...
+func (ccf *ContextCredentialsFactory) ForIAMAPIKey(iamAccountID, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return ccf.ForIAMAPIKeyWithContext(context.Background(), iamAccountID, apiKey, logger)
+}
+
+// ForIAMAPIKeyWithContext is ForIAMAPIKey honoring the deadline and cancellation of ctx
+func (ccf *ContextCredentialsFactory) ForIAMAPIKeyWithContext(ctx context.Context, iamAccountID, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	imsToken, err := iam.ExchangeAPIKeyForIMSToken(ctx, ccf.TokenExchangeService, apiKey, logger)
+	if err != nil {
+		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
+		logger.Error("Unable to retrieve IMS credentials from IAM API key", local.ZapError(err))
+		return provider.ContextCredentials{}, err
+	}
+
+	return forIMSToken(iamAccountID, imsToken), nil
+}
+
+// forIMSToken ...
+func forIMSToken(iamAccountID string, imsToken *iam.IMSToken) provider.ContextCredentials {
+	return provider.ContextCredentials{
+		AuthType:     IMSToken,
+		IAMAccountID: iamAccountID,
+		UserID:       strconv.Itoa(imsToken.UserID),
+		Credential:   imsToken.Token,
+	}
+}
@@ -0,0 +1,54 @@
+//go:build nosoftlayer
+// +build nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// ForIaaSAPIKey always returns ErrClassicNotCompiled with the nosoftlayer build tag
+func (ccf *ContextCredentialsFactory) ForIaaSAPIKey(iamAccountID, userid, apikey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrClassicNotCompiled
+}
+
+// ForRefreshToken always returns ErrClassicNotCompiled with the nosoftlayer build tag
+func (ccf *ContextCredentialsFactory) ForRefreshToken(refreshToken string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrClassicNotCompiled
+}
+
+// ForRefreshTokenWithContext always returns ErrClassicNotCompiled with the nosoftlayer build tag
+func (ccf *ContextCredentialsFactory) ForRefreshTokenWithContext(ctx context.Context, refreshToken string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrClassicNotCompiled
+}
+
+// ForIAMAPIKey always returns ErrClassicNotCompiled with the nosoftlayer build tag, use ForIAMAccessToken
+func (ccf *ContextCredentialsFactory) ForIAMAPIKey(iamAccountID, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrClassicNotCompiled
+}
+
+// ForIAMAPIKeyWithContext always returns ErrClassicNotCompiled with the nosoftlayer build tag
+func (ccf *ContextCredentialsFactory) ForIAMAPIKeyWithContext(ctx context.Context, iamAccountID, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrClassicNotCompiled
+}
@@ -0,0 +1,38 @@
+//go:build nosoftlayer
+// +build nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassicNotCompiled(t *testing.T) {
+	ccf := &ContextCredentialsFactory{}
+	_, err := ccf.ForIaaSAPIKey("account1", "user1", "abcdefg", logger)
+	assert.Equal(t, ErrClassicNotCompiled, err)
+	_, err = ccf.ForIAMAPIKey("account1", "abcdefg", logger)
+	assert.Equal(t, ErrClassicNotCompiled, err)
+	_, err = ccf.ForRefreshTokenWithContext(context.Background(), "token", logger)
+	assert.Equal(t, ErrClassicNotCompiled, err)
+}
@@ -30,7 +30,7 @@ type ContextCredentialsFactory struct {
 
 var _ local.ContextCredentialsFactory = &ContextCredentialsFactory{}
 
-// NewContextCredentialsFactory ...
+// NewContextCredentialsFactory returns a factory exchanging the API keys through the SharedTokenCache of the process
 func NewContextCredentialsFactory(authConfig *iam.AuthConfiguration, k8sClient *k8s_utils.KubernetesClient, providerType ...string) (*ContextCredentialsFactory, error) {
 	var tokenExchangeService iam.TokenExchangeService
 
@@ -39,6 +39,6 @@ func NewContextCredentialsFactory(authConfig *iam.AuthConfiguration, k8sClient *
 		return nil, err
 	}
 	return &ContextCredentialsFactory{
-		TokenExchangeService: tokenExchangeService,
+		TokenExchangeService: iam.NewCachingTokenExchangeService(tokenExchangeService, iam.SharedTokenCache()),
 	}, nil
 }
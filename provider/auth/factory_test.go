@@ -18,7 +18,11 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -26,8 +30,17 @@ import (
 	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
 	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
+var (
+	logger *zap.Logger
+)
+
+func init() {
+	logger, _ = zap.NewDevelopment()
+}
+
 func TestNewContextCredentialsFactory(t *testing.T) {
 	// Pass without k8s client
 	authConfig := &iam.AuthConfiguration{
@@ -44,7 +57,38 @@ func TestNewContextCredentialsFactory(t *testing.T) {
 	pwd, _ := os.Getwd()
 	file := filepath.Join(pwd, "..", "..", "etc", "libconfig.toml")
 	err = k8s_utils.FakeCreateSecret(k8sClient, "DEFAULT", file)
-	_, err = NewContextCredentialsFactory(authConfig, &k8sClient)
+	factory, err := NewContextCredentialsFactory(authConfig, &k8sClient)
 	fmt.Println(err)
 	assert.Nil(t, err)
+	assert.Equal(t, "*iam.cachingTokenExchangeService", fmt.Sprintf("%T", factory.TokenExchangeService))
+}
+
+func TestNewContextCredentialsFactoryTrustedProfile(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "profile-token", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	k8sClient, _ := k8s_utils.FakeGetk8sClientSet()
+	pwd, _ := os.Getwd()
+	err := k8s_utils.FakeCreateSecret(k8sClient, "DEFAULT", filepath.Join(pwd, "..", "..", "etc", "libconfig.toml"))
+	assert.Nil(t, err)
+	authConfig := &iam.AuthConfiguration{IamURL: server.URL, IamClientID: "test", IamClientSecret: "secret"}
+	factory, err := NewContextCredentialsFactory(authConfig, &k8sClient)
+	assert.Nil(t, err)
+
+	// the compute resource tokens are exchanged through the caching service of the factory
+	crTokenFile := filepath.Join(t.TempDir(), "cr-token")
+	assert.Nil(t, os.WriteFile(crTokenFile, []byte("cr-1"), 0600))
+	authenticator, err := factory.NewTrustedProfileAuthenticator("Profile-1", crTokenFile)
+	assert.Nil(t, err)
+	accessToken, err := authenticator.AccessToken(context.Background(), logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "profile-token", accessToken.Token)
+	assert.Equal(t, "cr-1", form.Get("cr_token"))
+	assert.Equal(t, "Profile-1", form.Get("profile_id"))
 }
@@ -18,7 +18,8 @@
 package auth
 
 import (
-	"strconv"
+	"context"
+	"errors"
 
 	"go.uber.org/zap"
 
@@ -28,6 +29,10 @@ import (
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 )
 
+// ErrClassicNotCompiled is returned by the classic infrastructure (IMS token and IaaS API key) credentials
+// with the nosoftlayer build tag
+var ErrClassicNotCompiled = errors.New("classic infrastructure (Softlayer) credentials are not compiled in, build without -tags nosoftlayer")
+
 const (
 	// IMSToken is an IMS user ID and token
 	IMSToken = provider.AuthType("IMS_TOKEN")
@@ -35,40 +40,14 @@ const (
 	IAMAccessToken = provider.AuthType("IAM_ACCESS_TOKEN")
 )
 
-// ForRefreshToken ...
-func (ccf *ContextCredentialsFactory) ForRefreshToken(refreshToken string, logger *zap.Logger) (provider.ContextCredentials, error) {
-	accessToken, err := ccf.TokenExchangeService.ExchangeRefreshTokenForAccessToken(refreshToken, logger)
-	if err != nil {
-		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
-		logger.Error("Unable to retrieve access token from refresh token", local.ZapError(err))
-		return provider.ContextCredentials{}, err
-	}
-
-	imsToken, err := ccf.TokenExchangeService.ExchangeAccessTokenForIMSToken(*accessToken, logger)
-	if err != nil {
-		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
-		logger.Error("Unable to retrieve IAM token from access token", local.ZapError(err))
-		return provider.ContextCredentials{}, err
-	}
-
-	return forIMSToken("", imsToken), nil
-}
-
-// ForIAMAPIKey ...
-func (ccf *ContextCredentialsFactory) ForIAMAPIKey(iamAccountID, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
-	imsToken, err := ccf.TokenExchangeService.ExchangeIAMAPIKeyForIMSToken(apiKey, logger)
-	if err != nil {
-		// Must preserve provider error code in the ErrorProviderAccountTemporarilyLocked case
-		logger.Error("Unable to retrieve IMS credentials from IAM API key", local.ZapError(err))
-		return provider.ContextCredentials{}, err
-	}
-
-	return forIMSToken(iamAccountID, imsToken), nil
-}
-
 // ForIAMAccessToken ...
 func (ccf *ContextCredentialsFactory) ForIAMAccessToken(apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
-	iamAccessToken, err := ccf.TokenExchangeService.ExchangeIAMAPIKeyForAccessToken(apiKey, logger)
+	return ccf.ForIAMAccessTokenWithContext(context.Background(), apiKey, logger)
+}
+
+// ForIAMAccessTokenWithContext is ForIAMAccessToken honoring the deadline and cancellation of ctx
+func (ccf *ContextCredentialsFactory) ForIAMAccessTokenWithContext(ctx context.Context, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	iamAccessToken, err := iam.ExchangeAPIKeyForAccessToken(ctx, ccf.TokenExchangeService, apiKey, logger)
 	if err != nil {
 		logger.Error("Unable to retrieve IAM access token from IAM API key", local.ZapError(err))
 		return provider.ContextCredentials{}, err
@@ -82,16 +61,6 @@ func (ccf *ContextCredentialsFactory) ForIAMAccessToken(apiKey string, logger *z
 	return forIAMAccessToken(iamAccountID, iamAccessToken), nil
 }
 
-// forIMSToken ...
-func forIMSToken(iamAccountID string, imsToken *iam.IMSToken) provider.ContextCredentials {
-	return provider.ContextCredentials{
-		AuthType:     IMSToken,
-		IAMAccountID: iamAccountID,
-		UserID:       strconv.Itoa(imsToken.UserID),
-		Credential:   imsToken.Token,
-	}
-}
-
 // forIAMAccessToken ...
 func forIAMAccessToken(iamAccountID string, iamAccessToken *iam.AccessToken) provider.ContextCredentials {
 	return provider.ContextCredentials{
@@ -0,0 +1,147 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+)
+
+const (
+	// DefaultCRTokenFilePath is the default path of the compute resource token projected in the pod
+	DefaultCRTokenFilePath = "/var/run/secrets/tokens/vault-token"
+
+	// trustedProfileRefreshMargin is how long before its expiry the access token is exchanged again, at most half
+	// of the lifetime of the token so that short lived tokens are still cached
+	trustedProfileRefreshMargin = 5 * time.Minute
+
+	// trustedProfileTokenTTL is the lifetime assumed for an access token without expiry
+	trustedProfileTokenTTL = 50 * time.Minute
+)
+
+// TrustedProfileAuthenticator authenticates as an IBM Cloud trusted profile with the compute resource (CR) token
+// mounted in the pod, so no long lived API key is needed. The CR token file is read again on every exchange,
+// as the kubelet rotates it, and the access token is exchanged again shortly before its expiry
+type TrustedProfileAuthenticator struct {
+	profileID       string
+	crTokenFilePath string
+	tes             iam.CRTokenExchangeService
+
+	mu          sync.Mutex
+	accessToken *iam.AccessToken
+	expiresAt   time.Time
+	refreshAt   time.Time
+}
+
+// NewTrustedProfileAuthenticator returns an authenticator of the trusted profile, reading the CR token from
+// crTokenFilePath (DefaultCRTokenFilePath if empty)
+func NewTrustedProfileAuthenticator(profileID, crTokenFilePath string, tes iam.CRTokenExchangeService) (*TrustedProfileAuthenticator, error) {
+	if profileID == "" {
+		return nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, "Trusted profile ID is required")
+	}
+	if tes == nil {
+		return nil, util.NewError(reasoncode.ErrorUnsupportedAuthType, "Token exchange service does not support compute resource tokens")
+	}
+	if crTokenFilePath == "" {
+		crTokenFilePath = DefaultCRTokenFilePath
+	}
+	return &TrustedProfileAuthenticator{profileID: profileID, crTokenFilePath: crTokenFilePath, tes: tes}, nil
+}
+
+// readCRToken reads the current CR token from the token file
+func (a *TrustedProfileAuthenticator) readCRToken() (string, error) {
+	data, err := os.ReadFile(a.crTokenFilePath)
+	if err != nil {
+		return "", util.NewError(reasoncode.ErrorFailedTokenExchange, "Unable to read the compute resource token file "+a.crTokenFilePath, err)
+	}
+	crToken := strings.TrimSpace(string(data))
+	if crToken == "" {
+		return "", util.NewError(reasoncode.ErrorFailedTokenExchange, "Compute resource token file "+a.crTokenFilePath+" is empty")
+	}
+	return crToken, nil
+}
+
+// AccessToken returns the access token of the trusted profile, exchanging the CR token if the token is missing
+// or about to expire
+func (a *TrustedProfileAuthenticator) AccessToken(ctx context.Context, logger *zap.Logger) (*iam.AccessToken, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.accessToken != nil && time.Now().Before(a.refreshAt) {
+		return a.accessToken, nil
+	}
+
+	crToken, err := a.readCRToken()
+	if err != nil {
+		logger.Error("Unable to read the compute resource token", local.ZapError(err))
+		return nil, err
+	}
+	accessToken, err := a.tes.ExchangeCRTokenForAccessToken(ctx, crToken, a.profileID, logger)
+	if err != nil {
+		logger.Error("Unable to exchange the compute resource token for the trusted profile", zap.String("ProfileID", a.profileID), local.ZapError(err))
+		return nil, err
+	}
+	now := time.Now()
+	a.accessToken = accessToken
+	a.expiresAt = accessToken.ExpiresAt
+	if a.expiresAt.IsZero() {
+		a.expiresAt = now.Add(trustedProfileTokenTTL)
+	}
+	margin := trustedProfileRefreshMargin
+	if half := a.expiresAt.Sub(now) / 2; half < margin {
+		margin = half
+	}
+	a.refreshAt = a.expiresAt.Add(-margin)
+	logger.Info("Exchanged compute resource token for the trusted profile", zap.String("ProfileID", a.profileID), zap.Time("ExpiresAt", a.expiresAt))
+	return accessToken, nil
+}
+
+// NewTrustedProfileAuthenticator returns an authenticator of the trusted profile with the token exchange
+// service of the factory
+func (ccf *ContextCredentialsFactory) NewTrustedProfileAuthenticator(profileID, crTokenFilePath string) (*TrustedProfileAuthenticator, error) {
+	tes, _ := ccf.TokenExchangeService.(iam.CRTokenExchangeService)
+	return NewTrustedProfileAuthenticator(profileID, crTokenFilePath, tes)
+}
+
+// ForTrustedProfile returns the IAM access token credentials of the trusted profile
+func (ccf *ContextCredentialsFactory) ForTrustedProfile(ctx context.Context, authenticator *TrustedProfileAuthenticator, logger *zap.Logger) (provider.ContextCredentials, error) {
+	if authenticator == nil {
+		return provider.ContextCredentials{}, errors.New("trusted profile authenticator is required")
+	}
+	accessToken, err := authenticator.AccessToken(ctx, logger)
+	if err != nil {
+		return provider.ContextCredentials{}, err
+	}
+	iamAccountID, err := ccf.TokenExchangeService.GetIAMAccountIDFromAccessToken(*accessToken, logger)
+	if err != nil {
+		logger.Error("Unable to retrieve IAM account ID from the trusted profile access token", local.ZapError(err))
+		return provider.ContextCredentials{}, err
+	}
+	return forIAMAccessToken(iamAccountID, accessToken), nil
+}
@@ -0,0 +1,104 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/IBM/ibmcloud-volume-interface/provider/iam"
+)
+
+type fakeCRTokenExchangeService struct {
+	fakeTokenExchangeService
+	crTokens  []string
+	expiresIn time.Duration
+}
+
+func (f *fakeCRTokenExchangeService) ExchangeCRTokenForAccessToken(ctx context.Context, crToken, profileID string, logger *zap.Logger) (*iam.AccessToken, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.crTokens = append(f.crTokens, crToken)
+	return &iam.AccessToken{Token: "access-" + crToken, ExpiresAt: time.Now().Add(f.expiresIn)}, nil
+}
+
+func (f *fakeCRTokenExchangeService) GetIAMAccountIDFromAccessToken(accessToken iam.AccessToken, logger *zap.Logger) (string, error) {
+	return "account-1", nil
+}
+
+func TestTrustedProfileAuthenticator(t *testing.T) {
+	crTokenFile := filepath.Join(t.TempDir(), "cr-token")
+	assert.Nil(t, os.WriteFile(crTokenFile, []byte("cr-1\n"), 0600))
+
+	tes := &fakeCRTokenExchangeService{expiresIn: time.Hour}
+	ccf := &ContextCredentialsFactory{TokenExchangeService: tes}
+	authenticator, err := ccf.NewTrustedProfileAuthenticator("Profile-1", crTokenFile)
+	assert.Nil(t, err)
+
+	credentials, err := ccf.ForTrustedProfile(context.Background(), authenticator, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, IAMAccessToken, credentials.AuthType)
+	assert.Equal(t, "account-1", credentials.IAMAccountID)
+	assert.Equal(t, "access-cr-1", credentials.Credential)
+
+	// cached until about to expire
+	assert.Nil(t, os.WriteFile(crTokenFile, []byte("cr-2"), 0600))
+	token, err := authenticator.AccessToken(context.Background(), logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "access-cr-1", token.Token)
+
+	// the rotated CR token is read again on the next exchange
+	tes.expiresIn = time.Minute
+	authenticator.refreshAt = time.Now()
+	token, _ = authenticator.AccessToken(context.Background(), logger)
+	assert.Equal(t, "access-cr-2", token.Token)
+
+	// a token living less than twice the refresh margin is still cached for half of its lifetime
+	token, _ = authenticator.AccessToken(context.Background(), logger)
+	assert.Equal(t, []string{"cr-1", "cr-2"}, tes.crTokens)
+	assert.Equal(t, "access-cr-2", token.Token)
+	assert.WithinDuration(t, authenticator.expiresAt.Add(-30*time.Second), authenticator.refreshAt, time.Second)
+}
+
+func TestTrustedProfileAuthenticatorErrors(t *testing.T) {
+	_, err := NewTrustedProfileAuthenticator("", "", &fakeCRTokenExchangeService{})
+	assert.NotNil(t, err)
+
+	ccf := &ContextCredentialsFactory{TokenExchangeService: &fakeTokenExchangeService{}}
+	_, err = ccf.NewTrustedProfileAuthenticator("Profile-1", "")
+	assert.NotNil(t, err)
+
+	authenticator, err := NewTrustedProfileAuthenticator("Profile-1", filepath.Join(t.TempDir(), "missing"), &fakeCRTokenExchangeService{})
+	assert.Nil(t, err)
+	_, err = authenticator.AccessToken(context.Background(), logger)
+	assert.NotNil(t, err)
+
+	crTokenFile := filepath.Join(t.TempDir(), "cr-token")
+	assert.Nil(t, os.WriteFile(crTokenFile, []byte("cr-1"), 0600))
+	authenticator, _ = NewTrustedProfileAuthenticator("Profile-1", crTokenFile, &fakeCRTokenExchangeService{fakeTokenExchangeService: fakeTokenExchangeService{err: errors.New("profile not found")}})
+	_, err = authenticator.AccessToken(context.Background(), logger)
+	assert.NotNil(t, err)
+}
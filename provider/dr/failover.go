@@ -0,0 +1,176 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dr helps scripting regional disaster recovery runbooks: re-pointing a provider session at the failover
+// region and rehydrating the volume handles which reference region scoped IDs
+package dr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+// Region is the endpoint set and resource group of the failover region
+type Region struct {
+	// Name of the region, e.g. us-east
+	Name string `json:"name"`
+
+	EndpointURL        string `json:"endpointURL"`
+	PrivateEndpointURL string `json:"privateEndpointURL,omitempty"`
+	TokenExchangeURL   string `json:"tokenExchangeURL,omitempty"`
+
+	// ZoneEndpoints of the region, the zone endpoints of the primary region are dropped
+	ZoneEndpoints map[string]string `json:"zoneEndpoints,omitempty"`
+
+	// ResourceGroupID in the region, resolved by the ResourceGroupResolver of the Failover if empty
+	ResourceGroupID string `json:"resourceGroupID,omitempty"`
+}
+
+// ResourceGroupResolver returns the resource group of the failover region, e.g. looked up by name
+type ResourceGroupResolver func(ctx context.Context, region Region) (string, error)
+
+// ProviderFactory returns the provider using the configuration
+type ProviderFactory func(conf *config.Config, logger *zap.Logger) (local.Provider, error)
+
+// CredentialsFunc returns the credentials of the session opened in the failover region
+type CredentialsFunc func(ctx context.Context, p local.Provider, conf *config.Config, logger *zap.Logger) (provider.ContextCredentials, error)
+
+// FailoverConfig returns a copy of the configuration pointed at the region, conf is not modified. The VPC and G2
+// endpoints are swapped, VPE is disabled as VPE gateways are region scoped, and the resource group is replaced
+// when the region has one
+func FailoverConfig(conf *config.Config, region Region) (*config.Config, error) {
+	if conf == nil || conf.VPC == nil {
+		return nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, "VPC configuration is required to fail over")
+	}
+	if region.EndpointURL == "" {
+		return nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, fmt.Sprintf("Endpoint of the failover region %s is required", region.Name))
+	}
+
+	failover := conf.Clone()
+	vpc := failover.VPC
+	vpc.EndpointURL = region.EndpointURL
+	vpc.G2EndpointURL = region.EndpointURL
+	vpc.PrivateEndpointURL = region.PrivateEndpointURL
+	vpc.G2EndpointPrivateURL = region.PrivateEndpointURL
+	if region.TokenExchangeURL != "" {
+		vpc.TokenExchangeURL = region.TokenExchangeURL
+		vpc.G2TokenExchangeURL = region.TokenExchangeURL
+	}
+	vpc.ZoneEndpoints = region.ZoneEndpoints
+	vpc.UseVPE = false
+	vpc.VPEEndpointURL = ""
+	vpc.VPETokenExchangeURL = ""
+	vpc.VPETLSServerName = ""
+	if region.ResourceGroupID != "" {
+		vpc.ResourceGroupID = region.ResourceGroupID
+		vpc.G2ResourceGroupID = region.ResourceGroupID
+	}
+	return failover, nil
+}
+
+// Failover re-points provider sessions at a failover region
+type Failover struct {
+	// NewProvider returns the provider of the failover configuration
+	NewProvider ProviderFactory
+
+	// Credentials re-authenticates against the failover region, by default with the IAM API key of the configuration
+	Credentials CredentialsFunc
+
+	// ResolveResourceGroup resolves the resource group of the regions without one, the resource group is kept if nil
+	ResolveResourceGroup ResourceGroupResolver
+}
+
+// Repoint opens a session in the failover region and closes the current session (if any) once it is open. The
+// current session is kept if the failover fails, the returned configuration is the one of the new session
+func (f *Failover) Repoint(ctx context.Context, current provider.Session, conf *config.Config, region Region, logger *zap.Logger) (provider.Session, *config.Config, error) {
+	if f.NewProvider == nil {
+		return nil, nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, "Provider factory is required to fail over")
+	}
+	if region.ResourceGroupID == "" && f.ResolveResourceGroup != nil {
+		resourceGroupID, err := f.ResolveResourceGroup(ctx, region)
+		if err != nil {
+			logger.Error("Failed to resolve the resource group of the failover region", zap.String("Region", region.Name), util.ZapError(err))
+			return nil, nil, err
+		}
+		region.ResourceGroupID = resourceGroupID
+	}
+
+	failoverConf, err := FailoverConfig(conf, region)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := failoverConf.Validate().Err(); err != nil {
+		logger.Error("Invalid failover configuration", zap.String("Region", region.Name), zap.Error(err))
+		return nil, nil, util.NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid configuration for the failover region %s", region.Name), err)
+	}
+
+	p, err := f.NewProvider(failoverConf, logger)
+	if err != nil {
+		logger.Error("Failed to create the provider of the failover region", zap.String("Region", region.Name), util.ZapError(err))
+		return nil, nil, err
+	}
+	credentials := f.Credentials
+	if credentials == nil {
+		credentials = IAMAPIKeyCredentials
+	}
+	contextCredentials, err := credentials(ctx, p, failoverConf, logger)
+	if err != nil {
+		logger.Error("Failed to authenticate against the failover region", zap.String("Region", region.Name), util.ZapError(err))
+		return nil, nil, err
+	}
+	contextCredentials.Region = region.Name
+
+	session, err := p.OpenSession(ctx, contextCredentials, logger)
+	if err != nil {
+		logger.Error("Failed to open a session in the failover region", zap.String("Region", region.Name), util.ZapError(err))
+		return nil, nil, err
+	}
+	if current != nil {
+		current.Close()
+	}
+	logger.Info("Session re-pointed at the failover region", zap.String("Region", region.Name), zap.String("EndpointURL", region.EndpointURL))
+	return session, failoverConf, nil
+}
+
+// contextAccessTokenFactory is implemented by the credentials factories exchanging the API key within the
+// deadline of a context, e.g. auth.ContextCredentialsFactory
+type contextAccessTokenFactory interface {
+	ForIAMAccessTokenWithContext(ctx context.Context, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error)
+}
+
+// IAMAPIKeyCredentials is the default CredentialsFunc, exchanging the API key of the VPC configuration for IAM
+// access token credentials, within the deadline of ctx when the credentials factory supports it
+func IAMAPIKeyCredentials(ctx context.Context, p local.Provider, conf *config.Config, logger *zap.Logger) (provider.ContextCredentials, error) {
+	apiKey, err := conf.VPC.GetAPIKey(ctx)
+	if err != nil {
+		return provider.ContextCredentials{}, err
+	}
+	ccf, err := p.ContextCredentialsFactory(nil)
+	if err != nil {
+		return provider.ContextCredentials{}, err
+	}
+	if contextFactory, ok := ccf.(contextAccessTokenFactory); ok {
+		return contextFactory.ForIAMAccessTokenWithContext(ctx, apiKey, logger)
+	}
+	return ccf.ForIAMAccessToken(apiKey, logger)
+}
@@ -0,0 +1,150 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dr ...
+package dr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func primaryConfig() *config.Config {
+	return &config.Config{VPC: &config.VPCProviderConfig{
+		Enabled:           true,
+		EndpointURL:       "https://us-south.iaas.cloud.ibm.com",
+		G2EndpointURL:     "https://us-south.iaas.cloud.ibm.com",
+		G2APIKey:          "key",
+		APIKey:            "key",
+		G2ResourceGroupID: "rg-south",
+		ResourceGroupID:   "rg-south",
+		ZoneEndpoints:     map[string]string{"us-south-1": "https://us-south-1.iaas.cloud.ibm.com"},
+		UseVPE:            true,
+		VPEEndpointURL:    "https://vpe.us-south.example.com",
+	}}
+}
+
+func TestFailoverConfig(t *testing.T) {
+	conf := primaryConfig()
+	region := Region{Name: "us-east", EndpointURL: "https://us-east.iaas.cloud.ibm.com", ResourceGroupID: "rg-east"}
+
+	failover, err := FailoverConfig(conf, region)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://us-east.iaas.cloud.ibm.com", failover.VPC.G2EndpointURL)
+	assert.Equal(t, "rg-east", failover.VPC.G2ResourceGroupID)
+	assert.False(t, failover.VPC.UseVPE)
+	assert.Empty(t, failover.VPC.ZoneEndpoints)
+
+	// The primary configuration is unchanged
+	assert.Equal(t, "https://us-south.iaas.cloud.ibm.com", conf.VPC.G2EndpointURL)
+	assert.True(t, conf.VPC.UseVPE)
+
+	failover, err = FailoverConfig(conf, Region{Name: "us-east", EndpointURL: "https://us-east.iaas.cloud.ibm.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, "rg-south", failover.VPC.G2ResourceGroupID)
+
+	_, err = FailoverConfig(conf, Region{Name: "us-east"})
+	assert.Equal(t, reasoncode.ErrorRequiredFieldMissing, util.ErrorReasonCode(err))
+	_, err = FailoverConfig(&config.Config{}, region)
+	assert.Equal(t, reasoncode.ErrorRequiredFieldMissing, util.ErrorReasonCode(err))
+}
+
+func TestFailoverRepoint(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	current, next := &fake.FakeSession{}, &fake.FakeSession{}
+	p := &fakes.Provider{}
+	p.OpenSessionReturns(next, nil)
+	ccf := &fakes.ContextCredentialsFactory{}
+	ccf.ForIAMAccessTokenReturns(provider.ContextCredentials{AuthType: provider.IAMAccessToken, Credential: "token"}, nil)
+	p.ContextCredentialsFactoryReturns(ccf, nil)
+
+	var created *config.Config
+	f := &Failover{
+		NewProvider: func(conf *config.Config, logger *zap.Logger) (local.Provider, error) {
+			created = conf
+			return p, nil
+		},
+		ResolveResourceGroup: func(ctx context.Context, region Region) (string, error) {
+			return "rg-" + region.Name, nil
+		},
+	}
+
+	session, conf, err := f.Repoint(context.Background(), current, primaryConfig(), Region{Name: "us-east", EndpointURL: "https://us-east.iaas.cloud.ibm.com"}, logger)
+	assert.Nil(t, err)
+	assert.Equal(t, next, session)
+	assert.Equal(t, created, conf)
+	assert.Equal(t, "rg-us-east", conf.VPC.G2ResourceGroupID)
+	assert.Equal(t, 1, current.CloseCallCount())
+	assert.Equal(t, 0, ccf.ForIAMAPIKeyCallCount())
+	apiKey, _ := ccf.ForIAMAccessTokenArgsForCall(0)
+	assert.Equal(t, "key", apiKey)
+	_, contextCredentials, _ := p.OpenSessionArgsForCall(0)
+	assert.Equal(t, "us-east", contextCredentials.Region)
+	assert.Equal(t, provider.IAMAccessToken, contextCredentials.AuthType)
+
+	// The current session is kept when the failover session cannot be opened
+	current = &fake.FakeSession{}
+	p.OpenSessionReturns(nil, errors.New("unreachable"))
+	_, _, err = f.Repoint(context.Background(), current, primaryConfig(), Region{Name: "us-east", EndpointURL: "https://us-east.iaas.cloud.ibm.com"}, logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, current.CloseCallCount())
+
+	f.ResolveResourceGroup = func(ctx context.Context, region Region) (string, error) {
+		return "", errors.New("resource group not found")
+	}
+	_, _, err = f.Repoint(context.Background(), current, primaryConfig(), Region{Name: "us-east", EndpointURL: "https://us-east.iaas.cloud.ibm.com"}, logger)
+	assert.NotNil(t, err)
+
+	_, _, err = (&Failover{}).Repoint(context.Background(), current, primaryConfig(), Region{Name: "us-east"}, logger)
+	assert.Equal(t, reasoncode.ErrorRequiredFieldMissing, util.ErrorReasonCode(err))
+}
+
+// contextFactory records the context of the access token exchange
+type contextFactory struct {
+	*fakes.ContextCredentialsFactory
+	ctx context.Context
+}
+
+func (f *contextFactory) ForIAMAccessTokenWithContext(ctx context.Context, apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	f.ctx = ctx
+	return provider.ContextCredentials{AuthType: provider.IAMAccessToken, Credential: "token-" + apiKey}, nil
+}
+
+func TestIAMAPIKeyCredentialsWithContext(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ccf := &contextFactory{ContextCredentialsFactory: &fakes.ContextCredentialsFactory{}}
+	p := &fakes.Provider{}
+	p.ContextCredentialsFactoryReturns(ccf, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	credentials, err := IAMAPIKeyCredentials(ctx, p, primaryConfig(), logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "token-key", credentials.Credential)
+	assert.Equal(t, ctx, ccf.ctx)
+	assert.Equal(t, 0, ccf.ForIAMAccessTokenCallCount())
+}
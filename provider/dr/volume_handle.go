@@ -0,0 +1,135 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dr ...
+package dr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// crnLocationIndex is the index of the location (region or zone) in the colon separated segments of a CRN
+const crnLocationIndex = 5
+
+// VolumeIDMap maps the IDs of the volumes of the primary region to the IDs of their replicas in the failover region
+type VolumeIDMap map[string]string
+
+// LoadVolumeIDMap reads a JSON object of primary to replica volume IDs, e.g. exported by the replication tooling
+func LoadVolumeIDMap(r io.Reader) (VolumeIDMap, error) {
+	ids := VolumeIDMap{}
+	if err := json.NewDecoder(r).Decode(&ids); err != nil {
+		return nil, util.NewError(reasoncode.ErrorBadRequest, "Invalid volume ID map", err)
+	}
+	return ids, nil
+}
+
+// RehydrateVolumeHandle returns the provider/type/id volume handle referencing the replica of the volume. Legacy
+// (classic) handles are not region scoped and an ErrorBadRequest error, a volume without replica an ErrorResourceNotFound error
+func RehydrateVolumeHandle(handle string, ids VolumeIDMap) (string, error) {
+	if util.IsLegacyVolumeHandle(handle) {
+		return "", util.NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Legacy volume handle '%s' is not region scoped", handle))
+	}
+	resourceID, err := util.ParseVolumeHandle(handle)
+	if err != nil {
+		return "", err
+	}
+	replicaID, ok := ids[resourceID.ID]
+	if !ok || replicaID == "" {
+		return "", util.NewError(reasoncode.ErrorResourceNotFound, fmt.Sprintf("No replica of volume %s in the failover region", resourceID.ID))
+	}
+	resourceID.ID = replicaID
+	return resourceID.VolumeHandle(), nil
+}
+
+// RehydrateVolumeHandles rehydrates the volume handles, returning the new handle of each handle. The handles which
+// fail are skipped and their errors returned together, so a runbook can report them and carry on with the others
+func RehydrateVolumeHandles(handles []string, ids VolumeIDMap) (map[string]string, map[string]error) {
+	rehydrated := map[string]string{}
+	failed := map[string]error{}
+	for _, handle := range handles {
+		newHandle, err := RehydrateVolumeHandle(handle, ids)
+		if err != nil {
+			failed[handle] = err
+			continue
+		}
+		rehydrated[handle] = newHandle
+	}
+	return rehydrated, failed
+}
+
+// FailoverZone returns the zone of the failover region matching the zone of the primary region, i.e. with the same
+// zone number (us-south-2 is us-east-2 in us-east)
+func FailoverZone(zone, primaryRegion, failoverRegion string) (string, error) {
+	if !strings.HasPrefix(zone, primaryRegion+"-") {
+		return "", util.NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Zone %s is not in region %s", zone, primaryRegion))
+	}
+	return failoverRegion + strings.TrimPrefix(zone, primaryRegion), nil
+}
+
+// FailoverCRN returns the CRN with its region (or zone) location moved to the failover region and the resource
+// ID replaced by the replica of ids, if any
+func FailoverCRN(crn, primaryRegion, failoverRegion string, ids VolumeIDMap) (string, error) {
+	segments := strings.Split(crn, ":")
+	if len(segments) < crnLocationIndex+5 || segments[0] != "crn" {
+		return "", util.NewError(reasoncode.ErrorBadRequest, fmt.Sprintf("Invalid CRN '%s'", crn))
+	}
+	if location := segments[crnLocationIndex]; location == primaryRegion {
+		segments[crnLocationIndex] = failoverRegion
+	} else {
+		zone, err := FailoverZone(location, primaryRegion, failoverRegion)
+		if err != nil {
+			return "", err
+		}
+		segments[crnLocationIndex] = zone
+	}
+	last := len(segments) - 1
+	if replicaID, ok := ids[segments[last]]; ok && replicaID != "" {
+		segments[last] = replicaID
+	}
+	return strings.Join(segments, ":"), nil
+}
+
+// RehydrateVolume updates the ID, zone and CRN of the volume to those of its replica in the failover region, the
+// volume is unchanged on error
+func RehydrateVolume(volume *provider.Volume, primaryRegion, failoverRegion string, ids VolumeIDMap) error {
+	replicaID, ok := ids[volume.VolumeID]
+	if !ok || replicaID == "" {
+		return util.NewError(reasoncode.ErrorResourceNotFound, fmt.Sprintf("No replica of volume %s in the failover region", volume.VolumeID))
+	}
+	zone, crn := volume.Az, volume.CRN
+	var err error
+	if zone != "" {
+		if zone, err = FailoverZone(zone, primaryRegion, failoverRegion); err != nil {
+			return err
+		}
+	}
+	if crn != "" {
+		if crn, err = FailoverCRN(crn, primaryRegion, failoverRegion, ids); err != nil {
+			return err
+		}
+	}
+	volume.Az = zone
+	volume.CRN = crn
+	volume.VolumeID = replicaID
+	return nil
+}
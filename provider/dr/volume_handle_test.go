@@ -0,0 +1,116 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dr ...
+package dr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadVolumeIDMap(t *testing.T) {
+	ids, err := LoadVolumeIDMap(strings.NewReader(`{"r006-vol-1": "r014-vol-1"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, VolumeIDMap{"r006-vol-1": "r014-vol-1"}, ids)
+
+	_, err = LoadVolumeIDMap(strings.NewReader(`[]`))
+	assert.Equal(t, reasoncode.ErrorBadRequest, util.ErrorReasonCode(err))
+}
+
+func TestRehydrateVolumeHandle(t *testing.T) {
+	ids := VolumeIDMap{"r006-vol-1": "r014-vol-1"}
+
+	testcases := []struct {
+		testcasename string
+		handle       string
+		expected     string
+		expectedCode reasoncode.ReasonCode
+	}{
+		{
+			testcasename: "Replicated volume",
+			handle:       "VPC-BLOCK/block/r006-vol-1",
+			expected:     "VPC-BLOCK/block/r014-vol-1",
+		},
+		{
+			testcasename: "No replica",
+			handle:       "VPC-BLOCK/block/r006-vol-2",
+			expectedCode: reasoncode.ErrorResourceNotFound,
+		},
+		{
+			testcasename: "Legacy handle",
+			handle:       "12345",
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+		{
+			testcasename: "Invalid handle",
+			handle:       "r006-vol-1",
+			expectedCode: reasoncode.ErrorBadRequest,
+		},
+	}
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			handle, err := RehydrateVolumeHandle(testcase.handle, ids)
+			if testcase.expectedCode != "" {
+				assert.Equal(t, testcase.expectedCode, util.ErrorReasonCode(err))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testcase.expected, handle)
+		})
+	}
+
+	rehydrated, failed := RehydrateVolumeHandles([]string{"VPC-BLOCK/block/r006-vol-1", "VPC-BLOCK/block/r006-vol-2"}, ids)
+	assert.Equal(t, map[string]string{"VPC-BLOCK/block/r006-vol-1": "VPC-BLOCK/block/r014-vol-1"}, rehydrated)
+	assert.Len(t, failed, 1)
+	assert.NotNil(t, failed["VPC-BLOCK/block/r006-vol-2"])
+}
+
+func TestFailoverCRN(t *testing.T) {
+	ids := VolumeIDMap{"r006-vol-1": "r014-vol-1"}
+
+	crn, err := FailoverCRN("crn:v1:bluemix:public:is:us-south-2:a/acc::volume:r006-vol-1", "us-south", "us-east", ids)
+	assert.Nil(t, err)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-east-2:a/acc::volume:r014-vol-1", crn)
+
+	crn, err = FailoverCRN("crn:v1:bluemix:public:is:us-south:a/acc::snapshot:r006-snap-1", "us-south", "us-east", ids)
+	assert.Nil(t, err)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-east:a/acc::snapshot:r006-snap-1", crn)
+
+	_, err = FailoverCRN("crn:v1:bluemix:public:is:eu-de-1:a/acc::volume:r010-vol-1", "us-south", "us-east", ids)
+	assert.Equal(t, reasoncode.ErrorBadRequest, util.ErrorReasonCode(err))
+	_, err = FailoverCRN("r006-vol-1", "us-south", "us-east", ids)
+	assert.Equal(t, reasoncode.ErrorBadRequest, util.ErrorReasonCode(err))
+}
+
+func TestRehydrateVolume(t *testing.T) {
+	ids := VolumeIDMap{"r006-vol-1": "r014-vol-1"}
+	volume := &provider.Volume{VolumeID: "r006-vol-1", Az: "us-south-1"}
+	volume.CRN = "crn:v1:bluemix:public:is:us-south-1:a/acc::volume:r006-vol-1"
+
+	assert.Nil(t, RehydrateVolume(volume, "us-south", "us-east", ids))
+	assert.Equal(t, "r014-vol-1", volume.VolumeID)
+	assert.Equal(t, "us-east-1", volume.Az)
+	assert.Equal(t, "crn:v1:bluemix:public:is:us-east-1:a/acc::volume:r014-vol-1", volume.CRN)
+
+	err := RehydrateVolume(&provider.Volume{VolumeID: "r006-vol-2"}, "us-south", "us-east", ids)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, util.ErrorReasonCode(err))
+}
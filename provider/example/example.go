@@ -0,0 +1,397 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package example is a worked example of a provider, a template for new storage backends. The backend API is
+// simulated in memory by a fake.MemorySession, the session goes through the real layers of the library:
+//
+//   - the errors are provider errors with reason codes (util.NewError), so the consumers classify them
+//   - the transient failures are retried with a util.ErrorRetrier
+//   - the operations are recorded in the Prometheus metrics (metrics.RecordOperation) and the session stats
+//   - the mutations go through util.RunMutation, so they are only planned in dry run (see Session.WithContext)
+//   - the methods the backend does not support are inherited from provider.DefaultVolumeProvider
+//
+// Consumers use it as any other provider, e.g. through util.NewContextSession for the WithContext methods
+package example
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+const (
+	// ProviderName is the name of the example provider
+	ProviderName = provider.VolumeProvider("example")
+
+	// VolumeType is the type of the example volumes
+	VolumeType = provider.VolumeType("block")
+
+	// AttachmentAttached is the status of a completed attachment
+	AttachmentAttached = "attached"
+)
+
+// defaultRetryPolicy retries the transient failures of the backend quickly, as it is in memory
+var defaultRetryPolicy = util.RetryPolicy{MaxAttempts: 3, RetryInterval: 10 * time.Millisecond}
+
+// Provider is the example local.Provider, its sessions share the simulated backend
+type Provider struct {
+	backend     *Backend
+	retryPolicy util.RetryPolicy
+}
+
+var _ local.Provider = &Provider{}
+
+// NewProvider returns a provider of an empty backend with the quotas
+func NewProvider(quotas fake.Quotas) *Provider {
+	return &Provider{backend: NewBackend(quotas), retryPolicy: defaultRetryPolicy}
+}
+
+// NewProviderFromConfig returns a provider of an empty backend with the quotas, its sessions retrying with the
+// retry policy of the configuration as the VPC providers do
+func NewProviderFromConfig(vpc *config.VPCProviderConfig, quotas fake.Quotas) (*Provider, error) {
+	policy, err := util.RetryPolicyFromConfig(vpc)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{backend: NewBackend(quotas), retryPolicy: policy}, nil
+}
+
+// Backend returns the simulated backend, e.g. to inject failures in tests
+func (p *Provider) Backend() *Backend {
+	return p.backend
+}
+
+// OpenSession opens a session of the backend. A real provider verifies the credentials here, or defers it
+// until the first call
+func (p *Provider) OpenSession(ctx context.Context, credentials provider.ContextCredentials, logger *zap.Logger) (provider.Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if credentials.Credential == "" {
+		return nil, util.NewError(reasoncode.ErrorUnauthorised, "The example provider requires a credential")
+	}
+	logger.Info("Opened example session", zap.String("region", credentials.Region))
+	return &Session{
+		backend: fake.NewMemorySession(p.backend.store),
+		region:  credentials.Region,
+		logger:  logger,
+		retrier: util.NewErrorRetrierWithPolicy(p.retryPolicy, logger),
+		stats:   util.NewStatsCollector(),
+	}, nil
+}
+
+// ContextCredentialsFactory returns the factory of the credentials of the example sessions
+func (p *Provider) ContextCredentialsFactory(datacenter *string) (local.ContextCredentialsFactory, error) {
+	region := ""
+	if datacenter != nil {
+		region = *datacenter
+	}
+	return &credentialsFactory{region: region}, nil
+}
+
+// credentialsFactory builds the credentials of the example sessions from an API key
+type credentialsFactory struct {
+	region string
+}
+
+// ForIaaSAPIKey ...
+func (f *credentialsFactory) ForIaaSAPIKey(iamAccountID, iaasUserID, iaasAPIKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IaaSAPIKey, Region: f.region, IAMAccountID: iamAccountID, UserID: iaasUserID, Credential: iaasAPIKey}, nil
+}
+
+// ForIAMAPIKey ...
+func (f *credentialsFactory) ForIAMAPIKey(iamAccountID, iamAPIKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IAMAPIKey, Region: f.region, IAMAccountID: iamAccountID, Credential: iamAPIKey}, nil
+}
+
+// ForIAMAccessToken ...
+func (f *credentialsFactory) ForIAMAccessToken(apiKey string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{AuthType: provider.IAMAccessToken, Region: f.region, Credential: apiKey}, nil
+}
+
+// Backend simulates the storage backend: the volumes and attachments, quotas and capacity of a fake.MemoryStore.
+// Failures can be injected to exercise the retries
+type Backend struct {
+	store *fake.MemoryStore
+}
+
+// NewBackend returns an empty backend with the quotas
+func NewBackend(quotas fake.Quotas) *Backend {
+	return &Backend{store: fake.NewMemoryStore(quotas)}
+}
+
+// Capacity returns the capacity store of the backend, e.g. to set the zone capacities
+func (b *Backend) Capacity() *fake.CapacitySimulator {
+	return b.store.Capacity()
+}
+
+// FailNext makes the next calls to the backend fail with a transient connection error
+func (b *Backend) FailNext(calls int) {
+	b.store.FailNext(fake.AnyOperation, util.NewError(reasoncode.ErrorTemporaryConnectionProblem, "The example backend connection was reset"), calls)
+}
+
+// Session is the example provider.Session. Every method follows the same steps: validate the request,
+// call the backend API with retries, record the metrics and return provider errors
+type Session struct {
+	// DefaultVolumeProvider implements the methods the backend does not support
+	provider.DefaultVolumeProvider
+
+	// backend is the client of the backend API, a real provider holds its REST client here
+	backend *fake.MemorySession
+	region  string
+	logger  *zap.Logger
+	retrier *util.ErrorRetrier
+	stats   *util.StatsCollector
+	ctx     context.Context
+}
+
+var _ provider.Session = &Session{}
+
+// WithContext returns a copy of the session running its operations with the context, e.g. a util.WithDryRun
+// context. The Session interface has no context, the provider passes it to the layers which take one
+func (s *Session) WithContext(ctx context.Context) *Session {
+	copied := *s
+	copied.ctx = ctx
+	return &copied
+}
+
+// operationContext returns the context of the operations of the session
+func (s *Session) operationContext() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// retry calls fn on the backend, retrying the retryable errors
+func (s *Session) retry(fn func() error) error {
+	return s.retrier.ErrorRetry(func() (error, bool) {
+		err := fn()
+		return err, !util.IsRetryableError(err)
+	})
+}
+
+// volume returns the volume of the backend as a volume of the example provider
+func (s *Session) volume(volume *provider.Volume) *provider.Volume {
+	volume.Provider = ProviderName
+	volume.VolumeType = VolumeType
+	return volume
+}
+
+// record records the completed operation in the metrics and the session stats, it is deferred with the
+// named error result so the error returned is recorded
+func (s *Session) record(operation string, start time.Time, errp *error) {
+	err := *errp
+	metrics.RecordOperation("example."+operation, start, err)
+	s.stats.RecordOperation(operation, err)
+	if err != nil {
+		s.logger.Error("Example operation failed", zap.String("operation", operation), util.ZapError(err))
+	}
+}
+
+// GetProviderDisplayName returns the name of the example provider
+func (s *Session) GetProviderDisplayName() provider.VolumeProvider {
+	return ProviderName
+}
+
+// ProviderName returns the name of the example provider
+func (s *Session) ProviderName() provider.VolumeProvider {
+	return ProviderName
+}
+
+// Type returns the type of the example volumes
+func (s *Session) Type() provider.VolumeType {
+	return VolumeType
+}
+
+// Close releases the session
+func (s *Session) Close() {
+	s.logger.Info("Closed example session")
+}
+
+// Stats returns the counters of the session
+func (s *Session) Stats() provider.ProviderStats {
+	return s.stats.Snapshot()
+}
+
+// GetCapabilities returns the capabilities of the simulated backend, it only expands detached volumes
+func (s *Session) GetCapabilities() (*provider.Capabilities, error) {
+	return &provider.Capabilities{Features: map[string]bool{provider.FeatureVolumeExpansion: true}}, nil
+}
+
+// CreateVolume creates the volume in the region of the session, the quotas and zone capacities of the backend apply
+func (s *Session) CreateVolume(volumeRequest provider.Volume) (volume *provider.Volume, err error) {
+	defer s.record("CreateVolume", time.Now(), &err)
+	if volumeRequest.Name == nil || *volumeRequest.Name == "" {
+		return nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, "Volume name is required")
+	}
+	if volumeRequest.Capacity == nil || *volumeRequest.Capacity <= 0 {
+		return nil, util.NewError(reasoncode.ErrorBadRequest, "Volume capacity must be a positive number of GiB")
+	}
+
+	volumeRequest.Region = s.region
+	err = util.RunMutation(s.operationContext(), "CreateVolume", *volumeRequest.Name, nil, func() error {
+		return s.retry(func() error {
+			created, err := s.backend.CreateVolume(volumeRequest)
+			if err == nil {
+				volume = s.volume(created)
+			}
+			return err
+		})
+	})
+	return volume, err
+}
+
+// GetVolume returns the volume, an ErrorResourceNotFound error if it does not exist
+func (s *Session) GetVolume(id string) (volume *provider.Volume, err error) {
+	defer s.record("GetVolume", time.Now(), &err)
+	err = s.retry(func() error {
+		found, err := s.backend.GetVolume(id)
+		if err == nil {
+			volume = s.volume(found)
+		}
+		return err
+	})
+	return volume, err
+}
+
+// GetVolumeByName returns the volume of the name, an ErrorResourceNotFound error if it does not exist
+func (s *Session) GetVolumeByName(name string) (volume *provider.Volume, err error) {
+	defer s.record("GetVolumeByName", time.Now(), &err)
+	err = s.retry(func() error {
+		found, err := s.backend.GetVolumeByName(name)
+		if err == nil {
+			volume = s.volume(found)
+		}
+		return err
+	})
+	return volume, err
+}
+
+// ListVolumes lists the volumes by ID, a page of at most util.PageLimit(limit) volumes from the start ID. The tags
+// are not supported, see ListVolumesWithFilters
+func (s *Session) ListVolumes(limit int, start string, tags map[string]string) (list *provider.VolumeList, err error) {
+	defer s.record("ListVolumes", time.Now(), &err)
+	if len(tags) > 0 {
+		return nil, util.NewError(reasoncode.ErrorUnsupportedFeature, "The example provider does not filter the volumes by tags")
+	}
+	return s.listVolumes(limit, start, provider.ListVolumesFilters{})
+}
+
+// ListVolumesWithFilters lists the volumes matching the filters like ListVolumes
+func (s *Session) ListVolumesWithFilters(limit int, start string, filters provider.ListVolumesFilters) (list *provider.VolumeList, err error) {
+	defer s.record("ListVolumesWithFilters", time.Now(), &err)
+	return s.listVolumes(limit, start, filters)
+}
+
+func (s *Session) listVolumes(limit int, start string, filters provider.ListVolumesFilters) (list *provider.VolumeList, err error) {
+	err = s.retry(func() error {
+		list, err = s.backend.ListVolumesWithFilters(util.PageLimit(limit), start, filters)
+		if err == nil {
+			for _, volume := range list.Volumes {
+				s.volume(volume)
+			}
+		}
+		return err
+	})
+	return list, err
+}
+
+// ExpandVolume expands the volume to the capacity, the quotas and zone capacities of the backend apply
+func (s *Session) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (capacity int64, err error) {
+	defer s.record("ExpandVolume", time.Now(), &err)
+	err = util.RunMutation(s.operationContext(), "ExpandVolume", expandVolumeRequest.VolumeID, nil, func() error {
+		return s.retry(func() error {
+			capacity, err = s.backend.ExpandVolume(expandVolumeRequest)
+			return err
+		})
+	})
+	return capacity, err
+}
+
+// DeleteVolume deletes the volume, a volume which is attached cannot be deleted
+func (s *Session) DeleteVolume(volume *provider.Volume) (err error) {
+	defer s.record("DeleteVolume", time.Now(), &err)
+	if volume == nil || volume.VolumeID == "" {
+		return util.NewError(reasoncode.ErrorRequiredFieldMissing, "Volume ID is required")
+	}
+	return util.RunMutation(s.operationContext(), "DeleteVolume", volume.VolumeID, nil, func() error {
+		return s.retry(func() error {
+			return s.backend.DeleteVolume(volume)
+		})
+	})
+}
+
+// AttachVolume attaches the volume to the instance, the attachment completes immediately
+func (s *Session) AttachVolume(attachRequest provider.VolumeAttachmentRequest) (response *provider.VolumeAttachmentResponse, err error) {
+	defer s.record("AttachVolume", time.Now(), &err)
+	if attachRequest.InstanceID == "" {
+		return nil, util.NewError(reasoncode.ErrorRequiredFieldMissing, "Instance ID is required")
+	}
+	err = util.RunMutation(s.operationContext(), "AttachVolume", attachRequest.VolumeID, map[string]string{"instanceID": attachRequest.InstanceID}, func() error {
+		return s.retry(func() error {
+			response, err = s.backend.AttachVolume(attachRequest)
+			return err
+		})
+	})
+	return response, err
+}
+
+// WaitForAttachVolume returns the attachment, the attachments of the example backend complete immediately
+func (s *Session) WaitForAttachVolume(attachRequest provider.VolumeAttachmentRequest) (*provider.VolumeAttachmentResponse, error) {
+	return s.GetVolumeAttachment(attachRequest)
+}
+
+// GetVolumeAttachment returns the attachment of the volume to the instance
+func (s *Session) GetVolumeAttachment(attachRequest provider.VolumeAttachmentRequest) (response *provider.VolumeAttachmentResponse, err error) {
+	defer s.record("GetVolumeAttachment", time.Now(), &err)
+	err = s.retry(func() error {
+		response, err = s.backend.GetVolumeAttachment(attachRequest)
+		return err
+	})
+	return response, err
+}
+
+// DetachVolume detaches the volume from the instance, detaching a volume which is not attached succeeds
+func (s *Session) DetachVolume(detachRequest provider.VolumeAttachmentRequest) (_ *http.Response, err error) {
+	defer s.record("DetachVolume", time.Now(), &err)
+	err = util.RunMutation(s.operationContext(), "DetachVolume", detachRequest.VolumeID, map[string]string{"instanceID": detachRequest.InstanceID}, func() error {
+		return s.retry(func() error {
+			_, err := s.backend.DetachVolume(detachRequest)
+			if util.ErrorReasonCode(err) == reasoncode.ErrorResourceNotFound {
+				return nil
+			}
+			return err
+		})
+	})
+	return nil, err
+}
+
+// WaitForDetachVolume returns once the volume is detached, the detachments of the example backend complete immediately
+func (s *Session) WaitForDetachVolume(detachRequest provider.VolumeAttachmentRequest) error {
+	return s.retry(func() error {
+		return s.backend.WaitForDetachVolume(detachRequest)
+	})
+}
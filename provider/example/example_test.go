@@ -0,0 +1,217 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package example ...
+package example
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/apicheck"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func openSession(t *testing.T, p *Provider) *Session {
+	logger, _ := zap.NewDevelopment()
+	factory, err := p.ContextCredentialsFactory(nil)
+	require.Nil(t, err)
+	credentials, err := factory.ForIAMAPIKey("account", "apikey", logger)
+	require.Nil(t, err)
+	sess, err := p.OpenSession(context.Background(), credentials, logger)
+	require.Nil(t, err)
+	return sess.(*Session)
+}
+
+func volumeRequest(name string, capacity int) provider.Volume {
+	return provider.Volume{Name: &name, Capacity: &capacity, Az: "us-south-1"}
+}
+
+func TestInterfaces(t *testing.T) {
+	apicheck.CheckProvider(t, &Provider{})
+	apicheck.CheckSession(t, &Session{})
+	apicheck.CheckContextSession(t, util.NewContextSession(&Session{}))
+}
+
+func TestOpenSessionRequiresCredential(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	_, err := NewProvider(fake.Quotas{}).OpenSession(context.Background(), provider.ContextCredentials{}, logger)
+	assert.Equal(t, reasoncode.ErrorUnauthorised, util.ErrorReasonCode(err))
+}
+
+func TestVolumeLifecycle(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+
+	volume, err := sess.CreateVolume(volumeRequest("vol-a", 10))
+	require.Nil(t, err)
+	assert.Equal(t, ProviderName, volume.Provider)
+
+	found, err := sess.GetVolumeByName("vol-a")
+	require.Nil(t, err)
+	assert.Equal(t, volume.VolumeID, found.VolumeID)
+
+	_, err = sess.CreateVolume(volumeRequest("vol-a", 10))
+	assert.Equal(t, reasoncode.ErrorResourceAlreadyExists, util.ErrorReasonCode(err))
+
+	capacity, err := sess.ExpandVolume(provider.ExpandVolumeRequest{VolumeID: volume.VolumeID, Capacity: 20})
+	require.Nil(t, err)
+	assert.Equal(t, int64(20), capacity)
+	found, err = sess.GetVolume(volume.VolumeID)
+	require.Nil(t, err)
+	assert.Equal(t, 20, *found.Capacity)
+
+	attachRequest := provider.VolumeAttachmentRequest{VolumeID: volume.VolumeID, InstanceID: "instance-1"}
+	attachment, err := sess.AttachVolume(attachRequest)
+	require.Nil(t, err)
+	assert.Equal(t, AttachmentAttached, attachment.Status)
+
+	_, err = sess.AttachVolume(provider.VolumeAttachmentRequest{VolumeID: volume.VolumeID, InstanceID: "instance-2"})
+	assert.Equal(t, reasoncode.ErrorVolumeAttachConflict, util.ErrorReasonCode(err))
+	assert.Equal(t, reasoncode.ErrorVolumeAttachConflict, util.ErrorReasonCode(sess.DeleteVolume(volume)))
+
+	_, err = sess.DetachVolume(attachRequest)
+	require.Nil(t, err)
+	require.Nil(t, sess.WaitForDetachVolume(attachRequest))
+	require.Nil(t, sess.DeleteVolume(volume))
+
+	_, err = sess.GetVolume(volume.VolumeID)
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, util.ErrorReasonCode(err))
+
+	stats := sess.Stats()
+	assert.Equal(t, provider.OperationCounts{Success: 1, Failure: 1}, stats.Operations["CreateVolume"])
+	assert.Equal(t, int64(1), stats.Operations["GetVolume"].Failure)
+}
+
+func TestCreateVolumeValidation(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+	testCases := []struct {
+		testcasename string
+		request      provider.Volume
+		expected     reasoncode.ReasonCode
+	}{
+		{testcasename: "missing name", request: provider.Volume{}, expected: reasoncode.ErrorRequiredFieldMissing},
+		{testcasename: "zero capacity", request: volumeRequest("vol-a", 0), expected: reasoncode.ErrorBadRequest},
+	}
+	for _, testcase := range testCases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			_, err := sess.CreateVolume(testcase.request)
+			assert.Equal(t, testcase.expected, util.ErrorReasonCode(err))
+		})
+	}
+}
+
+func TestQuotas(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{MaxVolumes: 1}))
+	_, err := sess.CreateVolume(volumeRequest("vol-a", 10))
+	require.Nil(t, err)
+	_, err = sess.CreateVolume(volumeRequest("vol-b", 10))
+	assert.NotNil(t, err)
+	_, err = sess.GetVolumeByName("vol-b")
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, util.ErrorReasonCode(err))
+}
+
+func TestTransientFailuresAreRetried(t *testing.T) {
+	p := NewProvider(fake.Quotas{})
+	sess := openSession(t, p)
+
+	p.Backend().FailNext(2)
+	_, err := sess.CreateVolume(volumeRequest("vol-a", 10))
+	require.Nil(t, err)
+
+	p.Backend().FailNext(10)
+	_, err = sess.GetVolumeByName("vol-a")
+	assert.Equal(t, reasoncode.ErrorTemporaryConnectionProblem, util.ErrorReasonCode(err))
+}
+
+func TestNewProviderFromConfig(t *testing.T) {
+	p, err := NewProviderFromConfig(&config.VPCProviderConfig{MaxRetryAttempt: 1}, fake.Quotas{})
+	require.Nil(t, err)
+	sess := openSession(t, p)
+
+	// a single attempt is made
+	p.Backend().FailNext(1)
+	_, err = sess.CreateVolume(volumeRequest("vol-a", 10))
+	assert.Equal(t, reasoncode.ErrorTemporaryConnectionProblem, util.ErrorReasonCode(err))
+
+	_, err = NewProviderFromConfig(&config.VPCProviderConfig{RetryJitter: 2}, fake.Quotas{})
+	assert.NotNil(t, err)
+}
+
+func TestListVolumes(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+	for _, name := range []string{"vol-a", "vol-b", "vol-c"} {
+		_, err := sess.CreateVolume(volumeRequest(name, 10))
+		require.Nil(t, err)
+	}
+
+	list, err := sess.ListVolumes(2, "", nil)
+	require.Nil(t, err)
+	assert.Len(t, list.Volumes, 2)
+	require.NotEmpty(t, list.Next)
+
+	list, err = sess.ListVolumes(2, list.Next, nil)
+	require.Nil(t, err)
+	assert.Len(t, list.Volumes, 1)
+	assert.Empty(t, list.Next)
+
+	_, err = sess.ListVolumes(2, "", map[string]string{"env": "dev"})
+	assert.Equal(t, reasoncode.ErrorUnsupportedFeature, util.ErrorReasonCode(err))
+}
+
+func TestGetCapabilities(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+	capabilities, err := sess.GetCapabilities()
+	require.Nil(t, err)
+	assert.True(t, capabilities.SupportsExpansion())
+	assert.False(t, capabilities.SupportsOnlineExpansion())
+	assert.False(t, capabilities.SupportsSnapshots())
+}
+
+func TestListVolumesWithFilters(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+	for _, name := range []string{"pvc-a", "pvc-b", "data-c"} {
+		_, err := sess.CreateVolume(volumeRequest(name, 10))
+		require.Nil(t, err)
+	}
+
+	list, err := sess.ListVolumesWithFilters(0, "", provider.ListVolumesFilters{NamePrefix: "pvc-", ZoneName: "us-south-1"})
+	require.Nil(t, err)
+	assert.Len(t, list.Volumes, 2)
+
+	list, err = sess.ListVolumesWithFilters(0, "", provider.ListVolumesFilters{ZoneName: "us-south-2"})
+	require.Nil(t, err)
+	assert.Empty(t, list.Volumes)
+}
+
+func TestDryRunSkipsMutations(t *testing.T) {
+	sess := openSession(t, NewProvider(fake.Quotas{}))
+	ctx, plan := util.WithDryRun(context.Background())
+
+	_, err := sess.WithContext(ctx).CreateVolume(volumeRequest("vol-a", 10))
+	require.Nil(t, err)
+	require.Len(t, plan.Steps(), 1)
+	assert.Equal(t, "CreateVolume", plan.Steps()[0].Operation)
+
+	_, err = sess.GetVolumeByName("vol-a")
+	assert.Equal(t, reasoncode.ErrorResourceNotFound, util.ErrorReasonCode(err))
+}
@@ -0,0 +1,98 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// IdentityTypeUser is the identity type of a user
+	IdentityTypeUser = "user"
+
+	// IdentityTypeServiceID is the identity type of a service ID
+	IdentityTypeServiceID = "ServiceId"
+
+	// IdentityTypeTrustedProfile is the identity type of a trusted profile
+	IdentityTypeTrustedProfile = "Profile"
+)
+
+// CallerIdentity is the IAM identity behind a credential, safe to log (it contains no secret)
+type CallerIdentity struct {
+	// IAMID of the identity, e.g. iam-ServiceId-...
+	IAMID string `json:"iamID"`
+
+	// Type of the identity: user, ServiceId or Profile
+	Type string `json:"type"`
+
+	// Name of the identity, the user e-mail for users
+	Name string `json:"name,omitempty"`
+
+	// AccountID of the account the identity belongs to
+	AccountID string `json:"accountID"`
+}
+
+type identityTokenClaims struct {
+	jwt.StandardClaims
+
+	IAMID   string `json:"iam_id"`
+	SubType string `json:"sub_type"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Account struct {
+		Bss string `json:"bss"`
+	} `json:"account"`
+}
+
+// String returns the identity as "type iam_id (name) in account account_id"
+func (i CallerIdentity) String() string {
+	if i.Name == "" {
+		return fmt.Sprintf("%s %s in account %s", i.Type, i.IAMID, i.AccountID)
+	}
+	return fmt.Sprintf("%s %s (%s) in account %s", i.Type, i.IAMID, i.Name, i.AccountID)
+}
+
+// CallerIdentityFromAccessToken returns the identity from the claims of the access token. The token is not
+// verified, it must come from the IAM token exchange
+func CallerIdentityFromAccessToken(accessToken AccessToken) (*CallerIdentity, error) {
+	token, _, err := new(jwt.Parser).ParseUnverified(accessToken.Token, &identityTokenClaims{})
+	if err != nil {
+		return nil, err
+	}
+	claims, haveClaims := token.Claims.(*identityTokenClaims)
+	if !haveClaims || claims.IAMID == "" {
+		return nil, errors.New("access token has no IAM identity")
+	}
+
+	identity := &CallerIdentity{
+		IAMID:     claims.IAMID,
+		Type:      claims.SubType,
+		Name:      claims.Name,
+		AccountID: claims.Account.Bss,
+	}
+	if identity.Type == "" {
+		identity.Type = IdentityTypeUser
+	}
+	if identity.Type == IdentityTypeUser && claims.Email != "" {
+		identity.Name = claims.Email
+	}
+	return identity, nil
+}
@@ -0,0 +1,72 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallerIdentityFromAccessToken(t *testing.T) {
+	fakeSigningKey := []byte("aabbccdd")
+	newToken := func(claims jwt.MapClaims) AccessToken {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(fakeSigningKey)
+		return AccessToken{Token: token}
+	}
+
+	testcases := []struct {
+		testcasename string
+		token        AccessToken
+		expected     *CallerIdentity
+		expectedErr  bool
+	}{
+		{
+			testcasename: "service ID",
+			token:        newToken(jwt.MapClaims{"iam_id": "iam-ServiceId-1", "sub_type": "ServiceId", "name": "csi-driver", "account": map[string]interface{}{"bss": "acc1"}}),
+			expected:     &CallerIdentity{IAMID: "iam-ServiceId-1", Type: IdentityTypeServiceID, Name: "csi-driver", AccountID: "acc1"},
+		},
+		{
+			testcasename: "user",
+			token:        newToken(jwt.MapClaims{"iam_id": "IBMid-1", "name": "Jane", "email": "jane@example.com", "account": map[string]interface{}{"bss": "acc1"}}),
+			expected:     &CallerIdentity{IAMID: "IBMid-1", Type: IdentityTypeUser, Name: "jane@example.com", AccountID: "acc1"},
+		},
+		{
+			testcasename: "no identity",
+			token:        newToken(jwt.MapClaims{"account": map[string]interface{}{"bss": "acc1"}}),
+			expectedErr:  true,
+		},
+		{
+			testcasename: "invalid token",
+			token:        AccessToken{Token: "invalid"},
+			expectedErr:  true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.testcasename, func(t *testing.T) {
+			identity, err := CallerIdentityFromAccessToken(testcase.token)
+			assert.Equal(t, testcase.expectedErr, err != nil)
+			assert.Equal(t, testcase.expected, identity)
+		})
+	}
+
+	identity := CallerIdentity{IAMID: "iam-ServiceId-1", Type: IdentityTypeServiceID, AccountID: "acc1"}
+	assert.Equal(t, "ServiceId iam-ServiceId-1 in account acc1", identity.String())
+}
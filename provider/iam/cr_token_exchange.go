@@ -0,0 +1,58 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
+)
+
+// CRTokenExchangeService is implemented by the token exchange services exchanging the compute resource (CR)
+// token of a pod for the access token of an IBM Cloud trusted profile
+type CRTokenExchangeService interface {
+	// ExchangeCRTokenForAccessToken exchanges the CR token for an access token of the trusted profile
+	ExchangeCRTokenForAccessToken(ctx context.Context, crToken, profileID string, logger *zap.Logger) (*AccessToken, error)
+}
+
+var _ CRTokenExchangeService = &tokenExchangeService{}
+
+// ExchangeCRTokenForAccessToken ...
+func (tes *tokenExchangeService) ExchangeCRTokenForAccessToken(ctx context.Context, crToken, profileID string, logger *zap.Logger) (*AccessToken, error) {
+	r := tes.newTokenExchangeRequest(ctx, logger)
+
+	r.request.Field("grant_type", "urn:ibm:params:oauth:grant-type:cr-token")
+	r.request.Field("cr_token", crToken)
+	r.request.Field("profile_id", profileID)
+
+	return r.exchangeForAccessToken()
+}
+
+var _ CRTokenExchangeService = &cachingTokenExchangeService{}
+
+// ExchangeCRTokenForAccessToken is not cached, the trusted profile authenticators cache their access token
+func (c *cachingTokenExchangeService) ExchangeCRTokenForAccessToken(ctx context.Context, crToken, profileID string, logger *zap.Logger) (*AccessToken, error) {
+	tes, ok := c.TokenExchangeService.(CRTokenExchangeService)
+	if !ok {
+		return nil, util.NewError(reasoncode.ErrorUnsupportedAuthType, "Token exchange service does not support compute resource tokens")
+	}
+	return tes.ExchangeCRTokenForAccessToken(ctx, crToken, profileID, logger)
+}
@@ -0,0 +1,327 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultTokenCacheTTL is the default lifetime of a cached token, below the one hour validity of IAM tokens
+const DefaultTokenCacheTTL = 50 * time.Minute
+
+// DefaultTokenRefreshWindow is the default time before the expiry of a cached token when it is refreshed in
+// the background, the cached token is returned meanwhile
+const DefaultTokenRefreshWindow = 5 * time.Minute
+
+// DefaultTokenExchangeTimeout bounds an exchange of the cache including its retries, it is not cancelled with the
+// context of the lookup starting it
+const DefaultTokenExchangeTimeout = 2 * time.Minute
+
+const (
+	accessTokenKind = "access"
+	imsTokenKind    = "ims"
+)
+
+// CredentialIdentity returns the identity of a credential (API key, profile ID ...) used as cache key.
+// It is a truncated SHA-256 hash, the credential itself is never stored or logged
+func CredentialIdentity(credential string) string {
+	sum := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(sum[:8])
+}
+
+// TokenCacheStats are the statistics of a TokenCache
+type TokenCacheStats struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Refreshes int64 `json:"refreshes"`
+	// SharedExchanges counts the lookups which waited for the exchange in flight of another lookup
+	SharedExchanges int64                `json:"sharedExchanges"`
+	Identities      []TokenIdentityStats `json:"identities"`
+}
+
+// TokenIdentityStats are the statistics of the cached token of one credential identity
+type TokenIdentityStats struct {
+	Identity  string    `json:"identity"`
+	Kind      string    `json:"kind"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type tokenCacheKey struct {
+	kind     string
+	identity string
+}
+
+type tokenCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// tokenFetch exchanges the credential for a new token
+type tokenFetch func(ctx context.Context) (interface{}, error)
+
+// tokenExchangeCall is an exchange in flight, shared by the concurrent lookups of the same token
+type tokenExchangeCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// TokenCache caches tokens per credential identity, so sessions of different accounts never share tokens.
+// Concurrent lookups of a missing token share a single exchange, and the tokens in use are refreshed in the
+// background before they expire
+type TokenCache struct {
+	ttl             time.Duration
+	exchangeTimeout time.Duration
+
+	mu              sync.Mutex
+	refreshWindow   time.Duration
+	entries         map[tokenCacheKey]*tokenCacheEntry
+	calls           map[tokenCacheKey]*tokenExchangeCall
+	hits            int64
+	misses          int64
+	refreshes       int64
+	sharedExchanges int64
+}
+
+var (
+	sharedTokenCache     *TokenCache
+	sharedTokenCacheOnce sync.Once
+)
+
+// NewTokenCache returns a TokenCache keeping tokens for ttl, DefaultTokenCacheTTL is used for a non-positive ttl.
+// The refresh window is DefaultTokenRefreshWindow, at most a fifth of ttl
+func NewTokenCache(ttl time.Duration) *TokenCache {
+	if ttl <= 0 {
+		ttl = DefaultTokenCacheTTL
+	}
+	c := &TokenCache{ttl: ttl, exchangeTimeout: DefaultTokenExchangeTimeout, entries: make(map[tokenCacheKey]*tokenCacheEntry), calls: make(map[tokenCacheKey]*tokenExchangeCall)}
+	c.SetRefreshWindow(DefaultTokenRefreshWindow)
+	return c
+}
+
+// SharedTokenCache returns the token cache of the process. The caching token exchange services built on it
+// (see NewCachingTokenExchangeService) exchange each API key once for all the sessions, instead of once per call
+func SharedTokenCache() *TokenCache {
+	sharedTokenCacheOnce.Do(func() {
+		sharedTokenCache = NewTokenCache(0)
+	})
+	return sharedTokenCache
+}
+
+// SetRefreshWindow sets the time before the expiry of a token when a lookup refreshes it in the background,
+// at most a fifth of the cache TTL. The background refresh is disabled for a non-positive window
+func (c *TokenCache) SetRefreshWindow(window time.Duration) {
+	if window > c.ttl/5 {
+		window = c.ttl / 5
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshWindow = window
+}
+
+// get returns the cached token of the credential, or fetches and caches a new one if missing or expired.
+// The token is refreshed in the background if it expires within the refresh window
+func (c *TokenCache) get(ctx context.Context, kind, credential string, fetch tokenFetch) (interface{}, error) {
+	key := tokenCacheKey{kind: kind, identity: CredentialIdentity(credential)}
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	if found && now.Before(entry.expiresAt) {
+		c.hits++
+		if _, inFlight := c.calls[key]; !inFlight && c.refreshWindow > 0 && !now.Before(entry.expiresAt.Add(-c.refreshWindow)) {
+			c.refreshes++
+			c.exchange(ctx, key, fetch)
+		}
+		c.mu.Unlock()
+		return entry.value, nil
+	}
+	if found {
+		c.refreshes++
+	} else {
+		c.misses++
+	}
+	call := c.exchange(ctx, key, fetch)
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// exchange returns the exchange in flight of the key, or starts one. The exchange is not cancelled with the
+// context of the lookup starting it, other lookups wait for it, it is bounded by the exchange timeout instead.
+// The caller holds c.mu
+func (c *TokenCache) exchange(ctx context.Context, key tokenCacheKey, fetch tokenFetch) *tokenExchangeCall {
+	if call, found := c.calls[key]; found {
+		c.sharedExchanges++
+		return call
+	}
+	call := &tokenExchangeCall{done: make(chan struct{})}
+	c.calls[key] = call
+	go func() {
+		start := time.Now()
+		fetchCtx, cancel := context.WithTimeout(detachedContext{ctx}, c.exchangeTimeout)
+		value, err := fetch(fetchCtx)
+		cancel()
+		c.mu.Lock()
+		// the exchange is dropped if the credential was invalidated meanwhile
+		if c.calls[key] == call {
+			delete(c.calls, key)
+			if err == nil {
+				c.entries[key] = &tokenCacheEntry{value: value, expiresAt: c.expiry(start, value)}
+			}
+		}
+		c.mu.Unlock()
+		call.value, call.err = value, err
+		close(call.done)
+	}()
+	return call
+}
+
+// expiry returns the expiry of a token exchanged at start, the TTL or the expiry of the token if earlier
+func (c *TokenCache) expiry(start time.Time, value interface{}) time.Time {
+	expiresAt := start.Add(c.ttl)
+	if token, ok := value.(*AccessToken); ok && !token.ExpiresAt.IsZero() && token.ExpiresAt.Before(expiresAt) {
+		expiresAt = token.ExpiresAt
+	}
+	return expiresAt
+}
+
+// Invalidate removes the cached tokens of the credential, the results of its exchanges in flight are not cached
+func (c *TokenCache) Invalidate(credential string) {
+	identity := CredentialIdentity(credential)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.identity == identity {
+			delete(c.entries, key)
+		}
+	}
+	for key := range c.calls {
+		if key.identity == identity {
+			delete(c.calls, key)
+		}
+	}
+}
+
+// detachedContext keeps the values of a context (e.g. the trace) but is never cancelled
+type detachedContext struct {
+	context.Context
+}
+
+// Deadline ...
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+// Done ...
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+// Err ...
+func (detachedContext) Err() error {
+	return nil
+}
+
+// Stats returns the cache statistics, identities are sorted
+func (c *TokenCache) Stats() TokenCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := TokenCacheStats{Hits: c.hits, Misses: c.misses, Refreshes: c.refreshes, SharedExchanges: c.sharedExchanges, Identities: []TokenIdentityStats{}}
+	for key, entry := range c.entries {
+		stats.Identities = append(stats.Identities, TokenIdentityStats{Identity: key.identity, Kind: key.kind, ExpiresAt: entry.expiresAt})
+	}
+	sort.Slice(stats.Identities, func(i, j int) bool {
+		a, b := stats.Identities[i], stats.Identities[j]
+		if a.Identity != b.Identity {
+			return a.Identity < b.Identity
+		}
+		return a.Kind < b.Kind
+	})
+	return stats
+}
+
+// cachingTokenExchangeService caches the API key exchanges of a TokenExchangeService
+type cachingTokenExchangeService struct {
+	TokenExchangeService
+	cache *TokenCache
+}
+
+var _ ContextTokenExchangeService = &cachingTokenExchangeService{}
+
+// NewCachingTokenExchangeService returns a TokenExchangeService caching the API key exchanges of tes
+// in cache, keyed by the API key identity
+func NewCachingTokenExchangeService(tes TokenExchangeService, cache *TokenCache) TokenExchangeService {
+	return &cachingTokenExchangeService{TokenExchangeService: tes, cache: cache}
+}
+
+// ExchangeIAMAPIKeyForIMSToken ...
+func (c *cachingTokenExchangeService) ExchangeIAMAPIKeyForIMSToken(iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
+	return c.ExchangeIAMAPIKeyForIMSTokenWithContext(context.Background(), iamAPIKey, logger)
+}
+
+// ExchangeIAMAPIKeyForIMSTokenWithContext ...
+func (c *cachingTokenExchangeService) ExchangeIAMAPIKeyForIMSTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
+	token, err := c.cache.get(ctx, imsTokenKind, iamAPIKey, func(ctx context.Context) (interface{}, error) {
+		logger.Debug("IMS token not cached, exchanging IAM API key", zap.String("Identity", CredentialIdentity(iamAPIKey)))
+		return ExchangeAPIKeyForIMSToken(ctx, c.TokenExchangeService, iamAPIKey, logger)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token.(*IMSToken), nil
+}
+
+// ExchangeIAMAPIKeyForAccessToken ...
+func (c *cachingTokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	return c.ExchangeIAMAPIKeyForAccessTokenWithContext(context.Background(), iamAPIKey, logger)
+}
+
+// ExchangeIAMAPIKeyForAccessTokenWithContext ...
+func (c *cachingTokenExchangeService) ExchangeIAMAPIKeyForAccessTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	token, err := c.cache.get(ctx, accessTokenKind, iamAPIKey, func(ctx context.Context) (interface{}, error) {
+		logger.Debug("Access token not cached, exchanging IAM API key", zap.String("Identity", CredentialIdentity(iamAPIKey)))
+		return ExchangeAPIKeyForAccessToken(ctx, c.TokenExchangeService, iamAPIKey, logger)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token.(*AccessToken), nil
+}
+
+// ExchangeRefreshTokenForAccessTokenWithContext is not cached
+func (c *cachingTokenExchangeService) ExchangeRefreshTokenForAccessTokenWithContext(ctx context.Context, refreshToken string, logger *zap.Logger) (*AccessToken, error) {
+	return ExchangeRefreshToken(ctx, c.TokenExchangeService, refreshToken, logger)
+}
+
+// ExchangeAccessTokenForIMSTokenWithContext is not cached
+func (c *cachingTokenExchangeService) ExchangeAccessTokenForIMSTokenWithContext(ctx context.Context, accessToken AccessToken, logger *zap.Logger) (*IMSToken, error) {
+	return ExchangeAccessToken(ctx, c.TokenExchangeService, accessToken, logger)
+}
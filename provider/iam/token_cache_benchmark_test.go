@@ -0,0 +1,70 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func BenchmarkTokenCacheHit(b *testing.B) {
+	logger := zap.NewNop()
+	tes := NewCachingTokenExchangeService(&countingTokenExchangeService{}, NewTokenCache(0))
+	_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	}
+}
+
+func BenchmarkTokenCacheHitParallel(b *testing.B) {
+	logger := zap.NewNop()
+	tes := NewCachingTokenExchangeService(&countingTokenExchangeService{}, NewTokenCache(0))
+	_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+		}
+	})
+}
+
+// BenchmarkTokenCacheInvalidate evicts one credential out of a cache of 1000 credentials
+func BenchmarkTokenCacheInvalidate(b *testing.B) {
+	logger := zap.NewNop()
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(&countingTokenExchangeService{}, cache)
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		_, _ = tes.ExchangeIAMAPIKeyForAccessToken(keys[i], logger)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		cache.Invalidate(key)
+		b.StopTimer()
+		_, _ = tes.ExchangeIAMAPIKeyForAccessToken(key, logger)
+		b.StartTimer()
+	}
+}
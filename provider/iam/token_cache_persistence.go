@@ -0,0 +1,103 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+)
+
+// persistedToken is a cached token as persisted to disk
+type persistedToken struct {
+	Kind      string    `json:"kind"`
+	Identity  string    `json:"identity"`
+	Token     string    `json:"token"`
+	UserID    int       `json:"userID,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Save persists the unexpired tokens to path, encrypted by the sealer (tokens are never persisted in clear)
+func (c *TokenCache) Save(path string, sealer *util.Sealer) error {
+	if sealer == nil {
+		return errors.New("token cache persistence requires an encryption key")
+	}
+	now := time.Now()
+	tokens := []persistedToken{}
+	c.mu.Lock()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			continue
+		}
+		token := persistedToken{Kind: key.kind, Identity: key.identity, ExpiresAt: entry.expiresAt}
+		switch value := entry.value.(type) {
+		case *AccessToken:
+			token.Token = value.Token
+		case *IMSToken:
+			token.Token, token.UserID = value.Token, value.UserID
+		default:
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	c.mu.Unlock()
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	return util.WriteSealedFile(path, sealer, data)
+}
+
+// Load restores the unexpired tokens persisted by Save, e.g. after a driver restart. Files sealed with a
+// previous key are re-sealed with the active key
+func (c *TokenCache) Load(path string, sealer *util.Sealer) error {
+	if sealer == nil {
+		return errors.New("token cache persistence requires an encryption key")
+	}
+	data, err := util.ReadSealedFile(path, sealer)
+	if err != nil {
+		return err
+	}
+	tokens := []persistedToken{}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, token := range tokens {
+		if !now.Before(token.ExpiresAt) {
+			continue
+		}
+		var value interface{}
+		switch token.Kind {
+		case accessTokenKind:
+			value = &AccessToken{Token: token.Token}
+		case imsTokenKind:
+			value = &IMSToken{Token: token.Token, UserID: token.UserID}
+		default:
+			continue
+		}
+		c.entries[tokenCacheKey{kind: token.Kind, identity: token.Identity}] = &tokenCacheEntry{value: value, expiresAt: token.ExpiresAt}
+	}
+	return nil
+}
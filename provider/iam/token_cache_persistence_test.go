@@ -0,0 +1,68 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTokenCachePersistence(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	path := filepath.Join(t.TempDir(), "tokens")
+	sealer, err := util.NewSealer(util.StaticKeyProvider{{ID: "v1", Key: bytes.Repeat([]byte{1}, 32)}})
+	assert.Nil(t, err)
+
+	backend := &countingTokenExchangeService{}
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(backend, cache)
+	_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	_, _ = tes.ExchangeIAMAPIKeyForIMSToken("key-a", logger)
+	assert.Nil(t, cache.Save(path, sealer))
+
+	raw, _ := os.ReadFile(path)
+	assert.False(t, bytes.Contains(raw, []byte("access-key-a")))
+
+	// restored after restart, no exchange
+	restored := NewTokenCache(0)
+	assert.Nil(t, restored.Load(path, sealer))
+	restoredTes := NewCachingTokenExchangeService(backend, restored)
+	accessToken, err := restoredTes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "access-key-a", accessToken.Token)
+	imsToken, err := restoredTes.ExchangeIAMAPIKeyForIMSToken("key-a", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "ims-key-a", imsToken.Token)
+	assert.Equal(t, 2, backend.exchanges)
+
+	// rotated key
+	rotated, _ := util.NewSealer(util.StaticKeyProvider{{ID: "v2", Key: bytes.Repeat([]byte{2}, 32)}, {ID: "v1", Key: bytes.Repeat([]byte{1}, 32)}})
+	assert.Nil(t, NewTokenCache(0).Load(path, rotated))
+
+	assert.NotNil(t, cache.Save(path, nil))
+	assert.NotNil(t, NewTokenCache(0).Load(path, nil))
+	wrongKey, _ := util.NewSealer(util.StaticKeyProvider{config.EncryptionKey{ID: "v3", Key: bytes.Repeat([]byte{3}, 32)}})
+	assert.NotNil(t, NewTokenCache(0).Load(path, wrongKey))
+}
@@ -0,0 +1,227 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package iam ...
+package iam
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// countingTokenExchangeService returns a distinct token per API key and counts the exchanges
+type countingTokenExchangeService struct {
+	TokenExchangeService
+	exchanges int
+	err       error
+}
+
+func (s *countingTokenExchangeService) ExchangeIAMAPIKeyForIMSToken(iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
+	s.exchanges++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &IMSToken{UserID: s.exchanges, Token: "ims-" + iamAPIKey}, nil
+}
+
+func (s *countingTokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	s.exchanges++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &AccessToken{Token: "access-" + iamAPIKey}, nil
+}
+
+func TestCredentialIdentity(t *testing.T) {
+	assert.Equal(t, CredentialIdentity("key-a"), CredentialIdentity("key-a"))
+	assert.NotEqual(t, CredentialIdentity("key-a"), CredentialIdentity("key-b"))
+	assert.NotContains(t, CredentialIdentity("key-a"), "key-a")
+}
+
+func TestCachingTokenExchangeService(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &countingTokenExchangeService{}
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(backend, cache)
+
+	tokenA, err := tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	assert.Nil(t, err)
+	tokenB, err := tes.ExchangeIAMAPIKeyForAccessToken("key-b", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "access-key-a", tokenA.Token)
+	assert.Equal(t, "access-key-b", tokenB.Token)
+
+	tokenA, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	assert.Equal(t, "access-key-a", tokenA.Token)
+	imsToken, _ := tes.ExchangeIAMAPIKeyForIMSToken("key-a", logger)
+	assert.Equal(t, "ims-key-a", imsToken.Token)
+	assert.Equal(t, 3, backend.exchanges)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(3), stats.Misses)
+	assert.Equal(t, 3, len(stats.Identities))
+	for _, identity := range stats.Identities {
+		assert.True(t, identity.ExpiresAt.After(time.Now()))
+	}
+
+	cache.Invalidate("key-a")
+	assert.Equal(t, 1, len(cache.Stats().Identities))
+
+	backend.err = errors.New("exchange failed")
+	_, err = tes.ExchangeIAMAPIKeyForIMSToken("key-c", logger)
+	assert.NotNil(t, err)
+
+	// the backend does not exchange compute resource tokens
+	_, err = tes.(CRTokenExchangeService).ExchangeCRTokenForAccessToken(context.Background(), "cr-1", "Profile-1", logger)
+	assert.NotNil(t, err)
+}
+
+func TestTokenCacheRefresh(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &countingTokenExchangeService{}
+	cache := NewTokenCache(time.Millisecond)
+	tes := NewCachingTokenExchangeService(backend, cache)
+
+	_, _ = tes.ExchangeIAMAPIKeyForIMSToken("key-a", logger)
+	time.Sleep(5 * time.Millisecond)
+	token, err := tes.ExchangeIAMAPIKeyForIMSToken("key-a", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, token.UserID)
+	assert.Equal(t, int64(1), cache.Stats().Refreshes)
+}
+
+// blockingTokenExchangeService blocks the exchanges until released and counts them
+type blockingTokenExchangeService struct {
+	TokenExchangeService
+	release   chan struct{}
+	exchanges int32
+	expiresAt time.Time
+}
+
+func (s *blockingTokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	<-s.release
+	atomic.AddInt32(&s.exchanges, 1)
+	return &AccessToken{Token: "access-" + iamAPIKey, ExpiresAt: s.expiresAt}, nil
+}
+
+func TestTokenCacheSingleFlight(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &blockingTokenExchangeService{release: make(chan struct{})}
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(backend, cache)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+			assert.Nil(t, err)
+			assert.Equal(t, "access-key-a", token.Token)
+		}()
+	}
+	assert.Eventually(t, func() bool {
+		stats := cache.Stats()
+		return stats.Misses == 10
+	}, time.Second, time.Millisecond)
+	close(backend.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&backend.exchanges))
+	assert.Equal(t, int64(9), cache.Stats().SharedExchanges)
+}
+
+func TestTokenCacheLookupCancelled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &blockingTokenExchangeService{release: make(chan struct{})}
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(backend, cache).(ContextTokenExchangeService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := tes.ExchangeIAMAPIKeyForAccessTokenWithContext(ctx, "key-a", logger)
+	assert.Equal(t, context.Canceled, err)
+
+	// the exchange started by the cancelled lookup completes and is cached
+	close(backend.release)
+	assert.Eventually(t, func() bool { return len(cache.Stats().Identities) == 1 }, time.Second, time.Millisecond)
+	_, err = tes.ExchangeIAMAPIKeyForAccessTokenWithContext(context.Background(), "key-a", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&backend.exchanges))
+}
+
+func TestTokenCacheExchangeTimeout(t *testing.T) {
+	cache := NewTokenCache(0)
+	cache.exchangeTimeout = 10 * time.Millisecond
+	_, err := cache.get(context.Background(), accessTokenKind, "key-a", func(fetchCtx context.Context) (interface{}, error) {
+		<-fetchCtx.Done()
+		return nil, fetchCtx.Err()
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Empty(t, cache.Stats().Identities)
+}
+
+func TestTokenCacheBackgroundRefresh(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &blockingTokenExchangeService{release: make(chan struct{})}
+	close(backend.release)
+	cache := NewTokenCache(time.Hour)
+	cache.SetRefreshWindow(time.Hour)
+	tes := NewCachingTokenExchangeService(backend, cache)
+
+	// the token expires within the window (capped to 12 minutes), lookups return it and refresh it in the background
+	backend.expiresAt = time.Now().Add(10 * time.Minute)
+	_, err := tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, backend.expiresAt, cache.Stats().Identities[0].ExpiresAt)
+
+	_, err = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&backend.exchanges) == 2 }, time.Second, time.Millisecond)
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Refreshes)
+}
+
+func TestTokenCacheInvalidateInFlight(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	backend := &blockingTokenExchangeService{release: make(chan struct{})}
+	cache := NewTokenCache(0)
+	tes := NewCachingTokenExchangeService(backend, cache)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = tes.ExchangeIAMAPIKeyForAccessToken("key-a", logger)
+	}()
+	assert.Eventually(t, func() bool { return cache.Stats().Misses == 1 }, time.Second, time.Millisecond)
+	cache.Invalidate("key-a")
+	close(backend.release)
+	<-done
+	assert.Empty(t, cache.Stats().Identities)
+}
+
+func TestSharedTokenCache(t *testing.T) {
+	assert.Same(t, SharedTokenCache(), SharedTokenCache())
+}
@@ -18,6 +18,7 @@
 package iam
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -29,10 +30,13 @@ import (
 
 	"github.com/IBM-Cloud/ibm-cloud-cli-sdk/common/rest"
 	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/tracing"
 	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
 	"github.com/IBM/secret-common-lib/pkg/secret_provider"
 	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
 	sp "github.com/IBM/secret-utils-lib/pkg/secret_provider"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -56,24 +60,36 @@ type AuthConfiguration struct {
 	IamURL          string
 	IamClientID     string
 	IamClientSecret string
+
+	// CircuitBreaker fast-fails the exchanges while the IAM endpoint is failing, e.g. the breaker of
+	// util.NewCircuitBreakerFromConfig. The exchanges are always sent if nil
+	CircuitBreaker *util.CircuitBreaker
+
+	// HTTPClient sends the exchanges of NewTokenExchangeService, config.GeneralCAHttpClient if nil. The client of
+	// config.VPCProviderConfig.HTTPClient reaches the IAM VPE gateway of the use_vpe configs, with IamURL set to
+	// the VPCProviderConfig.TokenExchangeEndpointURL
+	HTTPClient *http.Client
 }
 
 // TokenExchangeService ...
-var _ TokenExchangeService = &tokenExchangeService{}
+var _ ContextTokenExchangeService = &tokenExchangeService{}
 
 // NewTokenExchangeServiceWithClient ...
 func NewTokenExchangeServiceWithClient(authConfig *AuthConfiguration, httpClient *http.Client) (TokenExchangeService, error) {
 	return &tokenExchangeService{
 		authConfig: authConfig,
-		httpClient: httpClient,
+		httpClient: withPayloadMetrics(withCircuitBreaker(httpClient, authConfig.CircuitBreaker)),
 	}, nil
 }
 
 // NewTokenExchangeService ...
 func NewTokenExchangeService(authConfig *AuthConfiguration, k8sClient *k8s_utils.KubernetesClient, providerType ...string) (TokenExchangeService, error) {
-	httpClient, err := config.GeneralCAHttpClient()
-	if err != nil {
-		return nil, err
+	httpClient := authConfig.HTTPClient
+	if httpClient == nil {
+		var err error
+		if httpClient, err = config.GeneralCAHttpClient(); err != nil {
+			return nil, err
+		}
 	}
 
 	providerTypeArg := make(map[string]string)
@@ -90,13 +106,38 @@ func NewTokenExchangeService(authConfig *AuthConfiguration, k8sClient *k8s_utils
 
 	return &tokenExchangeService{
 		authConfig:     authConfig,
-		httpClient:     httpClient,
+		httpClient:     withPayloadMetrics(withCircuitBreaker(httpClient, authConfig.CircuitBreaker)),
 		secretprovider: spObject,
 	}, nil
 }
 
+// withCircuitBreaker returns a copy of the client sending its requests through the breaker, the client itself if
+// the breaker is nil
+func withCircuitBreaker(httpClient *http.Client, breaker *util.CircuitBreaker) *http.Client {
+	if breaker == nil {
+		return httpClient
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := *httpClient
+	client.Transport = breaker.Transport(httpClient.Transport)
+	return &client
+}
+
+// withPayloadMetrics returns a copy of the client recording the payload sizes of the exchanges
+func withPayloadMetrics(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := *httpClient
+	client.Transport = &metrics.PayloadTransport{Base: httpClient.Transport}
+	return &client
+}
+
 // tokenExchangeRequest ...
 type tokenExchangeRequest struct {
+	ctx          context.Context
 	tes          *tokenExchangeService
 	request      *rest.Request
 	client       *rest.Client
@@ -109,11 +150,17 @@ type tokenExchangeResponse struct {
 	AccessToken string `json:"access_token"`
 	ImsToken    string `json:"ims_token"`
 	ImsUserID   int    `json:"ims_user_id"`
+	ExpiresIn   int64  `json:"expires_in"`
 }
 
 // ExchangeRefreshTokenForAccessToken ...
 func (tes *tokenExchangeService) ExchangeRefreshTokenForAccessToken(refreshToken string, logger *zap.Logger) (*AccessToken, error) {
-	r := tes.newTokenExchangeRequest(logger)
+	return tes.ExchangeRefreshTokenForAccessTokenWithContext(context.Background(), refreshToken, logger)
+}
+
+// ExchangeRefreshTokenForAccessTokenWithContext ...
+func (tes *tokenExchangeService) ExchangeRefreshTokenForAccessTokenWithContext(ctx context.Context, refreshToken string, logger *zap.Logger) (*AccessToken, error) {
+	r := tes.newTokenExchangeRequest(ctx, logger)
 
 	r.request.Field("grant_type", "refresh_token")
 	r.request.Field("refresh_token", refreshToken)
@@ -123,7 +170,12 @@ func (tes *tokenExchangeService) ExchangeRefreshTokenForAccessToken(refreshToken
 
 // ExchangeAccessTokenForIMSToken ...
 func (tes *tokenExchangeService) ExchangeAccessTokenForIMSToken(accessToken AccessToken, logger *zap.Logger) (*IMSToken, error) {
-	r := tes.newTokenExchangeRequest(logger)
+	return tes.ExchangeAccessTokenForIMSTokenWithContext(context.Background(), accessToken, logger)
+}
+
+// ExchangeAccessTokenForIMSTokenWithContext ...
+func (tes *tokenExchangeService) ExchangeAccessTokenForIMSTokenWithContext(ctx context.Context, accessToken AccessToken, logger *zap.Logger) (*IMSToken, error) {
+	r := tes.newTokenExchangeRequest(ctx, logger)
 
 	r.request.Field("grant_type", "urn:ibm:params:oauth:grant-type:derive")
 	r.request.Field("response_type", "ims_portal")
@@ -134,7 +186,12 @@ func (tes *tokenExchangeService) ExchangeAccessTokenForIMSToken(accessToken Acce
 
 // ExchangeIAMAPIKeyForIMSToken ...
 func (tes *tokenExchangeService) ExchangeIAMAPIKeyForIMSToken(iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
-	r := tes.newTokenExchangeRequest(logger)
+	return tes.ExchangeIAMAPIKeyForIMSTokenWithContext(context.Background(), iamAPIKey, logger)
+}
+
+// ExchangeIAMAPIKeyForIMSTokenWithContext ...
+func (tes *tokenExchangeService) ExchangeIAMAPIKeyForIMSTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
+	r := tes.newTokenExchangeRequest(ctx, logger)
 
 	r.request.Field("grant_type", "urn:ibm:params:oauth:grant-type:apikey")
 	r.request.Field("response_type", "ims_portal")
@@ -145,8 +202,19 @@ func (tes *tokenExchangeService) ExchangeIAMAPIKeyForIMSToken(iamAPIKey string,
 
 // ExchangeIAMAPIKeyForAccessToken ...
 func (tes *tokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	return tes.ExchangeIAMAPIKeyForAccessTokenWithContext(context.Background(), iamAPIKey, logger)
+}
+
+// ExchangeIAMAPIKeyForAccessTokenWithContext fetches the token with the secret provider, which does not
+// accept a context: the context is only checked before fetching
+func (tes *tokenExchangeService) ExchangeIAMAPIKeyForAccessTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	logger.Info("Fetching using secret provider")
+	_, span := tracing.StartOperation(ctx, "IAMTokenExchange", tracing.AttributeTokenExchange.String("secret_provider"))
 	token, _, err := tes.secretprovider.GetDefaultIAMToken(false)
+	tracing.End(span, err)
 	if err != nil {
 		logger.Error("Error fetching iam token", zap.Error(err))
 		return nil, err
@@ -159,24 +227,36 @@ func (tes *tokenExchangeService) ExchangeIAMAPIKeyForAccessToken(iamAPIKey strin
 func (r *tokenExchangeRequest) exchangeForAccessToken() (*AccessToken, error) {
 	var iamResp *tokenExchangeResponse
 	var err error
-	err = r.errorRetrier.ErrorRetry(func() (error, bool) {
+	span := r.startSpan("access_token")
+	defer func() { tracing.End(span, err) }()
+	start := time.Now()
+	err = r.errorRetrier.ErrorRetryWithContext(r.ctx, func() (error, bool) {
 		iamResp, err = r.sendTokenExchangeRequest()
 		return err, !IsConnectionError(err) // Skip rettry if its not connection error
 	})
+	metrics.Recorder().ObserveTokenExchange("access_token", time.Since(start), err != nil)
 	if err != nil {
 		return nil, err
 	}
-	return &AccessToken{Token: iamResp.AccessToken}, nil
+	token := &AccessToken{Token: iamResp.AccessToken}
+	if iamResp.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(iamResp.ExpiresIn) * time.Second)
+	}
+	return token, nil
 }
 
 // exchangeForIMSToken ...
 func (r *tokenExchangeRequest) exchangeForIMSToken() (*IMSToken, error) {
 	var iamResp *tokenExchangeResponse
 	var err error
-	err = r.errorRetrier.ErrorRetry(func() (error, bool) {
+	span := r.startSpan("ims_token")
+	defer func() { tracing.End(span, err) }()
+	start := time.Now()
+	err = r.errorRetrier.ErrorRetryWithContext(r.ctx, func() (error, bool) {
 		iamResp, err = r.sendTokenExchangeRequest()
 		return err, !IsConnectionError(err)
 	})
+	metrics.Recorder().ObserveTokenExchange("ims_token", time.Since(start), err != nil)
 
 	if err != nil {
 		return nil, err
@@ -188,17 +268,60 @@ func (r *tokenExchangeRequest) exchangeForIMSToken() (*IMSToken, error) {
 }
 
 // newTokenExchangeRequest ...
-func (tes *tokenExchangeService) newTokenExchangeRequest(logger *zap.Logger) *tokenExchangeRequest {
+func (tes *tokenExchangeService) newTokenExchangeRequest(ctx context.Context, logger *zap.Logger) *tokenExchangeRequest {
 	client := rest.NewClient()
-	client.HTTPClient = tes.httpClient
+	client.HTTPClient = withRequestContext(ctx, tes.httpClient)
 	retyrInterval, _ := time.ParseDuration("3s")
+	errorRetrier := util.NewErrorRetrier(40, retyrInterval, logger)
+	errorRetrier.Operation = "IAMTokenExchange"
 	return &tokenExchangeRequest{
+		ctx:          ctx,
 		tes:          tes,
 		request:      rest.PostRequest(fmt.Sprintf("%s/oidc/token", tes.authConfig.IamURL)),
 		client:       client,
 		logger:       logger,
-		errorRetrier: util.NewErrorRetrier(40, retyrInterval, logger),
+		errorRetrier: errorRetrier,
+	}
+}
+
+// startSpan starts the span of the token exchange, the request is then sent with the trace context of the span
+func (r *tokenExchangeRequest) startSpan(exchange string) trace.Span {
+	ctx, span := tracing.StartOperation(r.ctx, "IAMTokenExchange", tracing.AttributeTokenExchange.String(exchange))
+	r.ctx = ctx
+	r.client.HTTPClient = withRequestContext(ctx, r.tes.httpClient)
+	return span
+}
+
+// contextTransport sends the requests with the context, and its trace context, as the rest client does not accept one
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+var _ http.RoundTripper = &contextTransport{}
+
+// RoundTrip ...
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	traced := req.Clone(t.ctx)
+	tracing.InjectHeaders(t.ctx, traced.Header)
+	return t.base.RoundTrip(traced)
+}
+
+// withRequestContext returns a copy of the client sending its requests with the context
+func withRequestContext(ctx context.Context, httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if ctx == context.Background() {
+		return httpClient
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
 	}
+	client := *httpClient
+	client.Transport = &contextTransport{ctx: ctx, base: base}
+	return &client
 }
 
 // sendTokenExchangeRequest ...
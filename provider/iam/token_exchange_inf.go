@@ -18,6 +18,9 @@
 package iam
 
 import (
+	"context"
+	"time"
+
 	"go.uber.org/zap"
 )
 
@@ -30,6 +33,9 @@ type IMSToken struct {
 // AccessToken ...
 type AccessToken struct {
 	Token string `json:"-"` // Do not trace
+
+	// ExpiresAt is the expiry returned by the token exchange, zero if unknown
+	ExpiresAt time.Time `json:"-"`
 }
 
 // TokenExchangeService ...
@@ -51,3 +57,66 @@ type TokenExchangeService interface {
 	// GetIAMAccountIDFromAccessToken ...
 	GetIAMAccountIDFromAccessToken(accessToken AccessToken, logger *zap.Logger) (string, error)
 }
+
+// ContextTokenExchangeService is optionally implemented by the token exchange services honoring the
+// deadline and cancellation of a context, e.g. the gRPC request of a CSI driver
+type ContextTokenExchangeService interface {
+	TokenExchangeService
+
+	// ExchangeRefreshTokenForAccessTokenWithContext ...
+	ExchangeRefreshTokenForAccessTokenWithContext(ctx context.Context, refreshToken string, logger *zap.Logger) (*AccessToken, error)
+
+	// ExchangeAccessTokenForIMSTokenWithContext ...
+	ExchangeAccessTokenForIMSTokenWithContext(ctx context.Context, accessToken AccessToken, logger *zap.Logger) (*IMSToken, error)
+
+	// ExchangeIAMAPIKeyForIMSTokenWithContext ...
+	ExchangeIAMAPIKeyForIMSTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*IMSToken, error)
+
+	// ExchangeIAMAPIKeyForAccessTokenWithContext ...
+	ExchangeIAMAPIKeyForAccessTokenWithContext(ctx context.Context, iamAPIKey string, logger *zap.Logger) (*AccessToken, error)
+}
+
+// ExchangeRefreshToken exchanges the refresh token with the context variant of tes if implemented,
+// otherwise the context is only checked before the exchange
+func ExchangeRefreshToken(ctx context.Context, tes TokenExchangeService, refreshToken string, logger *zap.Logger) (*AccessToken, error) {
+	if ctes, ok := tes.(ContextTokenExchangeService); ok {
+		return ctes.ExchangeRefreshTokenForAccessTokenWithContext(ctx, refreshToken, logger)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tes.ExchangeRefreshTokenForAccessToken(refreshToken, logger)
+}
+
+// ExchangeAccessToken exchanges the access token for an IMS token, see ExchangeRefreshToken
+func ExchangeAccessToken(ctx context.Context, tes TokenExchangeService, accessToken AccessToken, logger *zap.Logger) (*IMSToken, error) {
+	if ctes, ok := tes.(ContextTokenExchangeService); ok {
+		return ctes.ExchangeAccessTokenForIMSTokenWithContext(ctx, accessToken, logger)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tes.ExchangeAccessTokenForIMSToken(accessToken, logger)
+}
+
+// ExchangeAPIKeyForIMSToken exchanges the IAM API key for an IMS token, see ExchangeRefreshToken
+func ExchangeAPIKeyForIMSToken(ctx context.Context, tes TokenExchangeService, iamAPIKey string, logger *zap.Logger) (*IMSToken, error) {
+	if ctes, ok := tes.(ContextTokenExchangeService); ok {
+		return ctes.ExchangeIAMAPIKeyForIMSTokenWithContext(ctx, iamAPIKey, logger)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tes.ExchangeIAMAPIKeyForIMSToken(iamAPIKey, logger)
+}
+
+// ExchangeAPIKeyForAccessToken exchanges the IAM API key for an access token, see ExchangeRefreshToken
+func ExchangeAPIKeyForAccessToken(ctx context.Context, tes TokenExchangeService, iamAPIKey string, logger *zap.Logger) (*AccessToken, error) {
+	if ctes, ok := tes.(ContextTokenExchangeService); ok {
+		return ctes.ExchangeIAMAPIKeyForAccessTokenWithContext(ctx, iamAPIKey, logger)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return tes.ExchangeIAMAPIKeyForAccessToken(iamAPIKey, logger)
+}
@@ -18,6 +18,7 @@
 package iam
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -27,12 +28,18 @@ import (
 	"testing"
 	"time"
 
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/tracing"
 	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
 	"github.com/IBM/ibmcloud-volume-interface/lib/utils/reasoncode"
 
 	"github.com/IBM/ibmcloud-volume-interface/config"
 	"github.com/IBM/secret-utils-lib/pkg/k8s_utils"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -248,6 +255,10 @@ func TestNewTokenExchangeServiceWithClient(t *testing.T) {
 	tes, err := NewTokenExchangeServiceWithClient(authConfig, newClient)
 	assert.NoError(t, err)
 	assert.NotNil(t, tes)
+	// the payload sizes of the exchanges are recorded, the client of the caller is not modified
+	assert.IsType(t, &metrics.PayloadTransport{}, tes.(*tokenExchangeService).httpClient.Transport)
+	assert.Equal(t, newClient.Timeout, tes.(*tokenExchangeService).httpClient.Timeout)
+	assert.Nil(t, newClient.Transport)
 }
 
 func TestExchangeIAMAPIKeyForIMSToken(t *testing.T) {
@@ -510,6 +521,15 @@ func Test_NewTokenExchangeService(t *testing.T) {
 	err = k8s_utils.FakeCreateSecret(k8sClient, "DEFAULT", file)
 	_, err = NewTokenExchangeService(authConfig, &k8sClient)
 	assert.Nil(t, err)
+
+	// The exchanges reach the IAM VPE gateway with the client of the VPE config
+	vpc := &config.VPCProviderConfig{UseVPE: true, VPETokenExchangeURL: server.URL, VPETLSServerName: "iam.cloud.ibm.com"}
+	vpeClient, err := vpc.HTTPClient(time.Minute)
+	assert.Nil(t, err)
+	tes, err := NewTokenExchangeService(&AuthConfiguration{IamURL: vpc.TokenExchangeEndpointURL(), HTTPClient: vpeClient}, &k8sClient)
+	assert.Nil(t, err)
+	transport := tes.(*tokenExchangeService).httpClient.Transport.(*metrics.PayloadTransport)
+	assert.Equal(t, "iam.cloud.ibm.com", transport.Base.(*http.Transport).TLSClientConfig.ServerName)
 }
 
 const (
@@ -561,3 +581,141 @@ func (fs *FakeSecretProvider) GetPrivateContainerAPIRoute(readConfig bool) (stri
 func (fs *FakeSecretProvider) GetResourceGroupID() string {
 	return "resource-group-id"
 }
+
+func Test_ExchangeIAMAPIKeyForIMSTokenWithContext_Deadline(t *testing.T) {
+	httpSetup()
+
+	// IAM endpoint slower than the deadline of the caller
+	mux.HandleFunc("/oidc/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			w.WriteHeader(200)
+			fmt.Fprint(w, `{"ims_token": "ims_token_1","ims_user_id": 123}`)
+		},
+	)
+
+	authConfig := &AuthConfiguration{
+		IamURL:          server.URL,
+		IamClientID:     "test",
+		IamClientSecret: "secret",
+	}
+	tes, _ := NewTokenExchangeServiceWithClient(authConfig, http.DefaultClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	r, err := ExchangeAPIKeyForIMSToken(ctx, tes, "apikey1", logger)
+	assert.Nil(t, r)
+	assert.NotNil(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = ExchangeAPIKeyForIMSToken(ctx, tes, "apikey1", logger)
+	assert.NotNil(t, err)
+}
+
+func Test_ExchangeRefreshTokenForAccessTokenWithContext_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(nil)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	httpSetup()
+
+	var traceparent string
+	mux.HandleFunc("/oidc/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			w.WriteHeader(200)
+			fmt.Fprint(w, `{"access_token": "at_success"}`)
+		},
+	)
+
+	authConfig := &AuthConfiguration{
+		IamURL:          server.URL,
+		IamClientID:     "test",
+		IamClientSecret: "secret",
+	}
+	tes, _ := NewTokenExchangeServiceWithClient(authConfig, http.DefaultClient)
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "CSI.CreateVolume")
+	_, err := ExchangeRefreshToken(ctx, tes, "testrefreshtoken", logger)
+	parent.End()
+	assert.Nil(t, err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "IAMTokenExchange", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), tracing.AttributeTokenExchange.String("access_token"))
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+	}
+}
+
+func Test_ExchangeCRTokenForAccessToken(t *testing.T) {
+	httpSetup()
+
+	// IAM endpoint
+	mux.HandleFunc("/oidc/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "urn:ibm:params:oauth:grant-type:cr-token" || r.Form.Get("cr_token") != "cr-1" || r.Form.Get("profile_id") != "Profile-1" {
+				w.WriteHeader(400)
+				fmt.Fprint(w, `{"errorMessage": "Invalid compute resource token", "errorCode": "BXNIM0400E"}`)
+				return
+			}
+			w.WriteHeader(200)
+			fmt.Fprint(w, `{"access_token": "at_profile", "expires_in": 3600}`)
+		},
+	)
+
+	authConfig := &AuthConfiguration{
+		IamURL:          server.URL,
+		IamClientID:     "test",
+		IamClientSecret: "secret",
+	}
+	tes, _ := NewTokenExchangeServiceWithClient(authConfig, http.DefaultClient)
+	crtes := tes.(CRTokenExchangeService)
+
+	r, err := crtes.ExchangeCRTokenForAccessToken(context.Background(), "cr-1", "Profile-1", logger)
+	assert.Nil(t, err)
+	if assert.NotNil(t, r) {
+		assert.Equal(t, "at_profile", r.Token)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), r.ExpiresAt, time.Minute)
+	}
+
+	_, err = crtes.ExchangeCRTokenForAccessToken(context.Background(), "cr-2", "Profile-1", logger)
+	assert.NotNil(t, err)
+}
+
+func TestTokenExchangeCircuitBreaker(t *testing.T) {
+	httpSetup()
+	requests := 0
+	mux.HandleFunc("/oidc/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(500)
+		},
+	)
+
+	authConfig := &AuthConfiguration{
+		IamURL:          server.URL,
+		IamClientID:     "test",
+		IamClientSecret: "secret",
+		CircuitBreaker:  util.NewCircuitBreaker(1, time.Minute, logger),
+	}
+	tes, _ := NewTokenExchangeServiceWithClient(authConfig, http.DefaultClient)
+
+	_, err := tes.ExchangeIAMAPIKeyForIMSToken("apikey1", logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, requests)
+
+	// the circuit of the IAM endpoint is open, the exchange is not sent
+	_, err = tes.ExchangeIAMAPIKeyForIMSToken("apikey1", logger)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, requests)
+	// the client of the caller is not modified
+	assert.Nil(t, http.DefaultClient.Transport)
+}
@@ -0,0 +1,253 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry holds the providers of a consumer, the sessions opened through it and the shutdown hooks,
+// so the consumer tears everything down with a single Shutdown in dependency order
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+// ShutdownPhase orders the shutdown hooks, phases run in increasing order and the sessions opened through
+// the registry are closed after the last phase
+type ShutdownPhase int
+
+const (
+	// ShutdownDrain stops accepting work and drains work queues and background tasks
+	ShutdownDrain ShutdownPhase = iota
+	// ShutdownFlush flushes audit and metrics buffers
+	ShutdownFlush
+	// ShutdownPersist persists the state stores and caches
+	ShutdownPersist
+)
+
+// ErrShutdown is returned when using a registry which is shut down
+var ErrShutdown = errors.New("provider registry is shut down")
+
+// ShutdownHook is called once on shutdown, ctx bounds the whole shutdown
+type ShutdownHook func(ctx context.Context) error
+
+type shutdownHook struct {
+	phase ShutdownPhase
+	name  string
+	hook  ShutdownHook
+}
+
+// Hooks are the shutdown hooks of a registry
+type Hooks struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+}
+
+// OnShutdown registers a hook run in the phase, hooks of a phase run in registration order
+func (h *Hooks) OnShutdown(phase ShutdownPhase, name string, hook ShutdownHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, shutdownHook{phase: phase, name: name, hook: hook})
+}
+
+func (h *Hooks) ordered() []shutdownHook {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hooks := append([]shutdownHook(nil), h.hooks...)
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].phase < hooks[j].phase })
+	return hooks
+}
+
+// Registry registers the providers by name and tracks the sessions opened through it
+type Registry struct {
+	// Hooks run on Shutdown
+	Hooks Hooks
+
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	providers map[string]local.Provider
+	sessions  []provider.Session
+	tracker   *util.ActivityTracker
+	shutdown  bool
+}
+
+// New returns an empty registry
+func New(logger *zap.Logger) *Registry {
+	return &Registry{logger: logger, providers: map[string]local.Provider{}}
+}
+
+// Register registers the provider under the name
+func (r *Registry) Register(name string, p local.Provider) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		return ErrShutdown
+	}
+	if _, exists := r.providers[name]; exists {
+		return fmt.Errorf("provider '%s' is already registered", name)
+	}
+	r.providers[name] = p
+	return nil
+}
+
+// Get returns the provider registered under the name
+func (r *Registry) Get(name string) (local.Provider, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, found := r.providers[name]
+	if !found {
+		return nil, fmt.Errorf("provider '%s' is not registered", name)
+	}
+	return p, nil
+}
+
+// UseActivityTracker records the mutating operations of the sessions opened afterwards with the tracker of the
+// [activity_tracker] config, nothing is recorded if it is not enabled. The location is the region of the provider.
+// The tracker is closed on Shutdown, in the flush phase
+func (r *Registry) UseActivityTracker(at *config.ActivityTrackerConfig, location string) error {
+	tracker, err := util.ActivityTrackerFromConfig(at, location, r.logger)
+	if err != nil || tracker == nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		_ = tracker.Close()
+		return ErrShutdown
+	}
+	if r.tracker != nil {
+		_ = tracker.Close()
+		return errors.New("an activity tracker is already used")
+	}
+	r.tracker = tracker
+	r.Hooks.OnShutdown(ShutdownFlush, "activity tracker", func(ctx context.Context) error {
+		return tracker.Close()
+	})
+	return nil
+}
+
+// OpenSession opens a session of the named provider, it is closed on Shutdown. Its mutating operations are
+// refused during a maintenance freeze and recorded by the activity tracker of the registry (see util.NewGuardedSession)
+func (r *Registry) OpenSession(ctx context.Context, name string, credentials provider.ContextCredentials, logger *zap.Logger) (provider.Session, error) {
+	r.mu.Lock()
+	shutdown := r.shutdown
+	r.mu.Unlock()
+	if shutdown {
+		return nil, ErrShutdown
+	}
+	p, err := r.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	session, err := p.OpenSession(ctx, credentials, logger)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	tracker := r.tracker
+	r.mu.Unlock()
+	session = util.NewGuardedSession(session, tracker)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		// shut down while opening
+		session.Close()
+		return nil, ErrShutdown
+	}
+	r.sessions = append(r.sessions, session)
+	return session, nil
+}
+
+// Shutdown runs the shutdown hooks phase by phase (drain, flush, persist), then closes the sessions opened
+// through the registry, most recent first. All hooks run even if some fail, their errors are returned in a *ShutdownError.
+// Hooks not started when ctx is done are skipped. Only the first call shuts down, later calls return ErrShutdown
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	if r.shutdown {
+		r.mu.Unlock()
+		return ErrShutdown
+	}
+	r.shutdown = true
+	sessions := r.sessions
+	r.sessions = nil
+	r.mu.Unlock()
+
+	var errs []error
+	for _, hook := range r.Hooks.ordered() {
+		if err := ctx.Err(); err != nil {
+			r.logger.Warn("Skipping shutdown hook", zap.String("hook", hook.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("shutdown hook %s skipped: %w", hook.name, err))
+			continue
+		}
+		if err := hook.hook(ctx); err != nil {
+			r.logger.Error("Shutdown hook failed", zap.String("hook", hook.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("shutdown hook %s: %w", hook.name, err))
+		}
+	}
+
+	for i := len(sessions) - 1; i >= 0; i-- {
+		sessions[i].Close()
+	}
+	r.logger.Info("Provider registry shut down", zap.Int("sessions", len(sessions)), zap.Int("errors", len(errs)))
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ShutdownError{Errors: errs}
+}
+
+// ShutdownError holds the errors of the shutdown hooks, errors.Is and errors.As match any of them
+type ShutdownError struct {
+	Errors []error
+}
+
+// Error returns the messages of the errors, one per line
+func (e *ShutdownError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Is reports whether any of the errors matches target
+func (e *ShutdownError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors matching target
+func (e *ShutdownError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry ...
+package registry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRegistry(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := New(logger)
+	vpc := &fakes.Provider{}
+	assert.Nil(t, registry.Register("VPC", vpc))
+	assert.NotNil(t, registry.Register("VPC", vpc))
+
+	p, err := registry.Get("VPC")
+	assert.Nil(t, err)
+	assert.Equal(t, vpc, p)
+	_, err = registry.Get("IKS")
+	assert.NotNil(t, err)
+
+	vpc.OpenSessionReturns(nil, errors.New("invalid credentials"))
+	_, err = registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+	assert.NotNil(t, err)
+}
+
+func TestRegistryOpenSessionFreeze(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := New(logger)
+	vpc := &fakes.Provider{}
+	_ = registry.Register("VPC", vpc)
+	inner := &fake.FakeSession{}
+	vpc.OpenSessionReturns(inner, nil)
+	session, err := registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+	assert.Nil(t, err)
+
+	util.SetReadOnly(true, "upgrade")
+	defer util.SetReadOnly(false, "")
+	err = session.DeleteVolume(&provider.Volume{VolumeID: "vol-1"})
+	assert.True(t, errors.Is(err, provider.ErrMaintenanceFreeze))
+	assert.Equal(t, 0, inner.DeleteVolumeCallCount())
+	_, err = session.GetVolume("vol-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inner.GetVolumeCallCount())
+}
+
+func TestRegistryShutdown(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := New(logger)
+	vpc := &fakes.Provider{}
+	_ = registry.Register("VPC", vpc)
+
+	order := []string{}
+	first, second := &fake.FakeSession{}, &fake.FakeSession{}
+	first.CloseStub = func() { order = append(order, "close first") }
+	second.CloseStub = func() { order = append(order, "close second") }
+	vpc.OpenSessionReturnsOnCall(0, first, nil)
+	vpc.OpenSessionReturnsOnCall(1, second, nil)
+	_, _ = registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+	_, _ = registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+
+	hook := func(name string, err error) ShutdownHook {
+		return func(ctx context.Context) error {
+			order = append(order, name)
+			return err
+		}
+	}
+	registry.Hooks.OnShutdown(ShutdownPersist, "state store", hook("persist", nil))
+	registry.Hooks.OnShutdown(ShutdownDrain, "work queue", hook("drain", nil))
+	registry.Hooks.OnShutdown(ShutdownFlush, "audit", hook("flush", errors.New("flush failed")))
+
+	err := registry.Shutdown(context.Background())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "flush failed")
+	var shutdownErr *ShutdownError
+	assert.True(t, errors.As(err, &shutdownErr))
+	assert.Len(t, shutdownErr.Errors, 1)
+	assert.Equal(t, []string{"drain", "flush", "persist", "close second", "close first"}, order)
+
+	assert.Equal(t, ErrShutdown, registry.Shutdown(context.Background()))
+	assert.Equal(t, ErrShutdown, registry.Register("IKS", vpc))
+	_, err = registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+	assert.Equal(t, ErrShutdown, err)
+}
+
+func TestRegistryActivityTracker(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := New(logger)
+	vpc := &fakes.Provider{}
+	_ = registry.Register("VPC", vpc)
+	vpc.OpenSessionReturns(&fake.FakeSession{}, nil)
+
+	assert.Nil(t, registry.UseActivityTracker(nil, "us-south"))
+	path := filepath.Join(t.TempDir(), "events.log")
+	at := &config.ActivityTrackerConfig{Enabled: true, Sink: config.ActivityTrackerSinkFile, Path: path}
+	assert.Nil(t, registry.UseActivityTracker(at, "us-south"))
+	assert.NotNil(t, registry.UseActivityTracker(at, "us-south"))
+
+	session, err := registry.OpenSession(context.Background(), "VPC", provider.ContextCredentials{}, logger)
+	assert.Nil(t, err)
+	_, err = session.CreateSnapshot("vol-1", provider.SnapshotParameters{})
+	assert.Nil(t, err)
+	assert.Nil(t, registry.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "is.snapshot.create"))
+}
+
+func TestRegistryShutdownContextDone(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	registry := New(logger)
+	ran := false
+	registry.Hooks.OnShutdown(ShutdownDrain, "work queue", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := registry.Shutdown(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, ran)
+}
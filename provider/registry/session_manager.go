@@ -0,0 +1,190 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry ...
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrSessionManagerClosed is returned when using a session manager which is closed
+	ErrSessionManagerClosed = errors.New("session manager is closed")
+
+	// ErrOperationsInFlight is returned when the operations of the previous session are still in flight when
+	// the context is done, the session is closed in the background once they complete
+	ErrOperationsInFlight = errors.New("operations still in flight on the previous session")
+)
+
+// DefaultRotationTimeout bounds the rotations started by RotateOn, including the drain of the previous session
+const DefaultRotationTimeout = 2 * time.Minute
+
+// CredentialProbe verifies a session opened with new credentials before it replaces the current session
+type CredentialProbe func(ctx context.Context, session provider.Session) error
+
+// ListVolumesProbe verifies the credentials with a call listing a single volume, it needs no resource
+func ListVolumesProbe(ctx context.Context, session provider.Session) error {
+	_, err := session.ListVolumes(1, "", nil)
+	return err
+}
+
+// managedSession is a session and its operations in flight
+type managedSession struct {
+	session  provider.Session
+	inFlight sync.WaitGroup
+}
+
+// SessionManager holds the session of a provider opened with the current credentials. Operations run with Do
+// on the current session, and RotateCredentials switches to new credentials without dropping the operations
+// in flight: they complete on the previous session, which is closed once they are done
+type SessionManager struct {
+	provider local.Provider
+	probe    CredentialProbe
+	logger   *zap.Logger
+
+	// rotation serializes the rotations
+	rotation sync.Mutex
+
+	mu      sync.RWMutex
+	current *managedSession
+}
+
+// NewSessionManager opens the session of the provider with the credentials. The probe verifies the new credentials
+// of the rotations, ListVolumesProbe if nil
+func NewSessionManager(ctx context.Context, p local.Provider, credentials provider.ContextCredentials, probe CredentialProbe, logger *zap.Logger) (*SessionManager, error) {
+	if probe == nil {
+		probe = ListVolumesProbe
+	}
+	session, err := p.OpenSession(ctx, credentials, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionManager{provider: p, probe: probe, logger: logger, current: &managedSession{session: session}}, nil
+}
+
+// acquire returns the current session with an operation in flight, released with inFlight.Done
+func (m *SessionManager) acquire() (*managedSession, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.current == nil {
+		return nil, ErrSessionManagerClosed
+	}
+	m.current.inFlight.Add(1)
+	return m.current, nil
+}
+
+// Do runs the operation on the current session, the session is not closed by a rotation before the operation returns
+func (m *SessionManager) Do(op func(session provider.Session) error) error {
+	current, err := m.acquire()
+	if err != nil {
+		return err
+	}
+	defer current.inFlight.Done()
+	return op(current.session)
+}
+
+// RotateCredentials opens a session with the new credentials and verifies it with the probe, the current session
+// is kept if either fails. Otherwise the new operations run on the new session, and the previous session is closed
+// once its operations in flight complete. RotateCredentials waits for them until ctx is done, the previous session
+// is then closed in the background when they complete and an error matching ErrOperationsInFlight is returned,
+// the new session is in use nevertheless
+func (m *SessionManager) RotateCredentials(ctx context.Context, credentials provider.ContextCredentials) error {
+	m.rotation.Lock()
+	defer m.rotation.Unlock()
+
+	session, err := m.provider.OpenSession(ctx, credentials, m.logger)
+	if err != nil {
+		m.logger.Error("Failed to open a session with the new credentials, keeping the current credentials", zap.Error(err))
+		return err
+	}
+	if err := m.probe(ctx, session); err != nil {
+		m.logger.Error("The new credentials failed verification, keeping the current credentials", zap.Error(err))
+		session.Close()
+		return err
+	}
+
+	m.mu.Lock()
+	previous := m.current
+	if previous == nil {
+		m.mu.Unlock()
+		session.Close()
+		return ErrSessionManagerClosed
+	}
+	m.current = &managedSession{session: session}
+	m.mu.Unlock()
+	m.logger.Info("Switched to the new credentials, draining the operations of the previous session")
+	return m.drain(ctx, previous)
+}
+
+// drain closes the session once its operations in flight complete, waiting for them until ctx is done.
+// ErrOperationsInFlight is returned if they did not complete
+func (m *SessionManager) drain(ctx context.Context, managed *managedSession) error {
+	drained := make(chan struct{})
+	go func() {
+		managed.inFlight.Wait()
+		managed.session.Close()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		m.logger.Warn("Operations still in flight on the previous session, it will be closed when they complete", zap.Error(ctx.Err()))
+		return fmt.Errorf("%w: %v", ErrOperationsInFlight, ctx.Err())
+	}
+}
+
+// RotateOn rotates the credentials when the credential provider rotates, with the credentials built from its new
+// API key, within DefaultRotationTimeout. Rotation failures are logged, the current credentials stay in use
+func (m *SessionManager) RotateOn(credentialProvider config.CredentialProvider, build func(apiKey string) provider.ContextCredentials) {
+	credentialProvider.OnRotate(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultRotationTimeout)
+		defer cancel()
+		apiKey, err := credentialProvider.GetAPIKey(ctx)
+		if err != nil {
+			m.logger.Error("Failed to get the rotated API key", zap.Error(err))
+			return
+		}
+		if err = m.RotateCredentials(ctx, build(apiKey)); err != nil && !errors.Is(err, ErrOperationsInFlight) {
+			m.logger.Error("Failed to rotate the credentials", zap.Error(err))
+		}
+	})
+}
+
+// Close closes the current session once its operations in flight complete, waiting for them until ctx is done.
+// Later calls to Do return ErrSessionManagerClosed
+func (m *SessionManager) Close(ctx context.Context) error {
+	m.rotation.Lock()
+	defer m.rotation.Unlock()
+	m.mu.Lock()
+	current := m.current
+	m.current = nil
+	m.mu.Unlock()
+	if current == nil {
+		return ErrSessionManagerClosed
+	}
+	return m.drain(ctx, current)
+}
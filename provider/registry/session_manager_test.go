@@ -0,0 +1,174 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry ...
+package registry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider/fake"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSessionManagerRotateCredentials(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	vpc := &fakes.Provider{}
+	oldSession, newSession := &fake.FakeSession{}, &fake.FakeSession{}
+	vpc.OpenSessionReturnsOnCall(0, oldSession, nil)
+	vpc.OpenSessionReturnsOnCall(1, newSession, nil)
+	manager, err := NewSessionManager(context.Background(), vpc, provider.ContextCredentials{Credential: "old"}, nil, logger)
+	require.Nil(t, err)
+
+	// an operation in flight on the previous session during the rotation
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- manager.Do(func(session provider.Session) error {
+			assert.Equal(t, oldSession, session)
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	rotated := make(chan error)
+	go func() {
+		rotated <- manager.RotateCredentials(context.Background(), provider.ContextCredentials{Credential: "new"})
+	}()
+	assert.Eventually(t, func() bool { return newSession.ListVolumesCallCount() == 1 }, time.Second, time.Millisecond)
+
+	// new operations run on the new session, the previous one is not closed while in use
+	assert.Eventually(t, func() bool {
+		var current provider.Session
+		_ = manager.Do(func(session provider.Session) error { current = session; return nil })
+		return current == newSession
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, oldSession.CloseCallCount())
+
+	close(release)
+	assert.Nil(t, <-done)
+	assert.Nil(t, <-rotated)
+	assert.Equal(t, 1, oldSession.CloseCallCount())
+	_, credentials, _ := vpc.OpenSessionArgsForCall(1)
+	assert.Equal(t, "new", credentials.Credential)
+}
+
+func TestSessionManagerProbeFailure(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	vpc := &fakes.Provider{}
+	current, rejected := &fake.FakeSession{}, &fake.FakeSession{}
+	vpc.OpenSessionReturnsOnCall(0, current, nil)
+	vpc.OpenSessionReturnsOnCall(1, rejected, nil)
+	probe := func(ctx context.Context, session provider.Session) error {
+		if session == rejected {
+			return errors.New("invalid API key")
+		}
+		return nil
+	}
+	manager, err := NewSessionManager(context.Background(), vpc, provider.ContextCredentials{}, probe, logger)
+	require.Nil(t, err)
+
+	assert.NotNil(t, manager.RotateCredentials(context.Background(), provider.ContextCredentials{Credential: "bad"}))
+	assert.Equal(t, 1, rejected.CloseCallCount())
+	assert.Equal(t, 0, current.CloseCallCount())
+	_ = manager.Do(func(session provider.Session) error {
+		assert.Equal(t, current, session)
+		return nil
+	})
+
+	vpc.OpenSessionReturnsOnCall(2, nil, errors.New("unreachable"))
+	assert.NotNil(t, manager.RotateCredentials(context.Background(), provider.ContextCredentials{Credential: "new"}))
+}
+
+func TestSessionManagerDrainTimeout(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	vpc := &fakes.Provider{}
+	oldSession := &fake.FakeSession{}
+	vpc.OpenSessionReturnsOnCall(0, oldSession, nil)
+	vpc.OpenSessionReturnsOnCall(1, &fake.FakeSession{}, nil)
+	manager, err := NewSessionManager(context.Background(), vpc, provider.ContextCredentials{}, nil, logger)
+	require.Nil(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = manager.Do(func(session provider.Session) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = manager.RotateCredentials(ctx, provider.ContextCredentials{})
+	assert.True(t, errors.Is(err, ErrOperationsInFlight))
+	assert.Equal(t, 0, oldSession.CloseCallCount())
+
+	close(release)
+	assert.Eventually(t, func() bool { return oldSession.CloseCallCount() == 1 }, time.Second, time.Millisecond)
+}
+
+func TestSessionManagerClose(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	vpc := &fakes.Provider{}
+	session := &fake.FakeSession{}
+	vpc.OpenSessionReturns(session, nil)
+	manager, err := NewSessionManager(context.Background(), vpc, provider.ContextCredentials{}, nil, logger)
+	require.Nil(t, err)
+
+	assert.Nil(t, manager.Close(context.Background()))
+	assert.Equal(t, 1, session.CloseCallCount())
+	assert.Equal(t, ErrSessionManagerClosed, manager.Do(func(provider.Session) error { return nil }))
+	assert.Equal(t, ErrSessionManagerClosed, manager.RotateCredentials(context.Background(), provider.ContextCredentials{}))
+	assert.Equal(t, ErrSessionManagerClosed, manager.Close(context.Background()))
+}
+
+func TestSessionManagerRotateOn(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	keyFile := filepath.Join(t.TempDir(), "apikey")
+	require.Nil(t, os.WriteFile(keyFile, []byte("key-1"), 0600))
+	credentialProvider, err := config.NewFileCredentialProvider(keyFile, "")
+	require.Nil(t, err)
+
+	vpc := &fakes.Provider{}
+	vpc.OpenSessionReturns(&fake.FakeSession{}, nil)
+	manager, err := NewSessionManager(context.Background(), vpc, provider.ContextCredentials{Credential: "key-1"}, nil, logger)
+	require.Nil(t, err)
+	manager.RotateOn(credentialProvider, func(apiKey string) provider.ContextCredentials {
+		return provider.ContextCredentials{AuthType: provider.IAMAPIKey, Credential: apiKey}
+	})
+
+	require.Nil(t, os.WriteFile(keyFile, []byte("key-2"), 0600))
+	rotated, err := credentialProvider.Refresh()
+	require.Nil(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, 2, vpc.OpenSessionCallCount())
+	_, credentials, _ := vpc.OpenSessionArgsForCall(1)
+	assert.Equal(t, "key-2", credentials.Credential)
+}
@@ -0,0 +1,60 @@
+//go:build !nosoftlayer
+// +build !nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer ...
+package softlayer
+
+import (
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/deprecation"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+// Compiled reports whether the classic support is compiled in
+const Compiled = true
+
+// Adapter provides the classic infrastructure credentials through the local.ContextCredentialsFactory interface
+type Adapter struct {
+	local.ContextCredentialsFactory
+	conf *config.SoftlayerConfig
+}
+
+var _ local.ContextCredentialsFactory = &Adapter{}
+
+// NewAdapter returns an Adapter delegating the credential exchanges to ccf.
+// ErrNotEnabled is returned if classic block and file are not enabled in the config
+func NewAdapter(conf *config.Config, ccf local.ContextCredentialsFactory) (*Adapter, error) {
+	if !Enabled(conf) {
+		return nil, ErrNotEnabled
+	}
+	deprecation.Use(deprecation.KindProvider, "softlayer")
+	return &Adapter{ContextCredentialsFactory: ccf, conf: conf.Softlayer}, nil
+}
+
+// Credentials returns the IaaS API key credentials of the configured classic user
+func (a *Adapter) Credentials(iamAccountID string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return a.ForIaaSAPIKey(iamAccountID, a.conf.SoftlayerUsername, a.conf.SoftlayerAPIKey, logger)
+}
+
+// DataCenter returns the configured classic datacenter
+func (a *Adapter) DataCenter() string {
+	return a.conf.SoftlayerDataCenter
+}
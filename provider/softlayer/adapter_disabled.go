@@ -0,0 +1,51 @@
+//go:build nosoftlayer
+// +build nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer ...
+package softlayer
+
+import (
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"go.uber.org/zap"
+)
+
+// Compiled reports whether the classic support is compiled in
+const Compiled = false
+
+// Adapter is not available with the nosoftlayer build tag
+type Adapter struct {
+	local.ContextCredentialsFactory
+}
+
+// NewAdapter always returns ErrNotCompiled with the nosoftlayer build tag
+func NewAdapter(conf *config.Config, ccf local.ContextCredentialsFactory) (*Adapter, error) {
+	return nil, ErrNotCompiled
+}
+
+// Credentials always returns ErrNotCompiled with the nosoftlayer build tag
+func (a *Adapter) Credentials(iamAccountID string, logger *zap.Logger) (provider.ContextCredentials, error) {
+	return provider.ContextCredentials{}, ErrNotCompiled
+}
+
+// DataCenter always returns an empty datacenter with the nosoftlayer build tag
+func (a *Adapter) DataCenter() string {
+	return ""
+}
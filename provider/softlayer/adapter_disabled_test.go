@@ -0,0 +1,43 @@
+//go:build nosoftlayer
+// +build nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer ...
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewAdapterNotCompiled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	assert.False(t, Compiled)
+
+	conf := &config.Config{Softlayer: &config.SoftlayerConfig{SoftlayerBlockEnabled: true}}
+	_, err := NewAdapter(conf, nil)
+	assert.Equal(t, ErrNotCompiled, err)
+
+	adapter := &Adapter{}
+	_, err = adapter.Credentials("account", logger)
+	assert.Equal(t, ErrNotCompiled, err)
+	assert.Empty(t, adapter.DataCenter())
+}
@@ -0,0 +1,62 @@
+//go:build !nosoftlayer
+// +build !nosoftlayer
+
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer ...
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/deprecation"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewAdapter(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	assert.True(t, Compiled)
+
+	ccf := &fakes.ContextCredentialsFactory{}
+	_, err := NewAdapter(&config.Config{}, ccf)
+	assert.Equal(t, ErrNotEnabled, err)
+
+	conf := &config.Config{Softlayer: &config.SoftlayerConfig{SoftlayerBlockEnabled: true, SoftlayerUsername: "user", SoftlayerAPIKey: "key", SoftlayerDataCenter: "dal10"}}
+	adapter, err := NewAdapter(conf, ccf)
+	assert.Nil(t, err)
+	assert.Equal(t, "dal10", adapter.DataCenter())
+
+	// The use of the classic provider is reported as deprecated
+	reported := false
+	for _, d := range deprecation.Default.Used() {
+		reported = reported || (d.Kind == deprecation.KindProvider && d.Name == "softlayer")
+	}
+	assert.True(t, reported)
+
+	ccf.ForIaaSAPIKeyReturns(provider.ContextCredentials{AuthType: provider.IaaSAPIKey, UserID: "user"}, nil)
+	credentials, err := adapter.Credentials("account", logger)
+	assert.Nil(t, err)
+	assert.Equal(t, "user", credentials.UserID)
+	accountID, user, apiKey, _ := ccf.ForIaaSAPIKeyArgsForCall(0)
+	assert.Equal(t, "account", accountID)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, "key", apiKey)
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer isolates the classic infrastructure (Softlayer) support behind an adapter.
+// The adapter is compiled in unless the "nosoftlayer" build tag is set, VPC only consumers can leave it out:
+//
+//	go build -tags nosoftlayer ./...
+package softlayer
+
+import (
+	"errors"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+)
+
+var (
+	// ErrNotCompiled is returned when the classic support is not compiled in
+	ErrNotCompiled = errors.New("classic infrastructure (Softlayer) support is not compiled in, build without -tags nosoftlayer")
+
+	// ErrNotEnabled is returned when neither classic block nor file is enabled in the config
+	ErrNotEnabled = errors.New("classic infrastructure (Softlayer) block and file are not enabled")
+)
+
+// Enabled reports whether classic block or file is enabled in the config
+func Enabled(conf *config.Config) bool {
+	return conf != nil && conf.Softlayer != nil && (conf.Softlayer.SoftlayerBlockEnabled || conf.Softlayer.SoftlayerFileEnabled)
+}
+
+// ProviderNames returns the names of the classic providers enabled in the config
+func ProviderNames(conf *config.Config) []string {
+	names := []string{}
+	if !Enabled(conf) {
+		return names
+	}
+	if conf.Softlayer.SoftlayerBlockEnabled {
+		names = append(names, conf.Softlayer.SoftlayerBlockProviderName)
+	}
+	if conf.Softlayer.SoftlayerFileEnabled {
+		names = append(names, conf.Softlayer.SoftlayerFileProviderName)
+	}
+	return names
+}
@@ -0,0 +1,36 @@
+/**
+ * Copyright 2022 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package softlayer ...
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnabled(t *testing.T) {
+	assert.False(t, Enabled(nil))
+	assert.False(t, Enabled(&config.Config{}))
+	assert.False(t, Enabled(&config.Config{Softlayer: &config.SoftlayerConfig{}}))
+
+	conf := &config.Config{Softlayer: &config.SoftlayerConfig{SoftlayerFileEnabled: true, SoftlayerFileProviderName: "SOFTLAYER-FILE"}}
+	assert.True(t, Enabled(conf))
+	assert.Equal(t, []string{"SOFTLAYER-FILE"}, ProviderNames(conf))
+	assert.Empty(t, ProviderNames(&config.Config{}))
+}
@@ -0,0 +1,47 @@
+#!/bin/bash
+# Fails if the median ns/op or allocs/op of a benchmark of the current run exceeds the median of the baseline
+# by more than the threshold percentage. The benchmarks missing from the baseline are reported, not checked.
+# Usage: benchcheck.sh <baseline> <current> <threshold>
+
+BASELINE=$1
+CURRENT=$2
+THRESHOLD=${3:-20}
+
+# medians prints the median ns/op and allocs/op of each benchmark of the file, by package
+medians() {
+	awk '
+		/^pkg:/ { pkg = $2 }
+		/^Benchmark/ {
+			name = pkg "." $1
+			for (i = 3; i < NF; i++) {
+				if ($(i+1) == "ns/op") { ns[name] = ns[name] " " $i }
+				if ($(i+1) == "allocs/op") { allocs[name] = allocs[name] " " $i }
+			}
+		}
+		function median(values,    n, sorted, i, j, t) {
+			n = split(values, sorted, " ")
+			for (i = 1; i <= n; i++)
+				for (j = i + 1; j <= n; j++)
+					if (sorted[j] + 0 < sorted[i] + 0) { t = sorted[i]; sorted[i] = sorted[j]; sorted[j] = t }
+			return n % 2 ? sorted[(n + 1) / 2] : (sorted[n / 2] + sorted[n / 2 + 1]) / 2
+		}
+		END {
+			for (name in ns) print name, "ns/op", median(ns[name])
+			for (name in allocs) print name, "allocs/op", median(allocs[name])
+		}
+	' "$1"
+}
+
+awk -v threshold="$THRESHOLD" '
+	NR == FNR { baseline[$1 " " $2] = $3; next }
+	{
+		key = $1 " " $2
+		if (!(key in baseline)) { print "no baseline for " key; next }
+		limit = baseline[key] * (1 + threshold / 100)
+		if ($3 > limit && $3 - baseline[key] >= 1) {
+			printf "%s regressed: %s, baseline %s (threshold %s%%)\n", key, $3, baseline[key], threshold
+			failed = 1
+		}
+	}
+	END { exit failed }
+' <(medians "$BASELINE") <(medians "$CURRENT")